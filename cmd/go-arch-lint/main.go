@@ -1,10 +1,15 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 
 	"github.com/kgatilin/go-arch-lint/pkg/linter"
 )
@@ -13,20 +18,43 @@ import (
 // Example: go build -ldflags "-X main.version=v1.0.0" ./cmd/go-arch-lint
 var version = "0.0.9"
 
+// assumeYes is set by --yes or --non-interactive, before the command or among its flags.
+// No prompt is shown then: confirmations are accepted and choices must come from flags.
+var assumeYes bool
+
 func printHelp() {
 	fmt.Println(`go-arch-lint - Go architecture linter that enforces strict dependency rules
 
 USAGE:
-    go-arch-lint [command] [flags] [path]
+    go-arch-lint [--yes] [command] [flags] [path]
 
 COMMANDS:
     (default)         Validate architecture and check for violations
     init              Initialize .goarchlint config with a preset
     refresh           Refresh error_prompt section from preset (keeps custom rules)
     docs              Generate comprehensive architecture documentation
+    graph             Export the dependency graph or validate a pre-built one
+    config            Show the merged configuration or migrate the old format
+    history           Show violations and metrics across past git revisions
+    hotspots          Rank packages by churn, coupling and violations
+    promotions        Suggest packages to move between internal/ and pkg/
+    move              Plan or perform moving a package, rewriting its imports
+    gen-port          Generate an interface for the methods callers use of a type
+    gen-adapter       Scaffold an adapter implementing a package's interfaces with another
+    matrix            Validate with several configs and compare the results side by side
+    suppressions      List active rule exemptions with locations, reasons and ages
+    query             Answer questions about the package dependency graph
+    doctor            Check the Go toolchain, staticcheck, config, cache and permissions
+    aggregate         Merge JSON reports of many repositories into one dashboard dataset
     version           Show version information
     help              Show this help message
 
+GLOBAL FLAGS:
+    -yes, -non-interactive
+        Never prompt, for scripts and CI. Confirmations (refresh) are answered
+        with yes and choices (the init preset menu) must be given as flags.
+        Accepted before the command or among its flags
+
 DEFAULT COMMAND FLAGS:
     -format string
         Output format (default: violations only)
@@ -35,9 +63,27 @@ DEFAULT COMMAND FLAGS:
           api       - Public API documentation
           index     - Lightweight architecture index (quick reference)
           full      - Complete documentation (structure + rules + deps + API)
+          inventory - External modules with the layers and packages importing
+                      them, and the versions required in go.mod
+          json      - Machine-readable report of violations (with owning team)
+                      and coverage on stdout, the input of 'aggregate'
+          package-json - Documentation of one package as JSON: exports, deps,
+                      dependents, coverage and violations in its files; takes
+                      the package path instead of the project path
+          symbols-index - Exported symbols as JSON Lines (package, signature,
+                      doc summary, file and line) for embedding pipelines
 
     -detailed
-        Show detailed method-level dependencies (use with -format=markdown)
+        Show detailed method-level dependencies (use with -format=markdown),
+        weighted by call sites, and the strongest cross-layer couplings
+
+    -focus string
+        Restrict the dependency graph (-format=markdown) to the packages
+        matching a glob, where ** spans directories (e.g. internal/app/**)
+
+    -depth int
+        Imports around the -focus packages to include, in either direction
+        (default: 1, 0 shows only the matching packages)
 
     -staticcheck
         Run staticcheck and include results in output
@@ -49,6 +95,76 @@ DEFAULT COMMAND FLAGS:
     -strict (default: true)
         Fail (exit code 1) on any violations
 
+    -min-score int
+        Fail (exit code 1) if the conformance score is below this value
+        (0-100). The score weighs violations by severity, test coverage and
+        coupling between packages; it is printed with the violations and
+        included in -format=json (default: 0, no minimum)
+
+    -ci
+        Run in CI mode: go.mod replaces with local directories or forks
+        (replace_directives) fail the build instead of being reported as
+        warnings (default: on when the CI environment variable is set)
+
+    -strict-parse
+        Abort with an error on the first Go file that cannot be parsed
+        (default: report it as a violation and continue with the remaining files)
+
+    -profile string
+        Apply a named profile from the 'profiles' section of .goarchlint
+        on top of the preset and overrides (e.g. a strict nightly profile)
+
+    -mode string
+        Run mode: fast (default) skips the expensive checks listed under
+        'modes: full' in .goarchlint (test_coverage, staticcheck, type_checks)
+        and require_race_clean, full runs every enabled check. Fast locally,
+        full in CI
+
+    -build-matrix
+        Validate each GOOS/GOARCH/tags combination of 'build_matrix' in .goarchlint
+        separately, honoring build constraints; violations that only appear under
+        some targets are marked with them
+
+    -fail-fast
+        Stop at the first error-severity violation: the remaining checks,
+        staticcheck and documentation output are skipped. Coverage tests only
+        run once every other rule passes. For pre-commit hooks where only
+        pass/fail matters
+
+    -lang string
+        Language of the violation report and -format=full documentation: en or de.
+        Defaults to the language of LC_ALL, LC_MESSAGES or LANG, falling back to
+        English. Messages of individual rules are English only
+
+    -ascii
+        Replace box-drawing characters, ✓/✗ glyphs and emoji with ASCII, for
+        consoles and log aggregators that mangle UTF-8. Enabled automatically
+        for the violation report (not for -format output) when the locale
+        (LC_ALL, LC_CTYPE or LANG) is not UTF-8 and on Windows consoles other
+        than Windows Terminal; -ascii=false turns it off
+
+    -quiet
+        Print one compact 'file:line rule message' line per violation to
+        stdout, without banners, coverage summaries, tips or guidance
+        sections. For grep/awk pipelines
+
+    -check-update
+        Query GitHub releases and warn when a newer go-arch-lint exists, listing
+        the rule changes from the release notes of every newer release. Opt-in;
+        'check_update: true' in .goarchlint enables it for every run. A failed
+        check only prints a warning. GO_ARCH_LINT_GITHUB_API_URL replaces the
+        GitHub API URL, e.g. for a proxy
+
+    -cache
+        Reuse the result of an identical run (same commit, effective config,
+        flags and go-arch-lint version) instead of linting again. Only used
+        while the project directory has no uncommitted or untracked changes.
+        For pre-push hooks and CI retries
+
+    -cache-dir string
+        Directory of the run cache, e.g. a directory CI keeps between jobs.
+        Implies -cache (default: go-arch-lint/runs in the user cache directory)
+
 INIT COMMAND:
     go-arch-lint init [flags] [path]
 
@@ -57,15 +173,23 @@ INIT COMMAND:
     Flags:
         -preset string
             Preset to use: ddd, simple, hexagonal, custom
-            If not specified, shows interactive menu
+            If not specified, shows an interactive menu; required with -yes
+            or when stdin is not a terminal (CI, scripts)
 
         -create-dirs (default: true)
             Automatically create required directories defined in preset
 
+        -with-examples
+            Write minimal example code into the required directories (a domain
+            entity, an application service, an infrastructure adapter and a cmd
+            main, with tests) so the project builds and lints right away.
+            Requires go.mod and a preset other than custom
+
     Examples:
         go-arch-lint init                      # Interactive preset selection
         go-arch-lint init --preset=ddd         # Use Domain-Driven Design preset
         go-arch-lint init --preset=hexagonal   # Use Hexagonal Architecture preset
+        go-arch-lint init --preset=ddd --with-examples  # Start from compiling example code
 
 REFRESH COMMAND:
     go-arch-lint refresh [flags] [path]
@@ -78,6 +202,13 @@ REFRESH COMMAND:
             Switch to a different preset (optional)
             If not specified, refreshes with the same preset
 
+        -yes, -non-interactive
+            Write the refreshed config without asking for confirmation.
+            Before writing, refresh validates the project with the current and
+            the refreshed config and prints the difference in violations per
+            top-level directory, then asks for confirmation. When stdin is not
+            a terminal, nothing is written without -yes
+
     Examples:
         go-arch-lint refresh                   # Refresh with current preset
         go-arch-lint refresh --preset=ddd      # Switch to different preset
@@ -89,16 +220,323 @@ DOCS COMMAND:
     Each package entry includes a command to get detailed info on-demand.
 
     Flags:
-        -output string (default: "docs/arch-index.md")
-            Output file path for index documentation
+        -output string (default: "docs/arch-index.md", with -split: "docs/arch")
+            Output file path for index documentation (output directory with -split)
+        -split
+            Write cross-linked pages instead of one file: index.md,
+            layers/<layer>.md and packages/<package path>.md
+        -publish string
+            Publish the index to "confluence" or "notion" instead of writing a file
+        -title string (default: "<module> Architecture")
+            Page title when publishing
+        -check
+            Check that the index at -output is up to date instead of writing it
+            (generation dates are ignored). If it is not, list the exported
+            signatures that changed, were added or were removed since the index
+            was last committed. Exits with 1 if the index is out of date.
+
+    Publishing reads credentials from environment variables:
+        confluence: CONFLUENCE_URL, CONFLUENCE_USER, CONFLUENCE_API_TOKEN,
+                    CONFLUENCE_SPACE, CONFLUENCE_PARENT_ID (optional)
+        notion:     NOTION_TOKEN, NOTION_PARENT_PAGE_ID, or NOTION_PAGE_ID
+                    to replace an existing page
 
     Examples:
         go-arch-lint docs                                  # Generate index
         go-arch-lint docs --output=ARCH_INDEX.md          # Custom location
+        go-arch-lint docs --split                         # Multi-page docs in docs/arch
+        go-arch-lint docs --publish=confluence            # Create or update a Confluence page
+        go-arch-lint docs --check                         # Fail in CI if the index is stale
 
     To get details about a specific package:
         go-arch-lint -format=package pkg/linter           # Package details
 
+GRAPH COMMAND:
+    go-arch-lint graph export [flags] [path]
+    go-arch-lint graph import <graph.json> [path]
+
+    export writes the dependency graph for analysis in external tools.
+    import validates a previously exported JSON graph against the rules
+    in [path]/.goarchlint (only rules based on the dependency graph).
+    The JSON schema is documented in docs/graph-format.md.
+
+    Export flags:
+        -format string (default: "json")
+            Output format: json, graphml (yEd, Gephi), gexf (Gephi)
+
+        -output string
+            Write to file instead of stdout
+
+    Examples:
+        go-arch-lint graph export > graph.json
+        go-arch-lint graph export --format=graphml --output=deps.graphml
+        go-arch-lint graph import graph.json
+
+CONFIG COMMAND:
+    go-arch-lint config show [flags] [path]
+    go-arch-lint config migrate [path]
+
+    Print [path]/.goarchlint as written. With --effective, print the fully
+    merged configuration (preset + overrides, or the flat format, plus
+    defaults) with a comment naming the source of each value.
+
+    Flags:
+        -effective
+            Print the merged configuration with value sources
+
+        -profile string
+            Merge the named profile into the effective configuration
+
+    migrate upgrades a .goarchlint in the old flat format (preset_used and
+    top-level rules) to the preset/overrides format in place. Values that
+    differ from the preset become overrides. The previous file is backed up
+    to .goarchlint.backup.
+
+    Examples:
+        go-arch-lint config show
+        go-arch-lint config show --effective
+        go-arch-lint config show --effective --profile strict
+        go-arch-lint config migrate
+
+HISTORY COMMAND:
+    go-arch-lint history [flags] [path]
+
+    Analyze past revisions against the current .goarchlint rules and print
+    a time series of violations and metrics (files, packages, dependencies).
+    Revisions are read from git; the working tree is not touched.
+
+    Flags:
+        -since string
+            First revision to analyze: tag, branch or commit (default: all history)
+
+        -interval string (default: "tag")
+            tag    - each tag reachable from HEAD, plus HEAD
+            commit - each first-parent commit
+
+        -format string (default: "markdown")
+            Output format: markdown, json, csv
+
+    Examples:
+        go-arch-lint history --since v1.0.0
+        go-arch-lint history --since main~20 --interval commit --format=csv
+
+HOTSPOTS COMMAND:
+    go-arch-lint hotspots [flags] [path]
+
+    Rank packages by risk, combining git churn with coupling and violations:
+    score = commits × (afferent + efferent + 1) × (violations + 1).
+    High-scoring packages change often, are entangled and break rules,
+    so refactoring them pays off most.
+
+    Flags:
+        -since string
+            Only count commits since this date, e.g. "6 months ago" (default: all history)
+
+        -top int (default: 10)
+            Number of packages to report (0 for all)
+
+        -format string (default: "markdown")
+            Output format: markdown, json
+
+    Examples:
+        go-arch-lint hotspots --since="6 months ago"
+        go-arch-lint hotspots --top=0 --format=json
+
+PROMOTIONS COMMAND:
+    go-arch-lint promotions [flags] [path]
+
+    Advise on the public/private split of packages, from who imports them.
+    Internal packages imported by many pkg/ packages and not annotated
+    "archlint:stability experimental" or "deprecated" are candidates for
+    promotion to pkg/. pkg/ packages only imported from one cmd/ binary are
+    candidates for demotion to internal/. The report never fails the build.
+
+    Flags:
+        -min-importers int (default: 3)
+            pkg/ packages that must import an internal package to suggest promoting it
+
+        -format string (default: "markdown")
+            Output format: markdown, json
+
+    Examples:
+        go-arch-lint promotions
+        go-arch-lint promotions --min-importers=5 --format=json
+
+MOVE COMMAND:
+    go-arch-lint move [flags] <from> <to> [path]
+
+    Move the package directory <from> to <to>, both relative to the project
+    root, with its subpackages. The plan lists the files moved, every import
+    to rewrite (test files included) and the violations the move introduces
+    or resolves, found by validating the project with the move simulated in
+    memory. Without -plan, the move is then performed: imports are
+    rewritten, formatted like gofmt, and the directory is renamed.
+
+    Flags:
+        -plan
+            Only print the plan, change nothing
+
+        -format string (default: "markdown")
+            Plan format: markdown, json
+
+        -yes, -non-interactive
+            Perform the move without asking for confirmation. When stdin is
+            not a terminal, nothing is moved without -yes
+
+    Examples:
+        go-arch-lint move internal/foo internal/domain/foo --plan
+        go-arch-lint move --yes internal/foo internal/domain/foo
+
+GEN-PORT COMMAND:
+    go-arch-lint gen-port [flags] <dir>.<Type> [path]
+
+    Generate an interface (a port) covering the methods of a concrete type
+    that other packages call, found by type-checking the scanned packages,
+    plus a compile-time assertion that the type implements it. Callers can
+    then depend on the interface, inverting a forbidden dependency. The
+    assertion goes next to the type, or into the port file when the port
+    package already depends on the type's package. Existing files are never
+    overwritten.
+
+    Flags:
+        -into string
+            Directory of the package to declare the interface in (required)
+
+        -name string
+            Interface name (default: the name of the type)
+
+        -dry-run
+            Print the generated files instead of writing them
+
+    Examples:
+        go-arch-lint gen-port internal/infra/postgres.UserRepo --into internal/domain
+        go-arch-lint gen-port --dry-run --name=Users internal/infra/postgres.UserRepo --into internal/app
+
+GEN-ADAPTER COMMAND:
+    go-arch-lint gen-adapter --from <dir> --to <dir> [flags] [path]
+
+    Scaffold an adapter package bridging two packages the way the presets'
+    refactoring guidance describes: the consumer (-from) defines interfaces
+    and the adapter implements them by delegating to the target (-to), so
+    neither imports the other. Each exported interface of the consumer gets
+    an adapter wrapping the target type serving most of its methods (or the
+    target's functions), with a constructor and a compile-time assertion.
+    Slices of assignable elements are converted; methods the target has
+    nothing for are left as TODOs. Interfaces the target already implements
+    are skipped. Existing files are never overwritten.
+
+    Flags:
+        -from string
+            Directory of the package declaring the interfaces (required)
+
+        -to string
+            Directory of the package to delegate to (required)
+
+        -out string
+            Directory of the adapter package
+            (default: "<parent of -from>/<base of -to>adapter")
+
+        -dry-run
+            Print the generated files instead of writing them
+
+    Examples:
+        go-arch-lint gen-adapter --from pkg/app --to internal/billing
+        go-arch-lint gen-adapter --from pkg/app --to internal/billing --out pkg/adapters/billing --dry-run
+
+MATRIX COMMAND:
+    go-arch-lint matrix --configs <file>,<file>... [flags] [path]
+
+    Validate the project once per config file and report the results side
+    by side: errors, warnings and whether the build fails for each config,
+    and which config reports each violation at which severity. Useful to
+    evaluate a planned tightening of the rules before adopting it. The
+    project is scanned once, with the scan settings of the first config.
+    Coverage, staticcheck and the checks reading git history are left out.
+    The report never fails the build.
+
+    Flags:
+        -configs string
+            Comma-separated config files, in column order (required)
+
+        -format string (default: "markdown")
+            Output format: markdown, json
+
+    Examples:
+        go-arch-lint matrix --configs .goarchlint,strict.yaml
+        go-arch-lint matrix --configs strict.yaml,migration.yaml --format=json
+
+SUPPRESSIONS COMMAND:
+    go-arch-lint suppressions [flags] [path]
+
+    List everything exempt from the rules, for audits: ignored paths, shared
+    external import exclusions, exempt test imports, whitebox test exemptions,
+    package naming exceptions, rules deferred with active_from and inline
+    "//archlint:ignore <rule> until=YYYY-MM-DD reason=..." comments. Each
+    entry shows its location, reason and age (from git blame).
+
+    Flags:
+        -format string (default: "markdown")
+            Output format: markdown, json
+
+        -profile string
+            Apply a named profile from the config's profiles section
+
+    Examples:
+        go-arch-lint suppressions
+        go-arch-lint suppressions --format=json --profile=ci
+
+QUERY COMMAND:
+    go-arch-lint query <expression> [path]
+
+    Print the packages matching an expression over the package dependency
+    graph (test files excluded), one per line.
+
+    Packages:
+        internal/app        A package
+        internal/...        A directory and everything below it
+        cmd/*               A glob (path.Match)
+
+    Functions:
+        deps(X), rdeps(X)         Packages X imports / that import X, directly
+        closure(X), rclosure(X)   The same, transitively
+        layer(name)               Packages governed by a directories_import key
+                                  (full key or last segment, e.g. infra)
+        all(), local(), stdlib(), external(), main()
+
+    Operators: & (intersection), | (union), - (difference, needs a space
+    before it); & binds tighter, parentheses group.
+
+    Examples:
+        go-arch-lint query 'deps(internal/app) & layer(infra)'
+        go-arch-lint query 'rdeps(github.com/gin-gonic/gin) - layer(adapters)'
+        go-arch-lint query 'closure(main()) & external()'
+
+DOCTOR COMMAND:
+    go-arch-lint doctor [path]
+
+    Check what go-arch-lint needs: the Go toolchain, staticcheck (required only
+    when .goarchlint enables it), go.mod, a valid .goarchlint with existing
+    scan paths, a writable Go build cache (coverage runs go test) and write
+    access to the project and docs/. Failed checks come with a remediation step.
+    Exits with 1 when a required check fails.
+
+AGGREGATE COMMAND:
+    go-arch-lint aggregate [flags] <report.json>...
+
+    Merge the reports written with -format=json in many repositories into one
+    dataset for a cross-repository dashboard: violations by owning team
+    (rules.ownership.teams) and type, preset adoption and coverage. Arguments
+    are files or glob patterns.
+
+    Flags:
+        -format string (default: "json")
+            Output format: json, markdown
+
+    Examples:
+        go-arch-lint -format=json -exit-zero . > reports/billing.json
+        go-arch-lint aggregate reports/*.json
+        go-arch-lint aggregate --format=markdown 'reports/*.json' > dashboard.md
+
 EXAMPLES:
     # Validate current directory
     go-arch-lint .
@@ -112,18 +550,45 @@ EXAMPLES:
     # Show detailed method-level dependencies
     go-arch-lint -detailed -format=markdown .
 
+    # Show the graph around a subtree, two imports deep
+    go-arch-lint -format=markdown -focus='internal/app/**' -depth=2 .
+
     # Show public API
     go-arch-lint -format=api .
 
-    # Get details for a specific package
+    # Get details and health (coverage, violations) for a specific package
     go-arch-lint -format=package pkg/linter
 
+    # Get package details as JSON for IDE plugins and portals
+    go-arch-lint -format=package-json pkg/linter
+
+    # Index the exported symbols as JSON Lines for retrieval pipelines
+    go-arch-lint -format=symbols-index . > symbols.jsonl
+
     # Generate architecture index
     go-arch-lint docs
 
     # Check violations but don't fail CI
     go-arch-lint -exit-zero .
 
+    # Stop at the first file with syntax errors
+    go-arch-lint -strict-parse .
+
+    # Run the stricter rules of the 'strict' profile (e.g. nightly)
+    go-arch-lint -profile strict .
+
+    # In CI: also run the checks listed under 'modes: full'
+    go-arch-lint -mode=full .
+
+    # Check platform-specific files of every configured build target
+    go-arch-lint -build-matrix .
+
+    # Pre-commit hook: stop at the first error
+    go-arch-lint -fail-fast .
+
+    # Count violations per rule
+    go-arch-lint -quiet . | awk '{print $2}' | sort | uniq -c
+
 EXIT CODES:
     0 - No violations found (or -exit-zero flag used)
     1 - Violations found
@@ -144,6 +609,12 @@ func main() {
 }
 
 func run() int {
+	// Global flags come before the command
+	for len(os.Args) > 1 && isYesFlag(os.Args[1]) {
+		assumeYes = true
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+
 	// Check for help flags or subcommands before parsing flags
 	if len(os.Args) > 1 {
 		switch os.Args[1] {
@@ -159,28 +630,69 @@ func run() int {
 			return runRefresh()
 		case "docs":
 			return runDocs()
+		case "graph":
+			return runGraph()
+		case "config":
+			return runConfig()
+		case "history":
+			return runHistory()
+		case "hotspots":
+			return runHotspots()
+		case "promotions":
+			return runPromotions()
+		case "move":
+			return runMove()
+		case "gen-port":
+			return runGenPort()
+		case "gen-adapter":
+			return runGenAdapter()
+		case "matrix":
+			return runMatrix()
+		case "suppressions":
+			return runSuppressions()
+		case "query":
+			return runQuery()
+		case "doctor":
+			return runDoctor()
+		case "aggregate":
+			return runAggregate()
 		}
 	}
 
 	// Parse flags
 	flag.Usage = printUsage
-	formatFlag := flag.String("format", "", "Output format: markdown (deps), api (public API), package (single package details)")
+	formatFlag := flag.String("format", "", "Output format: markdown (deps), api (public API), package (single package details), package-json (single package as JSON), symbols-index (exported symbols as JSON Lines), json (report)")
 	detailedFlag := flag.Bool("detailed", false, "Show detailed method-level dependencies (with -format=markdown)")
 	staticcheckFlag := flag.Bool("staticcheck", false, "Run staticcheck and include results")
 	strictFlag := flag.Bool("strict", true, "Fail on any violations (default: true)")
+	minScoreFlag := flag.Int("min-score", 0, "Fail if the conformance score (0-100) is below this value")
 	exitZeroFlag := flag.Bool("exit-zero", false, "Always exit with code 0, even on violations")
+	ciFlag := flag.Bool("ci", false, "Fail on replace directive violations (default: on when CI is set)")
+	strictParseFlag := flag.Bool("strict-parse", false, "Abort on the first file with syntax errors instead of reporting it")
+	profileFlag := flag.String("profile", "", "Apply a named profile from the config's profiles section")
+	modeFlag := flag.String("mode", "", "Run mode: fast (default) skips the checks listed under modes.full, full runs them")
+	buildMatrixFlag := flag.Bool("build-matrix", false, "Validate each build_matrix target of the config separately")
+	checkUpdateFlag := flag.Bool("check-update", false, "Warn when a newer release exists and list its rule changes")
+	failFastFlag := flag.Bool("fail-fast", false, "Stop at the first violation that fails the build")
+	quietFlag := flag.Bool("quiet", false, "Print one compact line per violation to stdout")
+	asciiFlag := flag.Bool("ascii", false, "Render box-drawing characters and glyphs as ASCII (default: auto-detected)")
+	langFlag := flag.String("lang", "", "Language of the violation report and full docs (default: from LC_ALL, LC_MESSAGES or LANG)")
+	focusFlag := flag.String("focus", "", "Restrict the -format=markdown graph to packages matching a glob (e.g. internal/app/**)")
+	depthFlag := flag.Int("depth", 1, "Imports around the -focus packages to include, in either direction")
+	cacheFlag := flag.Bool("cache", false, "Reuse the result of an identical run on the same commit while the working tree is clean")
+	cacheDirFlag := flag.String("cache-dir", "", "Directory of the run cache (default: go-arch-lint/runs in the user cache directory)")
 	flag.Parse()
 
 	// Handle format=package specially
 	projectPath := "."
 	packagePath := ""
 
-	if *formatFlag == "package" {
-		// For package format, first argument is the package path
+	if *formatFlag == "package" || *formatFlag == "package-json" {
+		// For package formats, first argument is the package path
 		if flag.NArg() < 1 {
-			fmt.Fprintf(os.Stderr, "Error: package path required for -format=package\n")
-			fmt.Fprintf(os.Stderr, "Usage: go-arch-lint -format=package <package-path>\n")
-			fmt.Fprintf(os.Stderr, "Example: go-arch-lint -format=package pkg/linter\n")
+			fmt.Fprintf(os.Stderr, "Error: package path required for -format=%s\n", *formatFlag)
+			fmt.Fprintf(os.Stderr, "Usage: go-arch-lint -format=%s <package-path>\n", *formatFlag)
+			fmt.Fprintf(os.Stderr, "Example: go-arch-lint -format=%s pkg/linter\n", *formatFlag)
 			return 2
 		}
 		packagePath = flag.Arg(0)
@@ -201,7 +713,29 @@ func run() int {
 	}
 
 	// Run linter
-	graphOutput, violationsOutput, shouldFail, err := linter.Run(absPath, *formatFlag, *detailedFlag, *staticcheckFlag, packagePath)
+	graphOutput, violationsOutput, shouldFail, err := linter.RunWithOptions(absPath, linter.RunOptions{
+		Format:         *formatFlag,
+		Detailed:       *detailedFlag,
+		RunStaticcheck: *staticcheckFlag,
+		PackagePath:    packagePath,
+		StrictParse:    *strictParseFlag,
+		Profile:        *profileFlag,
+		Mode:           *modeFlag,
+		BuildMatrix:    *buildMatrixFlag,
+		CheckUpdate:    *checkUpdateFlag,
+		Version:        version,
+		UpdateAPIURL:   os.Getenv("GO_ARCH_LINT_GITHUB_API_URL"),
+		FailFast:       *failFastFlag,
+		Quiet:          *quietFlag,
+		Lang:           reportLanguage(*langFlag),
+		ASCII:          asciiOutput(*asciiFlag, *formatFlag),
+		Focus:          *focusFlag,
+		Depth:          *depthFlag,
+		MinScore:       *minScoreFlag,
+		CI:             ciMode(*ciFlag),
+		Cache:          *cacheFlag || *cacheDirFlag != "",
+		CacheDir:       *cacheDirFlag,
+	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 2
@@ -212,17 +746,19 @@ func run() int {
 		fmt.Println(graphOutput)
 	}
 
-	// Report violations
-	if violationsOutput != "" {
+	// Report violations; compact lines go to stdout for pipelines
+	if violationsOutput != "" && *quietFlag {
+		fmt.Print(violationsOutput)
+	} else if violationsOutput != "" {
 		fmt.Fprintln(os.Stderr, violationsOutput)
+	}
 
-		// Determine exit code
-		if *exitZeroFlag {
-			return 0
-		}
-		if shouldFail && *strictFlag {
-			return 1
-		}
+	// Determine exit code; with -format=json the violations are part of the report
+	if *exitZeroFlag {
+		return 0
+	}
+	if shouldFail && *strictFlag {
+		return 1
 	}
 
 	return 0
@@ -233,6 +769,8 @@ func runInit() int {
 	initFlags := flag.NewFlagSet("init", flag.ExitOnError)
 	presetFlag := initFlags.String("preset", "", "Preset to use (ddd, simple, hexagonal)")
 	createDirsFlag := initFlags.Bool("create-dirs", true, "Create required directories")
+	withExamplesFlag := initFlags.Bool("with-examples", false, "Write example code into the required directories")
+	registerYesFlags(initFlags)
 
 	// Parse flags starting from os.Args[2] (after "init")
 	if err := initFlags.Parse(os.Args[2:]); err != nil {
@@ -253,10 +791,18 @@ func runInit() int {
 		return 2
 	}
 
-	// If no preset specified, show interactive menu
+	// If no preset specified, show interactive menu; without a terminal nobody could answer it
 	preset := *presetFlag
 	if preset == "" {
-		selectedPreset, err := showPresetMenu()
+		if assumeYes {
+			fmt.Fprintf(os.Stderr, "Error: --preset is required with --yes (one of: %s)\n", strings.Join(presetChoices(), ", "))
+			return 2
+		}
+		if !isTerminal(os.Stdin) {
+			fmt.Fprintf(os.Stderr, "Error: --preset is required when stdin is not a terminal (one of: %s)\n", strings.Join(presetChoices(), ", "))
+			return 2
+		}
+		selectedPreset, err := showPresetMenu(os.Stdin, os.Stdout)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			return 2
@@ -265,7 +811,7 @@ func runInit() int {
 	}
 
 	// Run init
-	if err := linter.Init(absPath, preset, *createDirsFlag); err != nil {
+	if err := linter.InitWithOptions(absPath, linter.InitOptions{Preset: preset, CreateDirs: *createDirsFlag, WithExamples: *withExamplesFlag}); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 2
 	}
@@ -277,6 +823,7 @@ func runRefresh() int {
 	// Create a new flag set for refresh subcommand
 	refreshFlags := flag.NewFlagSet("refresh", flag.ExitOnError)
 	presetFlag := refreshFlags.String("preset", "", "Preset to switch to (ddd, simple, hexagonal). If not specified, refreshes with the same preset.")
+	registerYesFlags(refreshFlags)
 
 	// Parse flags starting from os.Args[2] (after "refresh")
 	if err := refreshFlags.Parse(os.Args[2:]); err != nil {
@@ -297,6 +844,20 @@ func runRefresh() int {
 		return 2
 	}
 
+	// Show how the refreshed config changes the violations before writing it
+	preview, err := linter.PreviewRefresh(absPath, *presetFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	fmt.Println(preview)
+
+	if !assumeYes {
+		if proceed, exitCode := confirmAction("Write the refreshed .goarchlint?", "Refresh cancelled, .goarchlint was not changed", "write it"); !proceed {
+			return exitCode
+		}
+	}
+
 	// Run refresh
 	if err := linter.Refresh(absPath, *presetFlag); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -306,39 +867,118 @@ func runRefresh() int {
 	return 0
 }
 
-func showPresetMenu() (string, error) {
-	presets := linter.AvailablePresets()
+// isYesFlag reports whether arg is the global --yes flag or its --non-interactive alias
+func isYesFlag(arg string) bool {
+	switch arg {
+	case "-yes", "--yes", "-non-interactive", "--non-interactive":
+		return true
+	}
+	return false
+}
 
-	fmt.Println("Select a project structure preset:")
-	fmt.Println()
+// registerYesFlags adds -yes and -non-interactive to a command that may prompt, keeping
+// the value already set before the command
+func registerYesFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&assumeYes, "yes", assumeYes, "Never prompt: accept confirmations, require choices as flags")
+	fs.BoolVar(&assumeYes, "non-interactive", assumeYes, "Same as -yes")
+}
 
-	for i, p := range presets {
-		fmt.Printf("  %d. %s - %s\n", i+1, p.Name, p.Description)
+// confirm asks a yes/no question and reports whether it was answered with yes.
+// Anything else, including the end of the input, counts as no.
+func confirm(in io.Reader, out io.Writer, question string) (yes, answered bool) {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	lines := bufio.NewScanner(in)
+	if !lines.Scan() {
+		fmt.Fprintln(out)
+		return false, false
 	}
-	fmt.Printf("  %d. custom - Empty template (fill your own)\n", len(presets)+1)
-	fmt.Println()
+	answer := strings.ToLower(strings.TrimSpace(lines.Text()))
+	return answer == "y" || answer == "yes", true
+}
 
-	fmt.Print("Enter your choice [1-", len(presets)+1, "]: ")
-	var choice int
-	if _, err := fmt.Scanln(&choice); err != nil {
-		return "", fmt.Errorf("invalid input: %w", err)
+// confirmAction asks the question on stdin and reports whether to proceed, with the exit
+// code to return otherwise. Declining is not an error, but without a terminal or an
+// answer (stdin is a pipe, a file or /dev/null) the command fails with exit code 2, so
+// scripts that expect the action notice it did not happen; they pass --yes instead.
+func confirmAction(question, cancelled, action string) (bool, int) {
+	if !isTerminal(os.Stdin) {
+		fmt.Fprintf(os.Stderr, "Error: %s (stdin is not a terminal; pass --yes to %s)\n", cancelled, action)
+		return false, 2
 	}
+	yes, answered := confirm(os.Stdin, os.Stdout, question)
+	if !answered {
+		fmt.Fprintf(os.Stderr, "Error: %s (no answer on stdin; pass --yes to %s)\n", cancelled, action)
+		return false, 2
+	}
+	if !yes {
+		fmt.Println(cancelled)
+		return false, 0
+	}
+	return true, 0
+}
+
+// isTerminal reports whether f is a character device such as a terminal, as opposed to a
+// pipe or a file that scripts and CI connect to stdin
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
 
-	if choice < 1 || choice > len(presets)+1 {
-		return "", fmt.Errorf("invalid choice: must be between 1 and %d", len(presets)+1)
+// presetChoices lists the names accepted by --preset, ending with custom
+func presetChoices() []string {
+	var names []string
+	for _, p := range linter.AvailablePresets() {
+		names = append(names, p.Name)
 	}
+	return append(names, "custom")
+}
+
+// showPresetMenu lets the user pick a preset by number or name, asking again on invalid
+// input. Enter picks the first preset. It fails when the input ends without a choice.
+func showPresetMenu(in io.Reader, out io.Writer) (string, error) {
+	presets := linter.AvailablePresets()
+	choices := presetChoices()
 
-	if choice == len(presets)+1 {
-		return "custom", nil
+	fmt.Fprintln(out, "Select a project structure preset:")
+	fmt.Fprintln(out)
+	for i, p := range presets {
+		fmt.Fprintf(out, "  %d. %s - %s\n", i+1, p.Name, p.Description)
 	}
+	fmt.Fprintf(out, "  %d. custom - Empty template (fill your own)\n", len(choices))
+	fmt.Fprintln(out)
+
+	lines := bufio.NewScanner(in)
+	for {
+		fmt.Fprintf(out, "Enter a number or name [1-%d, default %s]: ", len(choices), choices[0])
+		if !lines.Scan() {
+			fmt.Fprintln(out)
+			return "", fmt.Errorf("no preset selected, use --preset to choose one (one of: %s)", strings.Join(choices, ", "))
+		}
 
-	return presets[choice-1].Name, nil
+		answer := strings.TrimSpace(lines.Text())
+		if answer == "" {
+			return choices[0], nil
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(choices) {
+			return choices[n-1], nil
+		}
+		for _, name := range choices {
+			if strings.EqualFold(answer, name) {
+				return name, nil
+			}
+		}
+		fmt.Fprintf(out, "Invalid choice %q: enter a number between 1 and %d or one of: %s\n", answer, len(choices), strings.Join(choices, ", "))
+	}
 }
 
 func runDocs() int {
 	// Create a new flag set for docs subcommand
 	docsFlags := flag.NewFlagSet("docs", flag.ExitOnError)
-	outputFlag := docsFlags.String("output", "docs/arch-index.md", "Output file path for index documentation")
+	outputFlag := docsFlags.String("output", "docs/arch-index.md", "Output file path for index documentation (output directory with -split)")
+	splitFlag := docsFlags.Bool("split", false, "Write an index plus per-layer and per-package pages")
+	publishFlag := docsFlags.String("publish", "", "Publish the index to confluence or notion")
+	titleFlag := docsFlags.String("title", "", "Page title when publishing")
+	checkFlag := docsFlags.Bool("check", false, "Check that the index is up to date instead of writing it")
 
 	// Parse flags starting from os.Args[2] (after "docs")
 	if err := docsFlags.Parse(os.Args[2:]); err != nil {
@@ -359,6 +999,28 @@ func runDocs() int {
 		return 2
 	}
 
+	if *checkFlag {
+		if *splitFlag || *publishFlag != "" {
+			fmt.Fprintln(os.Stderr, "Error: -check cannot be combined with -split or -publish")
+			return 2
+		}
+		return checkDocs(absPath, *outputFlag)
+	}
+
+	if *splitFlag {
+		outputDir := "docs/arch"
+		docsFlags.Visit(func(f *flag.Flag) {
+			if f.Name == "output" {
+				outputDir = *outputFlag
+			}
+		})
+		return writeSplitDocs(absPath, outputDir)
+	}
+
+	if *publishFlag != "" {
+		return publishDocs(absPath, *publishFlag, *titleFlag)
+	}
+
 	// Generate index documentation
 	fmt.Println("Generating architecture index...")
 	indexOutput, violationsOutput, shouldFail, err := linter.Run(absPath, "index", false, false, "")
@@ -399,3 +1061,753 @@ func runDocs() int {
 
 	return 0
 }
+
+// checkDocs reports whether the index at docsPath is up to date and which exported
+// signatures changed since it was last committed
+func checkDocs(absPath, docsPath string) int {
+	report, stale, err := linter.CheckDocs(absPath, docsPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(report)
+	if stale {
+		return 1
+	}
+	return 0
+}
+
+// writeSplitDocs generates the multi-page documentation into outputDir
+func writeSplitDocs(absPath, outputDir string) int {
+	fmt.Println("Generating architecture documentation pages...")
+	pages, err := linter.SplitDocumentation(absPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if !filepath.IsAbs(outputDir) {
+		outputDir = filepath.Join(absPath, outputDir)
+	}
+
+	for relPath, content := range pages {
+		pagePath := filepath.Join(outputDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(pagePath), 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating output directory: %v\n", err)
+			return 2
+		}
+		if err := os.WriteFile(pagePath, []byte(content), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing documentation page: %v\n", err)
+			return 2
+		}
+	}
+
+	fmt.Printf("✓ Generated %d documentation pages: %s\n", len(pages), filepath.Join(outputDir, "index.md"))
+	return 0
+}
+
+// publishDocs publishes the architecture index with credentials from the environment
+func publishDocs(absPath, target, title string) int {
+	opts := linter.PublishOptions{Target: target, Title: title}
+	switch target {
+	case "confluence":
+		opts.URL = os.Getenv("CONFLUENCE_URL")
+		opts.User = os.Getenv("CONFLUENCE_USER")
+		opts.Token = os.Getenv("CONFLUENCE_API_TOKEN")
+		opts.Space = os.Getenv("CONFLUENCE_SPACE")
+		opts.ParentID = os.Getenv("CONFLUENCE_PARENT_ID")
+	case "notion":
+		opts.Token = os.Getenv("NOTION_TOKEN")
+		opts.ParentID = os.Getenv("NOTION_PARENT_PAGE_ID")
+		opts.PageID = os.Getenv("NOTION_PAGE_ID")
+	}
+
+	fmt.Printf("Publishing architecture index to %s...\n", target)
+	pageURL, err := linter.Publish(absPath, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Printf("✓ Published architecture index: %s\n", pageURL)
+	return 0
+}
+
+func runGraph() int {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "Usage: go-arch-lint graph export|import [flags] [args]")
+		return 2
+	}
+
+	switch os.Args[2] {
+	case "export":
+		return runGraphExport()
+	case "import":
+		return runGraphImport()
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown graph command %q (expected export or import)\n", os.Args[2])
+		return 2
+	}
+}
+
+func runDoctor() int {
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if len(os.Args) > 2 {
+		projectPath = os.Args[2]
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	report, failed := linter.Doctor(absPath)
+	fmt.Print(report)
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+func runConfig() int {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "Usage: go-arch-lint config show|migrate [flags] [path]")
+		return 2
+	}
+
+	switch os.Args[2] {
+	case "show":
+		return runConfigShow()
+	case "migrate":
+		return runConfigMigrate()
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown config command %q (expected show or migrate)\n", os.Args[2])
+		return 2
+	}
+}
+
+func runConfigShow() int {
+	// Create a new flag set for config show subcommand
+	showFlags := flag.NewFlagSet("config show", flag.ExitOnError)
+	effectiveFlag := showFlags.Bool("effective", false, "Print the merged configuration with value sources")
+	profileFlag := showFlags.String("profile", "", "Merge the named profile into the effective configuration")
+
+	// Parse flags starting from os.Args[3] (after "config show")
+	if err := showFlags.Parse(os.Args[3:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if showFlags.NArg() > 0 {
+		projectPath = showFlags.Arg(0)
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	configOutput, err := linter.ShowConfig(absPath, *effectiveFlag, *profileFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(configOutput)
+	return 0
+}
+
+func runConfigMigrate() int {
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if len(os.Args) > 3 {
+		projectPath = os.Args[3]
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	warnings, err := linter.MigrateConfig(absPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "⚠ %s\n", warning)
+	}
+	fmt.Println("✓ Migrated .goarchlint to the preset/overrides format")
+	fmt.Println("  Previous config backed up to .goarchlint.backup")
+	return 0
+}
+
+func runGraphExport() int {
+	// Create a new flag set for graph export subcommand
+	exportFlags := flag.NewFlagSet("graph export", flag.ExitOnError)
+	formatFlag := exportFlags.String("format", "json", "Output format: json, graphml, gexf")
+	outputFlag := exportFlags.String("output", "", "Write to file instead of stdout")
+
+	// Parse flags starting from os.Args[3] (after "graph export")
+	if err := exportFlags.Parse(os.Args[3:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if exportFlags.NArg() > 0 {
+		projectPath = exportFlags.Arg(0)
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	graphOutput, err := linter.ExportGraph(absPath, *formatFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if *outputFlag == "" {
+		fmt.Print(graphOutput)
+		return 0
+	}
+
+	if err := os.WriteFile(*outputFlag, []byte(graphOutput), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing graph: %v\n", err)
+		return 2
+	}
+	fmt.Fprintf(os.Stderr, "✓ Exported dependency graph: %s\n", *outputFlag)
+
+	return 0
+}
+
+func runGraphImport() int {
+	if len(os.Args) < 4 {
+		fmt.Fprintln(os.Stderr, "Usage: go-arch-lint graph import <graph.json> [path]")
+		return 2
+	}
+
+	graphData, err := os.ReadFile(os.Args[3])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading graph: %v\n", err)
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if len(os.Args) > 4 {
+		projectPath = os.Args[4]
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	violationsOutput, shouldFail, err := linter.ValidateGraph(absPath, graphData)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if violationsOutput != "" {
+		fmt.Fprintln(os.Stderr, violationsOutput)
+		if shouldFail {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func runHistory() int {
+	// Create a new flag set for history subcommand
+	historyFlags := flag.NewFlagSet("history", flag.ExitOnError)
+	sinceFlag := historyFlags.String("since", "", "First revision to analyze (tag, branch or commit)")
+	intervalFlag := historyFlags.String("interval", "tag", "Sampling interval: tag, commit")
+	formatFlag := historyFlags.String("format", "markdown", "Output format: markdown, json, csv")
+
+	// Parse flags starting from os.Args[2] (after "history")
+	if err := historyFlags.Parse(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if historyFlags.NArg() > 0 {
+		projectPath = historyFlags.Arg(0)
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	historyOutput, err := linter.History(absPath, linter.HistoryOptions{
+		Since:    *sinceFlag,
+		Interval: *intervalFlag,
+		Format:   *formatFlag,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(historyOutput)
+	return 0
+}
+
+func runHotspots() int {
+	// Create a new flag set for hotspots subcommand
+	hotspotsFlags := flag.NewFlagSet("hotspots", flag.ExitOnError)
+	sinceFlag := hotspotsFlags.String("since", "", "Only count commits since this date (e.g. \"6 months ago\")")
+	topFlag := hotspotsFlags.Int("top", 10, "Number of packages to report (0 for all)")
+	formatFlag := hotspotsFlags.String("format", "markdown", "Output format: markdown, json")
+
+	// Parse flags starting from os.Args[2] (after "hotspots")
+	if err := hotspotsFlags.Parse(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if hotspotsFlags.NArg() > 0 {
+		projectPath = hotspotsFlags.Arg(0)
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	hotspotsOutput, err := linter.Hotspots(absPath, linter.HotspotOptions{
+		Since:  *sinceFlag,
+		Top:    *topFlag,
+		Format: *formatFlag,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(hotspotsOutput)
+	return 0
+}
+
+func runPromotions() int {
+	// Create a new flag set for promotions subcommand
+	promotionsFlags := flag.NewFlagSet("promotions", flag.ExitOnError)
+	minImportersFlag := promotionsFlags.Int("min-importers", 3, "pkg/ packages that must import an internal package to suggest promoting it")
+	formatFlag := promotionsFlags.String("format", "markdown", "Output format: markdown, json")
+
+	// Parse flags starting from os.Args[2] (after "promotions")
+	if err := promotionsFlags.Parse(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if promotionsFlags.NArg() > 0 {
+		projectPath = promotionsFlags.Arg(0)
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	promotionsOutput, err := linter.Promotions(absPath, linter.PromotionOptions{
+		MinImporters: *minImportersFlag,
+		Format:       *formatFlag,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(promotionsOutput)
+	return 0
+}
+
+func runMove() int {
+	// Create a new flag set for move subcommand
+	moveFlags := flag.NewFlagSet("move", flag.ExitOnError)
+	planFlag := moveFlags.Bool("plan", false, "Only print the plan, change nothing")
+	formatFlag := moveFlags.String("format", "markdown", "Plan format: markdown, json")
+	registerYesFlags(moveFlags)
+
+	// Flags may follow the directories, as in "move internal/foo internal/domain/foo --plan"
+	args, err := parseInterspersed(moveFlags, os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if len(args) < 2 || len(args) > 3 {
+		fmt.Fprintln(os.Stderr, "Usage: go-arch-lint move [flags] <from> <to> [path]")
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if len(args) > 2 {
+		projectPath = args[2]
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	plan, err := linter.PlanMove(absPath, args[0], args[1], *formatFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	fmt.Print(plan)
+	if *planFlag {
+		return 0
+	}
+
+	if !assumeYes {
+		if proceed, exitCode := confirmAction(fmt.Sprintf("Move %s to %s?", args[0], args[1]), "Move cancelled, nothing was changed", "move"); !proceed {
+			return exitCode
+		}
+	}
+
+	if err := linter.Move(absPath, args[0], args[1]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	fmt.Printf("Moved %s to %s\n", args[0], args[1])
+	return 0
+}
+
+func runGenPort() int {
+	// Create a new flag set for gen-port subcommand
+	genPortFlags := flag.NewFlagSet("gen-port", flag.ExitOnError)
+	intoFlag := genPortFlags.String("into", "", "Directory of the package to declare the interface in")
+	nameFlag := genPortFlags.String("name", "", "Interface name (default: the name of the type)")
+	dryRunFlag := genPortFlags.Bool("dry-run", false, "Print the generated files instead of writing them")
+
+	// Flags may follow the type, as in "gen-port internal/infra/postgres.UserRepo --into internal/domain"
+	args, err := parseInterspersed(genPortFlags, os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if len(args) < 1 || len(args) > 2 {
+		fmt.Fprintln(os.Stderr, "Usage: go-arch-lint gen-port --into <dir> [flags] <dir>.<Type> [path]")
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if len(args) > 1 {
+		projectPath = args[1]
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	genPortOutput, err := linter.GenPort(absPath, args[0], linter.GenPortOptions{
+		Into:   *intoFlag,
+		Name:   *nameFlag,
+		DryRun: *dryRunFlag,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(genPortOutput)
+	return 0
+}
+
+func runMatrix() int {
+	// Create a new flag set for matrix subcommand
+	matrixFlags := flag.NewFlagSet("matrix", flag.ExitOnError)
+	configsFlag := matrixFlags.String("configs", "", "Comma-separated config files, in column order")
+	formatFlag := matrixFlags.String("format", "markdown", "Output format: markdown, json")
+
+	// Parse flags starting from os.Args[2] (after "matrix")
+	if err := matrixFlags.Parse(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if matrixFlags.NArg() > 0 {
+		projectPath = matrixFlags.Arg(0)
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	var configs []string
+	for _, configPath := range strings.Split(*configsFlag, ",") {
+		if configPath = strings.TrimSpace(configPath); configPath != "" {
+			configs = append(configs, configPath)
+		}
+	}
+
+	matrixOutput, err := linter.ConfigMatrix(absPath, linter.ConfigMatrixOptions{
+		Configs: configs,
+		Format:  *formatFlag,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(matrixOutput)
+	return 0
+}
+
+func runGenAdapter() int {
+	// Create a new flag set for gen-adapter subcommand
+	genAdapterFlags := flag.NewFlagSet("gen-adapter", flag.ExitOnError)
+	fromFlag := genAdapterFlags.String("from", "", "Directory of the package declaring the interfaces")
+	toFlag := genAdapterFlags.String("to", "", "Directory of the package to delegate to")
+	outFlag := genAdapterFlags.String("out", "", "Directory of the adapter package (default: \"<parent of -from>/<base of -to>adapter\")")
+	dryRunFlag := genAdapterFlags.Bool("dry-run", false, "Print the generated files instead of writing them")
+
+	args, err := parseInterspersed(genAdapterFlags, os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if len(args) > 1 {
+		fmt.Fprintln(os.Stderr, "Usage: go-arch-lint gen-adapter --from <dir> --to <dir> [flags] [path]")
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	genAdapterOutput, err := linter.GenAdapter(absPath, linter.GenAdapterOptions{
+		From:   *fromFlag,
+		To:     *toFlag,
+		Out:    *outFlag,
+		DryRun: *dryRunFlag,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(genAdapterOutput)
+	return 0
+}
+
+// parseInterspersed parses flags that may appear before, between and after positional
+// arguments, which the flag package stops at, and returns the positional arguments
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func runSuppressions() int {
+	// Create a new flag set for suppressions subcommand
+	suppressionsFlags := flag.NewFlagSet("suppressions", flag.ExitOnError)
+	formatFlag := suppressionsFlags.String("format", "markdown", "Output format: markdown, json")
+	profileFlag := suppressionsFlags.String("profile", "", "Apply a named profile from the config's profiles section")
+
+	// Parse flags starting from os.Args[2] (after "suppressions")
+	if err := suppressionsFlags.Parse(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if suppressionsFlags.NArg() > 0 {
+		projectPath = suppressionsFlags.Arg(0)
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	suppressionsOutput, err := linter.Suppressions(absPath, linter.SuppressionOptions{
+		Format:  *formatFlag,
+		Profile: *profileFlag,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(suppressionsOutput)
+	return 0
+}
+
+func runAggregate() int {
+	// Create a new flag set for aggregate subcommand
+	aggregateFlags := flag.NewFlagSet("aggregate", flag.ExitOnError)
+	formatFlag := aggregateFlags.String("format", "json", "Output format: json, markdown")
+
+	// Parse flags starting from os.Args[2] (after "aggregate")
+	if err := aggregateFlags.Parse(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if aggregateFlags.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: go-arch-lint aggregate [flags] <report.json>...")
+		return 2
+	}
+
+	aggregateOutput, err := linter.Aggregate(aggregateFlags.Args(), *formatFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(aggregateOutput)
+	return 0
+}
+
+func runQuery() int {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "Usage: go-arch-lint query <expression> [path]")
+		return 2
+	}
+
+	// Get project path from remaining args (optional)
+	projectPath := "."
+	if len(os.Args) > 3 {
+		projectPath = os.Args[3]
+	}
+
+	// Make path absolute
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid path: %v\n", err)
+		return 2
+	}
+
+	queryOutput, err := linter.Query(absPath, os.Args[2])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Print(queryOutput)
+	return 0
+}
+
+// reportLanguage returns the -lang value, or the language of the user's locale
+func reportLanguage(lang string) string {
+	if lang != "" {
+		return lang
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			return linter.LanguageFromLocale(locale)
+		}
+	}
+	return ""
+}
+
+// ciMode returns the -ci value if given, otherwise whether the CI environment variable,
+// set by most CI services, is set to something other than "false" or "0"
+func ciMode(ci bool) bool {
+	explicit := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "ci" {
+			explicit = true
+		}
+	})
+	if explicit {
+		return ci
+	}
+
+	value := strings.ToLower(os.Getenv("CI"))
+	return value != "" && value != "false" && value != "0"
+}
+
+// asciiOutput returns the -ascii value if given. Otherwise only the report printed
+// without -format is rendered as ASCII, when the terminal is unlikely to render UTF-8:
+// the locale is set but not UTF-8, or no locale is set on a Windows console other than
+// Windows Terminal. Generated documentation and graphs never depend on the locale.
+func asciiOutput(ascii bool, format string) bool {
+	explicit := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "ascii" {
+			explicit = true
+		}
+	})
+	if explicit {
+		return ascii
+	}
+	if format != "" {
+		return false
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(os.Getenv(name)); locale != "" {
+			return !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8")
+		}
+	}
+	return runtime.GOOS == "windows" && os.Getenv("WT_SESSION") == ""
+}
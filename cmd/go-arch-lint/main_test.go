@@ -1,11 +1,17 @@
 package main_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync/atomic"
 	"testing"
 )
 
@@ -14,6 +20,10 @@ var binaryPath string
 
 // TestMain runs before all tests and builds the binary once
 func TestMain(m *testing.M) {
+	// The report language and ASCII rendering follow the locale; the tests expect
+	// English UTF-8 output
+	os.Setenv("LC_ALL", "C.UTF-8")
+
 	// Build the binary once for all tests
 	binary, cleanup, err := setupBinary()
 	if err != nil {
@@ -62,6 +72,37 @@ func setupBinary() (string, func(), error) {
 	return binary, cleanup, nil
 }
 
+// writeProject writes files, keyed by their path relative to the project root, into a
+// temporary directory and returns it
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	for relPath, content := range files {
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmpDir
+}
+
+// runGit runs a git command in dir and fails the test on error, skipping it if git is
+// not installed
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
 func TestCLI_NoViolations_ExitCode0(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -714,6 +755,27 @@ go 1.21
 	}
 }
 
+func TestCLI_Init_WithExamples(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{"go.mod": "module github.com/test/project\n\ngo 1.21\n"})
+
+	cmd := exec.Command(binaryPath, "init", "--preset=ddd", "--with-examples")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("init failed: %v\nOutput: %s", err, output)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "cmd", "app", "main.go")); err != nil {
+		t.Errorf("expected the example cmd/app/main.go: %v", err)
+	}
+
+	// The example code follows the preset
+	cmd = exec.Command(binaryPath, ".")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("expected the examples to lint cleanly, got %v\nOutput: %s", err, output)
+	}
+}
+
 func TestCLI_Refresh_SamePreset(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -746,7 +808,7 @@ go 1.21
 	}
 
 	// Run refresh without preset flag (should use preset_used from config)
-	refreshCmd := exec.Command(binaryPath, "refresh")
+	refreshCmd := exec.Command(binaryPath, "refresh", "-yes")
 	refreshCmd.Dir = tmpDir
 	output, err = refreshCmd.CombinedOutput()
 	if err != nil {
@@ -813,7 +875,7 @@ go 1.21
 	}
 
 	// Refresh with ddd preset
-	refreshCmd := exec.Command(binaryPath, "refresh", "--preset=ddd")
+	refreshCmd := exec.Command(binaryPath, "refresh", "--preset=ddd", "-yes")
 	refreshCmd.Dir = tmpDir
 	output, err = refreshCmd.CombinedOutput()
 	if err != nil {
@@ -853,6 +915,121 @@ go 1.21
 	}
 }
 
+func TestCLI_Init_RequiresPresetWithoutTerminal(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A pipe on stdin stands in for CI: the menu must not wait for an answer
+	initCmd := exec.Command(binaryPath, "init")
+	initCmd.Dir = tmpDir
+	initCmd.Stdin = strings.NewReader("1\n")
+	output, err := initCmd.CombinedOutput()
+	if err == nil {
+		t.Fatal("expected init without --preset to fail when stdin is not a terminal")
+	}
+
+	outputStr := string(output)
+	if !strings.Contains(outputStr, "--preset is required when stdin is not a terminal") {
+		t.Errorf("expected error message about --preset, got: %s", outputStr)
+	}
+	if !strings.Contains(outputStr, "ddd, simple, hexagonal, custom") {
+		t.Errorf("expected the available presets to be listed, got: %s", outputStr)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".goarchlint")); !os.IsNotExist(err) {
+		t.Error("expected no .goarchlint to be created")
+	}
+}
+
+func TestCLI_GlobalYesFlag(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module github.com/test/project\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without --preset there is nothing to answer the menu
+	initCmd := exec.Command(binaryPath, "--non-interactive", "init")
+	initCmd.Dir = tmpDir
+	output, err := initCmd.CombinedOutput()
+	if err == nil {
+		t.Fatal("expected init without --preset to fail with --non-interactive")
+	}
+	if !strings.Contains(string(output), "--preset is required with --yes") {
+		t.Errorf("expected error message about --preset, got: %s", output)
+	}
+
+	initCmd = exec.Command(binaryPath, "--yes", "init", "--preset=simple")
+	initCmd.Dir = tmpDir
+	if output, err := initCmd.CombinedOutput(); err != nil {
+		t.Fatalf("init failed: %v\nOutput: %s", err, output)
+	}
+
+	// The global flag accepts the refresh confirmation
+	refreshCmd := exec.Command(binaryPath, "--yes", "refresh", "--preset=ddd")
+	refreshCmd.Dir = tmpDir
+	output, err = refreshCmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("refresh failed: %v\nOutput: %s", err, output)
+	}
+	if strings.Contains(string(output), "Refresh cancelled") {
+		t.Errorf("expected refresh to write without asking, got: %s", output)
+	}
+
+	configData, err := os.ReadFile(filepath.Join(tmpDir, ".goarchlint"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(configData), "name: ddd") {
+		t.Error("expected .goarchlint to be refreshed with the ddd preset")
+	}
+}
+
+func TestCLI_Refresh_PreviewWithoutConfirmation(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module github.com/test/project\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	initCmd := exec.Command(binaryPath, "init", "--preset=simple")
+	initCmd.Dir = tmpDir
+	if output, err := initCmd.CombinedOutput(); err != nil {
+		t.Fatalf("init failed: %v\nOutput: %s", err, output)
+	}
+	configPath := filepath.Join(tmpDir, ".goarchlint")
+	before, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Switching presets without answering the confirmation prompt only previews, and
+	// fails so scripts notice nothing was written
+	refreshCmd := exec.Command(binaryPath, "refresh", "--preset=ddd")
+	refreshCmd.Dir = tmpDir
+	output, _ := refreshCmd.CombinedOutput()
+	if exitCode := refreshCmd.ProcessState.ExitCode(); exitCode != 2 {
+		t.Fatalf("expected exit code 2 without a terminal nor --yes, got %d\nOutput: %s", exitCode, output)
+	}
+
+	outputStr := string(output)
+	if !strings.Contains(outputStr, "Preview: switching from 'simple' to 'ddd' adds") {
+		t.Errorf("expected a preview of the violation changes, got: %s", outputStr)
+	}
+	if !strings.Contains(outputStr, "new in internal/") {
+		t.Errorf("expected new violations in internal/ to be listed, got: %s", outputStr)
+	}
+	if !strings.Contains(outputStr, "Refresh cancelled") {
+		t.Errorf("expected refresh to be cancelled without confirmation, got: %s", outputStr)
+	}
+
+	after, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Error("expected .goarchlint to be unchanged without confirmation")
+	}
+}
+
 func TestCLI_Refresh_NoConfig(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -970,6 +1147,7 @@ go 1.21
 		"## Dependency Graph",
 		"## Public API",
 		"## Statistics",
+		"| Layer | Packages | Files | Lines | Size |",
 	}
 
 	for _, section := range expectedSections {
@@ -1719,3 +1897,1746 @@ func TestCLI_Version(t *testing.T) {
 	}
 }
 
+
+func TestCLI_DocsSplit(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: []
+scan_paths:
+  - cmd
+  - pkg
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for relPath, content := range map[string]string{
+		"cmd/app/main.go":        "package main\n\nimport \"github.com/test/project/pkg/service\"\n\nfunc main() { service.Run() }\n",
+		"pkg/service/service.go": "package service\n\nfunc Run() {}\n",
+	} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, filepath.Dir(relPath)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, relPath), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(binaryPath, "docs", "--split")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("docs --split failed: %v\nOutput: %s", err, output)
+	}
+
+	for _, page := range []string{"index.md", "layers/pkg.md", "packages/pkg/service.md", "packages/cmd/app.md"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "docs", "arch", page)); err != nil {
+			t.Errorf("expected page %s: %v", page, err)
+		}
+	}
+
+	// Custom output directory
+	cmd = exec.Command(binaryPath, "docs", "--split", "--output=site")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("docs --split --output failed: %v\nOutput: %s", err, output)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "site", "index.md")); err != nil {
+		t.Errorf("expected index in custom output directory: %v", err)
+	}
+}
+
+func TestCLI_DocsPublish(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    pkg: []
+scan_paths:
+  - pkg
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "pkg", "service"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "pkg", "service", "service.go"), []byte("package service\n\nfunc Run() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var published string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"results":[]}`))
+			return
+		}
+		published = r.Method + " " + r.URL.Path
+		w.Write([]byte(`{"id":"1","_links":{"webui":"/pages/1"}}`))
+	}))
+	defer server.Close()
+
+	cmd := exec.Command(binaryPath, "docs", "--publish=confluence", "--title=Arch")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(),
+		"CONFLUENCE_URL="+server.URL,
+		"CONFLUENCE_USER=me",
+		"CONFLUENCE_API_TOKEN=secret",
+		"CONFLUENCE_SPACE=ARCH",
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("docs --publish failed: %v\nOutput: %s", err, output)
+	}
+	if published != "POST /rest/api/content" {
+		t.Errorf("expected page to be created, got %q", published)
+	}
+	if !strings.Contains(string(output), server.URL+"/pages/1") {
+		t.Errorf("expected page URL in output, got: %s", output)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "docs", "arch-index.md")); err == nil {
+		t.Error("expected no index file when publishing")
+	}
+
+	// Unsupported target
+	cmd = exec.Command(binaryPath, "docs", "--publish=wiki")
+	cmd.Dir = tmpDir
+	output, err = cmd.CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Errorf("expected exit code 2 for unsupported target, got %v\nOutput: %s", err, output)
+	}
+}
+
+func TestCLI_GraphExportImport(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: []
+scan_paths:
+  - cmd
+  - pkg
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "cmd"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := `package main
+
+import "github.com/test/project/pkg"
+
+func main() {
+	pkg.Run()
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "cmd", "main.go"), []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Export to file
+	cmd := exec.Command(binaryPath, "graph", "export", "--output=graph.json")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("graph export failed: %v\nOutput: %s", err, output)
+	}
+
+	// Import the exported graph
+	cmd = exec.Command(binaryPath, "graph", "import", "graph.json")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Errorf("expected exit code 0 for graph import, got error: %v\nOutput: %s", err, output)
+	}
+
+	// Unsupported export format is an error
+	cmd = exec.Command(binaryPath, "graph", "export", "--format=dot")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if exitCode := cmd.ProcessState.ExitCode(); exitCode != 2 {
+		t.Errorf("expected exit code 2 for unsupported format, got %d\nOutput: %s", exitCode, output)
+	}
+
+	// Missing graph file is an error
+	cmd = exec.Command(binaryPath, "graph", "import", "missing.json")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if exitCode := cmd.ProcessState.ExitCode(); exitCode != 2 {
+		t.Errorf("expected exit code 2 for missing graph file, got %d\nOutput: %s", exitCode, output)
+	}
+}
+
+func TestCLI_JSONReportAndAggregate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: []
+scan_paths:
+  - cmd
+  - pkg
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "cmd"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := "package main\n\nimport _ \"github.com/test/project/pkg\"\n\nfunc main() {}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "cmd", "main.go"), []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The report goes to stdout and the violations still fail the run
+	cmd := exec.Command(binaryPath, "-format=json", ".")
+	cmd.Dir = tmpDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	_ = cmd.Run()
+	if exitCode := cmd.ProcessState.ExitCode(); exitCode != 1 {
+		t.Errorf("expected exit code 1 for violations, got %d\nStderr: %s", exitCode, stderr.String())
+	}
+	if strings.Contains(stderr.String(), "Forbidden Import") {
+		t.Errorf("expected the violations only in the report, got on stderr:\n%s", stderr.String())
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "report.json"), stdout.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd = exec.Command(binaryPath, "aggregate", "--format=markdown", "*.json")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("aggregate failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "| github.com/test/project | custom | 1 | 1 | - | 58 | failing |") {
+		t.Errorf("expected the repository row in the dashboard, got:\n%s", output)
+	}
+
+	// Aggregate needs at least one report
+	cmd = exec.Command(binaryPath, "aggregate")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if exitCode := cmd.ProcessState.ExitCode(); exitCode != 2 {
+		t.Errorf("expected exit code 2 without reports, got %d\nOutput: %s", exitCode, output)
+	}
+}
+
+func TestCLI_Quiet(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: []
+scan_paths:
+  - cmd
+  - pkg
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "cmd"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := "package main\n\nimport _ \"github.com/test/project/pkg\"\n\nfunc main() {}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "cmd", "main.go"), []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The compact lines go to stdout, so they can be piped
+	cmd := exec.Command(binaryPath, "-quiet", ".")
+	cmd.Dir = tmpDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	_ = cmd.Run()
+	if exitCode := cmd.ProcessState.ExitCode(); exitCode != 1 {
+		t.Errorf("expected exit code 1 for violations, got %d\nStderr: %s", exitCode, stderr.String())
+	}
+	// The code is the fix code, the type code and a hash of the fix text
+	if expected := regexp.MustCompile(`^cmd/main\.go:3:10 ARCH005-[0-9a-f]{6} rules\.directories_import\.cmd cmd imports pkg\n$`); !expected.MatchString(stdout.String()) {
+		t.Errorf("expected a line matching %s on stdout, got %q", expected, stdout.String())
+	}
+	if stderr.String() != "" {
+		t.Errorf("expected nothing on stderr, got:\n%s", stderr.String())
+	}
+}
+
+func TestCLI_Lang(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: []
+scan_paths:
+  - cmd
+  - pkg
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "cmd"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := "package main\n\nimport _ \"github.com/test/project/pkg\"\n\nfunc main() {}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "cmd", "main.go"), []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The locale selects the language unless -lang is given
+	cmd := exec.Command(binaryPath, ".")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "LC_ALL=", "LC_MESSAGES=", "LANG=de_DE.UTF-8")
+	output, _ := cmd.CombinedOutput()
+	if !strings.Contains(string(output), "ABHÄNGIGKEITSVERSTÖSSE ERKANNT") || !strings.Contains(string(output), "  Datei: cmd/main.go") {
+		t.Errorf("expected a German report for LANG=de_DE.UTF-8, got:\n%s", output)
+	}
+
+	cmd = exec.Command(binaryPath, "-lang=en", ".")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "LC_ALL=", "LC_MESSAGES=", "LANG=de_DE.UTF-8")
+	output, _ = cmd.CombinedOutput()
+	if !strings.Contains(string(output), "DEPENDENCY VIOLATIONS DETECTED") {
+		t.Errorf("expected -lang to override the locale, got:\n%s", output)
+	}
+
+	cmd = exec.Command(binaryPath, "-lang=fr", ".")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if exitCode := cmd.ProcessState.ExitCode(); exitCode != 2 || !strings.Contains(string(output), `unsupported language "fr" (available: de, en)`) {
+		t.Errorf("expected exit code 2 for an unsupported language, got %d:\n%s", exitCode, output)
+	}
+}
+
+func TestCLI_ASCII(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+preset:
+  name: simple
+  error_prompt:
+    enabled: true
+    architectural_goals: |
+      Keep the layers apart
+overrides:
+  rules:
+    directories_import:
+      cmd: []
+scan_paths:
+  - cmd
+  - pkg
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "cmd"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mainGo := "package main\n\nimport _ \"github.com/test/project/pkg\"\n\nfunc main() {}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "cmd", "main.go"), []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	isASCII := func(s string) bool {
+		for _, r := range s {
+			if r > 127 {
+				return false
+			}
+		}
+		return true
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		locale    string
+		wantASCII bool
+	}{
+		{name: "flag", args: []string{"-ascii"}, locale: "en_US.UTF-8", wantASCII: true},
+		{name: "non-UTF-8 locale", locale: "C", wantASCII: true},
+		{name: "UTF-8 locale", locale: "en_US.UTF-8", wantASCII: false},
+		{name: "flag overrides locale", args: []string{"-ascii=false"}, locale: "C", wantASCII: false},
+	}
+	for _, tt := range tests {
+		cmd := exec.Command(binaryPath, append(tt.args, ".")...)
+		cmd.Dir = tmpDir
+		cmd.Env = append(os.Environ(), "LC_ALL="+tt.locale)
+		output, _ := cmd.CombinedOutput()
+		if !strings.Contains(string(output), "cmd imports pkg") {
+			t.Fatalf("%s: expected the violation report, got:\n%s", tt.name, output)
+		}
+		if isASCII(string(output)) != tt.wantASCII {
+			t.Errorf("%s: expected ASCII output %v, got:\n%s", tt.name, tt.wantASCII, output)
+		}
+	}
+
+	// Documentation does not depend on the locale, only on the flag
+	for _, tt := range []struct {
+		args      []string
+		wantASCII bool
+	}{
+		{args: []string{"-format=full", "-exit-zero", "."}, wantASCII: false},
+		{args: []string{"-format=full", "-exit-zero", "-ascii", "."}, wantASCII: true},
+	} {
+		cmd := exec.Command(binaryPath, tt.args...)
+		cmd.Dir = tmpDir
+		cmd.Env = append(os.Environ(), "LC_ALL=C")
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%v failed: %v", tt.args, err)
+		}
+		if !strings.Contains(string(output), "cmd") || isASCII(string(output)) != tt.wantASCII {
+			t.Errorf("%v: expected ASCII documentation %v, got:\n%s", tt.args, tt.wantASCII, output)
+		}
+	}
+}
+
+func TestCLI_ConfigShowEffective(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+preset:
+  name: custom
+  rules:
+    directories_import:
+      cmd: [pkg]
+overrides:
+  rules:
+    detect_unused: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(binaryPath, "config", "show", "--effective")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("config show failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "cmd: [pkg] # preset custom") || !strings.Contains(string(output), "detect_unused: true # overrides") {
+		t.Errorf("expected value sources in effective config, got:\n%s", output)
+	}
+
+	// Unknown config command is an error
+	cmd = exec.Command(binaryPath, "config", "edit")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if exitCode := cmd.ProcessState.ExitCode(); exitCode != 2 {
+		t.Errorf("expected exit code 2 for unknown config command, got %d\nOutput: %s", exitCode, output)
+	}
+}
+
+func TestCLI_ConfigMigrate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+preset_used: custom
+rules:
+  directories_import:
+    cmd: [pkg]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(binaryPath, "config", "migrate")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("config migrate failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Migrated .goarchlint") {
+		t.Errorf("expected success message, got:\n%s", output)
+	}
+
+	migrated, err := os.ReadFile(filepath.Join(tmpDir, ".goarchlint"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(migrated), "preset:\n  name: custom\n") {
+		t.Errorf("expected preset/overrides format, got:\n%s", migrated)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".goarchlint.backup")); err != nil {
+		t.Errorf("expected backup file: %v", err)
+	}
+}
+
+func TestCLI_Move(t *testing.T) {
+	files := map[string]string{
+		".goarchlint":           "module: github.com/test/project\nrules:\n  directories_import:\n    cmd: [internal]\n    internal: []\nscan_paths:\n  - cmd\n  - internal\n",
+		"cmd/app/main.go":       "package main\n\nimport \"github.com/test/project/internal/util\"\n\nfunc main() { util.Run() }\n",
+		"internal/util/util.go": "package util\n\nfunc Run() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	// Flags may follow the directories
+	cmd := exec.Command(binaryPath, "move", "internal/util", "internal/platform/util", "--plan")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("move --plan failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "| cmd/app/main.go | 3 |") || strings.Contains(string(output), "Move cancelled") {
+		t.Errorf("expected only the plan, got:\n%s", output)
+	}
+
+	// Without a terminal nor --yes, nothing is moved and the command fails
+	cmd = exec.Command(binaryPath, "move", "internal/util", "internal/platform/util")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if exitCode := cmd.ProcessState.ExitCode(); exitCode != 2 {
+		t.Fatalf("expected exit code 2 without a terminal nor --yes, got %d\nOutput: %s", exitCode, output)
+	}
+	if !strings.Contains(string(output), "Move cancelled") {
+		t.Errorf("expected the move to be cancelled, got:\n%s", output)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "internal", "util")); err != nil {
+		t.Fatalf("expected internal/util to stay in place: %v", err)
+	}
+
+	cmd = exec.Command(binaryPath, "move", "--yes", "internal/util", "internal/platform/util")
+	cmd.Dir = tmpDir
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("move --yes failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Moved internal/util to internal/platform/util") {
+		t.Errorf("expected success message, got:\n%s", output)
+	}
+	main, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "app", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(main), "\"github.com/test/project/internal/platform/util\"") {
+		t.Errorf("expected the import to be rewritten:\n%s", main)
+	}
+
+	cmd = exec.Command(binaryPath, "move", "internal/util")
+	cmd.Dir = tmpDir
+	if err := cmd.Run(); err == nil {
+		t.Error("expected usage error without a destination")
+	}
+}
+
+func TestCLI_GenPort(t *testing.T) {
+	files := map[string]string{
+		".goarchlint":             "module: github.com/test/project\nscan_paths:\n  - internal\n",
+		"internal/infra/store.go": "package infra\n\ntype Store struct{}\n\nfunc (s *Store) Get(key string) string { return key }\n",
+		"internal/app/app.go":     "package app\n\nimport \"github.com/test/project/internal/infra\"\n\nfunc Run(s *infra.Store) string { return s.Get(\"k\") }\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	// Flags may follow the type
+	cmd := exec.Command(binaryPath, "gen-port", "internal/infra.Store", "--into", "internal/domain", "--dry-run")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("gen-port failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "\tGet(key string) string\n") || !strings.Contains(string(output), "// internal/infra/store_port.go") {
+		t.Errorf("expected the generated files, got:\n%s", output)
+	}
+
+	cmd = exec.Command(binaryPath, "gen-port", "internal/infra.Store")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(output), "-into is required") {
+		t.Errorf("expected an error without -into, got %v:\n%s", err, output)
+	}
+}
+
+func TestCLI_GenAdapter(t *testing.T) {
+	files := map[string]string{
+		".goarchlint":          "module: github.com/test/project\nscan_paths:\n  - pkg\n  - internal\n",
+		"pkg/app/app.go":       "package app\n\ntype Cache interface {\n\tGet(key string) string\n\tPut(key, value string)\n}\n",
+		"internal/kv/store.go": "package kv\n\ntype Store struct{}\n\nfunc (s *Store) Get(key string) string { return key }\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	cmd := exec.Command(binaryPath, "gen-adapter", "--from", "pkg/app", "--to", "internal/kv", "--dry-run")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("gen-adapter failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "// pkg/kvadapter/cache.go") || !strings.Contains(string(output), "\treturn a.target.Get(key)\n") {
+		t.Errorf("expected the generated adapter, got:\n%s", output)
+	}
+
+	cmd = exec.Command(binaryPath, "gen-adapter", "--from", "pkg/app")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(output), "-from and -to are required") {
+		t.Errorf("expected an error without -to, got %v:\n%s", err, output)
+	}
+}
+
+func TestCLI_Matrix(t *testing.T) {
+	files := map[string]string{
+		"go.mod":              "module github.com/test/project\n\ngo 1.21\n",
+		"strict.yaml":         "module: github.com/test/project\nrules:\n  directories_import:\n    pkg: []\n    internal: []\n",
+		"loose.yaml":          "module: github.com/test/project\nrules:\n  directories_import:\n    pkg: [internal]\n    internal: []\n",
+		"pkg/api/api.go":      "package api\n\nimport \"github.com/test/project/internal/store\"\n\nfunc Serve() { store.Save() }\n",
+		"internal/store/s.go": "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	// Reports side by side and never fails, even though strict.yaml fails the build
+	cmd := exec.Command(binaryPath, "matrix", "--configs", "loose.yaml, strict.yaml", ".")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("matrix failed: %v\nOutput: %s", err, output)
+	}
+	for _, want := range []string{"| loose.yaml | strict.yaml |", "| **Build** | passes | fails |", "`pkg/api/api.go:3` | - | error |"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	cmd = exec.Command(binaryPath, "matrix", ".")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(output), "-configs is required") {
+		t.Errorf("expected an error without -configs, got %v:\n%s", err, output)
+	}
+}
+
+func TestCLI_History(t *testing.T) {
+	files := map[string]string{
+		"go.mod":          "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":     "rules:\n  directories_import:\n    cmd: [pkg]\n    pkg: []\nscan_paths:\n  - cmd\n  - pkg\n",
+		"cmd/app/main.go": "package main\n\nimport \"github.com/test/project/pkg/api\"\n\nfunc main() { api.Serve() }\n",
+		"pkg/api/api.go":  "package api\n\nfunc Serve() {}\n",
+		"pkg/store/s.go":  "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+	runGit(t, tmpDir, "init", "-q")
+	runGit(t, tmpDir, "add", "-A")
+	runGit(t, tmpDir, "commit", "-q", "-m", "first")
+	runGit(t, tmpDir, "tag", "v1.0.0")
+
+	// HEAD adds a pkg -> pkg import, which the rules forbid
+	api := "package api\n\nimport \"github.com/test/project/pkg/store\"\n\nfunc Serve() { store.Save() }\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "pkg", "api", "api.go"), []byte(api), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, tmpDir, "commit", "-q", "-am", "second")
+
+	cmd := exec.Command(binaryPath, "history", "--since", "v1.0.0", "--format=csv")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("history failed: %v\nOutput: %s", err, output)
+	}
+
+	// One row per revision, oldest first, with the violations column going from 0 to 2
+	rows := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(rows) != 3 || !strings.HasPrefix(rows[0], "revision,commit,date,") {
+		t.Fatalf("expected a header and two revisions, got:\n%s", output)
+	}
+	for i, want := range []struct{ revision, violations string }{{"v1.0.0", "0"}, {"HEAD", "2"}} {
+		fields := strings.Split(rows[i+1], ",")
+		if fields[0] != want.revision || fields[7] != want.violations {
+			t.Errorf("expected %s with %s violations, got %q", want.revision, want.violations, rows[i+1])
+		}
+	}
+
+	// Every first-parent commit since v1.0.0, named by its short hash
+	cmd = exec.Command(binaryPath, "history", "--since", "v1.0.0", "--interval", "commit", "--format=csv")
+	cmd.Dir = tmpDir
+	if output, err = cmd.CombinedOutput(); err != nil {
+		t.Fatalf("history failed: %v\nOutput: %s", err, output)
+	}
+	if rows := strings.Split(strings.TrimSpace(string(output)), "\n"); len(rows) != 3 || !regexp.MustCompile(`^[0-9a-f]{7},`).MatchString(rows[2]) {
+		t.Errorf("expected two commits named by short hash, got:\n%s", output)
+	}
+
+	cmd = exec.Command(binaryPath, "history", "--since", "v9.9.9")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if cmd.ProcessState.ExitCode() != 2 || !strings.Contains(string(output), "unknown revision") {
+		t.Errorf("expected exit code 2 for an unknown revision, got %d:\n%s", cmd.ProcessState.ExitCode(), output)
+	}
+}
+
+func TestCLI_Hotspots(t *testing.T) {
+	files := map[string]string{
+		"go.mod":          "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":     "rules:\n  directories_import:\n    cmd: [pkg]\n    pkg: []\nscan_paths:\n  - cmd\n  - pkg\n",
+		"cmd/app/main.go": "package main\n\nimport \"github.com/test/project/pkg/api\"\n\nfunc main() { api.Serve() }\n",
+		"pkg/api/api.go":  "package api\n\nimport \"github.com/test/project/pkg/store\"\n\nfunc Serve() { store.Save() }\n",
+		"pkg/store/s.go":  "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+	runGit(t, tmpDir, "init", "-q")
+	runGit(t, tmpDir, "add", "-A")
+	runGit(t, tmpDir, "commit", "-q", "-m", "first")
+
+	// pkg/api changes again, and it breaks the rules by importing pkg/store
+	api := "package api\n\nimport \"github.com/test/project/pkg/store\"\n\nfunc Serve() { store.Save(); store.Save() }\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "pkg", "api", "api.go"), []byte(api), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, tmpDir, "commit", "-q", "-am", "second")
+
+	cmd := exec.Command(binaryPath, "hotspots", "--format=json", "--top=1")
+	cmd.Dir = tmpDir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("hotspots failed: %v\nOutput: %s", err, output)
+	}
+	var entries []struct {
+		Package    string `json:"package"`
+		Commits    int    `json:"commits"`
+		Afferent   int    `json:"afferent"`
+		Efferent   int    `json:"efferent"`
+		Violations int    `json:"violations"`
+		Score      int    `json:"score"`
+	}
+	if err := json.Unmarshal(output, &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the top package, got %+v", entries)
+	}
+	// 2 commits × (1 afferent + 1 efferent + 1) × (2 violations + 1)
+	if got := entries[0]; got.Package != "pkg/api" || got.Commits != 2 || got.Violations != 2 || got.Score != 18 {
+		t.Errorf("expected pkg/api ranked first with score 18, got %+v", got)
+	}
+
+	cmd = exec.Command(binaryPath, "hotspots", "--since", "1 year ago")
+	cmd.Dir = tmpDir
+	if output, err = cmd.CombinedOutput(); err != nil {
+		t.Fatalf("hotspots failed: %v\nOutput: %s", err, output)
+	}
+	for _, want := range []string{"based on changes since 1 year ago", "| 1 | pkg/api | 5 | 2 |", "| pkg/store |"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	cmd = exec.Command(binaryPath, "hotspots", "--since", "someday")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if cmd.ProcessState.ExitCode() != 2 || !strings.Contains(string(output), "invalid date") {
+		t.Errorf("expected exit code 2 for an invalid date, got %d:\n%s", cmd.ProcessState.ExitCode(), output)
+	}
+}
+
+func TestCLI_Query(t *testing.T) {
+	files := map[string]string{
+		"go.mod":                  "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":             "rules:\n  directories_import:\n    cmd: [internal/app]\n    internal/app: [internal/infra]\n    internal/infra: []\nscan_paths:\n  - cmd\n  - internal\n",
+		"cmd/app/main.go":         "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/test/project/internal/app\"\n)\n\nfunc main() { fmt.Println(app.Run()) }\n",
+		"internal/app/app.go":     "package app\n\nimport (\n\t\"github.com/gin-gonic/gin\"\n\t\"github.com/test/project/internal/infra/db\"\n)\n\nfunc Run() string { _ = gin.New; return db.Name() }\n",
+		"internal/infra/db/db.go": "package db\n\nfunc Name() string { return \"db\" }\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{"deps(internal/app) & layer(infra)", "internal/infra/db\n"},
+		{"closure(main()) & external()", "github.com/gin-gonic/gin\n"},
+		{"rdeps(internal/infra/...)", "internal/app\n"},
+		{"closure(cmd/*) - stdlib()", "github.com/gin-gonic/gin\ninternal/app\ninternal/infra/db\n"},
+		{"rdeps(cmd/app)", ""},
+	}
+	for _, tt := range tests {
+		cmd := exec.Command(binaryPath, "query", tt.expression, tmpDir)
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("query %q failed: %v", tt.expression, err)
+		}
+		if string(output) != tt.want {
+			t.Errorf("query %q = %q, want %q", tt.expression, output, tt.want)
+		}
+	}
+
+	for _, args := range [][]string{{"query"}, {"query", "deps(", tmpDir}} {
+		cmd := exec.Command(binaryPath, args...)
+		output, _ := cmd.CombinedOutput()
+		if cmd.ProcessState.ExitCode() != 2 {
+			t.Errorf("expected exit code 2 for %v, got %d:\n%s", args, cmd.ProcessState.ExitCode(), output)
+		}
+	}
+}
+
+func TestCLI_Doctor(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":         "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":    "scan_paths:\n  - pkg\n  - missing\n",
+		"pkg/api/api.go": "package api\n",
+	})
+
+	// Warnings, such as a missing scan path, do not fail
+	cmd := exec.Command(binaryPath, "doctor")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("expected doctor to pass, got %v:\n%s", err, output)
+	}
+	for _, want := range []string{"✓ go.mod: module github.com/test/project", "⚠ Config: .goarchlint is valid, but scan paths do not exist: missing", "is writable", "No problems found"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	// A broken config fails with its remediation step
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte("rules: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd = exec.Command(binaryPath, "doctor", tmpDir)
+	output, _ = cmd.CombinedOutput()
+	if cmd.ProcessState.ExitCode() != 1 {
+		t.Errorf("expected exit code 1 for an invalid config, got %d:\n%s", cmd.ProcessState.ExitCode(), output)
+	}
+	for _, want := range []string{"✗ Config: parsing config file", "→ Fix .goarchlint", "1 problem(s) found"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestCLI_Suppressions(t *testing.T) {
+	configYAML := `ignore_paths:
+  - internal/generated
+overrides:
+  rules:
+    test_files:
+      allow_whitebox:
+        - path: internal/parser
+          reason: Overridden reason
+preset:
+  name: simple
+  rules:
+    package_naming:
+      exceptions:
+        - internal/legacy
+profiles:
+  ci:
+    rules:
+      package_naming:
+        exceptions:
+          - internal/legacy
+`
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":               "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":          configYAML,
+		"internal/legacy/l.go": "package legacy\n",
+		"internal/parser/p.go": "package parser\n\n//archlint:ignore forbidden_import until=2099-01-01 reason=migrating\nimport _ \"fmt\"\n",
+	})
+
+	// The Source column names the layer listing each entry, with its line in that layer
+	cmd := exec.Command(binaryPath, "suppressions")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("suppressions failed: %v\nOutput: %s", err, output)
+	}
+	for _, want := range []string{
+		"| Kind | Target | Reason | Location | Source | Age |",
+		"| ignored path | `internal/generated` | - | .goarchlint:2 | .goarchlint | - |",
+		"| whitebox tests | `internal/parser` | Overridden reason | .goarchlint:7 | overrides | - |",
+		"| package name | `internal/legacy` | - | .goarchlint:14 | preset simple | - |",
+		"| inline ignore | `forbidden_import` | migrating",
+		"| internal/parser/p.go:3 | archlint:ignore |",
+	} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	// The profile listing the same exception as the preset is its source
+	cmd = exec.Command(binaryPath, "suppressions", "--format=json", "--profile=ci")
+	cmd.Dir = tmpDir
+	if output, err = cmd.Output(); err != nil {
+		t.Fatalf("suppressions failed: %v\nOutput: %s", err, output)
+	}
+	var entries []struct {
+		Target   string `json:"target"`
+		Source   string `json:"source"`
+		Location string `json:"location"`
+	}
+	if err := json.Unmarshal(output, &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	found := false
+	for _, entry := range entries {
+		if entry.Target == "internal/legacy" {
+			found = true
+			if entry.Source != "profile ci" || entry.Location != ".goarchlint:20" {
+				t.Errorf("expected internal/legacy from profile ci at .goarchlint:20, got %+v", entry)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected the package naming exception, got:\n%s", output)
+	}
+
+	cmd = exec.Command(binaryPath, "suppressions", "--profile=nope")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if cmd.ProcessState.ExitCode() != 2 || !strings.Contains(string(output), `unknown profile "nope"`) {
+		t.Errorf("expected exit code 2 for an unknown profile, got %d:\n%s", cmd.ProcessState.ExitCode(), output)
+	}
+}
+
+func TestCLI_Promotions(t *testing.T) {
+	files := map[string]string{
+		"go.mod":             "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":        "rules:\n  directories_import:\n    cmd: [pkg]\n    pkg: [internal]\n    internal: []\nscan_paths:\n  - cmd\n  - pkg\n  - internal\n",
+		"internal/util/u.go": "package util\n\nfunc U() {}\n",
+		"internal/beta/b.go": "// Package beta is not settled yet.\n//\n// archlint:stability experimental\npackage beta\n\nfunc B() {}\n",
+		"pkg/a/a.go":         "package a\n\nimport (\n\t\"github.com/test/project/internal/beta\"\n\t\"github.com/test/project/internal/util\"\n)\n\nfunc F() { util.U(); beta.B() }\n",
+		"pkg/b/b.go":         "package b\n\nimport (\n\t\"github.com/test/project/internal/beta\"\n\t\"github.com/test/project/internal/util\"\n)\n\nfunc F() { util.U(); beta.B() }\n",
+		"cmd/app/main.go":    "package main\n\nimport \"github.com/test/project/pkg/a\"\n\nfunc main() { a.F() }\n",
+		"cmd/tool/main.go":   "package main\n\nimport (\n\t\"github.com/test/project/pkg/a\"\n\t\"github.com/test/project/pkg/b\"\n)\n\nfunc main() { a.F(); b.F() }\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	// Two pkg/ importers are not enough by default
+	cmd := exec.Command(binaryPath, "promotions")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("promotions failed: %v\nOutput: %s", err, output)
+	}
+	for _, want := range []string{"## Promote to pkg/", "No candidates.", "| `pkg/b` | `internal/b` | cmd/tool |"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(string(output), "`pkg/a`") {
+		t.Errorf("expected pkg/a, used by two binaries, to stay public, got:\n%s", output)
+	}
+
+	// The experimental package is never promoted
+	cmd = exec.Command(binaryPath, "promotions", "--min-importers=2", "--format=json")
+	cmd.Dir = tmpDir
+	if output, err = cmd.Output(); err != nil {
+		t.Fatalf("promotions failed: %v\nOutput: %s", err, output)
+	}
+	var report struct {
+		Promote []struct {
+			Package   string   `json:"package"`
+			Target    string   `json:"target"`
+			Importers []string `json:"importers"`
+		} `json:"promote"`
+	}
+	if err := json.Unmarshal(output, &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if len(report.Promote) != 1 || report.Promote[0].Package != "internal/util" || report.Promote[0].Target != "pkg/util" || len(report.Promote[0].Importers) != 2 {
+		t.Errorf("expected only internal/util to be promoted, got %+v", report.Promote)
+	}
+
+	cmd = exec.Command(binaryPath, "promotions", "--format=xml")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if cmd.ProcessState.ExitCode() != 2 {
+		t.Errorf("expected exit code 2 for an unsupported format, got %d:\n%s", cmd.ProcessState.ExitCode(), output)
+	}
+}
+
+func TestCLI_DocsCheck(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":         "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":    "scan_paths:\n  - pkg\n",
+		"pkg/api/api.go": "package api\n\nfunc Serve() {}\n",
+	})
+
+	docsCheck := func() (string, int) {
+		t.Helper()
+		cmd := exec.Command(binaryPath, "docs", "--check")
+		cmd.Dir = tmpDir
+		output, _ := cmd.CombinedOutput()
+		return string(output), cmd.ProcessState.ExitCode()
+	}
+
+	cmd := exec.Command(binaryPath, "docs")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("docs failed: %v\nOutput: %s", err, output)
+	}
+	runGit(t, tmpDir, "init", "-q")
+	runGit(t, tmpDir, "add", "-A")
+	runGit(t, tmpDir, "commit", "-q", "-m", "docs")
+
+	if output, code := docsCheck(); code != 0 || !strings.Contains(output, "Documentation is up to date: docs/arch-index.md") {
+		t.Errorf("expected fresh docs to pass with exit code 0, got %d:\n%s", code, output)
+	}
+
+	// A changed exported signature makes the committed index stale
+	api := "package api\n\nfunc Serve(addr string) error { return nil }\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "pkg", "api", "api.go"), []byte(api), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output, code := docsCheck()
+	if code != 1 {
+		t.Errorf("expected exit code 1 for stale docs, got %d:\n%s", code, output)
+	}
+	for _, want := range []string{"Documentation is out of date: docs/arch-index.md", "~ pkg/api.Serve", "- Serve()", "+ Serve(string) error"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	cmd = exec.Command(binaryPath, "docs")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("docs failed: %v\nOutput: %s", err, output)
+	}
+	if output, code := docsCheck(); code != 0 {
+		t.Errorf("expected regenerated docs to pass, got %d:\n%s", code, output)
+	}
+
+	cmd = exec.Command(binaryPath, "docs", "--check", "--split")
+	cmd.Dir = tmpDir
+	if output, _ := cmd.CombinedOutput(); cmd.ProcessState.ExitCode() != 2 {
+		t.Errorf("expected exit code 2 for -check with -split, got %d:\n%s", cmd.ProcessState.ExitCode(), output)
+	}
+}
+
+func TestCLI_StrictParse(t *testing.T) {
+	// Only import blocks are parsed by default, so the syntax error must be in one
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":         "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":    "scan_paths:\n  - pkg\n",
+		"pkg/api/api.go": "package api\n\nimport fmt\n\nfunc Serve() {}\n",
+		"pkg/store/s.go": "package store\n\nimport \"github.com/test/project/pkg/api\"\n\nvar _ = api.Serve\n",
+	})
+
+	// By default the file is reported and the others are still checked
+	cmd := exec.Command(binaryPath, ".")
+	cmd.Dir = tmpDir
+	output, _ := cmd.CombinedOutput()
+	if cmd.ProcessState.ExitCode() != 1 {
+		t.Errorf("expected exit code 1, got %d:\n%s", cmd.ProcessState.ExitCode(), output)
+	}
+	for _, want := range []string{"[ERROR] Parse Error", "File: pkg/api/api.go:3:11", "pkg/store imports pkg/api"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	cmd = exec.Command(binaryPath, "-strict-parse", ".")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if cmd.ProcessState.ExitCode() != 2 {
+		t.Errorf("expected exit code 2 with -strict-parse, got %d:\n%s", cmd.ProcessState.ExitCode(), output)
+	}
+	if !strings.Contains(string(output), "api.go:3:11: missing import path") || strings.Contains(string(output), "pkg/store imports pkg/api") {
+		t.Errorf("expected only the parse error, got:\n%s", output)
+	}
+}
+
+func TestCLI_Profile(t *testing.T) {
+	configYAML := `rules:
+  directories_import:
+    cmd: [internal]
+    internal: []
+scan_paths:
+  - cmd
+  - internal
+profiles:
+  strict:
+    rules:
+      directories_import:
+        cmd: []
+`
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":            "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":       configYAML,
+		"cmd/app/main.go":   "package main\n\nimport \"github.com/test/project/internal/db\"\n\nfunc main() { db.Open() }\n",
+		"internal/db/db.go": "package db\n\nfunc Open() {}\n",
+	})
+
+	cmd := exec.Command(binaryPath, ".")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("expected no violations without a profile, got %v:\n%s", err, output)
+	}
+
+	// The profile replaces the allowed imports of cmd and is named as their source
+	cmd = exec.Command(binaryPath, "-profile", "strict", ".")
+	cmd.Dir = tmpDir
+	output, _ := cmd.CombinedOutput()
+	if cmd.ProcessState.ExitCode() != 1 {
+		t.Errorf("expected exit code 1 with the strict profile, got %d:\n%s", cmd.ProcessState.ExitCode(), output)
+	}
+	for _, want := range []string{"cmd/app imports internal/db", "Source: profile strict (rules.directories_import.cmd)"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	cmd = exec.Command(binaryPath, "-profile", "nope", ".")
+	cmd.Dir = tmpDir
+	output, _ = cmd.CombinedOutput()
+	if cmd.ProcessState.ExitCode() != 2 || !strings.Contains(string(output), `unknown profile "nope" (available: strict)`) {
+		t.Errorf("expected exit code 2 for an unknown profile, got %d:\n%s", cmd.ProcessState.ExitCode(), output)
+	}
+}
+
+func TestCLI_BuildMatrix(t *testing.T) {
+	rules := "rules:\n  directories_import:\n    internal/domain: []\n    internal/syscalls: []\nscan_paths:\n  - internal\n"
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":                           "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":                      rules + "build_matrix:\n  - goos: linux\n    goarch: amd64\n  - goos: windows\n    goarch: amd64\n    tags: [integration]\n",
+		"internal/domain/order.go":         "package domain\n\nfunc Order() {}\n",
+		"internal/domain/order_windows.go": "//go:build integration\n\npackage domain\n\nimport \"github.com/test/project/internal/syscalls\"\n\nvar _ = syscalls.Call\n",
+		"internal/syscalls/s.go":           "package syscalls\n\nfunc Call() {}\n",
+	})
+
+	run := func(args ...string) (string, int) {
+		t.Helper()
+		cmd := exec.Command(binaryPath, args...)
+		cmd.Dir = tmpDir
+		output, _ := cmd.CombinedOutput()
+		return string(output), cmd.ProcessState.ExitCode()
+	}
+
+	// The violation names the only target building the file
+	output, code := run("-build-matrix", ".")
+	if code != 1 || !strings.Contains(output, "internal/domain imports internal/syscalls (only under windows/amd64 with tags integration)") {
+		t.Errorf("expected the windows-only violation with exit code 1, got %d:\n%s", code, output)
+	}
+
+	// Without the windows target nothing builds the file
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(rules+"build_matrix:\n  - goos: linux\n    goarch: amd64\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if output, code := run("-build-matrix", "."); code != 0 {
+		t.Errorf("expected no violations for linux only, got %d:\n%s", code, output)
+	}
+	if output, code := run("."); code != 1 {
+		t.Errorf("expected a plain run to check every file, got %d:\n%s", code, output)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if output, code := run("-build-matrix", "."); code != 2 || !strings.Contains(output, "requires build_matrix targets") {
+		t.Errorf("expected exit code 2 without targets, got %d:\n%s", code, output)
+	}
+}
+
+func TestCLI_CheckUpdate(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":         "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":    "scan_paths:\n  - pkg\n",
+		"pkg/api/api.go": "package api\n",
+	})
+
+	var requests atomic.Int32
+	var unavailable atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/repos/kgatilin/go-arch-lint/releases" || unavailable.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[{"tag_name": "v99.0.0", "html_url": "https://example.com/v99.0.0", "body": "## Rule changes\n- Forbidden imports now cover test files\n"}]`))
+	}))
+	defer server.Close()
+
+	// The notice goes to stderr
+	run := func(args ...string) (string, int) {
+		t.Helper()
+		cmd := exec.Command(binaryPath, args...)
+		cmd.Dir = tmpDir
+		cmd.Env = append(os.Environ(), "GO_ARCH_LINT_GITHUB_API_URL="+server.URL)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		cmd.Run()
+		return stderr.String(), cmd.ProcessState.ExitCode()
+	}
+
+	// Opt-in only
+	if stderr, code := run("."); code != 0 || requests.Load() != 0 || stderr != "" {
+		t.Errorf("expected no update check without -check-update, got %d request(s), exit code %d:\n%s", requests.Load(), code, stderr)
+	}
+
+	stderr, code := run("-check-update", ".")
+	if code != 0 {
+		t.Errorf("expected exit code 0, got %d:\n%s", code, stderr)
+	}
+	for _, want := range []string{"go-arch-lint v99.0.0 is available (installed: ", "https://example.com/v99.0.0", "v99.0.0:", "Forbidden imports now cover test files"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("expected stderr to contain %q, got:\n%s", want, stderr)
+		}
+	}
+
+	// A failed check warns without failing the run
+	unavailable.Store(true)
+	if stderr, code := run("-check-update", "."); code != 0 || !strings.Contains(stderr, "Warning: checking for updates") {
+		t.Errorf("expected a warning and exit code 0 for a failed check, got %d:\n%s", code, stderr)
+	}
+}
+
+func TestCLI_FailFast(t *testing.T) {
+	// Three errors: cmd/app may import nothing, and neither may pkg/a
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":          "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":     "rules:\n  directories_import:\n    cmd: []\n    pkg: []\nscan_paths:\n  - cmd\n  - pkg\n",
+		"cmd/app/main.go": "package main\n\nimport (\n\t\"github.com/test/project/pkg/a\"\n\t\"github.com/test/project/pkg/b\"\n)\n\nfunc main() { a.A(); b.B() }\n",
+		"pkg/a/a.go":      "package a\n\nimport \"github.com/test/project/pkg/b\"\n\nfunc A() { b.B() }\n",
+		"pkg/b/b.go":      "package b\n\nfunc B() {}\n",
+	})
+
+	run := func(args ...string) (string, string, int) {
+		t.Helper()
+		cmd := exec.Command(binaryPath, args...)
+		cmd.Dir = tmpDir
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		cmd.Run()
+		return stdout.String(), stderr.String(), cmd.ProcessState.ExitCode()
+	}
+
+	stdout, stderr, code := run("-format=markdown", ".")
+	report := stdout + stderr
+	if code != 1 || strings.Count(report, "[ERROR]") != 3 || !strings.Contains(stdout, "# Dependency Graph") {
+		t.Fatalf("expected three errors and the graph without -fail-fast, got %d:\n%s", code, report)
+	}
+
+	// Only the first error, and no documentation output
+	stdout, stderr, code = run("-fail-fast", "-format=markdown", ".")
+	report = stdout + stderr
+	if code != 1 {
+		t.Errorf("expected exit code 1 with -fail-fast, got %d", code)
+	}
+	if strings.Count(report, "[ERROR]") != 1 || !strings.Contains(report, "cmd/app imports pkg/a, pkg/b") {
+		t.Errorf("expected only the first error, got:\n%s", report)
+	}
+	if !strings.Contains(report, "Stopped at the first error (-fail-fast)") {
+		t.Errorf("expected a note that more violations may exist, got:\n%s", report)
+	}
+	if strings.Contains(stdout, "# Dependency Graph") {
+		t.Errorf("expected the graph to be skipped, got:\n%s", stdout)
+	}
+
+	if _, _, code := run("-fail-fast", "-exit-zero", "."); code != 0 {
+		t.Errorf("expected -exit-zero to still apply, got exit code %d", code)
+	}
+}
+
+func TestCLI_Focus(t *testing.T) {
+	// cmd/app -> internal/app -> internal/domain -> internal/util; internal/other is unrelated
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":                  "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":             "rules:\n  directories_import:\n    cmd: [internal]\n    internal: [internal]\nscan_paths:\n  - cmd\n  - internal\n",
+		"cmd/app/main.go":         "package main\n\nimport \"github.com/test/project/internal/app\"\n\nfunc main() { app.Run() }\n",
+		"internal/app/app.go":     "package app\n\nimport \"github.com/test/project/internal/domain\"\n\nfunc Run() { domain.D() }\n",
+		"internal/domain/d.go":    "package domain\n\nimport \"github.com/test/project/internal/util\"\n\nfunc D() { util.U() }\n",
+		"internal/util/u.go":      "package util\n\nfunc U() {}\n",
+		"internal/other/other.go": "package other\n\nfunc O() {}\n",
+	})
+
+	run := func(args ...string) (string, int) {
+		t.Helper()
+		cmd := exec.Command(binaryPath, args...)
+		cmd.Dir = tmpDir
+		output, _ := cmd.CombinedOutput()
+		return string(output), cmd.ProcessState.ExitCode()
+	}
+
+	// The default depth keeps the direct importer and import of the focus package
+	output, code := run("-format=markdown", "-focus", "internal/app", ".")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d:\n%s", code, output)
+	}
+	for _, file := range []string{"## cmd/app/main.go", "## internal/app/app.go", "## internal/domain/d.go"} {
+		if !strings.Contains(output, file) {
+			t.Errorf("expected %s in the focused graph, got:\n%s", file, output)
+		}
+	}
+	for _, file := range []string{"## internal/util/u.go", "## internal/other/other.go"} {
+		if strings.Contains(output, file) {
+			t.Errorf("expected %s outside the focused graph, got:\n%s", file, output)
+		}
+	}
+
+	output, code = run("-format=markdown", "-focus", "internal/app", "-depth", "2", ".")
+	if code != 0 || !strings.Contains(output, "## internal/util/u.go") || strings.Contains(output, "## internal/other/other.go") {
+		t.Errorf("expected -depth 2 to reach internal/util only, got %d:\n%s", code, output)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"wrong format", []string{"-focus", "internal/app"}, "-focus is only supported with -format=markdown"},
+		{"no match", []string{"-format=markdown", "-focus", "nomatch/**"}, `no packages match focus pattern "nomatch/**"`},
+		{"negative depth", []string{"-format=markdown", "-focus", "internal/app", "-depth", "-1"}, "invalid depth -1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, code := run(append(tt.args, ".")...)
+			if code != 2 {
+				t.Errorf("expected exit code 2, got %d", code)
+			}
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("expected %q, got:\n%s", tt.want, output)
+			}
+		})
+	}
+}
+
+func TestCLI_MinScore(t *testing.T) {
+	// No violations, but the coupling keeps the score below 100
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":               "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":          "rules:\n  directories_import:\n    cmd: [internal]\n    internal: [internal]\nscan_paths:\n  - cmd\n  - internal\n",
+		"cmd/app/main.go":      "package main\n\nimport \"github.com/test/project/internal/app\"\n\nfunc main() { app.Run() }\n",
+		"internal/app/app.go":  "package app\n\nimport \"github.com/test/project/internal/domain\"\n\nfunc Run() { domain.D() }\n",
+		"internal/domain/d.go": "package domain\n\nfunc D() {}\n",
+	})
+
+	run := func(args ...string) (string, int) {
+		t.Helper()
+		cmd := exec.Command(binaryPath, args...)
+		cmd.Dir = tmpDir
+		output, _ := cmd.CombinedOutput()
+		return string(output), cmd.ProcessState.ExitCode()
+	}
+
+	output, code := run("-format=json", ".")
+	if code != 0 {
+		t.Fatalf("expected exit code 0 without -min-score, got %d:\n%s", code, output)
+	}
+	var report struct {
+		Score struct {
+			Score int `json:"score"`
+		} `json:"score"`
+	}
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("invalid JSON report: %v\n%s", err, output)
+	}
+	score := report.Score.Score
+	if score == 0 || score == 100 {
+		t.Fatalf("expected a score between 0 and 100, got %d", score)
+	}
+
+	// Meeting the minimum passes silently
+	output, code = run("-min-score", fmt.Sprint(score), ".")
+	if code != 0 || strings.Contains(output, "below the required minimum") {
+		t.Errorf("expected -min-score %d to pass, got %d:\n%s", score, code, output)
+	}
+
+	output, code = run("-min-score", fmt.Sprint(score+1), ".")
+	if code != 1 {
+		t.Errorf("expected exit code 1 below the minimum, got %d", code)
+	}
+	want := fmt.Sprintf("Conformance score %d is below the required minimum of %d", score, score+1)
+	if !strings.Contains(output, want) {
+		t.Errorf("expected %q, got:\n%s", want, output)
+	}
+
+	output, code = run("-format=json", "-min-score", fmt.Sprint(score+1), ".")
+	if code != 1 || !strings.Contains(output, `"failed": true`) {
+		t.Errorf("expected a failed JSON report, got %d:\n%s", code, output)
+	}
+
+	if _, code := run("-min-score", fmt.Sprint(score+1), "-exit-zero", "."); code != 0 {
+		t.Errorf("expected -exit-zero to override -min-score, got exit code %d", code)
+	}
+}
+
+func TestCLI_CI(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":              "module github.com/test/project\n\ngo 1.21\n\nrequire github.com/ourorg/billing v1.0.0\n\nreplace github.com/ourorg/billing => ../billing\n",
+		".goarchlint":         "rules:\n  directories_import:\n    internal: []\n  replace_directives:\n    enabled: true\nscan_paths:\n  - internal\n",
+		"internal/app/app.go": "package app\n\nfunc Run() {}\n",
+	})
+
+	tests := []struct {
+		name     string
+		ci       string // value of the CI environment variable
+		args     []string
+		severity string
+		exitCode int
+	}{
+		{"local run", "", nil, "[WARNING]", 0},
+		{"ci flag", "", []string{"-ci"}, "[ERROR]", 1},
+		{"ci environment", "true", nil, "[ERROR]", 1},
+		{"ci environment off", "false", nil, "[WARNING]", 0},
+		{"flag overrides environment", "true", []string{"-ci=false"}, "[WARNING]", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binaryPath, append(tt.args, ".")...)
+			cmd.Dir = tmpDir
+			cmd.Env = append(os.Environ(), "CI="+tt.ci)
+			output, _ := cmd.CombinedOutput()
+
+			if code := cmd.ProcessState.ExitCode(); code != tt.exitCode {
+				t.Errorf("expected exit code %d, got %d:\n%s", tt.exitCode, code, output)
+			}
+			if !strings.Contains(string(output), tt.severity+" Local or Forked Module Replace") {
+				t.Errorf("expected the replace reported as %s, got:\n%s", tt.severity, output)
+			}
+			// Outside CI the rule says how to make it fail
+			hint := strings.Contains(string(output), "(fails the build with -ci or when CI is set)")
+			if hint != (tt.exitCode == 0) {
+				t.Errorf("unexpected CI hint in output:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestCLI_Cache(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":      "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint": "rules:\n  directories_import:\n    pkg: []\nscan_paths:\n  - pkg\n",
+		"pkg/a/a.go":  "package a\n\nimport \"github.com/test/project/pkg/b\"\n\nfunc A() { b.B() }\n",
+		"pkg/b/b.go":  "package b\n\nfunc B() {}\n",
+	})
+	runGit(t, tmpDir, "init", "-q")
+	runGit(t, tmpDir, "add", "-A")
+	runGit(t, tmpDir, "commit", "-q", "-m", "first")
+	cacheDir := t.TempDir()
+
+	run := func() (string, string, int) {
+		t.Helper()
+		cmd := exec.Command(binaryPath, "-cache-dir", cacheDir, ".")
+		cmd.Dir = tmpDir
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		cmd.Run()
+		return stdout.String(), stderr.String(), cmd.ProcessState.ExitCode()
+	}
+	cached := func() int {
+		t.Helper()
+		entries, err := os.ReadDir(cacheDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(entries)
+	}
+	const hit = "Using the cached result of commit"
+
+	stdout, stderr, code := run()
+	if code != 1 || strings.Contains(stderr, hit) {
+		t.Fatalf("expected a fresh failing run, got %d:\n%s%s", code, stdout, stderr)
+	}
+	if cached() != 1 {
+		t.Fatalf("expected the result cached, got %d entries", cached())
+	}
+
+	// The second run returns the cached result, including the exit code
+	stdout2, stderr2, code2 := run()
+	if !strings.Contains(stderr2, hit) {
+		t.Errorf("expected a cache hit, got:\n%s", stderr2)
+	}
+	if code2 != code || stdout2 != stdout || !strings.Contains(stderr2, strings.TrimSpace(stderr)) {
+		t.Errorf("expected the cached result to match the first run, got %d:\n%s%s", code2, stdout2, stderr2)
+	}
+
+	// A dirty working tree always runs and is not cached
+	if err := os.WriteFile(filepath.Join(tmpDir, "pkg", "b", "c.go"), []byte("package b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, stderr, _ := run(); strings.Contains(stderr, hit) || cached() != 1 {
+		t.Errorf("expected an uncached run on a dirty tree, got %d entries:\n%s", cached(), stderr)
+	}
+	runGit(t, tmpDir, "add", "-A")
+	runGit(t, tmpDir, "commit", "-q", "-m", "second")
+
+	// A config change is a new commit and a new effective config: the cached failure
+	// does not apply
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte("rules:\n  directories_import:\n    pkg: [pkg]\nscan_paths:\n  - pkg\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, tmpDir, "commit", "-q", "-am", "allow pkg imports")
+	stdout, stderr, code = run()
+	if code != 0 || strings.Contains(stderr, hit) {
+		t.Errorf("expected a fresh passing run after the config change, got %d:\n%s%s", code, stdout, stderr)
+	}
+	if cached() != 2 {
+		t.Errorf("expected a second cache entry, got %d", cached())
+	}
+}
+
+func TestCLI_Mode(t *testing.T) {
+	const (
+		rules   = "rules:\n  directories_import:\n    internal: []\n  orphan_interfaces:\n    layers:\n      - internal/ports\nscan_paths:\n  - internal\n"
+		ports   = "package ports\n\ntype Notifier interface {\n\tNotify(msg string) error\n}\n"
+		finding = "Interface ports.Notifier is not implemented by any type in the codebase"
+		skipped = "Fast mode: skipping type_checks (run with -mode=full to include them)"
+	)
+
+	tests := []struct {
+		name    string
+		config  string
+		args    []string
+		finding bool
+		skipped bool
+	}{
+		{"fast by default", rules + "modes:\n  full: [type_checks]\n", nil, false, true},
+		{"fast", rules + "modes:\n  full: [type_checks]\n", []string{"-mode=fast"}, false, true},
+		{"full", rules + "modes:\n  full: [type_checks]\n", []string{"-mode=full"}, true, false},
+		{"quiet fast", rules + "modes:\n  full: [type_checks]\n", []string{"-quiet"}, false, false},
+		{"no modes section", rules, nil, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := writeProject(t, map[string]string{
+				"go.mod":                  "module github.com/test/project\n\ngo 1.21\n",
+				".goarchlint":             tt.config,
+				"internal/ports/ports.go": ports,
+			})
+			cmd := exec.Command(binaryPath, append(tt.args, ".")...)
+			cmd.Dir = tmpDir
+			output, _ := cmd.CombinedOutput()
+
+			// Orphan interfaces are informational, so every run passes
+			if code := cmd.ProcessState.ExitCode(); code != 0 {
+				t.Errorf("expected exit code 0, got %d:\n%s", code, output)
+			}
+			if strings.Contains(string(output), finding) != tt.finding {
+				t.Errorf("expected orphan interface reported: %v, got:\n%s", tt.finding, output)
+			}
+			if strings.Contains(string(output), skipped) != tt.skipped {
+				t.Errorf("expected skipped checks named: %v, got:\n%s", tt.skipped, output)
+			}
+		})
+	}
+
+	errorTests := []struct {
+		name   string
+		config string
+		args   []string
+		want   string
+	}{
+		{"unknown mode", rules, []string{"-mode=slow"}, `unknown mode "slow" (available: fast, full)`},
+		{"unknown check", rules + "modes:\n  full: [type_checks, bogus]\n", nil, `unknown check "bogus" in full`},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := writeProject(t, map[string]string{
+				"go.mod":                  "module github.com/test/project\n\ngo 1.21\n",
+				".goarchlint":             tt.config,
+				"internal/ports/ports.go": ports,
+			})
+			cmd := exec.Command(binaryPath, append(tt.args, ".")...)
+			cmd.Dir = tmpDir
+			output, _ := cmd.CombinedOutput()
+
+			if code := cmd.ProcessState.ExitCode(); code != 2 {
+				t.Errorf("expected exit code 2, got %d", code)
+			}
+			if !strings.Contains(string(output), tt.want) {
+				t.Errorf("expected %q, got:\n%s", tt.want, output)
+			}
+		})
+	}
+}
+
+func TestCLI_FormatPackageJSON(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":                  "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint":             "rules:\n  directories_import:\n    internal/app: []\n    internal/order: []\nscan_paths:\n  - internal\n",
+		"internal/order/order.go": "// Package order manages orders.\npackage order\n\nimport \"fmt\"\n\n// Order is a customer order\ntype Order struct {\n\tID string\n}\n\n// Place places an order\nfunc Place(id string) *Order {\n\tfmt.Println(id)\n\treturn &Order{ID: id}\n}\n",
+		"internal/app/app.go":     "package app\n\nimport \"github.com/test/project/internal/order\"\n\nfunc Run() { order.Place(\"1\") }\n",
+	})
+
+	type packageDoc struct {
+		Package     string   `json:"package"`
+		Path        string   `json:"path"`
+		Description string   `json:"description"`
+		Files       []string `json:"files"`
+		Exports     []struct {
+			Name       string   `json:"name"`
+			Kind       string   `json:"kind"`
+			Signature  string   `json:"signature"`
+			Doc        string   `json:"doc"`
+			Properties []string `json:"properties"`
+		} `json:"exports"`
+		Dependencies []struct {
+			ImportPath string `json:"import_path"`
+			Local      bool   `json:"local"`
+			Path       string `json:"path"`
+		} `json:"dependencies"`
+		Dependents []string `json:"dependents"`
+		Violations []struct {
+			Type string `json:"type"`
+			File string `json:"file"`
+			Line int    `json:"line"`
+		} `json:"violations"`
+	}
+
+	run := func(dir string) (packageDoc, string, int) {
+		t.Helper()
+		cmd := exec.Command(binaryPath, "-format=package-json", dir)
+		cmd.Dir = tmpDir
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		cmd.Run()
+		var doc packageDoc
+		if err := json.Unmarshal(stdout.Bytes(), &doc); err != nil {
+			t.Fatalf("invalid JSON for %s: %v\n%s%s", dir, err, stdout.String(), stderr.String())
+		}
+		return doc, stdout.String(), cmd.ProcessState.ExitCode()
+	}
+
+	// The exit code follows the whole run, like without -format: internal/app fails it
+	doc, output, code := run("internal/order")
+	if code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if doc.Package != "order" || doc.Path != "internal/order" || doc.Description != "Package order manages orders." {
+		t.Errorf("unexpected package header: %s", output)
+	}
+	if len(doc.Files) != 1 || doc.Files[0] != "internal/order/order.go" {
+		t.Errorf("unexpected files: %v", doc.Files)
+	}
+	if len(doc.Exports) != 2 ||
+		doc.Exports[0].Name != "Order" || doc.Exports[0].Kind != "type" || len(doc.Exports[0].Properties) != 1 ||
+		doc.Exports[1].Signature != "Place(string) *Order" || doc.Exports[1].Doc != "Place places an order" {
+		t.Errorf("unexpected exports: %s", output)
+	}
+	if len(doc.Dependencies) != 1 || doc.Dependencies[0].ImportPath != "fmt" || doc.Dependencies[0].Local {
+		t.Errorf("unexpected dependencies: %s", output)
+	}
+	if len(doc.Dependents) != 1 || doc.Dependents[0] != "internal/app" {
+		t.Errorf("expected internal/app as dependent, got %v", doc.Dependents)
+	}
+	// Lists are empty arrays rather than null
+	if !strings.Contains(output, `"violations": []`) {
+		t.Errorf("expected an empty violations array, got:\n%s", output)
+	}
+
+	// Only the violations of the package's files are listed
+	doc, output, code = run("internal/app")
+	if code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if len(doc.Dependencies) != 1 || !doc.Dependencies[0].Local || doc.Dependencies[0].Path != "internal/order" {
+		t.Errorf("expected the local dependency on internal/order, got:\n%s", output)
+	}
+	if len(doc.Violations) != 1 || doc.Violations[0].Type != "Forbidden Import" || doc.Violations[0].File != "internal/app/app.go" || doc.Violations[0].Line != 3 {
+		t.Errorf("expected the forbidden import of internal/app, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"dependents": []`) {
+		t.Errorf("expected an empty dependents array, got:\n%s", output)
+	}
+
+	cmd := exec.Command(binaryPath, "-format=package-json", "internal/missing")
+	cmd.Dir = tmpDir
+	errOutput, _ := cmd.CombinedOutput()
+	if code := cmd.ProcessState.ExitCode(); code != 2 || !strings.Contains(string(errOutput), "no files found in package: internal/missing") {
+		t.Errorf("expected exit code 2 for a missing package, got %d:\n%s", code, errOutput)
+	}
+}
+
+func TestCLI_FormatInventory(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":                  "module github.com/test/project\n\ngo 1.21\n\nrequire (\n\tgithub.com/jackc/pgx/v5 v5.5.0\n\tgithub.com/google/uuid v1.6.0\n)\n",
+		".goarchlint":             "rules:\n  directories_import:\n    cmd: [internal]\n    internal: [internal]\nscan_paths:\n  - cmd\n  - internal\n",
+		"internal/store/store.go": "package store\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/google/uuid\"\n\t\"github.com/jackc/pgx/v5\"\n\t\"github.com/jackc/pgx/v5/pgconn\"\n)\n\nvar _ pgx.Tx\nvar _ pgconn.PgConn\nvar _ = uuid.New\nvar _ = fmt.Sprint\n",
+		"internal/app/app.go":     "package app\n\nimport \"github.com/google/uuid\"\n\nvar _ = uuid.New\n",
+		// Not required in go.mod: listed by import path without a version
+		"cmd/app/main.go": "package main\n\nimport \"github.com/spf13/cobra\"\n\nvar _ cobra.Command\n\nfunc main() {}\n",
+	})
+
+	cmd := exec.Command(binaryPath, "-format=inventory", ".")
+	cmd.Dir = tmpDir
+	output, _ := cmd.CombinedOutput()
+
+	if code := cmd.ProcessState.ExitCode(); code != 0 {
+		t.Fatalf("expected exit code 0, got %d:\n%s", code, output)
+	}
+
+	// Subpackages are grouped under their module; the standard library is left out
+	for _, want := range []string{
+		"# Dependency Inventory",
+		"3 external modules imported by 3 packages.",
+		"| `github.com/google/uuid` | v1.6.0 | internal | 2 | 2 | 1 |",
+		"| `github.com/jackc/pgx/v5` | v5.5.0 | internal | 1 | 1 | 2 |",
+		"| `github.com/spf13/cobra` | - | cmd | 1 | 1 | 1 |",
+		"### `github.com/jackc/pgx/v5` v5.5.0\n\n- `internal/store` (internal, 1 file): `github.com/jackc/pgx/v5`, `github.com/jackc/pgx/v5/pgconn`",
+		"- `internal/app` (internal, 1 file): `github.com/google/uuid`",
+		"- `cmd/app` (cmd, 1 file): `github.com/spf13/cobra`",
+	} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected %q in inventory, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(string(output), "`fmt`") {
+		t.Errorf("expected the standard library left out, got:\n%s", output)
+	}
+}
+
+func TestCLI_FormatSymbolsIndex(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod":                  "module github.com/acme/app\n\ngo 1.21\n",
+		".goarchlint":             "rules:\n  directories_import:\n    pkg: []\n    internal: [pkg]\nscan_paths:\n  - pkg\n  - internal\n",
+		"pkg/cache/cache.go":      "package cache\n\n// MaxSize is the default capacity. It is large.\nconst MaxSize = 10\n\n// Cache stores values.\ntype Cache struct{}\n\n// Get returns a value.\nfunc (c *Cache) Get(key string) (string, bool) { return \"\", false }\n\n// Store is a cache backend\ntype Store interface{ Load() }\n\nvar Default = &Cache{}\n\nfunc helper() {}\n",
+		"pkg/cache/cache_test.go": "package cache\n\nfunc TestOnly() {}\n",
+		"internal/app/app.go":     "package app\n\nfunc Run() {}\n",
+	})
+
+	cmd := exec.Command(binaryPath, "-format=symbols-index", ".")
+	cmd.Dir = tmpDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	cmd.Run()
+
+	if code := cmd.ProcessState.ExitCode(); code != 0 {
+		t.Fatalf("expected exit code 0, got %d:\n%s%s", code, stdout.String(), stderr.String())
+	}
+
+	type symbol struct {
+		ID        string `json:"id"`
+		Package   string `json:"package"`
+		Name      string `json:"name"`
+		Kind      string `json:"kind"`
+		TypeKind  string `json:"type_kind"`
+		Signature string `json:"signature"`
+		Doc       string `json:"doc"`
+		File      string `json:"file"`
+		Line      int    `json:"line"`
+	}
+	var symbols []symbol
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		var s symbol
+		if err := json.Unmarshal([]byte(line), &s); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		symbols = append(symbols, s)
+	}
+
+	// Sorted by package, file and line; unexported and test-only symbols are left out
+	expected := []symbol{
+		{"github.com/acme/app/internal/app.Run", "github.com/acme/app/internal/app", "Run", "func", "", "Run()", "", "internal/app/app.go", 3},
+		{"github.com/acme/app/pkg/cache.MaxSize", "github.com/acme/app/pkg/cache", "MaxSize", "const", "", "MaxSize", "MaxSize is the default capacity.", "pkg/cache/cache.go", 4},
+		{"github.com/acme/app/pkg/cache.Cache", "github.com/acme/app/pkg/cache", "Cache", "type", "struct", "Cache", "Cache stores values.", "pkg/cache/cache.go", 7},
+		{"github.com/acme/app/pkg/cache.Cache.Get", "github.com/acme/app/pkg/cache", "Cache.Get", "func", "", "(*Cache) Get(string) (string, bool)", "Get returns a value.", "pkg/cache/cache.go", 10},
+		{"github.com/acme/app/pkg/cache.Store", "github.com/acme/app/pkg/cache", "Store", "type", "interface", "Store", "Store is a cache backend", "pkg/cache/cache.go", 13},
+		{"github.com/acme/app/pkg/cache.Default", "github.com/acme/app/pkg/cache", "Default", "var", "", "Default", "", "pkg/cache/cache.go", 15},
+	}
+	if len(symbols) != len(expected) {
+		t.Fatalf("expected %d symbols, got %d:\n%s", len(expected), len(symbols), stdout.String())
+	}
+	for i, want := range expected {
+		if symbols[i] != want {
+			t.Errorf("symbol %d: expected %+v, got %+v", i, want, symbols[i])
+		}
+	}
+}
@@ -4,19 +4,39 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// SchemaVersion is the newest .goarchlint schema version this build understands.
+// It is bumped whenever the meaning of existing keys changes.
+const SchemaVersion = 1
+
 type Config struct {
+	Version     int                 `yaml:"version,omitempty"` // Schema version the file was written for (0: unversioned)
 	Module      string              `yaml:"module"`
-	ScanPaths   []string            `yaml:"scan_paths,omitempty"`
+	ScanPaths   []ScanPath          `yaml:"scan_paths,omitempty"`
 	IgnorePaths []string            `yaml:"ignore_paths,omitempty"`
+	Docs        Docs                `yaml:"docs,omitempty"`
+	SourceLinks SourceLinks         `yaml:"source_links,omitempty"`
+	BuildMatrix []BuildTarget       `yaml:"build_matrix,omitempty"`
+	CheckUpdate bool                `yaml:"check_update,omitempty"` // Check for a newer release on every run
+	RulesFrom   *RulesFrom          `yaml:"rules_from,omitempty"`   // Shared rule bundle applied between the preset and the overrides
+	Layers      map[string][]string `yaml:"layers,omitempty"`       // Named layers: name -> directories, usable in rules in place of the directories
+	Modes       Modes               `yaml:"modes,omitempty"`        // Expensive checks reserved for --mode=full
 
 	// New format: preset + overrides
 	Preset    *PresetSection    `yaml:"preset,omitempty"`
 	Overrides *OverridesSection `yaml:"overrides,omitempty"`
 
+	// Named profiles: additional overrides selected per run (e.g. --profile strict)
+	Profiles map[string]OverridesSection `yaml:"profiles,omitempty"`
+
 	// Old format (backward compatibility): flat structure
 	Structure   Structure           `yaml:"structure,omitempty"`
 	Rules       Rules               `yaml:"rules,omitempty"`
@@ -25,6 +45,53 @@ type Config struct {
 
 	// Internal: merged result (populated after loading)
 	merged *mergedConfig
+
+	// Internal: rule provenance (populated after loading)
+	overrideKeys []string            // Dotted paths of all values set in the overrides section
+	isDefault    bool                // No .goarchlint file, built-in defaults are used
+	keySources   map[string]string   // Source of top-level values not read from .goarchlint
+	profileKeys  map[string][]string // Dotted paths of all values set in each profile
+	profile      string              // Selected profile (empty: none)
+	mode         string              // Selected run mode (empty: fast)
+	warnings     []string            // Problems found while loading that do not prevent it
+	bundle       *OverridesSection   // Rules of the rules_from bundle
+	bundleKeys   []string            // Dotted paths of all values set in the rules_from bundle
+	lines        map[string]int      // "dotted.key=value" -> line in .goarchlint, to locate suppressions
+}
+
+// ScanPath is a directory to scan, optionally forming a separate module root.
+// In YAML it is either a plain path or a mapping with path and module keys.
+type ScanPath struct {
+	Path   string `yaml:"path"`
+	Module string `yaml:"module,omitempty"` // Module of a nested go.mod (empty: project module)
+}
+
+// UnmarshalYAML accepts both "services/billing" and {path: services/billing, module: ...}
+func (sp *ScanPath) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		sp.Path = value.Value
+		return nil
+	}
+
+	type rawScanPath ScanPath
+	var raw rawScanPath
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw.Path == "" {
+		return fmt.Errorf("line %d: scan_paths entry requires a path", value.Line)
+	}
+	*sp = ScanPath(raw)
+	return nil
+}
+
+// MarshalYAML writes plain paths as scalars to keep configs compact
+func (sp ScanPath) MarshalYAML() (interface{}, error) {
+	if sp.Module == "" {
+		return sp.Path, nil
+	}
+	type rawScanPath ScanPath
+	return rawScanPath(sp), nil
 }
 
 // PresetSection contains the preset configuration
@@ -60,9 +127,47 @@ type ErrorPrompt struct {
 	BlackboxTestingGuidance  string   `yaml:"blackbox_testing_guidance,omitempty"`
 }
 
+// Docs configures generated documentation
+type Docs struct {
+	TemplatesDir         string `yaml:"templates_dir,omitempty"`          // Directory with index.md.tmpl / full.md.tmpl layouts (relative to the project root)
+	InlinePackageDetails bool   `yaml:"inline_package_details,omitempty"` // Embed the documentation of each package in the index under a collapsible section
+}
+
+// SourceLinks configures links from violations to the offending source lines
+type SourceLinks struct {
+	URL string `yaml:"url,omitempty"` // Template with {ref}, {file} and {line}, e.g. https://github.com/org/repo/blob/{ref}/{file}#L{line}
+	Ref string `yaml:"ref,omitempty"` // Branch, tag or commit for {ref} (default: the current git commit)
+}
+
+// BuildTarget is a GOOS/GOARCH/tags combination checked by the build matrix mode.
+// Empty fields default to the host platform.
+type BuildTarget struct {
+	GOOS   string   `yaml:"goos,omitempty"`
+	GOARCH string   `yaml:"goarch,omitempty"`
+	Tags   []string `yaml:"tags,omitempty"`
+}
+
+// String describes the target as "goos/goarch" with its tags, e.g. "linux/amd64 with tags integration"
+func (bt BuildTarget) String() string {
+	goos, goarch := bt.GOOS, bt.GOARCH
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	if len(bt.Tags) == 0 {
+		return goos + "/" + goarch
+	}
+	return fmt.Sprintf("%s/%s with tags %s", goos, goarch, strings.Join(bt.Tags, ","))
+}
+
 type Structure struct {
 	RequiredDirectories    map[string]string `yaml:"required_directories"`
 	AllowOtherDirectories  bool              `yaml:"allow_other_directories"`
+	DomainLayers           []string          `yaml:"domain_layers,omitempty"` // Directories whose types form the docs glossary (default: directories named domain)
+	PortLayers             []string          `yaml:"port_layers,omitempty"`   // Directories whose interfaces are mapped to adapters in the docs (default: directories named ports or domain)
+	RequireReadme          []string          `yaml:"require_readme,omitempty"` // Directories that must have a README.md describing the layer
 }
 
 type SharedExternalImports struct {
@@ -78,21 +183,183 @@ type TestCoverage struct {
 	PackageThresholds map[string]float64 `yaml:"package_thresholds,omitempty"` // Hierarchical package thresholds
 }
 
+type TypeLeaks struct {
+	Layers    []string `yaml:"layers"`    // Directories whose exported signatures are checked
+	Forbidden []string `yaml:"forbidden"` // External modules or local directories that must not appear in them
+}
+
+type ConfigLoading struct {
+	Enabled  bool     `yaml:"enabled"`
+	Allowed  []string `yaml:"allowed,omitempty"`  // Directories allowed to load configuration (default: cmd)
+	Packages []string `yaml:"packages,omitempty"` // Additional configuration libraries to confine
+}
+
+type FrameworkLockIn struct {
+	Enabled bool     `yaml:"enabled"`
+	Allowed []string `yaml:"allowed,omitempty"` // Adapter/handler directories (default: any handler(s)/adapter(s) directory)
+	Types   []string `yaml:"types,omitempty"`   // Framework types as "import/path.Type" (default: gin, echo, fiber contexts)
+}
+
+type HiddenDependencies struct {
+	Enabled  bool     `yaml:"enabled"`
+	Register []string `yaml:"register,omitempty"` // Function/method names registering a string key (default: Register, MustRegister, Provide)
+	Lookup   []string `yaml:"lookup,omitempty"`   // Function/method names looking up a string key (default: Get, MustGet, Lookup, Resolve)
+}
+
+type LicensePolicy struct {
+	Layers     map[string][]string `yaml:"layers"`               // Directory subtree -> allowed license categories or SPDX identifiers
+	Exceptions []string            `yaml:"exceptions,omitempty"` // Modules allowed in every layer, e.g. after legal review
+}
+
+type ReplaceDirectives struct {
+	Enabled bool     `yaml:"enabled"`
+	Allowed []string `yaml:"allowed,omitempty"` // Replaced modules (or path.Match patterns) whose replaces are accepted
+}
+
+type ExternalImportDepth struct {
+	Layers map[string]map[string]int `yaml:"layers"` // Directory subtree -> external module -> path elements allowed below the module
+}
+
+type PackageNaming struct {
+	Enabled    bool     `yaml:"enabled"`
+	Exceptions []string `yaml:"exceptions,omitempty"` // Package names exempt from the directory name check (default: main)
+}
+
+type Deprecations struct {
+	Enabled bool   `yaml:"enabled"`
+	Base    string `yaml:"base,omitempty"` // Git revision new usages are detected against (default: HEAD)
+}
+
+type Ownership struct {
+	Teams     map[string]string `yaml:"teams"`               // Directory subtree -> owning team
+	Contracts []string          `yaml:"contracts,omitempty"` // Public contract packages, glob patterns with ** (default: **/api, **/contract)
+}
+
+type TestSetupImports struct {
+	Enabled bool     `yaml:"enabled"`
+	Helpers []string `yaml:"helpers,omitempty"` // Test helper packages besides those named *test*, fixtures or builders
+}
+
+type TestScope struct {
+	Enabled bool     `yaml:"enabled"`
+	Shared  []string `yaml:"shared,omitempty"` // File base names (e.g. "helpers.go") any test may reference
+}
+
+type PackageDocs struct {
+	Layers []string `yaml:"layers"` // Directories whose packages must have a package doc comment
+}
+
+type OrphanInterfaces struct {
+	Layers []string `yaml:"layers"` // Consumer directories whose interfaces need an implementation in the codebase
+}
+
+type AdapterPorts struct {
+	Adapters []string `yaml:"adapters"` // Directories whose packages must implement at least one port
+	Ports    []string `yaml:"ports"`    // Directories whose interfaces are the ports adapters implement
+	// Require a compile-time assertion (var _ port.X = (*Adapter)(nil)) in the adapter
+	// package for every port an adapter type implements
+	RequireAssertions bool `yaml:"require_assertions,omitempty"`
+}
+
 type Rules struct {
 	DirectoriesImport     map[string][]string   `yaml:"directories_import"`
+	DefaultPolicy         string                `yaml:"default_policy,omitempty"` // "allow" (default) or "deny": what directories without a directories_import rule may import
 	DetectUnused          bool                  `yaml:"detect_unused"`
 	SharedExternalImports SharedExternalImports `yaml:"shared_external_imports,omitempty"`
 	TestFiles             TestFiles             `yaml:"test_files,omitempty"`
 	TestCoverage          TestCoverage          `yaml:"test_coverage,omitempty"`
 	Staticcheck           bool                  `yaml:"staticcheck,omitempty"`
 	StrictTestNaming      bool                  `yaml:"strict_test_naming,omitempty"`
+	DetectDuplicates      bool                  `yaml:"detect_duplicates,omitempty"` // Report structs/const blocks copied across layers
+	WrapIn                map[string]string     `yaml:"wrap_in,omitempty"`           // External module -> local package that wraps it
+	TypeLeaks             TypeLeaks             `yaml:"type_leaks,omitempty"`
+	ConfigLoading         ConfigLoading         `yaml:"config_loading,omitempty"`
+	FrameworkLockIn       FrameworkLockIn       `yaml:"framework_lock_in,omitempty"`
+	PackageNaming         PackageNaming         `yaml:"package_naming,omitempty"`
+	EnforceStability      bool                  `yaml:"enforce_stability,omitempty"` // Forbid stable packages from importing experimental ones
+	Deprecations          Deprecations          `yaml:"deprecations,omitempty"`
+	Ownership             Ownership             `yaml:"ownership,omitempty"`
+	PackageDocs           PackageDocs           `yaml:"package_docs,omitempty"`
+	TestSetupImports      TestSetupImports      `yaml:"test_setup_imports,omitempty"`
+	TestScope             TestScope             `yaml:"test_scope,omitempty"` // With strict_test_naming, keep foo_test.go to the declarations of foo.go
+	MainPackages          []string              `yaml:"main_packages,omitempty"` // Directories or ** globs where main packages may live
+	OrphanInterfaces      OrphanInterfaces      `yaml:"orphan_interfaces,omitempty"`
+	AdapterPorts          AdapterPorts          `yaml:"adapter_ports,omitempty"`
+	HiddenDependencies    HiddenDependencies    `yaml:"hidden_dependencies,omitempty"`
+	ExternalImportDepth   ExternalImportDepth   `yaml:"external_import_depth,omitempty"`
+	LicensePolicy         LicensePolicy         `yaml:"license_policy,omitempty"`
+	DetectVersionSprawl   bool                  `yaml:"detect_version_sprawl,omitempty"` // Report external modules imported in several major versions
+	ReplaceDirectives     ReplaceDirectives     `yaml:"replace_directives,omitempty"`
+	ActiveFrom            map[string]string     `yaml:"active_from,omitempty"` // Rule key -> date (YYYY-MM-DD) from which it fails the build
+	RequireShuffleClean   []string              `yaml:"require_shuffle_clean,omitempty"` // Directories whose tests must pass with go test -shuffle=on
+	RequireRaceClean      []string              `yaml:"require_race_clean,omitempty"`    // Directories whose tests must pass with go test -race (full mode only)
 }
 
 type TestFiles struct {
 	Lint            bool     `yaml:"lint"`
-	ExemptImports   []string `yaml:"exempt_imports,omitempty"`
+	ExemptImports   ExemptImports `yaml:"exempt_imports,omitempty"`
 	Location        string   `yaml:"location,omitempty"`    // "colocated" (default), "separate", "any"
 	RequireBlackbox bool     `yaml:"require_blackbox"`      // Require blackbox tests (package foo_test)
+	RequireTestdata bool     `yaml:"require_testdata,omitempty"` // Require fixtures read by tests to live under testdata/
+	AllowWhitebox   []WhiteboxExemption `yaml:"allow_whitebox,omitempty"` // Packages exempt from require_blackbox
+	SupportPackages []string `yaml:"support_packages,omitempty"` // Blessed shared fixture packages only test files may import
+}
+
+// ExemptImports lists imports test files may use regardless of layer rules. In YAML it is
+// either one list for all test files or a map from directory pattern to list, e.g.
+// {tests: ["*"], internal/domain: [testing, github.com/stretchr/testify]}.
+type ExemptImports struct {
+	All   []string            // Imports exempt in directories without a matching pattern
+	ByDir map[string][]string // Directory or glob pattern with ** -> imports exempt there
+}
+
+// allTestDirs is the directory pattern that stands for the list of all test files
+const allTestDirs = "**"
+
+// UnmarshalYAML accepts both a list and a map from directory pattern to list
+func (e *ExemptImports) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		return value.Decode(&e.All)
+	}
+
+	var byDir map[string][]string
+	if err := value.Decode(&byDir); err != nil {
+		return fmt.Errorf("line %d: exempt_imports must be a list or a map from directory to list", value.Line)
+	}
+	if all, ok := byDir[allTestDirs]; ok {
+		e.All = all
+		delete(byDir, allTestDirs)
+	}
+	if len(byDir) > 0 {
+		e.ByDir = byDir
+	}
+	return nil
+}
+
+// MarshalYAML writes a plain list unless directory patterns are set
+func (e ExemptImports) MarshalYAML() (interface{}, error) {
+	if len(e.ByDir) == 0 {
+		return e.All, nil
+	}
+	byDir := make(map[string][]string, len(e.ByDir)+1)
+	for dir, imports := range e.ByDir {
+		byDir[dir] = imports
+	}
+	if len(e.All) > 0 {
+		byDir[allTestDirs] = e.All
+	}
+	return byDir, nil
+}
+
+// IsZero reports whether no exempt imports are set, for omitempty
+func (e ExemptImports) IsZero() bool {
+	return len(e.All) == 0 && len(e.ByDir) == 0
+}
+
+// WhiteboxExemption exempts test files from require_blackbox with a visible justification
+type WhiteboxExemption struct {
+	Path   string `yaml:"path"`   // Directory or glob pattern with ** (e.g. internal/algo/**)
+	Reason string `yaml:"reason"` // Why the package needs whitebox tests (required)
 }
 
 // getMerged returns the merged config (handles both old and new formats)
@@ -104,14 +371,21 @@ func (c *Config) getMerged() *mergedConfig {
 	// Initialize merged config
 	c.merged = &mergedConfig{}
 
-	// New format: merge preset + overrides
-	if c.Preset != nil {
-		c.merged.Structure = c.Preset.Structure
-		c.merged.Rules = c.Preset.Rules
-		c.merged.ErrorPrompt = c.Preset.ErrorPrompt
-		c.merged.PresetName = c.Preset.Name
+	// New format: merge preset + rules_from bundle + overrides
+	if c.Preset != nil || c.RulesFrom != nil {
+		if c.Preset != nil {
+			c.merged.Structure = c.Preset.Structure
+			c.merged.Rules = c.Preset.Rules
+			c.merged.ErrorPrompt = c.Preset.ErrorPrompt
+			c.merged.PresetName = c.Preset.Name
+		}
 
-		// Apply overrides
+		// Apply the shared bundle, then the project's own overrides
+		if c.bundle != nil {
+			c.merged.Structure = mergeStructure(c.merged.Structure, c.bundle.Structure)
+			c.merged.Rules = mergeRules(c.merged.Rules, c.bundle.Rules)
+			c.merged.ErrorPrompt = mergeErrorPrompt(c.merged.ErrorPrompt, c.bundle.ErrorPrompt)
+		}
 		if c.Overrides != nil {
 			c.merged.Structure = mergeStructure(c.merged.Structure, c.Overrides.Structure)
 			c.merged.Rules = mergeRules(c.merged.Rules, c.Overrides.Rules)
@@ -125,9 +399,63 @@ func (c *Config) getMerged() *mergedConfig {
 		c.merged.PresetName = c.PresetUsed
 	}
 
+	// Apply the selected profile on top
+	if profile, ok := c.Profiles[c.profile]; ok {
+		c.merged.Structure = mergeStructure(c.merged.Structure, profile.Structure)
+		c.merged.Rules = mergeRules(c.merged.Rules, profile.Rules)
+		c.merged.ErrorPrompt = mergeErrorPrompt(c.merged.ErrorPrompt, profile.ErrorPrompt)
+	}
+
 	return c.merged
 }
 
+// SelectProfile applies the named profile from the profiles section on top of the
+// preset and overrides. An empty name selects no profile.
+func (c *Config) SelectProfile(name string) error {
+	if name != "" {
+		if _, ok := c.Profiles[name]; !ok {
+			available := c.GetProfiles()
+			if len(available) == 0 {
+				return fmt.Errorf("unknown profile %q (no profiles defined)", name)
+			}
+			return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(available, ", "))
+		}
+	}
+	c.profile = name
+	c.merged = nil
+	return nil
+}
+
+// GetProfiles returns the names of the defined profiles in sorted order
+func (c *Config) GetProfiles() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetScanPaths returns the directories to scan relative to the project root
+func (c *Config) GetScanPaths() []string {
+	paths := make([]string, len(c.ScanPaths))
+	for i, sp := range c.ScanPaths {
+		paths[i] = sp.Path
+	}
+	return paths
+}
+
+// GetModuleRoots returns the module path of each scan path with its own go.mod (module -> directory)
+func (c *Config) GetModuleRoots() map[string]string {
+	roots := make(map[string]string)
+	for _, sp := range c.ScanPaths {
+		if sp.Module != "" {
+			roots[sp.Module] = filepath.ToSlash(filepath.Clean(sp.Path))
+		}
+	}
+	return roots
+}
+
 // GetDirectoriesImport implements validator.Config interface
 func (c *Config) GetDirectoriesImport() map[string][]string {
 	return c.getMerged().Rules.DirectoriesImport
@@ -148,6 +476,52 @@ func (c *Config) ShouldAllowOtherDirectories() bool {
 	return c.getMerged().Structure.AllowOtherDirectories
 }
 
+// GetDocsTemplatesDir returns the directory holding documentation templates (empty: built-in layout)
+func (c *Config) GetDocsTemplatesDir() string {
+	return c.Docs.TemplatesDir
+}
+
+// ShouldInlinePackageDetails reports whether the architecture index embeds the
+// documentation of each package instead of naming the command printing it
+func (c *Config) ShouldInlinePackageDetails() bool {
+	return c.Docs.InlinePackageDetails
+}
+
+// GetSourceLinkTemplate returns the source link template (empty: no links)
+func (c *Config) GetSourceLinkTemplate() string {
+	return c.SourceLinks.URL
+}
+
+// GetSourceLinkRef returns the ref substituted for {ref} in source links (empty: current commit)
+func (c *Config) GetSourceLinkRef() string {
+	return c.SourceLinks.Ref
+}
+
+// ShouldCheckUpdate returns whether every run checks for a newer go-arch-lint release
+func (c *Config) ShouldCheckUpdate() bool {
+	return c.CheckUpdate
+}
+
+// GetBuildMatrix returns the build targets checked by the build matrix mode
+func (c *Config) GetBuildMatrix() []BuildTarget {
+	return c.BuildMatrix
+}
+
+// GetReadmeDirectories implements validator.Config interface
+func (c *Config) GetReadmeDirectories() []string {
+	return c.getMerged().Structure.RequireReadme
+}
+
+// GetDomainLayers returns the directories holding the domain model
+func (c *Config) GetDomainLayers() []string {
+	return c.getMerged().Structure.DomainLayers
+}
+
+// GetPortLayers returns the directories whose interfaces are documented with their implementations
+func (c *Config) GetPortLayers() []string {
+	return c.getMerged().Structure.PortLayers
+}
+
 // GetPresetUsed returns the name of the preset used to create this config
 func (c *Config) GetPresetUsed() string {
 	return c.getMerged().PresetName
@@ -189,7 +563,26 @@ func (c *Config) ShouldLintTestFiles() bool {
 
 // GetTestExemptImports implements validator.Config interface
 func (c *Config) GetTestExemptImports() []string {
-	return c.getMerged().Rules.TestFiles.ExemptImports
+	return c.getMerged().Rules.TestFiles.ExemptImports.All
+}
+
+// GetTestExemptImportsByDir implements validator.Config interface
+func (c *Config) GetTestExemptImportsByDir() map[string][]string {
+	return c.getMerged().Rules.TestFiles.ExemptImports.ByDir
+}
+
+// GetTestSupportPackages implements validator.Config interface
+func (c *Config) GetTestSupportPackages() []string {
+	return c.getMerged().Rules.TestFiles.SupportPackages
+}
+
+// GetDefaultPolicy implements validator.Config interface
+func (c *Config) GetDefaultPolicy() string {
+	policy := c.getMerged().Rules.DefaultPolicy
+	if policy == "" {
+		return "allow" // Default: directories without a rule may import anything
+	}
+	return policy
 }
 
 // GetTestFileLocation implements validator.Config interface
@@ -206,6 +599,20 @@ func (c *Config) ShouldRequireBlackboxTests() bool {
 	return c.getMerged().Rules.TestFiles.RequireBlackbox
 }
 
+// GetWhiteboxExemptions implements validator.Config interface
+func (c *Config) GetWhiteboxExemptions() map[string]string {
+	exemptions := make(map[string]string)
+	for _, exemption := range c.getMerged().Rules.TestFiles.AllowWhitebox {
+		exemptions[exemption.Path] = exemption.Reason
+	}
+	return exemptions
+}
+
+// ShouldRequireTestdataFixtures implements validator.Config interface
+func (c *Config) ShouldRequireTestdataFixtures() bool {
+	return c.getMerged().Rules.TestFiles.RequireTestdata
+}
+
 // IsCoverageEnabled implements coverage.Config interface
 func (c *Config) IsCoverageEnabled() bool {
 	return c.getMerged().Rules.TestCoverage.Enabled
@@ -240,6 +647,443 @@ func (c *Config) ShouldEnforceStrictTestNaming() bool {
 	return c.getMerged().Rules.StrictTestNaming
 }
 
+// ShouldDetectDuplicates implements validator.Config interface
+func (c *Config) ShouldDetectDuplicates() bool {
+	return c.getMerged().Rules.DetectDuplicates
+}
+
+// GetWrapIn implements validator.Config interface
+func (c *Config) GetWrapIn() map[string]string {
+	return c.getMerged().Rules.WrapIn
+}
+
+// GetTypeLeakLayers implements validator.Config interface
+func (c *Config) GetTypeLeakLayers() []string {
+	return c.getMerged().Rules.TypeLeaks.Layers
+}
+
+// GetTypeLeakForbidden implements validator.Config interface
+func (c *Config) GetTypeLeakForbidden() []string {
+	return c.getMerged().Rules.TypeLeaks.Forbidden
+}
+
+// ShouldConfineConfigLoading implements validator.Config interface
+func (c *Config) ShouldConfineConfigLoading() bool {
+	return c.getMerged().Rules.ConfigLoading.Enabled
+}
+
+// GetConfigLoadingAllowed implements validator.Config interface
+func (c *Config) GetConfigLoadingAllowed() []string {
+	allowed := c.getMerged().Rules.ConfigLoading.Allowed
+	if len(allowed) == 0 {
+		return []string{"cmd"} // Default: only entry points read configuration
+	}
+	return allowed
+}
+
+// GetConfigLoadingPackages implements validator.Config interface
+func (c *Config) GetConfigLoadingPackages() []string {
+	return c.getMerged().Rules.ConfigLoading.Packages
+}
+
+// ShouldDetectFrameworkLockIn implements validator.Config interface
+func (c *Config) ShouldDetectFrameworkLockIn() bool {
+	return c.getMerged().Rules.FrameworkLockIn.Enabled
+}
+
+// ShouldDetectTestSetupImports implements validator.Config interface
+func (c *Config) ShouldDetectTestSetupImports() bool {
+	return c.getMerged().Rules.TestSetupImports.Enabled
+}
+
+// GetTestSetupHelpers implements validator.Config interface
+func (c *Config) GetTestSetupHelpers() []string {
+	return c.getMerged().Rules.TestSetupImports.Helpers
+}
+
+// GetMainPackageLocations implements validator.Config interface
+func (c *Config) GetMainPackageLocations() []string {
+	return c.getMerged().Rules.MainPackages
+}
+
+// ShouldEnforceTestScope implements validator.Config interface
+func (c *Config) ShouldEnforceTestScope() bool {
+	return c.getMerged().Rules.TestScope.Enabled
+}
+
+// GetTestScopeShared implements validator.Config interface
+func (c *Config) GetTestScopeShared() []string {
+	return c.getMerged().Rules.TestScope.Shared
+}
+
+// GetFrameworkLockInAllowed implements validator.Config interface
+func (c *Config) GetFrameworkLockInAllowed() []string {
+	return c.getMerged().Rules.FrameworkLockIn.Allowed
+}
+
+// GetFrameworkLockInTypes implements validator.Config interface
+func (c *Config) GetFrameworkLockInTypes() []string {
+	types := c.getMerged().Rules.FrameworkLockIn.Types
+	if len(types) == 0 {
+		// Default: request contexts of popular web frameworks
+		return []string{
+			"github.com/gin-gonic/gin.Context",
+			"github.com/labstack/echo.Context",
+			"github.com/labstack/echo/v4.Context",
+			"github.com/gofiber/fiber/v2.Ctx",
+			"github.com/gofiber/fiber/v3.Ctx",
+			"github.com/valyala/fasthttp.RequestCtx",
+		}
+	}
+	return types
+}
+
+// ShouldDetectHiddenDependencies implements validator.Config interface
+func (c *Config) ShouldDetectHiddenDependencies() bool {
+	return c.getMerged().Rules.HiddenDependencies.Enabled
+}
+
+// GetHiddenDependencyRegisterFuncs returns the function and method names that register a
+// string key in a registry
+func (c *Config) GetHiddenDependencyRegisterFuncs() []string {
+	funcs := c.getMerged().Rules.HiddenDependencies.Register
+	if len(funcs) == 0 {
+		return []string{"Register", "MustRegister", "Provide"}
+	}
+	return funcs
+}
+
+// GetHiddenDependencyLookupFuncs returns the function and method names that look up a
+// string key in a registry
+func (c *Config) GetHiddenDependencyLookupFuncs() []string {
+	funcs := c.getMerged().Rules.HiddenDependencies.Lookup
+	if len(funcs) == 0 {
+		return []string{"Get", "MustGet", "Lookup", "Resolve"}
+	}
+	return funcs
+}
+
+// ShouldCheckPackageNaming implements validator.Config interface
+func (c *Config) ShouldCheckPackageNaming() bool {
+	return c.getMerged().Rules.PackageNaming.Enabled
+}
+
+// GetPackageNamingExceptions implements validator.Config interface
+func (c *Config) GetPackageNamingExceptions() []string {
+	exceptions := c.getMerged().Rules.PackageNaming.Exceptions
+	if len(exceptions) == 0 {
+		return []string{"main"} // Default: commands live in directories named after the binary
+	}
+	return exceptions
+}
+
+// ShouldEnforceStability implements validator.Config interface
+func (c *Config) ShouldEnforceStability() bool {
+	return c.getMerged().Rules.EnforceStability
+}
+
+// ShouldDetectDeprecatedUsages implements validator.Config interface
+func (c *Config) ShouldDetectDeprecatedUsages() bool {
+	return c.getMerged().Rules.Deprecations.Enabled
+}
+
+// GetDeprecationsBase returns the git revision new usages of deprecated symbols are detected against
+func (c *Config) GetDeprecationsBase() string {
+	base := c.getMerged().Rules.Deprecations.Base
+	if base == "" {
+		return "HEAD" // Default: uncommitted changes
+	}
+	return base
+}
+
+// GetOwnershipTeams implements validator.Config interface
+func (c *Config) GetOwnershipTeams() map[string]string {
+	return c.getMerged().Rules.Ownership.Teams
+}
+
+// GetOwnershipContracts implements validator.Config interface
+func (c *Config) GetOwnershipContracts() []string {
+	contracts := c.getMerged().Rules.Ownership.Contracts
+	if len(contracts) == 0 {
+		return []string{"**/api", "**/contract"}
+	}
+	return contracts
+}
+
+// GetPackageDocLayers implements validator.Config interface
+func (c *Config) GetPackageDocLayers() []string {
+	return c.getMerged().Rules.PackageDocs.Layers
+}
+
+// GetOrphanInterfaceLayers implements validator.Config interface
+func (c *Config) GetOrphanInterfaceLayers() []string {
+	return c.getMerged().Rules.OrphanInterfaces.Layers
+}
+
+// GetAdapterLayers implements validator.Config interface
+func (c *Config) GetAdapterLayers() []string {
+	return c.getMerged().Rules.AdapterPorts.Adapters
+}
+
+// GetAdapterPortLayers implements validator.Config interface
+func (c *Config) GetAdapterPortLayers() []string {
+	return c.getMerged().Rules.AdapterPorts.Ports
+}
+
+// ShouldRequireAdapterAssertions implements validator.Config interface
+func (c *Config) ShouldRequireAdapterAssertions() bool {
+	return c.getMerged().Rules.AdapterPorts.RequireAssertions
+}
+
+// GetShuffleCleanDirectories implements validator.Config interface
+func (c *Config) GetShuffleCleanDirectories() []string {
+	return c.getMerged().Rules.RequireShuffleClean
+}
+
+// GetRaceCleanDirectories implements validator.Config interface
+func (c *Config) GetRaceCleanDirectories() []string {
+	return c.getMerged().Rules.RequireRaceClean
+}
+
+// ShouldDetectVersionSprawl implements validator.Config interface
+func (c *Config) ShouldDetectVersionSprawl() bool {
+	return c.getMerged().Rules.DetectVersionSprawl
+}
+
+// ShouldCheckReplaceDirectives implements validator.Config interface
+func (c *Config) ShouldCheckReplaceDirectives() bool {
+	return c.getMerged().Rules.ReplaceDirectives.Enabled
+}
+
+// GetReplaceDirectivesAllowed implements validator.Config interface
+func (c *Config) GetReplaceDirectivesAllowed() []string {
+	return c.getMerged().Rules.ReplaceDirectives.Allowed
+}
+
+// GetExternalImportDepthLayers implements validator.Config interface
+func (c *Config) GetExternalImportDepthLayers() map[string]map[string]int {
+	return c.getMerged().Rules.ExternalImportDepth.Layers
+}
+
+// GetLicensePolicyLayers implements validator.Config interface
+func (c *Config) GetLicensePolicyLayers() map[string][]string {
+	return c.getMerged().Rules.LicensePolicy.Layers
+}
+
+// GetLicensePolicyExceptions implements validator.Config interface
+func (c *Config) GetLicensePolicyExceptions() []string {
+	return c.getMerged().Rules.LicensePolicy.Exceptions
+}
+
+// GetRuleSource returns the configuration layer that defines the rule with the given
+// dotted key (e.g. "rules.directories_import.cmd"): "profile <name>", "overrides",
+// "rules_from <source>", "preset <name>", ".goarchlint" or "defaults". An empty key
+// denotes a hardcoded rule ("built-in").
+func (c *Config) GetRuleSource(key string) string {
+	if key == "" {
+		return "built-in"
+	}
+	if c.isDefault {
+		return "defaults"
+	}
+	if c.profile != "" && keySet(c.profileKeys[c.profile], key) {
+		return "profile " + c.profile
+	}
+	if c.Preset == nil && c.RulesFrom == nil {
+		return ".goarchlint"
+	}
+	if keySet(c.overrideKeys, key) {
+		return "overrides"
+	}
+	if c.RulesFrom != nil && keySet(c.bundleKeys, key) {
+		return "rules_from " + c.RulesFrom.Source
+	}
+	if c.Preset == nil {
+		return "defaults"
+	}
+	if c.Preset.Name == "" {
+		return "preset"
+	}
+	return "preset " + c.Preset.Name
+}
+
+// GetRuleActivation returns the date from which the rule with the given dotted key
+// (e.g. "rules.directories_import.cmd") fails the build, as set in rules.active_from.
+// The most specific configured key wins, so "rules.directories_import" covers all of
+// its directories. It reports false if the rule has no activation date.
+func (c *Config) GetRuleActivation(key string) (time.Time, bool) {
+	if key == "" {
+		return time.Time{}, false
+	}
+	best := ""
+	for configured := range c.getMerged().Rules.ActiveFrom {
+		if (configured == key || strings.HasPrefix(key, configured+".")) && len(configured) > len(best) {
+			best = configured
+		}
+	}
+	if best == "" {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(activeFromLayout, c.getMerged().Rules.ActiveFrom[best])
+	if err != nil {
+		return time.Time{}, false // Rejected by Load
+	}
+	return date, true
+}
+
+// activeFromLayout is the date format of rules.active_from
+const activeFromLayout = "2006-01-02"
+
+// ruleLayers returns the rules of every layer: flat, preset, rules_from bundle, overrides
+// and profiles
+func (c *Config) ruleLayers() []Rules {
+	layers := []Rules{c.Rules}
+	if c.Preset != nil {
+		layers = append(layers, c.Preset.Rules)
+	}
+	if c.bundle != nil && c.bundle.Rules != nil {
+		layers = append(layers, *c.bundle.Rules)
+	}
+	if c.Overrides != nil && c.Overrides.Rules != nil {
+		layers = append(layers, *c.Overrides.Rules)
+	}
+	for _, name := range c.GetProfiles() {
+		if profile := c.Profiles[name]; profile.Rules != nil {
+			layers = append(layers, *profile.Rules)
+		}
+	}
+	return layers
+}
+
+// validateActiveFrom checks the dates of rules.active_from in every layer
+func (c *Config) validateActiveFrom() error {
+	for _, rules := range c.ruleLayers() {
+		keys := make([]string, 0, len(rules.ActiveFrom))
+		for key := range rules.ActiveFrom {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, err := time.Parse(activeFromLayout, rules.ActiveFrom[key]); err != nil {
+				return fmt.Errorf("rules.active_from: invalid date %q for %s (expected YYYY-MM-DD)", rules.ActiveFrom[key], key)
+			}
+		}
+	}
+	return nil
+}
+
+// checkSchemaVersion rejects configs written for a newer schema, whose keys this build
+// could silently misinterpret, and warns about configs written for an older one
+func (c *Config) checkSchemaVersion() error {
+	switch {
+	case c.Version < 0:
+		return fmt.Errorf("version must be a positive schema version, got %d", c.Version)
+	case c.Version > SchemaVersion:
+		return fmt.Errorf("config schema version %d is newer than this go-arch-lint supports (%d); upgrade with 'go install github.com/kgatilin/go-arch-lint/cmd/go-arch-lint@latest'", c.Version, SchemaVersion)
+	case c.Version > 0 && c.Version < SchemaVersion:
+		c.warnings = append(c.warnings, fmt.Sprintf("config schema version %d is older than the current version %d; review the changed rules in the release notes, then set 'version: %d'", c.Version, SchemaVersion, SchemaVersion))
+	}
+	return nil
+}
+
+// GetWarnings returns problems found while loading the config that do not prevent its use
+func (c *Config) GetWarnings() []string {
+	return c.warnings
+}
+
+// validateDefaultPolicy checks rules.default_policy in every layer, so a misspelled
+// policy does not silently leave unknown directories open
+func (c *Config) validateDefaultPolicy() error {
+	for _, rules := range c.ruleLayers() {
+		if policy := rules.DefaultPolicy; policy != "" && policy != "allow" && policy != "deny" {
+			return fmt.Errorf("rules.default_policy: invalid policy %q (expected allow or deny)", policy)
+		}
+	}
+	return nil
+}
+
+// validateAllowWhitebox checks that every whitebox exemption in every layer has a
+// path and a reason, so exemptions stay justified
+func (c *Config) validateAllowWhitebox() error {
+	for _, rules := range c.ruleLayers() {
+		for _, exemption := range rules.TestFiles.AllowWhitebox {
+			if exemption.Path == "" {
+				return fmt.Errorf("rules.test_files.allow_whitebox: entry without path (reason %q)", exemption.Reason)
+			}
+			if strings.TrimSpace(exemption.Reason) == "" {
+				return fmt.Errorf("rules.test_files.allow_whitebox: %s needs a reason", exemption.Path)
+			}
+		}
+	}
+	return nil
+}
+
+// validateExternalImportDepth checks that the depths of rules.external_import_depth in
+// every layer are not negative
+func (c *Config) validateExternalImportDepth() error {
+	for _, rules := range c.ruleLayers() {
+		layers := make([]string, 0, len(rules.ExternalImportDepth.Layers))
+		for layer := range rules.ExternalImportDepth.Layers {
+			layers = append(layers, layer)
+		}
+		sort.Strings(layers)
+		for _, layer := range layers {
+			for module, depth := range rules.ExternalImportDepth.Layers[layer] {
+				if depth < 0 {
+					return fmt.Errorf("rules.external_import_depth: negative depth %d for %s in %s", depth, module, layer)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// keySet reports whether the value at key, or a value inside it, is among the set keys
+func keySet(setKeys []string, key string) bool {
+	for _, set := range setKeys {
+		if set == key || strings.HasPrefix(key, set+".") || strings.HasPrefix(set, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenKeys returns the dotted paths of all values set in a YAML mapping. Nested
+// mappings are descended into, so only the keys that hold values are returned.
+func flattenKeys(prefix string, m map[string]interface{}) []string {
+	var keys []string
+	for k, v := range m {
+		key := prefix + k
+		if nested, ok := v.(map[string]interface{}); ok && len(nested) > 0 {
+			keys = append(keys, flattenKeys(key+".", nested)...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mergeWhiteboxExemptions appends override exemptions to the base ones. An override
+// for a path that is already exempt replaces its reason.
+func mergeWhiteboxExemptions(base, override []WhiteboxExemption) []WhiteboxExemption {
+	result := make([]WhiteboxExemption, len(base))
+	copy(result, base)
+	for _, exemption := range override {
+		replaced := false
+		for i := range result {
+			if result[i].Path == exemption.Path {
+				result[i].Reason = exemption.Reason
+				replaced = true
+			}
+		}
+		if !replaced {
+			result = append(result, exemption)
+		}
+	}
+	return result
+}
+
 // mergeStringSlices merges two string slices, avoiding duplicates
 func mergeStringSlices(base, override []string) []string {
 	// Create a set of existing items
@@ -290,6 +1134,21 @@ func mergeStructure(base Structure, override *Structure) Structure {
 		result.AllowOtherDirectories = true
 	}
 
+	// Merge domain_layers (additive)
+	if override.DomainLayers != nil {
+		result.DomainLayers = mergeStringSlices(result.DomainLayers, override.DomainLayers)
+	}
+
+	// Merge port_layers (additive)
+	if override.PortLayers != nil {
+		result.PortLayers = mergeStringSlices(result.PortLayers, override.PortLayers)
+	}
+
+	// Merge require_readme (additive)
+	if override.RequireReadme != nil {
+		result.RequireReadme = mergeStringSlices(result.RequireReadme, override.RequireReadme)
+	}
+
 	return result
 }
 
@@ -311,6 +1170,34 @@ func mergeRules(base Rules, override *Rules) Rules {
 		}
 	}
 
+	if override.DefaultPolicy != "" {
+		result.DefaultPolicy = override.DefaultPolicy
+	}
+
+	// Merge wrap_in (add/replace modules)
+	if override.WrapIn != nil {
+		wrapIn := make(map[string]string)
+		for k, v := range result.WrapIn {
+			wrapIn[k] = v
+		}
+		for k, v := range override.WrapIn {
+			wrapIn[k] = v
+		}
+		result.WrapIn = wrapIn
+	}
+
+	// Merge active_from (add/replace rule keys)
+	if override.ActiveFrom != nil {
+		activeFrom := make(map[string]string)
+		for k, v := range result.ActiveFrom {
+			activeFrom[k] = v
+		}
+		for k, v := range override.ActiveFrom {
+			activeFrom[k] = v
+		}
+		result.ActiveFrom = activeFrom
+	}
+
 	// Merge SharedExternalImports
 	if override.SharedExternalImports.Mode != "" {
 		result.SharedExternalImports.Mode = override.SharedExternalImports.Mode
@@ -324,10 +1211,172 @@ func mergeRules(base Rules, override *Rules) Rules {
 		result.SharedExternalImports.ExclusionPatterns = mergeStringSlices(result.SharedExternalImports.ExclusionPatterns, override.SharedExternalImports.ExclusionPatterns)
 	}
 
+	// Merge TypeLeaks
+	// Additive: append override layers and forbidden packages (avoiding duplicates)
+	if override.TypeLeaks.Layers != nil {
+		result.TypeLeaks.Layers = mergeStringSlices(result.TypeLeaks.Layers, override.TypeLeaks.Layers)
+	}
+	if override.TypeLeaks.Forbidden != nil {
+		result.TypeLeaks.Forbidden = mergeStringSlices(result.TypeLeaks.Forbidden, override.TypeLeaks.Forbidden)
+	}
+
+	// Merge ConfigLoading
+	// Additive: append override directories and libraries (avoiding duplicates)
+	if override.ConfigLoading.Allowed != nil {
+		result.ConfigLoading.Allowed = mergeStringSlices(result.ConfigLoading.Allowed, override.ConfigLoading.Allowed)
+	}
+	if override.ConfigLoading.Packages != nil {
+		result.ConfigLoading.Packages = mergeStringSlices(result.ConfigLoading.Packages, override.ConfigLoading.Packages)
+	}
+
+	// Merge FrameworkLockIn
+	// Additive: append override directories and types (avoiding duplicates)
+	if override.FrameworkLockIn.Allowed != nil {
+		result.FrameworkLockIn.Allowed = mergeStringSlices(result.FrameworkLockIn.Allowed, override.FrameworkLockIn.Allowed)
+	}
+	if override.FrameworkLockIn.Types != nil {
+		result.FrameworkLockIn.Types = mergeStringSlices(result.FrameworkLockIn.Types, override.FrameworkLockIn.Types)
+	}
+
+	// Merge HiddenDependencies
+	// Additive: append override function names (avoiding duplicates)
+	if override.HiddenDependencies.Register != nil {
+		result.HiddenDependencies.Register = mergeStringSlices(result.HiddenDependencies.Register, override.HiddenDependencies.Register)
+	}
+	if override.HiddenDependencies.Lookup != nil {
+		result.HiddenDependencies.Lookup = mergeStringSlices(result.HiddenDependencies.Lookup, override.HiddenDependencies.Lookup)
+	}
+
+	// Merge TestSetupImports
+	// Additive: append override helper packages (avoiding duplicates)
+	if override.TestSetupImports.Helpers != nil {
+		result.TestSetupImports.Helpers = mergeStringSlices(result.TestSetupImports.Helpers, override.TestSetupImports.Helpers)
+	}
+
+	// Merge MainPackages
+	// Additive: append override locations (avoiding duplicates)
+	if override.MainPackages != nil {
+		result.MainPackages = mergeStringSlices(result.MainPackages, override.MainPackages)
+	}
+
+	// Merge TestScope
+	// Additive: append override shared files (avoiding duplicates)
+	if override.TestScope.Shared != nil {
+		result.TestScope.Shared = mergeStringSlices(result.TestScope.Shared, override.TestScope.Shared)
+	}
+
+	// Merge PackageNaming
+	// Additive: append override exceptions (avoiding duplicates)
+	if override.PackageNaming.Exceptions != nil {
+		result.PackageNaming.Exceptions = mergeStringSlices(result.PackageNaming.Exceptions, override.PackageNaming.Exceptions)
+	}
+
+	// Merge Ownership (add/replace directories, additive contracts)
+	if override.Ownership.Teams != nil {
+		teams := make(map[string]string)
+		for k, v := range result.Ownership.Teams {
+			teams[k] = v
+		}
+		for k, v := range override.Ownership.Teams {
+			teams[k] = v
+		}
+		result.Ownership.Teams = teams
+	}
+	if override.Ownership.Contracts != nil {
+		result.Ownership.Contracts = mergeStringSlices(result.Ownership.Contracts, override.Ownership.Contracts)
+	}
+
+	// Merge ExternalImportDepth (add/replace layers)
+	if override.ExternalImportDepth.Layers != nil {
+		layers := make(map[string]map[string]int)
+		for k, v := range result.ExternalImportDepth.Layers {
+			layers[k] = v
+		}
+		for k, v := range override.ExternalImportDepth.Layers {
+			layers[k] = v
+		}
+		result.ExternalImportDepth.Layers = layers
+	}
+
+	// Merge LicensePolicy (add/replace layers, additive exceptions)
+	if override.LicensePolicy.Layers != nil {
+		layers := make(map[string][]string)
+		for k, v := range result.LicensePolicy.Layers {
+			layers[k] = v
+		}
+		for k, v := range override.LicensePolicy.Layers {
+			layers[k] = v
+		}
+		result.LicensePolicy.Layers = layers
+	}
+	if override.LicensePolicy.Exceptions != nil {
+		result.LicensePolicy.Exceptions = mergeStringSlices(result.LicensePolicy.Exceptions, override.LicensePolicy.Exceptions)
+	}
+
+	// Merge ReplaceDirectives
+	// Additive: append override modules (avoiding duplicates)
+	if override.ReplaceDirectives.Allowed != nil {
+		result.ReplaceDirectives.Allowed = mergeStringSlices(result.ReplaceDirectives.Allowed, override.ReplaceDirectives.Allowed)
+	}
+
+	// Merge RequireShuffleClean
+	// Additive: append override directories (avoiding duplicates)
+	if override.RequireShuffleClean != nil {
+		result.RequireShuffleClean = mergeStringSlices(result.RequireShuffleClean, override.RequireShuffleClean)
+	}
+
+	// Merge RequireRaceClean
+	// Additive: append override directories (avoiding duplicates)
+	if override.RequireRaceClean != nil {
+		result.RequireRaceClean = mergeStringSlices(result.RequireRaceClean, override.RequireRaceClean)
+	}
+
+	// Merge PackageDocs
+	// Additive: append override layers (avoiding duplicates)
+	if override.PackageDocs.Layers != nil {
+		result.PackageDocs.Layers = mergeStringSlices(result.PackageDocs.Layers, override.PackageDocs.Layers)
+	}
+
+	// Merge OrphanInterfaces
+	// Additive: append override layers (avoiding duplicates)
+	if override.OrphanInterfaces.Layers != nil {
+		result.OrphanInterfaces.Layers = mergeStringSlices(result.OrphanInterfaces.Layers, override.OrphanInterfaces.Layers)
+	}
+
+	// Merge AdapterPorts
+	// Additive: append override directories (avoiding duplicates)
+	if override.AdapterPorts.Adapters != nil {
+		result.AdapterPorts.Adapters = mergeStringSlices(result.AdapterPorts.Adapters, override.AdapterPorts.Adapters)
+	}
+	if override.AdapterPorts.Ports != nil {
+		result.AdapterPorts.Ports = mergeStringSlices(result.AdapterPorts.Ports, override.AdapterPorts.Ports)
+	}
+	if override.AdapterPorts.RequireAssertions {
+		result.AdapterPorts.RequireAssertions = true
+	}
+
+	// Merge Deprecations
+	if override.Deprecations.Base != "" {
+		result.Deprecations.Base = override.Deprecations.Base
+	}
+
 	// Merge TestFiles
 	// Additive: append override exempt imports to preset exempt imports (avoiding duplicates)
-	if override.TestFiles.ExemptImports != nil {
-		result.TestFiles.ExemptImports = mergeStringSlices(result.TestFiles.ExemptImports, override.TestFiles.ExemptImports)
+	if override.TestFiles.ExemptImports.All != nil {
+		result.TestFiles.ExemptImports.All = mergeStringSlices(result.TestFiles.ExemptImports.All, override.TestFiles.ExemptImports.All)
+	}
+	if override.TestFiles.ExemptImports.ByDir != nil {
+		byDir := make(map[string][]string)
+		for dir, imports := range result.TestFiles.ExemptImports.ByDir {
+			byDir[dir] = imports
+		}
+		for dir, imports := range override.TestFiles.ExemptImports.ByDir {
+			byDir[dir] = mergeStringSlices(byDir[dir], imports)
+		}
+		result.TestFiles.ExemptImports.ByDir = byDir
+	}
+	if override.TestFiles.SupportPackages != nil {
+		result.TestFiles.SupportPackages = mergeStringSlices(result.TestFiles.SupportPackages, override.TestFiles.SupportPackages)
 	}
 	if override.TestFiles.Location != "" {
 		result.TestFiles.Location = override.TestFiles.Location
@@ -366,12 +1415,52 @@ func mergeRules(base Rules, override *Rules) Rules {
 	if override.TestFiles.RequireBlackbox {
 		result.TestFiles.RequireBlackbox = true
 	}
+	if override.TestFiles.RequireTestdata {
+		result.TestFiles.RequireTestdata = true
+	}
+	// Additive: append override exemptions, replacing the reason of a repeated path
+	if override.TestFiles.AllowWhitebox != nil {
+		result.TestFiles.AllowWhitebox = mergeWhiteboxExemptions(result.TestFiles.AllowWhitebox, override.TestFiles.AllowWhitebox)
+	}
 	if override.TestCoverage.Enabled {
 		result.TestCoverage.Enabled = true
 	}
 	if override.StrictTestNaming {
 		result.StrictTestNaming = true
 	}
+	if override.DetectDuplicates {
+		result.DetectDuplicates = true
+	}
+	if override.ConfigLoading.Enabled {
+		result.ConfigLoading.Enabled = true
+	}
+	if override.FrameworkLockIn.Enabled {
+		result.FrameworkLockIn.Enabled = true
+	}
+	if override.HiddenDependencies.Enabled {
+		result.HiddenDependencies.Enabled = true
+	}
+	if override.TestSetupImports.Enabled {
+		result.TestSetupImports.Enabled = true
+	}
+	if override.TestScope.Enabled {
+		result.TestScope.Enabled = true
+	}
+	if override.PackageNaming.Enabled {
+		result.PackageNaming.Enabled = true
+	}
+	if override.EnforceStability {
+		result.EnforceStability = true
+	}
+	if override.DetectVersionSprawl {
+		result.DetectVersionSprawl = true
+	}
+	if override.ReplaceDirectives.Enabled {
+		result.ReplaceDirectives.Enabled = true
+	}
+	if override.Deprecations.Enabled {
+		result.Deprecations.Enabled = true
+	}
 
 	return result
 }
@@ -421,26 +1510,105 @@ func Load(projectPath string) (*Config, error) {
 		return nil, fmt.Errorf("reading config file: %w", err)
 	}
 
+	return Parse(projectPath, data)
+}
+
+// Parse parses the contents of a .goarchlint file of the project at projectPath.
+// It lets callers evaluate a configuration before it is written to disk.
+func Parse(projectPath string, data []byte) (*Config, error) {
 	var cfg Config
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("parsing config file: %w", err)
 	}
 
+	// Reject unknown keys: a typo would otherwise silently disable a rule
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	if problems := unknownKeys(&doc, reflect.TypeOf(cfg), ""); len(problems) > 0 {
+		return nil, fmt.Errorf("parsing config file: %s", strings.Join(problems, "; "))
+	}
+
+	// Remember which keys the overrides and profiles set, for rule provenance
+	var raw struct {
+		Overrides map[string]interface{}            `yaml:"overrides"`
+		Profiles  map[string]map[string]interface{} `yaml:"profiles"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	cfg.overrideKeys = flattenKeys("", raw.Overrides)
+	cfg.lines = suppressionLines(&doc)
+	cfg.profileKeys = make(map[string][]string)
+	for name, profile := range raw.Profiles {
+		cfg.profileKeys[name] = flattenKeys("", profile)
+	}
+
+	if err := cfg.checkSchemaVersion(); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	if err := cfg.loadRulesFrom(); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	cfg.normalizePaths()
+	if err := cfg.validateLayers(); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	cfg.expandLayers()
+	if err := cfg.validateActiveFrom(); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	if err := cfg.validateAllowWhitebox(); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	if err := cfg.validateDefaultPolicy(); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	if err := cfg.validateExternalImportDepth(); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	if err := cfg.validateModes(); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+
+	// Detect modules of nested roots declared without an explicit module
+	hasModuleRoots := false
+	for i, sp := range cfg.ScanPaths {
+		if sp.Module == "" {
+			if _, err := os.Stat(filepath.Join(projectPath, sp.Path, "go.mod")); err == nil {
+				module, err := detectModule(filepath.Join(projectPath, sp.Path))
+				if err != nil {
+					return nil, fmt.Errorf("detecting module of %s: %w", sp.Path, err)
+				}
+				cfg.ScanPaths[i].Module = module
+			}
+		}
+		if cfg.ScanPaths[i].Module != "" {
+			hasModuleRoots = true
+		}
+	}
+
 	// Auto-detect module from go.mod if not specified
+	// Multi-root repositories may not have a go.mod at the top level
+	cfg.keySources = make(map[string]string)
 	if cfg.Module == "" {
 		module, err := detectModule(projectPath)
-		if err != nil {
+		if err != nil && !hasModuleRoots {
 			return nil, fmt.Errorf("detecting module: %w", err)
 		}
 		cfg.Module = module
+		cfg.keySources["module"] = "go.mod"
 	}
 
 	// Set defaults if not specified
 	if len(cfg.ScanPaths) == 0 {
-		cfg.ScanPaths = []string{"cmd", "pkg", "internal"}
+		cfg.ScanPaths = []ScanPath{{Path: "cmd"}, {Path: "pkg"}, {Path: "internal"}}
+		cfg.keySources["scan_paths"] = "defaults"
 	}
 	if len(cfg.IgnorePaths) == 0 {
 		cfg.IgnorePaths = []string{"vendor", "testdata"}
+		cfg.keySources["ignore_paths"] = "defaults"
 	}
 
 	// For old format (backward compatibility): set default for Structure if not specified
@@ -458,8 +1626,10 @@ func defaultConfig(projectPath string) (*Config, error) {
 	}
 
 	return &Config{
+		isDefault:   true,
+		keySources:  map[string]string{"module": "go.mod"},
 		Module:      module,
-		ScanPaths:   []string{"cmd", "pkg", "internal"},
+		ScanPaths:   []ScanPath{{Path: "cmd"}, {Path: "pkg"}, {Path: "internal"}},
 		IgnorePaths: []string{"vendor", "testdata"},
 		Structure: Structure{
 			RequiredDirectories:   make(map[string]string),
@@ -1,8 +1,11 @@
 package config_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/kgatilin/go-arch-lint/internal/config"
@@ -186,7 +189,13 @@ rules:
       - testing
       - testify
     location: alongside
-  require_blackbox_tests: true
+    require_blackbox: true
+  test_coverage:
+    enabled: true
+    threshold: 80.0
+    package_thresholds:
+      internal/app: 90.0
+      pkg/http: 85.0
 
 error_prompt:
   enabled: true
@@ -195,13 +204,6 @@ error_prompt:
     - "Dependency inversion"
     - "Single responsibility"
   refactoring_guidance: "Move to proper layer"
-
-coverage:
-  enabled: true
-  threshold: 80.0
-  package_thresholds:
-    internal/app: 90.0
-    pkg/http: 85.0
 `
 	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
 		t.Fatal(err)
@@ -279,11 +281,21 @@ coverage:
 		t.Errorf("GetTestFileLocation() = %s, want alongside", cfg.GetTestFileLocation())
 	}
 
-	// Note: Coverage section is not loaded from YAML in current implementation
-	// These tests verify the methods exist and don't panic
-	_ = cfg.IsCoverageEnabled()
-	_ = cfg.GetCoverageThreshold()
-	_ = cfg.GetPackageThresholds()
+	// Test ShouldRequireBlackboxTests
+	if !cfg.ShouldRequireBlackboxTests() {
+		t.Error("ShouldRequireBlackboxTests() = false, want true")
+	}
+
+	// Test coverage settings
+	if !cfg.IsCoverageEnabled() {
+		t.Error("IsCoverageEnabled() = false, want true")
+	}
+	if cfg.GetCoverageThreshold() != 80.0 {
+		t.Errorf("GetCoverageThreshold() = %v, want 80", cfg.GetCoverageThreshold())
+	}
+	if cfg.GetPackageThresholds()["internal/app"] != 90.0 {
+		t.Errorf("GetPackageThresholds()[internal/app] = %v, want 90", cfg.GetPackageThresholds()["internal/app"])
+	}
 }
 
 // TestConfig_DefaultValues tests that interface methods return sensible defaults
@@ -777,3 +789,1603 @@ overrides:
 		}
 	}
 }
+
+func TestConfig_DetectDuplicates(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Preset without duplicate detection, enabled via overrides
+	configYAML := `
+module: example.com/test
+preset:
+  name: simple
+  rules:
+    directories_import:
+      cmd: [internal]
+      internal: []
+overrides:
+  rules:
+    detect_duplicates: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !cfg.ShouldDetectDuplicates() {
+		t.Error("ShouldDetectDuplicates() = false, want true")
+	}
+}
+
+func TestConfig_WrapIn_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: simple
+  rules:
+    directories_import:
+      cmd: [internal]
+      internal: []
+    wrap_in:
+      github.com/aws/aws-sdk-go-v2: internal/storage
+      github.com/stripe/stripe-go: internal/billing
+overrides:
+  rules:
+    wrap_in:
+      github.com/stripe/stripe-go: internal/payments
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	wrapIn := cfg.GetWrapIn()
+	if wrapIn["github.com/aws/aws-sdk-go-v2"] != "internal/storage" {
+		t.Errorf("expected preset wrapper to be kept, got %v", wrapIn)
+	}
+	if wrapIn["github.com/stripe/stripe-go"] != "internal/payments" {
+		t.Errorf("expected override wrapper to replace preset, got %v", wrapIn)
+	}
+}
+
+func TestConfig_HiddenDependencies_DefaultFuncs(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `
+module: example.com/test
+rules:
+  hidden_dependencies:
+    enabled: true
+    lookup: [Locate]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !cfg.ShouldDetectHiddenDependencies() {
+		t.Error("ShouldDetectHiddenDependencies() = false, want true")
+	}
+	if funcs := cfg.GetHiddenDependencyRegisterFuncs(); !reflect.DeepEqual(funcs, []string{"Register", "MustRegister", "Provide"}) {
+		t.Errorf("expected default register functions, got %v", funcs)
+	}
+	if funcs := cfg.GetHiddenDependencyLookupFuncs(); !reflect.DeepEqual(funcs, []string{"Locate"}) {
+		t.Errorf("expected configured lookup functions, got %v", funcs)
+	}
+}
+
+func TestConfig_FrameworkLockIn_DefaultTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `
+module: example.com/test
+rules:
+  framework_lock_in:
+    enabled: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !cfg.ShouldDetectFrameworkLockIn() {
+		t.Error("ShouldDetectFrameworkLockIn() = false, want true")
+	}
+
+	found := false
+	for _, typ := range cfg.GetFrameworkLockInTypes() {
+		if typ == "github.com/gin-gonic/gin.Context" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected gin.Context in default types, got %v", cfg.GetFrameworkLockInTypes())
+	}
+	if len(cfg.GetFrameworkLockInAllowed()) != 0 {
+		t.Errorf("expected no allowed directories by default, got %v", cfg.GetFrameworkLockInAllowed())
+	}
+}
+
+func TestConfig_PackageNaming_DefaultExceptions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `
+module: example.com/test
+rules:
+  package_naming:
+    enabled: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !cfg.ShouldCheckPackageNaming() {
+		t.Error("ShouldCheckPackageNaming() = false, want true")
+	}
+	exceptions := cfg.GetPackageNamingExceptions()
+	if len(exceptions) != 1 || exceptions[0] != "main" {
+		t.Errorf("GetPackageNamingExceptions() = %v, want [main]", exceptions)
+	}
+}
+
+func TestConfig_EnforceStability(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Preset without stability enforcement, enabled via overrides
+	configYAML := `
+module: example.com/test
+preset:
+  name: simple
+  rules:
+    directories_import:
+      cmd: [pkg]
+      pkg: []
+overrides:
+  rules:
+    enforce_stability: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !cfg.ShouldEnforceStability() {
+		t.Error("ShouldEnforceStability() = false, want true")
+	}
+}
+
+func TestConfig_Deprecations_Base(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `
+module: example.com/test
+rules:
+  deprecations:
+    enabled: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.ShouldDetectDeprecatedUsages() {
+		t.Error("ShouldDetectDeprecatedUsages() = false, want true")
+	}
+	if base := cfg.GetDeprecationsBase(); base != "HEAD" {
+		t.Errorf("GetDeprecationsBase() = %q, want HEAD", base)
+	}
+
+	configYAML += "    base: origin/main\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if base := cfg.GetDeprecationsBase(); base != "origin/main" {
+		t.Errorf("GetDeprecationsBase() = %q, want origin/main", base)
+	}
+}
+
+func TestConfig_Ownership_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: simple
+  rules:
+    directories_import:
+      internal: [internal]
+    ownership:
+      teams:
+        internal/payments: payments
+        internal/identity: identity
+overrides:
+  rules:
+    ownership:
+      teams:
+        internal/identity: accounts
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	teams := cfg.GetOwnershipTeams()
+	if teams["internal/payments"] != "payments" || teams["internal/identity"] != "accounts" {
+		t.Errorf("expected preset teams merged with overrides, got %v", teams)
+	}
+
+	contracts := cfg.GetOwnershipContracts()
+	if len(contracts) != 2 || contracts[0] != "**/api" || contracts[1] != "**/contract" {
+		t.Errorf("GetOwnershipContracts() = %v, want default [**/api **/contract]", contracts)
+	}
+
+	configYAML += "      contracts: [\"**/public\"]\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if contracts := cfg.GetOwnershipContracts(); len(contracts) != 1 || contracts[0] != "**/public" {
+		t.Errorf("GetOwnershipContracts() = %v, want [**/public]", contracts)
+	}
+}
+
+func TestConfig_PackageDocs_MergesLayers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: simple
+  rules:
+    package_docs:
+      layers: [pkg]
+overrides:
+  rules:
+    package_docs:
+      layers: [internal]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	layers := cfg.GetPackageDocLayers()
+	if len(layers) != 2 || layers[0] != "pkg" || layers[1] != "internal" {
+		t.Errorf("GetPackageDocLayers() = %v, want [pkg internal]", layers)
+	}
+}
+
+func TestConfig_DomainLayers_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  structure:
+    required_directories:
+      internal/domain: "Domain model"
+    domain_layers: [internal/domain]
+overrides:
+  structure:
+    domain_layers: [internal/catalog]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	layers := cfg.GetDomainLayers()
+	if len(layers) != 2 || layers[0] != "internal/domain" || layers[1] != "internal/catalog" {
+		t.Errorf("GetDomainLayers() = %v, want [internal/domain internal/catalog]", layers)
+	}
+}
+
+func TestConfig_DocsTemplatesDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goMod := "module example.com/test\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `
+module: example.com/test
+docs:
+  templates_dir: docs/templates
+  inline_package_details: true
+rules:
+  directories_import:
+    internal: []
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if dir := cfg.GetDocsTemplatesDir(); dir != "docs/templates" {
+		t.Errorf("GetDocsTemplatesDir() = %q, want docs/templates", dir)
+	}
+	if !cfg.ShouldInlinePackageDetails() {
+		t.Error("expected ShouldInlinePackageDetails() to be true")
+	}
+}
+
+func TestConfig_SourceLinks(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+source_links:
+  url: https://github.com/org/repo/blob/{ref}/{file}#L{line}
+  ref: main
+rules:
+  directories_import:
+    internal: []
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if tmpl := cfg.GetSourceLinkTemplate(); tmpl != "https://github.com/org/repo/blob/{ref}/{file}#L{line}" {
+		t.Errorf("GetSourceLinkTemplate() = %q", tmpl)
+	}
+	if ref := cfg.GetSourceLinkRef(); ref != "main" {
+		t.Errorf("GetSourceLinkRef() = %q, want main", ref)
+	}
+}
+
+func TestConfig_BuildMatrix(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+build_matrix:
+  - goos: linux
+    goarch: arm64
+  - goos: windows
+    goarch: amd64
+    tags: [integration, debug]
+rules:
+  directories_import:
+    internal: []
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	targets := cfg.GetBuildMatrix()
+	if len(targets) != 2 {
+		t.Fatalf("expected 2 build targets, got %d", len(targets))
+	}
+	if got := targets[0].String(); got != "linux/arm64" {
+		t.Errorf("targets[0].String() = %q, want linux/arm64", got)
+	}
+	if got := targets[1].String(); got != "windows/amd64 with tags integration,debug" {
+		t.Errorf("targets[1].String() = %q, want windows/amd64 with tags integration,debug", got)
+	}
+}
+
+func TestConfig_GetRuleSource(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    directories_import:
+      cmd: [internal/app]
+      cmd/tool: [internal/app]
+    detect_unused: true
+overrides:
+  rules:
+    directories_import:
+      cmd/tool: [internal/app, internal/infra]
+    test_files:
+      lint: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"", "built-in"},
+		{"rules.directories_import.cmd", "preset ddd"},
+		{"rules.directories_import.cmd/tool", "overrides"},
+		{"rules.detect_unused", "preset ddd"},
+		{"rules.test_files", "overrides"},
+		{"rules.test_files.location", "preset ddd"},
+	}
+	for _, tt := range tests {
+		if source := cfg.GetRuleSource(tt.key); source != tt.expected {
+			t.Errorf("GetRuleSource(%q) = %q, want %q", tt.key, source, tt.expected)
+		}
+	}
+}
+
+func TestConfig_GetRuleSource_FlatAndDefault(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if source := cfg.GetRuleSource("rules.directories_import.cmd"); source != "defaults" {
+		t.Errorf("expected defaults without config file, got %q", source)
+	}
+
+	configYAML := "rules:\n  directories_import:\n    cmd: [pkg]\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if source := cfg.GetRuleSource("rules.directories_import.cmd"); source != ".goarchlint" {
+		t.Errorf("expected .goarchlint for flat config, got %q", source)
+	}
+}
+
+func TestLoad_UnknownKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		yaml        string
+		expected    []string
+		notExpected string
+	}{
+		{
+			name:     "typo with suggestion",
+			yaml:     "module: example.com/test\nrules:\n  detect_unsued: true\n",
+			expected: []string{`line 3: unknown key "rules.detect_unsued" (did you mean "detect_unused"?)`},
+		},
+		{
+			name:     "nested under preset",
+			yaml:     "module: example.com/test\npreset:\n  name: ddd\n  rules:\n    test_files:\n      lnt: true\n",
+			expected: []string{`line 6: unknown key "preset.rules.test_files.lnt" (did you mean "lint"?)`},
+		},
+		{
+			name:     "scan path mapping",
+			yaml:     "module: example.com/test\nscan_paths:\n  - path: services/a\n    modul: example.com/a\n",
+			expected: []string{`line 4: unknown key "scan_paths.modul" (did you mean "module"?)`},
+		},
+		{
+			name:        "no close match",
+			yaml:        "module: example.com/test\ncoverage:\n  enabled: true\n",
+			expected:    []string{`line 2: unknown key "coverage"`},
+			notExpected: "did you mean",
+		},
+		{
+			name: "all problems reported",
+			yaml: "module: example.com/test\nrules:\n  detect_unsued: true\n  enforce_stabilty: true\n",
+			expected: []string{
+				`line 3: unknown key "rules.detect_unsued"`,
+				`line 4: unknown key "rules.enforce_stabilty" (did you mean "enforce_stability"?)`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := config.Load(tmpDir)
+			if err == nil {
+				t.Fatal("expected error for unknown key")
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected %q in error, got: %v", expected, err)
+				}
+			}
+			if tt.notExpected != "" && strings.Contains(err.Error(), tt.notExpected) {
+				t.Errorf("expected no %q in error, got: %v", tt.notExpected, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Profiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    directories_import:
+      cmd: [internal/app]
+overrides:
+  rules:
+    test_files:
+      lint: true
+profiles:
+  strict:
+    rules:
+      directories_import:
+        cmd: [pkg]
+      detect_unused: true
+  migration:
+    rules:
+      directories_import:
+        legacy: [internal/app]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if profiles := cfg.GetProfiles(); !reflect.DeepEqual(profiles, []string{"migration", "strict"}) {
+		t.Errorf("expected sorted profiles [migration strict], got %v", profiles)
+	}
+
+	// Without a profile only the preset and overrides apply
+	if cfg.ShouldDetectUnused() {
+		t.Error("expected detect_unused to be false without a profile")
+	}
+	if imports := cfg.GetDirectoriesImport()["cmd"]; !reflect.DeepEqual(imports, []string{"internal/app"}) {
+		t.Errorf("expected preset imports for cmd, got %v", imports)
+	}
+
+	if err := cfg.SelectProfile("strict"); err != nil {
+		t.Fatalf("SelectProfile failed: %v", err)
+	}
+	if !cfg.ShouldDetectUnused() {
+		t.Error("expected strict profile to enable detect_unused")
+	}
+	if imports := cfg.GetDirectoriesImport()["cmd"]; !reflect.DeepEqual(imports, []string{"pkg"}) {
+		t.Errorf("expected strict profile imports for cmd, got %v", imports)
+	}
+	if !cfg.ShouldLintTestFiles() {
+		t.Error("expected overrides to still apply with a profile")
+	}
+	if source := cfg.GetRuleSource("rules.directories_import.cmd"); source != "profile strict" {
+		t.Errorf("expected source 'profile strict', got %q", source)
+	}
+	if source := cfg.GetRuleSource("rules.test_files.lint"); source != "overrides" {
+		t.Errorf("expected source 'overrides', got %q", source)
+	}
+
+	err = cfg.SelectProfile("nightly")
+	if err == nil {
+		t.Fatal("expected error for unknown profile")
+	}
+	if !strings.Contains(err.Error(), `unknown profile "nightly" (available: migration, strict)`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestConfig_Profiles_FlatFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+rules:
+  directories_import:
+    cmd: [internal/app]
+profiles:
+  strict:
+    rules:
+      detect_unused: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := cfg.SelectProfile("strict"); err != nil {
+		t.Fatalf("SelectProfile failed: %v", err)
+	}
+	if !cfg.ShouldDetectUnused() {
+		t.Error("expected strict profile to enable detect_unused")
+	}
+	if imports := cfg.GetDirectoriesImport()["cmd"]; !reflect.DeepEqual(imports, []string{"internal/app"}) {
+		t.Errorf("expected flat imports for cmd, got %v", imports)
+	}
+	if source := cfg.GetRuleSource("rules.detect_unused"); source != "profile strict" {
+		t.Errorf("expected source 'profile strict', got %q", source)
+	}
+}
+
+func TestConfig_GetRuleActivation(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    active_from:
+      rules.directories_import: 2025-09-01
+overrides:
+  rules:
+    active_from:
+      rules.directories_import.cmd: 2026-01-15
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"rules.directories_import.internal", "2025-09-01"},
+		{"rules.directories_import.cmd", "2026-01-15"},
+		{"rules.directories_import.cmd/tool", "2025-09-01"},
+		{"rules.detect_unused", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		date, ok := cfg.GetRuleActivation(tt.key)
+		got := ""
+		if ok {
+			got = date.Format("2006-01-02")
+		}
+		if got != tt.expected {
+			t.Errorf("GetRuleActivation(%q) = %q, want %q", tt.key, got, tt.expected)
+		}
+	}
+}
+
+func TestLoad_InvalidActiveFromDate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := "module: example.com/test\nrules:\n  active_from:\n    rules.detect_unused: 09/01/2025\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := config.Load(tmpDir)
+	if err == nil {
+		t.Fatal("expected error for invalid active_from date")
+	}
+	if !strings.Contains(err.Error(), `invalid date "09/01/2025" for rules.detect_unused`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestConfig_RequireTestdataFixtures_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    test_files:
+      lint: true
+overrides:
+  rules:
+    test_files:
+      require_testdata: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.ShouldRequireTestdataFixtures() {
+		t.Error("expected overrides to enable require_testdata")
+	}
+	if !cfg.ShouldLintTestFiles() {
+		t.Error("expected preset test linting to be kept")
+	}
+}
+
+func TestConfig_TestSetupImports_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    test_setup_imports:
+      helpers: [internal/testkit]
+overrides:
+  rules:
+    test_setup_imports:
+      enabled: true
+      helpers: [internal/seed]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.ShouldDetectTestSetupImports() {
+		t.Error("expected overrides to enable test_setup_imports")
+	}
+	if helpers := cfg.GetTestSetupHelpers(); !reflect.DeepEqual(helpers, []string{"internal/testkit", "internal/seed"}) {
+		t.Errorf("expected additive helpers, got %v", helpers)
+	}
+}
+
+func TestConfig_AllowWhitebox_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    test_files:
+      require_blackbox: true
+      allow_whitebox:
+        - path: internal/algo
+          reason: tight algorithmic internals
+overrides:
+  rules:
+    test_files:
+      allow_whitebox:
+        - path: internal/algo
+          reason: heap invariants are not observable
+        - path: "**/cache"
+          reason: eviction order
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"internal/algo": "heap invariants are not observable",
+		"**/cache":      "eviction order",
+	}
+	if exemptions := cfg.GetWhiteboxExemptions(); !reflect.DeepEqual(exemptions, expected) {
+		t.Errorf("expected exemptions %v, got %v", expected, exemptions)
+	}
+}
+
+func TestLoad_AllowWhiteboxRequiresReason(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := "module: example.com/test\nrules:\n  test_files:\n    require_blackbox: true\n    allow_whitebox:\n      - path: internal/algo\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := config.Load(tmpDir)
+	if err == nil {
+		t.Fatal("expected error for exemption without reason")
+	}
+	if !strings.Contains(err.Error(), "rules.test_files.allow_whitebox: internal/algo needs a reason") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestConfig_TestScope_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    strict_test_naming: true
+    test_scope:
+      shared: [helpers.go]
+overrides:
+  rules:
+    test_scope:
+      enabled: true
+      shared: [fixtures.go]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.ShouldEnforceStrictTestNaming() || !cfg.ShouldEnforceTestScope() {
+		t.Error("expected strict_test_naming and test_scope to be enabled")
+	}
+	if shared := cfg.GetTestScopeShared(); !reflect.DeepEqual(shared, []string{"helpers.go", "fixtures.go"}) {
+		t.Errorf("expected additive shared files, got %v", shared)
+	}
+}
+
+func TestConfig_TestSupportPackages_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    test_files:
+      support_packages: [internal/testsupport]
+overrides:
+  rules:
+    test_files:
+      support_packages: [internal/fakes, internal/testsupport]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if pkgs := cfg.GetTestSupportPackages(); !reflect.DeepEqual(pkgs, []string{"internal/testsupport", "internal/fakes"}) {
+		t.Errorf("expected additive support packages, got %v", pkgs)
+	}
+}
+
+func TestConfig_ExemptImports_PerDirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    test_files:
+      exempt_imports: [testing]
+overrides:
+  rules:
+    test_files:
+      exempt_imports:
+        "**": [github.com/stretchr/testify]
+        tests: ["*"]
+        internal/domain: [testing]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if all := cfg.GetTestExemptImports(); !reflect.DeepEqual(all, []string{"testing", "github.com/stretchr/testify"}) {
+		t.Errorf("expected \"**\" to extend the global list, got %v", all)
+	}
+	expected := map[string][]string{"tests": {"*"}, "internal/domain": {"testing"}}
+	if byDir := cfg.GetTestExemptImportsByDir(); !reflect.DeepEqual(byDir, expected) {
+		t.Errorf("expected per-directory lists %v, got %v", expected, byDir)
+	}
+}
+
+func TestConfig_ExemptImports_RejectsScalar(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+rules:
+  test_files:
+    exempt_imports: testing
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := config.Load(tmpDir)
+	if err == nil || !strings.Contains(err.Error(), "exempt_imports must be a list or a map") {
+		t.Errorf("expected exempt_imports shape error, got %v", err)
+	}
+}
+
+func TestConfig_MainPackages_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    main_packages: [cmd]
+overrides:
+  rules:
+    main_packages: [tools/**, cmd]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if locations := cfg.GetMainPackageLocations(); !reflect.DeepEqual(locations, []string{"cmd", "tools/**"}) {
+		t.Errorf("expected additive main package locations, got %v", locations)
+	}
+}
+
+func TestConfig_OrphanInterfaces_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: hexagonal
+  rules:
+    orphan_interfaces:
+      layers: [internal/ports]
+overrides:
+  rules:
+    orphan_interfaces:
+      layers: [internal/core, internal/ports]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if layers := cfg.GetOrphanInterfaceLayers(); !reflect.DeepEqual(layers, []string{"internal/ports", "internal/core"}) {
+		t.Errorf("expected additive orphan interface layers, got %v", layers)
+	}
+}
+
+func TestConfig_AdapterPorts_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: hexagonal
+  rules:
+    adapter_ports:
+      adapters: [internal/adapters]
+      ports: [internal/ports]
+overrides:
+  rules:
+    adapter_ports:
+      ports: [internal/core]
+      require_assertions: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if adapters := cfg.GetAdapterLayers(); !reflect.DeepEqual(adapters, []string{"internal/adapters"}) {
+		t.Errorf("expected adapter layers from the preset, got %v", adapters)
+	}
+	if ports := cfg.GetAdapterPortLayers(); !reflect.DeepEqual(ports, []string{"internal/ports", "internal/core"}) {
+		t.Errorf("expected additive port layers, got %v", ports)
+	}
+	if !cfg.ShouldRequireAdapterAssertions() {
+		t.Error("expected the override to require adapter assertions")
+	}
+}
+
+func TestConfig_DefaultPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		configYAML string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "defaults to allow",
+			configYAML: "module: example.com/test\nrules:\n  directories_import:\n    cmd: [internal]\n",
+			want:       "allow",
+		},
+		{
+			name:       "overrides replace the preset policy",
+			configYAML: "module: example.com/test\npreset:\n  name: ddd\n  rules:\n    default_policy: allow\noverrides:\n  rules:\n    default_policy: deny\n",
+			want:       "deny",
+		},
+		{
+			name:       "rejects unknown policies",
+			configYAML: "module: example.com/test\nrules:\n  default_policy: dney\n",
+			wantErr:    `rules.default_policy: invalid policy "dney" (expected allow or deny)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(tt.configYAML), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(tmpDir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if policy := cfg.GetDefaultPolicy(); policy != tt.want {
+				t.Errorf("expected policy %q, got %q", tt.want, policy)
+			}
+		})
+	}
+}
+
+func TestConfig_ExternalImportDepth(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  rules:
+    external_import_depth:
+      layers:
+        internal/app:
+          github.com/aws/aws-sdk-go-v2: 2
+        internal/domain:
+          github.com/aws/aws-sdk-go-v2: 0
+overrides:
+  rules:
+    external_import_depth:
+      layers:
+        ./internal/app:
+          github.com/aws/aws-sdk-go-v2: 1
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := map[string]map[string]int{
+		"internal/app":    {"github.com/aws/aws-sdk-go-v2": 1},
+		"internal/domain": {"github.com/aws/aws-sdk-go-v2": 0},
+	}
+	if layers := cfg.GetExternalImportDepthLayers(); !reflect.DeepEqual(layers, want) {
+		t.Errorf("expected the override to replace the internal/app limits, got %v", layers)
+	}
+
+	negative := "module: example.com/test\nrules:\n  external_import_depth:\n    layers:\n      internal:\n        github.com/aws/aws-sdk-go-v2: -1\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(negative), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.Load(tmpDir); err == nil || !strings.Contains(err.Error(), "rules.external_import_depth: negative depth -1 for github.com/aws/aws-sdk-go-v2 in internal") {
+		t.Errorf("expected an error for a negative depth, got %v", err)
+	}
+}
+
+func TestConfig_Layers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+layers:
+  domain: [internal/domain, ./internal/shared/kernel]
+  app: [internal/app]
+preset:
+  name: ddd
+  structure:
+    domain_layers: [domain]
+  rules:
+    directories_import:
+      domain: []
+      app: [domain]
+      internal/shared/kernel: [internal/shared/ids]
+    license_policy:
+      layers:
+        domain: [permissive]
+overrides:
+  rules:
+    directories_import:
+      app: [domain, internal/infra]
+    active_from:
+      rules.directories_import.app: "2030-01-01"
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	want := map[string][]string{
+		"internal/domain": {},
+		// A directory listed by itself keeps its own rule
+		"internal/shared/kernel": {"internal/shared/ids"},
+		"internal/app":           {"internal/domain", "internal/shared/kernel", "internal/infra"},
+	}
+	if rules := cfg.GetDirectoriesImport(); !reflect.DeepEqual(rules, want) {
+		t.Errorf("expected layer names replaced by their directories, got %v", rules)
+	}
+	if layers := cfg.GetDomainLayers(); !reflect.DeepEqual(layers, []string{"internal/domain", "internal/shared/kernel"}) {
+		t.Errorf("expected domain layers expanded, got %v", layers)
+	}
+	if policy := cfg.GetLicensePolicyLayers(); len(policy) != 2 || policy["internal/shared/kernel"][0] != "permissive" {
+		t.Errorf("expected license policy layers expanded, got %v", policy)
+	}
+	if source := cfg.GetRuleSource("rules.directories_import.internal/app"); source != "overrides" {
+		t.Errorf("expected the app rule to come from the overrides, got %q", source)
+	}
+	if _, ok := cfg.GetRuleActivation("rules.directories_import.internal/app"); !ok {
+		t.Error("expected active_from to apply to the directories of the app layer")
+	}
+}
+
+func TestConfig_Layers_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		configYAML string
+		wantErr    string
+	}{
+		{
+			name:       "name with a slash",
+			configYAML: "module: example.com/test\nlayers:\n  internal/domain: [internal/domain]\n",
+			wantErr:    `layers: invalid layer name "internal/domain"`,
+		},
+		{
+			name:       "no directories",
+			configYAML: "module: example.com/test\nlayers:\n  domain: []\n",
+			wantErr:    "layers: domain lists no directories",
+		},
+		{
+			name:       "directory in two layers",
+			configYAML: "module: example.com/test\nlayers:\n  core: [internal/domain]\n  domain: [./internal/domain]\n",
+			wantErr:    "layers: internal/domain is in both core and domain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(tt.configYAML), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := config.Load(tmpDir); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_RequireReadme_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: ddd
+  structure:
+    require_readme: [internal/domain]
+overrides:
+  structure:
+    require_readme: [internal/app, internal/domain]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if dirs := cfg.GetReadmeDirectories(); !reflect.DeepEqual(dirs, []string{"internal/domain", "internal/app"}) {
+		t.Errorf("expected additive README directories, got %v", dirs)
+	}
+}
+
+func TestLoad_SchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		wantErr string
+	}{
+		{name: "unversioned"},
+		{name: "current", version: fmt.Sprintf("version: %d\n", config.SchemaVersion)},
+		{name: "newer", version: fmt.Sprintf("version: %d\n", config.SchemaVersion+1), wantErr: "is newer than this go-arch-lint supports"},
+		{name: "negative", version: "version: -1\n", wantErr: "version must be a positive schema version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			configYAML := tt.version + "module: example.com/test\n"
+			if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(tmpDir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if warnings := cfg.GetWarnings(); len(warnings) != 0 {
+				t.Errorf("expected no warnings, got %v", warnings)
+			}
+		})
+	}
+}
+
+func TestConfig_CheckUpdate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+check_update: true
+preset:
+  name: simple
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.ShouldCheckUpdate() {
+		t.Error("expected check_update to enable the update check")
+	}
+
+	effective, err := cfg.Effective()
+	if err != nil {
+		t.Fatalf("Effective failed: %v", err)
+	}
+	if !strings.Contains(effective, "check_update: true") {
+		t.Errorf("expected check_update in the effective config, got:\n%s", effective)
+	}
+}
+
+func TestConfig_Modes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+modes:
+  full: [test_coverage, type_checks]
+preset:
+  name: simple
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.RunsCheck(config.CheckTestCoverage) || cfg.RunsCheck(config.CheckTypeChecks) {
+		t.Error("expected the fast mode to skip the checks listed under modes.full")
+	}
+	if !cfg.RunsCheck(config.CheckStaticcheck) {
+		t.Error("expected the fast mode to run checks not listed under modes.full")
+	}
+	if cfg.RunsCheck(config.CheckRaceClean) {
+		t.Error("expected the fast mode to skip the race detector without listing it")
+	}
+
+	if err := cfg.SelectMode(config.ModeFull); err != nil {
+		t.Fatalf("SelectMode failed: %v", err)
+	}
+	if !cfg.RunsCheck(config.CheckTestCoverage) || !cfg.RunsCheck(config.CheckTypeChecks) || !cfg.RunsCheck(config.CheckRaceClean) {
+		t.Error("expected the full mode to run every check")
+	}
+	if err := cfg.SelectMode("slow"); err == nil || !strings.Contains(err.Error(), `unknown mode "slow" (available: fast, full)`) {
+		t.Errorf("expected unknown mode error, got %v", err)
+	}
+
+	invalid := strings.Replace(configYAML, "type_checks", "types", 1)
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(invalid), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.Load(tmpDir); err == nil || !strings.Contains(err.Error(), `modes: unknown check "types" in full (available: test_coverage, staticcheck, type_checks, require_shuffle_clean)`) {
+		t.Errorf("expected unknown check error, got %v", err)
+	}
+}
+
+func TestConfig_PortLayers_MergesOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+preset:
+  name: hexagonal
+  structure:
+    port_layers: [internal/ports]
+overrides:
+  structure:
+    port_layers: [internal/core]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if layers := cfg.GetPortLayers(); !reflect.DeepEqual(layers, []string{"internal/ports", "internal/core"}) {
+		t.Errorf("expected additive port layers, got %v", layers)
+	}
+}
+
+func TestLoad_MultiRootScanPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// No go.mod at the top level: every root declares or has its own module
+	configYAML := `
+scan_paths:
+  - path: services/billing
+    module: github.com/org/billing
+  - path: services/orders
+  - libs/shared
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ordersDir := filepath.Join(tmpDir, "services", "orders")
+	if err := os.MkdirAll(ordersDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(ordersDir, "go.mod"), []byte("module github.com/org/orders\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	paths := cfg.GetScanPaths()
+	if len(paths) != 3 || paths[0] != "services/billing" || paths[2] != "libs/shared" {
+		t.Errorf("unexpected scan paths: %v", paths)
+	}
+
+	roots := cfg.GetModuleRoots()
+	if len(roots) != 2 {
+		t.Fatalf("expected 2 module roots, got %v", roots)
+	}
+	if roots["github.com/org/billing"] != "services/billing" {
+		t.Errorf("expected explicit module root, got %v", roots)
+	}
+	if roots["github.com/org/orders"] != "services/orders" {
+		t.Errorf("expected module detected from nested go.mod, got %v", roots)
+	}
+}
+
+func TestLoad_ScanPathMappingRequiresPath(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `
+scan_paths:
+  - module: github.com/org/billing
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := config.Load(tmpDir); err == nil {
+		t.Error("expected error for scan_paths entry without path")
+	}
+}
+
+func TestLoad_NormalizesPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Paths as written on Windows or with a leading "./"
+	configYAML := `
+scan_paths:
+  - ./services\billing
+ignore_paths:
+  - ./generated/report\*.go
+structure:
+  required_directories:
+    internal\domain: "Domain model"
+  domain_layers:
+    - ./internal/domain
+rules:
+  directories_import:
+    internal\app:
+      - internal\domain
+    ./cmd:
+      - internal/app
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if paths := cfg.GetScanPaths(); len(paths) != 1 || paths[0] != "services/billing" {
+		t.Errorf("unexpected scan paths: %v", paths)
+	}
+	if _, ok := cfg.GetRequiredDirectories()["internal/domain"]; !ok {
+		t.Errorf("expected normalized required directory, got %v", cfg.GetRequiredDirectories())
+	}
+	if layers := cfg.GetDomainLayers(); len(layers) != 1 || layers[0] != "internal/domain" {
+		t.Errorf("unexpected domain layers: %v", layers)
+	}
+
+	rules := cfg.GetDirectoriesImport()
+	if allowed := rules["internal/app"]; len(allowed) != 1 || allowed[0] != "internal/domain" {
+		t.Errorf("expected normalized import rule, got %v", rules)
+	}
+	if _, ok := rules["cmd"]; !ok {
+		t.Errorf("expected leading ./ to be dropped, got %v", rules)
+	}
+
+	// A backslash in a glob pattern escapes a metacharacter and is kept
+	if len(cfg.IgnorePaths) != 1 || cfg.IgnorePaths[0] != `generated/report\*.go` {
+		t.Errorf("expected the escaped pattern to be kept, got %v", cfg.IgnorePaths)
+	}
+}
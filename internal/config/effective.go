@@ -0,0 +1,126 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// effectiveConfig is the layout of the merged configuration written by Effective
+type effectiveConfig struct {
+	Version     int                 `yaml:"version,omitempty"`
+	Module      string              `yaml:"module"`
+	ScanPaths   []ScanPath          `yaml:"scan_paths"`
+	IgnorePaths []string            `yaml:"ignore_paths"`
+	Docs        Docs                `yaml:"docs,omitempty"`
+	SourceLinks SourceLinks         `yaml:"source_links,omitempty"`
+	BuildMatrix []BuildTarget       `yaml:"build_matrix,omitempty"`
+	CheckUpdate bool                `yaml:"check_update,omitempty"`
+	RulesFrom   *RulesFrom          `yaml:"rules_from,omitempty"`
+	Layers      map[string][]string `yaml:"layers,omitempty"`
+	Modes       Modes               `yaml:"modes,omitempty"`
+	Preset      string              `yaml:"preset,omitempty"`
+	Structure   Structure           `yaml:"structure"`
+	Rules       Rules               `yaml:"rules"`
+	ErrorPrompt ErrorPrompt         `yaml:"error_prompt,omitempty"`
+}
+
+// Effective renders the fully merged configuration (preset + rules_from bundle + overrides,
+// or the flat format, plus defaults) as YAML. Each value carries a comment naming its source.
+func (c *Config) Effective() (string, error) {
+	merged := c.getMerged()
+	effective := effectiveConfig{
+		Version:     c.Version,
+		Module:      c.Module,
+		ScanPaths:   c.ScanPaths,
+		IgnorePaths: c.IgnorePaths,
+		Docs:        c.Docs,
+		SourceLinks: c.SourceLinks,
+		BuildMatrix: c.BuildMatrix,
+		CheckUpdate: c.CheckUpdate,
+		RulesFrom:   c.RulesFrom,
+		Layers:      c.Layers,
+		Modes:       c.Modes,
+		Preset:      merged.PresetName,
+		Structure:   merged.Structure,
+		Rules:       merged.Rules,
+		ErrorPrompt: merged.ErrorPrompt,
+	}
+
+	var doc yaml.Node
+	if err := doc.Encode(effective); err != nil {
+		return "", fmt.Errorf("encoding effective config: %w", err)
+	}
+	c.annotate(&doc, "")
+
+	var buf bytes.Buffer
+	buf.WriteString("# Effective configuration: every value is annotated with the layer that defines it\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return "", fmt.Errorf("encoding effective config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("encoding effective config: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// annotate adds source comments to the values of a mapping node. Nested mappings are
+// descended into; lists of scalars are written inline so the comment fits on one line.
+func (c *Config) annotate(node *yaml.Node, path string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		keyPath := key.Value
+		if path != "" {
+			keyPath = path + "." + key.Value
+		}
+
+		switch value.Kind {
+		case yaml.MappingNode:
+			if len(value.Content) > 0 {
+				c.annotate(value, keyPath)
+				continue
+			}
+			value.Style = yaml.FlowStyle
+			value.LineComment = c.valueSource(keyPath)
+		case yaml.SequenceNode:
+			if isScalarSequence(value) {
+				value.Style = yaml.FlowStyle
+				value.LineComment = c.valueSource(keyPath)
+			} else {
+				key.LineComment = c.valueSource(keyPath)
+			}
+		default:
+			value.LineComment = c.valueSource(keyPath)
+		}
+	}
+}
+
+// isScalarSequence reports whether a sequence node only contains scalars
+func isScalarSequence(node *yaml.Node) bool {
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
+
+// valueSource returns where the value at the given dotted path comes from
+func (c *Config) valueSource(path string) string {
+	top := strings.SplitN(path, ".", 2)[0]
+	switch top {
+	case "structure", "rules", "error_prompt":
+		return c.GetRuleSource(path)
+	}
+	if source, ok := c.keySources[top]; ok {
+		return source
+	}
+	if c.isDefault {
+		return "defaults"
+	}
+	return ".goarchlint"
+}
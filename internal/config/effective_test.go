@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+)
+
+func TestConfig_Effective(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `
+module: example.com/test
+scan_paths:
+  - path: services/billing
+    module: example.com/billing
+preset:
+  name: ddd
+  structure:
+    required_directories:
+      internal/domain: Domain logic
+  rules:
+    directories_import:
+      cmd: [internal/app]
+    detect_unused: true
+overrides:
+  rules:
+    directories_import:
+      cmd/tool: [internal/app, internal/infra]
+    test_files:
+      lint: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	effective, err := cfg.Effective()
+	if err != nil {
+		t.Fatalf("Effective failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"module: example.com/test # .goarchlint\n",
+		"scan_paths: # .goarchlint\n  - path: services/billing\n    module: example.com/billing\n",
+		"ignore_paths: [vendor, testdata] # defaults\n",
+		"preset: ddd # .goarchlint\n",
+		"    internal/domain: Domain logic # preset ddd\n",
+		"    cmd: [internal/app] # preset ddd\n",
+		"    cmd/tool: [internal/app, internal/infra] # overrides\n",
+		"  detect_unused: true # preset ddd\n",
+		"    lint: true # overrides\n",
+		"    require_blackbox: false # preset ddd\n",
+	} {
+		if !strings.Contains(effective, expected) {
+			t.Errorf("expected %q in effective config, got:\n%s", expected, effective)
+		}
+	}
+}
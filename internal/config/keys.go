@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// unknownKeys returns a description of every mapping key in node that does not
+// correspond to a field of t, with the nearest valid key as a suggestion
+func unknownKeys(node *yaml.Node, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.DocumentNode {
+		var problems []string
+		for _, child := range node.Content {
+			problems = append(problems, unknownKeys(child, t, path)...)
+		}
+		return problems
+	}
+
+	// ExemptImports is a list, or a map keyed by free-form directory patterns
+	if t == reflect.TypeOf(ExemptImports{}) {
+		return nil
+	}
+
+	var problems []string
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinKeyPath(path, key.Value)
+			field, ok := fields[key.Value]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("line %d: unknown key %q%s", key.Line, keyPath, suggestKey(key.Value, fields)))
+				continue
+			}
+			problems = append(problems, unknownKeys(value, field, keyPath)...)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			problems = append(problems, unknownKeys(node.Content[i+1], t.Elem(), joinKeyPath(path, node.Content[i].Value))...)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return nil
+		}
+		for _, item := range node.Content {
+			problems = append(problems, unknownKeys(item, t.Elem(), path)...)
+		}
+	}
+	return problems
+}
+
+// yamlFields maps the YAML keys of a struct to the types of their fields
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func joinKeyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// suggestKey returns a " (did you mean ...?)" hint with the valid key closest to key,
+// or an empty string if no key is close enough to be a likely typo
+func suggestKey(key string, fields map[string]reflect.Type) string {
+	best := ""
+	bestDistance := 0
+	for candidate := range fields {
+		distance := levenshtein(key, candidate)
+		if best == "" || distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best == "" || bestDistance > 2+len(key)/4 {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// validateLayers checks the layers section: every layer has a name that cannot be
+// mistaken for a directory or split a rule key, and lists directories, and no directory
+// belongs to two layers
+func (c *Config) validateLayers() error {
+	names := make([]string, 0, len(c.Layers))
+	for name := range c.Layers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	owners := make(map[string]string)
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, "/.") {
+			return fmt.Errorf("layers: invalid layer name %q (names must not contain / or .)", name)
+		}
+		if len(c.Layers[name]) == 0 {
+			return fmt.Errorf("layers: %s lists no directories", name)
+		}
+		for _, dir := range c.Layers[name] {
+			if owner, ok := owners[dir]; ok {
+				return fmt.Errorf("layers: %s is in both %s and %s", dir, owner, name)
+			}
+			owners[dir] = name
+		}
+	}
+	return nil
+}
+
+// expandLayers replaces the names of the layers section by their directories in every
+// structure and rules section, and in the keys recorded for rule provenance, so rules
+// can be written in terms of layer names
+func (c *Config) expandLayers() {
+	if len(c.Layers) == 0 {
+		return
+	}
+	c.Structure.expandLayers(c.Layers)
+	c.Rules.expandLayers(c.Layers)
+	sections := []*OverridesSection{c.Overrides, c.bundle}
+	if c.Preset != nil {
+		c.Preset.Structure.expandLayers(c.Layers)
+		c.Preset.Rules.expandLayers(c.Layers)
+	}
+	for _, profile := range c.Profiles {
+		// The sections hold pointers, so expanding a copy updates the profile
+		sections = append(sections, &profile)
+	}
+	for _, section := range sections {
+		if section == nil {
+			continue
+		}
+		if section.Structure != nil {
+			section.Structure.expandLayers(c.Layers)
+		}
+		if section.Rules != nil {
+			section.Rules.expandLayers(c.Layers)
+		}
+	}
+
+	c.overrideKeys = expandLayerRuleKeys(c.overrideKeys, c.Layers)
+	c.bundleKeys = expandLayerRuleKeys(c.bundleKeys, c.Layers)
+	for name, keys := range c.profileKeys {
+		c.profileKeys[name] = expandLayerRuleKeys(keys, c.Layers)
+	}
+}
+
+// expandLayers replaces layer names by their directories in the structure section
+func (s *Structure) expandLayers(layers map[string][]string) {
+	s.RequiredDirectories = expandLayerKeys(s.RequiredDirectories, layers)
+	s.DomainLayers = expandLayerList(s.DomainLayers, layers)
+	s.PortLayers = expandLayerList(s.PortLayers, layers)
+	s.RequireReadme = expandLayerList(s.RequireReadme, layers)
+}
+
+// expandLayers replaces layer names by their directories in the rules section, in the
+// same places that hold directories normalizePaths cleans up
+func (r *Rules) expandLayers(layers map[string][]string) {
+	for key, allowed := range r.DirectoriesImport {
+		r.DirectoriesImport[key] = expandLayerList(allowed, layers)
+	}
+	r.DirectoriesImport = expandLayerKeys(r.DirectoriesImport, layers)
+
+	r.TestFiles.ExemptImports.ByDir = expandLayerKeys(r.TestFiles.ExemptImports.ByDir, layers)
+	r.TestFiles.SupportPackages = expandLayerList(r.TestFiles.SupportPackages, layers)
+	r.TestCoverage.PackageThresholds = expandLayerKeys(r.TestCoverage.PackageThresholds, layers)
+
+	r.TypeLeaks.Layers = expandLayerList(r.TypeLeaks.Layers, layers)
+	r.TypeLeaks.Forbidden = expandLayerList(r.TypeLeaks.Forbidden, layers)
+	r.ConfigLoading.Allowed = expandLayerList(r.ConfigLoading.Allowed, layers)
+	r.FrameworkLockIn.Allowed = expandLayerList(r.FrameworkLockIn.Allowed, layers)
+	r.Ownership.Teams = expandLayerKeys(r.Ownership.Teams, layers)
+	r.LicensePolicy.Layers = expandLayerKeys(r.LicensePolicy.Layers, layers)
+	r.ExternalImportDepth.Layers = expandLayerKeys(r.ExternalImportDepth.Layers, layers)
+	r.TestSetupImports.Helpers = expandLayerList(r.TestSetupImports.Helpers, layers)
+	r.PackageDocs.Layers = expandLayerList(r.PackageDocs.Layers, layers)
+	r.MainPackages = expandLayerList(r.MainPackages, layers)
+	r.RequireShuffleClean = expandLayerList(r.RequireShuffleClean, layers)
+	r.RequireRaceClean = expandLayerList(r.RequireRaceClean, layers)
+	r.OrphanInterfaces.Layers = expandLayerList(r.OrphanInterfaces.Layers, layers)
+	r.AdapterPorts.Adapters = expandLayerList(r.AdapterPorts.Adapters, layers)
+	r.AdapterPorts.Ports = expandLayerList(r.AdapterPorts.Ports, layers)
+
+	if r.ActiveFrom != nil {
+		activeFrom := make(map[string]string, len(r.ActiveFrom))
+		for key, date := range r.ActiveFrom {
+			for _, expanded := range expandLayerRuleKey(key, layers) {
+				activeFrom[expanded] = date
+			}
+		}
+		r.ActiveFrom = activeFrom
+	}
+}
+
+// expandLayerList replaces layer names in a list of directories by their directories,
+// keeping the order and dropping duplicates
+func expandLayerList(paths []string, layers map[string][]string) []string {
+	if paths == nil {
+		return nil
+	}
+	expanded := make([]string, 0, len(paths))
+	seen := make(map[string]bool)
+	for _, p := range paths {
+		dirs, ok := layers[p]
+		if !ok {
+			dirs = []string{p}
+		}
+		for _, dir := range dirs {
+			if !seen[dir] {
+				seen[dir] = true
+				expanded = append(expanded, dir)
+			}
+		}
+	}
+	return expanded
+}
+
+// expandLayerKeys replaces layer names among the keys of a map by their directories,
+// each taking the layer's value. A directory that is a key itself keeps its own value.
+func expandLayerKeys[V any](m map[string]V, layers map[string][]string) map[string]V {
+	if m == nil {
+		return nil
+	}
+	expanded := make(map[string]V, len(m))
+	for key, value := range m {
+		for _, dir := range layers[key] {
+			expanded[dir] = value
+		}
+	}
+	for key, value := range m {
+		if _, ok := layers[key]; !ok {
+			expanded[key] = value
+		}
+	}
+	return expanded
+}
+
+// expandLayerRuleKeys expands the layer names in dotted rule keys, e.g.
+// rules.directories_import.domain to one key per directory of the domain layer
+func expandLayerRuleKeys(keys []string, layers map[string][]string) []string {
+	if keys == nil {
+		return nil
+	}
+	var expanded []string
+	for _, key := range keys {
+		expanded = append(expanded, expandLayerRuleKey(key, layers)...)
+	}
+	sort.Strings(expanded)
+	return expanded
+}
+
+// expandLayerRuleKey returns the keys a dotted rule key stands for: one per directory
+// if one of its elements is a layer name, and the key itself otherwise
+func expandLayerRuleKey(key string, layers map[string][]string) []string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		dirs, ok := layers[part]
+		if !ok || i < 2 {
+			continue
+		}
+		keys := make([]string, len(dirs))
+		for j, dir := range dirs {
+			expandedParts := append([]string{}, parts...)
+			expandedParts[i] = dir
+			keys[j] = strings.Join(expandedParts, ".")
+		}
+		return keys
+	}
+	return []string{key}
+}
@@ -0,0 +1,262 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// flatKeys are the top-level keys of the old flat format replaced by preset/overrides
+var flatKeys = map[string]bool{"preset_used": true, "structure": true, "rules": true, "error_prompt": true}
+
+// MigrateFlat converts a configuration in the old flat format (preset_used and
+// top-level structure/rules/error_prompt) to the preset/overrides format. Other
+// top-level keys are kept in place, and version is set to the current SchemaVersion.
+//
+// preset is the current content of the preset named by preset_used, or nil if the
+// configuration was not created from a known preset. The overrides then hold only
+// the values that differ from the preset. If the flat rules cannot be expressed as
+// overrides of the preset (e.g. they remove a preset entry), they are kept as the
+// preset section instead and a warning is returned.
+func MigrateFlat(data []byte, preset *PresetSection) ([]byte, []string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("config file is not a YAML mapping")
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	if cfg.Preset != nil || cfg.Overrides != nil {
+		return nil, nil, fmt.Errorf("config already uses the preset/overrides format")
+	}
+
+	root := doc.Content[0]
+	hasFlatKeys := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if flatKeys[root.Content[i].Value] {
+			hasFlatKeys = true
+		}
+	}
+	if !hasFlatKeys {
+		return nil, nil, fmt.Errorf("config has no flat-format rules to migrate")
+	}
+
+	flat := PresetSection{
+		Name:        cfg.PresetUsed,
+		Structure:   cfg.Structure,
+		Rules:       cfg.Rules,
+		ErrorPrompt: cfg.ErrorPrompt,
+	}
+	if flat.Name == "" {
+		flat.Name = "custom"
+	}
+
+	var warnings []string
+	section := flat
+	var overrides *OverridesSection
+	if preset != nil {
+		candidate := diffOverrides(*preset, flat)
+		if exact, err := reproduces(*preset, candidate, flat); err != nil {
+			return nil, nil, err
+		} else if exact {
+			section = *preset
+			section.Name = flat.Name
+			overrides = candidate
+		} else {
+			warnings = append(warnings, fmt.Sprintf("rules differ from preset %q in ways overrides cannot express; they were kept as the preset section, so review them before running 'refresh'", flat.Name))
+		}
+	}
+
+	// Rebuild the top-level mapping, putting the new sections where the flat keys were
+	var content []*yaml.Node
+	inserted := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if !flatKeys[key.Value] {
+			content = append(content, key, value)
+			continue
+		}
+		if inserted {
+			continue
+		}
+		inserted = true
+		nodes, err := sectionNodes(section, overrides)
+		if err != nil {
+			return nil, nil, err
+		}
+		content = append(content, nodes...)
+	}
+	root.Content = setSchemaVersion(content)
+
+	var buf bytes.Buffer
+	buf.WriteString("# Migrated by go-arch-lint config migrate from the flat format\n")
+	buf.WriteString("# Previous config backed up to .goarchlint.backup\n\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("encoding config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, nil, fmt.Errorf("encoding config: %w", err)
+	}
+	return buf.Bytes(), warnings, nil
+}
+
+// sectionNodes returns the key and value nodes of the preset and overrides sections
+func sectionNodes(section PresetSection, overrides *OverridesSection) ([]*yaml.Node, error) {
+	sections := struct {
+		Preset    PresetSection     `yaml:"preset"`
+		Overrides *OverridesSection `yaml:"overrides,omitempty"`
+	}{section, overrides}
+
+	var node yaml.Node
+	if err := node.Encode(sections); err != nil {
+		return nil, fmt.Errorf("encoding config: %w", err)
+	}
+	if len(node.Content) == 4 {
+		pruneZeroValues(node.Content[3])
+	}
+	return node.Content, nil
+}
+
+// pruneZeroValues removes false, empty and zero entries from a mapping node. Such
+// overrides have no effect when merged, so they are only noise in the overrides section.
+func pruneZeroValues(node *yaml.Node) {
+	var content []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind == yaml.MappingNode {
+			pruneZeroValues(value)
+		}
+		if isZeroNode(value) {
+			continue
+		}
+		content = append(content, key, value)
+	}
+	node.Content = content
+}
+
+// isZeroNode reports whether a node is false, zero, an empty string or an empty collection
+func isZeroNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(node.Content) == 0
+	case yaml.ScalarNode:
+		return node.Value == "false" || node.Value == "0" || node.Value == ""
+	}
+	return false
+}
+
+// diffOverrides returns the overrides that turn the preset into the flat configuration.
+// Only the differing map entries and fields are included.
+func diffOverrides(preset, flat PresetSection) *OverridesSection {
+	overrides := &OverridesSection{}
+	if diff, ok := diffValue(reflect.ValueOf(preset.Structure), reflect.ValueOf(flat.Structure)); ok {
+		structure := diff.Interface().(Structure)
+		overrides.Structure = &structure
+	}
+	if diff, ok := diffValue(reflect.ValueOf(preset.Rules), reflect.ValueOf(flat.Rules)); ok {
+		rules := diff.Interface().(Rules)
+		overrides.Rules = &rules
+	}
+	if diff, ok := diffValue(reflect.ValueOf(preset.ErrorPrompt), reflect.ValueOf(flat.ErrorPrompt)); ok {
+		errorPrompt := diff.Interface().(ErrorPrompt)
+		overrides.ErrorPrompt = &errorPrompt
+	}
+	if overrides.Structure == nil && overrides.Rules == nil && overrides.ErrorPrompt == nil {
+		return nil
+	}
+	return overrides
+}
+
+// diffValue returns the part of flat that differs from base: differing fields of a
+// struct, differing entries of a map, or flat itself. It reports false if both are equal.
+func diffValue(base, flat reflect.Value) (reflect.Value, bool) {
+	if reflect.DeepEqual(base.Interface(), flat.Interface()) {
+		return reflect.Value{}, false
+	}
+	if flat.Kind() == reflect.Slice && flat.Len() == 0 && base.Len() == 0 {
+		return reflect.Value{}, false
+	}
+
+	switch flat.Kind() {
+	case reflect.Struct:
+		result := reflect.New(flat.Type()).Elem()
+		for i := 0; i < flat.NumField(); i++ {
+			if diff, ok := diffValue(base.Field(i), flat.Field(i)); ok {
+				result.Field(i).Set(diff)
+			}
+		}
+		return result, true
+	case reflect.Map:
+		result := reflect.MakeMap(flat.Type())
+		for _, key := range flat.MapKeys() {
+			baseValue := base.MapIndex(key)
+			if !baseValue.IsValid() || !reflect.DeepEqual(baseValue.Interface(), flat.MapIndex(key).Interface()) {
+				result.SetMapIndex(key, flat.MapIndex(key))
+			}
+		}
+		if result.Len() == 0 {
+			return reflect.Value{}, false
+		}
+		return result, true
+	default:
+		return flat, true
+	}
+}
+
+// reproduces reports whether merging the overrides into the preset yields exactly the
+// flat configuration. Sections are compared in their YAML form.
+func reproduces(preset PresetSection, overrides *OverridesSection, flat PresetSection) (bool, error) {
+	// Merging modifies maps in place, so merge a copy of the preset
+	var base PresetSection
+	data, err := yaml.Marshal(preset)
+	if err != nil {
+		return false, fmt.Errorf("encoding preset: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &base); err != nil {
+		return false, fmt.Errorf("decoding preset: %w", err)
+	}
+
+	merged := PresetSection{Name: flat.Name, Structure: base.Structure, Rules: base.Rules, ErrorPrompt: base.ErrorPrompt}
+	if overrides != nil {
+		merged.Structure = mergeStructure(merged.Structure, overrides.Structure)
+		merged.Rules = mergeRules(merged.Rules, overrides.Rules)
+		merged.ErrorPrompt = mergeErrorPrompt(merged.ErrorPrompt, overrides.ErrorPrompt)
+	}
+
+	mergedData, err := yaml.Marshal(merged)
+	if err != nil {
+		return false, fmt.Errorf("encoding config: %w", err)
+	}
+	flatData, err := yaml.Marshal(flat)
+	if err != nil {
+		return false, fmt.Errorf("encoding config: %w", err)
+	}
+	return bytes.Equal(mergedData, flatData), nil
+}
+
+// setSchemaVersion sets the version key of top-level mapping content to SchemaVersion,
+// adding it first when missing
+func setSchemaVersion(content []*yaml.Node) []*yaml.Node {
+	value := fmt.Sprint(SchemaVersion)
+	for i := 0; i+1 < len(content); i += 2 {
+		if content[i].Value == "version" {
+			content[i+1].Value = value
+			return content
+		}
+	}
+	// The comment heading the file stays on top
+	key := &yaml.Node{Kind: yaml.ScalarNode, Value: "version"}
+	if len(content) > 0 {
+		key.HeadComment, content[0].HeadComment = content[0].HeadComment, ""
+	}
+	return append([]*yaml.Node{key, {Kind: yaml.ScalarNode, Value: value}}, content...)
+}
@@ -0,0 +1,113 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+)
+
+func testPreset() *config.PresetSection {
+	return &config.PresetSection{
+		Name: "simple",
+		Rules: config.Rules{
+			DirectoriesImport: map[string][]string{
+				"cmd":      {"pkg"},
+				"pkg":      {"internal"},
+				"internal": {},
+			},
+			DetectUnused: true,
+		},
+	}
+}
+
+func TestMigrateFlat_OverridesHoldCustomizations(t *testing.T) {
+	flat := `# Project rules
+module: example.com/test
+preset_used: simple
+scan_paths:
+  - cmd
+  - pkg
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: [internal]
+    internal: []
+    scripts: [pkg]
+  detect_unused: true
+  detect_duplicates: true
+ignore_paths:
+  - vendor
+`
+	migrated, warnings, err := config.MigrateFlat([]byte(flat), testPreset())
+	if err != nil {
+		t.Fatalf("MigrateFlat failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+
+	result := string(migrated)
+	for _, expected := range []string{
+		"# Project rules\nversion: 1\nmodule: example.com/test\n",
+		"preset:\n  name: simple\n",
+		"overrides:\n  rules:\n    directories_import:\n      scripts:\n        - pkg\n",
+		"    detect_duplicates: true\n",
+		"ignore_paths:\n  - vendor\n",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %q in migrated config, got:\n%s", expected, result)
+		}
+	}
+	if strings.Contains(result, "preset_used") {
+		t.Errorf("expected preset_used to be removed, got:\n%s", result)
+	}
+	if strings.Index(result, "preset:") > strings.Index(result, "scan_paths:") {
+		t.Errorf("expected new sections in place of preset_used, got:\n%s", result)
+	}
+	overrides := result[strings.Index(result, "overrides:"):]
+	if strings.Contains(overrides, "cmd:") || strings.Contains(overrides, "detect_unused") {
+		t.Errorf("expected only customized entries in overrides, got:\n%s", overrides)
+	}
+}
+
+func TestMigrateFlat_NotExpressibleKeepsRules(t *testing.T) {
+	// internal may import nothing in the preset, but the flat config dropped the rule
+	flat := `preset_used: simple
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: [internal]
+  detect_unused: false
+`
+	migrated, warnings, err := config.MigrateFlat([]byte(flat), testPreset())
+	if err != nil {
+		t.Fatalf("MigrateFlat failed: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected a warning, got %v", warnings)
+	}
+	if strings.Contains(string(migrated), "overrides:") || strings.Contains(string(migrated), "internal: []") {
+		t.Errorf("expected flat rules as preset section, got:\n%s", migrated)
+	}
+}
+
+func TestMigrateFlat_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"already migrated", "preset:\n  name: simple\n", "already uses"},
+		{"nothing to migrate", "module: example.com/test\n", "no flat-format rules"},
+		{"invalid yaml", "rules: [", "parsing config file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := config.MigrateFlat([]byte(tt.data), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Run modes selected with --mode: the fast default skips the checks listed under
+// modes.full, the full mode runs every enabled check
+const (
+	ModeFast = "fast"
+	ModeFull = "full"
+)
+
+// Checks the modes section can reserve for full mode
+const (
+	CheckTestCoverage = "test_coverage"         // Runs the tests with coverage
+	CheckStaticcheck  = "staticcheck"           // Runs staticcheck
+	CheckTypeChecks   = "type_checks"           // Type-checks packages for type_leaks, orphan_interfaces and adapter_ports
+	CheckShuffleClean = "require_shuffle_clean" // Runs the tests of the listed directories in random order
+)
+
+// CheckRaceClean runs the tests of the listed directories with the race detector. It
+// is too slow for the fast mode and always runs only with --mode=full.
+const CheckRaceClean = "require_race_clean"
+
+// modeChecks lists the checks the modes section accepts, in the order of error messages
+var modeChecks = []string{CheckTestCoverage, CheckStaticcheck, CheckTypeChecks, CheckShuffleClean}
+
+// fullOnlyChecks lists the checks that run only in full mode without being listed
+var fullOnlyChecks = []string{CheckRaceClean}
+
+// Modes splits the checks into a fast local mode and a full mode for CI
+type Modes struct {
+	Full []string `yaml:"full,omitempty"` // Expensive checks that only run with --mode=full
+}
+
+// validateModes checks that the modes section only names known checks
+func (c *Config) validateModes() error {
+	for _, check := range c.Modes.Full {
+		if !listsCheck(modeChecks, check) {
+			return fmt.Errorf("modes: unknown check %q in full (available: %s)", check, strings.Join(modeChecks, ", "))
+		}
+	}
+	return nil
+}
+
+// SelectMode selects the run mode, fast or full. An empty name selects the fast mode.
+func (c *Config) SelectMode(name string) error {
+	if name != "" && name != ModeFast && name != ModeFull {
+		return fmt.Errorf("unknown mode %q (available: %s, %s)", name, ModeFast, ModeFull)
+	}
+	c.mode = name
+	return nil
+}
+
+// RunsCheck reports whether an expensive check runs in the selected mode: checks listed
+// under modes.full, and the full-only checks, are skipped unless the full mode is
+// selected. Whether the check is enabled at all is up to its own settings.
+func (c *Config) RunsCheck(check string) bool {
+	return c.mode == ModeFull || (!listsCheck(c.Modes.Full, check) && !listsCheck(fullOnlyChecks, check))
+}
+
+// listsCheck reports whether checks contains check
+func listsCheck(checks []string, check string) bool {
+	for _, listed := range checks {
+		if listed == check {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package config
+
+import "strings"
+
+// normalizePath turns a directory, package or pattern from the configuration into the
+// slash-separated form the linter matches paths against: backslashes in literal paths
+// become slashes and a leading "./" is dropped, so a configuration written on Windows
+// matches the same directories on every OS. In glob patterns a backslash escapes a
+// metacharacter (`\*`), so patterns keep their backslashes.
+func normalizePath(p string) string {
+	if !strings.ContainsAny(p, "*?[") {
+		p = strings.ReplaceAll(p, `\`, "/")
+	}
+	if len(p) > 2 && strings.HasPrefix(p, "./") {
+		p = p[2:]
+	}
+	return p
+}
+
+// normalizePathList normalizes every path of a list in place
+func normalizePathList(paths []string) {
+	for i := range paths {
+		paths[i] = normalizePath(paths[i])
+	}
+}
+
+// normalizePathKeys returns m with normalized keys
+func normalizePathKeys[V any](m map[string]V) map[string]V {
+	if m == nil {
+		return nil
+	}
+	normalized := make(map[string]V, len(m))
+	for key, value := range m {
+		normalized[normalizePath(key)] = value
+	}
+	return normalized
+}
+
+// normalizePaths normalizes the paths of all configuration sections
+func (c *Config) normalizePaths() {
+	for i := range c.ScanPaths {
+		c.ScanPaths[i].Path = normalizePath(c.ScanPaths[i].Path)
+	}
+	normalizePathList(c.IgnorePaths)
+	for _, dirs := range c.Layers {
+		normalizePathList(dirs)
+	}
+
+	c.Structure.normalizePaths()
+	c.Rules.normalizePaths()
+	sections := []*OverridesSection{c.Overrides, c.bundle}
+	if c.Preset != nil {
+		c.Preset.Structure.normalizePaths()
+		c.Preset.Rules.normalizePaths()
+	}
+	for _, profile := range c.Profiles {
+		// The sections hold pointers, so normalizing a copy updates the profile
+		sections = append(sections, &profile)
+	}
+	for _, section := range sections {
+		if section == nil {
+			continue
+		}
+		if section.Structure != nil {
+			section.Structure.normalizePaths()
+		}
+		if section.Rules != nil {
+			section.Rules.normalizePaths()
+		}
+	}
+}
+
+// normalizePaths normalizes the directories of the structure section
+func (s *Structure) normalizePaths() {
+	s.RequiredDirectories = normalizePathKeys(s.RequiredDirectories)
+	normalizePathList(s.DomainLayers)
+	normalizePathList(s.PortLayers)
+	normalizePathList(s.RequireReadme)
+}
+
+// normalizePaths normalizes the directories, packages and patterns of the rules section
+func (r *Rules) normalizePaths() {
+	r.DirectoriesImport = normalizePathKeys(r.DirectoriesImport)
+	for _, allowed := range r.DirectoriesImport {
+		normalizePathList(allowed)
+	}
+	for module, wrapper := range r.WrapIn {
+		r.WrapIn[module] = normalizePath(wrapper)
+	}
+
+	r.TestFiles.ExemptImports.ByDir = normalizePathKeys(r.TestFiles.ExemptImports.ByDir)
+	for i := range r.TestFiles.AllowWhitebox {
+		r.TestFiles.AllowWhitebox[i].Path = normalizePath(r.TestFiles.AllowWhitebox[i].Path)
+	}
+	normalizePathList(r.TestFiles.SupportPackages)
+	r.TestCoverage.PackageThresholds = normalizePathKeys(r.TestCoverage.PackageThresholds)
+
+	normalizePathList(r.TypeLeaks.Layers)
+	normalizePathList(r.TypeLeaks.Forbidden)
+	normalizePathList(r.ConfigLoading.Allowed)
+	normalizePathList(r.FrameworkLockIn.Allowed)
+	r.Ownership.Teams = normalizePathKeys(r.Ownership.Teams)
+	r.LicensePolicy.Layers = normalizePathKeys(r.LicensePolicy.Layers)
+	r.ExternalImportDepth.Layers = normalizePathKeys(r.ExternalImportDepth.Layers)
+	normalizePathList(r.Ownership.Contracts)
+	normalizePathList(r.TestSetupImports.Helpers)
+	normalizePathList(r.PackageDocs.Layers)
+	normalizePathList(r.MainPackages)
+	normalizePathList(r.RequireShuffleClean)
+	normalizePathList(r.RequireRaceClean)
+	normalizePathList(r.OrphanInterfaces.Layers)
+	normalizePathList(r.AdapterPorts.Adapters)
+	normalizePathList(r.AdapterPorts.Ports)
+	r.ActiveFrom = normalizePathKeys(r.ActiveFrom)
+}
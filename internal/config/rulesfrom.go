@@ -0,0 +1,313 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RulesFrom points to a shared rule bundle in a git repository. In YAML it is either the
+// source alone or a mapping with source and commit keys.
+type RulesFrom struct {
+	Source string `yaml:"source"`           // git::<repository URL>[//<subdirectory>][?ref=<tag or branch>]
+	Commit string `yaml:"commit,omitempty"` // Commit SHA the source must resolve to (empty: not pinned)
+}
+
+// UnmarshalYAML accepts both "git::https://..." and {source: git::https://..., commit: ...}
+func (rf *RulesFrom) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		rf.Source = value.Value
+		return nil
+	}
+
+	type rawRulesFrom RulesFrom
+	var raw rawRulesFrom
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw.Source == "" {
+		return fmt.Errorf("line %d: rules_from requires a source", value.Line)
+	}
+	*rf = RulesFrom(raw)
+	return nil
+}
+
+// MarshalYAML writes an unpinned source as a scalar to keep configs compact
+func (rf RulesFrom) MarshalYAML() (interface{}, error) {
+	if rf.Commit == "" {
+		return rf.Source, nil
+	}
+	type rawRulesFrom RulesFrom
+	return rawRulesFrom(rf), nil
+}
+
+// rulesBundleFile is the file in the bundle directory that holds the shared rules
+const rulesBundleFile = "rules.yaml"
+
+// rulesRefreshInterval is how long a fetched unpinned bundle is used before its ref is
+// fetched again, so repeated loads within a run or a CI job do not refetch it
+const rulesRefreshInterval = time.Hour
+
+// rulesSource is a parsed rules_from source
+type rulesSource struct {
+	repo   string // Repository URL as passed to git
+	subdir string // Bundle directory inside the repository (empty: the root)
+	ref    string // Tag or branch (empty: the commit pin, or the default branch)
+}
+
+// parseRulesSource parses the go-getter style address git::<url>[//<subdir>][?ref=<ref>].
+// The subdirectory separator is the first // after the URL scheme.
+func parseRulesSource(source string) (rulesSource, error) {
+	var src rulesSource
+	rest, ok := strings.CutPrefix(source, "git::")
+	if !ok {
+		return src, fmt.Errorf("unsupported source %q (expected git::<repository URL>[//<subdirectory>][?ref=<ref>])", source)
+	}
+
+	if i := strings.Index(rest, "?"); i >= 0 {
+		query, err := url.ParseQuery(rest[i+1:])
+		if err != nil {
+			return src, fmt.Errorf("source %q: %w", source, err)
+		}
+		for key := range query {
+			if key != "ref" {
+				return src, fmt.Errorf("source %q: unsupported parameter %q (only ref is supported)", source, key)
+			}
+		}
+		src.ref = query.Get("ref")
+		rest = rest[:i]
+	}
+
+	start := 0
+	if i := strings.Index(rest, "://"); i >= 0 {
+		start = i + len("://")
+	}
+	if i := strings.Index(rest[start:], "//"); i >= 0 {
+		src.subdir = strings.Trim(rest[start+i+len("//"):], "/")
+		rest = rest[:start+i]
+	}
+	if src.subdir != "" {
+		src.subdir = path.Clean(src.subdir)
+		if src.subdir == ".." || strings.HasPrefix(src.subdir, "../") {
+			return src, fmt.Errorf("source %q: subdirectory must stay inside the repository", source)
+		}
+	}
+
+	src.repo = rest
+	if src.repo == "" {
+		return src, fmt.Errorf("source %q: missing repository URL", source)
+	}
+	// The repository and ref reach git as arguments, so neither may look like an option
+	if strings.HasPrefix(src.ref, "-") {
+		return src, fmt.Errorf("source %q: ref must not start with '-'", source)
+	}
+	if err := checkRulesRepo(src.repo); err != nil {
+		return src, fmt.Errorf("source %q: %w", source, err)
+	}
+	return src, nil
+}
+
+// checkRulesRepo accepts https://, ssh:// and file:// repository URLs and the scp-like
+// user@host:path form of ssh. Other transports (ext::, plain paths, ...) are rejected, as
+// they can run commands or read arbitrary locations.
+func checkRulesRepo(repo string) error {
+	if strings.HasPrefix(repo, "-") {
+		return fmt.Errorf("repository URL must not start with '-'")
+	}
+	if u, err := url.Parse(repo); err == nil && u.Scheme != "" {
+		switch u.Scheme {
+		case "https", "ssh", "file":
+		default:
+			return fmt.Errorf("unsupported repository scheme %q (expected https, ssh or file)", u.Scheme)
+		}
+		if strings.HasPrefix(u.Host, "-") || strings.HasPrefix(u.User.Username(), "-") {
+			return fmt.Errorf("repository host must not start with '-'")
+		}
+		return nil
+	}
+	if user, host, ok := strings.Cut(repo, "@"); ok && user != "" {
+		if host, _, ok := strings.Cut(host, ":"); ok && host != "" && !strings.ContainsAny(host, "/:") && !strings.HasPrefix(host, "-") {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported repository URL %q (expected https://, ssh://, file:// or user@host:path)", repo)
+}
+
+// loadRulesFrom fetches the rule bundle named by rules_from into the cache and reads it.
+// A pinned bundle is fetched once and then read from the cache, so runs work offline; the
+// fetched commit must match the pin. An unpinned bundle follows its ref: it is fetched
+// again once the cached copy is older than rulesRefreshInterval, falling back to the
+// cache when the fetch fails.
+func (c *Config) loadRulesFrom() error {
+	if c.RulesFrom == nil {
+		return nil
+	}
+	if c.Preset == nil && c.hasFlatRules() {
+		return fmt.Errorf("rules_from needs the preset/overrides format; run 'go-arch-lint config migrate' first")
+	}
+
+	src, err := parseRulesSource(c.RulesFrom.Source)
+	if err != nil {
+		return fmt.Errorf("rules_from: %w", err)
+	}
+	commit := strings.ToLower(c.RulesFrom.Commit)
+	if commit != "" && !isCommitSHA(commit) {
+		return fmt.Errorf("rules_from: commit %q is not a full commit SHA (40 hex characters)", c.RulesFrom.Commit)
+	}
+
+	dir, err := rulesCacheDir(src, commit)
+	if err != nil {
+		return fmt.Errorf("rules_from: %w", err)
+	}
+
+	cached, fresh := false, false
+	if info, err := os.Stat(dir); err == nil {
+		cached = true
+		fresh = time.Since(info.ModTime()) < rulesRefreshInterval
+	}
+	if !cached || (commit == "" && !fresh) {
+		if err := fetchRules(dir, src, commit); err != nil {
+			if !cached {
+				return fmt.Errorf("rules_from: fetching %s: %w", c.RulesFrom.Source, err)
+			}
+			c.warnings = append(c.warnings, fmt.Sprintf("rules_from: fetching %s failed, using the cached bundle: %v", c.RulesFrom.Source, err))
+		}
+	}
+
+	head, err := runGit(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("rules_from: reading cached bundle: %w", err)
+	}
+	if commit != "" && head != commit {
+		return fmt.Errorf("rules_from: %s resolved to commit %s, but commit pins %s; review the bundle changes and update the pin", c.RulesFrom.Source, head, c.RulesFrom.Commit)
+	}
+	if commit == "" {
+		c.warnings = append(c.warnings, fmt.Sprintf("rules_from: %s is not pinned; add 'commit: %s' to rules_from to pin the current bundle", c.RulesFrom.Source, head))
+	}
+
+	bundlePath := filepath.Join(dir, filepath.FromSlash(src.subdir), rulesBundleFile)
+	data, err := os.ReadFile(bundlePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("rules_from: %s has no %s", c.RulesFrom.Source, path.Join(src.subdir, rulesBundleFile))
+		}
+		return fmt.Errorf("rules_from: %w", err)
+	}
+	return c.parseRulesBundle(data)
+}
+
+// parseRulesBundle reads the structure, rules and error_prompt sections of a bundle and
+// remembers which keys it sets, for rule provenance
+func (c *Config) parseRulesBundle(data []byte) error {
+	var bundle OverridesSection
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return fmt.Errorf("rules_from: parsing %s: %w", rulesBundleFile, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("rules_from: parsing %s: %w", rulesBundleFile, err)
+	}
+	if problems := unknownKeys(&doc, reflect.TypeOf(bundle), ""); len(problems) > 0 {
+		return fmt.Errorf("rules_from: parsing %s: %s", rulesBundleFile, strings.Join(problems, "; "))
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("rules_from: parsing %s: %w", rulesBundleFile, err)
+	}
+	c.bundle = &bundle
+	c.bundleKeys = flattenKeys("", raw)
+	return nil
+}
+
+// hasFlatRules reports whether the old flat format sets structure or rules
+func (c *Config) hasFlatRules() bool {
+	return !reflect.DeepEqual(c.Structure, Structure{}) || !reflect.DeepEqual(c.Rules, Rules{}) || c.PresetUsed != ""
+}
+
+// rulesCacheDir returns the cache directory of a bundle: one per repository, ref and pin
+func rulesCacheDir(src rulesSource, commit string) (string, error) {
+	cacheRoot, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating the user cache directory: %w", err)
+	}
+	sum := sha256.Sum256([]byte(src.repo + "\x00" + src.ref + "\x00" + commit))
+	return filepath.Join(cacheRoot, "go-arch-lint", "rules", hex.EncodeToString(sum[:8])), nil
+}
+
+// fetchRules makes a shallow checkout of the bundle's ref (or pinned commit) in dir. The
+// checkout is prepared next to dir and swapped in when complete, so an interrupted fetch
+// never leaves a partial bundle behind.
+func fetchRules(dir string, src rulesSource, commit string) error {
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), filepath.Base(dir)+".fetch-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	want := src.ref
+	if want == "" {
+		want = commit
+	}
+	if want == "" {
+		want = "HEAD"
+	}
+	if _, err := runGit(tmp, "init", "-q"); err != nil {
+		return err
+	}
+	if _, err := runGit(tmp, "fetch", "-q", "--depth", "1", "--", src.repo, want); err != nil {
+		return err
+	}
+	if _, err := runGit(tmp, "checkout", "-q", "FETCH_HEAD"); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return err
+	}
+	// The modification time of the bundle directory records when it was fetched
+	now := time.Now()
+	return os.Chtimes(dir, now, now)
+}
+
+// runGit runs git in dir without prompting for credentials and returns its trimmed output
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if _, lookErr := exec.LookPath("git"); lookErr != nil {
+			return "", fmt.Errorf("git not found in PATH")
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// isCommitSHA reports whether s is a full hex commit SHA: 40 characters for SHA-1 or 64
+// for SHA-256 repositories. Servers only fetch commits by their full SHA, so abbreviated
+// pins are not accepted.
+func isCommitSHA(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,234 @@
+package config_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+)
+
+// runGit runs a git command in dir and returns its trimmed output, failing the test on error
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// newRulesRepo creates a git repository with a golang/rules.yaml bundle tagged v1 and
+// returns its file:// URL and the tagged commit. The user cache is redirected to a
+// temporary directory.
+func newRulesRepo(t *testing.T, bundle string) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	repoDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repoDir, "golang"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "golang", "rules.yaml"), []byte(bundle), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repoDir, "init", "-q")
+	runGit(t, repoDir, "add", "-A")
+	runGit(t, repoDir, "commit", "-q", "-m", "rules")
+	runGit(t, repoDir, "tag", "v1")
+	return "file://" + filepath.ToSlash(repoDir), runGit(t, repoDir, "rev-parse", "HEAD")
+}
+
+func writeConfig(t *testing.T, configYAML string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return tmpDir
+}
+
+func TestLoad_RulesFrom_MergesBetweenPresetAndOverrides(t *testing.T) {
+	repoURL, commit := newRulesRepo(t, `rules:
+  directories_import:
+    internal/domain: []
+    internal/app: [internal/domain]
+  detect_unused: true
+`)
+
+	tmpDir := writeConfig(t, `module: example.com/test
+rules_from:
+  source: git::`+repoURL+`//golang?ref=v1
+  commit: `+commit+`
+preset:
+  name: simple
+  structure:
+    required_directories: {}
+  rules:
+    directories_import:
+      cmd: [internal]
+      internal/domain: [internal/util]
+overrides:
+  rules:
+    directories_import:
+      internal/app: [internal/domain, internal/util]
+`)
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if warnings := cfg.GetWarnings(); len(warnings) != 0 {
+		t.Errorf("expected no warnings for a pinned bundle, got %v", warnings)
+	}
+
+	imports := cfg.GetDirectoriesImport()
+	if got := imports["cmd"]; len(got) != 1 || got[0] != "internal" {
+		t.Errorf("expected the preset rule for cmd, got %v", got)
+	}
+	if got := imports["internal/domain"]; len(got) != 0 {
+		t.Errorf("expected the bundle to replace the preset rule for internal/domain, got %v", got)
+	}
+	if got := imports["internal/app"]; len(got) != 2 {
+		t.Errorf("expected the overrides to replace the bundle rule for internal/app, got %v", got)
+	}
+	if !cfg.ShouldDetectUnused() {
+		t.Error("expected detect_unused from the bundle")
+	}
+
+	source := "rules_from git::" + repoURL + "//golang?ref=v1"
+	sources := map[string]string{
+		"rules.directories_import.cmd":             "preset simple",
+		"rules.directories_import.internal/domain": source,
+		"rules.directories_import.internal/app":    "overrides",
+		"rules.detect_unused":                      source,
+	}
+	for key, want := range sources {
+		if got := cfg.GetRuleSource(key); got != want {
+			t.Errorf("GetRuleSource(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLoad_RulesFrom_PinnedBundleIsCached(t *testing.T) {
+	repoURL, commit := newRulesRepo(t, "rules:\n  detect_unused: true\n")
+	tmpDir := writeConfig(t, "module: example.com/test\nrules_from:\n  source: git::"+repoURL+"//golang?ref=v1\n  commit: "+commit+"\n")
+
+	if _, err := config.Load(tmpDir); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// The repository is gone: the pinned bundle must come from the cache
+	if err := os.RemoveAll(strings.TrimPrefix(repoURL, "file://")); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load from cache failed: %v", err)
+	}
+	if !cfg.ShouldDetectUnused() {
+		t.Error("expected detect_unused from the cached bundle")
+	}
+}
+
+func TestLoad_RulesFrom_RejectsCommitMismatch(t *testing.T) {
+	repoURL, _ := newRulesRepo(t, "rules:\n  detect_unused: true\n")
+	tmpDir := writeConfig(t, "module: example.com/test\nrules_from:\n  source: git::"+repoURL+"//golang?ref=v1\n  commit: 0123456789abcdef0123456789abcdef01234567\n")
+
+	_, err := config.Load(tmpDir)
+	if err == nil || !strings.Contains(err.Error(), "but commit pins 0123456789abcdef0123456789abcdef01234567") {
+		t.Errorf("expected a commit mismatch error, got %v", err)
+	}
+}
+
+func TestLoad_RulesFrom_UnpinnedWarns(t *testing.T) {
+	repoURL, commit := newRulesRepo(t, "rules:\n  detect_unused: true\n")
+	tmpDir := writeConfig(t, "module: example.com/test\nrules_from: git::"+repoURL+"//golang?ref=v1\n")
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.ShouldDetectUnused() {
+		t.Error("expected detect_unused from the bundle")
+	}
+	warnings := cfg.GetWarnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "commit: "+commit) {
+		t.Errorf("expected a warning suggesting the pin %s, got %v", commit, warnings)
+	}
+	if got := cfg.GetRuleSource("rules.directories_import.cmd"); got != "defaults" {
+		t.Errorf("expected keys the bundle does not set to come from the defaults, got %q", got)
+	}
+
+	// A freshly fetched bundle is reused without fetching the ref again
+	if err := os.RemoveAll(strings.TrimPrefix(repoURL, "file://")); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load from cache failed: %v", err)
+	}
+	if warnings := cfg.GetWarnings(); len(warnings) != 1 || strings.Contains(warnings[0], "failed") {
+		t.Errorf("expected the cached bundle without a fetch, got warnings %v", warnings)
+	}
+}
+
+func TestLoad_RulesFrom_Errors(t *testing.T) {
+	repoURL, commit := newRulesRepo(t, "rules:\n  detect_unsued: true\n")
+
+	tests := []struct {
+		name      string
+		rulesFrom string
+		extra     string
+		wantErr   string
+	}{
+		{name: "unsupported source", rulesFrom: "https://example.com/rules", wantErr: "unsupported source"},
+		{name: "unsupported parameter", rulesFrom: "git::" + repoURL + "?depth=1", wantErr: `unsupported parameter "depth"`},
+		{name: "invalid commit", rulesFrom: "{source: 'git::" + repoURL + "//golang', commit: main}", wantErr: "is not a full commit SHA"},
+		{name: "abbreviated commit", rulesFrom: "{source: 'git::" + repoURL + "//golang', commit: " + commit[:7] + "}", wantErr: "is not a full commit SHA"},
+		{name: "missing bundle", rulesFrom: "{source: 'git::" + repoURL + "?ref=v1', commit: " + commit + "}", wantErr: "has no rules.yaml"},
+		{name: "unknown bundle key", rulesFrom: "{source: 'git::" + repoURL + "//golang?ref=v1', commit: " + commit + "}", wantErr: `unknown key "rules.detect_unsued"`},
+		{name: "option as repository", rulesFrom: "'git::--upload-pack=touch pwned;true?ref=.'", wantErr: "must not start with '-'"},
+		{name: "option as ref", rulesFrom: "'git::" + repoURL + "?ref=--upload-pack=touch pwned'", wantErr: "ref must not start with '-'"},
+		{name: "option as ssh host", rulesFrom: "'git::ssh://-oProxyCommand=true/repo'", wantErr: "must not start with '-'"},
+		{name: "unsupported scheme", rulesFrom: "'git::ext::sh -c touch% pwned'", wantErr: "unsupported repository"},
+		{name: "plain path", rulesFrom: "git::" + filepath.ToSlash(t.TempDir()), wantErr: "unsupported repository URL"},
+		{name: "flat format", rulesFrom: "git::" + repoURL + "//golang", extra: "rules:\n  detect_unused: true\n", wantErr: "needs the preset/overrides format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := writeConfig(t, "module: example.com/test\nrules_from: "+tt.rulesFrom+"\n"+tt.extra)
+			_, err := config.Load(tmpDir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoad_RulesFrom_OptionInjection(t *testing.T) {
+	newRulesRepo(t, "rules:\n  detect_unused: true\n")
+	marker := filepath.Join(t.TempDir(), "pwned")
+
+	for _, source := range []string{
+		"git::--upload-pack=touch " + marker + ";true?ref=.",
+		"git::file:///nonexistent?ref=--upload-pack=touch " + marker + ";true",
+	} {
+		tmpDir := writeConfig(t, "module: example.com/test\nrules_from: '"+source+"'\n")
+		if _, err := config.Load(tmpDir); err == nil {
+			t.Errorf("expected %q to be rejected", source)
+		}
+		if _, err := os.Stat(marker); err == nil {
+			t.Fatalf("%q ran a command through git", source)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package config
+
+import (
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Suppression is a configured exemption from the rules, listed for audits
+type Suppression struct {
+	Kind   string    // Mechanism, e.g. "ignored path" or "whitebox tests"
+	Key    string    // Dotted configuration key (e.g. "rules.test_files.allow_whitebox")
+	Target string    // What is exempt: a path, an import, a package name or a rule
+	Reason string    // Recorded justification, empty if the configuration has none
+	Until  time.Time // For deferred rules: the date from which the rule fails the build
+	Source string    // Configuration layer defining it (see GetRuleSource)
+	Line   int       // Line in .goarchlint, 0 if defined by another layer
+}
+
+// GetSuppressions returns everything the configuration exempts from the rules: ignored
+// paths, shared external import exclusions, test import exemptions, whitebox test
+// exemptions, package naming exceptions and rules deferred with active_from. Each entry
+// is attributed to the layer that lists it; when several layers list it, to the one
+// applied last, as for rules.
+func (c *Config) GetSuppressions() []Suppression {
+	var suppressions []Suppression
+	for _, ignored := range c.IgnorePaths {
+		suppression := Suppression{Kind: "ignored path", Key: "ignore_paths", Target: ignored, Source: ".goarchlint"}
+		suppression.Line = c.lines["ignore_paths="+ignored]
+		if suppression.Line == 0 {
+			suppression.Source = "defaults"
+		}
+		suppressions = append(suppressions, suppression)
+	}
+
+	type origin struct {
+		source string
+		line   int
+	}
+	origins := make(map[string]origin) // Line key of the item -> layer listing it last
+	for _, layer := range c.suppressionLayers() {
+		items, lineKeys := rulesSuppressions(layer.rules)
+		for i := range items {
+			line := c.lines[layer.section+lineKeys[i]]
+			if line == 0 && items[i].Key == "rules.test_files.exempt_imports" {
+				// Listed under "**" when exempt imports are set per directory
+				line = c.lines[layer.section+"rules.test_files.exempt_imports."+allTestDirs+"="+items[i].Target]
+			}
+			origins[lineKeys[i]] = origin{source: layer.source, line: line}
+		}
+	}
+
+	items, lineKeys := rulesSuppressions(c.getMerged().Rules)
+	for i, suppression := range items {
+		origin := origins[lineKeys[i]]
+		suppression.Source, suppression.Line = origin.source, origin.line
+		if c.isDefault || suppression.Source == "" {
+			suppression.Source = "defaults"
+		}
+		suppressions = append(suppressions, suppression)
+	}
+	return suppressions
+}
+
+// suppressionLayer is a configuration layer that can list suppressions
+type suppressionLayer struct {
+	source  string // Layer name, as returned by GetRuleSource
+	section string // Prefix of its keys in the line index (see suppressionLines)
+	rules   Rules
+}
+
+// suppressionLayers returns the rule layers in the order they are merged: the flat
+// rules, or the preset, the rules_from bundle and the overrides; then the selected profile
+func (c *Config) suppressionLayers() []suppressionLayer {
+	var layers []suppressionLayer
+	if c.Preset == nil && c.RulesFrom == nil {
+		layers = append(layers, suppressionLayer{source: ".goarchlint", rules: c.Rules})
+	}
+	if c.Preset != nil {
+		source := "preset"
+		if c.Preset.Name != "" {
+			source += " " + c.Preset.Name
+		}
+		layers = append(layers, suppressionLayer{source: source, section: "preset:", rules: c.Preset.Rules})
+	}
+	if c.RulesFrom != nil && c.bundle != nil && c.bundle.Rules != nil {
+		// The bundle is not part of .goarchlint, so its entries have no line
+		layers = append(layers, suppressionLayer{source: "rules_from " + c.RulesFrom.Source, section: "rules_from:", rules: *c.bundle.Rules})
+	}
+	if (c.Preset != nil || c.RulesFrom != nil) && c.Overrides != nil && c.Overrides.Rules != nil {
+		layers = append(layers, suppressionLayer{source: "overrides", section: "overrides:", rules: *c.Overrides.Rules})
+	}
+	if profile, ok := c.Profiles[c.profile]; ok && profile.Rules != nil {
+		layers = append(layers, suppressionLayer{source: "profile " + c.profile, section: "profiles." + c.profile + ":", rules: *profile.Rules})
+	}
+	return layers
+}
+
+// rulesSuppressions lists the suppressions set by rules, without source or line, and
+// the "dotted.key=value" keys locating each of them in the line index
+func rulesSuppressions(rules Rules) ([]Suppression, []string) {
+	var suppressions []Suppression
+	var lineKeys []string
+	add := func(kind, key, target, reason string) {
+		suppressions = append(suppressions, Suppression{Kind: kind, Key: key, Target: target, Reason: reason})
+		lineKeys = append(lineKeys, key+"="+target)
+	}
+
+	for _, exclusion := range rules.SharedExternalImports.Exclusions {
+		add("excluded import", "rules.shared_external_imports.exclusions", exclusion, "")
+	}
+	for _, pattern := range rules.SharedExternalImports.ExclusionPatterns {
+		add("excluded import", "rules.shared_external_imports.exclusion_patterns", pattern, "")
+	}
+	for _, imp := range rules.TestFiles.ExemptImports.All {
+		add("exempt test import", "rules.test_files.exempt_imports", imp, "")
+	}
+	dirs := make([]string, 0, len(rules.TestFiles.ExemptImports.ByDir))
+	for dir := range rules.TestFiles.ExemptImports.ByDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		for _, imp := range rules.TestFiles.ExemptImports.ByDir[dir] {
+			add("exempt test import", "rules.test_files.exempt_imports."+dir, imp, "")
+			suppressions[len(suppressions)-1].Target = imp + " in " + dir
+		}
+	}
+	for _, exemption := range rules.TestFiles.AllowWhitebox {
+		add("whitebox tests", "rules.test_files.allow_whitebox", exemption.Path, exemption.Reason)
+	}
+	for _, exception := range rules.PackageNaming.Exceptions {
+		add("package name", "rules.package_naming.exceptions", exception, "")
+	}
+
+	deferred := make([]string, 0, len(rules.ActiveFrom))
+	for key := range rules.ActiveFrom {
+		deferred = append(deferred, key)
+	}
+	sort.Strings(deferred)
+	for _, key := range deferred {
+		date, err := time.Parse(activeFromLayout, rules.ActiveFrom[key])
+		if err != nil {
+			continue // Rejected by Load
+		}
+		add("deferred rule", "rules.active_from", key, "")
+		suppressions[len(suppressions)-1].Until = date
+	}
+
+	return suppressions, lineKeys
+}
+
+// suppressionLines indexes the lines of a .goarchlint document by "dotted.key=value" for
+// list items, the path of list entries that are mappings, and map keys. Keys of the
+// preset, overrides and profiles sections are indexed like the flat rules, prefixed with
+// their section ("preset:", "overrides:", "profiles.<name>:"), so every layer locates its
+// own entries.
+func suppressionLines(doc *yaml.Node) map[string]int {
+	lines := make(map[string]int)
+	section := ""
+	record := func(key string, line int) {
+		if _, ok := lines[section+key]; !ok {
+			lines[section+key] = line
+		}
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if path == "" {
+					walk(value, key.Value)
+					continue
+				}
+				record(path+"="+normalizePath(key.Value), key.Line)
+				walk(value, path+"."+key.Value)
+			}
+		case yaml.SequenceNode:
+			for _, item := range node.Content {
+				switch item.Kind {
+				case yaml.ScalarNode:
+					record(path+"="+normalizePath(item.Value), item.Line)
+				case yaml.MappingNode:
+					for i := 0; i+1 < len(item.Content); i += 2 {
+						if item.Content[i].Value == "path" {
+							record(path+"="+normalizePath(item.Content[i+1].Value), item.Line)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return lines
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "preset", "overrides":
+			section = key.Value + ":"
+			walk(value, "")
+		case "profiles":
+			for j := 1; j < len(value.Content); j += 2 {
+				section = "profiles." + value.Content[j-1].Value + ":"
+				walk(value.Content[j], "")
+			}
+		default:
+			section = ""
+			walk(value, key.Value)
+		}
+	}
+	return lines
+}
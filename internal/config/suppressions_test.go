@@ -0,0 +1,195 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+)
+
+func TestConfig_GetSuppressions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: example.com/test
+ignore_paths:
+  - generated
+rules:
+  shared_external_imports:
+    exclusions:
+      - fmt
+  test_files:
+    exempt_imports:
+      "**":
+        - testing
+      internal/db:
+        - github.com/ory/dockertest
+    allow_whitebox:
+      - path: internal/parser
+        reason: Table tests exercise the unexported tokenizer
+  package_naming:
+    exceptions:
+      - internal/legacy
+  active_from:
+    rules.directories_import.cmd: "2030-01-01"
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	suppressions := cfg.GetSuppressions()
+	byTarget := make(map[string]config.Suppression)
+	for _, suppression := range suppressions {
+		byTarget[suppression.Target] = suppression
+	}
+
+	tests := []struct {
+		target string
+		kind   string
+		line   int
+		source string
+	}{
+		{"generated", "ignored path", 3, ".goarchlint"},
+		{"fmt", "excluded import", 7, ".goarchlint"},
+		{"testing", "exempt test import", 11, ".goarchlint"},
+		{"github.com/ory/dockertest in internal/db", "exempt test import", 13, ".goarchlint"},
+		{"internal/parser", "whitebox tests", 15, ".goarchlint"},
+		{"internal/legacy", "package name", 19, ".goarchlint"},
+		{"rules.directories_import.cmd", "deferred rule", 21, ".goarchlint"},
+	}
+	for _, tt := range tests {
+		suppression, ok := byTarget[tt.target]
+		if !ok {
+			t.Errorf("expected suppression of %s, got %+v", tt.target, suppressions)
+			continue
+		}
+		if suppression.Kind != tt.kind || suppression.Line != tt.line || suppression.Source != tt.source {
+			t.Errorf("suppression of %s = %+v, want kind %q, line %d, source %q", tt.target, suppression, tt.kind, tt.line, tt.source)
+		}
+	}
+
+	if reason := byTarget["internal/parser"].Reason; reason != "Table tests exercise the unexported tokenizer" {
+		t.Errorf("expected whitebox reason, got %q", reason)
+	}
+	if until := byTarget["rules.directories_import.cmd"].Until.Format("2006-01-02"); until != "2030-01-01" {
+		t.Errorf("expected deferred rule until 2030-01-01, got %s", until)
+	}
+}
+
+func TestConfig_GetSuppressions_PresetAndOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: example.com/test
+preset:
+  name: simple
+  rules:
+    shared_external_imports:
+      exclusions:
+        - fmt
+overrides:
+  rules:
+    shared_external_imports:
+      exclusions:
+        - strings
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	lines := make(map[string]int)
+	sources := make(map[string]string)
+	for _, suppression := range cfg.GetSuppressions() {
+		lines[suppression.Target] = suppression.Line
+		sources[suppression.Target] = suppression.Source
+	}
+	if lines["fmt"] != 7 || lines["strings"] != 12 {
+		t.Errorf("expected fmt on line 7 and strings on line 12, got %v", lines)
+	}
+	if sources["strings"] != "overrides" {
+		t.Errorf("expected strings from overrides, got %q", sources["strings"])
+	}
+	// Override exclusions add to the preset's, which keep their own source
+	if sources["fmt"] != "preset simple" {
+		t.Errorf("expected fmt from the preset, got %q", sources["fmt"])
+	}
+	// Default ignored paths have no line in .goarchlint
+	if lines["vendor"] != 0 || sources["vendor"] != "defaults" {
+		t.Errorf("expected vendor ignored by defaults, got line %d, source %q", lines["vendor"], sources["vendor"])
+	}
+}
+
+func TestConfig_GetSuppressions_PerLayerLines(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// The profile lists the same package naming exception as the preset, and the
+	// overrides a path the preset's whitebox exemption also uses
+	configYAML := `module: example.com/test
+overrides:
+  rules:
+    test_files:
+      allow_whitebox:
+        - path: internal/parser
+          reason: Overridden reason
+preset:
+  name: simple
+  rules:
+    package_naming:
+      exceptions:
+        - internal/legacy
+    test_files:
+      allow_whitebox:
+        - path: internal/parser
+          reason: Preset reason
+profiles:
+  ci:
+    rules:
+      package_naming:
+        exceptions:
+          - internal/legacy
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	find := func(target string) config.Suppression {
+		t.Helper()
+		for _, suppression := range cfg.GetSuppressions() {
+			if suppression.Target == target {
+				return suppression
+			}
+		}
+		t.Fatalf("expected suppression of %s", target)
+		return config.Suppression{}
+	}
+
+	// The overrides come first in the file, but the whitebox exemption is still
+	// attributed to them, with their line, since they apply after the preset
+	if got := find("internal/parser"); got.Source != "overrides" || got.Line != 6 || got.Reason != "Overridden reason" {
+		t.Errorf("unexpected whitebox exemption: %+v", got)
+	}
+	if got := find("internal/legacy"); got.Source != "preset simple" || got.Line != 13 {
+		t.Errorf("expected the exception from the preset on line 13, got %+v", got)
+	}
+
+	if err := cfg.SelectProfile("ci"); err != nil {
+		t.Fatal(err)
+	}
+	if got := find("internal/legacy"); got.Source != "profile ci" || got.Line != 23 {
+		t.Errorf("expected the exception from profile ci on line 23, got %+v", got)
+	}
+}
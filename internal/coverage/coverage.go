@@ -2,6 +2,7 @@ package coverage
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -42,16 +43,23 @@ func (pc PackageCoverage) HasTests() bool {
 type Runner struct {
 	projectPath string
 	moduleName  string
+	out         io.Writer // Progress output
 }
 
-// New creates a new coverage runner
+// New creates a new coverage runner that prints its progress to stdout
 func New(projectPath, moduleName string) *Runner {
 	return &Runner{
 		projectPath: projectPath,
 		moduleName:  moduleName,
+		out:         os.Stdout,
 	}
 }
 
+// SetOutput sets where the progress of Run is printed
+func (r *Runner) SetOutput(w io.Writer) {
+	r.out = w
+}
+
 // Run executes coverage analysis for all packages in scanPaths
 func (r *Runner) Run(scanPaths []string) ([]PackageCoverage, error) {
 	var results []PackageCoverage
@@ -67,16 +75,16 @@ func (r *Runner) Run(scanPaths []string) ([]PackageCoverage, error) {
 	}
 
 	// Print header
-	fmt.Printf("\n🔍 Running test coverage analysis for %d packages...\n\n", len(packages))
+	fmt.Fprintf(r.out, "\n🔍 Running test coverage analysis for %d packages...\n\n", len(packages))
 
 	for i, pkg := range packages {
 		// Show progress
-		fmt.Printf("  [%d/%d] Testing %s...", i+1, len(packages), getShortPackageName(pkg, r.moduleName))
+		fmt.Fprintf(r.out, "  [%d/%d] Testing %s...", i+1, len(packages), getShortPackageName(pkg, r.moduleName))
 
 		coverage, hasTests, err := r.runCoverageForPackage(pkg)
 		if err != nil {
 			// If coverage fails (e.g., no test files), record 0% with hasTests=false
-			fmt.Printf(" no tests\n")
+			fmt.Fprintf(r.out, " no tests\n")
 			results = append(results, PackageCoverage{
 				PackagePath: pkg,
 				Coverage:    0,
@@ -86,9 +94,9 @@ func (r *Runner) Run(scanPaths []string) ([]PackageCoverage, error) {
 		}
 
 		if !hasTests {
-			fmt.Printf(" no tests\n")
+			fmt.Fprintf(r.out, " no tests\n")
 		} else {
-			fmt.Printf(" %.1f%%\n", coverage)
+			fmt.Fprintf(r.out, " %.1f%%\n", coverage)
 		}
 
 		results = append(results, PackageCoverage{
@@ -98,7 +106,7 @@ func (r *Runner) Run(scanPaths []string) ([]PackageCoverage, error) {
 		})
 	}
 
-	fmt.Println() // Empty line after progress
+	fmt.Fprintln(r.out) // Empty line after progress
 
 	return results, nil
 }
@@ -193,19 +201,24 @@ func SummarizeByDirectory(results []PackageCoverage, moduleName string, scanPath
 
 // PrintSummary displays a formatted coverage summary table
 func PrintSummary(summaries []DirectorySummary, overallCoverage float64) {
+	WriteSummary(os.Stdout, summaries, overallCoverage)
+}
+
+// WriteSummary writes a formatted coverage summary table to w
+func WriteSummary(w io.Writer, summaries []DirectorySummary, overallCoverage float64) {
 	if len(summaries) == 0 {
 		return
 	}
 
-	fmt.Println("📊 Coverage Summary by Directory:")
-	fmt.Println()
-	fmt.Println("┌────────────────────┬──────────┬─────────┬──────────────┐")
-	fmt.Println("│ Directory          │ Packages │ Tested  │ Coverage     │")
-	fmt.Println("├────────────────────┼──────────┼─────────┼──────────────┤")
+	fmt.Fprintln(w, "📊 Coverage Summary by Directory:")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "┌────────────────────┬──────────┬─────────┬──────────────┐")
+	fmt.Fprintln(w, "│ Directory          │ Packages │ Tested  │ Coverage     │")
+	fmt.Fprintln(w, "├────────────────────┼──────────┼─────────┼──────────────┤")
 
 	for _, summary := range summaries {
 		coverageBar := getCoverageBar(summary.AvgCoverage)
-		fmt.Printf("│ %-18s │ %8d │ %7d │ %5.1f%% %s │\n",
+		fmt.Fprintf(w, "│ %-18s │ %8d │ %7d │ %5.1f%% %s │\n",
 			truncate(summary.Directory, 18),
 			summary.PackageCount,
 			summary.TestedPackages,
@@ -214,11 +227,11 @@ func PrintSummary(summaries []DirectorySummary, overallCoverage float64) {
 		)
 	}
 
-	fmt.Println("├────────────────────┴──────────┴─────────┼──────────────┤")
+	fmt.Fprintln(w, "├────────────────────┴──────────┴─────────┼──────────────┤")
 	overallBar := getCoverageBar(overallCoverage)
-	fmt.Printf("│ Overall Project Coverage                │ %5.1f%% %s │\n", overallCoverage, overallBar)
-	fmt.Println("└──────────────────────────────────────────┴──────────────┘")
-	fmt.Println()
+	fmt.Fprintf(w, "│ Overall Project Coverage                │ %5.1f%% %s │\n", overallCoverage, overallBar)
+	fmt.Fprintln(w, "└──────────────────────────────────────────┴──────────────┘")
+	fmt.Fprintln(w)
 }
 
 // getCoverageBar returns a visual bar representation of coverage
@@ -1,8 +1,10 @@
 package coverage_test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kgatilin/go-arch-lint/internal/coverage"
@@ -209,6 +211,8 @@ func Helper() string {
 
 	// Run coverage
 	runner := coverage.New(tmpDir, "github.com/test/project")
+	var progress bytes.Buffer
+	runner.SetOutput(&progress)
 	results, err := runner.Run([]string{"internal"})
 	if err != nil {
 		t.Fatalf("Run() error = %v", err)
@@ -218,6 +222,9 @@ func Helper() string {
 	if len(results) != 1 {
 		t.Errorf("Run() found %d packages, want 1", len(results))
 	}
+	if !strings.Contains(progress.String(), "[1/1] Testing internal...") {
+		t.Errorf("expected the progress in the configured output, got:\n%s", progress.String())
+	}
 
 	// Check that coverage is 0
 	if len(results) > 0 {
@@ -374,6 +381,22 @@ func TestPrintSummary_EmptySummaries(t *testing.T) {
 	// If no panic, test passes
 }
 
+func TestWriteSummary(t *testing.T) {
+	summaries := []coverage.DirectorySummary{
+		{Directory: "internal/domain", PackageCount: 2, TestedPackages: 1, AvgCoverage: 75},
+	}
+
+	var buf bytes.Buffer
+	coverage.WriteSummary(&buf, summaries, 75)
+
+	if !strings.Contains(buf.String(), "│ internal/domain    │        2 │       1 │  75.0% ██░ │") {
+		t.Errorf("missing the directory row, got:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "│ Overall Project Coverage                │  75.0% ██░ │") {
+		t.Errorf("missing the overall row, got:\n%s", buf.String())
+	}
+}
+
 func TestRunner_Run_NonExistentDirectory(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -0,0 +1,141 @@
+package gitdiff
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Changes describes the lines added in the working tree relative to a git revision
+type Changes struct {
+	addedLines map[string]map[int]bool // file -> added line numbers
+	newFiles   map[string]bool         // untracked files (every line is new)
+}
+
+// IsLineAdded implements validator.ChangeSet interface
+func (c *Changes) IsLineAdded(relPath string, line int) bool {
+	if c.newFiles[relPath] {
+		return true
+	}
+	return c.addedLines[relPath][line]
+}
+
+// Differ computes changes of a project directory with git
+type Differ struct {
+	projectPath string
+}
+
+// New creates a differ for the git working tree containing projectPath
+func New(projectPath string) *Differ {
+	return &Differ{projectPath: projectPath}
+}
+
+// Changes returns lines added or modified since base, including uncommitted and untracked files.
+// Paths are relative to the project path.
+func (d *Differ) Changes(base string) (*Changes, error) {
+	// base comes from the config and is passed to git as an argument, so it must not look like an option
+	if base == "" || strings.HasPrefix(base, "-") {
+		return nil, fmt.Errorf("invalid base revision %q", base)
+	}
+
+	diffOutput, err := d.git("diff", "--relative", "--unified=0", "--no-color", "--no-ext-diff", base, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	addedLines, err := parseAddedLines(diffOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	untrackedOutput, err := d.git("ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	newFiles := make(map[string]bool)
+	for _, file := range strings.Split(strings.TrimSpace(string(untrackedOutput)), "\n") {
+		if file != "" {
+			newFiles[file] = true
+		}
+	}
+
+	return &Changes{addedLines: addedLines, newFiles: newFiles}, nil
+}
+
+// git runs a git command in the project directory
+func (d *Differ) git(args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = d.projectPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+// parseAddedLines extracts added line numbers per file from unified diff output with zero context
+func parseAddedLines(diffOutput []byte) (map[string]map[int]bool, error) {
+	addedLines := make(map[string]map[int]bool)
+
+	var currentFile string
+	scanner := bufio.NewScanner(bytes.NewReader(diffOutput))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			// "+++ b/path" for added or modified files, "+++ /dev/null" for deletions
+			currentFile = ""
+			if path := strings.TrimPrefix(line, "+++ "); strings.HasPrefix(path, "b/") {
+				currentFile = strings.TrimPrefix(path, "b/")
+			}
+
+		case strings.HasPrefix(line, "@@ ") && currentFile != "":
+			start, count, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			if addedLines[currentFile] == nil {
+				addedLines[currentFile] = make(map[int]bool)
+			}
+			for l := start; l < start+count; l++ {
+				addedLines[currentFile][l] = true
+			}
+		}
+	}
+
+	return addedLines, scanner.Err()
+}
+
+// parseHunkHeader returns the new-file line range of a hunk header like "@@ -10,2 +12,3 @@"
+func parseHunkHeader(header string) (int, int, error) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, fmt.Errorf("invalid hunk header: %s", header)
+	}
+
+	rangeSpec := strings.TrimPrefix(fields[2], "+")
+	startSpec, countSpec, hasCount := strings.Cut(rangeSpec, ",")
+
+	start, err := strconv.Atoi(startSpec)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid hunk header: %s", header)
+	}
+
+	count := 1 // Omitted count means a single line
+	if hasCount {
+		if count, err = strconv.Atoi(countSpec); err != nil {
+			return 0, 0, fmt.Errorf("invalid hunk header: %s", header)
+		}
+	}
+
+	return start, count, nil
+}
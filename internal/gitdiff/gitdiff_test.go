@@ -0,0 +1,106 @@
+package gitdiff_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/gitdiff"
+)
+
+// runGit runs a git command in dir and fails the test on error
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChanges_AddedAndUntrackedLines(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repoDir := t.TempDir()
+	projectDir := filepath.Join(repoDir, "service")
+
+	writeFile(t, filepath.Join(projectDir, "internal", "order", "order.go"), "package order\n\nfunc A() {}\n\nfunc B() {}\n")
+	writeFile(t, filepath.Join(repoDir, "other", "other.go"), "package other\n")
+	runGit(t, repoDir, "init", "-q")
+	runGit(t, repoDir, "add", "-A")
+	runGit(t, repoDir, "commit", "-q", "-m", "initial")
+
+	// Modify line 3, insert two lines after line 5, and add an untracked file
+	writeFile(t, filepath.Join(projectDir, "internal", "order", "order.go"), "package order\n\nfunc A() { C() }\n\nfunc B() {}\n\nfunc C() {}\n")
+	writeFile(t, filepath.Join(projectDir, "internal", "order", "new.go"), "package order\n")
+	writeFile(t, filepath.Join(repoDir, "other", "other.go"), "package other\n\nfunc D() {}\n")
+
+	changes, err := gitdiff.New(projectDir).Changes("HEAD")
+	if err != nil {
+		t.Fatalf("Changes failed: %v", err)
+	}
+
+	tests := []struct {
+		file  string
+		line  int
+		added bool
+	}{
+		{"internal/order/order.go", 1, false},
+		{"internal/order/order.go", 3, true},
+		{"internal/order/order.go", 5, false},
+		{"internal/order/order.go", 6, true},
+		{"internal/order/order.go", 7, true},
+		{"internal/order/new.go", 1, true},
+		// Paths are relative to the project, and files outside it are ignored
+		{"other/other.go", 3, false},
+		{"../other/other.go", 3, false},
+	}
+	for _, tt := range tests {
+		if got := changes.IsLineAdded(tt.file, tt.line); got != tt.added {
+			t.Errorf("IsLineAdded(%s, %d) = %v, want %v", tt.file, tt.line, got, tt.added)
+		}
+	}
+}
+
+func TestChanges_InvalidRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repoDir := t.TempDir()
+	runGit(t, repoDir, "init", "-q")
+
+	if _, err := gitdiff.New(repoDir).Changes("no-such-revision"); err == nil {
+		t.Error("expected error for unknown revision")
+	}
+}
+
+func TestChanges_RejectsOptionAsRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repoDir := t.TempDir()
+	runGit(t, repoDir, "init", "-q")
+	output := filepath.Join(t.TempDir(), "written")
+
+	if _, err := gitdiff.New(repoDir).Changes("--output=" + output); err == nil {
+		t.Error("expected error for a revision starting with '-'")
+	}
+	if _, err := os.Stat(output); err == nil {
+		t.Error("git wrote the file named by the revision")
+	}
+}
@@ -0,0 +1,609 @@
+package githistory
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Intervals lists the supported sampling intervals
+var Intervals = []string{"tag", "commit"}
+
+// Revision is a point in the history of a repository
+type Revision struct {
+	Name   string // Tag name, short commit hash, or "HEAD"
+	Commit string // Full commit hash
+	Date   time.Time
+}
+
+// FileChurn describes how often a file changed
+type FileChurn struct {
+	Commits      []string // Hashes of the commits touching the file, newest first
+	LinesChanged int      // Added plus deleted lines
+}
+
+// Repository reads past revisions of the git repository containing a project directory
+type Repository struct {
+	projectPath string
+}
+
+// New creates a repository reader for the git repository containing projectPath
+func New(projectPath string) *Repository {
+	return &Repository{projectPath: projectPath}
+}
+
+// Revisions returns the revisions from since (inclusive) up to HEAD, oldest first.
+// With the "tag" interval every tag reachable from HEAD is a revision, followed by HEAD
+// if it is not tagged; with the "commit" interval every first-parent commit is.
+// An empty since starts at the beginning of the history.
+func (r *Repository) Revisions(since, interval string) ([]Revision, error) {
+	if !slices.Contains(Intervals, interval) {
+		return nil, fmt.Errorf("unsupported interval %q (supported: %s)", interval, strings.Join(Intervals, ", "))
+	}
+
+	repo, err := r.open()
+	if err != nil {
+		return nil, err
+	}
+	head, err := resolveCommit(repo, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	var first *object.Commit
+	if since != "" {
+		if first, err = resolveCommit(repo, since); err != nil {
+			return nil, err
+		}
+	}
+
+	var revisions []Revision
+	switch interval {
+	case "tag":
+		if revisions, err = tagRevisions(repo, head, first); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, newRevision("HEAD", head))
+	case "commit":
+		if revisions, err = commitRevisions(head, first); err != nil {
+			return nil, err
+		}
+	}
+
+	// HEAD is already covered by its tag
+	seen := make(map[string]bool)
+	unique := revisions[:0]
+	for _, rev := range revisions {
+		if !seen[rev.Commit] {
+			seen[rev.Commit] = true
+			unique = append(unique, rev)
+		}
+	}
+	return unique, nil
+}
+
+// tagRevisions returns the tags reachable from head that contain first (any tag if
+// first is nil), by creation date
+func tagRevisions(repo *git.Repository, head, first *object.Commit) ([]Revision, error) {
+	reachable, err := ancestors(repo, head)
+	if err != nil {
+		return nil, err
+	}
+
+	type tag struct {
+		revision Revision
+		created  time.Time
+	}
+	var tags []tag
+	refs, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %w", err)
+	}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		// Annotated tags were created when they were tagged, lightweight ones with their commit
+		var commit *object.Commit
+		var created time.Time
+		if annotated, err := repo.TagObject(ref.Hash()); err == nil {
+			if commit, err = annotated.Commit(); err != nil {
+				return nil // Tags of trees and blobs are not revisions
+			}
+			created = annotated.Tagger.When
+		} else if commit, err = repo.CommitObject(ref.Hash()); err == nil {
+			created = commit.Committer.When
+		} else {
+			return nil
+		}
+
+		if !reachable[commit.Hash] {
+			return nil
+		}
+		if first != nil {
+			contained, err := first.IsAncestor(commit)
+			if err != nil {
+				return fmt.Errorf("checking %s: %w", ref.Name().Short(), err)
+			}
+			if !contained {
+				return nil
+			}
+		}
+		tags = append(tags, tag{revision: newRevision(ref.Name().Short(), commit), created: created})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Tags created at the same time are ordered by name, as git does
+	sort.Slice(tags, func(i, j int) bool {
+		if !tags[i].created.Equal(tags[j].created) {
+			return tags[i].created.Before(tags[j].created)
+		}
+		return tags[i].revision.Name < tags[j].revision.Name
+	})
+	revisions := make([]Revision, len(tags))
+	for i := range tags {
+		revisions[i] = tags[i].revision
+	}
+	return revisions, nil
+}
+
+// commitRevisions returns the first-parent commits from first (the root commit if nil)
+// up to head, oldest first, each named by its short hash. Returns an error if first is
+// not on the first-parent chain of head, such as a commit of a merged branch.
+func commitRevisions(head, first *object.Commit) ([]Revision, error) {
+	var commits []*object.Commit
+	for commit := head; ; {
+		commits = append(commits, commit)
+		if first != nil && commit.Hash == first.Hash {
+			break
+		}
+		if commit.NumParents() == 0 {
+			if first != nil {
+				return nil, fmt.Errorf("%s is not on the first-parent history of HEAD", first.Hash.String()[:7])
+			}
+			break
+		}
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, fmt.Errorf("reading parent of %s: %w", commit.Hash, err)
+		}
+		commit = parent
+	}
+
+	revisions := make([]Revision, len(commits))
+	for i, commit := range commits {
+		revisions[len(commits)-1-i] = newRevision(commit.Hash.String()[:7], commit)
+	}
+	return revisions, nil
+}
+
+// ancestors returns the hashes of commit and all commits reachable from it
+func ancestors(repo *git.Repository, commit *object.Commit) (map[plumbing.Hash]bool, error) {
+	commits, err := repo.Log(&git.LogOptions{From: commit.Hash})
+	if err != nil {
+		return nil, fmt.Errorf("reading history of %s: %w", commit.Hash, err)
+	}
+	reachable := make(map[plumbing.Hash]bool)
+	err = commits.ForEach(func(c *object.Commit) error {
+		reachable[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading history of %s: %w", commit.Hash, err)
+	}
+	return reachable, nil
+}
+
+// resolveCommit returns the commit a revision (tag, branch, commit hash, HEAD~2, ...)
+// points to
+func resolveCommit(repo *git.Repository, revision string) (*object.Commit, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf("unknown revision %q: %w", revision, err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("reading commit %s: %w", hash, err)
+	}
+	return commit, nil
+}
+
+// newRevision returns the revision of a commit, dated by its committer date
+func newRevision(name string, commit *object.Commit) Revision {
+	return Revision{Name: name, Commit: commit.Hash.String(), Date: commit.Committer.When}
+}
+
+// Churn returns per-file change statistics for commits since the given date
+// ("2024-01-01", an RFC 3339 time or a relative date such as "6 months ago"; empty for
+// the whole history). Paths are relative to the project path; files outside it are not
+// included.
+func (r *Repository) Churn(since string) (map[string]FileChurn, error) {
+	var after time.Time
+	if since != "" {
+		var err error
+		if after, err = parseDate(since, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	churn := make(map[string]FileChurn)
+	err := r.walkChanges(after, func(commit *object.Commit, changes object.Changes) error {
+		patch, err := changes.Patch()
+		if err != nil {
+			return fmt.Errorf("diffing %s: %w", commit.Hash, err)
+		}
+		lines := make(map[string]int)
+		for _, stat := range patch.Stats() {
+			lines[stat.Name] = stat.Addition + stat.Deletion
+		}
+
+		// Binary files have no line statistics, but the commit still counts
+		for _, change := range changes {
+			path := changePath(change)
+			stats := churn[path]
+			stats.Commits = append(stats.Commits, commit.Hash.String())
+			stats.LinesChanged += lines[path]
+			churn[path] = stats
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return churn, nil
+}
+
+// LastChanged returns the committer date of the last commit that changed each file,
+// by path relative to the project path. Files that were never committed are not
+// included.
+func (r *Repository) LastChanged() (map[string]time.Time, error) {
+	// Commits are visited newest first, so the first date seen for a file is its last change
+	changed := make(map[string]time.Time)
+	err := r.walkChanges(time.Time{}, func(commit *object.Commit, changes object.Changes) error {
+		for _, change := range changes {
+			if path := changePath(change); changed[path].IsZero() {
+				changed[path] = commit.Committer.When.UTC()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// Head returns the full hash of the commit checked out in the working tree
+func (r *Repository) Head() (string, error) {
+	repo, err := r.open()
+	if err != nil {
+		return "", err
+	}
+	head, err := resolveCommit(repo, "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return head.Hash.String(), nil
+}
+
+// Clean reports whether the project directory matches HEAD: no modified, staged or
+// untracked files (ignored files do not count)
+func (r *Repository) Clean() (bool, error) {
+	repo, err := r.open()
+	if err != nil {
+		return false, err
+	}
+	prefix, err := r.prefix(repo)
+	if err != nil {
+		return false, err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return false, fmt.Errorf("opening working tree: %w", err)
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return false, fmt.Errorf("reading status: %w", err)
+	}
+
+	for path, file := range status {
+		if prefix != "" && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		if file.Staging != git.Unmodified || file.Worktree != git.Unmodified {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// LastCommit returns the full hash of the last commit that changed path (relative to
+// the project directory), or "" if path was never committed
+func (r *Repository) LastCommit(path string) (string, error) {
+	path = filepath.ToSlash(path)
+	last := ""
+	err := r.walkChanges(time.Time{}, func(commit *object.Commit, changes object.Changes) error {
+		for _, change := range changes {
+			if changePath(change) == path {
+				last = commit.Hash.String()
+				return storer.ErrStop
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return last, nil
+}
+
+// LineDates returns the author date of the commit that last changed each line of path
+// (relative to the project directory), by line number in the working tree. Uncommitted
+// lines are left out.
+func (r *Repository) LineDates(path string) (map[int]time.Time, error) {
+	repo, err := r.open()
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := r.prefix(repo)
+	if err != nil {
+		return nil, err
+	}
+	head, err := resolveCommit(repo, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	blame, err := git.Blame(head, repoPath(prefix, filepath.ToSlash(path)))
+	if err != nil {
+		return nil, fmt.Errorf("blaming %s: %w", path, err)
+	}
+	content, err := os.ReadFile(filepath.Join(r.projectPath, path))
+	if err != nil {
+		return nil, err
+	}
+
+	// Lines of HEAD keep their date where the working tree has them unchanged; inserted
+	// lines are not committed yet
+	var committed strings.Builder
+	for _, line := range blame.Lines {
+		committed.WriteString(line.Text + "\n")
+	}
+	dates := make(map[int]time.Time, len(blame.Lines))
+	old, current := 0, 0
+	for _, d := range diff.Do(committed.String(), string(content)) {
+		count := strings.Count(d.Text, "\n")
+		if !strings.HasSuffix(d.Text, "\n") {
+			count++
+		}
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			for i := 0; i < count && old < len(blame.Lines); i++ {
+				dates[current+i+1] = blame.Lines[old].Date
+				old++
+			}
+			current += count
+		case diffmatchpatch.DiffDelete:
+			old += count
+		case diffmatchpatch.DiffInsert:
+			current += count
+		}
+	}
+	return dates, nil
+}
+
+// walkChanges calls fn for each commit reachable from HEAD committed after since (any
+// commit if since is zero), newest first, with the changes it made to the project
+// directory as paths relative to it. Merge commits are skipped, as git log does, and so
+// are commits not touching the project. fn may return storer.ErrStop to end the walk.
+func (r *Repository) walkChanges(since time.Time, fn func(commit *object.Commit, changes object.Changes) error) error {
+	repo, err := r.open()
+	if err != nil {
+		return err
+	}
+	prefix, err := r.prefix(repo)
+	if err != nil {
+		return err
+	}
+	head, err := resolveCommit(repo, "HEAD")
+	if err != nil {
+		return err
+	}
+
+	options := &git.LogOptions{From: head.Hash, Order: git.LogOrderCommitterTime}
+	if !since.IsZero() {
+		options.Since = &since
+	}
+	commits, err := repo.Log(options)
+	if err != nil {
+		return fmt.Errorf("reading history: %w", err)
+	}
+	err = commits.ForEach(func(commit *object.Commit) error {
+		if commit.NumParents() > 1 {
+			return nil
+		}
+		after, err := projectTree(commit, prefix)
+		if err != nil {
+			return err
+		}
+		var before *object.Tree
+		if commit.NumParents() == 1 {
+			parent, err := commit.Parent(0)
+			if err != nil {
+				return fmt.Errorf("reading parent of %s: %w", commit.Hash, err)
+			}
+			if before, err = projectTree(parent, prefix); err != nil {
+				return err
+			}
+		}
+
+		changes, err := object.DiffTree(before, after)
+		if err != nil {
+			return fmt.Errorf("diffing %s: %w", commit.Hash, err)
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		return fn(commit, changes)
+	})
+	if err != nil && err != storer.ErrStop {
+		return err
+	}
+	return nil
+}
+
+// projectTree returns the tree of the project directory at commit, or nil if it did not
+// exist then
+func projectTree(commit *object.Commit, prefix string) (*object.Tree, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("reading tree of %s: %w", commit.Hash, err)
+	}
+	if prefix == "" {
+		return tree, nil
+	}
+	if tree, err = tree.Tree(prefix); err == object.ErrDirectoryNotFound {
+		return nil, nil
+	}
+	return tree, err
+}
+
+// changePath returns the path of the file a change added, modified or deleted
+func changePath(change *object.Change) string {
+	if change.To.Name != "" {
+		return change.To.Name
+	}
+	return change.From.Name
+}
+
+// repoPath returns the path relative to the repository root of a path relative to the
+// project directory
+func repoPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	return prefix + "/" + path
+}
+
+// dateUnits are the units of relative dates, by singular name
+var dateUnits = map[string]func(t time.Time, n int) time.Time{
+	"second": func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Second) },
+	"minute": func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Minute) },
+	"hour":   func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Hour) },
+	"day":    func(t time.Time, n int) time.Time { return t.AddDate(0, 0, -n) },
+	"week":   func(t time.Time, n int) time.Time { return t.AddDate(0, 0, -7*n) },
+	"month":  func(t time.Time, n int) time.Time { return t.AddDate(0, -n, 0) },
+	"year":   func(t time.Time, n int) time.Time { return t.AddDate(-n, 0, 0) },
+}
+
+// parseDate parses an absolute date ("2024-01-01" or RFC 3339) or a date relative to now
+// ("6 months ago", "1 week ago")
+func parseDate(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) == 3 && fields[2] == "ago" {
+		n, err := strconv.Atoi(fields[0])
+		if shift, ok := dateUnits[strings.TrimSuffix(fields[1], "s")]; ok && err == nil && n >= 0 {
+			return shift(now, n), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD, RFC 3339 or e.g. \"6 months ago\")", value)
+}
+
+// Prefix returns the project directory relative to the repository root, using forward
+// slashes and without a trailing slash ("" if the project is the repository root)
+func (r *Repository) Prefix() (string, error) {
+	repo, err := r.open()
+	if err != nil {
+		return "", err
+	}
+	return r.prefix(repo)
+}
+
+// prefix returns the project directory relative to the root of the working tree of repo
+func (r *Repository) prefix(repo *git.Repository) (string, error) {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("opening working tree: %w", err)
+	}
+
+	// Symlinks, such as a temporary directory below /var on macOS, are resolved on both
+	// sides so the paths compare
+	root, err := filepath.EvalSymlinks(worktree.Filesystem.Root())
+	if err != nil {
+		return "", err
+	}
+	dir, err := filepath.Abs(r.projectPath)
+	if err != nil {
+		return "", err
+	}
+	if dir, err = filepath.EvalSymlinks(dir); err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// Files returns the project directory as of commit, read from the repository in memory
+// without touching the working tree. Returns an error if the project directory did not
+// exist at commit.
+func (r *Repository) Files(commit string) (fs.FS, error) {
+	repo, err := r.open()
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := r.prefix(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := repo.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return nil, fmt.Errorf("reading commit %s: %w", commit, err)
+	}
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("reading tree of %s: %w", commit, err)
+	}
+	if prefix != "" {
+		if tree, err = tree.Tree(prefix); err != nil {
+			return nil, fmt.Errorf("%s does not exist at %s: %w", prefix, commit, err)
+		}
+	}
+
+	return treeFS{tree: tree, date: c.Committer.When}, nil
+}
+
+// open opens the repository containing the project directory
+func (r *Repository) open() (*git.Repository, error) {
+	repo, err := git.PlainOpenWithOptions(r.projectPath, &git.PlainOpenOptions{DetectDotGit: true, EnableDotGitCommonDir: true})
+	if err != nil {
+		return nil, fmt.Errorf("opening git repository: %w", err)
+	}
+	return repo, nil
+}
@@ -0,0 +1,445 @@
+package githistory_test
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/kgatilin/go-arch-lint/internal/githistory"
+)
+
+// runGit runs a git command in dir and fails the test on error
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setupRepo creates a repository with the project in a subdirectory and three commits:
+// v1.0.0, v1.1.0 and an untagged HEAD
+func setupRepo(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repoDir := t.TempDir()
+	projectDir := filepath.Join(repoDir, "service")
+
+	writeFile(t, filepath.Join(projectDir, "a.go"), "package service\n")
+	writeFile(t, filepath.Join(repoDir, "other.go"), "package other\n")
+	runGit(t, repoDir, "init", "-q")
+	runGit(t, repoDir, "add", "-A")
+	runGit(t, repoDir, "commit", "-q", "-m", "first")
+	runGit(t, repoDir, "tag", "v1.0.0")
+
+	writeFile(t, filepath.Join(projectDir, "internal", "b.go"), "package internal\n")
+	runGit(t, repoDir, "add", "-A")
+	runGit(t, repoDir, "commit", "-q", "-m", "second")
+	runGit(t, repoDir, "tag", "v1.1.0")
+
+	writeFile(t, filepath.Join(projectDir, "c.go"), "package service\n")
+	runGit(t, repoDir, "add", "-A")
+	runGit(t, repoDir, "commit", "-q", "-m", "third")
+
+	return repoDir, projectDir
+}
+
+func revisionNames(revisions []githistory.Revision) []string {
+	names := make([]string, len(revisions))
+	for i, rev := range revisions {
+		names[i] = rev.Name
+	}
+	return names
+}
+
+func TestHeadAndPrefix(t *testing.T) {
+	repoDir, projectDir := setupRepo(t)
+
+	head, err := githistory.New(projectDir).Head()
+	if err != nil {
+		t.Fatalf("Head failed: %v", err)
+	}
+	if len(head) != 40 {
+		t.Errorf("expected full commit hash, got %q", head)
+	}
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{projectDir, "service"},
+		{repoDir, ""},
+	}
+	for _, tt := range tests {
+		prefix, err := githistory.New(tt.dir).Prefix()
+		if err != nil {
+			t.Fatalf("Prefix failed: %v", err)
+		}
+		if prefix != tt.want {
+			t.Errorf("Prefix() in %s = %q, want %q", tt.dir, prefix, tt.want)
+		}
+	}
+
+	if _, err := githistory.New(t.TempDir()).Head(); err == nil {
+		t.Error("expected error outside a repository")
+	}
+}
+
+func TestClean(t *testing.T) {
+	repoDir, projectDir := setupRepo(t)
+	repo := githistory.New(projectDir)
+
+	assertClean := func(want bool) {
+		t.Helper()
+		clean, err := repo.Clean()
+		if err != nil {
+			t.Fatalf("Clean failed: %v", err)
+		}
+		if clean != want {
+			t.Errorf("Clean() = %v, want %v", clean, want)
+		}
+	}
+
+	assertClean(true)
+
+	// Changes outside the project directory do not count
+	writeFile(t, filepath.Join(repoDir, "other.go"), "package other\n\nvar x int\n")
+	assertClean(true)
+
+	writeFile(t, filepath.Join(projectDir, "internal", "new.go"), "package internal\n")
+	assertClean(false)
+	if err := os.Remove(filepath.Join(projectDir, "internal", "new.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, filepath.Join(projectDir, "a.go"), "package service\n\nvar y int\n")
+	assertClean(false)
+
+	if _, err := githistory.New(t.TempDir()).Clean(); err == nil {
+		t.Error("expected error outside a repository")
+	}
+}
+
+func TestRevisions_Tags(t *testing.T) {
+	_, projectDir := setupRepo(t)
+	repo := githistory.New(projectDir)
+
+	tests := []struct {
+		since string
+		want  []string
+	}{
+		{"", []string{"v1.0.0", "v1.1.0", "HEAD"}},
+		{"v1.1.0", []string{"v1.1.0", "HEAD"}},
+	}
+
+	for _, tt := range tests {
+		revisions, err := repo.Revisions(tt.since, "tag")
+		if err != nil {
+			t.Fatalf("Revisions(%q) failed: %v", tt.since, err)
+		}
+		got := revisionNames(revisions)
+		if len(got) != len(tt.want) {
+			t.Fatalf("Revisions(%q) = %v, want %v", tt.since, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Revisions(%q) = %v, want %v", tt.since, got, tt.want)
+				break
+			}
+		}
+		for _, rev := range revisions {
+			if len(rev.Commit) != 40 || rev.Date.IsZero() {
+				t.Errorf("expected commit hash and date, got %+v", rev)
+			}
+		}
+	}
+}
+
+func TestRevisions_TaggedHeadNotDuplicated(t *testing.T) {
+	repoDir, projectDir := setupRepo(t)
+	// Annotated, as release tags usually are
+	runGit(t, repoDir, "tag", "-a", "v1.2.0", "-m", "Release 1.2.0")
+
+	revisions, err := githistory.New(projectDir).Revisions("v1.1.0", "tag")
+	if err != nil {
+		t.Fatalf("Revisions failed: %v", err)
+	}
+	got := revisionNames(revisions)
+	if len(got) != 2 || got[0] != "v1.1.0" || got[1] != "v1.2.0" {
+		t.Errorf("expected [v1.1.0 v1.2.0], got %v", got)
+	}
+}
+
+func TestRevisions_Commits(t *testing.T) {
+	_, projectDir := setupRepo(t)
+	repo := githistory.New(projectDir)
+
+	revisions, err := repo.Revisions("v1.0.0", "commit")
+	if err != nil {
+		t.Fatalf("Revisions failed: %v", err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("expected 3 commits, got %v", revisionNames(revisions))
+	}
+	for _, rev := range revisions {
+		if rev.Name != rev.Commit[:7] {
+			t.Errorf("expected short hash as name, got %s for %s", rev.Name, rev.Commit)
+		}
+	}
+	if revisions[0].Date.After(revisions[2].Date) {
+		t.Error("expected revisions oldest first")
+	}
+
+	all, err := repo.Revisions("", "commit")
+	if err != nil {
+		t.Fatalf("Revisions failed: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("expected 3 commits without since, got %v", revisionNames(all))
+	}
+}
+
+func TestRevisions_Errors(t *testing.T) {
+	_, projectDir := setupRepo(t)
+	repo := githistory.New(projectDir)
+
+	if _, err := repo.Revisions("", "week"); err == nil {
+		t.Error("expected error for unsupported interval")
+	}
+	if _, err := repo.Revisions("v9.9.9", "tag"); err == nil {
+		t.Error("expected error for unknown since revision")
+	}
+	if _, err := githistory.New(t.TempDir()).Revisions("", "tag"); err == nil {
+		t.Error("expected error outside a git repository")
+	}
+}
+
+func TestRevisions_CommitsSinceMergedBranch(t *testing.T) {
+	repoDir, projectDir := setupRepo(t)
+
+	// A commit of a branch merged into HEAD is reachable, but not on the first-parent chain
+	runGit(t, repoDir, "checkout", "-q", "-b", "side", "v1.0.0")
+	writeFile(t, filepath.Join(projectDir, "side.go"), "package service\n")
+	runGit(t, repoDir, "add", "-A")
+	runGit(t, repoDir, "commit", "-q", "-m", "side")
+	runGit(t, repoDir, "checkout", "-q", "-")
+	runGit(t, repoDir, "merge", "-q", "--no-ff", "-m", "merge", "side")
+
+	if _, err := githistory.New(projectDir).Revisions("side", "commit"); err == nil {
+		t.Error("expected error for a since revision off the first-parent history")
+	}
+
+	revisions, err := githistory.New(projectDir).Revisions("v1.1.0", "commit")
+	if err != nil {
+		t.Fatalf("Revisions failed: %v", err)
+	}
+	if len(revisions) != 3 {
+		t.Errorf("expected v1.1.0, the third commit and the merge, got %v", revisionNames(revisions))
+	}
+}
+
+func TestLastCommit(t *testing.T) {
+	_, projectDir := setupRepo(t)
+	repo := githistory.New(projectDir)
+
+	revisions, err := repo.Revisions("", "tag")
+	if err != nil {
+		t.Fatalf("Revisions failed: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"a.go", revisions[0].Commit},
+		{"internal/b.go", revisions[1].Commit},
+		{"c.go", revisions[2].Commit},
+		{"missing.go", ""},
+	}
+	for _, tt := range tests {
+		commit, err := repo.LastCommit(tt.path)
+		if err != nil {
+			t.Fatalf("LastCommit(%s) failed: %v", tt.path, err)
+		}
+		if commit != tt.want {
+			t.Errorf("LastCommit(%s) = %q, want %q", tt.path, commit, tt.want)
+		}
+	}
+
+	if _, err := githistory.New(t.TempDir()).LastCommit("a.go"); err == nil {
+		t.Error("expected error outside a repository")
+	}
+}
+
+func TestLineDates(t *testing.T) {
+	repoDir, projectDir := setupRepo(t)
+	repo := githistory.New(projectDir)
+
+	// a.go has one line from the first commit; append a committed and an uncommitted line
+	writeFile(t, filepath.Join(projectDir, "a.go"), "package service\n\nvar x = 1\n")
+	runGit(t, repoDir, "add", "-A")
+	runGit(t, repoDir, "commit", "-q", "-m", "fourth", "--date", "2020-01-02T12:00:00Z")
+	writeFile(t, filepath.Join(projectDir, "a.go"), "package service\n\nvar x = 1\nvar y = 2\n")
+
+	dates, err := repo.LineDates("a.go")
+	if err != nil {
+		t.Fatalf("LineDates failed: %v", err)
+	}
+	if len(dates) != 3 {
+		t.Fatalf("expected dates for the 3 committed lines, got %v", dates)
+	}
+	if dates[1].Year() < 2021 {
+		t.Errorf("expected line 1 from the first commit, got %v", dates[1])
+	}
+	if got := dates[3].UTC().Format("2006-01-02"); got != "2020-01-02" {
+		t.Errorf("expected line 3 from the backdated commit, got %s", got)
+	}
+	if _, ok := dates[4]; ok {
+		t.Error("expected no date for the uncommitted line")
+	}
+
+	// Lines after an uncommitted one keep their dates at their new line numbers
+	writeFile(t, filepath.Join(projectDir, "a.go"), "package service\n\nvar y = 2\nvar x = 1\n")
+	if dates, err = repo.LineDates("a.go"); err != nil {
+		t.Fatalf("LineDates failed: %v", err)
+	}
+	if _, ok := dates[3]; ok {
+		t.Error("expected no date for the inserted line")
+	}
+	if got := dates[4].UTC().Format("2006-01-02"); got != "2020-01-02" {
+		t.Errorf("expected the committed line to move to line 4, got %v", dates)
+	}
+
+	if _, err := repo.LineDates("missing.go"); err == nil {
+		t.Error("expected error for a file git does not know")
+	}
+}
+
+func TestFiles(t *testing.T) {
+	_, projectDir := setupRepo(t)
+	repo := githistory.New(projectDir)
+
+	revisions, err := repo.Revisions("", "tag")
+	if err != nil {
+		t.Fatalf("Revisions failed: %v", err)
+	}
+
+	files, err := repo.Files(revisions[0].Commit)
+	if err != nil {
+		t.Fatalf("Files failed: %v", err)
+	}
+
+	// Project files of v1.0.0 only, relative to the project directory
+	if err := fstest.TestFS(files, "a.go"); err != nil {
+		t.Errorf("expected a valid file system: %v", err)
+	}
+	for _, absent := range []string{"internal/b.go", "c.go", "other.go", "service"} {
+		if _, err := fs.Stat(files, absent); err == nil {
+			t.Errorf("expected %s not to be in v1.0.0", absent)
+		}
+	}
+
+	files, err = repo.Files(revisions[1].Commit)
+	if err != nil {
+		t.Fatalf("Files failed: %v", err)
+	}
+	content, err := fs.ReadFile(files, "internal/b.go")
+	if err != nil || string(content) != "package internal\n" {
+		t.Errorf("expected internal/b.go of v1.1.0, got %q (%v)", content, err)
+	}
+
+	// The working tree is not modified
+	if _, err := os.Stat(filepath.Join(projectDir, "c.go")); err != nil {
+		t.Errorf("expected working tree to be untouched: %v", err)
+	}
+
+	if _, err := repo.Files("0000000000000000000000000000000000000000"); err == nil {
+		t.Error("expected error for unknown commit")
+	}
+}
+
+func TestLastChanged(t *testing.T) {
+	_, projectDir := setupRepo(t)
+	repo := githistory.New(projectDir)
+
+	changed, err := repo.LastChanged()
+	if err != nil {
+		t.Fatalf("LastChanged failed: %v", err)
+	}
+	if len(changed) != 3 {
+		t.Errorf("expected the 3 files of the project, got %v", changed)
+	}
+	if _, ok := changed["other.go"]; ok {
+		t.Error("expected files outside the project to be excluded")
+	}
+
+	revisions, err := repo.Revisions("", "tag")
+	if err != nil {
+		t.Fatalf("Revisions failed: %v", err)
+	}
+	if !changed["internal/b.go"].Equal(revisions[1].Date) || !changed["c.go"].Equal(revisions[2].Date) {
+		t.Errorf("expected the dates of the commits that added the files, got %v", changed)
+	}
+
+	if _, err := githistory.New(t.TempDir()).LastChanged(); err == nil {
+		t.Error("expected error outside a git repository")
+	}
+}
+
+func TestChurn(t *testing.T) {
+	repoDir, projectDir := setupRepo(t)
+
+	// Modify a.go twice more and a file outside the project once
+	writeFile(t, filepath.Join(projectDir, "a.go"), "package service\n\nfunc A() {}\n")
+	writeFile(t, filepath.Join(repoDir, "other.go"), "package other\n\nfunc B() {}\n")
+	runGit(t, repoDir, "commit", "-q", "-am", "fourth")
+	writeFile(t, filepath.Join(projectDir, "a.go"), "package service\n\nfunc A() { _ = 1 }\n")
+	runGit(t, repoDir, "commit", "-q", "-am", "fifth")
+
+	churn, err := githistory.New(projectDir).Churn("")
+	if err != nil {
+		t.Fatalf("Churn failed: %v", err)
+	}
+
+	if got := churn["a.go"]; len(got.Commits) != 3 || got.LinesChanged != 5 {
+		t.Errorf("expected a.go with 3 commits and 5 changed lines, got %+v", got)
+	}
+	if got := churn["internal/b.go"]; len(got.Commits) != 1 || got.LinesChanged != 1 {
+		t.Errorf("expected internal/b.go with 1 commit, got %+v", got)
+	}
+	if _, ok := churn["other.go"]; ok {
+		t.Error("expected files outside the project to be excluded")
+	}
+
+	recent, err := githistory.New(projectDir).Churn("1 year ago")
+	if err != nil {
+		t.Fatalf("Churn failed: %v", err)
+	}
+	if len(recent["a.go"].Commits) != 3 {
+		t.Errorf("expected all recent commits to be counted, got %+v", recent["a.go"])
+	}
+
+	if _, err := githistory.New(projectDir).Churn("someday"); err == nil {
+		t.Error("expected error for an invalid date")
+	}
+	if _, err := githistory.New(t.TempDir()).Churn(""); err == nil {
+		t.Error("expected error outside a git repository")
+	}
+}
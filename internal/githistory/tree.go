@@ -0,0 +1,159 @@
+package githistory
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// treeFS is a read-only file system over a git tree, for reading a revision without
+// checking it out. Only directories and regular files are in it: symlinks and
+// submodules do not matter for analysis.
+type treeFS struct {
+	tree *object.Tree
+	date time.Time // Modification time of every entry: the date of the commit
+}
+
+// Open implements fs.FS
+func (t treeFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if name == "." {
+		return t.openDir(name, t.tree)
+	}
+
+	entry, err := t.tree.FindEntry(name)
+	if err != nil || !included(entry.Mode) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	if entry.Mode == filemode.Dir {
+		dir, err := t.tree.Tree(name)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		return t.openDir(name, dir)
+	}
+
+	file, err := t.tree.TreeEntryFile(entry)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &treeFile{info: t.fileInfo(entry.Name, entry.Mode, file.Size), ReadCloser: reader}, nil
+}
+
+// openDir lists the included entries of a directory
+func (t treeFS) openDir(name string, dir *object.Tree) (fs.File, error) {
+	entries := make([]fs.DirEntry, 0, len(dir.Entries))
+	for i := range dir.Entries {
+		entry := &dir.Entries[i]
+		if !included(entry.Mode) {
+			continue
+		}
+
+		var size int64
+		if entry.Mode != filemode.Dir {
+			file, err := dir.TreeEntryFile(entry)
+			if err != nil {
+				return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+			}
+			size = file.Size
+		}
+		entries = append(entries, t.fileInfo(entry.Name, entry.Mode, size))
+	}
+
+	return &treeDir{info: t.fileInfo(path.Base(name), filemode.Dir, 0), entries: entries}, nil
+}
+
+// fileInfo describes a tree entry
+func (t treeFS) fileInfo(name string, mode filemode.FileMode, size int64) entryInfo {
+	info := entryInfo{name: name, mode: 0644, size: size, date: t.date}
+	switch mode {
+	case filemode.Dir:
+		info.mode = fs.ModeDir | 0755
+	case filemode.Executable:
+		info.mode = 0755
+	}
+	return info
+}
+
+// included reports whether entries with the given mode are in the file system
+func included(mode filemode.FileMode) bool {
+	return mode == filemode.Dir || mode.IsRegular() || mode == filemode.Executable
+}
+
+// entryInfo describes a tree entry, both as fs.FileInfo and as fs.DirEntry
+type entryInfo struct {
+	name string
+	mode fs.FileMode
+	size int64
+	date time.Time
+}
+
+func (i entryInfo) Name() string               { return i.name }
+func (i entryInfo) Size() int64                { return i.size }
+func (i entryInfo) Mode() fs.FileMode          { return i.mode }
+func (i entryInfo) ModTime() time.Time         { return i.date }
+func (i entryInfo) IsDir() bool                { return i.mode.IsDir() }
+func (i entryInfo) Sys() any                   { return nil }
+func (i entryInfo) Type() fs.FileMode          { return i.mode.Type() }
+func (i entryInfo) Info() (fs.FileInfo, error) { return i, nil }
+
+// treeFile is an open file of a tree
+type treeFile struct {
+	info entryInfo
+	io.ReadCloser
+}
+
+// Stat implements fs.File
+func (f *treeFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+// treeDir is an open directory of a tree
+type treeDir struct {
+	info    entryInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+// Stat implements fs.File
+func (d *treeDir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+// Read implements fs.File
+func (d *treeDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+// Close implements fs.File
+func (d *treeDir) Close() error {
+	return nil
+}
+
+// ReadDir implements fs.ReadDirFile
+func (d *treeDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries := d.entries[d.offset:]
+	if n > 0 {
+		if len(entries) == 0 {
+			return nil, io.EOF
+		}
+		if n < len(entries) {
+			entries = entries[:n]
+		}
+	}
+	d.offset += len(entries)
+	return entries, nil
+}
@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"path"
+	"sort"
+)
+
+// Coupling is the weight of the imports of one local package by another
+type Coupling struct {
+	From      string   // Directory of the importing package
+	To        string   // Directory of the imported package
+	Files     int      // Files of From importing To
+	Symbols   []string // Distinct symbols of To used by From, sorted
+	CallSites int      // References of From to the symbols of To
+}
+
+// Couplings aggregates the file dependencies between local packages into package level
+// couplings, sorted by importing and imported package. Symbols and call sites are only
+// known for graphs built in detailed mode.
+func (g *Graph) Couplings() []Coupling {
+	type edge struct{ from, to string }
+	couplings := make(map[edge]*Coupling)
+	symbols := make(map[edge]map[string]bool)
+
+	for _, node := range g.Nodes {
+		dir := path.Dir(node.RelPath)
+		for _, dep := range node.Dependencies {
+			if !dep.IsLocal || dep.LocalPath == dir {
+				continue
+			}
+			key := edge{dir, dep.LocalPath}
+			coupling, ok := couplings[key]
+			if !ok {
+				coupling = &Coupling{From: dir, To: dep.LocalPath}
+				couplings[key] = coupling
+				symbols[key] = make(map[string]bool)
+			}
+			coupling.Files++
+			coupling.CallSites += dep.CallSites
+			for _, symbol := range dep.UsedSymbols {
+				symbols[key][symbol] = true
+			}
+		}
+	}
+
+	result := make([]Coupling, 0, len(couplings))
+	for key, coupling := range couplings {
+		for symbol := range symbols[key] {
+			coupling.Symbols = append(coupling.Symbols, symbol)
+		}
+		sort.Strings(coupling.Symbols)
+		result = append(result, *coupling)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].From != result[j].From {
+			return result[i].From < result[j].From
+		}
+		return result[i].To < result[j].To
+	})
+	return result
+}
@@ -0,0 +1,42 @@
+package graph_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+)
+
+func TestCouplings(t *testing.T) {
+	files := []graph.FileInfo{
+		testFileInfo{relPath: "cmd/app/main.go", pkg: "main", imports: []string{"github.com/test/project/pkg/service", "fmt"}},
+		testFileInfo{relPath: "cmd/app/flags.go", pkg: "main", imports: []string{"github.com/test/project/pkg/service"}},
+		testFileInfo{relPath: "pkg/service/service.go", pkg: "service", imports: []string{"github.com/test/project/pkg/store"}},
+		testFileInfo{relPath: "pkg/service/service_test.go", pkg: "service", imports: []string{"github.com/test/project/pkg/service"}},
+		testFileInfo{relPath: "pkg/store/store.go", pkg: "store"},
+	}
+	usageMap := map[string]map[string][]string{
+		"cmd/app/main.go":        {"github.com/test/project/pkg/service": {"New", "Run"}, "fmt": {"Println"}},
+		"cmd/app/flags.go":       {"github.com/test/project/pkg/service": {"Config"}},
+		"pkg/service/service.go": {"github.com/test/project/pkg/store": {"Save"}},
+	}
+	g := graph.BuildDetailed(files, "github.com/test/project", usageMap)
+	g.ApplyCallSites(map[string]map[string]int{
+		"cmd/app/main.go":        {"github.com/test/project/pkg/service": 4, "fmt": 2},
+		"cmd/app/flags.go":       {"github.com/test/project/pkg/service": 1},
+		"pkg/service/service.go": {"github.com/test/project/pkg/store": 2},
+	})
+
+	if dep := g.Nodes[0].Dependencies[1]; dep.GetCallSites() != 2 {
+		t.Errorf("expected 2 call sites of fmt, got %d", dep.GetCallSites())
+	}
+
+	// External imports and imports of the package itself are no couplings
+	expected := []graph.Coupling{
+		{From: "cmd/app", To: "pkg/service", Files: 2, Symbols: []string{"Config", "New", "Run"}, CallSites: 5},
+		{From: "pkg/service", To: "pkg/store", Files: 1, Symbols: []string{"Save"}, CallSites: 2},
+	}
+	if couplings := g.Couplings(); !reflect.DeepEqual(couplings, expected) {
+		t.Errorf("Couplings() = %+v, want %+v", couplings, expected)
+	}
+}
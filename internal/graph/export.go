@@ -0,0 +1,419 @@
+package graph
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the version of the JSON graph format written by Export and read by Import
+const SchemaVersion = 1
+
+// ExportFormats lists the supported export formats
+var ExportFormats = []string{"json", "graphml", "gexf"}
+
+// jsonGraph is the file-level JSON representation of a graph (see docs/graph-format.md)
+type jsonGraph struct {
+	Version int        `json:"version"`
+	Module  string     `json:"module"`
+	Files   []jsonFile `json:"files"`
+}
+
+type jsonFile struct {
+	Path          string           `json:"path"`
+	Package       string           `json:"package"`
+	IsTest        bool             `json:"is_test,omitempty"`
+	PackageLine   int              `json:"package_line,omitempty"`
+	PackageColumn int              `json:"package_column,omitempty"`
+	DIFramework   string           `json:"di_framework,omitempty"`
+	Lines         int              `json:"lines,omitempty"`
+	Size          int64            `json:"size,omitempty"`
+	Modified      string           `json:"modified,omitempty"` // RFC 3339
+	Dependencies  []jsonDependency `json:"dependencies"`
+}
+
+type jsonDependency struct {
+	ImportPath string   `json:"import_path"`
+	Local      bool     `json:"local"`
+	LocalPath  string   `json:"local_path,omitempty"`
+	Symbols    []string `json:"symbols,omitempty"`
+	Line       int      `json:"line,omitempty"`
+	Column     int      `json:"column,omitempty"`
+	Blank      bool     `json:"blank,omitempty"`
+	Registers  []string `json:"registers,omitempty"`
+	CallSites  int      `json:"call_sites,omitempty"`
+}
+
+// Export serializes the graph in the given format: "json" (file level, lossless) or
+// "graphml"/"gexf" (package level, for tools like Gephi, yEd or Neo4j)
+func (g *Graph) Export(format string) (string, error) {
+	switch format {
+	case "json":
+		return g.exportJSON()
+	case "graphml":
+		return g.exportGraphML()
+	case "gexf":
+		return g.exportGEXF()
+	default:
+		return "", fmt.Errorf("unsupported graph format %q (supported: %s)", format, strings.Join(ExportFormats, ", "))
+	}
+}
+
+// Import reads a graph previously written by Export in JSON format
+func Import(data []byte) (*Graph, error) {
+	var jg jsonGraph
+	if err := json.Unmarshal(data, &jg); err != nil {
+		return nil, fmt.Errorf("parsing graph: %w", err)
+	}
+	if jg.Version != SchemaVersion {
+		return nil, fmt.Errorf("unsupported graph schema version %d (expected %d)", jg.Version, SchemaVersion)
+	}
+
+	g := &Graph{
+		Nodes:         make([]FileNode, 0, len(jg.Files)),
+		module:        jg.Module,
+		localPackages: make(map[string]bool),
+	}
+
+	for _, file := range jg.Files {
+		if file.Path == "" {
+			return nil, fmt.Errorf("graph file entry without path")
+		}
+		// Graphs written by other tools on Windows may use backslashes
+		file.Path = strings.ReplaceAll(file.Path, `\`, "/")
+		g.localPackages[path.Dir(file.Path)] = true
+
+		node := FileNode{
+			RelPath:       file.Path,
+			Package:       file.Package,
+			Dependencies:  make([]Dependency, 0, len(file.Dependencies)),
+			BaseName:      strings.TrimSuffix(strings.TrimSuffix(path.Base(file.Path), ".go"), "_test"),
+			IsTest:        file.IsTest,
+			PackageLine:   file.PackageLine,
+			PackageColumn: file.PackageColumn,
+			DIFramework:   file.DIFramework,
+			LineCount:     file.Lines,
+			Size:          file.Size,
+		}
+		if file.Modified != "" {
+			modTime, err := time.Parse(time.RFC3339Nano, file.Modified)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid modified time: %w", file.Path, err)
+			}
+			node.ModTime = modTime
+		}
+		for _, dep := range file.Dependencies {
+			node.Dependencies = append(node.Dependencies, Dependency{
+				ImportPath:  dep.ImportPath,
+				IsLocal:     dep.Local,
+				LocalPath:   strings.ReplaceAll(dep.LocalPath, `\`, "/"),
+				UsedSymbols: dep.Symbols,
+				Line:        dep.Line,
+				Column:      dep.Column,
+				Blank:       dep.Blank,
+				Registers:   dep.Registers,
+				CallSites:   dep.CallSites,
+			})
+		}
+		g.Nodes = append(g.Nodes, node)
+	}
+
+	return g, nil
+}
+
+// GetModule returns the module path of the graph
+func (g *Graph) GetModule() string {
+	return g.module
+}
+
+func (g *Graph) exportJSON() (string, error) {
+	jg := jsonGraph{
+		Version: SchemaVersion,
+		Module:  g.module,
+		Files:   make([]jsonFile, 0, len(g.Nodes)),
+	}
+
+	for _, node := range g.Nodes {
+		file := jsonFile{
+			Path:          node.RelPath,
+			Package:       node.Package,
+			IsTest:        node.IsTest,
+			PackageLine:   node.PackageLine,
+			PackageColumn: node.PackageColumn,
+			DIFramework:   node.DIFramework,
+			Lines:         node.LineCount,
+			Size:          node.Size,
+			Dependencies:  make([]jsonDependency, 0, len(node.Dependencies)),
+		}
+		if !node.ModTime.IsZero() {
+			file.Modified = node.ModTime.UTC().Format(time.RFC3339Nano)
+		}
+		for _, dep := range node.Dependencies {
+			file.Dependencies = append(file.Dependencies, jsonDependency{
+				ImportPath: dep.ImportPath,
+				Local:      dep.IsLocal,
+				LocalPath:  dep.LocalPath,
+				Symbols:    dep.UsedSymbols,
+				Line:       dep.Line,
+				Column:     dep.Column,
+				Blank:      dep.Blank,
+				Registers:  dep.Registers,
+				CallSites:  dep.CallSites,
+			})
+		}
+		jg.Files = append(jg.Files, file)
+	}
+
+	data, err := json.MarshalIndent(jg, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// packageNode is a package in the package-level graph
+type packageNode struct {
+	ID      string // Local directory or external import path
+	Label   string // Package name
+	Kind    string // "local", "stdlib" or "external"
+	Files   int    // Number of scanned files (local packages only)
+	Lines   int    // Lines of the scanned files (local packages only)
+	Imports map[string]int
+	Wiring  map[string]bool // Imported packages wired in at runtime by a blank import
+}
+
+// packageGraph aggregates file dependencies into package-level nodes and weighted edges
+func (g *Graph) packageGraph() []*packageNode {
+	nodes := make(map[string]*packageNode)
+	getNode := func(id, label, kind string) *packageNode {
+		if node, ok := nodes[id]; ok {
+			return node
+		}
+		node := &packageNode{ID: id, Label: label, Kind: kind, Imports: make(map[string]int), Wiring: make(map[string]bool)}
+		nodes[id] = node
+		return node
+	}
+
+	for _, file := range g.Nodes {
+		dir := path.Dir(file.RelPath)
+		source := getNode(dir, strings.TrimSuffix(file.Package, "_test"), "local")
+		source.Files++
+		source.Lines += file.LineCount
+
+		for _, dep := range file.Dependencies {
+			target := dep.ImportPath
+			if dep.IsLocal {
+				target = dep.LocalPath
+				getNode(target, path.Base(target), "local")
+			} else if IsStdLib(dep.ImportPath) {
+				getNode(target, path.Base(target), "stdlib")
+			} else {
+				getNode(target, path.Base(target), "external")
+			}
+			if target != dir {
+				source.Imports[target]++
+				if dep.IsRuntimeWiring() {
+					source.Wiring[target] = true
+				}
+			}
+		}
+	}
+
+	result := make([]*packageNode, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, node)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// sortedImports returns the imported package IDs of a node in a stable order
+func (n *packageNode) sortedImports() []string {
+	targets := make([]string, 0, len(n.Imports))
+	for target := range n.Imports {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+	Default  string `xml:"default,omitempty"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (g *Graph) exportGraphML() (string, error) {
+	doc := graphMLDocument{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", AttrName: "label", AttrType: "string"},
+			{ID: "kind", For: "node", AttrName: "kind", AttrType: "string"},
+			{ID: "files", For: "node", AttrName: "files", AttrType: "int"},
+			{ID: "lines", For: "node", AttrName: "lines", AttrType: "int"},
+			{ID: "weight", For: "edge", AttrName: "weight", AttrType: "int"},
+			{ID: "wiring", For: "edge", AttrName: "wiring", AttrType: "boolean", Default: "false"},
+		},
+		Graph: graphMLGraph{ID: g.module, EdgeDefault: "directed"},
+	}
+
+	for _, node := range g.packageGraph() {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: node.ID,
+			Data: []graphMLData{
+				{Key: "label", Value: node.Label},
+				{Key: "kind", Value: node.Kind},
+				{Key: "files", Value: fmt.Sprintf("%d", node.Files)},
+				{Key: "lines", Value: fmt.Sprintf("%d", node.Lines)},
+			},
+		})
+		for _, target := range node.sortedImports() {
+			edgeData := []graphMLData{{Key: "weight", Value: fmt.Sprintf("%d", node.Imports[target])}}
+			if node.Wiring[target] {
+				edgeData = append(edgeData, graphMLData{Key: "wiring", Value: "true"})
+			}
+			doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+				Source: node.ID,
+				Target: target,
+				Data:   edgeData,
+			})
+		}
+	}
+
+	return marshalXML(doc)
+}
+
+type gexfDocument struct {
+	XMLName xml.Name  `xml:"gexf"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string         `xml:"defaultedgetype,attr"`
+	Mode            string         `xml:"mode,attr"`
+	Attributes      gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode     `xml:"nodes>node"`
+	Edges           []gexfEdge     `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID        string          `xml:"id,attr"`
+	Label     string          `xml:"label,attr"`
+	AttValues []gexfAttrValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttrValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type gexfEdge struct {
+	ID     string `xml:"id,attr"`
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+	Weight int    `xml:"weight,attr"`
+	Kind   string `xml:"kind,attr,omitempty"` // "wiring" for runtime wiring edges
+}
+
+func (g *Graph) exportGEXF() (string, error) {
+	doc := gexfDocument{
+		XMLNS:   "http://gexf.net/1.3",
+		Version: "1.3",
+		Graph: gexfGraph{
+			DefaultEdgeType: "directed",
+			Mode:            "static",
+			Attributes: gexfAttributes{
+				Class: "node",
+				Attributes: []gexfAttribute{
+					{ID: "kind", Title: "kind", Type: "string"},
+					{ID: "files", Title: "files", Type: "integer"},
+					{ID: "lines", Title: "lines", Type: "integer"},
+				},
+			},
+		},
+	}
+
+	for _, node := range g.packageGraph() {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, gexfNode{
+			ID:    node.ID,
+			Label: node.Label,
+			AttValues: []gexfAttrValue{
+				{For: "kind", Value: node.Kind},
+				{For: "files", Value: fmt.Sprintf("%d", node.Files)},
+				{For: "lines", Value: fmt.Sprintf("%d", node.Lines)},
+			},
+		})
+		for _, target := range node.sortedImports() {
+			edge := gexfEdge{
+				ID:     fmt.Sprintf("%d", len(doc.Graph.Edges)),
+				Source: node.ID,
+				Target: target,
+				Weight: node.Imports[target],
+			}
+			if node.Wiring[target] {
+				edge.Kind = "wiring"
+			}
+			doc.Graph.Edges = append(doc.Graph.Edges, edge)
+		}
+	}
+
+	return marshalXML(doc)
+}
+
+// marshalXML renders an indented XML document with declaration
+func marshalXML(doc any) (string, error) {
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data) + "\n", nil
+}
@@ -0,0 +1,242 @@
+package graph_test
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+)
+
+func exportTestGraph() *graph.Graph {
+	files := []graph.FileInfo{
+		testFileInfo{
+			relPath:   "cmd/app/main.go",
+			baseName:  "main",
+			pkg:       "main",
+			imports:   []string{"github.com/test/project/internal/order", "fmt"},
+			lines:     []int{4, 5},
+			columns:   []int{2, 2},
+			lineCount: 12,
+			size:      230,
+		},
+		testFileInfo{
+			relPath:  "internal/order/order.go",
+			baseName: "order",
+			pkg:      "order",
+			imports:  []string{"github.com/google/uuid"},
+		},
+		testFileInfo{
+			relPath:     "internal/order/service.go",
+			baseName:    "service",
+			pkg:         "order",
+			imports:     []string{"github.com/google/uuid"},
+			diFramework: "fx",
+		},
+		testFileInfo{
+			relPath:  "internal/order/order_test.go",
+			baseName: "order",
+			pkg:      "order_test",
+			imports:  []string{"github.com/test/project/internal/order", "testing"},
+			isTest:   true,
+		},
+	}
+	return graph.Build(files, "github.com/test/project")
+}
+
+func TestExport_JSONRoundTrip(t *testing.T) {
+	g := exportTestGraph()
+	g.ApplyCallSites(map[string]map[string]int{"cmd/app/main.go": {"github.com/test/project/internal/order": 3}})
+	g.ApplyModTimes(map[string]time.Time{"cmd/app/main.go": time.Date(2026, 3, 14, 9, 26, 53, 0, time.UTC)})
+
+	data, err := g.Export("json")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.Contains(data, `"version": 1`) || !strings.Contains(data, `"module": "github.com/test/project"`) {
+		t.Errorf("expected schema version and module in JSON, got:\n%s", data)
+	}
+
+	imported, err := graph.Import([]byte(data))
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if imported.GetModule() != "github.com/test/project" {
+		t.Errorf("expected module to round-trip, got %s", imported.GetModule())
+	}
+	if imported.Nodes[0].Dependencies[0].Line != 4 {
+		t.Errorf("expected import line to round-trip, got %d", imported.Nodes[0].Dependencies[0].Line)
+	}
+	if imported.Nodes[0].Dependencies[0].Column != 2 {
+		t.Errorf("expected import column to round-trip, got %d", imported.Nodes[0].Dependencies[0].Column)
+	}
+	if imported.Nodes[0].Dependencies[0].CallSites != 3 {
+		t.Errorf("expected call sites to round-trip, got %d", imported.Nodes[0].Dependencies[0].CallSites)
+	}
+	if imported.Nodes[0].PackageLine != 1 || imported.Nodes[0].PackageColumn != 9 {
+		t.Errorf("expected package position to round-trip, got %d:%d", imported.Nodes[0].PackageLine, imported.Nodes[0].PackageColumn)
+	}
+	if !strings.Contains(data, `"modified": "2026-03-14T09:26:53Z"`) || imported.Nodes[0].LineCount != 12 || imported.Nodes[0].Size != 230 {
+		t.Errorf("expected file metadata to round-trip, got %+v", imported.Nodes[0])
+	}
+	if !reflect.DeepEqual(imported.Nodes, g.Nodes) {
+		t.Errorf("expected nodes to round-trip\nwant: %+v\ngot:  %+v", g.Nodes, imported.Nodes)
+	}
+	if len(imported.GetLocalPackages()) != 2 {
+		t.Errorf("expected 2 local packages, got %v", imported.GetLocalPackages())
+	}
+}
+
+func TestImport_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"malformed", `{"version": 1,`},
+		{"wrong version", `{"version": 2, "files": []}`},
+		{"missing path", `{"version": 1, "files": [{"package": "order"}]}`},
+		{"invalid modified time", `{"version": 1, "files": [{"path": "a.go", "modified": "yesterday"}]}`},
+	}
+
+	for _, tt := range tests {
+		if _, err := graph.Import([]byte(tt.data)); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestImport_NormalizesBackslashes(t *testing.T) {
+	data := `{"version": 1, "files": [{"path": "internal\\app\\app.go", "package": "app",
+		"dependencies": [{"import_path": "example.com/test/internal/domain", "local": true, "local_path": "internal\\domain"}]}]}`
+
+	g, err := graph.Import([]byte(data))
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	node := g.Nodes[0]
+	if node.RelPath != "internal/app/app.go" || node.BaseName != "app" {
+		t.Errorf("expected slash-separated path, got %q (base %q)", node.RelPath, node.BaseName)
+	}
+	if dep := node.Dependencies[0]; dep.LocalPath != "internal/domain" {
+		t.Errorf("expected slash-separated local path, got %q", dep.LocalPath)
+	}
+	if pkgs := g.GetLocalPackages(); len(pkgs) != 1 || pkgs[0] != "internal/app" {
+		t.Errorf("unexpected local packages: %v", pkgs)
+	}
+}
+
+func TestExport_GraphML(t *testing.T) {
+	data, err := exportTestGraph().Export("graphml")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	var doc struct {
+		Graph struct {
+			Nodes []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+				Data   string `xml:"data"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("invalid GraphML: %v\n%s", err, data)
+	}
+
+	// cmd/app, internal/order, fmt, uuid and testing
+	if len(doc.Graph.Nodes) != 5 {
+		t.Errorf("expected 5 package nodes, got %d", len(doc.Graph.Nodes))
+	}
+
+	edges := make(map[string]string)
+	for _, edge := range doc.Graph.Edges {
+		edges[edge.Source+" -> "+edge.Target] = edge.Data
+	}
+	if edges["internal/order -> github.com/google/uuid"] != "2" {
+		t.Errorf("expected weighted edge from internal/order to uuid, got %v", edges)
+	}
+	if _, ok := edges["internal/order -> internal/order"]; ok {
+		t.Error("expected no self edge for external test package")
+	}
+}
+
+func TestExport_GEXF(t *testing.T) {
+	data, err := exportTestGraph().Export("gexf")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	if !strings.Contains(data, `<gexf xmlns="http://gexf.net/1.3" version="1.3">`) {
+		t.Errorf("expected GEXF 1.3 root element, got:\n%s", data)
+	}
+	if !strings.Contains(data, `source="cmd/app" target="internal/order" weight="1"`) {
+		t.Errorf("expected cmd/app -> internal/order edge, got:\n%s", data)
+	}
+	if !strings.Contains(data, `<attvalue for="kind" value="stdlib"></attvalue>`) {
+		t.Errorf("expected stdlib kind attribute, got:\n%s", data)
+	}
+}
+
+func TestExport_UnsupportedFormat(t *testing.T) {
+	if _, err := exportTestGraph().Export("dot"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestExport_RuntimeWiring(t *testing.T) {
+	files := []graph.FileInfo{
+		testFileInfo{
+			relPath:      "cmd/app/main.go",
+			baseName:     "main",
+			pkg:          "main",
+			imports:      []string{"github.com/test/project/internal/drivers", "github.com/lib/pq"},
+			blankImports: []string{"github.com/test/project/internal/drivers", "github.com/lib/pq"},
+		},
+		testFileInfo{
+			relPath:       "internal/drivers/drivers.go",
+			baseName:      "drivers",
+			pkg:           "drivers",
+			registrations: []string{"sql.Register"},
+		},
+	}
+	g := graph.Build(files, "github.com/test/project")
+
+	data, err := g.Export("json")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.Contains(data, `"blank": true`) || !strings.Contains(data, `"sql.Register"`) {
+		t.Errorf("expected blank imports and registrations in JSON, got:\n%s", data)
+	}
+	imported, err := graph.Import([]byte(data))
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if !reflect.DeepEqual(imported.Nodes, g.Nodes) {
+		t.Errorf("expected runtime wiring to round-trip\nwant: %+v\ngot:  %+v", g.Nodes, imported.Nodes)
+	}
+
+	graphML, err := g.Export("graphml")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.Contains(graphML, `<data key="wiring">true</data>`) {
+		t.Errorf("expected wiring data on GraphML edges, got:\n%s", graphML)
+	}
+
+	gexf, err := g.Export("gexf")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.Contains(gexf, `target="internal/drivers" weight="1" kind="wiring"`) {
+		t.Errorf("expected wiring kind on GEXF edges, got:\n%s", gexf)
+	}
+}
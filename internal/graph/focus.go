@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Focus returns the subgraph of the local packages matching pattern and the packages
+// within depth imports of them, in either direction. The pattern is a directory glob
+// where "**" spans any number of segments (e.g. internal/app/**). Imports of local
+// packages outside the subgraph are dropped; external imports are kept.
+func (g *Graph) Focus(pattern string, depth int) (*Graph, error) {
+	if depth < 0 {
+		return nil, fmt.Errorf("invalid depth %d: must be 0 or more", depth)
+	}
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, fmt.Errorf("invalid focus pattern %q: %w", pattern, err)
+		}
+	}
+
+	// Package level edges in both directions
+	neighbors := make(map[string]map[string]bool)
+	link := func(from, to string) {
+		if neighbors[from] == nil {
+			neighbors[from] = make(map[string]bool)
+		}
+		neighbors[from][to] = true
+	}
+	kept := make(map[string]bool)
+	for _, node := range g.Nodes {
+		dir := path.Dir(node.RelPath)
+		if matchSegments(segments, strings.Split(dir, "/")) {
+			kept[dir] = true
+		}
+		for _, dep := range node.Dependencies {
+			if dep.IsLocal && dep.LocalPath != dir {
+				link(dir, dep.LocalPath)
+				link(dep.LocalPath, dir)
+			}
+		}
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("no packages match focus pattern %q", pattern)
+	}
+
+	// Breadth-first expansion, one import per level
+	frontier := make(map[string]bool, len(kept))
+	for pkg := range kept {
+		frontier[pkg] = true
+	}
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		next := make(map[string]bool)
+		for pkg := range frontier {
+			for neighbor := range neighbors[pkg] {
+				if !kept[neighbor] {
+					kept[neighbor] = true
+					next[neighbor] = true
+				}
+			}
+		}
+		frontier = next
+	}
+
+	focused := &Graph{
+		module:        g.module,
+		localPackages: make(map[string]bool),
+		registrations: g.registrations,
+	}
+	for pkg := range g.localPackages {
+		if kept[pkg] {
+			focused.localPackages[pkg] = true
+		}
+	}
+	for _, node := range g.Nodes {
+		if !kept[path.Dir(node.RelPath)] {
+			continue
+		}
+		deps := make([]Dependency, 0, len(node.Dependencies))
+		for _, dep := range node.Dependencies {
+			if dep.IsLocal && !kept[dep.LocalPath] {
+				continue
+			}
+			deps = append(deps, dep)
+		}
+		node.Dependencies = deps
+		focused.Nodes = append(focused.Nodes, node)
+	}
+	return focused, nil
+}
+
+// matchSegments matches path segments against a glob pattern where "**" spans any
+// number of segments (including none) and other segments use path.Match syntax.
+// This duplicates the validator's matching to maintain internal package isolation.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(pattern[0], segments[0]); err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,71 @@
+package graph_test
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+)
+
+func TestFocus(t *testing.T) {
+	// cmd/app -> internal/app/api -> internal/app/core -> internal/store -> internal/db
+	files := []graph.FileInfo{
+		testFileInfo{relPath: "cmd/app/main.go", pkg: "main", imports: []string{"github.com/test/project/internal/app/api"}},
+		testFileInfo{relPath: "internal/app/api/api.go", pkg: "api", imports: []string{"github.com/test/project/internal/app/core", "net/http"}},
+		testFileInfo{relPath: "internal/app/core/core.go", pkg: "core", imports: []string{"github.com/test/project/internal/store"}},
+		testFileInfo{relPath: "internal/store/store.go", pkg: "store", imports: []string{"github.com/test/project/internal/db"}},
+		testFileInfo{relPath: "internal/db/db.go", pkg: "db"},
+	}
+	g := graph.Build(files, "github.com/test/project")
+
+	packages := func(g *graph.Graph) string {
+		var dirs []string
+		for _, node := range g.Nodes {
+			dirs = append(dirs, path.Dir(node.RelPath))
+		}
+		sort.Strings(dirs)
+		return strings.Join(dirs, ",")
+	}
+
+	tests := []struct {
+		pattern  string
+		depth    int
+		expected string
+	}{
+		{"internal/app/**", 0, "internal/app/api,internal/app/core"},
+		{"internal/app/**", 1, "cmd/app,internal/app/api,internal/app/core,internal/store"},
+		{"internal/app/**", 2, "cmd/app,internal/app/api,internal/app/core,internal/db,internal/store"},
+		{"internal/store", 1, "internal/app/core,internal/db,internal/store"},
+		{"internal/*", 0, "internal/db,internal/store"},
+	}
+	for _, tt := range tests {
+		focused, err := g.Focus(tt.pattern, tt.depth)
+		if err != nil {
+			t.Fatalf("Focus(%q, %d) failed: %v", tt.pattern, tt.depth, err)
+		}
+		if got := packages(focused); got != tt.expected {
+			t.Errorf("Focus(%q, %d) = %s, want %s", tt.pattern, tt.depth, got, tt.expected)
+		}
+	}
+
+	// Imports of dropped local packages are removed, external imports are kept
+	focused, err := g.Focus("internal/app/api", 0)
+	if err != nil {
+		t.Fatalf("Focus failed: %v", err)
+	}
+	if len(focused.Nodes) != 1 || len(focused.Nodes[0].Dependencies) != 1 || focused.Nodes[0].Dependencies[0].ImportPath != "net/http" {
+		t.Errorf("expected only the external import to remain, got %+v", focused.Nodes)
+	}
+
+	if _, err := g.Focus("internal/missing/**", 1); err == nil {
+		t.Error("expected error for pattern matching no package")
+	}
+	if _, err := g.Focus("internal/[", 1); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+	if _, err := g.Focus("internal/**", -1); err == nil {
+		t.Error("expected error for negative depth")
+	}
+}
@@ -1,8 +1,9 @@
 package graph
 
 import (
-	"path/filepath"
+	"path"
 	"strings"
+	"time"
 )
 
 // FileInfo interface defines what we need from scanned files
@@ -10,8 +11,17 @@ type FileInfo interface {
 	GetRelPath() string
 	GetPackage() string
 	GetImports() []string
+	GetImportLines() []int   // Line of each import, parallel to GetImports (nil if unknown)
+	GetImportColumns() []int // Column of each import, parallel to GetImports (nil if unknown)
+	GetPackageLine() int     // Line of the package clause (0 if unknown)
+	GetPackageColumn() int   // Column of the package clause (0 if unknown)
 	GetBaseName() string
 	GetIsTest() bool
+	GetBlankImports() []string      // Imports for side effects only (import _ "path")
+	GetInitRegistrations() []string // What the file's init functions register (nil if unknown)
+	GetDIFramework() string         // DI framework whose wiring the file holds ("wire", "fx", "dig"; empty if none)
+	GetLineCount() int              // Number of lines (0 if unknown)
+	GetSize() int64                 // Size in bytes (0 if unknown)
 }
 
 type Dependency struct {
@@ -19,6 +29,11 @@ type Dependency struct {
 	IsLocal     bool     // Whether this is a local (project) import
 	LocalPath   string   // Relative path for local imports (e.g., "pkg/http")
 	UsedSymbols []string // Symbols used from this import (empty if not tracked)
+	Line        int      // Line of the import in the file (0 if unknown)
+	Column      int      // Column of the import path in the file (0 if unknown)
+	Blank       bool     // Imported for side effects only (import _ "path")
+	Registers   []string // For blank imports of local packages: what the package's init functions register
+	CallSites   int      // References to the used symbols in the file (0 if not tracked)
 }
 
 // Methods for adapter pattern (structural typing - no imports needed)
@@ -38,12 +53,49 @@ func (d Dependency) GetUsedSymbols() []string {
 	return d.UsedSymbols
 }
 
+func (d Dependency) GetCallSites() int {
+	return d.CallSites
+}
+
+// IsRuntimeWiring reports whether the import wires the imported package in at runtime:
+// a blank import whose package registers itself from init (database drivers, image
+// decoders, plugins). Local packages count only if their init functions register
+// something; the init functions of other packages are not scanned, so any blank import
+// of them counts, except "embed".
+func (d Dependency) IsRuntimeWiring() bool {
+	if !d.Blank {
+		return false
+	}
+	if d.IsLocal {
+		return len(d.Registers) > 0
+	}
+	return d.ImportPath != "embed"
+}
+
+func (d Dependency) GetRegistrations() []string {
+	return d.Registers
+}
+
+func (d Dependency) GetLine() int {
+	return d.Line
+}
+
+func (d Dependency) GetColumn() int {
+	return d.Column
+}
+
 type FileNode struct {
-	RelPath      string
-	Package      string
-	Dependencies []Dependency
-	BaseName     string // Base name without extension and _test suffix
-	IsTest       bool   // Whether this is a test file
+	RelPath       string
+	Package       string
+	Dependencies  []Dependency
+	BaseName      string    // Base name without extension and _test suffix
+	IsTest        bool      // Whether this is a test file
+	PackageLine   int       // Line of the package clause (0 if unknown)
+	PackageColumn int       // Column of the package name in the package clause (0 if unknown)
+	DIFramework   string    // DI framework whose wiring the file holds (empty if none)
+	LineCount     int       // Number of lines (0 if unknown)
+	Size          int64     // Size in bytes (0 if unknown)
+	ModTime       time.Time // Time of the last commit that changed the file (zero if unknown)
 }
 
 // Methods for adapter pattern (structural typing - no imports needed)
@@ -55,6 +107,14 @@ func (fn FileNode) GetPackage() string {
 	return fn.Package
 }
 
+func (fn FileNode) GetPackageLine() int {
+	return fn.PackageLine
+}
+
+func (fn FileNode) GetPackageColumn() int {
+	return fn.PackageColumn
+}
+
 func (fn FileNode) GetBaseName() string {
 	return fn.BaseName
 }
@@ -63,94 +123,203 @@ func (fn FileNode) GetIsTest() bool {
 	return fn.IsTest
 }
 
+func (fn FileNode) GetDIFramework() string {
+	return fn.DIFramework
+}
+
+func (fn FileNode) GetLineCount() int {
+	return fn.LineCount
+}
+
+func (fn FileNode) GetSize() int64 {
+	return fn.Size
+}
+
+func (fn FileNode) GetModTime() time.Time {
+	return fn.ModTime
+}
+
 type Graph struct {
 	Nodes         []FileNode
 	module        string
-	localPackages map[string]bool // Set of all local package paths
+	localPackages map[string]bool     // Set of all local package paths
+	registrations map[string][]string // Local package path -> what its init functions register
+	fileInits     map[string][]string // Non-test file path -> what its init functions register
+	moduleRoots   map[string]string   // Nested module path -> directory, as applied by ApplyModuleRoots
 }
 
 // Build creates a dependency graph from scanned files
 func Build(files []FileInfo, module string) *Graph {
+	return BuildDetailed(files, module, nil)
+}
+
+// BuildDetailed creates a dependency graph with detailed symbol usage from scanned files
+// usageMap is a map from file RelPath to (import path to used symbols)
+func BuildDetailed(files []FileInfo, module string, usageMap map[string]map[string][]string) *Graph {
 	g := &Graph{
 		Nodes:         make([]FileNode, 0, len(files)),
 		module:        module,
 		localPackages: make(map[string]bool),
+		fileInits:     make(map[string][]string),
 	}
 
 	// First pass: collect all local packages
+	g.collectPackages(files)
+
+	// Second pass: build dependencies with usage information
 	for _, file := range files {
-		// Get package path from file location
-		dir := filepath.Dir(file.GetRelPath())
-		dir = filepath.ToSlash(dir)
-		g.localPackages[dir] = true
+		g.Nodes = append(g.Nodes, g.newNode(file, usageMap[file.GetRelPath()]))
 	}
+	g.collectRegistrations()
+	g.linkRegistrations()
 
-	// Second pass: build dependencies
-	for _, file := range files {
-		imports := file.GetImports()
-		node := FileNode{
-			RelPath:      file.GetRelPath(),
-			Package:      file.GetPackage(),
-			Dependencies: make([]Dependency, 0, len(imports)),
-			BaseName:     file.GetBaseName(),
-			IsTest:       file.GetIsTest(),
-		}
+	return g
+}
 
-		for _, imp := range imports {
-			dep := g.classifyImport(imp)
-			node.Dependencies = append(node.Dependencies, dep)
-		}
+// Update rebuilds the nodes of changed files and drops the nodes of removed files,
+// keeping the rest of the graph, so tools that watch a project need not rebuild it.
+// usageMap holds the used symbols of the changed files as in BuildDetailed (nil if not
+// tracked). Changed files without a node are appended. Module roots applied before are
+// applied to the new nodes, but call sites must be applied again for the changed files.
+// Graphs read by Import keep the registrations they were exported with.
+func (g *Graph) Update(changed []FileInfo, removed []string, usageMap map[string]map[string][]string) {
+	dropped := make(map[string]bool, len(removed))
+	for _, relPath := range removed {
+		dropped[relPath] = true
+	}
+	rebuilt := make(map[string]FileNode, len(changed))
+	for _, file := range changed {
+		node := g.newNode(file, usageMap[file.GetRelPath()])
+		g.resolveModuleRoots(&node)
+		rebuilt[node.RelPath] = node
+		delete(dropped, node.RelPath)
+	}
 
-		g.Nodes = append(g.Nodes, node)
+	nodes := make([]FileNode, 0, len(g.Nodes)+len(changed))
+	for _, node := range g.Nodes {
+		if dropped[node.RelPath] {
+			continue
+		}
+		if updated, ok := rebuilt[node.RelPath]; ok {
+			node = updated
+			delete(rebuilt, node.RelPath)
+		}
+		nodes = append(nodes, node)
+	}
+	for _, file := range changed {
+		if node, ok := rebuilt[file.GetRelPath()]; ok {
+			nodes = append(nodes, node)
+		}
 	}
+	g.Nodes = nodes
 
-	return g
+	g.localPackages = make(map[string]bool)
+	for _, node := range g.Nodes {
+		g.localPackages[path.Dir(node.RelPath)] = true
+	}
+	if g.fileInits == nil {
+		return // Imported graph
+	}
+	for relPath := range dropped {
+		delete(g.fileInits, relPath)
+	}
+	for _, file := range changed {
+		delete(g.fileInits, file.GetRelPath())
+		if !file.GetIsTest() {
+			g.fileInits[file.GetRelPath()] = file.GetInitRegistrations()
+		}
+	}
+	g.collectRegistrations()
+	g.linkRegistrations()
 }
 
-// BuildDetailed creates a dependency graph with detailed symbol usage from scanned files
-// usageMap is a map from file RelPath to (import path to used symbols)
-func BuildDetailed(files []FileInfo, module string, usageMap map[string]map[string][]string) *Graph {
-	g := &Graph{
-		Nodes:         make([]FileNode, 0, len(files)),
-		module:        module,
-		localPackages: make(map[string]bool),
+// newNode creates the node of a scanned file. fileUsage maps the file's import paths
+// to the symbols used from them (nil if not tracked).
+func (g *Graph) newNode(file FileInfo, fileUsage map[string][]string) FileNode {
+	imports := file.GetImports()
+	node := FileNode{
+		RelPath:       file.GetRelPath(),
+		Package:       file.GetPackage(),
+		Dependencies:  make([]Dependency, 0, len(imports)),
+		BaseName:      file.GetBaseName(),
+		IsTest:        file.GetIsTest(),
+		PackageLine:   file.GetPackageLine(),
+		PackageColumn: file.GetPackageColumn(),
+		DIFramework:   file.GetDIFramework(),
+		LineCount:     file.GetLineCount(),
+		Size:          file.GetSize(),
 	}
 
-	// First pass: collect all local packages
-	for _, file := range files {
-		// Get package path from file location
-		dir := filepath.Dir(file.GetRelPath())
-		dir = filepath.ToSlash(dir)
-		g.localPackages[dir] = true
+	for i, imp := range imports {
+		dep := g.classifyImportDetailed(imp, fileUsage[imp])
+		dep.Line, dep.Column = importPosition(file, i)
+		dep.Blank = containsImport(file.GetBlankImports(), imp)
+		node.Dependencies = append(node.Dependencies, dep)
 	}
+	return node
+}
 
-	// Second pass: build dependencies with usage information
+// collectPackages records the local packages of the scanned files and what the init
+// functions of their non-test files register
+func (g *Graph) collectPackages(files []FileInfo) {
 	for _, file := range files {
-		node := FileNode{
-			RelPath:      file.GetRelPath(),
-			Package:      file.GetPackage(),
-			Dependencies: make([]Dependency, 0),
-			BaseName:     file.GetBaseName(),
-			IsTest:       file.GetIsTest(),
+		// Get package path from file location
+		g.localPackages[path.Dir(file.GetRelPath())] = true
+		if !file.GetIsTest() {
+			g.fileInits[file.GetRelPath()] = file.GetInitRegistrations()
 		}
+	}
+}
 
-		// Get usage information for this file
-		fileUsageMap := usageMap[file.GetRelPath()]
-		if fileUsageMap == nil {
-			fileUsageMap = make(map[string][]string)
+// collectRegistrations groups what the init functions of the files register by package,
+// in the order of the nodes
+func (g *Graph) collectRegistrations() {
+	g.registrations = make(map[string][]string)
+	for _, node := range g.Nodes {
+		if inits, ok := g.fileInits[node.RelPath]; ok {
+			dir := path.Dir(node.RelPath)
+			g.registrations[dir] = append(g.registrations[dir], inits...)
 		}
+	}
+}
 
-		// Create dependencies with symbol information
-		imports := file.GetImports()
-		for _, imp := range imports {
-			dep := g.classifyImportDetailed(imp, fileUsageMap[imp])
-			node.Dependencies = append(node.Dependencies, dep)
+// linkRegistrations attaches to each blank import of a local package what the init
+// functions of that package register. Imported graphs keep the registrations they were
+// exported with.
+func (g *Graph) linkRegistrations() {
+	if g.registrations == nil {
+		return
+	}
+	for i := range g.Nodes {
+		for j := range g.Nodes[i].Dependencies {
+			dep := &g.Nodes[i].Dependencies[j]
+			if dep.Blank && dep.IsLocal {
+				dep.Registers = g.registrations[dep.LocalPath]
+			}
 		}
+	}
+}
 
-		g.Nodes = append(g.Nodes, node)
+// containsImport checks if a list of import paths contains the given one
+func containsImport(imports []string, importPath string) bool {
+	for _, imp := range imports {
+		if imp == importPath {
+			return true
+		}
 	}
+	return false
+}
 
-	return g
+// importPosition returns the line and column of the i-th import of a file (0 if unknown)
+func importPosition(file FileInfo, i int) (int, int) {
+	var line, column int
+	if lines := file.GetImportLines(); i < len(lines) {
+		line = lines[i]
+	}
+	if columns := file.GetImportColumns(); i < len(columns) {
+		column = columns[i]
+	}
+	return line, column
 }
 
 func (g *Graph) classifyImport(importPath string) Dependency {
@@ -159,7 +328,7 @@ func (g *Graph) classifyImport(importPath string) Dependency {
 
 func (g *Graph) classifyImportDetailed(importPath string, usedSymbols []string) Dependency {
 	// Check if it's a local import (starts with module path)
-	if strings.HasPrefix(importPath, g.module) {
+	if g.module != "" && strings.HasPrefix(importPath, g.module) {
 		localPath := strings.TrimPrefix(importPath, g.module+"/")
 		return Dependency{
 			ImportPath:  importPath,
@@ -176,6 +345,76 @@ func (g *Graph) classifyImportDetailed(importPath string, usedSymbols []string)
 	}
 }
 
+// ApplyModuleRoots reclassifies imports of nested modules as local dependencies.
+// roots maps a module path to its directory relative to the project root, so that
+// repositories with several go.mod files can be analyzed as a single architecture.
+func (g *Graph) ApplyModuleRoots(roots map[string]string) {
+	if len(roots) == 0 {
+		return
+	}
+
+	g.moduleRoots = roots
+	for i := range g.Nodes {
+		g.resolveModuleRoots(&g.Nodes[i])
+	}
+	g.linkRegistrations()
+}
+
+// resolveModuleRoots reclassifies the imports of a node that belong to a nested module
+func (g *Graph) resolveModuleRoots(node *FileNode) {
+	for j := range node.Dependencies {
+		dep := &node.Dependencies[j]
+
+		// Longest module path wins (nested modules may share a prefix)
+		bestModule := ""
+		for module := range g.moduleRoots {
+			if (dep.ImportPath == module || strings.HasPrefix(dep.ImportPath, module+"/")) && len(module) > len(bestModule) {
+				bestModule = module
+			}
+		}
+		if bestModule == "" {
+			continue
+		}
+
+		rootDir := g.moduleRoots[bestModule]
+		subPath := strings.TrimPrefix(strings.TrimPrefix(dep.ImportPath, bestModule), "/")
+
+		dep.IsLocal = true
+		dep.LocalPath = rootDir
+		if subPath != "" {
+			dep.LocalPath = rootDir + "/" + subPath
+		}
+	}
+}
+
+// ApplyCallSites records how often each file references the symbols of its imports, as
+// the weight of its dependencies. callSites maps a file path to the number of references
+// per import path, as collected in detailed mode. Files missing from callSites keep
+// their weights, so after Update only the changed files need to be passed.
+func (g *Graph) ApplyCallSites(callSites map[string]map[string]int) {
+	for i := range g.Nodes {
+		fileCallSites, ok := callSites[g.Nodes[i].RelPath]
+		if !ok {
+			continue
+		}
+		for j := range g.Nodes[i].Dependencies {
+			dep := &g.Nodes[i].Dependencies[j]
+			dep.CallSites = fileCallSites[dep.ImportPath]
+		}
+	}
+}
+
+// ApplyModTimes records when each file last changed. modTimes maps a file path to the
+// time of the last commit that changed it, which, unlike file system times, is the same
+// in every checkout of a commit. Files missing from modTimes keep their time.
+func (g *Graph) ApplyModTimes(modTimes map[string]time.Time) {
+	for i := range g.Nodes {
+		if modTime, ok := modTimes[g.Nodes[i].RelPath]; ok {
+			g.Nodes[i].ModTime = modTime
+		}
+	}
+}
+
 // IsStdLib checks if an import is from the standard library
 func IsStdLib(importPath string) bool {
 	// Standard library packages don't contain a dot in the first path segment
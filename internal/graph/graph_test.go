@@ -8,18 +8,34 @@ import (
 
 // testFileInfo implements graph.FileInfo interface for testing
 type testFileInfo struct {
-	relPath  string
-	pkg      string
-	imports  []string
-	baseName string
-	isTest   bool
+	relPath       string
+	pkg           string
+	imports       []string
+	lines         []int
+	columns       []int
+	baseName      string
+	isTest        bool
+	blankImports  []string
+	registrations []string
+	diFramework   string
+	lineCount     int
+	size          int64
 }
 
-func (t testFileInfo) GetRelPath() string   { return t.relPath }
-func (t testFileInfo) GetPackage() string   { return t.pkg }
-func (t testFileInfo) GetImports() []string { return t.imports }
-func (t testFileInfo) GetBaseName() string  { return t.baseName }
-func (t testFileInfo) GetIsTest() bool      { return t.isTest }
+func (t testFileInfo) GetRelPath() string             { return t.relPath }
+func (t testFileInfo) GetPackage() string             { return t.pkg }
+func (t testFileInfo) GetImports() []string           { return t.imports }
+func (t testFileInfo) GetImportLines() []int          { return t.lines }
+func (t testFileInfo) GetImportColumns() []int        { return t.columns }
+func (t testFileInfo) GetPackageLine() int            { return 1 }
+func (t testFileInfo) GetPackageColumn() int          { return 9 }
+func (t testFileInfo) GetBaseName() string            { return t.baseName }
+func (t testFileInfo) GetIsTest() bool                { return t.isTest }
+func (t testFileInfo) GetBlankImports() []string      { return t.blankImports }
+func (t testFileInfo) GetInitRegistrations() []string { return t.registrations }
+func (t testFileInfo) GetDIFramework() string         { return t.diFramework }
+func (t testFileInfo) GetLineCount() int              { return t.lineCount }
+func (t testFileInfo) GetSize() int64                 { return t.size }
 
 func TestBuild_LocalAndExternalImports(t *testing.T) {
 	files := []graph.FileInfo{
@@ -412,3 +428,160 @@ func findDependency(deps []graph.Dependency, importPath string) *graph.Dependenc
 	}
 	return nil
 }
+
+func TestApplyModuleRoots(t *testing.T) {
+	files := []graph.FileInfo{
+		testFileInfo{
+			relPath: "services/billing/internal/invoice/invoice.go",
+			pkg:     "invoice",
+			imports: []string{
+				"github.com/org/billing/internal/tax",
+				"github.com/org/billing",
+				"github.com/org/billing-sdk/client",
+				"github.com/org/shared/money",
+				"github.com/org/shared/money/v2",
+			},
+		},
+	}
+
+	g := graph.Build(files, "")
+	g.ApplyModuleRoots(map[string]string{
+		"github.com/org/billing":         "services/billing",
+		"github.com/org/shared/money":    "libs/money",
+		"github.com/org/shared/money/v2": "libs/money/v2",
+	})
+
+	expected := []struct {
+		isLocal   bool
+		localPath string
+	}{
+		{true, "services/billing/internal/tax"},
+		{true, "services/billing"},
+		{false, ""}, // Prefix match must end on a path boundary
+		{true, "libs/money"},
+		{true, "libs/money/v2"}, // Longest module wins
+	}
+
+	deps := g.Nodes[0].Dependencies
+	if len(deps) != len(expected) {
+		t.Fatalf("expected %d dependencies, got %d", len(expected), len(deps))
+	}
+	for i, want := range expected {
+		if deps[i].IsLocal != want.isLocal || deps[i].LocalPath != want.localPath {
+			t.Errorf("%s: expected local=%v path=%q, got local=%v path=%q",
+				deps[i].ImportPath, want.isLocal, want.localPath, deps[i].IsLocal, deps[i].LocalPath)
+		}
+	}
+}
+
+func TestBuild_RuntimeWiring(t *testing.T) {
+	files := []graph.FileInfo{
+		testFileInfo{
+			relPath: "cmd/server/main.go",
+			pkg:     "main",
+			imports: []string{
+				"embed",
+				"github.com/lib/pq",
+				"github.com/test/project/internal/codecs",
+				"github.com/test/project/internal/plain",
+				"github.com/test/project/internal/types",
+			},
+			blankImports: []string{
+				"embed",
+				"github.com/lib/pq",
+				"github.com/test/project/internal/codecs",
+				"github.com/test/project/internal/plain",
+			},
+		},
+		testFileInfo{
+			relPath:       "internal/codecs/gzip.go",
+			pkg:           "codecs",
+			registrations: []string{"image.RegisterFormat"},
+		},
+		testFileInfo{
+			relPath:       "internal/codecs/gzip_test.go",
+			pkg:           "codecs",
+			isTest:        true,
+			registrations: []string{"testRegistry[...]"},
+		},
+		testFileInfo{relPath: "internal/plain/plain.go", pkg: "plain"},
+		testFileInfo{relPath: "internal/types/types.go", pkg: "types", registrations: []string{"sql.Register"}},
+	}
+
+	g := graph.Build(files, "github.com/test/project")
+
+	expected := map[string]bool{
+		"embed":             false, // Blank, but embeds files rather than wiring code
+		"github.com/lib/pq": true,  // External init functions are not scanned
+		"github.com/test/project/internal/codecs": true,
+		"github.com/test/project/internal/plain":  false, // Registers nothing
+		"github.com/test/project/internal/types":  false, // Not a blank import
+	}
+	for _, dep := range g.Nodes[0].Dependencies {
+		if dep.IsRuntimeWiring() != expected[dep.ImportPath] {
+			t.Errorf("%s: expected runtime wiring %v", dep.ImportPath, expected[dep.ImportPath])
+		}
+	}
+
+	codecs := findDependency(g.Nodes[0].Dependencies, "github.com/test/project/internal/codecs")
+	if codecs == nil || len(codecs.GetRegistrations()) != 1 || codecs.GetRegistrations()[0] != "image.RegisterFormat" {
+		t.Errorf("expected the registrations of non-test files only, got %+v", codecs)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	module := "github.com/test/project"
+	files := []graph.FileInfo{
+		testFileInfo{relPath: "cmd/app/main.go", pkg: "main", imports: []string{module + "/pkg/api"}},
+		testFileInfo{relPath: "pkg/api/api.go", pkg: "api", imports: []string{module + "/internal/store", "example.com/tools/log"}},
+		testFileInfo{relPath: "internal/store/store.go", pkg: "store"},
+		testFileInfo{relPath: "internal/old/old.go", pkg: "old"},
+	}
+	g := graph.Build(files, module)
+	g.ApplyModuleRoots(map[string]string{"example.com/tools": "tools"})
+
+	changed := []graph.FileInfo{
+		// The entry point now wires the store through a blank import
+		testFileInfo{relPath: "cmd/app/main.go", pkg: "main", imports: []string{module + "/pkg/api", module + "/internal/store"}, blankImports: []string{module + "/internal/store"}},
+		testFileInfo{relPath: "internal/store/store.go", pkg: "store", registrations: []string{"sql.Register"}},
+		testFileInfo{relPath: "internal/cache/cache.go", pkg: "cache", imports: []string{"example.com/tools/log"}},
+	}
+	g.Update(changed, []string{"internal/old/old.go"}, map[string]map[string][]string{
+		"internal/cache/cache.go": {"example.com/tools/log": {"Printf"}},
+	})
+
+	var paths []string
+	for _, node := range g.Nodes {
+		paths = append(paths, node.RelPath)
+	}
+	want := []string{"cmd/app/main.go", "pkg/api/api.go", "internal/store/store.go", "internal/cache/cache.go"}
+	if len(paths) != len(want) {
+		t.Fatalf("expected nodes %v, got %v", want, paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("expected nodes %v, got %v", want, paths)
+		}
+	}
+
+	store := findDependency(g.Nodes[0].Dependencies, module+"/internal/store")
+	if store == nil || !store.IsRuntimeWiring() || len(store.GetRegistrations()) != 1 {
+		t.Errorf("expected the blank import to carry the new registrations, got %+v", store)
+	}
+
+	log := findDependency(g.Nodes[3].Dependencies, "example.com/tools/log")
+	if log == nil || !log.IsLocal || log.LocalPath != "tools/log" {
+		t.Errorf("expected module roots to apply to new nodes, got %+v", log)
+	}
+	if len(log.GetUsedSymbols()) != 1 || log.GetUsedSymbols()[0] != "Printf" {
+		t.Errorf("expected the used symbols of the new node, got %+v", log)
+	}
+
+	packages := make(map[string]bool)
+	for _, pkg := range g.GetLocalPackages() {
+		packages[pkg] = true
+	}
+	if packages["internal/old"] || !packages["internal/cache"] || len(packages) != 4 {
+		t.Errorf("expected the local packages to follow the update, got %v", g.GetLocalPackages())
+	}
+}
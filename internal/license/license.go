@@ -0,0 +1,189 @@
+// Package license detects the licenses of the modules a project requires from the
+// license files in the vendor directory or the module cache, in the spirit of
+// go-licenses, and groups them into categories policies can refer to.
+package license
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// License categories, from least to most restrictive
+const (
+	CategoryPermissive   = "permissive"    // MIT, BSD, Apache-2.0, ISC, ...
+	CategoryWeakCopyleft = "weak-copyleft" // MPL-2.0, LGPL, EPL: changes to the module itself must be shared
+	CategoryCopyleft     = "copyleft"      // GPL, AGPL: derived works must be shared under the same license
+	CategoryUnknown      = "unknown"       // No license file found, or its text was not recognized
+)
+
+// ModuleLicense is the detected license of a required module
+type ModuleLicense struct {
+	Module   string
+	Version  string
+	License  string // SPDX identifier (empty if not detected)
+	Category string
+}
+
+// Methods for adapter pattern (structural typing - no imports needed)
+func (m ModuleLicense) GetModule() string {
+	return m.Module
+}
+
+func (m ModuleLicense) GetLicense() string {
+	return m.License
+}
+
+func (m ModuleLicense) GetCategory() string {
+	return m.Category
+}
+
+// signature identifies a license by phrases of its text, matched case-insensitively
+// with whitespace collapsed. Signatures are tried in order, so the more specific ones
+// (LGPL, AGPL) come before those whose phrases they contain (GPL).
+type signature struct {
+	id       string
+	category string
+	phrases  []string
+}
+
+var signatures = []signature{
+	{"AGPL-3.0", CategoryCopyleft, []string{"gnu affero general public license"}},
+	{"LGPL-3.0", CategoryWeakCopyleft, []string{"gnu lesser general public license", "version 3"}},
+	{"LGPL-2.1", CategoryWeakCopyleft, []string{"gnu lesser general public license"}},
+	{"LGPL-2.0", CategoryWeakCopyleft, []string{"gnu library general public license"}},
+	{"GPL-3.0", CategoryCopyleft, []string{"gnu general public license", "version 3"}},
+	{"GPL-2.0", CategoryCopyleft, []string{"gnu general public license"}},
+	{"MPL-2.0", CategoryWeakCopyleft, []string{"mozilla public license"}},
+	{"EPL-2.0", CategoryWeakCopyleft, []string{"eclipse public license"}},
+	{"Apache-2.0", CategoryPermissive, []string{"apache license", "version 2.0"}},
+	{"MIT", CategoryPermissive, []string{"permission is hereby granted, free of charge"}},
+	{"BSD-3-Clause", CategoryPermissive, []string{"redistribution and use in source and binary forms", "endorse or promote products"}},
+	{"BSD-2-Clause", CategoryPermissive, []string{"redistribution and use in source and binary forms"}},
+	{"ISC", CategoryPermissive, []string{"permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{"Unlicense", CategoryPermissive, []string{"this is free and unencumbered software released into the public domain"}},
+	{"Zlib", CategoryPermissive, []string{"altered source versions must be plainly marked"}},
+}
+
+// Detect returns the licenses of the required modules (module path -> version), sorted
+// by module. A module is looked up in the vendor directory of the project files first,
+// then in the module cache; modules found in neither have an unknown license.
+func Detect(project fs.FS, requirements map[string]string) []ModuleLicense {
+	var modCache fs.FS
+	if dir := moduleCache(); dir != "" {
+		modCache = os.DirFS(dir)
+	}
+
+	// moduleDir is a directory of a module in a file system
+	type moduleDir struct {
+		fsys fs.FS
+		name string
+	}
+
+	licenses := make([]ModuleLicense, 0, len(requirements))
+	for module, version := range requirements {
+		dirs := []moduleDir{{project, path.Join("vendor", module)}}
+		if modCache != nil {
+			dirs = append(dirs, moduleDir{modCache, escapePath(module) + "@" + escapePath(version)})
+		}
+
+		entry := ModuleLicense{Module: module, Version: version, Category: CategoryUnknown}
+		for _, dir := range dirs {
+			if id, category, ok := detectDir(dir.fsys, dir.name); ok {
+				entry.License = id
+				entry.Category = category
+				break
+			}
+		}
+		licenses = append(licenses, entry)
+	}
+
+	sort.Slice(licenses, func(i, j int) bool {
+		return licenses[i].Module < licenses[j].Module
+	})
+	return licenses
+}
+
+// Identify returns the SPDX identifier and category of a license text, or false if the
+// text matches no known license
+func Identify(text string) (string, string, bool) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+	for _, sig := range signatures {
+		matches := true
+		for _, phrase := range sig.phrases {
+			if !strings.Contains(normalized, phrase) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return sig.id, sig.category, true
+		}
+	}
+	return "", "", false
+}
+
+// detectDir identifies the license of the module in dir of fsys from its first
+// recognized LICENSE, LICENCE or COPYING file. It reports false if dir has none.
+func detectDir(fsys fs.FS, dir string) (string, string, bool) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return "", "", false
+	}
+
+	found := false
+	for _, entry := range entries {
+		name := strings.ToUpper(entry.Name())
+		if entry.IsDir() || !(strings.HasPrefix(name, "LICENSE") || strings.HasPrefix(name, "LICENCE") || strings.HasPrefix(name, "COPYING")) {
+			continue
+		}
+		found = true
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		if id, category, ok := Identify(string(data)); ok {
+			return id, category, true
+		}
+	}
+	if found {
+		// A license file exists, but its text is not one of the known licenses
+		return "", CategoryUnknown, true
+	}
+	return "", "", false
+}
+
+// moduleCache returns the module cache directory: GOMODCACHE, or pkg/mod in the first
+// GOPATH entry, or ~/go/pkg/mod (empty if no home directory is known)
+func moduleCache() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	if gopath := filepath.SplitList(os.Getenv("GOPATH")); len(gopath) > 0 && gopath[0] != "" {
+		return filepath.Join(gopath[0], "pkg", "mod")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, "go", "pkg", "mod")
+}
+
+// escapePath escapes a module path or version the way the module cache stores it: each
+// upper-case letter becomes an exclamation mark followed by the lower-case letter
+func escapePath(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			sb.WriteRune('!')
+			sb.WriteRune(unicode.ToLower(r))
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,81 @@
+package license_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/license"
+)
+
+func TestIdentify(t *testing.T) {
+	tests := []struct {
+		text         string
+		wantID       string
+		wantCategory string
+	}{
+		{"MIT License\n\nPermission is hereby granted, free of charge, to any person", "MIT", license.CategoryPermissive},
+		{"Apache License\n                           Version 2.0, January 2004", "Apache-2.0", license.CategoryPermissive},
+		{"Redistribution and use in source and binary forms, with or without\nmodification... Neither the name of Google Inc. nor the names of its contributors may be used to endorse or promote products", "BSD-3-Clause", license.CategoryPermissive},
+		{"Redistribution and use in source and binary forms, with or without modification, are permitted", "BSD-2-Clause", license.CategoryPermissive},
+		{"Permission to use, copy, modify, and/or distribute this software for any purpose with or without fee", "ISC", license.CategoryPermissive},
+		{"Mozilla Public License Version 2.0", "MPL-2.0", license.CategoryWeakCopyleft},
+		{"GNU LESSER GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007", "LGPL-3.0", license.CategoryWeakCopyleft},
+		{"GNU GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007", "GPL-3.0", license.CategoryCopyleft},
+		{"GNU GENERAL PUBLIC LICENSE\n Version 2, June 1991", "GPL-2.0", license.CategoryCopyleft},
+		{"GNU AFFERO GENERAL PUBLIC LICENSE\n Version 3, 19 November 2007", "AGPL-3.0", license.CategoryCopyleft},
+	}
+	for _, tt := range tests {
+		id, category, ok := license.Identify(tt.text)
+		if !ok || id != tt.wantID || category != tt.wantCategory {
+			t.Errorf("Identify(%.40q) = %q, %q, %v; want %q, %q", tt.text, id, category, ok, tt.wantID, tt.wantCategory)
+		}
+	}
+
+	if _, _, ok := license.Identify("All rights reserved."); ok {
+		t.Error("expected a proprietary notice not to be identified")
+	}
+}
+
+func TestDetect(t *testing.T) {
+	projectDir := t.TempDir()
+	modCache := t.TempDir()
+	t.Setenv("GOMODCACHE", modCache)
+
+	files := map[string]string{
+		// Vendored modules take precedence over the module cache
+		filepath.Join(projectDir, "vendor/github.com/lib/pq/LICENSE.md"): "Permission is hereby granted, free of charge, to any person",
+		filepath.Join(modCache, "github.com/lib/pq@v1.10.9/LICENSE"):     "GNU GENERAL PUBLIC LICENSE Version 3",
+		// Upper-case letters are escaped in the module cache
+		filepath.Join(modCache, "github.com/!burnt!sushi/toml@v1.3.2/COPYING"): "The MIT License (MIT)\n\nPermission is hereby granted, free of charge",
+		filepath.Join(modCache, "example.com/gpl@v0.1.0/LICENSE"):              "GNU Affero General Public License",
+		filepath.Join(modCache, "example.com/custom@v1.0.0/LICENSE"):           "Internal use only.",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := license.Detect(os.DirFS(projectDir), map[string]string{
+		"github.com/lib/pq":          "v1.10.9",
+		"github.com/BurntSushi/toml": "v1.3.2",
+		"example.com/gpl":            "v0.1.0",
+		"example.com/custom":         "v1.0.0",
+		"example.com/missing":        "v2.0.0",
+	})
+	want := []license.ModuleLicense{
+		{Module: "example.com/custom", Version: "v1.0.0", Category: license.CategoryUnknown},
+		{Module: "example.com/gpl", Version: "v0.1.0", License: "AGPL-3.0", Category: license.CategoryCopyleft},
+		{Module: "example.com/missing", Version: "v2.0.0", Category: license.CategoryUnknown},
+		{Module: "github.com/BurntSushi/toml", Version: "v1.3.2", License: "MIT", Category: license.CategoryPermissive},
+		{Module: "github.com/lib/pq", Version: "v1.10.9", License: "MIT", Category: license.CategoryPermissive},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Detect() =\n%+v\nwant\n%+v", got, want)
+	}
+}
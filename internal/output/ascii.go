@@ -0,0 +1,61 @@
+package output
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// asciiSymbols maps the box-drawing characters, glyphs and emoji of the text output to
+// ASCII of at most their display width
+var asciiSymbols = []struct{ symbol, ascii string }{
+	{"─", "-"}, {"━", "="}, {"═", "="},
+	{"│", "|"}, {"║", "|"},
+	{"┌", "+"}, {"┐", "+"}, {"└", "+"}, {"┘", "+"},
+	{"╔", "+"}, {"╗", "+"}, {"╚", "+"}, {"╝", "+"},
+	{"├", "+"}, {"┤", "+"}, {"┬", "+"}, {"┴", "+"}, {"┼", "+"},
+	{"█", "#"}, {"░", "."},
+	{"✓", "v"}, {"✗", "X"}, {"⚠", "!"}, {"ℹ", "i"},
+	{"→", ">"}, {"›", ">"}, {"•", "*"}, {"×", "x"},
+	{"💡", "*"}, {"🔍", "*"}, {"📊", "*"},
+}
+
+// asciiReplacer applies asciiSymbols, padding each replacement with spaces to the width
+// of the symbol (two columns for emoji), so tables and indented text stay aligned
+var asciiReplacer = func() *strings.Replacer {
+	var pairs []string
+	for _, s := range asciiSymbols {
+		width := 1
+		if r, _ := utf8.DecodeRuneInString(s.symbol); r >= 0x1F000 {
+			width = 2
+		}
+		pairs = append(pairs, s.symbol, s.ascii+strings.Repeat(" ", width-len(s.ascii)))
+	}
+	return strings.NewReplacer(pairs...)
+}()
+
+// ASCII replaces the non-ASCII symbols of the text output with ASCII equivalents, for
+// consoles and log aggregators that mangle UTF-8. Other text, such as translated
+// messages or names from the code, is left as is.
+func ASCII(s string) string {
+	return asciiReplacer.Replace(s)
+}
+
+// asciiWriter applies ASCII to everything written to it
+type asciiWriter struct {
+	w io.Writer
+}
+
+// NewASCIIWriter returns a writer that applies ASCII before writing to w
+func NewASCIIWriter(w io.Writer) io.Writer {
+	return asciiWriter{w: w}
+}
+
+// Write implements io.Writer; it reports len(p) on success, since the replacement
+// changes the length of the written text
+func (aw asciiWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(aw.w, ASCII(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,53 @@
+package output_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestASCII(t *testing.T) {
+	tests := map[string]string{
+		"┌─ VIOLATIONS ─┐":              "+- VIOLATIONS -+",
+		"║ DETECTED ║":                  "| DETECTED |",
+		"**Status**: ✓ Exists":          "**Status**: v Exists",
+		"✗ 2 violation(s)":              "X 2 violation(s)",
+		"- `cmd` → `[pkg]`":             "- `cmd` > `[pkg]`",
+		"💡 TIP: Address violations":     "*  TIP: Address violations",
+		"│ 75.0% ██░ │":                 "| 75.0% ##. |",
+		"internal/domäne imports infra": "internal/domäne imports infra",
+	}
+	for input, want := range tests {
+		if got := output.ASCII(input); got != want {
+			t.Errorf("ASCII(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestASCII_KeepsWidths(t *testing.T) {
+	// Continuation lines of the tip are indented to the text after the emoji, and table
+	// cells are padded to the width of their glyphs
+	input := "💡 TIP: first line\n   second line\n| ✓ | → |\n| a | b |\n"
+	want := "*  TIP: first line\n   second line\n| v | > |\n| a | b |\n"
+	if got := output.ASCII(input); got != want {
+		t.Errorf("ASCII(%q) = %q, want %q", input, got, want)
+	}
+}
+
+func TestNewASCIIWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := output.NewASCIIWriter(&buf)
+
+	n, err := fmt.Fprintf(w, "%s ✓\n", "done")
+	if err != nil {
+		t.Fatalf("Fprintf failed: %v", err)
+	}
+	if n != len("done ✓\n") {
+		t.Errorf("expected the length of the original text, got %d", n)
+	}
+	if buf.String() != "done v\n" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
@@ -0,0 +1,88 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Binary is a main package and the local packages its program is built from
+type Binary struct {
+	Package   string   // Directory path of the main package
+	Reachable []string // Local packages transitively imported by the main package, sorted
+}
+
+// buildBinaries finds every main package in the graph, wherever it lives, and the
+// local packages reachable from it. Test files are ignored: they are not part of
+// the program.
+func buildBinaries(graph Graph) []Binary {
+	mains := make(map[string]bool)
+	imports := make(map[string]map[string]bool) // package -> local packages imported by its files
+
+	for _, node := range graph.GetNodes() {
+		if strings.HasSuffix(node.GetRelPath(), "_test.go") {
+			continue
+		}
+		pkgPath := extractPackagePath(node.GetRelPath())
+		if node.GetPackage() == "main" {
+			mains[pkgPath] = true
+		}
+		if imports[pkgPath] == nil {
+			imports[pkgPath] = make(map[string]bool)
+		}
+		for _, dep := range node.GetDependencies() {
+			if dep.IsLocalDep() && dep.GetLocalPath() != pkgPath {
+				imports[pkgPath][dep.GetLocalPath()] = true
+			}
+		}
+	}
+
+	binaries := make([]Binary, 0, len(mains))
+	for mainPkg := range mains {
+		visited := map[string]bool{mainPkg: true}
+		queue := []string{mainPkg}
+		var reachable []string
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for dep := range imports[current] {
+				if visited[dep] {
+					continue
+				}
+				visited[dep] = true
+				reachable = append(reachable, dep)
+				queue = append(queue, dep)
+			}
+		}
+		sort.Strings(reachable)
+		binaries = append(binaries, Binary{Package: mainPkg, Reachable: reachable})
+	}
+
+	sort.Slice(binaries, func(i, j int) bool {
+		return binaries[i].Package < binaries[j].Package
+	})
+
+	return binaries
+}
+
+// writeBinaries writes a section listing the main packages; nothing is written without any
+func writeBinaries(sb *strings.Builder, binaries []Binary, messages *Messages) {
+	if len(binaries) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("## %s\n\n", messages.Text("docs.binaries")))
+	sb.WriteString(messages.Text("docs.binaries_intro") + "\n\n")
+	for _, binary := range binaries {
+		if len(binary.Reachable) == 0 {
+			sb.WriteString(fmt.Sprintf("- **%s** → *(no local packages)*\n", binary.Package))
+			continue
+		}
+		noun := "packages"
+		if len(binary.Reachable) == 1 {
+			noun = "package"
+		}
+		sb.WriteString(fmt.Sprintf("- **%s** (%d %s) → %s\n", binary.Package, len(binary.Reachable), noun, strings.Join(binary.Reachable, ", ")))
+	}
+	sb.WriteString("\n")
+}
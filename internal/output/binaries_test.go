@@ -0,0 +1,57 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+// localDepForIndex creates a dependency on a package of the test project
+func localDepForIndex(localPath string) output.Dependency {
+	return &testDependencyForIndex{importPath: "github.com/test/project/" + localPath, isLocal: true, localPath: localPath}
+}
+
+func binariesGraph() *testGraphForIndex {
+	return &testGraphForIndex{
+		nodes: []output.FileNode{
+			&testFileNodeForIndex{relPath: "cmd/api/main.go", pkgName: "main", dependencies: []output.Dependency{localDepForIndex("internal/app")}},
+			// Test files are not part of the program
+			&testFileNodeForIndex{relPath: "cmd/api/main_test.go", pkgName: "main", dependencies: []output.Dependency{localDepForIndex("internal/testkit")}},
+			&testFileNodeForIndex{relPath: "internal/app/app.go", pkgName: "app", dependencies: []output.Dependency{localDepForIndex("internal/domain")}},
+			&testFileNodeForIndex{relPath: "internal/domain/order.go", pkgName: "domain"},
+			// A main package outside cmd/
+			&testFileNodeForIndex{relPath: "tools/gen/main.go", pkgName: "main"},
+		},
+	}
+}
+
+func TestGenerateIndexDocumentation_Binaries(t *testing.T) {
+	result := output.GenerateIndexDocumentation(output.FullDocumentation{Graph: binariesGraph()})
+
+	if !strings.Contains(result, "## Binaries") {
+		t.Fatalf("expected binaries section, got:\n%s", result)
+	}
+	binaries := result[strings.Index(result, "## Binaries"):]
+	binaries = binaries[:strings.Index(binaries, "## Package Directory")]
+
+	expected := "- **cmd/api** (2 packages) → internal/app, internal/domain\n" +
+		"- **tools/gen** → *(no local packages)*\n"
+	if !strings.Contains(binaries, expected) {
+		t.Errorf("expected binaries:\n%s\ngot:\n%s", expected, binaries)
+	}
+}
+
+func TestGenerateFullDocumentation_Binaries(t *testing.T) {
+	result := output.GenerateFullDocumentation(output.FullDocumentation{Graph: binariesGraph()})
+
+	if !strings.Contains(result, "- [Binaries](#binaries)") || !strings.Contains(result, "## Binaries") {
+		t.Errorf("expected binaries section with a table of contents entry, got:\n%s", result)
+	}
+
+	// Without main packages the section is omitted
+	result = output.GenerateFullDocumentation(output.FullDocumentation{Graph: &testGraphForIndex{}})
+	if strings.Contains(result, "Binaries") {
+		t.Errorf("expected no binaries section without main packages, got:\n%s", result)
+	}
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatViolationsCompact formats violations as one "file:line[:column] code rule message"
+// line each, without banners or guidance, for grep/awk pipelines. The code is the fix code
+// (ARCH005-3f2a1c), the same as in the FIXES section of the report, or the code of the
+// violation type for violations without a fix. The rule is the configuration key of the
+// rule, or the violation type in kebab case for hardcoded rules.
+func FormatViolationsCompact(violations []Violation) string {
+	var sb strings.Builder
+	for _, v := range violations {
+		sb.WriteString(compactLocation(v))
+		sb.WriteString(" ")
+		code := FixCode(v)
+		if code == "" {
+			code = v.GetCode()
+		}
+		if code != "" {
+			sb.WriteString(code)
+			sb.WriteString(" ")
+		}
+		sb.WriteString(compactRule(v))
+		sb.WriteString(" ")
+		sb.WriteString(strings.Join(strings.Fields(v.GetIssue()), " "))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// compactLocation returns the file position of a violation, "." for project-wide ones
+func compactLocation(v Violation) string {
+	location := v.GetFile()
+	if location == "" {
+		location = "."
+	}
+	if v.GetLine() > 0 {
+		location += fmt.Sprintf(":%d", v.GetLine())
+		if v.GetColumn() > 0 {
+			location += fmt.Sprintf(":%d", v.GetColumn())
+		}
+	}
+	return location
+}
+
+// compactRule returns the rule key of a violation, falling back to its type
+func compactRule(v Violation) string {
+	if key := v.GetRuleKey(); key != "" {
+		return key
+	}
+	return strings.Join(strings.Fields(strings.ToLower(v.GetType())), "-")
+}
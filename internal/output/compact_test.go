@@ -0,0 +1,43 @@
+package output_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestFormatViolationsCompact(t *testing.T) {
+	violations := []output.Violation{
+		&testViolation{
+			violationType: "Forbidden Import",
+			file:          "internal/order/order.go",
+			line:          3,
+			column:        8,
+			issue:         "internal/order imports internal/store",
+			ruleKey:       "rules.directories_import.internal/order",
+			code:          "ARCH005",
+		},
+		&testViolation{
+			violationType: "Forbidden pkg-to-pkg Dependency",
+			file:          "pkg/http/handler.go",
+			issue:         "pkg/http imports\npkg/database",
+		},
+		&testViolation{
+			violationType: "Missing Required Directory",
+			issue:         "internal/domain does not exist",
+			ruleKey:       "structure.required_directories",
+			code:          "ARCH006",
+		},
+	}
+
+	expected := "internal/order/order.go:3:8 ARCH005 rules.directories_import.internal/order internal/order imports internal/store\n" +
+		"pkg/http/handler.go forbidden-pkg-to-pkg-dependency pkg/http imports pkg/database\n" +
+		". ARCH006 structure.required_directories internal/domain does not exist\n"
+	if result := output.FormatViolationsCompact(violations); result != expected {
+		t.Errorf("unexpected compact output:\n%s\nexpected:\n%s", result, expected)
+	}
+
+	if result := output.FormatViolationsCompact(nil); result != "" {
+		t.Errorf("expected no output without violations, got %q", result)
+	}
+}
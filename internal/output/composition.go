@@ -0,0 +1,45 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompositionRoot is a main package, or a package holding DI wiring (wire, fx, dig), and
+// the components its functions construct and wire together
+type CompositionRoot struct {
+	Package    string      // Directory path of the package
+	Components []Component // In order of construction
+}
+
+// Component is a value a composition root constructs and the components injected into it
+type Component struct {
+	Name     string   // Constructor or type, qualified by the directory of a local package or the import path of any other, e.g. "internal/app.NewOrderService"
+	Injected []string // Components passed to the constructor or set as fields, in the same form
+	Provided bool     // Registered with a DI container, which injects its dependencies by type
+}
+
+// writeCompositionRoots writes a section listing what each main package constructs and
+// injects; nothing is written without composition roots
+func writeCompositionRoots(sb *strings.Builder, roots []CompositionRoot, messages *Messages) {
+	if len(roots) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("## %s\n\n", messages.Text("docs.roots")))
+	sb.WriteString(messages.Text("docs.roots_intro") + "\n\n")
+	for _, root := range roots {
+		sb.WriteString(fmt.Sprintf("- **%s**\n", root.Package))
+		for _, component := range root.Components {
+			line := "  - " + component.Name
+			if len(component.Injected) > 0 {
+				line += " ← " + strings.Join(component.Injected, ", ")
+			}
+			if component.Provided {
+				line += " *(provided)*"
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	sb.WriteString("\n")
+}
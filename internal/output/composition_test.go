@@ -0,0 +1,56 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func compositionRoots() []output.CompositionRoot {
+	return []output.CompositionRoot{
+		{
+			Package: "cmd/api",
+			Components: []output.Component{
+				{Name: "internal/infra/postgres.NewRepo", Injected: []string{"database/sql.Open"}},
+				{Name: "internal/infra/stripe.Client"},
+				{Name: "internal/app.NewOrderService", Injected: []string{"internal/infra/postgres.NewRepo", "internal/infra/stripe.Client"}},
+			},
+		},
+		{
+			Package:    "internal/app",
+			Components: []output.Component{{Name: "internal/app.NewMailer", Provided: true}},
+		},
+	}
+}
+
+func TestGenerateFullDocumentation_CompositionRoot(t *testing.T) {
+	result := output.GenerateFullDocumentation(output.FullDocumentation{Graph: &testGraphForIndex{}, Roots: compositionRoots()})
+
+	if !strings.Contains(result, "- [Composition Root](#composition-root)") || !strings.Contains(result, "## Composition Root") {
+		t.Fatalf("expected composition root section with a table of contents entry, got:\n%s", result)
+	}
+	expected := "- **cmd/api**\n" +
+		"  - internal/infra/postgres.NewRepo ← database/sql.Open\n" +
+		"  - internal/infra/stripe.Client\n" +
+		"  - internal/app.NewOrderService ← internal/infra/postgres.NewRepo, internal/infra/stripe.Client\n" +
+		"- **internal/app**\n" +
+		"  - internal/app.NewMailer *(provided)*\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected components:\n%s\ngot:\n%s", expected, result)
+	}
+
+	// Without composition roots the section is omitted
+	result = output.GenerateFullDocumentation(output.FullDocumentation{Graph: &testGraphForIndex{}})
+	if strings.Contains(result, "Composition Root") {
+		t.Errorf("expected no composition root section without main packages, got:\n%s", result)
+	}
+}
+
+func TestGenerateIndexDocumentation_CompositionRoot(t *testing.T) {
+	result := output.GenerateIndexDocumentation(output.FullDocumentation{Graph: &testGraphForIndex{}, Roots: compositionRoots()})
+
+	if !strings.Contains(result, "## Composition Root") || !strings.Contains(result, "  - internal/infra/stripe.Client\n") {
+		t.Errorf("expected composition root section, got:\n%s", result)
+	}
+}
@@ -0,0 +1,129 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ConfigMatrixFormats lists the supported config matrix formats
+var ConfigMatrixFormats = []string{"markdown", "json"}
+
+// ConfigMatrix holds the results of validating one scan of a project with several configs
+type ConfigMatrix struct {
+	Configs    []MatrixConfig    `json:"configs"`
+	Violations []MatrixViolation `json:"violations"` // Sorted by file, line and type
+	Notes      []string          `json:"notes,omitempty"`
+}
+
+// MatrixConfig summarizes the results of one config
+type MatrixConfig struct {
+	Name     string `json:"name"` // Column label, the file name unless several configs share it
+	Path     string `json:"path"`
+	Errors   int    `json:"errors"`
+	Warnings int    `json:"warnings"`
+	Infos    int    `json:"infos"`
+	Fails    bool   `json:"fails"` // Whether the build fails with this config
+}
+
+// MatrixViolation is a violation reported by at least one config
+type MatrixViolation struct {
+	Type       string   `json:"type"`
+	File       string   `json:"file"`
+	Line       int      `json:"line,omitempty"`
+	Issue      string   `json:"issue"`
+	Severities []string `json:"severities"` // Per config, in order: "error", "warning", "info", or "" if not reported
+}
+
+// FormatConfigMatrix renders a config matrix as markdown or json
+func FormatConfigMatrix(matrix ConfigMatrix, format string) (string, error) {
+	switch format {
+	case "markdown":
+		return generateConfigMatrixMarkdown(matrix), nil
+	case "json":
+		data, err := json.MarshalIndent(matrix, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported matrix format %q (supported: %s)", format, strings.Join(ConfigMatrixFormats, ", "))
+	}
+}
+
+func generateConfigMatrixMarkdown(matrix ConfigMatrix) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Config Matrix\n\n")
+
+	names := make([]string, len(matrix.Configs))
+	for i, cfg := range matrix.Configs {
+		names[i] = cfg.Name
+	}
+	writeRow := func(cells ...string) {
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	separator := func(columns int) {
+		sb.WriteString("|" + strings.Repeat("---|", columns) + "\n")
+	}
+
+	writeRow(append([]string{""}, names...)...)
+	separator(len(names) + 1)
+	summary := []struct {
+		label string
+		value func(MatrixConfig) string
+	}{
+		{"Errors", func(c MatrixConfig) string { return fmt.Sprint(c.Errors) }},
+		{"Warnings", func(c MatrixConfig) string { return fmt.Sprint(c.Warnings) }},
+		{"Info", func(c MatrixConfig) string { return fmt.Sprint(c.Infos) }},
+		{"Build", func(c MatrixConfig) string {
+			if c.Fails {
+				return "fails"
+			}
+			return "passes"
+		}},
+	}
+	for _, row := range summary {
+		cells := []string{"**" + row.label + "**"}
+		for _, cfg := range matrix.Configs {
+			cells = append(cells, row.value(cfg))
+		}
+		writeRow(cells...)
+	}
+
+	sb.WriteString(fmt.Sprintf("\n## Violations (%d)\n\n", len(matrix.Violations)))
+	if len(matrix.Violations) == 0 {
+		sb.WriteString("No config reports violations.\n")
+	} else {
+		writeRow(append([]string{"Violation", "Location"}, names...)...)
+		separator(len(names) + 2)
+		for _, viol := range matrix.Violations {
+			location := "-"
+			if viol.Line > 0 {
+				location = fmt.Sprintf("`%s:%d`", viol.File, viol.Line)
+			} else if viol.File != "" {
+				location = "`" + viol.File + "`"
+			}
+			cells := []string{
+				fmt.Sprintf("**%s**: %s", viol.Type, strings.ReplaceAll(viol.Issue, "|", "\\|")),
+				location,
+			}
+			for _, severity := range viol.Severities {
+				if severity == "" {
+					severity = "-"
+				}
+				cells = append(cells, severity)
+			}
+			writeRow(cells...)
+		}
+	}
+
+	if len(matrix.Notes) > 0 {
+		sb.WriteString("\n## Notes\n\n")
+		for _, note := range matrix.Notes {
+			sb.WriteString(fmt.Sprintf("- %s\n", note))
+		}
+	}
+
+	return sb.String()
+}
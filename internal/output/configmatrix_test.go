@@ -0,0 +1,88 @@
+package output_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestFormatConfigMatrix_Markdown(t *testing.T) {
+	matrix := output.ConfigMatrix{
+		Configs: []output.MatrixConfig{
+			{Name: "loose.yaml", Path: "loose.yaml", Warnings: 1},
+			{Name: "strict.yaml", Path: "strict.yaml", Errors: 2, Fails: true},
+		},
+		Violations: []output.MatrixViolation{
+			{Type: "Forbidden Import", File: "pkg/app/app.go", Line: 3, Issue: "pkg/app imports a|b", Severities: []string{"warning", "error"}},
+			{Type: "Unused Package", File: "internal/old", Issue: "internal/old is not imported", Severities: []string{"", "error"}},
+			{Type: "Missing Go Module", Issue: "go.mod not found", Severities: []string{"", "error"}},
+		},
+		Notes: []string{"strict.yaml scans the project differently"},
+	}
+
+	result, err := output.FormatConfigMatrix(matrix, "markdown")
+	if err != nil {
+		t.Fatalf("FormatConfigMatrix failed: %v", err)
+	}
+
+	expected := []string{
+		"# Config Matrix",
+		"|  | loose.yaml | strict.yaml |",
+		"| **Errors** | 0 | 2 |",
+		"| **Warnings** | 1 | 0 |",
+		"| **Build** | passes | fails |",
+		"## Violations (3)",
+		"| **Forbidden Import**: pkg/app imports a\\|b | `pkg/app/app.go:3` | warning | error |",
+		"| **Unused Package**: internal/old is not imported | `internal/old` | - | error |",
+		"| **Missing Go Module**: go.mod not found | - | - | error |",
+		"## Notes",
+		"- strict.yaml scans the project differently",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+
+	empty, err := output.FormatConfigMatrix(output.ConfigMatrix{Configs: matrix.Configs}, "markdown")
+	if err != nil {
+		t.Fatalf("FormatConfigMatrix failed: %v", err)
+	}
+	if !strings.Contains(empty, "No config reports violations.") || strings.Contains(empty, "## Notes") {
+		t.Errorf("expected an empty violations section and no notes, got:\n%s", empty)
+	}
+}
+
+func TestFormatConfigMatrix_JSON(t *testing.T) {
+	matrix := output.ConfigMatrix{
+		Configs:    []output.MatrixConfig{{Name: "strict.yaml", Path: "configs/strict.yaml", Errors: 1, Fails: true}},
+		Violations: []output.MatrixViolation{{Type: "Forbidden Import", File: "pkg/app/app.go", Line: 3, Issue: "x", Severities: []string{"error"}}},
+	}
+
+	result, err := output.FormatConfigMatrix(matrix, "json")
+	if err != nil {
+		t.Fatalf("FormatConfigMatrix failed: %v", err)
+	}
+
+	var decoded output.ConfigMatrix
+	if err := json.Unmarshal([]byte(result), &decoded); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, result)
+	}
+	if len(decoded.Configs) != 1 || !decoded.Configs[0].Fails || decoded.Configs[0].Path != "configs/strict.yaml" {
+		t.Errorf("unexpected configs: %+v", decoded.Configs)
+	}
+	if len(decoded.Violations) != 1 || decoded.Violations[0].Severities[0] != "error" {
+		t.Errorf("unexpected violations: %+v", decoded.Violations)
+	}
+	if strings.Contains(result, "notes") {
+		t.Errorf("expected notes to be omitted, got:\n%s", result)
+	}
+}
+
+func TestFormatConfigMatrix_UnsupportedFormat(t *testing.T) {
+	if _, err := output.FormatConfigMatrix(output.ConfigMatrix{}, "html"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Coupling is a weighted dependency between local packages of different layers
+type Coupling struct {
+	From      string // Directory of the importing package
+	FromLayer string // Layer of the importing package
+	To        string // Directory of the imported package
+	ToLayer   string // Layer of the imported package
+	Files     int    // Files of From importing To
+	Symbols   int    // Distinct symbols of To used by From
+	CallSites int    // References of From to the symbols of To
+}
+
+// GenerateCouplings renders the strongest cross-layer couplings, heaviest first, so
+// the dependencies worth breaking first stand out
+func GenerateCouplings(couplings []Coupling) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Strongest Couplings\n\n")
+	if len(couplings) == 0 {
+		sb.WriteString("No dependencies between packages of different layers.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("Cross-layer dependencies weighted by their use, heaviest first:\n\n")
+	sb.WriteString("| From | To | Call sites | Symbols | Files |\n")
+	sb.WriteString("|------|----|------------|---------|-------|\n")
+	for _, coupling := range couplings {
+		sb.WriteString(fmt.Sprintf("| `%s` (%s) | `%s` (%s) | %d | %d | %d |\n",
+			coupling.From, layerLabel(coupling.FromLayer), coupling.To, layerLabel(coupling.ToLayer),
+			coupling.CallSites, coupling.Symbols, coupling.Files))
+	}
+	return sb.String()
+}
+
+// layerLabel names a layer, or marks packages outside the configured layers
+func layerLabel(layer string) string {
+	if layer == "" {
+		return "no layer"
+	}
+	return layer
+}
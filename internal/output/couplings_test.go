@@ -0,0 +1,31 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestGenerateCouplings(t *testing.T) {
+	result := output.GenerateCouplings([]output.Coupling{
+		{From: "pkg/api", FromLayer: "pkg", To: "internal/store", ToLayer: "internal", Files: 2, Symbols: 3, CallSites: 9},
+		{From: "tools/gen", To: "internal/store", ToLayer: "internal", Files: 1, Symbols: 1, CallSites: 1},
+	})
+
+	expected := []string{
+		"# Strongest Couplings\n",
+		"| From | To | Call sites | Symbols | Files |\n",
+		"| `pkg/api` (pkg) | `internal/store` (internal) | 9 | 3 | 2 |\n",
+		"| `tools/gen` (no layer) | `internal/store` (internal) | 1 | 1 | 1 |\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, result)
+		}
+	}
+
+	if result := output.GenerateCouplings(nil); !strings.Contains(result, "No dependencies between packages of different layers.") {
+		t.Errorf("expected note for no couplings, got:\n%s", result)
+	}
+}
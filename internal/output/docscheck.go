@@ -0,0 +1,133 @@
+package output
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DocsCheck is the result of comparing documentation on disk with freshly generated documentation
+type DocsCheck struct {
+	Path    string            // Documentation file as given on the command line
+	Stale   bool              // Whether the documentation differs from a fresh generation
+	Missing bool              // Whether the documentation file does not exist
+	Commit  string            // Commit that last changed the documentation, empty if it was never committed
+	Changes []SignatureChange // Exported API changes since Commit, sorted by symbol
+}
+
+// SignatureChange is an exported symbol that was added, removed or changed between two versions
+type SignatureChange struct {
+	Symbol string // Package directory and symbol, e.g. "internal/app.Service.Create"
+	Before string // Signature in the old version, empty if the symbol was added
+	After  string // Signature in the new version, empty if the symbol was removed
+}
+
+// DiffExportedAPI compares the exported declarations of two versions of a project and
+// returns the symbols whose signature (and, for types, exported fields) changed, plus
+// those added or removed, sorted by symbol
+func DiffExportedAPI(before, after []FileWithAPI) []SignatureChange {
+	old := exportedSignatures(before)
+	current := exportedSignatures(after)
+
+	var changes []SignatureChange
+	for symbol, sig := range current {
+		if oldSig, ok := old[symbol]; !ok || oldSig != sig {
+			changes = append(changes, SignatureChange{Symbol: symbol, Before: oldSig, After: sig})
+		}
+	}
+	for symbol, sig := range old {
+		if _, ok := current[symbol]; !ok {
+			changes = append(changes, SignatureChange{Symbol: symbol, Before: sig})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Symbol < changes[j].Symbol
+	})
+	return changes
+}
+
+// exportedSignatures maps each exported symbol of non-test files, qualified by its
+// package directory, to its signature
+func exportedSignatures(files []FileWithAPI) map[string]string {
+	signatures := make(map[string]string)
+	for _, file := range files {
+		if strings.HasSuffix(file.GetRelPath(), "_test.go") {
+			continue
+		}
+		dir := path.Dir(file.GetRelPath())
+		for _, decl := range file.GetExportedDecls() {
+			sig := decl.GetSignature()
+			if props := decl.GetProperties(); len(props) > 0 {
+				sig += " { " + strings.Join(props, "; ") + " }"
+			}
+			signatures[dir+"."+declSymbol(decl)] = sig
+		}
+	}
+	return signatures
+}
+
+// FormatDocsCheck renders the result of a documentation check, listing the exported
+// API changes that made stale documentation out of date
+func FormatDocsCheck(check DocsCheck) string {
+	var sb strings.Builder
+
+	if !check.Stale {
+		sb.WriteString(fmt.Sprintf("✓ Documentation is up to date: %s\n", check.Path))
+		return sb.String()
+	}
+
+	if check.Missing {
+		sb.WriteString(fmt.Sprintf("✗ Documentation is missing: %s\n\n", check.Path))
+	} else {
+		sb.WriteString(fmt.Sprintf("✗ Documentation is out of date: %s\n\n", check.Path))
+	}
+
+	switch {
+	case check.Commit == "":
+		sb.WriteString("The documentation was never committed, so exported API changes cannot be listed.\n\n")
+	case len(check.Changes) == 0:
+		sb.WriteString(fmt.Sprintf("No exported signatures changed since the documentation was last committed (%s); other parts of the architecture did.\n\n", shortCommit(check.Commit)))
+	default:
+		sb.WriteString(fmt.Sprintf("Exported API changes since the documentation was last committed (%s):\n\n", shortCommit(check.Commit)))
+		writeSignatureChanges(&sb, "Changed", check.Changes, func(c SignatureChange) bool { return c.Before != "" && c.After != "" })
+		writeSignatureChanges(&sb, "Added", check.Changes, func(c SignatureChange) bool { return c.Before == "" })
+		writeSignatureChanges(&sb, "Removed", check.Changes, func(c SignatureChange) bool { return c.After == "" })
+	}
+
+	sb.WriteString("Run \"go-arch-lint docs\" to regenerate it.\n")
+	return sb.String()
+}
+
+// writeSignatureChanges writes the changes matching a filter under a heading
+func writeSignatureChanges(sb *strings.Builder, heading string, changes []SignatureChange, match func(SignatureChange) bool) {
+	var lines []string
+	for _, change := range changes {
+		if !match(change) {
+			continue
+		}
+		switch {
+		case change.Before == "":
+			lines = append(lines, fmt.Sprintf("  + %s: %s", change.Symbol, change.After))
+		case change.After == "":
+			lines = append(lines, fmt.Sprintf("  - %s: %s", change.Symbol, change.Before))
+		default:
+			lines = append(lines, fmt.Sprintf("  ~ %s\n      - %s\n      + %s", change.Symbol, change.Before, change.After))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	sb.WriteString(heading + ":\n")
+	sb.WriteString(strings.Join(lines, "\n") + "\n\n")
+}
+
+// shortCommit abbreviates a commit hash for display
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
@@ -0,0 +1,108 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestDiffExportedAPI(t *testing.T) {
+	before := []output.FileWithAPI{
+		&testFileWithAPI{
+			relPath: "internal/app/service.go",
+			pkg:     "app",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "Service", kind: "type", signature: "Service", properties: []string{"Name string"}},
+				&testExportedDecl{name: "Create", kind: "func", signature: "(*Service) Create(Order) error"},
+				&testExportedDecl{name: "Legacy", kind: "func", signature: "Legacy()"},
+				&testExportedDecl{name: "Version", kind: "const", signature: "Version"},
+			},
+		},
+	}
+	after := []output.FileWithAPI{
+		&testFileWithAPI{
+			relPath: "internal/app/service.go",
+			pkg:     "app",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "Service", kind: "type", signature: "Service", properties: []string{"Name string", "Timeout int"}},
+				&testExportedDecl{name: "Create", kind: "func", signature: "(*Service) Create(context.Context, Order) error"},
+				&testExportedDecl{name: "NewService", kind: "func", signature: "NewService() *Service"},
+				&testExportedDecl{name: "Version", kind: "const", signature: "Version"},
+			},
+		},
+		&testFileWithAPI{
+			relPath: "internal/app/service_test.go",
+			pkg:     "app",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "TestCreate", kind: "func", signature: "TestCreate(*testing.T)"},
+			},
+		},
+	}
+
+	changes := output.DiffExportedAPI(before, after)
+
+	expected := []output.SignatureChange{
+		{Symbol: "internal/app.Legacy", Before: "Legacy()"},
+		{Symbol: "internal/app.NewService", After: "NewService() *Service"},
+		{Symbol: "internal/app.Service", Before: "Service { Name string }", After: "Service { Name string; Timeout int }"},
+		{Symbol: "internal/app.Service.Create", Before: "(*Service) Create(Order) error", After: "(*Service) Create(context.Context, Order) error"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+	for i, want := range expected {
+		if changes[i] != want {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want)
+		}
+	}
+}
+
+func TestFormatDocsCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		check    output.DocsCheck
+		expected []string
+	}{
+		{
+			name:     "up to date",
+			check:    output.DocsCheck{Path: "docs/arch-index.md"},
+			expected: []string{"✓ Documentation is up to date: docs/arch-index.md\n"},
+		},
+		{
+			name:     "missing",
+			check:    output.DocsCheck{Path: "docs/arch-index.md", Stale: true, Missing: true},
+			expected: []string{"✗ Documentation is missing: docs/arch-index.md", "never committed", "Run \"go-arch-lint docs\""},
+		},
+		{
+			name:     "stale without signature changes",
+			check:    output.DocsCheck{Path: "docs/arch-index.md", Stale: true, Commit: "0123456789abcdef"},
+			expected: []string{"✗ Documentation is out of date", "No exported signatures changed since the documentation was last committed (0123456)"},
+		},
+		{
+			name: "stale with signature changes",
+			check: output.DocsCheck{Path: "docs/arch-index.md", Stale: true, Commit: "0123456789abcdef", Changes: []output.SignatureChange{
+				{Symbol: "internal/app.Legacy", Before: "Legacy()"},
+				{Symbol: "internal/app.NewService", After: "NewService() *Service"},
+				{Symbol: "internal/app.Service.Create", Before: "(*Service) Create(Order) error", After: "(*Service) Create(context.Context, Order) error"},
+			}},
+			expected: []string{
+				"Exported API changes since the documentation was last committed (0123456):\n\n",
+				"Changed:\n  ~ internal/app.Service.Create\n      - (*Service) Create(Order) error\n      + (*Service) Create(context.Context, Order) error\n\n",
+				"Added:\n  + internal/app.NewService: NewService() *Service\n\n",
+				"Removed:\n  - internal/app.Legacy: Legacy()\n\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := output.FormatDocsCheck(tt.check)
+			for _, want := range tt.expected {
+				if !strings.Contains(result, want) {
+					t.Errorf("expected %q in report, got:\n%s", want, result)
+				}
+			}
+		})
+	}
+}
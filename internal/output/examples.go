@@ -0,0 +1,63 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// examplesBySymbol groups the Example functions reported by the files of a package by
+// the symbol they document; package examples are keyed by ""
+func examplesBySymbol(files []FileWithAPI) map[string][]Example {
+	examples := make(map[string][]Example)
+	for _, file := range files {
+		for _, example := range file.GetExamples() {
+			examples[example.GetSymbol()] = append(examples[example.GetSymbol()], example)
+		}
+	}
+	return examples
+}
+
+// declSymbol returns the symbol Example functions name for a declaration: "Cache.Get"
+// for methods, the declaration name otherwise
+func declSymbol(decl ExportedDecl) string {
+	if typeName, ok := methodReceiver(decl.GetSignature()); ok {
+		return typeName + "." + decl.GetName()
+	}
+	return decl.GetName()
+}
+
+// methodReceiver returns the receiver type of a method signature without pointer and
+// type parameters ("Cache" for "(*Cache[K, V]) Get(K) V"); false for functions
+func methodReceiver(sig string) (string, bool) {
+	// Methods start with their receiver like (*Type) or (Type)
+	if !strings.HasPrefix(sig, "(") {
+		return "", false
+	}
+	endIdx := strings.Index(sig, ")")
+	if endIdx <= 0 {
+		return "", false
+	}
+	typeName := strings.TrimPrefix(sig[1:endIdx], "*")
+	if idx := strings.Index(typeName, "["); idx >= 0 {
+		typeName = typeName[:idx]
+	}
+	return typeName, true
+}
+
+// formatExamples lists Example functions with their location, e.g.
+// "`ExampleCache_Get` (pkg/cache/example_test.go:12)"
+func formatExamples(examples []Example) string {
+	parts := make([]string, len(examples))
+	for i, example := range examples {
+		parts[i] = fmt.Sprintf("`%s` (%s:%d)", example.GetName(), example.GetRelPath(), example.GetLine())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// withSummary appends the first sentence of a doc comment to a list entry
+func withSummary(entry, doc string) string {
+	if summary := packageSynopsis(doc); summary != "" {
+		return entry + " - " + summary
+	}
+	return entry
+}
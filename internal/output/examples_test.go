@@ -0,0 +1,92 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+type testExample struct {
+	name    string
+	symbol  string
+	relPath string
+	line    int
+}
+
+func (te *testExample) GetName() string    { return te.name }
+func (te *testExample) GetSymbol() string  { return te.symbol }
+func (te *testExample) GetRelPath() string { return te.relPath }
+func (te *testExample) GetLine() int       { return te.line }
+
+func exampleFiles() []output.FileWithAPI {
+	return []output.FileWithAPI{
+		&testFileWithAPI{
+			relPath: "pkg/cache/cache.go",
+			pkg:     "cache",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "Cache", kind: "type", signature: "Cache", typeKind: "struct", doc: "Cache stores values by key. It is safe for concurrent use."},
+				&testExportedDecl{name: "Get", kind: "func", signature: "(*Cache) Get(string) string", doc: "Get returns the value for a key."},
+				&testExportedDecl{name: "New", kind: "func", signature: "New() *Cache", doc: "New creates\nan empty cache."},
+				&testExportedDecl{name: "DefaultSize", kind: "const", signature: "DefaultSize", doc: "DefaultSize is the initial capacity."},
+			},
+			examples: []output.Example{
+				&testExample{name: "Example", relPath: "pkg/cache/example_test.go", line: 5},
+				&testExample{name: "ExampleCache", symbol: "Cache", relPath: "pkg/cache/example_test.go", line: 10},
+				&testExample{name: "ExampleCache_Get", symbol: "Cache.Get", relPath: "pkg/cache/example_test.go", line: 15},
+				&testExample{name: "ExampleNew", symbol: "New", relPath: "pkg/cache/example_test.go", line: 20},
+			},
+		},
+	}
+}
+
+func TestGenerateAPIMarkdown_DocSummariesAndExamples(t *testing.T) {
+	result := output.GenerateAPIMarkdown(exampleFiles())
+
+	expected := []string{
+		"**Examples**: `Example` (pkg/cache/example_test.go:5)\n",
+		"- **Cache** - Cache stores values by key.\n",
+		"    - (*Cache) Get(string) string - Get returns the value for a key.\n",
+		"      - Examples: `ExampleCache_Get` (pkg/cache/example_test.go:15)\n",
+		"  - Examples: `ExampleCache` (pkg/cache/example_test.go:10)\n",
+		"- New() *Cache - New creates an empty cache.\n",
+		"  - Examples: `ExampleNew` (pkg/cache/example_test.go:20)\n",
+		"- DefaultSize - DefaultSize is the initial capacity.\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(result, line) {
+			t.Errorf("expected %q in API markdown, got:\n%s", line, result)
+		}
+	}
+
+	// Only the first sentence of a doc comment is shown
+	if strings.Contains(result, "safe for concurrent use") {
+		t.Errorf("expected only the first sentence of doc comments, got:\n%s", result)
+	}
+}
+
+func TestGeneratePackageDocumentation_DocSummariesAndExamples(t *testing.T) {
+	doc := output.PackageDocumentation{
+		PackageName: "cache",
+		PackagePath: "pkg/cache",
+		Files:       exampleFiles(),
+		FileCount:   1,
+		ExportCount: 4,
+	}
+
+	result := output.GeneratePackageDocumentation(doc)
+
+	expected := []string{
+		"**Examples**: `Example` (pkg/cache/example_test.go:5)\n",
+		"#### Cache\n\nCache stores values by key.\n\n",
+		"- `(*Cache) Get(string) string` - Get returns the value for a key.\n  - Examples: `ExampleCache_Get` (pkg/cache/example_test.go:15)\n",
+		"**Examples**: `ExampleCache` (pkg/cache/example_test.go:10)\n",
+		"- `New() *Cache` - New creates an empty cache.\n  - Examples: `ExampleNew` (pkg/cache/example_test.go:20)\n",
+		"- `DefaultSize` - DefaultSize is the initial capacity.\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(result, line) {
+			t.Errorf("expected %q in package documentation, got:\n%s", line, result)
+		}
+	}
+}
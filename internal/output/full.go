@@ -27,29 +27,71 @@ type FullDocumentation struct {
 	Files          []FileWithAPI
 	Violations     []Violation
 	ViolationCount int
+	Score          *Score // Conformance score (nil: not shown)
 	FileCount      int
 	PackageCount   int
+	DomainLayers   []string                        // Directories whose types make up the glossary (empty: directories named domain)
+	Ports          []Port                          // Interfaces of port layers with their implementations (nil: section omitted)
+	Roots          []CompositionRoot               // Components main packages construct and inject (nil: section omitted)
+	Layers         []LayerSize                     // Size of the scanned code per layer (nil: not shown)
+	PackageDetails map[string]PackageDocumentation // Documentation the index embeds under each package, by directory (nil: the index names -format=package instead)
+	Version        string                          // Version of go-arch-lint shown in the status header of the full documentation (empty: not shown)
+	Lang           string                          // Language of the full documentation's headings and labels (empty: English)
 }
 
 // GenerateFullDocumentation creates a comprehensive markdown document
 func GenerateFullDocumentation(doc FullDocumentation) string {
-	var sb strings.Builder
+	return buildFullDocumentation(doc).String()
+}
+
+// buildFullDocumentation writes the comprehensive documentation section by section
+func buildFullDocumentation(doc FullDocumentation) *docBuilder {
+	sb := &docBuilder{}
+	messages := messagesFor(doc.Lang)
+
+	glossary := buildGlossary(doc.Files, doc.DomainLayers)
+	binaries := buildBinaries(doc.Graph)
+	wiring := buildRuntimeWiring(doc.Graph)
 
 	// Header
-	sb.WriteString("# Project Architecture\n\n")
-	sb.WriteString(fmt.Sprintf("**Generated by go-arch-lint on %s**\n\n", time.Now().Format("2006-01-02")))
+	sb.section("header")
+	sb.WriteString(fmt.Sprintf("# %s\n\n", messages.Text("docs.title")))
+	sb.WriteString(fmt.Sprintf("**%s**\n\n", messages.Text("docs.generated", time.Now().Format("2006-01-02"))))
+
+	// Status badges, so stale documentation is visible at a glance
+	sb.section("status")
+	writeDocumentationStatus(&sb.Builder, doc, time.Now())
 
 	// Table of Contents
-	sb.WriteString("## Table of Contents\n")
-	sb.WriteString("- [Project Structure](#project-structure)\n")
-	sb.WriteString("- [Architectural Rules](#architectural-rules)\n")
-	sb.WriteString("- [Dependency Graph](#dependency-graph)\n")
-	sb.WriteString("- [Public API](#public-api)\n")
-	sb.WriteString("- [Statistics](#statistics)\n")
+	sb.section("toc")
+	sb.WriteString(fmt.Sprintf("## %s\n", messages.Text("docs.toc")))
+	tocEntries := []string{"docs.structure", "docs.rules", "docs.dependencies"}
+	if len(binaries) > 0 {
+		tocEntries = append(tocEntries, "docs.binaries")
+	}
+	if len(wiring) > 0 {
+		tocEntries = append(tocEntries, "docs.wiring")
+	}
+	if len(doc.Roots) > 0 {
+		tocEntries = append(tocEntries, "docs.roots")
+	}
+	tocEntries = append(tocEntries, "docs.api")
+	if len(glossary) > 0 {
+		tocEntries = append(tocEntries, "docs.glossary")
+	}
+	if len(doc.Ports) > 0 {
+		tocEntries = append(tocEntries, "docs.ports")
+	}
+	tocEntries = append(tocEntries, "docs.statistics")
+	for _, id := range tocEntries {
+		title := messages.Text(id)
+		sb.WriteString(fmt.Sprintf("- [%s](#%s)\n", title, headingAnchor(title)))
+	}
 	sb.WriteString("\n---\n\n")
 
 	// Project Structure Section
-	sb.WriteString("## Project Structure\n\n")
+	sb.section("structure")
+	sb.WriteString(fmt.Sprintf("## %s\n\n", messages.Text("docs.structure")))
 	if len(doc.Structure.RequiredDirectories) > 0 {
 		sb.WriteString("Required directories as defined in `.goarchlint`:\n\n")
 
@@ -86,7 +128,8 @@ func GenerateFullDocumentation(doc FullDocumentation) string {
 	sb.WriteString("---\n\n")
 
 	// Architectural Rules Section
-	sb.WriteString("## Architectural Rules\n\n")
+	sb.section("rules")
+	sb.WriteString(fmt.Sprintf("## %s\n\n", messages.Text("docs.rules")))
 	sb.WriteString("From `.goarchlint`:\n\n")
 	sb.WriteString("```yaml\n")
 
@@ -123,7 +166,8 @@ func GenerateFullDocumentation(doc FullDocumentation) string {
 	sb.WriteString("---\n\n")
 
 	// Dependency Graph Section
-	sb.WriteString("## Dependency Graph\n\n")
+	sb.section("dependency_graph")
+	sb.WriteString(fmt.Sprintf("## %s\n\n", messages.Text("docs.dependencies")))
 	sb.WriteString("Detailed method-level dependencies between files:\n\n")
 
 	for _, node := range doc.Graph.GetNodes() {
@@ -146,7 +190,7 @@ func GenerateFullDocumentation(doc FullDocumentation) string {
 		if len(localDeps) > 0 {
 			sb.WriteString("**Local Dependencies**:\n")
 			for _, dep := range localDeps {
-				sb.WriteString(fmt.Sprintf("- %s\n", dep.GetLocalPath()))
+				sb.WriteString(fmt.Sprintf("- %s%s\n", dep.GetLocalPath(), wiringNote(dep)))
 				usedSymbols := dep.GetUsedSymbols()
 				if len(usedSymbols) > 0 {
 					for _, symbol := range usedSymbols {
@@ -163,7 +207,7 @@ func GenerateFullDocumentation(doc FullDocumentation) string {
 		if len(externalDeps) > 0 {
 			sb.WriteString("**External Dependencies**:\n")
 			for _, dep := range externalDeps {
-				sb.WriteString(fmt.Sprintf("- %s\n", dep.GetImportPath()))
+				sb.WriteString(fmt.Sprintf("- %s%s\n", dep.GetImportPath(), wiringNote(dep)))
 				usedSymbols := dep.GetUsedSymbols()
 				if len(usedSymbols) > 0 {
 					for _, symbol := range usedSymbols {
@@ -179,8 +223,21 @@ func GenerateFullDocumentation(doc FullDocumentation) string {
 		sb.WriteString("---\n\n")
 	}
 
+	// Binaries Section
+	sb.section("binaries")
+	writeBinaries(&sb.Builder, binaries, messages)
+
+	// Runtime Wiring Section
+	sb.section("wiring")
+	writeRuntimeWiring(&sb.Builder, wiring, messages)
+
+	// Composition Root Section
+	sb.section("composition")
+	writeCompositionRoots(&sb.Builder, doc.Roots, messages)
+
 	// Public API Section
-	sb.WriteString("## Public API\n\n")
+	sb.section("api")
+	sb.WriteString(fmt.Sprintf("## %s\n\n", messages.Text("docs.api")))
 	sb.WriteString("Exported interfaces and types available for consumption:\n\n")
 
 	if len(doc.Files) == 0 {
@@ -195,6 +252,12 @@ func GenerateFullDocumentation(doc FullDocumentation) string {
 
 		for pkgName, files := range packageFiles {
 			sb.WriteString(fmt.Sprintf("### %s\n\n", pkgName))
+			if description := packageDescription(files); description != "" {
+				sb.WriteString(description + "\n\n")
+			}
+			if stability := packageStability(files); stability != "" {
+				sb.WriteString(fmt.Sprintf("**Stability**: %s\n\n", stability))
+			}
 
 			for _, file := range files {
 				decls := file.GetExportedDecls()
@@ -245,14 +308,32 @@ func GenerateFullDocumentation(doc FullDocumentation) string {
 				}
 			}
 
+			var packageDecls []ExportedDecl
+			for _, file := range files {
+				packageDecls = append(packageDecls, file.GetExportedDecls()...)
+			}
+			writeTypeHierarchy(&sb.Builder, packageDecls, "####")
+
 			sb.WriteString("---\n\n")
 		}
 	}
 
+	// Glossary Section
+	sb.section("glossary")
+	writeGlossary(&sb.Builder, glossary, messages)
+
+	// Ports and Adapters Section
+	sb.section("ports")
+	writePorts(&sb.Builder, doc.Ports, messages)
+
 	// Statistics Section
-	sb.WriteString("## Statistics\n\n")
-	sb.WriteString(fmt.Sprintf("- **Total Files**: %d\n", doc.FileCount))
-	sb.WriteString(fmt.Sprintf("- **Total Packages**: %d\n", doc.PackageCount))
+	sb.section("statistics")
+	sb.WriteString(fmt.Sprintf("## %s\n\n", messages.Text("docs.statistics")))
+	sb.WriteString(fmt.Sprintf("- **%s**: %d\n", messages.Text("docs.total_files"), doc.FileCount))
+	sb.WriteString(fmt.Sprintf("- **%s**: %d\n", messages.Text("docs.total_packages"), doc.PackageCount))
+	if len(doc.Layers) > 0 {
+		sb.WriteString(fmt.Sprintf("- **%s**: %d\n", messages.Text("docs.total_lines"), totalLines(doc.Layers)))
+	}
 	if len(doc.Structure.RequiredDirectories) > 0 {
 		existingCount := 0
 		for _, exists := range doc.Structure.ExistingDirs {
@@ -260,9 +341,12 @@ func GenerateFullDocumentation(doc FullDocumentation) string {
 				existingCount++
 			}
 		}
-		sb.WriteString(fmt.Sprintf("- **Required Directories**: %d/%d present\n", existingCount, len(doc.Structure.RequiredDirectories)))
+		sb.WriteString(fmt.Sprintf("- **%s**: %s\n", messages.Text("docs.required_dirs"), messages.Text("docs.present", existingCount, len(doc.Structure.RequiredDirectories))))
+	}
+	sb.WriteString(fmt.Sprintf("- **%s**: %d\n", messages.Text("docs.violations"), doc.ViolationCount))
+	if doc.Score != nil {
+		sb.WriteString(fmt.Sprintf("- **%s**: %d/100\n", messages.Text("docs.score"), doc.Score.Score))
 	}
-	sb.WriteString(fmt.Sprintf("- **Violations**: %d\n", doc.ViolationCount))
 
 	// Count external dependencies
 	externalDepsSet := make(map[string]bool)
@@ -273,10 +357,54 @@ func GenerateFullDocumentation(doc FullDocumentation) string {
 			}
 		}
 	}
-	sb.WriteString(fmt.Sprintf("- **External Dependencies**: %d\n", len(externalDepsSet)))
+	sb.WriteString(fmt.Sprintf("- **%s**: %d\n", messages.Text("docs.external_deps"), len(externalDepsSet)))
+	writeLayerSizes(&sb.Builder, doc.Layers, messages)
 
 	sb.WriteString("\n---\n\n")
-	sb.WriteString("*This documentation is auto-generated. To regenerate: `go-arch-lint -format=full .` or `go-arch-lint -format=docs .`*\n")
+	sb.WriteString(fmt.Sprintf("*%s*\n", messages.Text("docs.footer")))
+
+	return sb
+}
 
-	return sb.String()
+// writeDocumentationStatus writes the status header of the full documentation: badges for
+// the violations, test coverage, conformance score, generation time and tool version, and
+// the same values in an HTML comment dashboards can scrape, e.g.
+// <!-- go-arch-lint-status violations=0 coverage=87 score=96 version=1.2.0 generated=2025-01-02T15:04:05Z -->
+func writeDocumentationStatus(sb *strings.Builder, doc FullDocumentation, generated time.Time) {
+	color := "brightgreen"
+	if doc.ViolationCount > 0 {
+		color = "red"
+	}
+	badges := []string{badge("violations", doc.ViolationCount, color)}
+	fields := []string{fmt.Sprintf("violations=%d", doc.ViolationCount)}
+	if doc.Score != nil {
+		if doc.Score.Coverage != nil {
+			badges = append(badges, badge("coverage", fmt.Sprintf("%d%%", *doc.Score.Coverage), percentColor(*doc.Score.Coverage)))
+			fields = append(fields, fmt.Sprintf("coverage=%d", *doc.Score.Coverage))
+		}
+		badges = append(badges, badge("conformance", fmt.Sprintf("%d/100", doc.Score.Score), percentColor(doc.Score.Score)))
+		fields = append(fields, fmt.Sprintf("score=%d", doc.Score.Score))
+	}
+	badges = append(badges, badge("generated", generated.UTC().Format("2006-01-02 15:04 UTC"), "lightgrey"))
+	if doc.Version != "" {
+		badges = append(badges, badge("go-arch-lint", doc.Version, "blue"))
+		fields = append(fields, "version="+doc.Version)
+	}
+	fields = append(fields, "generated="+generated.UTC().Format(time.RFC3339))
+
+	sb.WriteString(strings.Join(badges, " ") + "\n\n")
+	sb.WriteString("<!-- go-arch-lint-status " + strings.Join(fields, " ") + " -->\n\n")
+}
+
+// percentColor returns the badge color of a percentage: green from 80, yellow from 60
+// and red below
+func percentColor(percent int) string {
+	switch {
+	case percent >= 80:
+		return "brightgreen"
+	case percent >= 60:
+		return "yellow"
+	default:
+		return "red"
+	}
 }
@@ -0,0 +1,100 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GlossaryEntry is a domain term: an exported struct or interface of a domain package
+type GlossaryEntry struct {
+	Term       string // Type name
+	Kind       string // "struct" or "interface"
+	Package    string // Directory path of the defining package
+	Definition string // First paragraph of the type's doc comment
+}
+
+// buildGlossary collects the exported structs and interfaces of domain packages.
+// Domain packages are those within one of domainLayers, or, when none are
+// configured, those with a directory named "domain" in their path.
+func buildGlossary(files []FileWithAPI, domainLayers []string) []GlossaryEntry {
+	var entries []GlossaryEntry
+
+	for _, file := range files {
+		if strings.HasSuffix(file.GetRelPath(), "_test.go") {
+			continue
+		}
+		pkgPath := extractPackagePath(file.GetRelPath())
+		if !isDomainPackage(pkgPath, domainLayers) {
+			continue
+		}
+
+		for _, decl := range file.GetExportedDecls() {
+			if decl.GetKind() != "type" || (decl.GetTypeKind() != "struct" && decl.GetTypeKind() != "interface") {
+				continue
+			}
+			entries = append(entries, GlossaryEntry{
+				Term:       decl.GetName(),
+				Kind:       decl.GetTypeKind(),
+				Package:    pkgPath,
+				Definition: firstParagraph(decl.GetDoc()),
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		ti, tj := strings.ToLower(entries[i].Term), strings.ToLower(entries[j].Term)
+		if ti != tj {
+			return ti < tj
+		}
+		return entries[i].Package < entries[j].Package
+	})
+
+	return entries
+}
+
+// writeGlossary writes a glossary section; nothing is written without entries
+func writeGlossary(sb *strings.Builder, entries []GlossaryEntry, messages *Messages) {
+	if len(entries) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("## %s\n\n", messages.Text("docs.glossary")))
+	sb.WriteString(messages.Text("docs.glossary_intro") + "\n\n")
+	for _, entry := range entries {
+		definition := entry.Definition
+		if definition == "" {
+			definition = "*(undocumented)*"
+		}
+		sb.WriteString(fmt.Sprintf("- **%s** (%s, `%s`): %s\n", entry.Term, entry.Kind, entry.Package, definition))
+	}
+	sb.WriteString("\n")
+}
+
+// isDomainPackage reports whether pkgPath belongs to a domain layer
+func isDomainPackage(pkgPath string, domainLayers []string) bool {
+	if len(domainLayers) == 0 {
+		for _, segment := range strings.Split(pkgPath, "/") {
+			if segment == "domain" {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, layer := range domainLayers {
+		layer = strings.TrimSuffix(layer, "/")
+		if pkgPath == layer || strings.HasPrefix(pkgPath, layer+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// firstParagraph returns the first paragraph of a doc comment on a single line
+func firstParagraph(doc string) string {
+	if idx := strings.Index(doc, "\n\n"); idx >= 0 {
+		doc = doc[:idx]
+	}
+	return strings.Join(strings.Fields(doc), " ")
+}
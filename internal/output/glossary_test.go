@@ -0,0 +1,107 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func glossaryFiles() []output.FileWithAPI {
+	return []output.FileWithAPI{
+		&testFileWithAPIForIndex{
+			relPath: "internal/domain/order/order.go",
+			pkgName: "order",
+			exportedDecls: []output.ExportedDecl{
+				&testExportedDeclForIndex{name: "Order", kind: "type", typeKind: "struct", doc: "Order is a customer's request\nto buy products.\n\nOrders are immutable."},
+				&testExportedDeclForIndex{name: "Repository", kind: "type", typeKind: "interface", doc: "Repository stores orders."},
+				&testExportedDeclForIndex{name: "Status", kind: "type", typeKind: "other", doc: "Status of an order."},
+				&testExportedDeclForIndex{name: "Place", kind: "func", doc: "Place creates an order."},
+			},
+		},
+		&testFileWithAPIForIndex{
+			relPath: "internal/domain/order/order_test.go",
+			pkgName: "order_test",
+			exportedDecls: []output.ExportedDecl{
+				&testExportedDeclForIndex{name: "Fixture", kind: "type", typeKind: "struct"},
+			},
+		},
+		&testFileWithAPIForIndex{
+			relPath: "internal/domain/customer.go",
+			pkgName: "domain",
+			exportedDecls: []output.ExportedDecl{
+				&testExportedDeclForIndex{name: "Customer", kind: "type", typeKind: "struct"},
+			},
+		},
+		&testFileWithAPIForIndex{
+			relPath: "internal/infra/db.go",
+			pkgName: "infra",
+			exportedDecls: []output.ExportedDecl{
+				&testExportedDeclForIndex{name: "DB", kind: "type", typeKind: "struct", doc: "DB is a database handle."},
+			},
+		},
+	}
+}
+
+func TestGenerateIndexDocumentation_Glossary(t *testing.T) {
+	doc := output.FullDocumentation{
+		Graph: &testGraphForIndex{},
+		Files: glossaryFiles(),
+	}
+
+	result := output.GenerateIndexDocumentation(doc)
+
+	if !strings.Contains(result, "## Glossary") {
+		t.Fatalf("expected glossary section, got:\n%s", result)
+	}
+	glossary := result[strings.Index(result, "## Glossary"):]
+	glossary = glossary[:strings.Index(glossary, "## Agent Guidance")]
+
+	expected := "- **Customer** (struct, `internal/domain`): *(undocumented)*\n" +
+		"- **Order** (struct, `internal/domain/order`): Order is a customer's request to buy products.\n" +
+		"- **Repository** (interface, `internal/domain/order`): Repository stores orders.\n"
+	if !strings.Contains(glossary, expected) {
+		t.Errorf("expected glossary entries:\n%s\ngot:\n%s", expected, glossary)
+	}
+	for _, excluded := range []string{"Status", "Place", "Fixture", "DB"} {
+		if strings.Contains(glossary, "**"+excluded+"**") {
+			t.Errorf("expected %s to be excluded from glossary, got:\n%s", excluded, glossary)
+		}
+	}
+}
+
+func TestGenerateIndexDocumentation_GlossaryDomainLayers(t *testing.T) {
+	doc := output.FullDocumentation{
+		Graph:        &testGraphForIndex{},
+		Files:        glossaryFiles(),
+		DomainLayers: []string{"internal/infra/"},
+	}
+
+	result := output.GenerateIndexDocumentation(doc)
+
+	if !strings.Contains(result, "- **DB** (struct, `internal/infra`): DB is a database handle.\n") {
+		t.Errorf("expected configured domain layer in glossary, got:\n%s", result)
+	}
+	if strings.Contains(result, "**Order**") {
+		t.Errorf("expected directories named domain to be ignored when layers are configured, got:\n%s", result)
+	}
+}
+
+func TestGenerateFullDocumentation_Glossary(t *testing.T) {
+	doc := output.FullDocumentation{
+		Graph: &testGraphForIndex{},
+		Files: glossaryFiles(),
+	}
+
+	result := output.GenerateFullDocumentation(doc)
+	if !strings.Contains(result, "- [Glossary](#glossary)") || !strings.Contains(result, "- **Repository** (interface, `internal/domain/order`)") {
+		t.Errorf("expected glossary in full documentation, got:\n%s", result)
+	}
+
+	// No domain types, no glossary
+	doc.Files = glossaryFiles()[3:]
+	result = output.GenerateFullDocumentation(doc)
+	if strings.Contains(result, "Glossary") {
+		t.Errorf("expected no glossary without domain types, got:\n%s", result)
+	}
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// typeHierarchy describes how the exported types of a package are composed by
+// embedding, one line per struct or interface that embeds other types, sorted by name:
+//   - interfaces: "`ReadWriter` = `Reader` + `Writer`"
+//   - structs: "`Admin` embeds `User` (embeds `Base`)", following embedding chains
+//     through the types of the same package
+func typeHierarchy(decls []ExportedDecl) []string {
+	typesByName := make(map[string]ExportedDecl)
+	var names []string
+	for _, decl := range decls {
+		if decl.GetKind() != "type" {
+			continue
+		}
+		typesByName[decl.GetName()] = decl
+		if len(decl.GetEmbeds()) > 0 {
+			names = append(names, decl.GetName())
+		}
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		seen := map[string]bool{name: true}
+		lines = append(lines, fmt.Sprintf("`%s` %s", name, describeEmbeds(typesByName[name], typesByName, seen)))
+	}
+	return lines
+}
+
+// describeEmbeds renders the embedded types of a declaration, expanding embedded
+// types of the same package that embed types themselves
+func describeEmbeds(decl ExportedDecl, typesByName map[string]ExportedDecl, seen map[string]bool) string {
+	parts := make([]string, 0, len(decl.GetEmbeds()))
+	for _, embed := range decl.GetEmbeds() {
+		part := "`" + embed + "`"
+		// Strip pointer and type arguments to look the embedded type up
+		name := strings.TrimPrefix(embed, "*")
+		if idx := strings.Index(name, "["); idx >= 0 {
+			name = name[:idx]
+		}
+		if inner, ok := typesByName[name]; ok && len(inner.GetEmbeds()) > 0 && !seen[name] {
+			seen[name] = true
+			part += " (" + describeEmbeds(inner, typesByName, seen) + ")"
+		}
+		parts = append(parts, part)
+	}
+
+	if decl.GetTypeKind() == "interface" {
+		return "= " + strings.Join(parts, " + ")
+	}
+	return "embeds " + strings.Join(parts, ", ")
+}
+
+// writeTypeHierarchy writes the type hierarchy of a package under a heading of the
+// given level (e.g. "###"); nothing is written if no type embeds another
+func writeTypeHierarchy(sb *strings.Builder, decls []ExportedDecl, level string) {
+	lines := typeHierarchy(decls)
+	if len(lines) == 0 {
+		return
+	}
+
+	sb.WriteString(level + " Type Hierarchy\n\n")
+	for _, line := range lines {
+		sb.WriteString("- " + line + "\n")
+	}
+	sb.WriteString("\n")
+}
@@ -0,0 +1,67 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestGenerateAPIMarkdown_TypeHierarchy(t *testing.T) {
+	files := []output.FileWithAPI{
+		&testFileWithAPI{
+			relPath: "pkg/store/store.go",
+			pkg:     "store",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "Reader", kind: "type", signature: "Reader", typeKind: "interface"},
+				&testExportedDecl{name: "Writer", kind: "type", signature: "Writer", typeKind: "interface"},
+				&testExportedDecl{name: "ReadWriter", kind: "type", signature: "ReadWriter", typeKind: "interface", embeds: []string{"Reader", "Writer"}},
+				&testExportedDecl{name: "ReadWriteCloser", kind: "type", signature: "ReadWriteCloser", typeKind: "interface", embeds: []string{"ReadWriter", "io.Closer"}},
+				&testExportedDecl{name: "Base", kind: "type", signature: "Base", typeKind: "struct"},
+				&testExportedDecl{name: "User", kind: "type", signature: "User", typeKind: "struct", embeds: []string{"*Base"}},
+				&testExportedDecl{name: "Admin", kind: "type", signature: "Admin", typeKind: "struct", embeds: []string{"User"}},
+			},
+		},
+	}
+
+	result := output.GenerateAPIMarkdown(files)
+
+	if !strings.Contains(result, "### Type Hierarchy") {
+		t.Fatalf("expected Type Hierarchy section, got:\n%s", result)
+	}
+
+	expected := []string{
+		"- `ReadWriter` = `Reader` + `Writer`\n",
+		"- `ReadWriteCloser` = `ReadWriter` (= `Reader` + `Writer`) + `io.Closer`\n",
+		"- `User` embeds `*Base`\n",
+		"- `Admin` embeds `User` (embeds `*Base`)\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(result, line) {
+			t.Errorf("expected hierarchy line %q, got:\n%s", line, result)
+		}
+	}
+
+	// Types without embedded types are not listed
+	if strings.Contains(result, "- `Base`") {
+		t.Errorf("expected Base to be omitted from the hierarchy, got:\n%s", result)
+	}
+}
+
+func TestGenerateAPIMarkdown_NoTypeHierarchyWithoutEmbedding(t *testing.T) {
+	files := []output.FileWithAPI{
+		&testFileWithAPI{
+			relPath: "pkg/store/store.go",
+			pkg:     "store",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "Store", kind: "type", signature: "Store", typeKind: "struct"},
+			},
+		},
+	}
+
+	result := output.GenerateAPIMarkdown(files)
+
+	if strings.Contains(result, "### Type Hierarchy") {
+		t.Errorf("expected no Type Hierarchy section, got:\n%s", result)
+	}
+}
@@ -0,0 +1,151 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryFormats lists the supported history output formats
+var HistoryFormats = []string{"markdown", "json", "csv"}
+
+// HistoryEntry holds the analysis results of one revision
+type HistoryEntry struct {
+	Revision                string         `json:"revision"`
+	Commit                  string         `json:"commit"`
+	Date                    time.Time      `json:"date"`
+	Error                   string         `json:"error,omitempty"` // Set if the revision could not be analyzed
+	FileCount               int            `json:"files"`
+	PackageCount            int            `json:"packages"`
+	LocalDependencyCount    int            `json:"local_dependencies"`    // Package-level edges within the module
+	ExternalDependencyCount int            `json:"external_dependencies"` // Distinct non-stdlib external imports
+	ViolationCount          int            `json:"violations"`
+	ErrorCount              int            `json:"errors"` // Violations that fail the build
+	ViolationsByType        map[string]int `json:"violations_by_type,omitempty"`
+	Score                   int            `json:"score"` // Conformance score without coverage, 0 to 100
+}
+
+// FormatHistory renders a time series of revision analyses as markdown, json or csv
+func FormatHistory(entries []HistoryEntry, format string) (string, error) {
+	switch format {
+	case "markdown":
+		return generateHistoryMarkdown(entries), nil
+	case "json":
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case "csv":
+		return generateHistoryCSV(entries)
+	default:
+		return "", fmt.Errorf("unsupported history format %q (supported: %s)", format, strings.Join(HistoryFormats, ", "))
+	}
+}
+
+func generateHistoryMarkdown(entries []HistoryEntry) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Architecture History\n\n")
+	if len(entries) == 0 {
+		sb.WriteString("No revisions found.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| Revision | Date | Files | Packages | Local Deps | External Deps | Violations | Change | Score |\n")
+	sb.WriteString("|----------|------|-------|----------|------------|---------------|------------|--------|-------|\n")
+
+	previous := -1
+	for _, entry := range entries {
+		date := entry.Date.Format("2006-01-02")
+		if entry.Error != "" {
+			sb.WriteString(fmt.Sprintf("| %s | %s | - | - | - | - | - | skipped: %s | - |\n", entry.Revision, date, entry.Error))
+			continue
+		}
+
+		change := "-"
+		if previous >= 0 {
+			change = fmt.Sprintf("%+d", entry.ViolationCount-previous)
+		}
+		previous = entry.ViolationCount
+
+		violations := strconv.Itoa(entry.ViolationCount)
+		if entry.ErrorCount != entry.ViolationCount {
+			violations = fmt.Sprintf("%d (%d errors)", entry.ViolationCount, entry.ErrorCount)
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %d | %d | %s | %s | %d |\n",
+			entry.Revision, date, entry.FileCount, entry.PackageCount,
+			entry.LocalDependencyCount, entry.ExternalDependencyCount, violations, change, entry.Score))
+	}
+
+	// Breakdown by violation type, only for revisions with violations
+	var breakdown strings.Builder
+	for _, entry := range entries {
+		if len(entry.ViolationsByType) == 0 {
+			continue
+		}
+		breakdown.WriteString(fmt.Sprintf("| %s | %s |\n", entry.Revision, formatViolationCounts(entry.ViolationsByType)))
+	}
+	if breakdown.Len() > 0 {
+		sb.WriteString("\n## Violations by Type\n\n")
+		sb.WriteString("| Revision | Violations |\n")
+		sb.WriteString("|----------|------------|\n")
+		sb.WriteString(breakdown.String())
+	}
+
+	return sb.String()
+}
+
+func generateHistoryCSV(entries []HistoryEntry) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{{"revision", "commit", "date", "files", "packages", "local_dependencies",
+		"external_dependencies", "violations", "errors", "violations_by_type", "score", "error"}}
+	for _, entry := range entries {
+		score := strconv.Itoa(entry.Score)
+		if entry.Error != "" {
+			score = ""
+		}
+		records = append(records, []string{
+			entry.Revision,
+			entry.Commit,
+			entry.Date.Format(time.RFC3339),
+			strconv.Itoa(entry.FileCount),
+			strconv.Itoa(entry.PackageCount),
+			strconv.Itoa(entry.LocalDependencyCount),
+			strconv.Itoa(entry.ExternalDependencyCount),
+			strconv.Itoa(entry.ViolationCount),
+			strconv.Itoa(entry.ErrorCount),
+			formatViolationCounts(entry.ViolationsByType),
+			score,
+			entry.Error,
+		})
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// formatViolationCounts renders counts per violation type sorted by type, e.g. "Forbidden Import: 2; Unused Package: 1"
+func formatViolationCounts(counts map[string]int) string {
+	types := make([]string, 0, len(counts))
+	for violationType := range counts {
+		types = append(types, violationType)
+	}
+	sort.Strings(types)
+
+	parts := make([]string, len(types))
+	for i, violationType := range types {
+		parts[i] = fmt.Sprintf("%s: %d", violationType, counts[violationType])
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,118 @@
+package output_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func testHistoryEntries() []output.HistoryEntry {
+	return []output.HistoryEntry{
+		{
+			Revision:             "v1.0.0",
+			Commit:               "1111111111111111111111111111111111111111",
+			Date:                 time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+			FileCount:            10,
+			PackageCount:         4,
+			LocalDependencyCount: 3,
+			Score:                87,
+		},
+		{
+			Revision: "v1.1.0",
+			Commit:   "2222222222222222222222222222222222222222",
+			Date:     time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC),
+			Error:    "no .go files",
+		},
+		{
+			Revision:                "HEAD",
+			Commit:                  "3333333333333333333333333333333333333333",
+			Date:                    time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+			FileCount:               14,
+			PackageCount:            5,
+			LocalDependencyCount:    6,
+			ExternalDependencyCount: 2,
+			ViolationCount:          3,
+			ErrorCount:              2,
+			ViolationsByType:        map[string]int{"Unused Package": 1, "Forbidden Import": 2},
+			Score:                   62,
+		},
+	}
+}
+
+func TestFormatHistory_Markdown(t *testing.T) {
+	result, err := output.FormatHistory(testHistoryEntries(), "markdown")
+	if err != nil {
+		t.Fatalf("FormatHistory failed: %v", err)
+	}
+
+	expected := []string{
+		"# Architecture History",
+		"| v1.0.0 | 2024-01-10 | 10 | 4 | 3 | 0 | 0 | - | 87 |",
+		"| v1.1.0 | 2024-02-10 | - | - | - | - | - | skipped: no .go files | - |",
+		"| HEAD | 2024-03-10 | 14 | 5 | 6 | 2 | 3 (2 errors) | +3 | 62 |",
+		"## Violations by Type",
+		"| HEAD | Forbidden Import: 2; Unused Package: 1 |",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "| v1.0.0 | Forbidden") {
+		t.Error("expected breakdown only for revisions with violations")
+	}
+
+	empty, err := output.FormatHistory(nil, "markdown")
+	if err != nil || !strings.Contains(empty, "No revisions found") {
+		t.Errorf("expected empty history message, got %q (%v)", empty, err)
+	}
+}
+
+func TestFormatHistory_JSON(t *testing.T) {
+	result, err := output.FormatHistory(testHistoryEntries(), "json")
+	if err != nil {
+		t.Fatalf("FormatHistory failed: %v", err)
+	}
+
+	var entries []output.HistoryEntry
+	if err := json.Unmarshal([]byte(result), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+	if len(entries) != 3 || entries[2].ViolationsByType["Forbidden Import"] != 2 || entries[1].Error != "no .go files" {
+		t.Errorf("unexpected round trip result: %+v", entries)
+	}
+	if !strings.Contains(result, `"local_dependencies": 6`) {
+		t.Errorf("expected snake_case field names, got:\n%s", result)
+	}
+}
+
+func TestFormatHistory_CSV(t *testing.T) {
+	result, err := output.FormatHistory(testHistoryEntries(), "csv")
+	if err != nil {
+		t.Fatalf("FormatHistory failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(result), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header and 3 rows, got:\n%s", result)
+	}
+	if !strings.HasPrefix(lines[0], "revision,commit,date,") {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	want := "HEAD,3333333333333333333333333333333333333333,2024-03-10T12:00:00Z,14,5,6,2,3,2,Forbidden Import: 2; Unused Package: 1,62,"
+	if lines[3] != want {
+		t.Errorf("expected row %q, got %q", want, lines[3])
+	}
+	if !strings.HasSuffix(lines[2], ",,no .go files") {
+		t.Errorf("expected no score for a skipped revision, got %q", lines[2])
+	}
+}
+
+func TestFormatHistory_UnsupportedFormat(t *testing.T) {
+	if _, err := output.FormatHistory(testHistoryEntries(), "xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// HotspotFormats lists the supported hotspot report formats
+var HotspotFormats = []string{"markdown", "json"}
+
+// HotspotEntry holds churn, coupling and violation data of one package
+type HotspotEntry struct {
+	Package      string `json:"package"`
+	Lines        int    `json:"lines"`         // Lines of the package's scanned files
+	Commits      int    `json:"commits"`       // Commits touching the package in the analyzed period
+	LinesChanged int    `json:"lines_changed"` // Added plus deleted lines
+	Afferent     int    `json:"afferent"`      // Local packages importing this package
+	Efferent     int    `json:"efferent"`      // Local packages imported by this package
+	Violations   int    `json:"violations"`
+	Score        int    `json:"score"`
+}
+
+// FormatHotspots renders a hotspot ranking (highest risk first) as markdown or json
+func FormatHotspots(entries []HotspotEntry, since string, format string) (string, error) {
+	switch format {
+	case "markdown":
+		return generateHotspotsMarkdown(entries, since), nil
+	case "json":
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported hotspots format %q (supported: %s)", format, strings.Join(HotspotFormats, ", "))
+	}
+}
+
+func generateHotspotsMarkdown(entries []HotspotEntry, since string) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Architecture Hotspots\n\n")
+	period := "the entire history"
+	if since != "" {
+		period = "changes since " + since
+	}
+	sb.WriteString(fmt.Sprintf("Packages ranked by risk: churn × coupling × violations, based on %s.\n", period))
+	sb.WriteString("Score = commits × (afferent + efferent + 1) × (violations + 1).\n\n")
+
+	if len(entries) == 0 {
+		sb.WriteString("No changed packages found.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| # | Package | Lines | Commits | Lines Changed | Afferent | Efferent | Violations | Score |\n")
+	sb.WriteString("|---|---------|-------|---------|---------------|----------|----------|------------|-------|\n")
+	for i, entry := range entries {
+		sb.WriteString(fmt.Sprintf("| %d | %s | %d | %d | %d | %d | %d | %d | %d |\n",
+			i+1, entry.Package, entry.Lines, entry.Commits, entry.LinesChanged,
+			entry.Afferent, entry.Efferent, entry.Violations, entry.Score))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,62 @@
+package output_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestFormatHotspots_Markdown(t *testing.T) {
+	entries := []output.HotspotEntry{
+		{Package: "internal/order", Lines: 820, Commits: 12, LinesChanged: 340, Afferent: 3, Efferent: 2, Violations: 1, Score: 144},
+		{Package: "pkg/api", Commits: 4, LinesChanged: 20, Efferent: 1, Score: 8},
+	}
+
+	result, err := output.FormatHotspots(entries, "6 months ago", "markdown")
+	if err != nil {
+		t.Fatalf("FormatHotspots failed: %v", err)
+	}
+
+	expected := []string{
+		"# Architecture Hotspots",
+		"based on changes since 6 months ago",
+		"| 1 | internal/order | 820 | 12 | 340 | 3 | 2 | 1 | 144 |",
+		"| 2 | pkg/api | 0 | 4 | 20 | 0 | 1 | 0 | 8 |",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+
+	empty, err := output.FormatHotspots(nil, "", "markdown")
+	if err != nil {
+		t.Fatalf("FormatHotspots failed: %v", err)
+	}
+	if !strings.Contains(empty, "the entire history") || !strings.Contains(empty, "No changed packages found") {
+		t.Errorf("unexpected empty report:\n%s", empty)
+	}
+}
+
+func TestFormatHotspots_JSON(t *testing.T) {
+	entries := []output.HotspotEntry{{Package: "internal/order", Commits: 2, Afferent: 1, Score: 4}}
+
+	result, err := output.FormatHotspots(entries, "", "json")
+	if err != nil {
+		t.Fatalf("FormatHotspots failed: %v", err)
+	}
+
+	var decoded []output.HotspotEntry
+	if err := json.Unmarshal([]byte(result), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+	if len(decoded) != 1 || decoded[0] != entries[0] {
+		t.Errorf("unexpected round trip result: %+v", decoded)
+	}
+
+	if _, err := output.FormatHotspots(entries, "", "csv"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
@@ -17,6 +17,7 @@ type FileDetail struct {
 type PackageIndexInfo struct {
 	Name         string
 	Path         string       // Directory path for the package (e.g., "pkg/linter")
+	Description  string       // First sentence of the package doc comment
 	FileCount    int
 	Files        []FileDetail // List of files with line counts
 	ExportCount  int
@@ -33,14 +34,25 @@ type LayerPackages struct {
 
 // GenerateIndexDocumentation creates a lightweight architecture index
 func GenerateIndexDocumentation(doc FullDocumentation) string {
-	var sb strings.Builder
+	return buildIndexDocumentation(doc).String()
+}
+
+// buildIndexDocumentation writes the architecture index section by section
+func buildIndexDocumentation(doc FullDocumentation) *docBuilder {
+	sb := &docBuilder{}
 
 	// Header
+	sb.section("header")
 	sb.WriteString("# Project Architecture Index\n\n")
 	sb.WriteString(fmt.Sprintf("**Generated by go-arch-lint on %s**\n\n", time.Now().Format("2006-01-02")))
-	sb.WriteString("*Quick architecture reference. Use package-specific Details commands for comprehensive information.*\n\n")
+	if doc.PackageDetails != nil {
+		sb.WriteString("*Quick architecture reference. Expand the Details of a package for comprehensive information.*\n\n")
+	} else {
+		sb.WriteString("*Quick architecture reference. Use package-specific Details commands for comprehensive information.*\n\n")
+	}
 
 	// Quick Reference
+	sb.section("quick_reference")
 	sb.WriteString("## Quick Reference\n\n")
 	statusStr := "✓ 0 violations"
 	if doc.ViolationCount > 0 {
@@ -52,6 +64,7 @@ func GenerateIndexDocumentation(doc FullDocumentation) string {
 	sb.WriteString(fmt.Sprintf("- **Files**: %d\n\n", doc.FileCount))
 
 	// Architecture Summary
+	sb.section("architecture_summary")
 	sb.WriteString("## Architecture Summary\n\n")
 	if len(doc.Structure.RequiredDirectories) > 0 {
 		sb.WriteString("Project uses strict layered architecture:\n\n")
@@ -68,6 +81,7 @@ func GenerateIndexDocumentation(doc FullDocumentation) string {
 	}
 
 	// Architectural Rules (CRITICAL per mentor feedback)
+	sb.section("rules")
 	sb.WriteString("## Architectural Rules\n\n")
 	sb.WriteString("**Layer Dependencies:**\n\n")
 	for dir, allowed := range doc.Rules.DirectoriesImport {
@@ -84,6 +98,7 @@ func GenerateIndexDocumentation(doc FullDocumentation) string {
 	}
 
 	// Dependency Graph (package-level, non-detailed)
+	sb.section("dependency_graph")
 	sb.WriteString("## Dependency Graph\n\n")
 	sb.WriteString("Package-level dependencies (local dependencies only):\n\n")
 
@@ -114,16 +129,29 @@ func GenerateIndexDocumentation(doc FullDocumentation) string {
 		sb.WriteString("\n")
 	}
 
+	// Binaries (main packages) and the packages they are built from
+	sb.section("binaries")
+	writeBinaries(&sb.Builder, buildBinaries(doc.Graph), nil)
+
+	// Blank imports wiring packages in at runtime
+	sb.section("wiring")
+	writeRuntimeWiring(&sb.Builder, buildRuntimeWiring(doc.Graph), nil)
+
+	// What the main packages construct and inject
+	sb.section("composition")
+	writeCompositionRoots(&sb.Builder, doc.Roots, nil)
+
 	// Build package index by layer
 	packagesByLayer := buildPackagesByLayer(doc.Files)
 
 	// Package Directory
+	sb.section("packages")
 	sb.WriteString("## Package Directory\n\n")
 
 	if len(packagesByLayer.CmdPackages) > 0 {
 		sb.WriteString("### cmd (Application Entry Points)\n\n")
 		for _, pkg := range packagesByLayer.CmdPackages {
-			formatPackageEntry(&sb, pkg)
+			formatPackageEntry(&sb.Builder, pkg, doc.PackageDetails)
 		}
 		sb.WriteString("\n")
 	}
@@ -131,7 +159,7 @@ func GenerateIndexDocumentation(doc FullDocumentation) string {
 	if len(packagesByLayer.PkgPackages) > 0 {
 		sb.WriteString("### pkg (Public APIs)\n\n")
 		for _, pkg := range packagesByLayer.PkgPackages {
-			formatPackageEntry(&sb, pkg)
+			formatPackageEntry(&sb.Builder, pkg, doc.PackageDetails)
 		}
 		sb.WriteString("\n")
 	}
@@ -139,17 +167,31 @@ func GenerateIndexDocumentation(doc FullDocumentation) string {
 	if len(packagesByLayer.InternalPackages) > 0 {
 		sb.WriteString("### internal (Isolated Primitives)\n\n")
 		for _, pkg := range packagesByLayer.InternalPackages {
-			formatPackageEntry(&sb, pkg)
+			formatPackageEntry(&sb.Builder, pkg, doc.PackageDetails)
 		}
 		sb.WriteString("\n")
 	}
 
+	// Glossary of domain terms (ubiquitous language)
+	sb.section("glossary")
+	writeGlossary(&sb.Builder, buildGlossary(doc.Files, doc.DomainLayers), nil)
+
+	// Interfaces of port layers and their adapters
+	sb.section("ports")
+	writePorts(&sb.Builder, doc.Ports, nil)
+
 	// Agent Guidance
+	sb.section("guidance")
 	sb.WriteString("## Agent Guidance\n\n")
 	sb.WriteString("To get detailed information about specific packages:\n\n")
 	sb.WriteString("**Per-package details**:\n")
-	sb.WriteString("- Each package above has a **Details** command to get full information\n")
-	sb.WriteString("- Example: `go-arch-lint -format=package pkg/linter`\n")
+	if doc.PackageDetails != nil {
+		sb.WriteString("- Each package above has a collapsible **Details** section with full information\n")
+		sb.WriteString("- Link to it with its anchor, e.g. `#package-pkg-linter`\n")
+	} else {
+		sb.WriteString("- Each package above has a **Details** command to get full information\n")
+		sb.WriteString("- Example: `go-arch-lint -format=package pkg/linter`\n")
+	}
 	sb.WriteString("- Shows: full API, dependencies, exported types/functions for that package\n\n")
 	sb.WriteString("**Project-wide information**:\n")
 	sb.WriteString("- Public API for all packages: `./go-arch-lint -format=api .`\n")
@@ -157,14 +199,22 @@ func GenerateIndexDocumentation(doc FullDocumentation) string {
 	sb.WriteString("- Method-level dependencies: `./go-arch-lint -detailed -format=markdown .`\n")
 	sb.WriteString("- Check violations: `./go-arch-lint .`\n\n")
 	sb.WriteString("**Common workflows**:\n")
-	sb.WriteString("- Understanding a package → Find it below, run the Details command\n")
+	if doc.PackageDetails != nil {
+		sb.WriteString("- Understanding a package → Find it above, expand its Details\n")
+	} else {
+		sb.WriteString("- Understanding a package → Find it below, run the Details command\n")
+	}
 	sb.WriteString("- Adding new feature → Review architectural rules above, check layer constraints\n")
 	sb.WriteString("- Checking violations → Run `./go-arch-lint .` to see current issues with guidance\n\n")
 
 	// Statistics
+	sb.section("statistics")
 	sb.WriteString("## Statistics\n\n")
 	sb.WriteString(fmt.Sprintf("- **Total Files**: %d\n", doc.FileCount))
 	sb.WriteString(fmt.Sprintf("- **Total Packages**: %d\n", doc.PackageCount))
+	if len(doc.Layers) > 0 {
+		sb.WriteString(fmt.Sprintf("- **Total Lines**: %d\n", totalLines(doc.Layers)))
+	}
 	sb.WriteString(fmt.Sprintf("- **Violations**: %d\n", doc.ViolationCount))
 
 	// Count external dependencies
@@ -176,13 +226,19 @@ func GenerateIndexDocumentation(doc FullDocumentation) string {
 			}
 		}
 	}
-	sb.WriteString(fmt.Sprintf("- **External Dependencies**: %d\n\n", len(externalDepsSet)))
+	sb.WriteString(fmt.Sprintf("- **External Dependencies**: %d\n", len(externalDepsSet)))
+	writeLayerSizes(&sb.Builder, doc.Layers, nil)
+	sb.WriteString("\n")
 
 	sb.WriteString("---\n\n")
-	sb.WriteString("*Use the package-specific Details commands above to get comprehensive information about each package.*\n")
+	if doc.PackageDetails != nil {
+		sb.WriteString("*Expand the Details of a package above for comprehensive information about it.*\n")
+	} else {
+		sb.WriteString("*Use the package-specific Details commands above to get comprehensive information about each package.*\n")
+	}
 	sb.WriteString("*Run `./go-arch-lint docs` to regenerate this index.*\n")
 
-	return sb.String()
+	return sb
 }
 
 // buildPackagesByLayer organizes packages by architectural layer
@@ -252,6 +308,19 @@ func buildPackagesByLayer(files []FileWithAPI) LayerPackages {
 		}
 	}
 
+	// Describe packages by the first sentence of their doc comment
+	packageFiles := make(map[string][]FileWithAPI)
+	for _, file := range files {
+		pkgPath := "."
+		if idx := strings.LastIndex(file.GetRelPath(), "/"); idx >= 0 {
+			pkgPath = file.GetRelPath()[:idx]
+		}
+		packageFiles[pkgPath] = append(packageFiles[pkgPath], file)
+	}
+	for pkgPath, pkg := range packageMap {
+		pkg.Description = packageSynopsis(packageDescription(packageFiles[pkgPath]))
+	}
+
 	// Organize by layer (using Path not Name)
 	result := LayerPackages{
 		CmdPackages:      []PackageIndexInfo{},
@@ -290,10 +359,14 @@ func buildPackagesByLayer(files []FileWithAPI) LayerPackages {
 	return result
 }
 
-// formatPackageEntry formats a single package entry in the index
-func formatPackageEntry(sb *strings.Builder, pkg PackageIndexInfo) {
+// formatPackageEntry formats a single package entry in the index, with its documentation
+// from details under a collapsible section if it has any
+func formatPackageEntry(sb *strings.Builder, pkg PackageIndexInfo, details map[string]PackageDocumentation) {
 	// Package name and path
 	sb.WriteString(fmt.Sprintf("- **%s** (`%s`)\n", pkg.Name, pkg.Path))
+	if pkg.Description != "" {
+		sb.WriteString(fmt.Sprintf("  - %s\n", pkg.Description))
+	}
 
 	// Format files with line counts
 	if len(pkg.Files) > 0 {
@@ -316,12 +389,49 @@ func formatPackageEntry(sb *strings.Builder, pkg PackageIndexInfo) {
 		sb.WriteString(fmt.Sprintf("  - Key exports: %s\n", strings.Join(pkg.KeyExports, ", ")))
 	}
 
-	// Command to get detailed info
-	sb.WriteString(fmt.Sprintf("  - **Details**: `go-arch-lint -format=package %s`\n", pkg.Path))
+	// Embedded documentation, or the command to get detailed info
+	if pkgDoc, ok := details[pkg.Path]; ok {
+		sb.WriteString(fmt.Sprintf("\n<details id=\"%s\">\n<summary>Details</summary>\n\n", packageAnchor(pkg.Path)))
+		for _, section := range buildPackageDocumentation(pkgDoc).sections() {
+			// The footer names the command the embedded page replaces
+			if section.name != "footer" {
+				sb.WriteString(demoteHeadings(section.content, 3))
+			}
+		}
+		sb.WriteString("\n</details>\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("  - **Details**: `go-arch-lint -format=package %s`\n", pkg.Path))
+	}
 
 	sb.WriteString("\n")
 }
 
+// packageAnchor returns the HTML id of the embedded documentation of a package:
+// "package-pkg-linter" for pkg/linter
+func packageAnchor(pkgPath string) string {
+	return "package-" + strings.NewReplacer("/", "-", ".", "-").Replace(pkgPath)
+}
+
+// demoteHeadings moves the markdown headings of a document levels deeper, down to the
+// deepest level markdown has, so a document can be embedded below a heading of another
+// one. Code blocks are left alone.
+func demoteHeadings(markdown string, levels int) string {
+	lines := strings.Split(markdown, "\n")
+	inCode := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+		}
+		text := strings.TrimLeft(line, "#")
+		level := len(line) - len(text)
+		if inCode || level == 0 || !strings.HasPrefix(text, " ") {
+			continue
+		}
+		lines[i] = strings.Repeat("#", min(level+levels, 6)) + text
+	}
+	return strings.Join(lines, "\n")
+}
+
 // PackageDependencies holds dependency information for a package
 type PackageDependencies struct {
 	LocalDeps    []string // Local package dependencies (deduplicated)
@@ -20,6 +20,9 @@ func (td *testDependencyForIndex) GetImportPath() string  { return td.importPath
 func (td *testDependencyForIndex) IsLocalDep() bool        { return td.isLocal }
 func (td *testDependencyForIndex) GetLocalPath() string    { return td.localPath }
 func (td *testDependencyForIndex) GetUsedSymbols() []string { return td.symbols }
+func (td *testDependencyForIndex) GetCallSites() int        { return 0 }
+func (td *testDependencyForIndex) IsRuntimeWiring() bool     { return false }
+func (td *testDependencyForIndex) GetRegistrations() []string { return nil }
 
 type testFileNodeForIndex struct {
 	relPath      string
@@ -42,24 +45,37 @@ type testExportedDeclForIndex struct {
 	kind       string
 	signature  string
 	properties []string
+	typeKind   string
+	embeds     []string
+	doc        string
+	line       int
 }
 
 func (ted *testExportedDeclForIndex) GetName() string       { return ted.name }
 func (ted *testExportedDeclForIndex) GetKind() string       { return ted.kind }
 func (ted *testExportedDeclForIndex) GetSignature() string  { return ted.signature }
 func (ted *testExportedDeclForIndex) GetProperties() []string { return ted.properties }
+func (ted *testExportedDeclForIndex) GetTypeKind() string   { return ted.typeKind }
+func (ted *testExportedDeclForIndex) GetEmbeds() []string     { return ted.embeds }
+func (ted *testExportedDeclForIndex) GetDoc() string        { return ted.doc }
+func (ted *testExportedDeclForIndex) GetLine() int          { return ted.line }
 
 type testFileWithAPIForIndex struct {
 	relPath      string
 	pkgName      string
 	exportedDecls []output.ExportedDecl
 	lineCount    int
+	stability    string
+	packageDoc   string
 }
 
 func (twa *testFileWithAPIForIndex) GetRelPath() string                    { return twa.relPath }
 func (twa *testFileWithAPIForIndex) GetPackage() string                    { return twa.pkgName }
 func (twa *testFileWithAPIForIndex) GetExportedDecls() []output.ExportedDecl { return twa.exportedDecls }
 func (twa *testFileWithAPIForIndex) GetLineCount() int                     { return twa.lineCount }
+func (twa *testFileWithAPIForIndex) GetStability() string                  { return twa.stability }
+func (twa *testFileWithAPIForIndex) GetPackageDoc() string                 { return twa.packageDoc }
+func (twa *testFileWithAPIForIndex) GetExamples() []output.Example { return nil }
 
 // Tests
 
@@ -770,3 +786,79 @@ func TestGenerateIndexDocumentation_DependencyGraphHandlesEmptyGraph(t *testing.
 		t.Error("Expected message about no packages found in empty graph")
 	}
 }
+
+func TestGenerateIndexDocumentation_PackageDescriptions(t *testing.T) {
+	files := []output.FileWithAPI{
+		&testFileWithAPIForIndex{
+			relPath:    "pkg/linter/doc.go",
+			pkgName:    "linter",
+			packageDoc: "Package linter validates\narchitecture rules. It wires the internal packages.\n\nDetails follow.",
+		},
+		&testFileWithAPIForIndex{
+			relPath:    "pkg/linter/linter_test.go",
+			pkgName:    "linter_test",
+			packageDoc: "Test doc is ignored.",
+		},
+		&testFileWithAPIForIndex{
+			relPath: "internal/config/config.go",
+			pkgName: "config",
+		},
+	}
+
+	doc := output.FullDocumentation{
+		Graph: &testGraphForIndex{},
+		Files: files,
+	}
+
+	result := output.GenerateIndexDocumentation(doc)
+
+	if !strings.Contains(result, "- **linter** (`pkg/linter`)\n  - Package linter validates architecture rules.\n") {
+		t.Errorf("expected first sentence of package doc under linter, got:\n%s", result)
+	}
+	if strings.Contains(result, "Details follow") || strings.Contains(result, "Test doc") {
+		t.Errorf("expected only the synopsis of non-test docs, got:\n%s", result)
+	}
+	if !strings.Contains(result, "- **config** (`internal/config`)\n  - Files:") {
+		t.Errorf("expected no description for undocumented package, got:\n%s", result)
+	}
+}
+
+func TestGenerateIndexDocumentation_InlinePackageDetails(t *testing.T) {
+	linterFiles := []output.FileWithAPI{
+		&testFileWithAPIForIndex{
+			relPath: "pkg/linter/linter.go",
+			pkgName: "linter",
+			exportedDecls: []output.ExportedDecl{
+				&testExportedDeclForIndex{name: "Run", kind: "func", signature: "Run() error"},
+			},
+		},
+	}
+	files := append(linterFiles, &testFileWithAPIForIndex{relPath: "internal/config/config.go", pkgName: "config"})
+
+	doc := output.FullDocumentation{
+		Graph: &testGraphForIndex{},
+		Files: files,
+		PackageDetails: map[string]output.PackageDocumentation{
+			"pkg/linter": {PackageName: "linter", PackagePath: "pkg/linter", Files: linterFiles, FileCount: 1, ExportCount: 1},
+		},
+	}
+
+	result := output.GenerateIndexDocumentation(doc)
+
+	if !strings.Contains(result, "\n<details id=\"package-pkg-linter\">\n<summary>Details</summary>\n\n#### Package: linter\n") {
+		t.Errorf("expected the linter documentation under a collapsible section with demoted headings, got:\n%s", result)
+	}
+	if !strings.Contains(result, "\n##### Overview\n") || !strings.Contains(result, "Run() error") {
+		t.Errorf("expected the full package documentation inline, got:\n%s", result)
+	}
+	if strings.Contains(result, "`go-arch-lint -format=package pkg/linter`") {
+		t.Errorf("expected no details command for an inlined package, got:\n%s", result)
+	}
+	// Packages without documentation keep the command
+	if !strings.Contains(result, "  - **Details**: `go-arch-lint -format=package internal/config`\n") {
+		t.Errorf("expected the details command for packages without inline documentation, got:\n%s", result)
+	}
+	if !strings.Contains(result, "Expand the Details of a package") {
+		t.Errorf("expected the header to point at the collapsible sections, got:\n%s", result)
+	}
+}
@@ -0,0 +1,101 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// InventoryModule is an external module imported by the project
+type InventoryModule struct {
+	Path      string              // Module path, or the import path if go.mod does not require a matching module
+	Version   string              // Version required in go.mod (empty if not required)
+	Importers []InventoryImporter // Local packages importing the module, sorted by package
+}
+
+// InventoryImporter is a local package importing packages of an external module
+type InventoryImporter struct {
+	Package string   // Directory of the importing package
+	Layer   string   // Layer of the importing package (empty if outside every layer)
+	Files   int      // Files of the package importing the module
+	Imports []string // Packages of the module imported, sorted
+}
+
+// GenerateInventory renders the external modules of a project with the layers and
+// packages importing them: an architectural bill of materials
+func GenerateInventory(modules []InventoryModule) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Dependency Inventory\n\n")
+	if len(modules) == 0 {
+		sb.WriteString("No external modules imported.\n")
+		return sb.String()
+	}
+
+	importers := make(map[string]bool)
+	for _, module := range modules {
+		for _, importer := range module.Importers {
+			importers[importer.Package] = true
+		}
+	}
+	sb.WriteString(fmt.Sprintf("%s imported by %s.\n\n",
+		countLabel(len(modules), "external module", "external modules"), countLabel(len(importers), "package", "packages")))
+
+	sb.WriteString("| Module | Version | Layers | Packages | Files | Imported packages |\n")
+	sb.WriteString("|--------|---------|--------|----------|-------|-------------------|\n")
+	for _, module := range modules {
+		files := 0
+		imports := make(map[string]bool)
+		for _, importer := range module.Importers {
+			files += importer.Files
+			for _, imp := range importer.Imports {
+				imports[imp] = true
+			}
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %d | %d | %d |\n",
+			module.Path, versionLabel(module.Version), strings.Join(inventoryLayers(module), ", "),
+			len(module.Importers), files, len(imports)))
+	}
+
+	sb.WriteString("\n## Importers\n")
+	for _, module := range modules {
+		sb.WriteString(fmt.Sprintf("\n### `%s` %s\n\n", module.Path, versionLabel(module.Version)))
+		for _, importer := range module.Importers {
+			sb.WriteString(fmt.Sprintf("- `%s` (%s, %s): `%s`\n",
+				importer.Package, layerLabel(importer.Layer), countLabel(importer.Files, "file", "files"), strings.Join(importer.Imports, "`, `")))
+		}
+	}
+
+	return sb.String()
+}
+
+// inventoryLayers returns the sorted layers importing a module
+func inventoryLayers(module InventoryModule) []string {
+	seen := make(map[string]bool)
+	var layers []string
+	for _, importer := range module.Importers {
+		layer := layerLabel(importer.Layer)
+		if !seen[layer] {
+			seen[layer] = true
+			layers = append(layers, layer)
+		}
+	}
+	sort.Strings(layers)
+	return layers
+}
+
+// versionLabel shows the required version of a module, or a dash if go.mod has none
+func versionLabel(version string) string {
+	if version == "" {
+		return "-"
+	}
+	return version
+}
+
+// countLabel renders a count with the singular or plural noun
+func countLabel(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
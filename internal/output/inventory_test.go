@@ -0,0 +1,51 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestGenerateInventory(t *testing.T) {
+	result := output.GenerateInventory([]output.InventoryModule{
+		{
+			Path:    "github.com/lib/pq",
+			Version: "v1.10.9",
+			Importers: []output.InventoryImporter{
+				{Package: "cmd/app", Layer: "cmd", Files: 1, Imports: []string{"github.com/lib/pq"}},
+				{Package: "internal/store", Layer: "internal", Files: 2, Imports: []string{"github.com/lib/pq", "github.com/lib/pq/hstore"}},
+			},
+		},
+		{
+			Path:      "example.com/tools/gen",
+			Importers: []output.InventoryImporter{{Package: "tools", Files: 1, Imports: []string{"example.com/tools/gen"}}},
+		},
+	})
+
+	expected := []string{
+		"# Dependency Inventory\n",
+		"2 external modules imported by 3 packages.\n",
+		"| Module | Version | Layers | Packages | Files | Imported packages |\n",
+		"| `github.com/lib/pq` | v1.10.9 | cmd, internal | 2 | 3 | 2 |\n",
+		"| `example.com/tools/gen` | - | no layer | 1 | 1 | 1 |\n",
+		"### `github.com/lib/pq` v1.10.9\n\n- `cmd/app` (cmd, 1 file): `github.com/lib/pq`\n- `internal/store` (internal, 2 files): `github.com/lib/pq`, `github.com/lib/pq/hstore`\n",
+		"### `example.com/tools/gen` -\n\n- `tools` (no layer, 1 file): `example.com/tools/gen`\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in inventory, got:\n%s", want, result)
+		}
+	}
+
+	single := output.GenerateInventory([]output.InventoryModule{
+		{Path: "github.com/lib/pq", Importers: []output.InventoryImporter{{Package: "internal/store", Files: 1, Imports: []string{"github.com/lib/pq"}}}},
+	})
+	if !strings.Contains(single, "1 external module imported by 1 package.\n") {
+		t.Errorf("expected singular counts, got:\n%s", single)
+	}
+
+	if result := output.GenerateInventory(nil); !strings.Contains(result, "No external modules imported.") {
+		t.Errorf("expected note for no external modules, got:\n%s", result)
+	}
+}
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LayerSize holds the size of the scanned code of one layer
+type LayerSize struct {
+	Layer    string // directories_import key (empty: packages outside the configured layers)
+	Packages int
+	Files    int
+	Lines    int
+	Size     int64 // Bytes
+}
+
+// writeLayerSizes writes the table of per-layer sizes of the statistics section, and
+// nothing without layers
+func writeLayerSizes(sb *strings.Builder, layers []LayerSize, messages *Messages) {
+	if len(layers) == 0 {
+		return
+	}
+
+	sb.WriteString("\n" + messages.Text("docs.layer_sizes") + "\n")
+	sb.WriteString("|---|---|---|---|---|\n")
+	for _, layer := range layers {
+		label := "`" + layer.Layer + "`"
+		if layer.Layer == "" {
+			label = messages.Text("docs.no_layer")
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %s |\n", label, layer.Packages, layer.Files, layer.Lines, formatSize(layer.Size)))
+	}
+}
+
+// totalLines sums the lines of all layers
+func totalLines(layers []LayerSize) int {
+	total := 0
+	for _, layer := range layers {
+		total += layer.Lines
+	}
+	return total
+}
+
+// formatSize renders a byte count with a binary unit, e.g. 1536 as 1.5 KB
+func formatSize(bytes int64) string {
+	if bytes < 1024 {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	size := float64(bytes) / 1024
+	for _, unit := range []string{"KB", "MB"} {
+		if size < 1024 {
+			return fmt.Sprintf("%.1f %s", size, unit)
+		}
+		size /= 1024
+	}
+	return fmt.Sprintf("%.1f GB", size)
+}
@@ -0,0 +1,68 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func testLayerSizes() []output.LayerSize {
+	return []output.LayerSize{
+		{Layer: "internal", Packages: 3, Files: 7, Lines: 1204, Size: 35840},
+		{Layer: "pkg", Packages: 1, Files: 2, Lines: 96, Size: 2600000},
+		{Packages: 1, Files: 1, Lines: 12, Size: 180},
+	}
+}
+
+func TestGenerateIndexDocumentation_LayerSizes(t *testing.T) {
+	result := output.GenerateIndexDocumentation(output.FullDocumentation{Graph: &testGraphForIndex{}, Layers: testLayerSizes()})
+
+	expected := []string{
+		"- **Total Lines**: 1312\n",
+		"- **External Dependencies**: 0\n\n" +
+			"| Layer | Packages | Files | Lines | Size |\n" +
+			"|---|---|---|---|---|\n" +
+			"| `internal` | 3 | 7 | 1204 | 35.0 KB |\n" +
+			"| `pkg` | 1 | 2 | 96 | 2.5 MB |\n" +
+			"| *(no layer)* | 1 | 1 | 12 | 180 B |\n\n---",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+
+	// Without layer sizes the statistics are unchanged
+	result = output.GenerateIndexDocumentation(output.FullDocumentation{Graph: &testGraphForIndex{}})
+	if strings.Contains(result, "Total Lines") || !strings.Contains(result, "- **External Dependencies**: 0\n\n---") {
+		t.Errorf("expected no layer sizes, got:\n%s", result)
+	}
+}
+
+func TestGenerateFullDocumentation_LayerSizes(t *testing.T) {
+	result := output.GenerateFullDocumentation(output.FullDocumentation{Graph: &testGraphForIndex{}, Layers: testLayerSizes(), Lang: "de"})
+
+	expected := []string{
+		"- **Zeilen gesamt**: 1312\n",
+		"| Schicht | Pakete | Dateien | Zeilen | Größe |\n",
+		"| *(keine Schicht)* | 1 | 1 | 12 | 180 B |\n\n---",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+}
+
+func TestRenderDocumentTemplate_LayerSizes(t *testing.T) {
+	doc := output.FullDocumentation{Graph: &testGraphForIndex{}, Layers: testLayerSizes()}
+
+	result, err := output.RenderDocumentTemplate("index", `{{range .Layers}}{{.Layer}}={{.Lines}} {{end}}`, doc)
+	if err != nil {
+		t.Fatalf("RenderDocumentTemplate failed: %v", err)
+	}
+	if result != "internal=1204 pkg=96 =12 " {
+		t.Errorf("unexpected layer sizes: %q", result)
+	}
+}
@@ -1,7 +1,10 @@
 package output
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 )
@@ -12,6 +15,9 @@ type Dependency interface {
 	IsLocalDep() bool
 	GetLocalPath() string
 	GetUsedSymbols() []string
+	GetCallSites() int          // References to the used symbols, 0 if not tracked
+	IsRuntimeWiring() bool      // Blank import wiring the package in through its init functions
+	GetRegistrations() []string // What the init functions of a wired local package register
 }
 
 // FileNode interface for rendering file nodes
@@ -32,6 +38,10 @@ type ExportedDecl interface {
 	GetKind() string
 	GetSignature() string
 	GetProperties() []string
+	GetTypeKind() string // "struct", "interface" or "other" for types, empty otherwise
+	GetEmbeds() []string // Embedded types of structs and interfaces
+	GetDoc() string      // Doc comment text, empty if undocumented
+	GetLine() int        // Line of the declared name
 }
 
 // FileWithAPI represents a file with exported API information
@@ -40,6 +50,17 @@ type FileWithAPI interface {
 	GetPackage() string
 	GetExportedDecls() []ExportedDecl
 	GetLineCount() int
+	GetStability() string   // "experimental", "stable", "deprecated" or empty
+	GetPackageDoc() string  // Package doc comment, empty if the file has none
+	GetExamples() []Example // Example functions of the package, reported by one of its files
+}
+
+// Example represents a runnable Example function from a package's test files
+type Example interface {
+	GetName() string    // Function name, e.g. "ExampleCache_Get"
+	GetSymbol() string  // Documented symbol, e.g. "Cache.Get"; empty for package examples
+	GetRelPath() string // Test file declaring the example
+	GetLine() int
 }
 
 // Violation represents a validation violation
@@ -47,9 +68,27 @@ type Violation interface {
 	GetType() string
 	GetFile() string
 	GetLine() int
+	GetColumn() int // Column in the file, 0 if unknown
 	GetIssue() string
 	GetRule() string
 	GetFix() string
+	GetSeverity() string // "error", "warning" or "info"
+	GetLink() string     // Link to the source, empty if not configured
+	GetSpans() []Span    // All offending locations of a collapsed violation
+	GetSource() string   // Configuration layer that defined the rule, empty if unknown
+	GetRuleKey() string  // Configuration key of the rule, empty for hardcoded rules
+	GetCode() string     // Stable code of the violation type, e.g. "ARCH005" (empty if unknown)
+	// Localize returns the issue, rule and fix with the message formats translate
+	// returns for their message IDs and English formats
+	Localize(translate func(id, format string) string) (issue, rule, fix string)
+}
+
+// Span represents one offending location of a violation
+type Span interface {
+	GetLine() int
+	GetColumn() int
+	GetIssue() string
+	Localize(translate func(id, format string) string) string // Issue, translated like Violation.Localize
 }
 
 // GenerateMarkdown creates a markdown representation of the dependency graph
@@ -88,7 +127,7 @@ func GenerateMarkdown(g Graph) string {
 
 		for _, dep := range deps {
 			if dep.IsLocalDep() {
-				sb.WriteString(fmt.Sprintf("  - local:%s\n", dep.GetLocalPath()))
+				sb.WriteString(fmt.Sprintf("  - local:%s%s%s\n", dep.GetLocalPath(), callSitesNote(dep), wiringNote(dep)))
 				// Add used symbols if available
 				usedSymbols := dep.GetUsedSymbols()
 				if len(usedSymbols) > 0 {
@@ -96,8 +135,13 @@ func GenerateMarkdown(g Graph) string {
 						sb.WriteString(fmt.Sprintf("    - %s\n", symbol))
 					}
 				}
-			} else if !isStdLib(dep.GetImportPath()) {
-				sb.WriteString(fmt.Sprintf("  - external:%s\n", dep.GetImportPath()))
+			} else if isStdLib(dep.GetImportPath()) {
+				// Standard library imports are only listed when they wire a package in
+				if dep.IsRuntimeWiring() {
+					sb.WriteString(fmt.Sprintf("  - stdlib:%s%s\n", dep.GetImportPath(), wiringNote(dep)))
+				}
+			} else {
+				sb.WriteString(fmt.Sprintf("  - external:%s%s%s\n", dep.GetImportPath(), callSitesNote(dep), wiringNote(dep)))
 				// Add used symbols if available
 				usedSymbols := dep.GetUsedSymbols()
 				if len(usedSymbols) > 0 {
@@ -114,6 +158,18 @@ func GenerateMarkdown(g Graph) string {
 	return sb.String()
 }
 
+// callSitesNote returns the weight of a dependency in detailed mode, empty if not tracked
+func callSitesNote(dep Dependency) string {
+	switch dep.GetCallSites() {
+	case 0:
+		return ""
+	case 1:
+		return " (1 call site)"
+	default:
+		return fmt.Sprintf(" (%d call sites)", dep.GetCallSites())
+	}
+}
+
 // isStdLib checks if an import is from the standard library
 func isStdLib(importPath string) bool {
 	// Standard library packages don't contain a dot in the first path segment
@@ -138,6 +194,13 @@ type ErrorContext struct {
 
 // FormatViolationsWithContext creates a formatted report with architectural context
 func FormatViolationsWithContext(violations []Violation, errorContext *ErrorContext) string {
+	return FormatViolationsLocalized(violations, errorContext, nil)
+}
+
+// FormatViolationsLocalized creates a formatted report with architectural context, with
+// headings, labels, tips, violation types and texts in the language of messages (English
+// if nil)
+func FormatViolationsLocalized(violations []Violation, errorContext *ErrorContext, messages *Messages) string {
 	if len(violations) == 0 {
 		return ""
 	}
@@ -147,54 +210,88 @@ func FormatViolationsWithContext(violations []Violation, errorContext *ErrorCont
 	// Add architectural context preamble if enabled
 	if errorContext != nil && errorContext.Enabled {
 		sb.WriteString("╔════════════════════════════════════════════════════════════════════════════════╗\n")
-		sb.WriteString("║                     ARCHITECTURAL VIOLATIONS DETECTED                          ║\n")
+		sb.WriteString(fmt.Sprintf("║                     %-59s║\n", messages.Text("report.banner")))
 		sb.WriteString("╚════════════════════════════════════════════════════════════════════════════════╝\n\n")
 
 		if errorContext.PresetName != "" {
-			sb.WriteString(fmt.Sprintf("This project uses the '%s' architectural preset.\n", errorContext.PresetName))
+			sb.WriteString(messages.Text("report.preset", errorContext.PresetName))
 		}
-		sb.WriteString("The violations below indicate that the current structure does not align with\n")
-		sb.WriteString("the target architecture. Please review the architectural goals and refactoring\n")
-		sb.WriteString("guidance to understand how to properly restructure the code.\n\n")
+		sb.WriteString(messages.Text("report.intro"))
 
 		if errorContext.ArchitecturalGoals != "" {
-			sb.WriteString("┌─ ARCHITECTURAL GOALS ──────────────────────────────────────────────────────────┐\n")
+			sb.WriteString(boxHeader(messages.Text("report.goals")))
 			sb.WriteString(errorContext.ArchitecturalGoals)
 			sb.WriteString("└────────────────────────────────────────────────────────────────────────────────┘\n\n")
 		}
 
 		if len(errorContext.Principles) > 0 {
-			sb.WriteString("┌─ KEY PRINCIPLES ───────────────────────────────────────────────────────────────┐\n")
+			sb.WriteString(boxHeader(messages.Text("report.principles")))
 			for _, principle := range errorContext.Principles {
 				sb.WriteString(fmt.Sprintf("  • %s\n", principle))
 			}
 			sb.WriteString("└────────────────────────────────────────────────────────────────────────────────┘\n\n")
 		}
 
-		sb.WriteString("┌─ VIOLATIONS ───────────────────────────────────────────────────────────────────┐\n\n")
+		sb.WriteString(boxHeader(messages.Text("report.violations")) + "\n")
 	} else {
-		sb.WriteString("DEPENDENCY VIOLATIONS DETECTED\n\n")
+		sb.WriteString(messages.Text("report.header") + "\n\n")
 	}
 
+	footnotes, footnoteCodes := fixFootnotes(violations, messages)
 	for _, v := range violations {
-		sb.WriteString(fmt.Sprintf("[ERROR] %s\n", v.GetType()))
+		severity := strings.ToUpper(v.GetSeverity())
+		if severity == "" {
+			severity = "ERROR"
+		}
+		sb.WriteString(fmt.Sprintf("[%s] %s\n", severity, messages.violationType(v)))
 
 		if v.GetFile() != "" {
-			sb.WriteString(fmt.Sprintf("  File: %s", v.GetFile()))
+			sb.WriteString(fmt.Sprintf("  %s: %s", messages.Text("report.file"), v.GetFile()))
 			if v.GetLine() > 0 {
 				sb.WriteString(fmt.Sprintf(":%d", v.GetLine()))
+				if v.GetColumn() > 0 {
+					sb.WriteString(fmt.Sprintf(":%d", v.GetColumn()))
+				}
 			}
 			sb.WriteString("\n")
 		}
+		if v.GetLink() != "" {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", messages.Text("report.link"), v.GetLink()))
+		}
+		if spans := v.GetSpans(); len(spans) > 0 {
+			sb.WriteString(fmt.Sprintf("  %s:\n", messages.Text("report.locations")))
+			for _, span := range spans {
+				sb.WriteString(fmt.Sprintf("    %s:%d:%d %s\n", v.GetFile(), span.GetLine(), span.GetColumn(), span.Localize(messages.translate)))
+			}
+		}
 
-		sb.WriteString(fmt.Sprintf("  Issue: %s\n", v.GetIssue()))
-		sb.WriteString(fmt.Sprintf("  Rule: %s\n", v.GetRule()))
-		sb.WriteString(fmt.Sprintf("  Fix: %s\n", v.GetFix()))
+		issue, rule, fix := v.Localize(messages.translate)
+		sb.WriteString(fmt.Sprintf("  %s: %s\n", messages.Text("report.issue"), issue))
+		sb.WriteString(fmt.Sprintf("  %s: %s\n", messages.Text("report.rule"), rule))
+		if v.GetSource() != "" {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", messages.Text("report.source"), v.GetSource()))
+		}
+		if code := FixCode(v); footnotes[code] != "" {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", messages.Text("report.fix"), messages.Text("report.fix_ref", code)))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", messages.Text("report.fix"), fix))
+		}
 		sb.WriteString("\n")
 	}
 
 	if errorContext != nil && errorContext.Enabled {
 		sb.WriteString("└────────────────────────────────────────────────────────────────────────────────┘\n\n")
+	}
+
+	if len(footnoteCodes) > 0 {
+		sb.WriteString(messages.Text("report.fixes") + "\n")
+		for _, code := range footnoteCodes {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", code, footnotes[code]))
+		}
+		sb.WriteString("\n")
+	}
+
+	if errorContext != nil && errorContext.Enabled {
 
 		// Categorize violations into different types
 		hasCoverageViolations := false
@@ -224,28 +321,28 @@ func FormatViolationsWithContext(violations []Violation, errorContext *ErrorCont
 
 		// Show refactoring guidance ONLY for architectural violations
 		if hasArchitecturalViolations && errorContext.RefactoringGuidance != "" {
-			sb.WriteString("┌─ REFACTORING GUIDANCE ─────────────────────────────────────────────────────────┐\n")
+			sb.WriteString(boxHeader(messages.Text("guidance.refactor")))
 			sb.WriteString(errorContext.RefactoringGuidance)
 			sb.WriteString("└────────────────────────────────────────────────────────────────────────────────┘\n\n")
 		}
 
 		// Show test coverage guidance ONLY for coverage violations
 		if hasCoverageViolations && errorContext.CoverageGuidance != "" {
-			sb.WriteString("┌─ TEST COVERAGE GUIDANCE ───────────────────────────────────────────────────────┐\n")
+			sb.WriteString(boxHeader(messages.Text("guidance.coverage")))
 			sb.WriteString(errorContext.CoverageGuidance)
 			sb.WriteString("└────────────────────────────────────────────────────────────────────────────────┘\n\n")
 		}
 
 		// Show test naming guidance ONLY for test naming violations
 		if hasTestNamingViolations && errorContext.TestNamingGuidance != "" {
-			sb.WriteString("┌─ TEST NAMING GUIDANCE ─────────────────────────────────────────────────────────┐\n")
+			sb.WriteString(boxHeader(messages.Text("guidance.naming")))
 			sb.WriteString(errorContext.TestNamingGuidance)
 			sb.WriteString("└────────────────────────────────────────────────────────────────────────────────┘\n\n")
 		}
 
 		// Show blackbox testing guidance ONLY for whitebox test violations
 		if hasWhiteboxTestViolations && errorContext.BlackboxTestingGuidance != "" {
-			sb.WriteString("┌─ BLACKBOX TESTING GUIDANCE ────────────────────────────────────────────────────┐\n")
+			sb.WriteString(boxHeader(messages.Text("guidance.blackbox")))
 			sb.WriteString(errorContext.BlackboxTestingGuidance)
 			sb.WriteString("└────────────────────────────────────────────────────────────────────────────────┘\n\n")
 		}
@@ -253,41 +350,127 @@ func FormatViolationsWithContext(violations []Violation, errorContext *ErrorCont
 		// Different tips based on violation types
 		hasAnyTestViolations := hasCoverageViolations || hasTestNamingViolations || hasWhiteboxTestViolations
 		if hasAnyTestViolations && hasArchitecturalViolations {
-			sb.WriteString("💡 TIP: Address architectural violations first, then improve test quality and coverage.\n")
-			sb.WriteString("   Good tests verify correct behavior - make sure the architecture is sound\n")
-			sb.WriteString("   before investing in comprehensive test coverage.\n")
+			sb.WriteString(messages.Text("tip.tests_and_arch"))
 		} else if hasArchitecturalViolations {
-			sb.WriteString("💡 TIP: These violations show architectural misalignment, not just linter errors.\n")
-			sb.WriteString("   Focus on understanding WHY the target architecture matters, then refactor\n")
-			sb.WriteString("   accordingly. Don't just move code to make the linter happy - restructure\n")
-			sb.WriteString("   to achieve the architectural goals described above.\n")
+			sb.WriteString(messages.Text("tip.architecture"))
 		} else if hasWhiteboxTestViolations && (hasCoverageViolations || hasTestNamingViolations) {
-			sb.WriteString("💡 TIP: Start with blackbox testing, then improve coverage and naming.\n")
-			sb.WriteString("   Blackbox tests (package foo_test) are more resilient to refactoring and\n")
-			sb.WriteString("   encourage better API design. After converting to blackbox, focus on coverage.\n")
+			sb.WriteString(messages.Text("tip.blackbox_first"))
 		} else if hasWhiteboxTestViolations {
-			sb.WriteString("💡 TIP: Blackbox testing improves test resilience and API design.\n")
-			sb.WriteString("   Tests using 'package foo_test' verify behavior through the public interface,\n")
-			sb.WriteString("   making them more maintainable and resilient to internal refactoring.\n")
+			sb.WriteString(messages.Text("tip.blackbox"))
 		} else if hasCoverageViolations {
-			sb.WriteString("💡 TIP: Test coverage ensures your code works correctly and can be refactored safely.\n")
-			sb.WriteString("   Focus on testing critical paths and business logic first. Use coverage\n")
-			sb.WriteString("   reports to identify untested code, then write tests that verify behavior.\n")
+			sb.WriteString(messages.Text("tip.coverage"))
 		} else if hasTestNamingViolations {
-			sb.WriteString("💡 TIP: Consistent test naming helps teams navigate and understand test suites.\n")
-			sb.WriteString("   Orphaned test files often indicate outdated tests after refactoring. Clean\n")
-			sb.WriteString("   them up to maintain a clear relationship between code and tests.\n")
+			sb.WriteString(messages.Text("tip.naming"))
 		}
 	}
 
 	return sb.String()
 }
 
+// FixCode returns the stable code of the fix of a violation: the code of its violation
+// type and a short hash of the English fix text, e.g. "ARCH005-3f2a1c". The same fix has
+// the same code in every run and language, and the different fixes of one type (such as
+// the per-layer fixes of forbidden imports) have different codes. Returns "" for
+// violations without a type code or a fix.
+func FixCode(v Violation) string {
+	code, fix := v.GetCode(), v.GetFix()
+	if code == "" || fix == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(fix))
+	return code + "-" + hex.EncodeToString(sum[:3])
+}
+
+// fixFootnotes collects the fixes shared by several violations, so each of them is printed
+// once and the violations refer to its code. Returns the fix texts, in the language of
+// messages, by fix code and the shared codes in order.
+func fixFootnotes(violations []Violation, messages *Messages) (map[string]string, []string) {
+	counts := make(map[string]int)
+	texts := make(map[string]string)
+	for _, v := range violations {
+		code := FixCode(v)
+		if code == "" {
+			continue
+		}
+		counts[code]++
+		_, _, texts[code] = v.Localize(messages.translate)
+	}
+
+	var codes []string
+	for code, count := range counts {
+		if count > 1 {
+			codes = append(codes, code)
+		} else {
+			delete(texts, code)
+		}
+	}
+	sort.Strings(codes)
+	return texts, codes
+}
+
+// SourceLink expands a source link template for a file and line. The template may use
+// {ref}, {file} and {line}; for violations without a line, a "#..." fragment holding
+// {line} is dropped. Returns "" if the template is empty.
+func SourceLink(template, ref, file string, line int) string {
+	if template == "" {
+		return ""
+	}
+
+	link := template
+	if line > 0 {
+		link = strings.ReplaceAll(link, "{line}", fmt.Sprintf("%d", line))
+	} else {
+		if idx := strings.LastIndex(link, "#"); idx >= 0 && strings.Contains(link[idx:], "{line}") {
+			link = link[:idx]
+		}
+		link = strings.ReplaceAll(link, "{line}", "")
+	}
+	link = strings.ReplaceAll(link, "{ref}", ref)
+	return strings.ReplaceAll(link, "{file}", filepath.ToSlash(file))
+}
+
 // FormatViolations creates a formatted report of violations (without context)
 func FormatViolations(violations []Violation) string {
 	return FormatViolationsWithContext(violations, nil)
 }
 
+// packageStability returns the stability annotation declared by any file of a package
+func packageStability(files []FileWithAPI) string {
+	for _, file := range files {
+		if stability := file.GetStability(); stability != "" {
+			return stability
+		}
+	}
+	return ""
+}
+
+// packageDescription returns the package doc comment declared by any non-test file of a package
+func packageDescription(files []FileWithAPI) string {
+	for _, file := range files {
+		if strings.HasSuffix(file.GetRelPath(), "_test.go") {
+			continue
+		}
+		if doc := file.GetPackageDoc(); doc != "" {
+			return doc
+		}
+	}
+	return ""
+}
+
+// packageSynopsis returns the first sentence of a package description on a single line
+func packageSynopsis(description string) string {
+	// The first paragraph holds the summary
+	if idx := strings.Index(description, "\n\n"); idx >= 0 {
+		description = description[:idx]
+	}
+	description = strings.Join(strings.Fields(description), " ")
+
+	if idx := strings.Index(description, ". "); idx >= 0 {
+		description = description[:idx+1]
+	}
+	return description
+}
+
 // GenerateAPIMarkdown creates a markdown representation of public APIs by package
 func GenerateAPIMarkdown(files []FileWithAPI) string {
 	var sb strings.Builder
@@ -324,6 +507,16 @@ func GenerateAPIMarkdown(files []FileWithAPI) string {
 		}
 
 		sb.WriteString(fmt.Sprintf("## %s\n\n", pkg))
+		if description := packageDescription(files); description != "" {
+			sb.WriteString(description + "\n\n")
+		}
+		if stability := packageStability(files); stability != "" {
+			sb.WriteString(fmt.Sprintf("**Stability**: %s\n\n", stability))
+		}
+		examples := examplesBySymbol(files)
+		if len(examples[""]) > 0 {
+			sb.WriteString(fmt.Sprintf("**Examples**: %s\n\n", formatExamples(examples[""])))
+		}
 
 		// Sort declarations by name
 		sort.Slice(allDecls, func(i, j int) bool {
@@ -354,18 +547,9 @@ func GenerateAPIMarkdown(files []FileWithAPI) string {
 		standaloneFuncs := []ExportedDecl{}
 
 		for _, decl := range funcDecls {
-			sig := decl.GetSignature()
-			// Check if this is a method (starts with receiver like (*Type) or (Type))
-			if strings.HasPrefix(sig, "(") {
-				// Extract type name from receiver
-				endIdx := strings.Index(sig, ")")
-				if endIdx > 0 {
-					receiver := sig[1:endIdx]
-					// Remove pointer if present
-					typeName := strings.TrimPrefix(receiver, "*")
-					methodsByType[typeName] = append(methodsByType[typeName], decl)
-					continue
-				}
+			if typeName, ok := methodReceiver(decl.GetSignature()); ok {
+				methodsByType[typeName] = append(methodsByType[typeName], decl)
+				continue
 			}
 			standaloneFuncs = append(standaloneFuncs, decl)
 		}
@@ -379,9 +563,9 @@ func GenerateAPIMarkdown(files []FileWithAPI) string {
 
 				// Format type name (bold if has methods, italic if no methods)
 				if len(methods) > 0 {
-					sb.WriteString(fmt.Sprintf("- **%s**\n", typeDecl.GetName()))
+					sb.WriteString(withSummary(fmt.Sprintf("- **%s**", typeDisplayName(typeDecl)), typeDecl.GetDoc()) + "\n")
 				} else {
-					sb.WriteString(fmt.Sprintf("- *%s*\n", typeDecl.GetName()))
+					sb.WriteString(withSummary(fmt.Sprintf("- *%s*", typeDisplayName(typeDecl)), typeDecl.GetDoc()) + "\n")
 				}
 
 				// Show properties if any
@@ -396,18 +580,32 @@ func GenerateAPIMarkdown(files []FileWithAPI) string {
 				if len(methods) > 0 {
 					sb.WriteString("  - Methods:\n")
 					for _, method := range methods {
-						sb.WriteString(fmt.Sprintf("    - %s\n", method.GetSignature()))
+						sb.WriteString(withSummary("    - "+method.GetSignature(), method.GetDoc()) + "\n")
+						if methodExamples := examples[declSymbol(method)]; len(methodExamples) > 0 {
+							sb.WriteString(fmt.Sprintf("      - Examples: %s\n", formatExamples(methodExamples)))
+						}
 					}
 				}
+
+				// Show examples if any
+				if typeExamples := examples[typeDecl.GetName()]; len(typeExamples) > 0 {
+					sb.WriteString(fmt.Sprintf("  - Examples: %s\n", formatExamples(typeExamples)))
+				}
 			}
 			sb.WriteString("\n")
 		}
 
+		// Type hierarchy section
+		writeTypeHierarchy(&sb, typeDecls, "###")
+
 		// Package functions section
 		if len(standaloneFuncs) > 0 {
 			sb.WriteString("### Package Functions\n\n")
 			for _, decl := range standaloneFuncs {
-				sb.WriteString(fmt.Sprintf("- %s\n", decl.GetSignature()))
+				sb.WriteString(withSummary("- "+decl.GetSignature(), decl.GetDoc()) + "\n")
+				if funcExamples := examples[decl.GetName()]; len(funcExamples) > 0 {
+					sb.WriteString(fmt.Sprintf("  - Examples: %s\n", formatExamples(funcExamples)))
+				}
 			}
 			sb.WriteString("\n")
 		}
@@ -416,7 +614,7 @@ func GenerateAPIMarkdown(files []FileWithAPI) string {
 		if len(constDecls) > 0 {
 			sb.WriteString("### Constants\n\n")
 			for _, decl := range constDecls {
-				sb.WriteString(fmt.Sprintf("- %s\n", decl.GetName()))
+				sb.WriteString(withSummary("- "+decl.GetName(), decl.GetDoc()) + "\n")
 			}
 			sb.WriteString("\n")
 		}
@@ -425,7 +623,7 @@ func GenerateAPIMarkdown(files []FileWithAPI) string {
 		if len(varDecls) > 0 {
 			sb.WriteString("### Variables\n\n")
 			for _, decl := range varDecls {
-				sb.WriteString(fmt.Sprintf("- %s\n", decl.GetName()))
+				sb.WriteString(withSummary("- "+decl.GetName(), decl.GetDoc()) + "\n")
 			}
 			sb.WriteString("\n")
 		}
@@ -433,3 +631,12 @@ func GenerateAPIMarkdown(files []FileWithAPI) string {
 
 	return sb.String()
 }
+
+// typeDisplayName returns the name of a type declaration, including its type
+// parameters for generic types (e.g. "Cache[K comparable, V any]")
+func typeDisplayName(decl ExportedDecl) string {
+	if sig := decl.GetSignature(); strings.HasPrefix(sig, decl.GetName()+"[") {
+		return sig
+	}
+	return decl.GetName()
+}
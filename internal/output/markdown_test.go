@@ -10,16 +10,22 @@ import (
 // Test structs that implement output interfaces for testing
 
 type testDependency struct {
-	importPath  string
-	isLocal     bool
-	localPath   string
-	usedSymbols []string
+	importPath    string
+	isLocal       bool
+	localPath     string
+	usedSymbols   []string
+	wiring        bool
+	registrations []string
+	callSites     int
 }
 
 func (td *testDependency) GetImportPath() string   { return td.importPath }
 func (td *testDependency) IsLocalDep() bool        { return td.isLocal }
 func (td *testDependency) GetLocalPath() string    { return td.localPath }
 func (td *testDependency) GetUsedSymbols() []string { return td.usedSymbols }
+func (td *testDependency) GetCallSites() int        { return td.callSites }
+func (td *testDependency) IsRuntimeWiring() bool     { return td.wiring }
+func (td *testDependency) GetRegistrations() []string { return td.registrations }
 
 type testFileNode struct {
 	relPath      string
@@ -41,17 +47,47 @@ type testViolation struct {
 	violationType string
 	file          string
 	line          int
+	column        int
 	issue         string
 	rule          string
 	fix           string
+	severity      string
+	link          string
+	spans         []output.Span
+	source        string
+	ruleKey       string
+	code          string
 }
 
 func (tv *testViolation) GetType() string  { return tv.violationType }
 func (tv *testViolation) GetFile() string  { return tv.file }
 func (tv *testViolation) GetLine() int     { return tv.line }
+func (tv *testViolation) GetColumn() int   { return tv.column }
 func (tv *testViolation) GetIssue() string { return tv.issue }
 func (tv *testViolation) GetRule() string  { return tv.rule }
 func (tv *testViolation) GetFix() string   { return tv.fix }
+func (tv *testViolation) GetSeverity() string { return tv.severity }
+func (tv *testViolation) GetLink() string     { return tv.link }
+func (tv *testViolation) GetSpans() []output.Span { return tv.spans }
+func (tv *testViolation) GetSource() string       { return tv.source }
+func (tv *testViolation) GetRuleKey() string      { return tv.ruleKey }
+func (tv *testViolation) GetCode() string         { return tv.code }
+func (tv *testViolation) Localize(func(id, format string) string) (string, string, string) {
+	return tv.issue, tv.rule, tv.fix
+}
+
+type testSpan struct {
+	line   int
+	column int
+	issue  string
+}
+
+func (ts testSpan) GetLine() int     { return ts.line }
+func (ts testSpan) GetColumn() int   { return ts.column }
+func (ts testSpan) GetIssue() string { return ts.issue }
+func (ts testSpan) Localize(func(id, format string) string) string {
+	return ts.issue
+}
 
 func TestGenerateMarkdown_Basic(t *testing.T) {
 	g := &testGraph{
@@ -105,6 +141,30 @@ func TestGenerateMarkdown_Basic(t *testing.T) {
 	}
 }
 
+func TestGenerateMarkdown_CallSites(t *testing.T) {
+	g := &testGraph{
+		nodes: []output.FileNode{
+			&testFileNode{
+				relPath: "cmd/api/main.go",
+				pkg:     "main",
+				dependencies: []output.Dependency{
+					&testDependency{importPath: "github.com/test/project/pkg/service", isLocal: true, localPath: "pkg/service", usedSymbols: []string{"Run"}, callSites: 3},
+					&testDependency{importPath: "github.com/test/project/pkg/store", isLocal: true, localPath: "pkg/store", usedSymbols: []string{"Open"}, callSites: 1},
+					&testDependency{importPath: "github.com/external/lib", isLocal: false},
+				},
+			},
+		},
+	}
+
+	md := output.GenerateMarkdown(g)
+
+	for _, want := range []string{"  - local:pkg/service (3 call sites)\n", "  - local:pkg/store (1 call site)\n", "  - external:github.com/external/lib\n"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in markdown, got:\n%s", want, md)
+		}
+	}
+}
+
 func TestGenerateMarkdown_NoDependencies(t *testing.T) {
 	g := &testGraph{
 		nodes: []output.FileNode{
@@ -215,12 +275,129 @@ func TestFormatViolations_UnusedPackage(t *testing.T) {
 	}
 }
 
+func TestFormatViolations_SourceLink(t *testing.T) {
+	violations := []output.Violation{
+		&testViolation{
+			violationType: "Forbidden Import",
+			file:          "cmd/app/main.go",
+			line:          5,
+			issue:         "cmd/app imports internal/store",
+			link:          "https://github.com/org/repo/blob/abc123/cmd/app/main.go#L5",
+		},
+	}
+
+	result := output.FormatViolations(violations)
+
+	if !strings.Contains(result, "  File: cmd/app/main.go:5\n  Link: https://github.com/org/repo/blob/abc123/cmd/app/main.go#L5\n") {
+		t.Errorf("expected link after file, got:\n%s", result)
+	}
+}
+
+func TestFormatViolations_Column(t *testing.T) {
+	violations := []output.Violation{
+		&testViolation{
+			violationType: "Forbidden Import",
+			file:          "cmd/app/main.go",
+			line:          3,
+			column:        8,
+			issue:         "cmd/app imports internal/store",
+		},
+	}
+
+	result := output.FormatViolations(violations)
+
+	if !strings.Contains(result, "  File: cmd/app/main.go:3:8\n") {
+		t.Errorf("expected line and column after file, got:\n%s", result)
+	}
+}
+
+func TestFormatViolations_Spans(t *testing.T) {
+	violations := []output.Violation{
+		&testViolation{
+			violationType: "Forbidden Import",
+			file:          "internal/order/service.go",
+			line:          4,
+			column:        2,
+			issue:         "internal/order imports internal/store, internal/billing",
+			spans: []output.Span{
+				testSpan{line: 4, column: 2, issue: "internal/order imports internal/store"},
+				testSpan{line: 5, column: 2, issue: "internal/order imports internal/billing"},
+			},
+		},
+	}
+
+	result := output.FormatViolations(violations)
+
+	expected := "  Locations:\n" +
+		"    internal/order/service.go:4:2 internal/order imports internal/store\n" +
+		"    internal/order/service.go:5:2 internal/order imports internal/billing\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected locations of all imports, got:\n%s", result)
+	}
+	if strings.Count(result, "[ERROR]") != 1 {
+		t.Errorf("expected a single violation, got:\n%s", result)
+	}
+}
+
+func TestFormatViolations_Source(t *testing.T) {
+	violations := []output.Violation{
+		&testViolation{
+			violationType: "Forbidden Import",
+			file:          "cmd/app/main.go",
+			issue:         "cmd/app imports internal/store",
+			rule:          "cmd can only import from: [pkg]",
+			source:        "overrides (rules.directories_import.cmd)",
+		},
+		&testViolation{
+			violationType: "Forbidden Import",
+			file:          "cmd/tool/main.go",
+			issue:         "cmd/tool imports internal/store",
+			rule:          "cmd can only import from: [pkg]",
+		},
+	}
+
+	result := output.FormatViolations(violations)
+
+	if !strings.Contains(result, "  Rule: cmd can only import from: [pkg]\n  Source: overrides (rules.directories_import.cmd)\n") {
+		t.Errorf("expected source after rule, got:\n%s", result)
+	}
+	if strings.Count(result, "Source:") != 1 {
+		t.Errorf("expected no source line for violation without source, got:\n%s", result)
+	}
+}
+
+func TestSourceLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		file     string
+		line     int
+		expected string
+	}{
+		{"with line", "https://github.com/org/repo/blob/{ref}/{file}#L{line}", "pkg/a.go", 12, "https://github.com/org/repo/blob/main/pkg/a.go#L12"},
+		{"without line drops fragment", "https://github.com/org/repo/blob/{ref}/{file}#L{line}", "pkg", 0, "https://github.com/org/repo/blob/main/pkg"},
+		{"query parameter line", "https://git.example.com/repo/src/{file}?at={ref}&line={line}", "pkg/a.go", 0, "https://git.example.com/repo/src/pkg/a.go?at=main&line="},
+		{"no template", "", "pkg/a.go", 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := output.SourceLink(tt.template, "main", tt.file, tt.line); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
 // Test adapter for FileWithAPI
 type testFileWithAPI struct {
-	relPath   string
-	pkg       string
-	decls     []output.ExportedDecl
-	lineCount int
+	relPath    string
+	pkg        string
+	decls      []output.ExportedDecl
+	lineCount  int
+	stability  string
+	packageDoc string
+	examples   []output.Example
 }
 
 func (tf *testFileWithAPI) GetRelPath() string {
@@ -239,12 +416,28 @@ func (tf *testFileWithAPI) GetLineCount() int {
 	return tf.lineCount
 }
 
+func (tf *testFileWithAPI) GetStability() string {
+	return tf.stability
+}
+
+func (tf *testFileWithAPI) GetPackageDoc() string {
+	return tf.packageDoc
+}
+
+func (tf *testFileWithAPI) GetExamples() []output.Example {
+	return tf.examples
+}
+
 // Test adapter for ExportedDecl
 type testExportedDecl struct {
 	name       string
 	kind       string
 	signature  string
 	properties []string
+	typeKind   string
+	embeds     []string
+	doc        string
+	line       int
 }
 
 func (te *testExportedDecl) GetName() string {
@@ -263,6 +456,22 @@ func (te *testExportedDecl) GetProperties() []string {
 	return te.properties
 }
 
+func (te *testExportedDecl) GetTypeKind() string {
+	return te.typeKind
+}
+
+func (te *testExportedDecl) GetEmbeds() []string {
+	return te.embeds
+}
+
+func (te *testExportedDecl) GetDoc() string {
+	return te.doc
+}
+
+func (te *testExportedDecl) GetLine() int {
+	return te.line
+}
+
 func TestGenerateAPIMarkdown_Basic(t *testing.T) {
 	files := []output.FileWithAPI{
 		&testFileWithAPI{
@@ -341,6 +550,63 @@ func TestGenerateAPIMarkdown_Basic(t *testing.T) {
 	}
 }
 
+func TestGenerateAPIMarkdown_Stability(t *testing.T) {
+	files := []output.FileWithAPI{
+		&testFileWithAPI{
+			relPath:   "pkg/client/doc.go",
+			pkg:       "client",
+			stability: "experimental",
+		},
+		&testFileWithAPI{
+			relPath: "pkg/client/client.go",
+			pkg:     "client",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "Client", kind: "type", signature: "Client"},
+			},
+		},
+		&testFileWithAPI{
+			relPath: "pkg/server/server.go",
+			pkg:     "server",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "Server", kind: "type", signature: "Server"},
+			},
+		},
+	}
+
+	result := output.GenerateAPIMarkdown(files)
+
+	if !strings.Contains(result, "## client\n\n**Stability**: experimental\n\n") {
+		t.Errorf("expected stability under client package, got:\n%s", result)
+	}
+	if strings.Count(result, "**Stability**") != 1 {
+		t.Errorf("expected stability only for annotated packages, got:\n%s", result)
+	}
+}
+
+func TestGenerateAPIMarkdown_PackageDescription(t *testing.T) {
+	files := []output.FileWithAPI{
+		&testFileWithAPI{
+			relPath:    "pkg/client/doc.go",
+			pkg:        "client",
+			packageDoc: "Package client talks to the API.\n\nRequests are retried.",
+			stability:  "stable",
+		},
+		&testFileWithAPI{
+			relPath: "pkg/client/client.go",
+			pkg:     "client",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "Client", kind: "type", signature: "Client"},
+			},
+		},
+	}
+
+	result := output.GenerateAPIMarkdown(files)
+
+	if !strings.Contains(result, "## client\n\nPackage client talks to the API.\n\nRequests are retried.\n\n**Stability**: stable\n\n") {
+		t.Errorf("expected full package description before stability, got:\n%s", result)
+	}
+}
+
 func TestGenerateAPIMarkdown_MultiplePackages(t *testing.T) {
 	files := []output.FileWithAPI{
 		&testFileWithAPI{
@@ -460,6 +726,33 @@ func TestGenerateAPIMarkdown_OnlyFunctions(t *testing.T) {
 	}
 }
 
+func TestGenerateAPIMarkdown_GenericTypes(t *testing.T) {
+	files := []output.FileWithAPI{
+		&testFileWithAPI{
+			relPath: "pkg/cache/cache.go",
+			pkg:     "cache",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "Cache", kind: "type", signature: "Cache[K comparable, V any]", typeKind: "struct"},
+				&testExportedDecl{name: "Get", kind: "func", signature: "(*Cache[K, V]) Get(K) (V, bool)"},
+				&testExportedDecl{name: "Keys", kind: "func", signature: "Keys[K comparable, V any](*Cache[K, V]) []K"},
+			},
+		},
+	}
+
+	result := output.GenerateAPIMarkdown(files)
+
+	// Generic type is shown with its type parameters and owns its methods
+	if !strings.Contains(result, "- **Cache[K comparable, V any]**") {
+		t.Errorf("expected generic type with type parameters, got:\n%s", result)
+	}
+	if !strings.Contains(result, "    - (*Cache[K, V]) Get(K) (V, bool)") {
+		t.Errorf("expected method grouped under generic type, got:\n%s", result)
+	}
+	if !strings.Contains(result, "- Keys[K comparable, V any](*Cache[K, V]) []K") {
+		t.Errorf("expected generic function with full signature, got:\n%s", result)
+	}
+}
+
 func TestGenerateFullDocumentation_Complete(t *testing.T) {
 	doc := output.FullDocumentation{
 		Structure: output.StructureInfo{
@@ -599,6 +892,46 @@ func TestGenerateFullDocumentation_WithViolations(t *testing.T) {
 	}
 }
 
+func TestGenerateFullDocumentation_StatusHeader(t *testing.T) {
+	coverage := 72
+	doc := output.FullDocumentation{
+		Graph:          &testGraph{nodes: []output.FileNode{}},
+		Files:          []output.FileWithAPI{},
+		ViolationCount: 2,
+		Score:          &output.Score{Score: 91, Coverage: &coverage},
+		Version:        "1.4.0",
+	}
+
+	result := output.GenerateFullDocumentation(doc)
+
+	for _, want := range []string{
+		"![violations: 2](https://img.shields.io/badge/violations-2-red)",
+		"![coverage: 72%](https://img.shields.io/badge/coverage-72%25-yellow)",
+		"![conformance: 91/100](https://img.shields.io/badge/conformance-91%2F100-brightgreen)",
+		"![go-arch-lint: 1.4.0](https://img.shields.io/badge/go--arch--lint-1.4.0-blue)",
+		"<!-- go-arch-lint-status violations=2 coverage=72 score=91 version=1.4.0 generated=",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in the status header, got:\n%s", want, result)
+		}
+	}
+	if !strings.Contains(result, "![generated: ") {
+		t.Errorf("expected the generation time in the status header, got:\n%s", result)
+	}
+	if strings.Index(result, "go-arch-lint-status") > strings.Index(result, "## Table of Contents") {
+		t.Errorf("expected the status header before the table of contents, got:\n%s", result)
+	}
+
+	// Values that are unknown are left out
+	result = output.GenerateFullDocumentation(output.FullDocumentation{Graph: &testGraph{}, Files: []output.FileWithAPI{}})
+	if strings.Contains(result, "coverage") || strings.Contains(result, "conformance") || strings.Contains(result, "version=") {
+		t.Errorf("expected only the violations and generation time without a score and version, got:\n%s", result)
+	}
+	if !strings.Contains(result, "![violations: 0](https://img.shields.io/badge/violations-0-brightgreen)") {
+		t.Errorf("expected a green violations badge, got:\n%s", result)
+	}
+}
+
 func TestGenerateFullDocumentation_NoRequiredDirs(t *testing.T) {
 	doc := output.FullDocumentation{
 		Structure: output.StructureInfo{
@@ -871,3 +1204,44 @@ func TestFormatViolationsWithContext_MixedViolations(t *testing.T) {
 		t.Error("missing mixed violations tip")
 	}
 }
+
+func TestFormatViolations_FixFootnotes(t *testing.T) {
+	violations := []output.Violation{
+		&testViolation{violationType: "Forbidden Import", file: "cmd/app/main.go", issue: "cmd/app imports internal/c", fix: "Go through pkg/ instead", code: "ARCH005"},
+		&testViolation{violationType: "Forbidden Import", file: "internal/a/a.go", issue: "internal/a imports internal/c", fix: "Depend on an interface instead", code: "ARCH005"},
+		&testViolation{violationType: "Missing Package Doc", file: "internal/b", issue: "internal/b has no package doc", fix: "Add a package comment", code: "ARCH027"},
+		&testViolation{violationType: "Forbidden Import", file: "internal/b/b.go", issue: "internal/b imports internal/c", fix: "Depend on an interface instead", code: "ARCH005"},
+		&testViolation{violationType: "Forbidden Import", file: "cmd/tool/main.go", issue: "cmd/tool imports internal/c", fix: "Go through pkg/ instead", code: "ARCH005"},
+	}
+	layerCode, cmdCode := output.FixCode(violations[1]), output.FixCode(violations[0])
+
+	// Each fix text has its own code, derived from the type and the text
+	if !strings.HasPrefix(layerCode, "ARCH005-") || !strings.HasPrefix(cmdCode, "ARCH005-") || layerCode == cmdCode {
+		t.Fatalf("expected distinct ARCH005 fix codes, got %q and %q", layerCode, cmdCode)
+	}
+	if output.FixCode(violations[3]) != layerCode {
+		t.Errorf("expected the same fix to have the same code")
+	}
+
+	result := output.FormatViolations(violations)
+
+	for code, fix := range map[string]string{layerCode: "Depend on an interface instead", cmdCode: "Go through pkg/ instead"} {
+		if strings.Count(result, fix) != 1 {
+			t.Errorf("expected the shared fix %q to be printed once, got:\n%s", fix, result)
+		}
+		if strings.Count(result, "  Fix: see "+code+"\n") != 2 {
+			t.Errorf("expected two violations to reference %s, got:\n%s", code, result)
+		}
+		if !strings.Contains(result, "  "+code+": "+fix+"\n") {
+			t.Errorf("missing the footnote of %s, got:\n%s", code, result)
+		}
+	}
+	if !strings.Contains(result, "\nFIXES\n") {
+		t.Errorf("missing the footnotes section, got:\n%s", result)
+	}
+
+	// A fix used by one violation only stays inline
+	if !strings.Contains(result, "  Fix: Add a package comment\n") || strings.Contains(result, "ARCH027") {
+		t.Errorf("expected the unique fix inline, got:\n%s", result)
+	}
+}
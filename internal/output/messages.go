@@ -0,0 +1,195 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultLanguage is the language of the built-in messages, used when none is selected
+const DefaultLanguage = "en"
+
+// catalogs holds the messages of every supported language by message ID. Languages
+// other than English may leave IDs out; those fall back to the English text.
+var catalogs = map[string]map[string]string{
+	"en": messagesEN,
+	"de": messagesDE,
+}
+
+// messagesEN is the English message catalog; it defines every message ID except those
+// of violation types ("type.<code>") and violation texts ("violation.…"), which are
+// defined in English where the violations are reported
+var messagesEN = map[string]string{
+	// Violation report
+	"report.banner":      "ARCHITECTURAL VIOLATIONS DETECTED",
+	"report.preset":      "This project uses the '%s' architectural preset.\n",
+	"report.intro":       "The violations below indicate that the current structure does not align with\nthe target architecture. Please review the architectural goals and refactoring\nguidance to understand how to properly restructure the code.\n\n",
+	"report.goals":       "ARCHITECTURAL GOALS",
+	"report.principles":  "KEY PRINCIPLES",
+	"report.violations":  "VIOLATIONS",
+	"report.header":      "DEPENDENCY VIOLATIONS DETECTED",
+	"report.file":        "File",
+	"report.link":        "Link",
+	"report.locations":   "Locations",
+	"report.issue":       "Issue",
+	"report.rule":        "Rule",
+	"report.source":      "Source",
+	"report.fix":         "Fix",
+	"report.fix_ref":     "see %s",
+	"report.fixes":       "FIXES",
+	"guidance.refactor":  "REFACTORING GUIDANCE",
+	"guidance.coverage":  "TEST COVERAGE GUIDANCE",
+	"guidance.naming":    "TEST NAMING GUIDANCE",
+	"guidance.blackbox":  "BLACKBOX TESTING GUIDANCE",
+	"tip.tests_and_arch": "💡 TIP: Address architectural violations first, then improve test quality and coverage.\n   Good tests verify correct behavior - make sure the architecture is sound\n   before investing in comprehensive test coverage.\n",
+	"tip.architecture":   "💡 TIP: These violations show architectural misalignment, not just linter errors.\n   Focus on understanding WHY the target architecture matters, then refactor\n   accordingly. Don't just move code to make the linter happy - restructure\n   to achieve the architectural goals described above.\n",
+	"tip.blackbox_first": "💡 TIP: Start with blackbox testing, then improve coverage and naming.\n   Blackbox tests (package foo_test) are more resilient to refactoring and\n   encourage better API design. After converting to blackbox, focus on coverage.\n",
+	"tip.blackbox":       "💡 TIP: Blackbox testing improves test resilience and API design.\n   Tests using 'package foo_test' verify behavior through the public interface,\n   making them more maintainable and resilient to internal refactoring.\n",
+	"tip.coverage":       "💡 TIP: Test coverage ensures your code works correctly and can be refactored safely.\n   Focus on testing critical paths and business logic first. Use coverage\n   reports to identify untested code, then write tests that verify behavior.\n",
+	"tip.naming":         "💡 TIP: Consistent test naming helps teams navigate and understand test suites.\n   Orphaned test files often indicate outdated tests after refactoring. Clean\n   them up to maintain a clear relationship between code and tests.\n",
+
+	// Conformance score
+	"score.summary":          "Conformance score: %d/100 (violations %d, coupling %d)",
+	"score.summary_coverage": "Conformance score: %d/100 (violations %d, coverage %d, coupling %d)",
+	"score.below_minimum":    "Conformance score %d is below the required minimum of %d",
+
+	// Full documentation
+	"docs.title":          "Project Architecture",
+	"docs.generated":      "Generated by go-arch-lint on %s",
+	"docs.toc":            "Table of Contents",
+	"docs.structure":      "Project Structure",
+	"docs.rules":          "Architectural Rules",
+	"docs.dependencies":   "Dependency Graph",
+	"docs.binaries":       "Binaries",
+	"docs.wiring":         "Runtime Wiring",
+	"docs.roots":          "Composition Root",
+	"docs.api":            "Public API",
+	"docs.glossary":       "Glossary",
+	"docs.ports":          "Ports and Adapters",
+	"docs.statistics":     "Statistics",
+	"docs.binaries_intro": "Main packages and the local packages each program is built from:",
+	"docs.wiring_intro":   "Blank imports that wire packages in through their init functions (drivers, decoders, plugins), with what they register. No symbol of these packages is used, so the dependency is easy to miss:",
+	"docs.roots_intro":    "Components the main packages and the dependency injection wiring (wire, fx, dig) construct (results of constructors named New… or Open…, composite literals of imported types), each with the components injected into it. Components marked provided are registered with a DI container, which injects their dependencies by type:",
+	"docs.glossary_intro": "Domain terms (exported structs and interfaces of domain packages) with their doc comments:",
+	"docs.ports_intro":    "Interfaces of port and domain packages and the concrete types implementing them:",
+	"docs.total_files":    "Total Files",
+	"docs.total_packages": "Total Packages",
+	"docs.total_lines":    "Total Lines",
+	"docs.layer_sizes":    "| Layer | Packages | Files | Lines | Size |",
+	"docs.no_layer":       "*(no layer)*",
+	"docs.required_dirs":  "Required Directories",
+	"docs.present":        "%d/%d present",
+	"docs.violations":     "Violations",
+	"docs.score":          "Conformance Score",
+	"docs.external_deps":  "External Dependencies",
+	"docs.footer":         "This documentation is auto-generated. To regenerate: `go-arch-lint -format=full .` or `go-arch-lint -format=docs .`",
+}
+
+// Messages holds the texts of one language. A nil *Messages yields the English texts.
+type Messages struct {
+	lang  string
+	texts map[string]string
+}
+
+// NewMessages returns the messages of a language; an empty language selects English
+func NewMessages(lang string) (*Messages, error) {
+	if lang == "" {
+		lang = DefaultLanguage
+	}
+	texts, ok := catalogs[lang]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language %q (available: %s)", lang, strings.Join(Languages(), ", "))
+	}
+	return &Messages{lang: lang, texts: texts}, nil
+}
+
+// Languages returns the supported languages, sorted
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// LanguageFromLocale returns the supported language of a POSIX locale such as
+// "de_DE.UTF-8", or DefaultLanguage for "C", "POSIX", empty and unsupported locales
+func LanguageFromLocale(locale string) string {
+	lang := strings.ToLower(locale)
+	if idx := strings.IndexAny(lang, "_-.@"); idx >= 0 {
+		lang = lang[:idx]
+	}
+	if _, ok := catalogs[lang]; ok {
+		return lang
+	}
+	return DefaultLanguage
+}
+
+// Text returns the message with the given ID, formatted with args if any
+func (m *Messages) Text(id string, args ...any) string {
+	text, ok := "", false
+	if m != nil {
+		text, ok = m.texts[id]
+	}
+	if !ok {
+		text = messagesEN[id]
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// translate returns the translation of a violation message format by its message ID, or
+// format itself if the catalog has none. Violation texts are defined in English where
+// they are reported, so the English catalog does not list them.
+func (m *Messages) translate(id, format string) string {
+	if m != nil {
+		if text, ok := m.texts[id]; ok {
+			return text
+		}
+	}
+	return format
+}
+
+// violationType returns the name of the type of a violation, translated by its code
+func (m *Messages) violationType(v Violation) string {
+	if m != nil && v.GetCode() != "" {
+		if text, ok := m.texts["type."+v.GetCode()]; ok {
+			return text
+		}
+	}
+	return v.GetType()
+}
+
+// messagesFor returns the messages of a language, falling back to English for
+// unsupported ones; callers that take the language from users validate it first
+func messagesFor(lang string) *Messages {
+	messages, err := NewMessages(lang)
+	if err != nil {
+		return nil
+	}
+	return messages
+}
+
+// boxHeader returns the top border of a report box titled title
+func boxHeader(title string) string {
+	width := 77 - len([]rune(title))
+	return "┌─ " + title + " " + strings.Repeat("─", max(width, 0)) + "┐\n"
+}
+
+// headingAnchor returns the anchor GitHub generates for a markdown heading
+func headingAnchor(heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,312 @@
+package output
+
+// messagesDE is the German message catalog
+var messagesDE = map[string]string{
+	// Violation report
+	"report.banner":      "ARCHITEKTURVERSTÖSSE ERKANNT",
+	"report.preset":      "Dieses Projekt verwendet das Architektur-Preset '%s'.\n",
+	"report.intro":       "Die folgenden Verstöße zeigen, dass die aktuelle Struktur nicht der\nZielarchitektur entspricht. Bitte lies die Architekturziele und die Hinweise\nzum Refactoring, um den Code sinnvoll umzustrukturieren.\n\n",
+	"report.goals":       "ARCHITEKTURZIELE",
+	"report.principles":  "GRUNDPRINZIPIEN",
+	"report.violations":  "VERSTÖSSE",
+	"report.header":      "ABHÄNGIGKEITSVERSTÖSSE ERKANNT",
+	"report.file":        "Datei",
+	"report.link":        "Link",
+	"report.locations":   "Stellen",
+	"report.issue":       "Problem",
+	"report.rule":        "Regel",
+	"report.source":      "Quelle",
+	"report.fix":         "Behebung",
+	"report.fix_ref":     "siehe %s",
+	"report.fixes":       "BEHEBUNGEN",
+	"guidance.refactor":  "HINWEISE ZUM REFACTORING",
+	"guidance.coverage":  "HINWEISE ZUR TESTABDECKUNG",
+	"guidance.naming":    "HINWEISE ZUR TESTBENENNUNG",
+	"guidance.blackbox":  "HINWEISE ZU BLACKBOX-TESTS",
+	"tip.tests_and_arch": "💡 TIPP: Behebe zuerst die Architekturverstöße, dann Testqualität und -abdeckung.\n   Gute Tests prüfen korrektes Verhalten - stelle sicher, dass die Architektur\n   stimmt, bevor du in umfassende Testabdeckung investierst.\n",
+	"tip.architecture":   "💡 TIPP: Diese Verstöße zeigen Abweichungen von der Architektur, nicht nur Linterfehler.\n   Mach dir klar, WARUM die Zielarchitektur wichtig ist, und refaktoriere\n   entsprechend. Verschiebe Code nicht nur, um den Linter zufriedenzustellen -\n   strukturiere so um, dass die oben beschriebenen Architekturziele erreicht werden.\n",
+	"tip.blackbox_first": "💡 TIPP: Beginne mit Blackbox-Tests, verbessere danach Abdeckung und Benennung.\n   Blackbox-Tests (package foo_test) überstehen Refactorings besser und fördern\n   ein gutes API-Design. Kümmere dich nach der Umstellung um die Abdeckung.\n",
+	"tip.blackbox":       "💡 TIPP: Blackbox-Tests machen Tests robuster und verbessern das API-Design.\n   Tests mit 'package foo_test' prüfen das Verhalten über die öffentliche\n   Schnittstelle und überstehen interne Refactorings.\n",
+	"tip.coverage":       "💡 TIPP: Testabdeckung sichert, dass der Code korrekt ist und gefahrlos refaktoriert werden kann.\n   Teste zuerst kritische Pfade und Geschäftslogik. Finde ungetesteten Code mit\n   Abdeckungsberichten und schreibe dann Tests, die das Verhalten prüfen.\n",
+	"tip.naming":         "💡 TIPP: Einheitliche Testnamen helfen Teams, sich in Testsuiten zurechtzufinden.\n   Verwaiste Testdateien sind nach Refactorings oft veraltet. Räume sie auf,\n   damit die Zuordnung zwischen Code und Tests klar bleibt.\n",
+
+	// Conformance score
+	"score.summary":          "Konformitätswert: %d/100 (Verstöße %d, Kopplung %d)",
+	"score.summary_coverage": "Konformitätswert: %d/100 (Verstöße %d, Abdeckung %d, Kopplung %d)",
+	"score.below_minimum":    "Konformitätswert %d liegt unter dem geforderten Minimum von %d",
+
+	// Full documentation
+	"docs.title":          "Projektarchitektur",
+	"docs.generated":      "Erstellt von go-arch-lint am %s",
+	"docs.toc":            "Inhaltsverzeichnis",
+	"docs.structure":      "Projektstruktur",
+	"docs.rules":          "Architekturregeln",
+	"docs.dependencies":   "Abhängigkeitsgraph",
+	"docs.binaries":       "Programme",
+	"docs.wiring":         "Laufzeitverdrahtung",
+	"docs.roots":          "Composition Root",
+	"docs.api":            "Öffentliche API",
+	"docs.glossary":       "Glossar",
+	"docs.ports":          "Ports und Adapter",
+	"docs.statistics":     "Statistik",
+	"docs.binaries_intro": "Main-Pakete und die lokalen Pakete, aus denen jedes Programm gebaut wird:",
+	"docs.wiring_intro":   "Blank-Importe, die Pakete über ihre init-Funktionen einbinden (Treiber, Decoder, Plugins), mit dem, was sie registrieren. Kein Symbol dieser Pakete wird verwendet, daher wird die Abhängigkeit leicht übersehen:",
+	"docs.roots_intro":    "Komponenten, die die Main-Pakete und die Dependency-Injection-Verdrahtung (wire, fx, dig) erzeugen (Ergebnisse von Konstruktoren namens New… oder Open…, Composite-Literale importierter Typen), jeweils mit den Komponenten, die in sie injiziert werden. Als provided markierte Komponenten sind bei einem DI-Container registriert, der ihre Abhängigkeiten anhand des Typs injiziert:",
+	"docs.glossary_intro": "Fachbegriffe (exportierte Structs und Interfaces der Domain-Pakete) mit ihren Doc-Kommentaren:",
+	"docs.ports_intro":    "Interfaces der Port- und Domain-Pakete und die konkreten Typen, die sie implementieren:",
+	"docs.total_files":    "Dateien gesamt",
+	"docs.total_packages": "Pakete gesamt",
+	"docs.total_lines":    "Zeilen gesamt",
+	"docs.layer_sizes":    "| Schicht | Pakete | Dateien | Zeilen | Größe |",
+	"docs.no_layer":       "*(keine Schicht)*",
+	"docs.required_dirs":  "Pflichtverzeichnisse",
+	"docs.present":        "%d/%d vorhanden",
+	"docs.violations":     "Verstöße",
+	"docs.score":          "Konformitätswert",
+	"docs.external_deps":  "Externe Abhängigkeiten",
+	"docs.footer":         "Diese Dokumentation wird automatisch erzeugt. Neu erzeugen mit: `go-arch-lint -format=full .` oder `go-arch-lint -format=docs .`",
+
+	// Violation types, by code
+	"type.ARCH001": "Verbotene pkg-zu-pkg-Abhängigkeit",
+	"type.ARCH002": "Ebenen überspringender Import",
+	"type.ARCH003": "cmd-übergreifende Abhängigkeit",
+	"type.ARCH004": "Ungenutztes Paket",
+	"type.ARCH005": "Verbotener Import",
+	"type.ARCH006": "Fehlendes Pflichtverzeichnis",
+	"type.ARCH007": "Unerwartetes Verzeichnis",
+	"type.ARCH008": "Leeres Pflichtverzeichnis",
+	"type.ARCH009": "Ungenutztes Pflichtverzeichnis",
+	"type.ARCH010": "Geteilter externer Import",
+	"type.ARCH011": "Testdatei am falschen Ort",
+	"type.ARCH012": "Whitebox-Test",
+	"type.ARCH013": "Unzureichende Testabdeckung",
+	"type.ARCH014": "Verstoß gegen Testbenennung",
+	"type.ARCH015": "Doppelte Definition",
+	"type.ARCH016": "Externer Import ohne Wrapper",
+	"type.ARCH017": "Umgehung der Anti-Corruption-Schicht",
+	"type.ARCH018": "Typleck in exportierter Signatur",
+	"type.ARCH019": "Verstreutes Laden der Konfiguration",
+	"type.ARCH020": "Framework-Lock-in",
+	"type.ARCH021": "Parserfehler",
+	"type.ARCH022": "Mehrere Pakete im Verzeichnis",
+	"type.ARCH023": "Paketname passt nicht zum Verzeichnis",
+	"type.ARCH024": "Stabiles Paket importiert experimentelles",
+	"type.ARCH025": "Neue Verwendung eines veralteten Symbols",
+	"type.ARCH026": "Teamübergreifender Zugriff auf Interna",
+	"type.ARCH027": "Fehlende Paketdokumentation",
+	"type.ARCH028": "Testfixture außerhalb von testdata",
+	"type.ARCH029": "Testaufbau über Paketinterna",
+	"type.ARCH030": "Test überschreitet Unit-Umfang",
+	"type.ARCH031": "Produktionsimport einer Testhilfe",
+	"type.ARCH032": "Nicht gelistetes Fixture-Paket",
+	"type.ARCH033": "Main-Paket außerhalb erlaubter Orte",
+	"type.ARCH034": "Verwaistes Interface",
+	"type.ARCH035": "Adapter implementiert keinen Port",
+	"type.ARCH036": "Fehlende Adapter-Zusicherung",
+	"type.ARCH037": "Zyklische Schichtregeln",
+	"type.ARCH038": "Fehlende Schicht-README",
+	"type.ARCH039": "Versteckte Abhängigkeit",
+	"type.ARCH040": "Unzulässige Lizenz einer Abhängigkeit",
+	"type.ARCH041": "Mehrere Hauptversionen",
+	"type.ARCH042": "Replace auf lokales Verzeichnis oder Fork",
+	"type.ARCH043": "Externer Import zu tief",
+	"type.ARCH044": "Reihenfolgeabhängiger Test",
+	"type.ARCH045": "Data Race",
+
+	// Violation texts
+	"violation.import.issue":                          "%s importiert %s",
+	"violation.cross_cmd.rule":                        "cmd-Pakete dürfen keine anderen cmd-Pakete importieren",
+	"violation.cross_cmd.fix":                         "Lagere gemeinsamen Code nach pkg/ oder internal/ aus",
+	"violation.pkg_to_pkg.rule":                       "pkg-Pakete dürfen keine anderen pkg-Pakete importieren (außer eigenen Unterpaketen)",
+	"violation.pkg_to_pkg.fix":                        "Importiere aus internal/ oder definiere das Interface lokal",
+	"violation.skip_level.rule":                       "Nur direkte Unterpakete dürfen importiert werden, keine tiefer verschachtelten",
+	"violation.skip_level.fix":                        "Importiere stattdessen %s",
+	"violation.forbidden.rule":                        "%s darf nur importieren aus: %v",
+	"violation.forbidden.rule_default_policy":         "%s hat keine directories_import-Regel und default_policy ist deny",
+	"violation.forbidden.fix":                         "Strukturiere die Abhängigkeiten gemäß den erlaubten Importen um",
+	"violation.forbidden.fix_internal":                "Verwende Interfaces und Dependency Inversion statt direkter Importe",
+	"violation.forbidden.fix_default_policy":          "Lege eine directories_import-Regel für %s an, die auflistet, was es importieren darf",
+	"violation.unused.issue":                          "Paket %s wird von keinem cmd/-Paket importiert",
+	"violation.unused.rule":                           "Alle Pakete sollten transitiv aus cmd/ importiert werden",
+	"violation.unused.fix":                            "Entferne das Paket oder importiere es aus cmd/",
+	"violation.required_directory.rule":               "Zweck des Verzeichnisses: %s",
+	"violation.missing_directory.issue":               "Pflichtverzeichnis '%s' existiert nicht",
+	"violation.missing_directory.fix":                 "Lege das Verzeichnis an: mkdir -p %s",
+	"violation.missing_directory.issue_not_directory": "'%s' existiert, ist aber kein Verzeichnis",
+	"violation.missing_directory.fix_not_directory":   "Entferne die Datei und lege das Verzeichnis an: rm %s && mkdir -p %s",
+	"violation.unexpected_directory.issue":            "Verzeichnis '%s' enthält Go-Code, gehört aber nicht zur vorgegebenen Struktur",
+	"violation.unexpected_directory.rule":             "allow_other_directories ist false - nur Pflichtverzeichnisse sind erlaubt",
+	"violation.unexpected_directory.fix":              "Verschiebe den Code in ein Pflichtverzeichnis oder ergänze '%s: \"<Zweck>\"' in structure.required_directories und eine directories_import-Regel für %s in .goarchlint",
+	"violation.empty_directory.issue":                 "Pflichtverzeichnis '%s' existiert, enthält aber keine Go-Pakete",
+	"violation.empty_directory.fix":                   "Lege das erste Paket in %s an oder entferne es aus required_directories, falls die Schicht nicht gebraucht wird",
+	"violation.unused_directory.issue":                "Pflichtverzeichnis '%s' enthält keine gescannten Go-Dateien",
+	"violation.unused_directory.fix":                  "Lege Go-Code in %s an oder entferne es aus required_directories",
+	"violation.shared_external_import.issue":          "Externes Paket '%s' wird von %d Schichten importiert\n  Importiert von:\n    - %s",
+	"violation.shared_external_import.location":       "%s (Schicht: %s)",
+	"violation.shared_external_import.rule":           "Externe Pakete sollten in der Regel genau einer Schicht gehören",
+	"violation.shared_external_import.fix":            "Entweder: (1) '%s' zu shared_external_imports.exclusions hinzufügen, falls es eine Hilfsbibliothek ist, oder (2) die Verwendung in einer Schicht bündeln",
+	"violation.test_file_location.issue":              "Testdatei liegt in einem separaten tests/-Verzeichnis",
+	"violation.test_file_location.rule":               "Testdateien sollten neben dem getesteten Code liegen (location: colocated)",
+	"violation.test_file_location.fix":                "Verschiebe die Testdatei in das Verzeichnis des getesteten Codes",
+	"violation.test_file_location.issue_separate":     "Testdatei liegt neben dem Code statt im tests/-Verzeichnis",
+	"violation.test_file_location.rule_separate":      "Testdateien sollten in einem separaten tests/-Verzeichnis liegen (location: separate)",
+	"violation.test_file_location.fix_separate":       "Verschiebe die Testdatei in das tests/-Verzeichnis, gespiegelt zur Quellstruktur",
+	"violation.whitebox_test.issue":                   "Testdatei verwendet Whitebox-Tests (package %s statt %s)",
+	"violation.whitebox_test.rule":                    "Blackbox-Tests sind vorgeschrieben, damit Tests die öffentliche API prüfen und nicht die interne Implementierung",
+	"violation.whitebox_test.fix":                     "Ändere die Paketdeklaration von 'package %s' zu 'package %s' oder nimm das Paket mit Begründung in test_files.allow_whitebox auf",
+	"violation.low_coverage.issue":                    "Testabdeckung des Pakets %.1f%% liegt unter dem Schwellwert %.0f%%",
+	"violation.low_coverage.issue_no_tests":           "Paket hat keine Tests (0%% Abdeckung, Schwellwert: %.0f%%)",
+	"violation.low_coverage.rule":                     "Mindestabdeckung durch Tests: %.0f%% (hierarchischer Schwellwert)",
+	"violation.low_coverage.fix": `Verbessere die Testabdeckung dieses Pakets:
+1. Zeige die aktuelle Abdeckung mit 'go test -cover %s' an
+2. Zeige die Abdeckung im Detail mit 'go test -coverprofile=coverage.out %s && go tool cover -html=coverage.out' an
+3. Schreibe Tests für nicht abgedeckte Codepfade
+4. Erwäge tabellengetriebene Tests für eine bessere Abdeckung`,
+	"violation.low_coverage.fix_no_tests": `Lege Testdateien für dieses Paket an:
+1. Erstelle %s_test.go-Dateien im Paketverzeichnis
+2. Schreibe Tests für die exportierte API
+3. Prüfe sie mit 'go test ./...'`,
+	"violation.test_naming.issue":                       "Mehrere Implementierungsdateien mit dem Basisnamen '%s' im Verzeichnis '%s'",
+	"violation.test_naming.rule":                        "strict_test_naming: Jeder Basisname sollte genau eine Implementierungsdatei pro Verzeichnis haben",
+	"violation.test_naming.fix":                         "Benenne die doppelten Implementierungsdateien mit dem Basisnamen '%s' um oder führe sie zusammen",
+	"violation.test_naming.issue_tests":                 "Mehrere Testdateien mit dem Basisnamen '%s' im Verzeichnis '%s'",
+	"violation.test_naming.rule_tests":                  "strict_test_naming: Jeder Basisname sollte höchstens eine Testdatei haben (foo_test.go)",
+	"violation.test_naming.fix_tests":                   "Führe die Testdateien in einer Datei '%s_test.go' zusammen oder verwende unterschiedliche Basisnamen",
+	"violation.test_naming.issue_orphan":                "Testdatei '%s' hat keine zugehörige Implementierungsdatei",
+	"violation.test_naming.rule_orphan":                 "strict_test_naming: Jede Testdatei braucht eine zugehörige Implementierungsdatei (foo_test.go -> foo.go)",
+	"violation.test_naming.fix_orphan":                  "Lege die Implementierungsdatei '%s.go' im selben Verzeichnis an oder entferne bzw. benenne die verwaiste Testdatei um",
+	"violation.duplicate_definition.issue":              "%s in %d Schichten identisch definiert\n  Definiert in:\n    - %s",
+	"violation.duplicate_definition.struct":             "Struct",
+	"violation.duplicate_definition.constant_block":     "Konstantenblock",
+	"violation.duplicate_definition.location":           "%s:%d %s (Schicht: %s)",
+	"violation.duplicate_definition.rule":               "Definitionen sollten einen festen Ort haben, statt in mehrere Schichten kopiert zu werden",
+	"violation.duplicate_definition.fix":                "Lege die Definition (%s) einmal in einem Paket an, das alle Kopien importieren dürfen, und verwende sie wieder",
+	"violation.duplicate_definition.fix_home":           "Behalte die Definition in %s und importiere sie an den anderen Stellen",
+	"violation.unwrapped_import.issue":                  "%s importiert %s direkt, statt %s zu verwenden",
+	"violation.unwrapped_import.rule":                   "%s darf nur von seinem Wrapper-Paket %s importiert werden",
+	"violation.unwrapped_import.fix":                    "Verwende stattdessen die Abstraktionen von %s",
+	"violation.wrapper_bypass.issue":                    "Exportiertes %s legt %s.%s des gekapselten Moduls %s offen",
+	"violation.wrapper_bypass.rule":                     "Die öffentliche API von %s darf keine Typen des gekapselten Moduls preisgeben",
+	"violation.wrapper_bypass.fix":                      "Definiere einen eigenen Typ in %s und übersetze intern von/nach %s.%s",
+	"violation.type_leak.issue":                         "Exportiertes %s legt %s.%s offen (verboten: %s)",
+	"violation.type_leak.rule":                          "Exportierte Signaturen in Kernschichten dürfen keine Typen aus verbotenen Paketen enthalten",
+	"violation.type_leak.fix":                           "Ersetze %s durch einen Typ oder ein Interface von %s und setze es intern um",
+	"violation.config_loading.issue":                    "%s importiert die Konfigurationsbibliothek %s",
+	"violation.config_loading.issue_env":                "%s liest die Umgebung über %s",
+	"violation.config_loading.rule":                     "Konfiguration darf nur geladen werden in: %s",
+	"violation.config_loading.fix":                      "Lade die Konfiguration beim Start und reiche die Werte über Konstruktoren oder ein typisiertes Config-Struct herein",
+	"violation.config_loading.fix_env":                  "Lies Umgebungsvariablen beim Start und reiche die Werte über Konstruktoren oder ein typisiertes Config-Struct herein",
+	"violation.framework_lock_in.issue":                 "%s verwendet den Framework-Typ %s in seiner Signatur",
+	"violation.framework_lock_in.rule":                  "Typen von Web-Frameworks müssen in Adapter- bzw. Handler-Paketen bleiben",
+	"violation.framework_lock_in.fix":                   "Lies die benötigten Werte im Handler aus und übergib einfache Parameter oder Domain-Typen",
+	"violation.parse_error.issue":                       "Datei konnte nicht geparst werden: %s",
+	"violation.parse_error.rule":                        "Alle Go-Dateien müssen syntaktisch gültig sein, um gegen die Architekturregeln geprüft zu werden",
+	"violation.parse_error.fix":                         "Behebe den Syntaxfehler (Details mit 'go build' oder 'gofmt'); die Regeln für diese Datei wurden übersprungen",
+	"violation.multiple_packages.issue":                 "%s enthält mehrere Pakete: %s",
+	"violation.multiple_packages.rule":                  "Jedes Verzeichnis muss genau ein Paket enthalten (plus sein _test-Paket)",
+	"violation.multiple_packages.fix":                   "Verschiebe jedes Paket in ein eigenes Verzeichnis",
+	"violation.package_name.issue":                      "Paket %s passt nicht zum Verzeichnis %s",
+	"violation.package_name.rule":                       "Paketnamen müssen dem Verzeichnisnamen entsprechen",
+	"violation.package_name.fix":                        "Benenne das Paket in %s um oder verschiebe es in ein Verzeichnis namens %s",
+	"violation.unstable_dependency.issue":               "Stabiles Paket %s importiert das experimentelle Paket %s",
+	"violation.unstable_dependency.rule":                "Als stabil markierte Pakete dürfen nicht von experimentellen Paketen abhängen",
+	"violation.unstable_dependency.fix":                 "Stabilisiere %s (// archlint:stability stable) oder entferne die Abhängigkeit",
+	"violation.deprecated_usage.issue":                  "Neue Verwendung des veralteten %s.%s (aus %s)",
+	"violation.deprecated_usage.rule":                   "Veraltete Symbole dürfen in anderen Paketen keine neuen Aufrufer bekommen",
+	"violation.deprecated_usage.fix":                    "Verwende den Ersatz, den der Deprecated:-Kommentar von %s.%s nennt",
+	"violation.cross_team_import.issue":                 "%s (Team %s) importiert %s, ein internes Paket von Team %s",
+	"violation.cross_team_import.rule":                  "Pakete anderer Teams dürfen nur über öffentliche Vertragspakete verwendet werden (%s)",
+	"violation.cross_team_import.fix":                   "Verwende stattdessen ein Vertragspaket von Team %s oder bitte das Team, das Benötigte dort bereitzustellen",
+	"violation.missing_package_doc.issue":               "Paket %s hat keinen Paket-Doc-Kommentar",
+	"violation.missing_package_doc.rule":                "Pakete in %s müssen ihren Zweck in einem Paket-Doc-Kommentar beschreiben",
+	"violation.missing_package_doc.fix":                 "Füge direkt über der package-Klausel einen Kommentar ein, der mit \"// Package %s\" beginnt (z. B. in %s/doc.go)",
+	"violation.fixture_location.issue":                  "Test liest die Fixture %q außerhalb von testdata/ über %s",
+	"violation.fixture_location.rule":                   "Testfixtures müssen unter einem testdata/-Verzeichnis liegen",
+	"violation.fixture_location.fix":                    "Verschiebe %s in testdata/ neben den Test; das go-Tool ignoriert testdata-Verzeichnisse",
+	"violation.fixture_location.issue_production":       "Produktionscode liest die Testfixture %q über %s",
+	"violation.fixture_location.rule_production":        "Produktionscode darf nicht von Dateien unter testdata/ abhängen",
+	"violation.fixture_location.fix_production":         "Bette die Datei mit go:embed aus einem Ort außerhalb von testdata ein oder nimm ihren Pfad aus der Konfiguration",
+	"violation.test_setup_import.issue":                 "Test importiert %s nur, um Fixtures zu bauen (%s)",
+	"violation.test_setup_import.rule":                  "Tests dürfen die Werte anderer Pakete für den Testaufbau nicht aus deren Interna zusammensetzen",
+	"violation.test_setup_import.fix":                   "Verwende exportierte Testhelfer oder Builder (z. B. ein Paket %stest), statt %s-Werte direkt zu konstruieren",
+	"violation.test_scope.issue":                        "%s_test.go verweist auf Deklarationen außerhalb von %s.go: %s",
+	"violation.test_scope.declared_in":                  "%s ist in %s.go deklariert",
+	"violation.test_scope.rule":                         "strict_test_naming: %s_test.go sollte nur die in %s.go deklarierten Funktionen testen",
+	"violation.test_scope.fix":                          "Verschiebe diese Tests in die Testdatei der deklarierenden Datei oder liste gemeinsam genutzte Dateien in test_scope.shared auf",
+	"violation.test_support_import.issue":               "%s importiert das Testhilfe-Paket %s",
+	"violation.test_support_import.rule":                "Testhilfe-Pakete (test_files.support_packages) dürfen nur von _test.go-Dateien importiert werden",
+	"violation.test_support_import.fix":                 "Verschiebe den Code, den die Produktion braucht, aus %s heraus oder importiere es nur aus Tests",
+	"violation.unlisted_fixtures.issue":                 "Test importiert das Fixture-Paket %s, das kein gelistetes Testhilfe-Paket ist",
+	"violation.unlisted_fixtures.rule":                  "Gemeinsame Testfixtures müssen aus test_files.support_packages stammen: %v",
+	"violation.unlisted_fixtures.fix":                   "Verschiebe die Fixtures in ein gelistetes Testhilfe-Paket oder ergänze %s in test_files.support_packages",
+	"violation.main_package_location.issue":             "Main-Paket %s liegt außerhalb der erlaubten Orte",
+	"violation.main_package_location.rule":              "Main-Pakete dürfen nur hier liegen: %s",
+	"violation.main_package_location.fix":               "Verschiebe das Programm an einen erlaubten Ort (z. B. cmd/%s) oder ergänze %s in main_packages",
+	"violation.orphan_interface.issue":                  "Interface %s.%s wird von keinem Typ in der Codebasis implementiert",
+	"violation.orphan_interface.rule":                   "Interfaces in Consumer-Schichten sollten mindestens eine Implementierung haben",
+	"violation.orphan_interface.fix":                    "Entferne %s, falls es nicht mehr gebraucht wird, oder ergänze den Adapter, der es implementiert",
+	"violation.portless_adapter.issue":                  "Adapter-Paket %s implementiert keinen Port",
+	"violation.portless_adapter.rule":                   "Adapter-Pakete sollten ein Interface implementieren aus: %s",
+	"violation.portless_adapter.fix":                    "Deklariere den Port, den dieser Adapter bedient, im Kern oder verschiebe das Paket aus der Adapter-Schicht",
+	"violation.unasserted_adapter.issue":                "%s implementiert den Port %s ohne Zusicherung zur Compilezeit",
+	"violation.unasserted_adapter.rule":                 "Adapter-Typen müssen zur Compilezeit zusichern, welche Ports sie implementieren",
+	"violation.unasserted_adapter.fix":                  "Füge var _ %s = (*%s)(nil) in %s hinzu",
+	"violation.layer_cycle.issue":                       "directories_import-Regeln bilden einen Zyklus: %s",
+	"violation.layer_cycle.rule":                        "Schichten müssen eine Hierarchie bilden: Darf A B importieren, darf B A nicht importieren, weder direkt noch über andere Schichten",
+	"violation.layer_cycle.fix":                         "Entscheide, welche von %s von der anderen abhängt, und entferne die Erlaubnis in der Gegenrichtung",
+	"violation.missing_readme.issue":                    "Verzeichnis '%s' hat keine README.md, die die Schicht beschreibt",
+	"violation.missing_readme.issue_empty":              "README.md von '%s' ist leer",
+	"violation.missing_readme.rule":                     "In require_readme gelistete Schichten müssen ihren Zweck in einer README.md dokumentieren",
+	"violation.missing_readme.fix":                      "Schreibe eine %s/README.md, die erklärt, was in %s gehört und wovon es abhängen darf",
+	"violation.hidden_dependency.issue":                 "%s sucht %q über %s, %s",
+	"violation.hidden_dependency.registered_at":         "registriert in %s",
+	"violation.hidden_dependency.declared_at":           "deklariert in %s",
+	"violation.hidden_dependency.rule":                  "Registries und Reflection dürfen Importregeln nicht umgehen: %s darf nur importieren aus: %v",
+	"violation.hidden_dependency.rule_default_policy":   "Registries und Reflection dürfen Importregeln nicht umgehen: %s hat keine directories_import-Regel und default_policy ist deny",
+	"violation.hidden_dependency.fix":                   "Reiche die Abhängigkeit über ein Interface des Verwenders herein oder erlaube den Import in directories_import, falls die Kopplung gewollt ist",
+	"violation.license_policy.issue":                    "%s importiert %s, lizenziert unter %s (%s)",
+	"violation.license_policy.issue_unknown":            "%s importiert %s, dessen Lizenz nicht erkannt wurde",
+	"violation.license_policy.rule":                     "%s darf nur von Modulen mit diesen Lizenzen abhängen: %s",
+	"violation.license_policy.fix":                      "Ersetze %s durch ein Modul mit erlaubter Lizenz, verschiebe die Verwendung in eine Schicht, deren Richtlinie %s erlaubt, oder nimm es nach Prüfung in license_policy.exceptions auf",
+	"violation.license_policy.fix_unknown":              "Führe 'go mod download' aus, damit die Lizenz von %s aus dem Modul-Cache gelesen werden kann, oder prüfe sie und nimm das Modul in license_policy.exceptions auf",
+	"violation.version_sprawl.issue":                    "Externes Modul '%s' wird in %d Hauptversionen importiert (%s)\n  Importiert von:\n    - %s",
+	"violation.version_sprawl.location":                 "%s: %s (Schicht: %s)",
+	"violation.version_sprawl.rule":                     "Jedes externe Modul sollte nur in einer Hauptversion verwendet werden",
+	"violation.version_sprawl.fix":                      "Migriere die Importe von %s auf v%d, damit nur eine Version von %s bleibt",
+	"violation.replace_directive.issue":                 "go.mod ersetzt %s durch den Fork %s",
+	"violation.replace_directive.issue_local":           "go.mod ersetzt %s durch das lokale Verzeichnis %s",
+	"violation.replace_directive.rule":                  "Replace-Direktiven auf lokale Verzeichnisse oder Forks dürfen nicht ausgeliefert werden",
+	"violation.replace_directive.fix":                   "Bringe die Änderungen in %s ein und entferne das Replace oder ergänze %s in replace_directives.allowed, falls der Fork bewusst gepflegt wird",
+	"violation.replace_directive.fix_local":             "Veröffentliche die Änderungen an %s und fordere die neue Version an oder ergänze %s in replace_directives.allowed",
+	"violation.replace_directive.outside_ci":            " (lässt den Build mit -ci oder gesetztem CI fehlschlagen)",
+	"violation.import_depth.issue":                      "%s importiert %s, %s unter %s",
+	"violation.import_depth.level":                      "1 Ebene",
+	"violation.import_depth.levels":                     "%d Ebenen",
+	"violation.import_depth.rule":                       "%s darf Pakete von %s höchstens %s unter dem Modul importieren",
+	"violation.import_depth.rule_root":                  "%s darf nur das Wurzelpaket von %s importieren",
+	"violation.import_depth.fix":                        "Greife über ein lokales Fassadenpaket auf %s zu oder importiere stattdessen %s",
+	"violation.order_dependent_test.issue":              "%s schlägt fehl, wenn die Tests von %s %s laufen",
+	"violation.order_dependent_test.issue_outside_test": "Die Tests von %s schlagen außerhalb eines Tests fehl, wenn sie %s laufen",
+	"violation.order_dependent_test.random_order":       "in zufälliger Reihenfolge",
+	"violation.order_dependent_test.random_order_seed":  "in zufälliger Reihenfolge (Seed %s)",
+	"violation.order_dependent_test.rule":               "Die Tests in %s müssen in jeder Reihenfolge bestehen (go test -shuffle=on)",
+	"violation.order_dependent_test.fix": `Mache %s unabhängig von den anderen Tests:
+1. Reproduziere die fehlschlagende Reihenfolge mit '%s'
+2. Finde den Zustand, den er mit ihnen teilt: Variablen auf Paketebene, Dateien, Umgebungsvariablen, registrierte Handler
+3. Lass jeden Test seine eigenen Fixtures aufbauen (t.TempDir, t.Setenv) und seine Änderungen mit t.Cleanup zurücknehmen`,
+	"violation.order_dependent_test.fix_outside_test": "Führe '%s' aus und behebe den gemeldeten Build-Fehler, Panic oder Timeout",
+	"violation.data_race.issue":                       "%s von %s hat einen Data Race%s",
+	"violation.data_race.issue_outside_test":          "Die Tests von %s haben einen Data Race außerhalb eines Tests%s",
+	"violation.data_race.access":                      ": %s in %s",
+	"violation.data_race.rule":                        "Die Tests in %s müssen den Race Detector bestehen (go test -race)",
+	"violation.data_race.fix": `Synchronisiere die konkurrierenden Zugriffe:
+1. Reproduziere den Race mit '%s' und lies beide Stacks des Berichts
+2. Finde die Variable oder das Feld, das sich die Goroutinen teilen
+3. Schütze es mit einem sync.Mutex, übergib es über einen Channel oder verwende sync/atomic`,
+	"violation.enforced_from":  " (durchgesetzt ab %s, %s)",
+	"violation.in_day":         "in 1 Tag",
+	"violation.in_days":        "in %d Tagen",
+	"violation.only_under":     " (nur unter %s)",
+	"violation.ignore_expired": " (archlint:ignore in %s ist am %s abgelaufen)",
+	"violation.ignore_expires": " (archlint:ignore in %s läuft am %s ab, %s)",
+}
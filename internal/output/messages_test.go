@@ -0,0 +1,117 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestNewMessages(t *testing.T) {
+	if languages := output.Languages(); strings.Join(languages, ",") != "de,en" {
+		t.Errorf("unexpected languages: %v", languages)
+	}
+
+	messages, err := output.NewMessages("")
+	if err != nil {
+		t.Fatalf("NewMessages failed: %v", err)
+	}
+	if text := messages.Text("report.fix_ref", "ARCH001"); text != "see ARCH001" {
+		t.Errorf("expected English messages by default, got %q", text)
+	}
+
+	var nilMessages *output.Messages
+	if text := nilMessages.Text("report.file"); text != "File" {
+		t.Errorf("expected English messages without a catalog, got %q", text)
+	}
+
+	if _, err := output.NewMessages("fr"); err == nil || !strings.Contains(err.Error(), "available: de, en") {
+		t.Errorf("expected an error listing the languages, got %v", err)
+	}
+}
+
+func TestLanguageFromLocale(t *testing.T) {
+	tests := map[string]string{
+		"de_DE.UTF-8": "de",
+		"de_AT":       "de",
+		"DE":          "de",
+		"en_US.UTF-8": "en",
+		"fr_FR.UTF-8": "en",
+		"C":           "en",
+		"POSIX":       "en",
+		"":            "en",
+	}
+	for locale, want := range tests {
+		if got := output.LanguageFromLocale(locale); got != want {
+			t.Errorf("LanguageFromLocale(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestFormatViolationsLocalized_German(t *testing.T) {
+	messages, err := output.NewMessages("de")
+	if err != nil {
+		t.Fatalf("NewMessages failed: %v", err)
+	}
+	violations := []output.Violation{
+		&testViolation{violationType: "Forbidden Import", file: "internal/a/a.go", line: 3, issue: "internal/a imports internal/c", rule: "internal/a can only import from: []", fix: "Depend on an interface", code: "ARCH005"},
+		&testViolation{violationType: "Forbidden Import", file: "internal/b/b.go", line: 3, issue: "internal/b imports internal/c", rule: "internal/b can only import from: []", fix: "Depend on an interface", code: "ARCH005"},
+	}
+	errorContext := &output.ErrorContext{Enabled: true, PresetName: "ddd", ArchitecturalGoals: "Keep the domain pure\n", RefactoringGuidance: "Invert dependencies\n"}
+
+	result := output.FormatViolationsLocalized(violations, errorContext, messages)
+	code := output.FixCode(violations[0])
+
+	expected := []string{
+		"ARCHITEKTURVERSTÖSSE ERKANNT",
+		"Dieses Projekt verwendet das Architektur-Preset 'ddd'.",
+		"┌─ ARCHITEKTURZIELE ─",
+		"[ERROR] Verbotener Import\n",
+		"  Datei: internal/a/a.go:3\n",
+		"  Problem: internal/a imports internal/c\n",
+		"  Regel: internal/a can only import from: []\n",
+		"  Behebung: siehe " + code + "\n",
+		"BEHEBUNGEN\n  " + code + ": Depend on an interface\n",
+		"┌─ HINWEISE ZUM REFACTORING ─",
+		"💡 TIPP: Diese Verstöße zeigen",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+
+	// Translated box borders keep the width of the English ones
+	for _, line := range strings.Split(result, "\n") {
+		if strings.HasPrefix(line, "┌─") || strings.HasPrefix(line, "║") {
+			if width := utf8.RuneCountInString(line); width != 82 {
+				t.Errorf("expected box lines of 82 columns, got %d: %q", width, line)
+			}
+		}
+	}
+}
+
+func TestGenerateFullDocumentation_German(t *testing.T) {
+	doc := output.FullDocumentation{
+		Graph: &testGraph{},
+		Lang:  "de",
+	}
+
+	result := output.GenerateFullDocumentation(doc)
+
+	expected := []string{
+		"# Projektarchitektur\n",
+		"## Inhaltsverzeichnis\n",
+		"- [Projektstruktur](#projektstruktur)\n",
+		"- [Öffentliche API](#öffentliche-api)\n",
+		"## Öffentliche API\n",
+		"## Statistik\n",
+		"- **Dateien gesamt**: 0\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MovePlanFormats lists the supported move plan formats
+var MovePlanFormats = []string{"markdown", "json"}
+
+// MovePlan describes what moving a package directory changes
+type MovePlan struct {
+	From       string          `json:"from"`        // Directory moved, relative to the project root
+	To         string          `json:"to"`          // Destination directory
+	FromImport string          `json:"from_import"` // Import path of the package before the move
+	ToImport   string          `json:"to_import"`   // Import path of the package after the move
+	Files      []string        `json:"files"`       // Files moved, relative to From
+	Rewrites   []ImportRewrite `json:"rewrites"`    // Imports to rewrite, sorted by file and line
+	Introduced []MoveViolation `json:"introduced"`  // Violations the move adds, at their new location
+	Resolved   []MoveViolation `json:"resolved"`    // Violations the move removes, at their current location
+	Notes      []string        `json:"notes,omitempty"`
+}
+
+// ImportRewrite is an import of the moved package, or one of its subpackages, to rewrite
+type ImportRewrite struct {
+	File string `json:"file"` // File importing the package, at its current location
+	Line int    `json:"line"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// MoveViolation is a violation introduced or resolved by a move
+type MoveViolation struct {
+	Type  string `json:"type"`
+	File  string `json:"file"`
+	Line  int    `json:"line,omitempty"`
+	Issue string `json:"issue"`
+}
+
+// FormatMovePlan renders a move plan as markdown or json
+func FormatMovePlan(plan MovePlan, format string) (string, error) {
+	switch format {
+	case "markdown":
+		return generateMovePlanMarkdown(plan), nil
+	case "json":
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported move plan format %q (supported: %s)", format, strings.Join(MovePlanFormats, ", "))
+	}
+}
+
+func generateMovePlanMarkdown(plan MovePlan) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Move Plan: `%s` → `%s`\n\n", plan.From, plan.To))
+	sb.WriteString(fmt.Sprintf("Import path `%s` becomes `%s`.\n", plan.FromImport, plan.ToImport))
+
+	sb.WriteString(fmt.Sprintf("\n## Files Moved (%d)\n\n", len(plan.Files)))
+	for _, file := range plan.Files {
+		sb.WriteString(fmt.Sprintf("- `%s/%s` → `%s/%s`\n", plan.From, file, plan.To, file))
+	}
+
+	files := make(map[string]bool)
+	for _, rewrite := range plan.Rewrites {
+		files[rewrite.File] = true
+	}
+	sb.WriteString(fmt.Sprintf("\n## Import Rewrites (%s in %s)\n\n",
+		countLabel(len(plan.Rewrites), "import", "imports"), countLabel(len(files), "file", "files")))
+	if len(plan.Rewrites) == 0 {
+		sb.WriteString("No file imports the package.\n")
+	} else {
+		sb.WriteString("| File | Line | Old | New |\n")
+		sb.WriteString("|------|------|-----|-----|\n")
+		for _, rewrite := range plan.Rewrites {
+			sb.WriteString(fmt.Sprintf("| %s | %d | `%s` | `%s` |\n", rewrite.File, rewrite.Line, rewrite.Old, rewrite.New))
+		}
+	}
+
+	sb.WriteString("\n## Violations\n\n")
+	if len(plan.Introduced) == 0 && len(plan.Resolved) == 0 {
+		sb.WriteString("The move does not change any violations.\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("The move introduces %d and resolves %d violations.\n", len(plan.Introduced), len(plan.Resolved)))
+		writeMoveViolations(&sb, "Introduced", plan.Introduced)
+		writeMoveViolations(&sb, "Resolved", plan.Resolved)
+	}
+
+	if len(plan.Notes) > 0 {
+		sb.WriteString("\n## Notes\n\n")
+		for _, note := range plan.Notes {
+			sb.WriteString(fmt.Sprintf("- %s\n", note))
+		}
+	}
+
+	return sb.String()
+}
+
+// writeMoveViolations renders one list of changed violations, if it is not empty
+func writeMoveViolations(sb *strings.Builder, title string, violations []MoveViolation) {
+	if len(violations) == 0 {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("\n### %s\n\n", title))
+	for _, viol := range violations {
+		location := viol.File
+		if viol.Line > 0 {
+			location = fmt.Sprintf("%s:%d", viol.File, viol.Line)
+		}
+		sb.WriteString(fmt.Sprintf("- **%s** `%s`: %s\n", viol.Type, location, viol.Issue))
+	}
+}
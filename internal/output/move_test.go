@@ -0,0 +1,87 @@
+package output_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestFormatMovePlan_Markdown(t *testing.T) {
+	plan := output.MovePlan{
+		From:       "internal/foo",
+		To:         "internal/domain/foo",
+		FromImport: "example.com/m/internal/foo",
+		ToImport:   "example.com/m/internal/domain/foo",
+		Files:      []string{"foo.go", "sub/sub.go"},
+		Rewrites: []output.ImportRewrite{
+			{File: "cmd/app/main.go", Line: 4, Old: "example.com/m/internal/foo", New: "example.com/m/internal/domain/foo"},
+			{File: "cmd/app/main.go", Line: 5, Old: "example.com/m/internal/foo/sub", New: "example.com/m/internal/domain/foo/sub"},
+		},
+		Introduced: []output.MoveViolation{{Type: "Forbidden Import", File: "internal/domain/foo/foo.go", Line: 3, Issue: "internal/domain/foo imports internal/bar"}},
+		Resolved:   []output.MoveViolation{},
+		Notes:      []string{".goarchlint mentions internal/foo; update the paths in the config by hand."},
+	}
+
+	result, err := output.FormatMovePlan(plan, "markdown")
+	if err != nil {
+		t.Fatalf("FormatMovePlan failed: %v", err)
+	}
+
+	expected := []string{
+		"# Move Plan: `internal/foo` → `internal/domain/foo`",
+		"## Files Moved (2)",
+		"- `internal/foo/sub/sub.go` → `internal/domain/foo/sub/sub.go`",
+		"## Import Rewrites (2 imports in 1 file)",
+		"| cmd/app/main.go | 5 | `example.com/m/internal/foo/sub` | `example.com/m/internal/domain/foo/sub` |",
+		"The move introduces 1 and resolves 0 violations.",
+		"### Introduced",
+		"- **Forbidden Import** `internal/domain/foo/foo.go:3`: internal/domain/foo imports internal/bar",
+		"## Notes",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "### Resolved") {
+		t.Errorf("expected no resolved section, got:\n%s", result)
+	}
+
+	empty, err := output.FormatMovePlan(output.MovePlan{From: "a", To: "b"}, "markdown")
+	if err != nil {
+		t.Fatalf("FormatMovePlan failed: %v", err)
+	}
+	if !strings.Contains(empty, "No file imports the package.") || !strings.Contains(empty, "The move does not change any violations.") {
+		t.Errorf("unexpected empty plan:\n%s", empty)
+	}
+}
+
+func TestFormatMovePlan_JSON(t *testing.T) {
+	plan := output.MovePlan{
+		From:       "internal/foo",
+		To:         "pkg/foo",
+		Files:      []string{"foo.go"},
+		Rewrites:   []output.ImportRewrite{{File: "pkg/api/api.go", Line: 3, Old: "m/internal/foo", New: "m/pkg/foo"}},
+		Introduced: []output.MoveViolation{},
+		Resolved:   []output.MoveViolation{{Type: "Forbidden Import", File: "pkg/api/api.go", Issue: "pkg/api imports internal/foo"}},
+	}
+
+	result, err := output.FormatMovePlan(plan, "json")
+	if err != nil {
+		t.Fatalf("FormatMovePlan failed: %v", err)
+	}
+
+	var decoded output.MovePlan
+	if err := json.Unmarshal([]byte(result), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+	if decoded.To != "pkg/foo" || len(decoded.Rewrites) != 1 || decoded.Rewrites[0] != plan.Rewrites[0] || len(decoded.Resolved) != 1 {
+		t.Errorf("unexpected round trip result: %+v", decoded)
+	}
+
+	if _, err := output.FormatMovePlan(plan, "csv"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
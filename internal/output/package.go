@@ -12,24 +12,67 @@ type PackageDocumentation struct {
 	PackagePath  string
 	Files        []FileWithAPI
 	Dependencies []Dependency // From graph
+	Dependents   []Dependent  // Local packages importing this package, sorted by path
 	FileCount    int
 	ExportCount  int
+	Health       *PackageHealth // Coverage and violations, nil when the package was not validated
+}
+
+// PackageHealth is the current state of a package against the architecture rules
+type PackageHealth struct {
+	Coverage   *ReportPackageCoverage // nil when test coverage is disabled
+	Threshold  float64                // Coverage threshold applying to the package
+	Violations int                    // Open violations in the files of the package
+}
+
+// Dependent is a local package importing the documented package
+type Dependent struct {
+	Path      string // Directory of the importing package
+	FileCount int    // Files of the importing package that import the documented package
 }
 
 // GeneratePackageDocumentation creates detailed documentation for a single package
 func GeneratePackageDocumentation(doc PackageDocumentation) string {
-	var sb strings.Builder
+	return buildPackageDocumentation(doc).String()
+}
+
+// buildPackageDocumentation writes the package documentation section by section
+func buildPackageDocumentation(doc PackageDocumentation) *docBuilder {
+	sb := &docBuilder{}
 
 	// Header
+	sb.section("header")
 	sb.WriteString(fmt.Sprintf("# Package: %s\n\n", doc.PackageName))
 	sb.WriteString(fmt.Sprintf("**Path**: `%s`\n\n", doc.PackagePath))
+	if doc.Health != nil {
+		sb.WriteString(healthBadges(*doc.Health) + "\n\n")
+	}
+	if description := packageDescription(doc.Files); description != "" {
+		sb.WriteString(description + "\n\n")
+	}
+	if stability := packageStability(doc.Files); stability != "" {
+		sb.WriteString(fmt.Sprintf("**Stability**: %s\n\n", stability))
+	}
+	examples := examplesBySymbol(doc.Files)
+	if len(examples[""]) > 0 {
+		sb.WriteString(fmt.Sprintf("**Examples**: %s\n\n", formatExamples(examples[""])))
+	}
 
 	// Quick stats
+	sb.section("overview")
 	sb.WriteString("## Overview\n\n")
 	sb.WriteString(fmt.Sprintf("- **Files**: %d\n", doc.FileCount))
-	sb.WriteString(fmt.Sprintf("- **Exports**: %d\n\n", doc.ExportCount))
+	sb.WriteString(fmt.Sprintf("- **Exports**: %d\n", doc.ExportCount))
+	if doc.Health != nil {
+		if doc.Health.Coverage != nil {
+			sb.WriteString(fmt.Sprintf("- **Coverage**: %s\n", coverageStatus(*doc.Health)))
+		}
+		sb.WriteString(fmt.Sprintf("- **Violations**: %d open\n", doc.Health.Violations))
+	}
+	sb.WriteString("\n")
 
 	// Dependencies
+	sb.section("dependencies")
 	if len(doc.Dependencies) > 0 {
 		sb.WriteString("## Dependencies\n\n")
 		sb.WriteString("This package imports:\n\n")
@@ -68,7 +111,25 @@ func GeneratePackageDocumentation(doc PackageDocumentation) string {
 		sb.WriteString("This package has no dependencies.\n\n")
 	}
 
+	// Reverse dependencies
+	sb.section("dependents")
+	sb.WriteString("## Imported By\n\n")
+	if len(doc.Dependents) > 0 {
+		sb.WriteString("This package is imported by:\n\n")
+		for _, dependent := range doc.Dependents {
+			files := "files"
+			if dependent.FileCount == 1 {
+				files = "file"
+			}
+			sb.WriteString(fmt.Sprintf("- `%s` (%d %s)\n", dependent.Path, dependent.FileCount, files))
+		}
+		sb.WriteString("\n")
+	} else {
+		sb.WriteString("No local package imports this package.\n\n")
+	}
+
 	// Exported API
+	sb.section("api")
 	sb.WriteString("## Exported API\n\n")
 
 	if doc.ExportCount == 0 {
@@ -119,18 +180,9 @@ func GeneratePackageDocumentation(doc PackageDocumentation) string {
 		standaloneFuncs := []ExportedDecl{}
 
 		for _, decl := range functions {
-			sig := decl.GetSignature()
-			// Check if this is a method (starts with receiver like (*Type) or (Type))
-			if strings.HasPrefix(sig, "(") {
-				// Extract type name from receiver
-				endIdx := strings.Index(sig, ")")
-				if endIdx > 0 {
-					receiver := sig[1:endIdx]
-					// Remove pointer if present
-					typeName := strings.TrimPrefix(receiver, "*")
-					methodsByType[typeName] = append(methodsByType[typeName], decl)
-					continue
-				}
+			if typeName, ok := methodReceiver(decl.GetSignature()); ok {
+				methodsByType[typeName] = append(methodsByType[typeName], decl)
+				continue
 			}
 			standaloneFuncs = append(standaloneFuncs, decl)
 		}
@@ -140,6 +192,9 @@ func GeneratePackageDocumentation(doc PackageDocumentation) string {
 			sb.WriteString("### Types\n\n")
 			for _, typeDecl := range types {
 				sb.WriteString(fmt.Sprintf("#### %s\n\n", typeDecl.GetName()))
+				if summary := packageSynopsis(typeDecl.GetDoc()); summary != "" {
+					sb.WriteString(summary + "\n\n")
+				}
 
 				if typeDecl.GetSignature() != "" {
 					sb.WriteString(fmt.Sprintf("```go\n%s\n```\n\n", typeDecl.GetSignature()))
@@ -160,18 +215,32 @@ func GeneratePackageDocumentation(doc PackageDocumentation) string {
 				if len(methods) > 0 {
 					sb.WriteString("**Methods**:\n\n")
 					for _, method := range methods {
-						sb.WriteString(fmt.Sprintf("- `%s`\n", method.GetSignature()))
+						sb.WriteString(withSummary(fmt.Sprintf("- `%s`", method.GetSignature()), method.GetDoc()) + "\n")
+						if methodExamples := examples[declSymbol(method)]; len(methodExamples) > 0 {
+							sb.WriteString(fmt.Sprintf("  - Examples: %s\n", formatExamples(methodExamples)))
+						}
 					}
 					sb.WriteString("\n")
 				}
+
+				// Show examples if any
+				if typeExamples := examples[typeDecl.GetName()]; len(typeExamples) > 0 {
+					sb.WriteString(fmt.Sprintf("**Examples**: %s\n\n", formatExamples(typeExamples)))
+				}
 			}
 		}
 
+		// Output Type Hierarchy
+		writeTypeHierarchy(&sb.Builder, types, "###")
+
 		// Output Functions
 		if len(standaloneFuncs) > 0 {
 			sb.WriteString("### Functions\n\n")
 			for _, fn := range standaloneFuncs {
-				sb.WriteString(fmt.Sprintf("- `%s`\n", fn.GetSignature()))
+				sb.WriteString(withSummary(fmt.Sprintf("- `%s`", fn.GetSignature()), fn.GetDoc()) + "\n")
+				if funcExamples := examples[fn.GetName()]; len(funcExamples) > 0 {
+					sb.WriteString(fmt.Sprintf("  - Examples: %s\n", formatExamples(funcExamples)))
+				}
 			}
 			sb.WriteString("\n")
 		}
@@ -180,7 +249,7 @@ func GeneratePackageDocumentation(doc PackageDocumentation) string {
 		if len(constants) > 0 {
 			sb.WriteString("### Constants\n\n")
 			for _, c := range constants {
-				sb.WriteString(fmt.Sprintf("- `%s`\n", c.GetName()))
+				sb.WriteString(withSummary(fmt.Sprintf("- `%s`", c.GetName()), c.GetDoc()) + "\n")
 			}
 			sb.WriteString("\n")
 		}
@@ -189,13 +258,14 @@ func GeneratePackageDocumentation(doc PackageDocumentation) string {
 		if len(variables) > 0 {
 			sb.WriteString("### Variables\n\n")
 			for _, v := range variables {
-				sb.WriteString(fmt.Sprintf("- `%s`\n", v.GetName()))
+				sb.WriteString(withSummary(fmt.Sprintf("- `%s`", v.GetName()), v.GetDoc()) + "\n")
 			}
 			sb.WriteString("\n")
 		}
 	}
 
 	// File list
+	sb.section("files")
 	sb.WriteString("## Files\n\n")
 	if len(doc.Files) > 0 {
 		fileNames := []string{}
@@ -210,8 +280,40 @@ func GeneratePackageDocumentation(doc PackageDocumentation) string {
 		sb.WriteString("\n")
 	}
 
+	sb.section("footer")
 	sb.WriteString("---\n\n")
 	sb.WriteString("*Generated by `go-arch-lint -format=package`*\n")
 
-	return sb.String()
+	return sb
+}
+
+// healthBadges returns shields.io badges for the coverage and the open violations of a package
+func healthBadges(health PackageHealth) string {
+	var badges []string
+	if health.Coverage != nil {
+		color := "brightgreen"
+		if health.Coverage.Coverage < health.Threshold {
+			color = "red"
+		}
+		badges = append(badges, badge("coverage", fmt.Sprintf("%.1f%%", health.Coverage.Coverage), color))
+	}
+	color := "brightgreen"
+	if health.Violations > 0 {
+		color = "red"
+	}
+	badges = append(badges, badge("violations", health.Violations, color))
+	return strings.Join(badges, " ")
+}
+
+// coverageStatus describes the coverage of a package against its threshold
+func coverageStatus(health PackageHealth) string {
+	value := fmt.Sprintf("%.1f%%", health.Coverage.Coverage)
+	if !health.Coverage.HasTests {
+		value = "no tests"
+	}
+	status := "✓ meets threshold"
+	if health.Coverage.Coverage < health.Threshold {
+		status = "✗ below threshold"
+	}
+	return fmt.Sprintf("%s (threshold %.0f%%, %s)", value, health.Threshold, status)
 }
@@ -0,0 +1,49 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestGeneratePackageDocumentation_Health(t *testing.T) {
+	doc := output.PackageDocumentation{
+		PackageName: "cache",
+		PackagePath: "pkg/cache",
+		Files:       []output.FileWithAPI{&testFileWithAPI{relPath: "pkg/cache/cache.go", pkg: "cache"}},
+		FileCount:   1,
+		Health: &output.PackageHealth{
+			Coverage:   &output.ReportPackageCoverage{Package: "github.com/test/project/pkg/cache", Coverage: 62.5, HasTests: true},
+			Threshold:  80,
+			Violations: 2,
+		},
+	}
+
+	result := output.GeneratePackageDocumentation(doc)
+
+	expected := []string{
+		"![coverage: 62.5%](https://img.shields.io/badge/coverage-62.5%25-red) ![violations: 2](https://img.shields.io/badge/violations-2-red)\n",
+		"- **Coverage**: 62.5% (threshold 80%, ✗ below threshold)\n",
+		"- **Violations**: 2 open\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in documentation, got:\n%s", want, result)
+		}
+	}
+
+	// Without measured coverage only the violations are shown
+	doc.Health = &output.PackageHealth{}
+	result = output.GeneratePackageDocumentation(doc)
+	if strings.Contains(result, "coverage") || !strings.Contains(result, "![violations: 0](https://img.shields.io/badge/violations-0-brightgreen)") {
+		t.Errorf("expected only a passing violations badge, got:\n%s", result)
+	}
+
+	// Packages that were not validated have no health information
+	doc.Health = nil
+	result = output.GeneratePackageDocumentation(doc)
+	if strings.Contains(result, "Violations") {
+		t.Errorf("expected no health information, got:\n%s", result)
+	}
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// PackageReport is the documentation of a single package as JSON, written by
+// -format=package-json for IDE plugins and portals that render package pages
+type PackageReport struct {
+	Package      string                 `json:"package"` // Package name
+	Path         string                 `json:"path"`    // Directory relative to the project
+	Description  string                 `json:"description,omitempty"`
+	Stability    string                 `json:"stability,omitempty"`
+	Files        []string               `json:"files"`
+	Exports      []PackageExport        `json:"exports"`
+	Dependencies []PackageDependency    `json:"dependencies"`
+	Dependents   []string               `json:"dependents"`         // Directories of local packages importing the package
+	Coverage     *ReportPackageCoverage `json:"coverage,omitempty"` // Set when test coverage is enabled
+	Violations   []ReportViolation      `json:"violations"`         // Violations in the files of the package
+}
+
+// PackageExport is an exported declaration of a package
+type PackageExport struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind"` // func, type, const or var
+	Signature  string   `json:"signature"`
+	Receiver   string   `json:"receiver,omitempty"`   // Receiver type of methods
+	Doc        string   `json:"doc,omitempty"`        // Doc comment
+	Properties []string `json:"properties,omitempty"` // Exported struct fields
+	Embeds     []string `json:"embeds,omitempty"`     // Embedded types of structs and interfaces
+	Examples   []string `json:"examples,omitempty"`   // Example functions documenting the declaration
+}
+
+// PackageDependency is an import of a package
+type PackageDependency struct {
+	ImportPath string `json:"import_path"`
+	Local      bool   `json:"local"`
+	Path       string `json:"path,omitempty"` // Directory of local packages
+}
+
+// NewPackageReport fills a package report with the exports, dependencies, dependents and
+// files of the package documentation; coverage and violations are left to the caller
+func NewPackageReport(doc PackageDocumentation) PackageReport {
+	report := PackageReport{
+		Package:      doc.PackageName,
+		Path:         doc.PackagePath,
+		Description:  packageDescription(doc.Files),
+		Stability:    packageStability(doc.Files),
+		Files:        []string{},
+		Exports:      []PackageExport{},
+		Dependencies: []PackageDependency{},
+		Dependents:   []string{},
+		Violations:   []ReportViolation{},
+	}
+
+	examples := examplesBySymbol(doc.Files)
+	for _, file := range doc.Files {
+		report.Files = append(report.Files, file.GetRelPath())
+		if strings.HasSuffix(file.GetRelPath(), "_test.go") {
+			continue
+		}
+		for _, decl := range file.GetExportedDecls() {
+			export := PackageExport{
+				Name:       decl.GetName(),
+				Kind:       decl.GetKind(),
+				Signature:  decl.GetSignature(),
+				Doc:        decl.GetDoc(),
+				Properties: decl.GetProperties(),
+				Embeds:     decl.GetEmbeds(),
+			}
+			export.Receiver, _ = methodReceiver(decl.GetSignature())
+			for _, example := range examples[declSymbol(decl)] {
+				export.Examples = append(export.Examples, example.GetName())
+			}
+			report.Exports = append(report.Exports, export)
+		}
+	}
+	sort.Strings(report.Files)
+	sort.SliceStable(report.Exports, func(i, j int) bool {
+		return report.Exports[i].Name < report.Exports[j].Name
+	})
+
+	for _, dep := range doc.Dependencies {
+		dependency := PackageDependency{ImportPath: dep.GetImportPath(), Local: dep.IsLocalDep()}
+		if dep.IsLocalDep() {
+			dependency.Path = dep.GetLocalPath()
+		}
+		report.Dependencies = append(report.Dependencies, dependency)
+	}
+	for _, dependent := range doc.Dependents {
+		report.Dependents = append(report.Dependents, dependent.Path)
+	}
+
+	return report
+}
+
+// FormatPackageReport renders a package report as indented JSON
+func FormatPackageReport(report PackageReport) (string, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
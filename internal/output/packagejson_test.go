@@ -0,0 +1,88 @@
+package output_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestNewPackageReport(t *testing.T) {
+	doc := output.PackageDocumentation{
+		PackageName: "cache",
+		PackagePath: "pkg/cache",
+		Files: []output.FileWithAPI{
+			&testFileWithAPI{
+				relPath:    "pkg/cache/cache.go",
+				pkg:        "cache",
+				packageDoc: "Package cache stores values.",
+				stability:  "stable",
+				decls: []output.ExportedDecl{
+					&testExportedDecl{name: "Get", kind: "func", signature: "(*Cache) Get(string) string", doc: "Get returns a value."},
+					&testExportedDecl{name: "Cache", kind: "type", signature: "Cache", typeKind: "struct", properties: []string{"Size int"}, embeds: []string{"sync.Mutex"}},
+				},
+				examples: []output.Example{
+					&testExample{name: "ExampleCache_Get", symbol: "Cache.Get", relPath: "pkg/cache/example_test.go", line: 3},
+				},
+			},
+			&testFileWithAPI{
+				relPath: "pkg/cache/cache_test.go",
+				pkg:     "cache",
+				decls: []output.ExportedDecl{
+					&testExportedDecl{name: "TestGet", kind: "func", signature: "TestGet(*testing.T)"},
+				},
+			},
+		},
+		Dependencies: []output.Dependency{
+			&testDependency{importPath: "github.com/test/project/pkg/store", isLocal: true, localPath: "pkg/store"},
+			&testDependency{importPath: "sync"},
+		},
+		Dependents: []output.Dependent{{Path: "cmd/app", FileCount: 2}},
+	}
+
+	report := output.NewPackageReport(doc)
+
+	if report.Package != "cache" || report.Path != "pkg/cache" || report.Description != "Package cache stores values." || report.Stability != "stable" {
+		t.Errorf("unexpected package fields: %+v", report)
+	}
+	if len(report.Files) != 2 || report.Files[0] != "pkg/cache/cache.go" {
+		t.Errorf("expected both files sorted, got %v", report.Files)
+	}
+
+	// Exports of test files are left out; exports are sorted by name
+	if len(report.Exports) != 2 {
+		t.Fatalf("expected 2 exports, got %+v", report.Exports)
+	}
+	cache, get := report.Exports[0], report.Exports[1]
+	if cache.Name != "Cache" || len(cache.Properties) != 1 || len(cache.Embeds) != 1 || cache.Receiver != "" {
+		t.Errorf("unexpected type export: %+v", cache)
+	}
+	if get.Name != "Get" || get.Receiver != "Cache" || get.Doc != "Get returns a value." || len(get.Examples) != 1 || get.Examples[0] != "ExampleCache_Get" {
+		t.Errorf("unexpected method export: %+v", get)
+	}
+
+	if len(report.Dependencies) != 2 || !report.Dependencies[0].Local || report.Dependencies[0].Path != "pkg/store" || report.Dependencies[1].Local {
+		t.Errorf("unexpected dependencies: %+v", report.Dependencies)
+	}
+	if len(report.Dependents) != 1 || report.Dependents[0] != "cmd/app" {
+		t.Errorf("unexpected dependents: %v", report.Dependents)
+	}
+
+	// Lists are never null, so consumers can iterate without checks
+	out, err := output.FormatPackageReport(report)
+	if err != nil {
+		t.Fatalf("FormatPackageReport failed: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, key := range []string{"dependents", "violations"} {
+		if _, ok := decoded[key].([]interface{}); !ok {
+			t.Errorf("expected %s to be an array, got %v", key, decoded[key])
+		}
+	}
+	if _, ok := decoded["coverage"]; ok {
+		t.Error("expected coverage to be omitted when not measured")
+	}
+}
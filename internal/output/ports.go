@@ -0,0 +1,44 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Port is an interface of a port or domain package and the concrete types implementing it
+type Port struct {
+	Interface    string   // Interface name
+	Package      string   // Directory path of the declaring package
+	Implementers []string // Implementing types as "dir.Type", or "*dir.Type" when only the pointer implements it
+}
+
+// sortedPorts returns the ports ordered by package and interface name
+func sortedPorts(ports []Port) []Port {
+	sorted := append([]Port(nil), ports...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Package != sorted[j].Package {
+			return sorted[i].Package < sorted[j].Package
+		}
+		return sorted[i].Interface < sorted[j].Interface
+	})
+	return sorted
+}
+
+// writePorts writes a section mapping each port to its adapters; nothing is written without ports
+func writePorts(sb *strings.Builder, ports []Port, messages *Messages) {
+	if len(ports) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("## %s\n\n", messages.Text("docs.ports")))
+	sb.WriteString(messages.Text("docs.ports_intro") + "\n\n")
+	for _, port := range sortedPorts(ports) {
+		if len(port.Implementers) == 0 {
+			sb.WriteString(fmt.Sprintf("- **%s** (`%s`) → *(no implementation)*\n", port.Interface, port.Package))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("- **%s** (`%s`) → %s\n", port.Interface, port.Package, strings.Join(port.Implementers, ", ")))
+	}
+	sb.WriteString("\n")
+}
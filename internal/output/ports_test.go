@@ -0,0 +1,43 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func testPorts() []output.Port {
+	return []output.Port{
+		{Interface: "Notifier", Package: "internal/ports", Implementers: nil},
+		{Interface: "Clock", Package: "internal/domain"},
+		{Interface: "OrderRepository", Package: "internal/ports", Implementers: []string{"*internal/adapters/db.Repo", "internal/adapters/memory.Store"}},
+	}
+}
+
+func TestGenerateIndexDocumentation_Ports(t *testing.T) {
+	result := output.GenerateIndexDocumentation(output.FullDocumentation{Graph: &testGraphForIndex{}, Ports: testPorts()})
+
+	expected := "## Ports and Adapters\n\n" +
+		"Interfaces of port and domain packages and the concrete types implementing them:\n\n" +
+		"- **Clock** (`internal/domain`) → *(no implementation)*\n" +
+		"- **Notifier** (`internal/ports`) → *(no implementation)*\n" +
+		"- **OrderRepository** (`internal/ports`) → *internal/adapters/db.Repo, internal/adapters/memory.Store\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected ports section:\n%s\ngot:\n%s", expected, result)
+	}
+}
+
+func TestGenerateFullDocumentation_Ports(t *testing.T) {
+	result := output.GenerateFullDocumentation(output.FullDocumentation{Graph: &testGraphForIndex{}, Ports: testPorts()})
+
+	if !strings.Contains(result, "- [Ports and Adapters](#ports-and-adapters)") || !strings.Contains(result, "## Ports and Adapters") {
+		t.Errorf("expected ports section with a table of contents entry, got:\n%s", result)
+	}
+
+	// Without ports the section is omitted
+	result = output.GenerateFullDocumentation(output.FullDocumentation{Graph: &testGraphForIndex{}})
+	if strings.Contains(result, "Ports and Adapters") {
+		t.Errorf("expected no ports section without ports, got:\n%s", result)
+	}
+}
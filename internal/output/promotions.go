@@ -0,0 +1,85 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PromotionFormats lists the supported promotion advisor formats
+var PromotionFormats = []string{"markdown", "json"}
+
+// PromotionEntry is a package that may be in the wrong top-level directory
+type PromotionEntry struct {
+	Package   string   `json:"package"`
+	Target    string   `json:"target"`              // Suggested directory
+	Importers []string `json:"importers"`           // Local packages importing the package, sorted
+	Stability string   `json:"stability,omitempty"` // archlint:stability annotation (empty if absent)
+}
+
+// PromotionReport lists internal packages worth promoting to pkg/ and pkg/ packages
+// worth demoting to internal/
+type PromotionReport struct {
+	MinImporters int              `json:"min_importers"` // pkg/ importers an internal package needs to be promoted
+	Promote      []PromotionEntry `json:"promote"`
+	Demote       []PromotionEntry `json:"demote"`
+}
+
+// FormatPromotions renders the promotion advisor report as markdown or json
+func FormatPromotions(report PromotionReport, format string) (string, error) {
+	switch format {
+	case "markdown":
+		return generatePromotionsMarkdown(report), nil
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported promotions format %q (supported: %s)", format, strings.Join(PromotionFormats, ", "))
+	}
+}
+
+func generatePromotionsMarkdown(report PromotionReport) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Package Promotion Advisor\n\n")
+	sb.WriteString("Advisory: packages whose importers suggest another top-level directory. Nothing here fails the build.\n\n")
+
+	sb.WriteString("## Promote to pkg/\n\n")
+	sb.WriteString(fmt.Sprintf("Internal packages imported by at least %d pkg/ packages whose API is not marked experimental or deprecated.\n\n", report.MinImporters))
+	if len(report.Promote) == 0 {
+		sb.WriteString("No candidates.\n")
+	} else {
+		sb.WriteString("| Package | Suggested | Importers | Stability |\n")
+		sb.WriteString("|---------|-----------|-----------|-----------|\n")
+		for _, entry := range report.Promote {
+			sb.WriteString(fmt.Sprintf("| `%s` | `%s` | %d: %s | %s |\n",
+				entry.Package, entry.Target, len(entry.Importers), strings.Join(entry.Importers, ", "), stabilityLabel(entry.Stability)))
+		}
+		sb.WriteString("\nAnnotate unannotated candidates with `// archlint:stability stable` once their API is reviewed.\n")
+	}
+
+	sb.WriteString("\n## Demote to internal/\n\n")
+	sb.WriteString("pkg/ packages used only by the packages of one cmd/ binary.\n\n")
+	if len(report.Demote) == 0 {
+		sb.WriteString("No candidates.\n")
+	} else {
+		sb.WriteString("| Package | Suggested | Importers |\n")
+		sb.WriteString("|---------|-----------|-----------|\n")
+		for _, entry := range report.Demote {
+			sb.WriteString(fmt.Sprintf("| `%s` | `%s` | %s |\n", entry.Package, entry.Target, strings.Join(entry.Importers, ", ")))
+		}
+	}
+
+	return sb.String()
+}
+
+// stabilityLabel shows a stability annotation, or a dash for packages without one
+func stabilityLabel(stability string) string {
+	if stability == "" {
+		return "-"
+	}
+	return stability
+}
@@ -0,0 +1,76 @@
+package output_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestFormatPromotions_Markdown(t *testing.T) {
+	report := output.PromotionReport{
+		MinImporters: 2,
+		Promote: []output.PromotionEntry{
+			{Package: "internal/money", Target: "pkg/money", Importers: []string{"pkg/billing", "pkg/orders"}, Stability: "stable"},
+			{Package: "internal/ids", Target: "pkg/ids", Importers: []string{"pkg/billing", "pkg/orders"}},
+		},
+		Demote: []output.PromotionEntry{
+			{Package: "pkg/flags", Target: "internal/flags", Importers: []string{"cmd/server"}},
+		},
+	}
+
+	result, err := output.FormatPromotions(report, "markdown")
+	if err != nil {
+		t.Fatalf("FormatPromotions failed: %v", err)
+	}
+
+	expected := []string{
+		"# Package Promotion Advisor",
+		"imported by at least 2 pkg/ packages",
+		"| `internal/money` | `pkg/money` | 2: pkg/billing, pkg/orders | stable |",
+		"| `internal/ids` | `pkg/ids` | 2: pkg/billing, pkg/orders | - |",
+		"| `pkg/flags` | `internal/flags` | cmd/server |",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+
+	empty, err := output.FormatPromotions(output.PromotionReport{MinImporters: 3}, "markdown")
+	if err != nil {
+		t.Fatalf("FormatPromotions failed: %v", err)
+	}
+	if strings.Count(empty, "No candidates.") != 2 {
+		t.Errorf("expected both sections to be empty, got:\n%s", empty)
+	}
+}
+
+func TestFormatPromotions_JSON(t *testing.T) {
+	report := output.PromotionReport{
+		MinImporters: 3,
+		Promote:      []output.PromotionEntry{{Package: "internal/money", Target: "pkg/money", Importers: []string{"pkg/a", "pkg/b", "pkg/c"}}},
+		Demote:       []output.PromotionEntry{},
+	}
+
+	result, err := output.FormatPromotions(report, "json")
+	if err != nil {
+		t.Fatalf("FormatPromotions failed: %v", err)
+	}
+
+	var decoded output.PromotionReport
+	if err := json.Unmarshal([]byte(result), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+	if decoded.MinImporters != 3 || len(decoded.Promote) != 1 || decoded.Promote[0].Target != "pkg/money" || len(decoded.Demote) != 0 {
+		t.Errorf("unexpected round trip result: %+v", decoded)
+	}
+	if strings.Contains(result, "stability") {
+		t.Errorf("expected empty stability to be omitted, got:\n%s", result)
+	}
+
+	if _, err := output.FormatPromotions(report, "csv"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
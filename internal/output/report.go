@@ -0,0 +1,196 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReportVersion is the version of the JSON report layout written by -format=json.
+// It is bumped whenever existing fields change meaning.
+const ReportVersion = 1
+
+// Report is the machine-readable result of one linter run, the input of aggregate
+type Report struct {
+	ReportVersion int               `json:"report_version"`
+	Module        string            `json:"module"`
+	Preset        string            `json:"preset,omitempty"`
+	Profile       string            `json:"profile,omitempty"`
+	Failed        bool              `json:"failed"` // The violations fail the build
+	Violations    []ReportViolation `json:"violations"`
+	Coverage      *ReportCoverage   `json:"coverage,omitempty"` // Set when test coverage is enabled
+	Score         *Score            `json:"score,omitempty"`    // Conformance score, unset if a fail-fast run stopped early
+}
+
+// ReportViolation is a violation in a JSON report
+type ReportViolation struct {
+	Type     string `json:"type"`
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Issue    string `json:"issue"`
+	Rule     string `json:"rule,omitempty"`
+	Fix      string `json:"fix,omitempty"`
+	Severity string `json:"severity"` // error, warning or info
+	RuleKey  string `json:"rule_key,omitempty"`
+	Code     string `json:"code,omitempty"`     // Stable code of the violation type, e.g. ARCH005
+	FixCode  string `json:"fix_code,omitempty"` // Stable code of the fix, e.g. ARCH005-3f2a1c
+	Source   string `json:"source,omitempty"`   // Configuration layer that defined the rule
+	Team     string `json:"team,omitempty"`     // Owning team from rules.ownership.teams
+}
+
+// ReportCoverage holds the test coverage measured by a run
+type ReportCoverage struct {
+	Overall  float64                 `json:"overall"`
+	Packages []ReportPackageCoverage `json:"packages,omitempty"`
+}
+
+// ReportPackageCoverage is the test coverage of one package
+type ReportPackageCoverage struct {
+	Package  string  `json:"package"`
+	Coverage float64 `json:"coverage"`
+	HasTests bool    `json:"has_tests"`
+}
+
+// FormatReport renders a report as indented JSON
+func FormatReport(report Report) (string, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// AggregateFormats lists the supported aggregate output formats
+var AggregateFormats = []string{"json", "markdown"}
+
+// UnownedTeam groups violations in files no team owns
+const UnownedTeam = "unowned"
+
+// AggregateDataset merges the reports of many repositories
+type AggregateDataset struct {
+	RepositoryCount  int                 `json:"repository_count"`
+	ViolationCount   int                 `json:"violations"`
+	ErrorCount       int                 `json:"errors"`
+	AverageCoverage  *float64            `json:"average_coverage,omitempty"` // Mean overall coverage of the repositories that measure it
+	AverageScore     *float64            `json:"average_score,omitempty"`    // Mean conformance score of the reports that have one
+	PresetAdoption   map[string]int      `json:"preset_adoption"`            // Preset -> number of repositories
+	ViolationsByType map[string]int      `json:"violations_by_type"`
+	Teams            []TeamSummary       `json:"teams"` // Most violations first
+	Repositories     []RepositorySummary `json:"repositories"`
+}
+
+// TeamSummary counts the violations in the files a team owns, across repositories
+type TeamSummary struct {
+	Team             string         `json:"team"`
+	Repositories     int            `json:"repositories"` // Repositories with violations of the team
+	Violations       int            `json:"violations"`
+	Errors           int            `json:"errors"`
+	ViolationsByType map[string]int `json:"violations_by_type"`
+}
+
+// RepositorySummary is the aggregated result of one report
+type RepositorySummary struct {
+	Report           string         `json:"report"` // Report file
+	Module           string         `json:"module"`
+	Preset           string         `json:"preset"`
+	Failed           bool           `json:"failed"`
+	Violations       int            `json:"violations"`
+	Errors           int            `json:"errors"`
+	Coverage         *float64       `json:"coverage,omitempty"`
+	Score            *int           `json:"score,omitempty"` // Conformance score, nil for reports without one
+	ViolationsByType map[string]int `json:"violations_by_type,omitempty"`
+}
+
+// FormatAggregate renders an aggregated dataset as json or markdown
+func FormatAggregate(dataset AggregateDataset, format string) (string, error) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(dataset, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case "markdown":
+		return generateAggregateMarkdown(dataset), nil
+	default:
+		return "", fmt.Errorf("unsupported aggregate format %q (supported: %s)", format, strings.Join(AggregateFormats, ", "))
+	}
+}
+
+func generateAggregateMarkdown(dataset AggregateDataset) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Architecture Dashboard\n\n")
+	sb.WriteString(fmt.Sprintf("%d repositories, %d violations (%d errors)", dataset.RepositoryCount, dataset.ViolationCount, dataset.ErrorCount))
+	if dataset.AverageCoverage != nil {
+		sb.WriteString(fmt.Sprintf(", average coverage %.1f%%", *dataset.AverageCoverage))
+	}
+	if dataset.AverageScore != nil {
+		sb.WriteString(fmt.Sprintf(", average conformance score %.0f", *dataset.AverageScore))
+	}
+	sb.WriteString("\n\n")
+
+	sb.WriteString("## Repositories\n\n")
+	sb.WriteString("| Module | Preset | Violations | Errors | Coverage | Score | Status |\n")
+	sb.WriteString("|--------|--------|------------|--------|----------|-------|--------|\n")
+	for _, repo := range dataset.Repositories {
+		coverage := "-"
+		if repo.Coverage != nil {
+			coverage = fmt.Sprintf("%.1f%%", *repo.Coverage)
+		}
+		score := "-"
+		if repo.Score != nil {
+			score = strconv.Itoa(*repo.Score)
+		}
+		status := "passing"
+		if repo.Failed {
+			status = "failing"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %s | %s | %s |\n", repo.Module, repo.Preset, repo.Violations, repo.Errors, coverage, score, status))
+	}
+
+	if len(dataset.Teams) > 0 {
+		sb.WriteString("\n## Violations by Team\n\n")
+		sb.WriteString("| Team | Repositories | Violations | Errors | Types |\n")
+		sb.WriteString("|------|--------------|------------|--------|-------|\n")
+		for _, team := range dataset.Teams {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %s |\n", team.Team, team.Repositories, team.Violations, team.Errors, formatViolationCounts(team.ViolationsByType)))
+		}
+	}
+
+	sb.WriteString("\n## Preset Adoption\n\n")
+	sb.WriteString("| Preset | Repositories |\n")
+	sb.WriteString("|--------|--------------|\n")
+	for _, preset := range sortedByCount(dataset.PresetAdoption) {
+		sb.WriteString(fmt.Sprintf("| %s | %d |\n", preset, dataset.PresetAdoption[preset]))
+	}
+
+	if len(dataset.ViolationsByType) > 0 {
+		sb.WriteString("\n## Violations by Type\n\n")
+		sb.WriteString("| Type | Violations |\n")
+		sb.WriteString("|------|------------|\n")
+		for _, violationType := range sortedByCount(dataset.ViolationsByType) {
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", violationType, dataset.ViolationsByType[violationType]))
+		}
+	}
+
+	return sb.String()
+}
+
+// sortedByCount returns the keys of counts, highest count first, then by name
+func sortedByCount(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,53 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestFormatAggregate_Markdown(t *testing.T) {
+	coverage := 72.5
+	averageScore, score := 81.0, 81
+	dataset := output.AggregateDataset{
+		RepositoryCount:  2,
+		ViolationCount:   3,
+		ErrorCount:       2,
+		AverageCoverage:  &coverage,
+		AverageScore:     &averageScore,
+		PresetAdoption:   map[string]int{"ddd": 1, "hexagonal": 1},
+		ViolationsByType: map[string]int{"Forbidden Import": 2, "Missing Package Doc": 1},
+		Teams: []output.TeamSummary{
+			{Team: "checkout", Repositories: 2, Violations: 3, Errors: 2, ViolationsByType: map[string]int{"Forbidden Import": 2, "Missing Package Doc": 1}},
+		},
+		Repositories: []output.RepositorySummary{
+			{Module: "github.com/org/billing", Preset: "ddd", Failed: true, Violations: 2, Errors: 1, Coverage: &coverage, Score: &score},
+			{Module: "github.com/org/search", Preset: "hexagonal", Violations: 1, Errors: 1},
+		},
+	}
+
+	result, err := output.FormatAggregate(dataset, "markdown")
+	if err != nil {
+		t.Fatalf("FormatAggregate failed: %v", err)
+	}
+
+	expected := []string{
+		"# Architecture Dashboard",
+		"2 repositories, 3 violations (2 errors), average coverage 72.5%, average conformance score 81",
+		"| github.com/org/billing | ddd | 2 | 1 | 72.5% | 81 | failing |",
+		"| github.com/org/search | hexagonal | 1 | 1 | - | - | passing |",
+		"| checkout | 2 | 3 | 2 | Forbidden Import: 2; Missing Package Doc: 1 |",
+		"| ddd | 1 |",
+		"| Forbidden Import | 2 |",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+
+	if _, err := output.FormatAggregate(dataset, "csv"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,18 @@
+package output
+
+// Score is the architecture conformance score of a run, from 0 (worst) to 100 (best).
+// It is the weighted average of its components, each also from 0 to 100.
+type Score struct {
+	Score      int  `json:"score"`
+	Violations int  `json:"violations"`         // Violations per package, weighted by severity
+	Coverage   *int `json:"coverage,omitempty"` // Overall test coverage, nil if not measured
+	Coupling   int  `json:"coupling"`           // Local imports per package
+}
+
+// FormatScore renders the conformance score as a line of the violation report
+func FormatScore(score Score, messages *Messages) string {
+	if score.Coverage == nil {
+		return messages.Text("score.summary", score.Score, score.Violations, score.Coupling) + "\n"
+	}
+	return messages.Text("score.summary_coverage", score.Score, score.Violations, *score.Coverage, score.Coupling) + "\n"
+}
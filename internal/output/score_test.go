@@ -0,0 +1,44 @@
+package output_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestFormatScore(t *testing.T) {
+	coverage := 64
+	german, err := output.NewMessages("de")
+	if err != nil {
+		t.Fatalf("NewMessages failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		score    output.Score
+		messages *output.Messages
+		want     string
+	}{
+		{
+			name:  "without coverage",
+			score: output.Score{Score: 84, Violations: 80, Coupling: 97},
+			want:  "Conformance score: 84/100 (violations 80, coupling 97)\n",
+		},
+		{
+			name:  "with coverage",
+			score: output.Score{Score: 79, Violations: 80, Coverage: &coverage, Coupling: 97},
+			want:  "Conformance score: 79/100 (violations 80, coverage 64, coupling 97)\n",
+		},
+		{
+			name:     "german",
+			score:    output.Score{Score: 79, Violations: 80, Coverage: &coverage, Coupling: 97},
+			messages: german,
+			want:     "Konformitätswert: 79/100 (Verstöße 80, Abdeckung 64, Kopplung 97)\n",
+		},
+	}
+	for _, tt := range tests {
+		if got := output.FormatScore(tt.score, tt.messages); got != tt.want {
+			t.Errorf("%s: FormatScore = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package output
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// layerPage describes a layer page of the split documentation
+type layerPage struct {
+	Name     string
+	Title    string
+	Packages []PackageIndexInfo
+}
+
+// GenerateSplitDocumentation creates the architecture documentation as a set of
+// cross-linked pages instead of one large file: an index.md overview, one page per
+// layer under layers/ and one page per package under packages/<package path>.md.
+// packages holds the details of every package; the result maps page paths
+// (relative to the output directory) to their content.
+func GenerateSplitDocumentation(doc FullDocumentation, packages []PackageDocumentation) map[string]string {
+	pages := make(map[string]string)
+
+	byLayer := buildPackagesByLayer(doc.Files)
+	layers := []layerPage{
+		{Name: "cmd", Title: "Application Entry Points", Packages: byLayer.CmdPackages},
+		{Name: "pkg", Title: "Public APIs", Packages: byLayer.PkgPackages},
+		{Name: "internal", Title: "Isolated Primitives", Packages: byLayer.InternalPackages},
+	}
+
+	// Only packages listed on a layer page get a page, so every link has a target
+	layerOf := make(map[string]string)
+	for _, layer := range layers {
+		for _, pkg := range layer.Packages {
+			layerOf[pkg.Path] = layer.Name
+		}
+	}
+
+	pkgDeps := buildPackageDependencies(doc.Graph)
+	importedBy := make(map[string][]string)
+	for pkgPath, deps := range pkgDeps {
+		for _, dep := range deps.LocalDeps {
+			importedBy[dep] = append(importedBy[dep], pkgPath)
+		}
+	}
+
+	pages["index.md"] = splitIndexPage(doc, layers)
+
+	for _, layer := range layers {
+		if len(layer.Packages) == 0 {
+			continue
+		}
+		pages[layerPagePath(layer.Name)] = splitLayerPage(layer, pkgDeps, layerOf)
+	}
+
+	for _, pkgDoc := range packages {
+		layer, ok := layerOf[pkgDoc.PackagePath]
+		if !ok {
+			continue
+		}
+		pages[packagePagePath(pkgDoc.PackagePath)] = splitPackagePage(pkgDoc, layer, pkgDeps[pkgDoc.PackagePath].LocalDeps, importedBy[pkgDoc.PackagePath], layerOf)
+	}
+
+	return pages
+}
+
+// splitIndexPage writes the entry page linking to the layer pages
+func splitIndexPage(doc FullDocumentation, layers []layerPage) string {
+	var sb strings.Builder
+
+	sections := make(map[string]string)
+	for _, s := range buildIndexDocumentation(doc).sections() {
+		sections[s.name] = s.content
+	}
+
+	sb.WriteString("# Project Architecture Index\n\n")
+	sb.WriteString(fmt.Sprintf("**Generated by go-arch-lint on %s**\n\n", time.Now().Format("2006-01-02")))
+	sb.WriteString(sections["quick_reference"])
+	sb.WriteString(sections["architecture_summary"])
+	sb.WriteString(sections["rules"])
+
+	sb.WriteString("## Layers\n\n")
+	for _, layer := range layers {
+		if len(layer.Packages) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("- [%s](%s) - %s (%d packages)\n", layer.Name, layerPagePath(layer.Name), layer.Title, len(layer.Packages)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(sections["glossary"])
+	sb.WriteString(sections["statistics"])
+
+	return sb.String()
+}
+
+// splitLayerPage writes the package directory of one layer
+func splitLayerPage(layer layerPage, pkgDeps map[string]PackageDependencies, layerOf map[string]string) string {
+	var sb strings.Builder
+	pagePath := layerPagePath(layer.Name)
+
+	sb.WriteString(fmt.Sprintf("[Index](%s) › %s\n\n", relativeLink(pagePath, "index.md"), layer.Name))
+	sb.WriteString(fmt.Sprintf("# Layer: %s (%s)\n\n", layer.Name, layer.Title))
+
+	for _, pkg := range layer.Packages {
+		sb.WriteString(fmt.Sprintf("## [%s](%s)\n\n", pkg.Path, relativeLink(pagePath, packagePagePath(pkg.Path))))
+		if pkg.Description != "" {
+			sb.WriteString(pkg.Description + "\n\n")
+		}
+		sb.WriteString(fmt.Sprintf("- **Package**: %s\n", pkg.Name))
+		sb.WriteString(fmt.Sprintf("- **Files**: %d | **Exports**: %d\n", pkg.FileCount, pkg.ExportCount))
+		if len(pkg.KeyExports) > 0 {
+			sb.WriteString(fmt.Sprintf("- **Key exports**: %s\n", strings.Join(pkg.KeyExports, ", ")))
+		}
+		if deps := pkgDeps[pkg.Path].LocalDeps; len(deps) > 0 {
+			sb.WriteString(fmt.Sprintf("- **Imports**: %s\n", packageLinks(pagePath, deps, layerOf)))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// splitPackagePage writes the details of one package with links to related packages
+func splitPackagePage(doc PackageDocumentation, layer string, imports, importedBy []string, layerOf map[string]string) string {
+	var sb strings.Builder
+	pagePath := packagePagePath(doc.PackagePath)
+
+	sb.WriteString(fmt.Sprintf("[Index](%s) › [%s](%s) › %s\n\n",
+		relativeLink(pagePath, "index.md"), layer, relativeLink(pagePath, layerPagePath(layer)), doc.PackagePath))
+
+	for _, s := range buildPackageDocumentation(doc).sections() {
+		if s.name == "footer" {
+			break
+		}
+		// Related Packages below links the importing packages instead
+		if s.name == "dependents" {
+			continue
+		}
+		sb.WriteString(s.content)
+	}
+
+	sb.WriteString("## Related Packages\n\n")
+	if len(imports) == 0 && len(importedBy) == 0 {
+		sb.WriteString("No local packages import or are imported by this package.\n\n")
+	}
+	if len(imports) > 0 {
+		sb.WriteString(fmt.Sprintf("- **Imports**: %s\n", packageLinks(pagePath, imports, layerOf)))
+	}
+	if len(importedBy) > 0 {
+		sb.WriteString(fmt.Sprintf("- **Imported by**: %s\n", packageLinks(pagePath, importedBy, layerOf)))
+	}
+	if len(imports) > 0 || len(importedBy) > 0 {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("---\n\n")
+	sb.WriteString("*Generated by `go-arch-lint docs --split`*\n")
+
+	return sb.String()
+}
+
+// packageLinks formats package paths sorted, as links where the package has a page
+func packageLinks(fromPage string, pkgPaths []string, layerOf map[string]string) string {
+	sorted := make([]string, len(pkgPaths))
+	copy(sorted, pkgPaths)
+	sort.Strings(sorted)
+
+	links := make([]string, len(sorted))
+	for i, pkgPath := range sorted {
+		if _, ok := layerOf[pkgPath]; ok {
+			links[i] = fmt.Sprintf("[%s](%s)", pkgPath, relativeLink(fromPage, packagePagePath(pkgPath)))
+		} else {
+			links[i] = fmt.Sprintf("`%s`", pkgPath)
+		}
+	}
+	return strings.Join(links, ", ")
+}
+
+// layerPagePath returns the page path of a layer
+func layerPagePath(layer string) string {
+	return "layers/" + layer + ".md"
+}
+
+// packagePagePath returns the page path of a package, mirroring its directory
+func packagePagePath(pkgPath string) string {
+	return "packages/" + pkgPath + ".md"
+}
+
+// relativeLink returns the relative link from one page to another
+func relativeLink(from, to string) string {
+	fromDir := path.Dir(from)
+	if fromDir == "." {
+		return to
+	}
+	return strings.Repeat("../", strings.Count(fromDir, "/")+1) + to
+}
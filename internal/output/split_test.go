@@ -0,0 +1,108 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestGenerateSplitDocumentation(t *testing.T) {
+	linterFile := &testFileWithAPIForIndex{
+		relPath:    "pkg/linter/linter.go",
+		pkgName:    "linter",
+		packageDoc: "Package linter runs the checks.",
+		exportedDecls: []output.ExportedDecl{
+			&testExportedDeclForIndex{name: "Run", kind: "func", signature: "Run() error"},
+		},
+	}
+	configFile := &testFileWithAPIForIndex{
+		relPath: "internal/config/config.go",
+		pkgName: "config",
+		exportedDecls: []output.ExportedDecl{
+			&testExportedDeclForIndex{name: "Config", kind: "type", signature: "Config"},
+		},
+	}
+	mainFile := &testFileWithAPIForIndex{relPath: "cmd/app/main.go", pkgName: "main"}
+
+	graph := &testGraphForIndex{
+		nodes: []output.FileNode{
+			&testFileNodeForIndex{
+				relPath:      "cmd/app/main.go",
+				pkgName:      "main",
+				dependencies: []output.Dependency{&testDependencyForIndex{importPath: "github.com/test/project/pkg/linter", isLocal: true, localPath: "pkg/linter"}},
+			},
+			&testFileNodeForIndex{
+				relPath: "pkg/linter/linter.go",
+				pkgName: "linter",
+				dependencies: []output.Dependency{
+					&testDependencyForIndex{importPath: "github.com/test/project/internal/config", isLocal: true, localPath: "internal/config"},
+					&testDependencyForIndex{importPath: "github.com/test/project/tools/gen", isLocal: true, localPath: "tools/gen"},
+				},
+			},
+		},
+	}
+
+	doc := output.FullDocumentation{
+		Rules: output.RulesInfo{
+			DirectoriesImport: map[string][]string{"cmd": {"pkg"}, "pkg": {"internal"}, "internal": {}},
+		},
+		Graph:        graph,
+		Files:        []output.FileWithAPI{linterFile, configFile, mainFile},
+		FileCount:    3,
+		PackageCount: 3,
+	}
+	packages := []output.PackageDocumentation{
+		{PackageName: "linter", PackagePath: "pkg/linter", Files: []output.FileWithAPI{linterFile}, FileCount: 1, ExportCount: 1},
+		{PackageName: "config", PackagePath: "internal/config", Files: []output.FileWithAPI{configFile}, FileCount: 1, ExportCount: 1},
+		{PackageName: "main", PackagePath: "cmd/app", Files: []output.FileWithAPI{mainFile}, FileCount: 1},
+		{PackageName: "gen", PackagePath: "tools/gen", FileCount: 1}, // Outside the layers: no page
+	}
+
+	pages := output.GenerateSplitDocumentation(doc, packages)
+
+	expectedPages := []string{
+		"index.md",
+		"layers/cmd.md", "layers/pkg.md", "layers/internal.md",
+		"packages/cmd/app.md", "packages/pkg/linter.md", "packages/internal/config.md",
+	}
+	if len(pages) != len(expectedPages) {
+		t.Errorf("expected %d pages, got %d", len(expectedPages), len(pages))
+	}
+	for _, page := range expectedPages {
+		if _, ok := pages[page]; !ok {
+			t.Errorf("expected page %s", page)
+		}
+	}
+
+	index := pages["index.md"]
+	if !strings.Contains(index, "- [pkg](layers/pkg.md) - Public APIs (1 packages)") || !strings.Contains(index, "## Architectural Rules") {
+		t.Errorf("expected index to link layer pages, got:\n%s", index)
+	}
+	if strings.Contains(index, "## Package Directory") {
+		t.Errorf("expected package directory to move to layer pages, got:\n%s", index)
+	}
+
+	layer := pages["layers/pkg.md"]
+	if !strings.HasPrefix(layer, "[Index](../index.md) › pkg\n") ||
+		!strings.Contains(layer, "## [pkg/linter](../packages/pkg/linter.md)\n\nPackage linter runs the checks.") ||
+		!strings.Contains(layer, "- **Imports**: [internal/config](../packages/internal/config.md), `tools/gen`") {
+		t.Errorf("unexpected layer page:\n%s", layer)
+	}
+
+	pkgPage := pages["packages/pkg/linter.md"]
+	if !strings.HasPrefix(pkgPage, "[Index](../../index.md) › [pkg](../../layers/pkg.md) › pkg/linter\n\n# Package: linter") {
+		t.Errorf("expected breadcrumb before package documentation, got:\n%s", pkgPage)
+	}
+	if !strings.Contains(pkgPage, "- **Imports**: [internal/config](../../packages/internal/config.md), `tools/gen`\n") ||
+		!strings.Contains(pkgPage, "- **Imported by**: [cmd/app](../../packages/cmd/app.md)\n") {
+		t.Errorf("expected related package links, got:\n%s", pkgPage)
+	}
+	if strings.Contains(pkgPage, "-format=package") || !strings.HasSuffix(pkgPage, "*Generated by `go-arch-lint docs --split`*\n") {
+		t.Errorf("expected split docs footer, got:\n%s", pkgPage)
+	}
+
+	if !strings.Contains(pages["packages/internal/config.md"], "- **Imported by**: [pkg/linter](../../packages/pkg/linter.md)") {
+		t.Errorf("expected reverse link to pkg/linter, got:\n%s", pages["packages/internal/config.md"])
+	}
+}
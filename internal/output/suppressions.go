@@ -0,0 +1,94 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SuppressionFormats lists the supported suppression report formats
+var SuppressionFormats = []string{"markdown", "json"}
+
+// SuppressionEntry is an active exemption from the rules
+type SuppressionEntry struct {
+	Kind     string `json:"kind"`               // Mechanism, e.g. "ignored path" or "inline ignore"
+	Key      string `json:"key"`                // Dotted configuration key
+	Target   string `json:"target"`             // What is exempt
+	Reason   string `json:"reason,omitempty"`   // Recorded justification
+	Until    string `json:"until,omitempty"`    // For deferred rules and expiring directives: date from which the rule fails the build
+	Source   string `json:"source"`             // Configuration layer defining it, or "archlint:ignore"
+	Location string `json:"location,omitempty"` // File and line, e.g. ".goarchlint:12"
+	Added    string `json:"added,omitempty"`    // Date of the commit that last changed the line
+	AgeDays  *int   `json:"age_days,omitempty"` // Days since Added, nil if unknown
+}
+
+// FormatSuppressions renders the active suppressions as markdown or json
+func FormatSuppressions(entries []SuppressionEntry, format string) (string, error) {
+	switch format {
+	case "markdown":
+		return generateSuppressionsMarkdown(entries), nil
+	case "json":
+		if entries == nil {
+			entries = []SuppressionEntry{}
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported suppressions format %q (supported: %s)", format, strings.Join(SuppressionFormats, ", "))
+	}
+}
+
+func generateSuppressionsMarkdown(entries []SuppressionEntry) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Active Suppressions\n\n")
+	if len(entries) == 0 {
+		sb.WriteString("Nothing is exempt from the architecture rules.\n")
+		return sb.String()
+	}
+
+	unjustified := 0
+	for _, entry := range entries {
+		if entry.Reason == "" {
+			unjustified++
+		}
+	}
+	sb.WriteString(fmt.Sprintf("%d suppression(s) exempt code from the architecture rules, %d without a recorded reason.\n", len(entries), unjustified))
+	sb.WriteString("Age is the time since the configuration line was last changed.\n\n")
+
+	sb.WriteString("| Kind | Target | Reason | Location | Source | Age |\n")
+	sb.WriteString("|------|--------|--------|----------|--------|-----|\n")
+	for _, entry := range entries {
+		reason := entry.Reason
+		if entry.Until != "" {
+			reason = strings.TrimSpace(reason + " enforced from " + entry.Until)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | `%s` | %s | %s | %s | %s |\n",
+			entry.Kind, entry.Target, orDash(reason), orDash(entry.Location), entry.Source, suppressionAge(entry)))
+	}
+
+	return sb.String()
+}
+
+// suppressionAge describes how long a suppression has been in place
+func suppressionAge(entry SuppressionEntry) string {
+	if entry.AgeDays == nil {
+		return "-"
+	}
+	unit := "days"
+	if *entry.AgeDays == 1 {
+		unit = "day"
+	}
+	return fmt.Sprintf("%d %s (%s)", *entry.AgeDays, unit, entry.Added)
+}
+
+// orDash returns s, or "-" for an empty table cell
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
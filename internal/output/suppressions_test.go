@@ -0,0 +1,70 @@
+package output_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestFormatSuppressions(t *testing.T) {
+	age := 30
+	entries := []output.SuppressionEntry{
+		{Kind: "whitebox tests", Key: "rules.test_files.allow_whitebox", Target: "internal/parser", Reason: "Table tests", Source: ".goarchlint", Location: ".goarchlint:12", Added: "2024-05-01", AgeDays: &age},
+		{Kind: "deferred rule", Key: "rules.active_from", Target: "rules.directories_import.cmd", Until: "2030-01-01", Source: ".goarchlint", Location: ".goarchlint:20"},
+		{Kind: "ignored path", Key: "ignore_paths", Target: "vendor", Source: "defaults"},
+	}
+
+	markdown, err := output.FormatSuppressions(entries, "markdown")
+	if err != nil {
+		t.Fatalf("FormatSuppressions failed: %v", err)
+	}
+	expected := []string{
+		"# Active Suppressions\n",
+		"3 suppression(s) exempt code from the architecture rules, 2 without a recorded reason.\n",
+		"| Kind | Target | Reason | Location | Source | Age |\n",
+		"| whitebox tests | `internal/parser` | Table tests | .goarchlint:12 | .goarchlint | 30 days (2024-05-01) |\n",
+		"| deferred rule | `rules.directories_import.cmd` | enforced from 2030-01-01 | .goarchlint:20 | .goarchlint | - |\n",
+		"| ignored path | `vendor` | - | - | defaults | - |\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, markdown)
+		}
+	}
+
+	data, err := output.FormatSuppressions(entries, "json")
+	if err != nil {
+		t.Fatalf("FormatSuppressions failed: %v", err)
+	}
+	var decoded []output.SuppressionEntry
+	if err := json.Unmarshal([]byte(data), &decoded); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, data)
+	}
+	if len(decoded) != 3 || decoded[0].AgeDays == nil || *decoded[0].AgeDays != 30 || decoded[2].AgeDays != nil {
+		t.Errorf("unexpected json round trip: %s", data)
+	}
+
+	if _, err := output.FormatSuppressions(entries, "csv"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestFormatSuppressions_Empty(t *testing.T) {
+	markdown, err := output.FormatSuppressions(nil, "markdown")
+	if err != nil {
+		t.Fatalf("FormatSuppressions failed: %v", err)
+	}
+	if !strings.Contains(markdown, "Nothing is exempt from the architecture rules.") {
+		t.Errorf("expected note for no suppressions, got:\n%s", markdown)
+	}
+
+	data, err := output.FormatSuppressions(nil, "json")
+	if err != nil {
+		t.Fatalf("FormatSuppressions failed: %v", err)
+	}
+	if strings.TrimSpace(data) != "[]" {
+		t.Errorf("expected empty json list, got %q", data)
+	}
+}
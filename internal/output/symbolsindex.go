@@ -0,0 +1,76 @@
+package output
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+)
+
+// SymbolEntry is a line of the symbols index: an exported symbol with its package,
+// signature, doc summary and location, self-contained so it can be embedded on its own
+type SymbolEntry struct {
+	ID        string `json:"id"`                  // Package import path and symbol, e.g. "example.com/app/cache.Cache.Get"
+	Package   string `json:"package"`             // Import path of the package
+	Name      string `json:"name"`                // Symbol as Example functions name it: "Cache.Get" for methods
+	Kind      string `json:"kind"`                // "func", "type", "const" or "var"
+	TypeKind  string `json:"type_kind,omitempty"` // For types: "struct", "interface" or "other"
+	Signature string `json:"signature"`
+	Doc       string `json:"doc,omitempty"` // First sentence of the doc comment
+	File      string `json:"file"`          // Path relative to the project root
+	Line      int    `json:"line"`
+}
+
+// SymbolsIndex lists the exported symbols of the non-test files, sorted by package,
+// file and line. Packages are named by import path under module.
+func SymbolsIndex(module string, files []FileWithAPI) []SymbolEntry {
+	entries := []SymbolEntry{}
+	for _, file := range files {
+		if strings.HasSuffix(file.GetRelPath(), "_test.go") {
+			continue
+		}
+		importPath := module
+		if dir := path.Dir(file.GetRelPath()); dir != "." {
+			importPath = module + "/" + dir
+		}
+		for _, decl := range file.GetExportedDecls() {
+			name := declSymbol(decl)
+			entries = append(entries, SymbolEntry{
+				ID:        importPath + "." + name,
+				Package:   importPath,
+				Name:      name,
+				Kind:      decl.GetKind(),
+				TypeKind:  decl.GetTypeKind(),
+				Signature: decl.GetSignature(),
+				Doc:       packageSynopsis(decl.GetDoc()),
+				File:      file.GetRelPath(),
+				Line:      decl.GetLine(),
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Package != entries[j].Package {
+			return entries[i].Package < entries[j].Package
+		}
+		if entries[i].File != entries[j].File {
+			return entries[i].File < entries[j].File
+		}
+		return entries[i].Line < entries[j].Line
+	})
+	return entries
+}
+
+// FormatSymbolsIndex renders the symbols index as JSON Lines, one symbol per line
+func FormatSymbolsIndex(entries []SymbolEntry) (string, error) {
+	var sb strings.Builder
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return "", err
+		}
+		sb.Write(data)
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
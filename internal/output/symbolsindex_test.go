@@ -0,0 +1,74 @@
+package output_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func TestSymbolsIndex(t *testing.T) {
+	files := []output.FileWithAPI{
+		&testFileWithAPI{
+			relPath: "pkg/cache/cache.go",
+			pkg:     "cache",
+			decls: []output.ExportedDecl{
+				&testExportedDecl{name: "Get", kind: "func", signature: "(*Cache[K, V]) Get(K) (V, bool)", doc: "Get returns a cached value. Expired values are missing.", line: 20},
+				&testExportedDecl{name: "Cache", kind: "type", typeKind: "struct", signature: "Cache[K comparable, V any]", doc: "Cache is an LRU cache", line: 8},
+			},
+		},
+		&testFileWithAPI{
+			relPath: "pkg/cache/cache_test.go",
+			pkg:     "cache_test",
+			decls:   []output.ExportedDecl{&testExportedDecl{name: "TestGet", kind: "func", signature: "TestGet(*testing.T)", line: 5}},
+		},
+		&testFileWithAPI{
+			relPath: "main.go",
+			pkg:     "main",
+			decls:   []output.ExportedDecl{&testExportedDecl{name: "Version", kind: "var", signature: "Version", line: 3}},
+		},
+	}
+
+	entries := output.SymbolsIndex("example.com/app", files)
+	want := []output.SymbolEntry{
+		{ID: "example.com/app.Version", Package: "example.com/app", Name: "Version", Kind: "var", Signature: "Version", File: "main.go", Line: 3},
+		{ID: "example.com/app/pkg/cache.Cache", Package: "example.com/app/pkg/cache", Name: "Cache", Kind: "type", TypeKind: "struct", Signature: "Cache[K comparable, V any]", Doc: "Cache is an LRU cache", File: "pkg/cache/cache.go", Line: 8},
+		{ID: "example.com/app/pkg/cache.Cache.Get", Package: "example.com/app/pkg/cache", Name: "Cache.Get", Kind: "func", Signature: "(*Cache[K, V]) Get(K) (V, bool)", Doc: "Get returns a cached value.", File: "pkg/cache/cache.go", Line: 20},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d symbols without the test file, got %d: %+v", len(want), len(entries), entries)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("symbol %d:\n got %+v\nwant %+v", i, entries[i], want[i])
+		}
+	}
+}
+
+func TestFormatSymbolsIndex(t *testing.T) {
+	entries := []output.SymbolEntry{
+		{ID: "example.com/app.Run", Package: "example.com/app", Name: "Run", Kind: "func", Signature: "Run() error", File: "main.go", Line: 5},
+		{ID: "example.com/app.Config", Package: "example.com/app", Name: "Config", Kind: "type", TypeKind: "struct", Signature: "Config", Doc: "Config holds the settings.", File: "main.go", Line: 9},
+	}
+
+	result, err := output.FormatSymbolsIndex(entries)
+	if err != nil {
+		t.Fatalf("FormatSymbolsIndex failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(result, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per symbol, got:\n%s", result)
+	}
+	if lines[0] != `{"id":"example.com/app.Run","package":"example.com/app","name":"Run","kind":"func","signature":"Run() error","file":"main.go","line":5}` {
+		t.Errorf("unexpected line without doc and type kind: %s", lines[0])
+	}
+	var entry output.SymbolEntry
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil || entry != entries[1] {
+		t.Errorf("expected the second line to decode to %+v, got %+v (%v)", entries[1], entry, err)
+	}
+
+	if empty, err := output.FormatSymbolsIndex(nil); err != nil || empty != "" {
+		t.Errorf("expected no output without symbols, got %q (%v)", empty, err)
+	}
+}
@@ -0,0 +1,139 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateDocuments lists the documents whose layout can be overridden by a template
+var TemplateDocuments = []string{"index", "full"}
+
+// TemplateData is passed to documentation templates.
+//
+// Sections holds the built-in sections of the document rendered as markdown, keyed
+// by name, so a template can reorder, drop or wrap them and add its own content:
+//   - index: header, quick_reference, architecture_summary, rules, dependency_graph,
+//     binaries, wiring, composition, packages, glossary, ports, guidance, statistics
+//   - full: header, status, toc, structure, rules, dependency_graph, binaries, wiring,
+//     composition, api, glossary, ports, statistics
+type TemplateData struct {
+	Date           string            // Generation date (YYYY-MM-DD)
+	Sections       map[string]string // Built-in sections by name (empty string if a section has no content)
+	SectionOrder   []string          // Section names in built-in order
+	ViolationCount int
+	FileCount      int
+	PackageCount   int
+	Packages       []PackageIndexInfo // Packages of all layers, sorted by path
+	Glossary       []GlossaryEntry
+	Binaries       []Binary          // Main packages with the local packages they are built from
+	Wiring         []WiringEdge      // Blank imports wiring packages in at runtime
+	Roots          []CompositionRoot // Components main packages construct and inject, sorted by package
+	Ports          []Port            // Interfaces of port layers with their implementations, sorted by package
+	Layers         []LayerSize       // Size of the scanned code per layer
+	Score          *Score            // Conformance score (nil: not computed, as for the index)
+	Version        string            // Version of go-arch-lint (empty: unknown)
+}
+
+// RenderDocumentTemplate renders a documentation template for one of TemplateDocuments.
+// Besides the data fields, templates can use the functions "section" (rendered
+// section by name, failing on unknown names) and "badge" (shields.io badge markdown).
+func RenderDocumentTemplate(document, text string, doc FullDocumentation) (string, error) {
+	var builder *docBuilder
+	switch document {
+	case "index":
+		builder = buildIndexDocumentation(doc)
+	case "full":
+		builder = buildFullDocumentation(doc)
+	default:
+		return "", fmt.Errorf("unsupported template document %q (supported: %s)", document, strings.Join(TemplateDocuments, ", "))
+	}
+
+	data := TemplateData{
+		Date:           time.Now().Format("2006-01-02"),
+		Sections:       make(map[string]string),
+		ViolationCount: doc.ViolationCount,
+		FileCount:      doc.FileCount,
+		PackageCount:   doc.PackageCount,
+		Glossary:       buildGlossary(doc.Files, doc.DomainLayers),
+		Binaries:       buildBinaries(doc.Graph),
+		Wiring:         buildRuntimeWiring(doc.Graph),
+		Roots:          doc.Roots,
+		Ports:          sortedPorts(doc.Ports),
+		Layers:         doc.Layers,
+		Score:          doc.Score,
+		Version:        doc.Version,
+	}
+	for _, s := range builder.sections() {
+		data.Sections[s.name] = s.content
+		data.SectionOrder = append(data.SectionOrder, s.name)
+	}
+	layers := buildPackagesByLayer(doc.Files)
+	data.Packages = append(data.Packages, layers.CmdPackages...)
+	data.Packages = append(data.Packages, layers.PkgPackages...)
+	data.Packages = append(data.Packages, layers.InternalPackages...)
+
+	funcs := template.FuncMap{
+		"section": func(name string) (string, error) {
+			content, ok := data.Sections[name]
+			if !ok {
+				return "", fmt.Errorf("unknown %s section %q (available: %s)", document, name, strings.Join(data.SectionOrder, ", "))
+			}
+			return content, nil
+		},
+		"badge": badge,
+	}
+
+	tmpl, err := template.New(document).Funcs(funcs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", document, err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", document, err)
+	}
+	return sb.String(), nil
+}
+
+// badge returns the markdown for a shields.io static badge
+func badge(label string, value interface{}, color string) string {
+	escape := strings.NewReplacer("-", "--", "_", "__", " ", "_", "/", "%2F", "%", "%25")
+	message := fmt.Sprint(value)
+	return fmt.Sprintf("![%s: %s](https://img.shields.io/badge/%s-%s-%s)", label, message, escape.Replace(label), escape.Replace(message), escape.Replace(color))
+}
+
+// docSection is a named part of a generated document
+type docSection struct {
+	name    string
+	content string
+}
+
+// docBuilder accumulates a document and records where each named section starts,
+// so built-in documents can be split into sections for templates
+type docBuilder struct {
+	strings.Builder
+	names  []string
+	starts []int
+}
+
+// section starts a new section; everything written until the next call belongs to it
+func (db *docBuilder) section(name string) {
+	db.names = append(db.names, name)
+	db.starts = append(db.starts, db.Len())
+}
+
+// sections returns the recorded sections in order
+func (db *docBuilder) sections() []docSection {
+	content := db.String()
+	result := make([]docSection, len(db.names))
+	for i, name := range db.names {
+		end := len(content)
+		if i+1 < len(db.starts) {
+			end = db.starts[i+1]
+		}
+		result[i] = docSection{name: name, content: content[db.starts[i]:end]}
+	}
+	return result
+}
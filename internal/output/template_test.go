@@ -0,0 +1,92 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func templateDoc() output.FullDocumentation {
+	return output.FullDocumentation{
+		Rules: output.RulesInfo{
+			DirectoriesImport: map[string][]string{"internal": {}},
+		},
+		Graph:          &testGraphForIndex{},
+		Files:          glossaryFiles(),
+		ViolationCount: 2,
+		FileCount:      3,
+		PackageCount:   3,
+	}
+}
+
+func TestRenderDocumentTemplate_Index(t *testing.T) {
+	text := `# {{.PackageCount}} packages {{badge "arch violations" .ViolationCount "red"}}
+{{section "glossary"}}{{section "rules"}}{{range .Packages}}* {{.Path}}
+{{end}}{{range .Glossary}}[{{.Term}}]{{end}}`
+
+	result, err := output.RenderDocumentTemplate("index", text, templateDoc())
+	if err != nil {
+		t.Fatalf("RenderDocumentTemplate failed: %v", err)
+	}
+
+	if !strings.HasPrefix(result, "# 3 packages ![arch violations: 2](https://img.shields.io/badge/arch_violations-2-red)\n") {
+		t.Errorf("expected header with badge, got:\n%s", result)
+	}
+
+	glossaryIdx := strings.Index(result, "## Glossary")
+	rulesIdx := strings.Index(result, "## Architectural Rules")
+	if glossaryIdx < 0 || rulesIdx < 0 || glossaryIdx > rulesIdx {
+		t.Errorf("expected glossary section before rules section, got:\n%s", result)
+	}
+	if strings.Contains(result, "## Dependency Graph") || strings.Contains(result, "## Statistics") {
+		t.Errorf("expected sections not referenced by the template to be left out, got:\n%s", result)
+	}
+
+	if !strings.Contains(result, "* internal/domain\n* internal/domain/order\n* internal/infra\n") {
+		t.Errorf("expected packages sorted by path, got:\n%s", result)
+	}
+	if !strings.HasSuffix(result, "[Customer][Order][Repository]") {
+		t.Errorf("expected glossary terms, got:\n%s", result)
+	}
+}
+
+func TestRenderDocumentTemplate_SectionsMatchBuiltInLayout(t *testing.T) {
+	doc := templateDoc()
+	doc.Files = doc.Files[:1] // One package, so the full document's package order is stable
+
+	for document, expected := range map[string]string{
+		"index": output.GenerateIndexDocumentation(doc),
+		"full":  output.GenerateFullDocumentation(doc),
+	} {
+		result, err := output.RenderDocumentTemplate(document, `{{range .SectionOrder}}{{index $.Sections .}}{{end}}`, doc)
+		if err != nil {
+			t.Fatalf("RenderDocumentTemplate(%s) failed: %v", document, err)
+		}
+		if result != expected {
+			t.Errorf("%s: expected concatenated sections to reproduce the built-in document\ngot:\n%s\nwant:\n%s", document, result, expected)
+		}
+	}
+}
+
+func TestRenderDocumentTemplate_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		text     string
+		errText  string
+	}{
+		{"unsupported document", "api", "", `unsupported template document "api"`},
+		{"parse error", "index", "{{.Missing", "failed to parse index template"},
+		{"unknown section", "full", `{{section "packages"}}`, `unknown full section "packages"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := output.RenderDocumentTemplate(tt.document, tt.text, templateDoc())
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("expected error containing %q, got %v", tt.errText, err)
+			}
+		})
+	}
+}
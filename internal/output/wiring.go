@@ -0,0 +1,82 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WiringEdge is a blank import that wires a package in at runtime through its init
+// functions, such as a database driver, an image decoder or a plugin
+type WiringEdge struct {
+	Package   string   // Directory path of the importing package
+	Import    string   // Directory of a local package, or import path of any other package
+	Registers []string // What the init functions of a local package register, e.g. "sql.Register"
+}
+
+// buildRuntimeWiring collects the runtime wiring edges of the graph, one per pair of
+// packages. Test files are ignored: they are not part of the program.
+func buildRuntimeWiring(graph Graph) []WiringEdge {
+	seen := make(map[string]bool)
+	var edges []WiringEdge
+
+	for _, node := range graph.GetNodes() {
+		if strings.HasSuffix(node.GetRelPath(), "_test.go") {
+			continue
+		}
+		pkgPath := extractPackagePath(node.GetRelPath())
+		for _, dep := range node.GetDependencies() {
+			if !dep.IsRuntimeWiring() {
+				continue
+			}
+			target := dep.GetImportPath()
+			if dep.IsLocalDep() {
+				target = dep.GetLocalPath()
+			}
+			if seen[pkgPath+" "+target] {
+				continue
+			}
+			seen[pkgPath+" "+target] = true
+			edges = append(edges, WiringEdge{Package: pkgPath, Import: target, Registers: dep.GetRegistrations()})
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Package != edges[j].Package {
+			return edges[i].Package < edges[j].Package
+		}
+		return edges[i].Import < edges[j].Import
+	})
+
+	return edges
+}
+
+// writeRuntimeWiring writes a section listing the runtime wiring edges; nothing is
+// written without any
+func writeRuntimeWiring(sb *strings.Builder, edges []WiringEdge, messages *Messages) {
+	if len(edges) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("## %s\n\n", messages.Text("docs.wiring")))
+	sb.WriteString(messages.Text("docs.wiring_intro") + "\n\n")
+	for _, edge := range edges {
+		sb.WriteString(fmt.Sprintf("- **%s** → %s", edge.Package, edge.Import))
+		if len(edge.Registers) > 0 {
+			sb.WriteString(fmt.Sprintf(" (%s)", strings.Join(edge.Registers, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+// wiringNote marks a runtime wiring edge in a dependency list
+func wiringNote(dep Dependency) string {
+	if !dep.IsRuntimeWiring() {
+		return ""
+	}
+	if registrations := dep.GetRegistrations(); len(registrations) > 0 {
+		return fmt.Sprintf(" (runtime wiring: %s)", strings.Join(registrations, ", "))
+	}
+	return " (runtime wiring)"
+}
@@ -0,0 +1,92 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+func wiringGraph() *testGraph {
+	return &testGraph{
+		nodes: []output.FileNode{
+			&testFileNode{
+				relPath: "cmd/api/main.go",
+				pkg:     "main",
+				dependencies: []output.Dependency{
+					&testDependency{importPath: "github.com/test/project/internal/app", isLocal: true, localPath: "internal/app"},
+					&testDependency{importPath: "github.com/test/project/internal/codecs", isLocal: true, localPath: "internal/codecs", wiring: true, registrations: []string{"image.RegisterFormat"}},
+					&testDependency{importPath: "github.com/lib/pq", wiring: true},
+					&testDependency{importPath: "image/png", wiring: true},
+				},
+			},
+			// Both files of the package import the driver; the edge is listed once
+			&testFileNode{
+				relPath: "cmd/api/db.go",
+				pkg:     "main",
+				dependencies: []output.Dependency{
+					&testDependency{importPath: "github.com/lib/pq", wiring: true},
+				},
+			},
+			// Test files are not part of the program
+			&testFileNode{
+				relPath: "cmd/api/main_test.go",
+				pkg:     "main",
+				dependencies: []output.Dependency{
+					&testDependency{importPath: "github.com/test/project/internal/fakes", isLocal: true, localPath: "internal/fakes", wiring: true},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateFullDocumentation_RuntimeWiring(t *testing.T) {
+	result := output.GenerateFullDocumentation(output.FullDocumentation{Graph: wiringGraph()})
+
+	if !strings.Contains(result, "- [Runtime Wiring](#runtime-wiring)") || !strings.Contains(result, "## Runtime Wiring") {
+		t.Fatalf("expected runtime wiring section with a table of contents entry, got:\n%s", result)
+	}
+	wiring := result[strings.Index(result, "## Runtime Wiring"):]
+	wiring = wiring[:strings.Index(wiring, "\n\n## ")]
+
+	expected := "- **cmd/api** → github.com/lib/pq\n" +
+		"- **cmd/api** → image/png\n" +
+		"- **cmd/api** → internal/codecs (image.RegisterFormat)"
+	if !strings.HasSuffix(wiring, expected) {
+		t.Errorf("expected wiring edges:\n%s\ngot:\n%s", expected, wiring)
+	}
+	if !strings.Contains(result, "- internal/codecs (runtime wiring: image.RegisterFormat)\n") {
+		t.Errorf("expected wiring note in the dependency list, got:\n%s", result)
+	}
+
+	// Without blank imports the section is omitted
+	result = output.GenerateFullDocumentation(output.FullDocumentation{Graph: binariesGraph()})
+	if strings.Contains(result, "Runtime Wiring") {
+		t.Errorf("expected no runtime wiring section without blank imports, got:\n%s", result)
+	}
+}
+
+func TestGenerateMarkdown_RuntimeWiring(t *testing.T) {
+	md := output.GenerateMarkdown(wiringGraph())
+
+	expected := []string{
+		"  - local:internal/app\n",
+		"  - local:internal/codecs (runtime wiring: image.RegisterFormat)\n",
+		"  - external:github.com/lib/pq (runtime wiring)\n",
+		// Standard library imports are listed only when they wire a package in
+		"  - stdlib:image/png (runtime wiring)\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(md, line) {
+			t.Errorf("expected %q in:\n%s", line, md)
+		}
+	}
+}
+
+func TestGenerateIndexDocumentation_RuntimeWiring(t *testing.T) {
+	result := output.GenerateIndexDocumentation(output.FullDocumentation{Graph: wiringGraph()})
+
+	if !strings.Contains(result, "## Runtime Wiring") || !strings.Contains(result, "- **cmd/api** → internal/codecs (image.RegisterFormat)\n") {
+		t.Errorf("expected runtime wiring section, got:\n%s", result)
+	}
+}
@@ -0,0 +1,173 @@
+package publish
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Confluence publishes pages to a Confluence space through the REST API
+type Confluence struct {
+	BaseURL    string // Site URL including the context path (e.g. https://example.atlassian.net/wiki)
+	User       string // Account email (Cloud) or user name
+	Token      string // API token or password
+	Space      string // Space key
+	ParentID   string // Optional parent page ID for new pages
+	HTTPClient *http.Client
+}
+
+// confluenceContent is the subset of the Confluence content resource used for publishing
+type confluenceContent struct {
+	ID      string `json:"id"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Links struct {
+		Base  string `json:"base"`
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+// Publish creates the page in the space, or updates the page with the same title
+func (c *Confluence) Publish(page Page) (string, error) {
+	if c.BaseURL == "" || c.User == "" || c.Token == "" || c.Space == "" {
+		return "", fmt.Errorf("confluence publishing requires a base URL, user, API token and space key")
+	}
+
+	api := &client{
+		httpClient: c.HTTPClient,
+		authorize: func(req *http.Request) {
+			req.SetBasicAuth(c.User, c.Token)
+		},
+	}
+	base := strings.TrimSuffix(c.BaseURL, "/") + "/rest/api/content"
+
+	var existing struct {
+		Results []confluenceContent `json:"results"`
+	}
+	query := url.Values{"spaceKey": {c.Space}, "title": {page.Title}, "expand": {"version"}}
+	if err := api.do(http.MethodGet, base+"?"+query.Encode(), nil, &existing); err != nil {
+		return "", err
+	}
+
+	body := map[string]interface{}{
+		"type":  "page",
+		"title": page.Title,
+		"space": map[string]string{"key": c.Space},
+		"body": map[string]interface{}{
+			"storage": map[string]string{
+				"value":          ConfluenceStorage(page.Markdown),
+				"representation": "storage",
+			},
+		},
+	}
+
+	var result confluenceContent
+	if len(existing.Results) > 0 {
+		current := existing.Results[0]
+		body["id"] = current.ID
+		body["version"] = map[string]int{"number": current.Version.Number + 1}
+		if err := api.do(http.MethodPut, base+"/"+current.ID, body, &result); err != nil {
+			return "", err
+		}
+	} else {
+		if c.ParentID != "" {
+			body["ancestors"] = []map[string]string{{"id": c.ParentID}}
+		}
+		if err := api.do(http.MethodPost, base, body, &result); err != nil {
+			return "", err
+		}
+	}
+
+	if result.Links.WebUI == "" {
+		return result.ID, nil
+	}
+	linkBase := result.Links.Base
+	if linkBase == "" {
+		linkBase = strings.TrimSuffix(c.BaseURL, "/")
+	}
+	return linkBase + result.Links.WebUI, nil
+}
+
+// ConfluenceStorage converts markdown to Confluence storage format (XHTML)
+func ConfluenceStorage(markdown string) string {
+	var sb strings.Builder
+	openLists := 0
+
+	closeLists := func(depth int) {
+		for openLists > depth {
+			sb.WriteString("</li></ul>")
+			openLists--
+		}
+	}
+
+	for _, b := range parseMarkdown(markdown) {
+		if b.kind != blockListItem {
+			closeLists(0)
+		}
+
+		switch b.kind {
+		case blockHeading:
+			sb.WriteString(fmt.Sprintf("<h%d>%s</h%d>", b.level, confluenceInline(b.text), b.level))
+		case blockParagraph:
+			sb.WriteString("<p>" + confluenceInline(b.text) + "</p>")
+		case blockListItem:
+			depth := b.level + 1
+			if depth > openLists+1 {
+				depth = openLists + 1
+			}
+			switch {
+			case depth > openLists:
+				sb.WriteString("<ul><li>")
+				openLists++
+			default:
+				closeLists(depth)
+				sb.WriteString("</li><li>")
+			}
+			sb.WriteString(confluenceInline(b.text))
+		case blockCode:
+			sb.WriteString(`<ac:structured-macro ac:name="code">`)
+			if b.language != "" {
+				sb.WriteString(`<ac:parameter ac:name="language">` + html.EscapeString(b.language) + "</ac:parameter>")
+			}
+			// "]]>" cannot appear inside CDATA: split it across two sections
+			content := strings.ReplaceAll(b.text, "]]>", "]]]]><![CDATA[>")
+			sb.WriteString("<ac:plain-text-body><![CDATA[" + content + "]]></ac:plain-text-body></ac:structured-macro>")
+		case blockDivider:
+			sb.WriteString("<hr />")
+		}
+		sb.WriteString("\n")
+	}
+	closeLists(0)
+
+	return sb.String()
+}
+
+// confluenceInline converts inline markdown to XHTML
+func confluenceInline(text string) string {
+	var sb strings.Builder
+	for _, s := range parseInline(text) {
+		if s.image {
+			sb.WriteString(`<ac:image ac:alt="` + html.EscapeString(s.text) + `"><ri:url ri:value="` + html.EscapeString(s.link) + `" /></ac:image>`)
+			continue
+		}
+
+		content := html.EscapeString(s.text)
+		if s.code {
+			content = "<code>" + content + "</code>"
+		}
+		if s.italic {
+			content = "<em>" + content + "</em>"
+		}
+		if s.bold {
+			content = "<strong>" + content + "</strong>"
+		}
+		if s.link != "" {
+			content = `<a href="` + html.EscapeString(s.link) + `">` + content + "</a>"
+		}
+		sb.WriteString(content)
+	}
+	return sb.String()
+}
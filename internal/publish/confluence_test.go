@@ -0,0 +1,114 @@
+package publish_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/publish"
+)
+
+func TestConfluenceStorage(t *testing.T) {
+	markdown := "# Project <Index>\n\n" +
+		"Some **bold** and *italic* text with `a<b>` and a [link](layers/pkg.md).\n" +
+		"Continued line ![badge](https://img.shields.io/badge/x-1-red)\n\n" +
+		"- **linter** (`pkg/linter`)\n" +
+		"  - Files: 2\n" +
+		"  - Exports: 3\n" +
+		"- **config**\n\n" +
+		"```yaml\nrules: []\nweird: ]]>\n```\n\n" +
+		"---\n\n" +
+		"*(undocumented)* and 2 * 3\n"
+
+	expected := "<h1>Project &lt;Index&gt;</h1>\n" +
+		`<p>Some <strong>bold</strong> and <em>italic</em> text with <code>a&lt;b&gt;</code> and a <a href="layers/pkg.md">link</a>. Continued line <ac:image ac:alt="badge"><ri:url ri:value="https://img.shields.io/badge/x-1-red" /></ac:image></p>` + "\n" +
+		"<ul><li><strong>linter</strong> (<code>pkg/linter</code>)\n" +
+		"<ul><li>Files: 2\n" +
+		"</li><li>Exports: 3\n" +
+		"</li></ul></li><li><strong>config</strong>\n" +
+		"</li></ul>" + `<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">yaml</ac:parameter><ac:plain-text-body><![CDATA[rules: []` + "\n" + `weird: ]]]]><![CDATA[>]]></ac:plain-text-body></ac:structured-macro>` + "\n" +
+		"<hr />\n" +
+		"<p><em>(undocumented)</em> and 2 * 3</p>\n"
+
+	if result := publish.ConfluenceStorage(markdown); result != expected {
+		t.Errorf("unexpected storage format\ngot:\n%s\nwant:\n%s", result, expected)
+	}
+}
+
+func TestConfluence_Publish(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   string
+		wantMethod string
+		wantPath   string
+	}{
+		{"creates new page", `{"results":[]}`, http.MethodPost, "/wiki/rest/api/content"},
+		{"updates existing page", `{"results":[{"id":"42","version":{"number":7}}]}`, http.MethodPut, "/wiki/rest/api/content/42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var written map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if user, token, ok := r.BasicAuth(); !ok || user != "me@example.com" || token != "secret" {
+					t.Errorf("expected basic auth, got %q/%q", user, token)
+				}
+				if r.Method == http.MethodGet {
+					if r.URL.Query().Get("spaceKey") != "ARCH" || r.URL.Query().Get("title") != "Architecture" {
+						t.Errorf("unexpected lookup query: %s", r.URL.RawQuery)
+					}
+					w.Write([]byte(tt.existing))
+					return
+				}
+				if r.Method != tt.wantMethod || r.URL.Path != tt.wantPath {
+					t.Errorf("expected %s %s, got %s %s", tt.wantMethod, tt.wantPath, r.Method, r.URL.Path)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&written); err != nil {
+					t.Fatal(err)
+				}
+				w.Write([]byte(`{"id":"42","_links":{"base":"https://example.atlassian.net/wiki","webui":"/spaces/ARCH/pages/42"}}`))
+			}))
+			defer server.Close()
+
+			c := &publish.Confluence{BaseURL: server.URL + "/wiki/", User: "me@example.com", Token: "secret", Space: "ARCH", ParentID: "7"}
+			url, err := c.Publish(publish.Page{Title: "Architecture", Markdown: "# Hello"})
+			if err != nil {
+				t.Fatalf("Publish failed: %v", err)
+			}
+			if url != "https://example.atlassian.net/wiki/spaces/ARCH/pages/42" {
+				t.Errorf("unexpected page URL %q", url)
+			}
+
+			body := written["body"].(map[string]interface{})["storage"].(map[string]interface{})
+			if body["value"] != "<h1>Hello</h1>\n" || body["representation"] != "storage" {
+				t.Errorf("unexpected body: %v", body)
+			}
+			if tt.wantMethod == http.MethodPut {
+				if version := written["version"].(map[string]interface{})["number"]; version != float64(8) {
+					t.Errorf("expected version 8, got %v", version)
+				}
+			} else if _, ok := written["ancestors"]; !ok {
+				t.Errorf("expected parent page for new page, got: %v", written)
+			}
+		})
+	}
+}
+
+func TestConfluence_PublishErrors(t *testing.T) {
+	if _, err := (&publish.Confluence{BaseURL: "https://example.com"}).Publish(publish.Page{Title: "x"}); err == nil {
+		t.Error("expected error for missing credentials")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"not permitted"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	c := &publish.Confluence{BaseURL: server.URL, User: "me", Token: "secret", Space: "ARCH"}
+	_, err := c.Publish(publish.Page{Title: "Architecture"})
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "not permitted") {
+		t.Errorf("expected API error with status and message, got %v", err)
+	}
+}
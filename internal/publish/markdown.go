@@ -0,0 +1,198 @@
+package publish
+
+import (
+	"strings"
+)
+
+// blockKind identifies a markdown block element
+type blockKind int
+
+const (
+	blockHeading blockKind = iota
+	blockParagraph
+	blockListItem
+	blockCode
+	blockDivider
+)
+
+// block is a markdown block element
+type block struct {
+	kind     blockKind
+	level    int    // Heading level (1-6) or list item depth (0 for top-level items)
+	text     string // Inline markdown (raw content for code blocks)
+	language string // Code block language
+}
+
+// span is a run of inline text with uniform formatting
+type span struct {
+	text   string
+	bold   bool
+	italic bool
+	code   bool
+	link   string // Link target, empty if the span is not a link
+	image  bool   // The span is an image: text is the alt text and link its source
+}
+
+// parseMarkdown splits markdown into blocks. It supports the subset used by the
+// generated documentation: ATX headings, paragraphs, "-" and "*" bullet lists
+// indented by two spaces per level, fenced code blocks and horizontal rules.
+func parseMarkdown(markdown string) []block {
+	var blocks []block
+	var paragraph []string
+	var code *block
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, block{kind: blockParagraph, text: strings.Join(paragraph, " ")})
+			paragraph = nil
+		}
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if code != nil {
+			if strings.HasPrefix(trimmed, "```") {
+				code.text = strings.TrimSuffix(code.text, "\n")
+				blocks = append(blocks, *code)
+				code = nil
+			} else {
+				code.text += line + "\n"
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			code = &block{kind: blockCode, language: strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))}
+		case trimmed == "":
+			flush()
+		case isDivider(trimmed):
+			flush()
+			blocks = append(blocks, block{kind: blockDivider})
+		case headingLevel(trimmed) > 0:
+			flush()
+			level := headingLevel(trimmed)
+			blocks = append(blocks, block{kind: blockHeading, level: level, text: strings.TrimSpace(trimmed[level:])})
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			flush()
+			indent := len(line) - len(strings.TrimLeft(line, " "))
+			blocks = append(blocks, block{kind: blockListItem, level: indent / 2, text: strings.TrimSpace(trimmed[2:])})
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+
+	flush()
+	if code != nil {
+		// Unterminated fence: keep the content
+		code.text = strings.TrimSuffix(code.text, "\n")
+		blocks = append(blocks, *code)
+	}
+
+	return blocks
+}
+
+// isDivider reports whether a line is a horizontal rule ("---", "***")
+func isDivider(line string) bool {
+	if len(line) < 3 {
+		return false
+	}
+	return strings.Trim(line, "-") == "" || strings.Trim(line, "*") == ""
+}
+
+// headingLevel returns the level of an ATX heading line, or 0 if it is not a heading
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+// parseInline splits inline markdown into formatted spans. It understands code
+// spans, **bold**, *italic*, links and images; markers without a closing
+// counterpart are kept as text.
+func parseInline(text string) []span {
+	var spans []span
+	var current strings.Builder
+	bold, italic := false, false
+
+	emit := func() {
+		if current.Len() > 0 {
+			spans = append(spans, span{text: current.String(), bold: bold, italic: italic})
+			current.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		switch {
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end >= 0 {
+				emit()
+				spans = append(spans, span{text: rest[1 : end+1], bold: bold, italic: italic, code: true})
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "**"):
+			if bold || strings.Contains(rest[2:], "**") {
+				emit()
+				bold = !bold
+				i += 2
+				continue
+			}
+		case rest[0] == '*':
+			if italic || strings.Contains(rest[1:], "*") {
+				emit()
+				italic = !italic
+				i++
+				continue
+			}
+		case strings.HasPrefix(rest, "!["):
+			if label, target, n := parseLink(rest[1:]); n > 0 {
+				emit()
+				spans = append(spans, span{text: label, link: target, image: true})
+				i += n + 1
+				continue
+			}
+		case rest[0] == '[':
+			if label, target, n := parseLink(rest); n > 0 {
+				emit()
+				spans = append(spans, span{text: label, bold: bold, italic: italic, link: target})
+				i += n
+				continue
+			}
+		}
+
+		current.WriteByte(rest[0])
+		i++
+	}
+	emit()
+
+	return spans
+}
+
+// parseLink parses "[label](target)" at the start of text and returns its parts
+// and length, or a zero length if text does not start with a link
+func parseLink(text string) (string, string, int) {
+	closeLabel := strings.Index(text, "](")
+	if !strings.HasPrefix(text, "[") || closeLabel < 0 {
+		return "", "", 0
+	}
+	closeTarget := strings.IndexByte(text[closeLabel+2:], ')')
+	if closeTarget < 0 {
+		return "", "", 0
+	}
+	label := text[1:closeLabel]
+	target := text[closeLabel+2 : closeLabel+2+closeTarget]
+	if strings.ContainsAny(label, "[]") {
+		return "", "", 0
+	}
+	return label, target, closeLabel + 2 + closeTarget + 1
+}
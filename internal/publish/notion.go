@@ -0,0 +1,228 @@
+package publish
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	notionAPIURL   = "https://api.notion.com/v1"
+	notionVersion  = "2022-06-28"
+	notionMaxText  = 2000 // Maximum length of one rich text object
+	notionMaxBatch = 100  // Maximum number of blocks per request
+)
+
+// notionLanguages maps code fence languages to Notion code block languages
+var notionLanguages = map[string]string{
+	"go":   "go",
+	"yaml": "yaml",
+	"yml":  "yaml",
+	"json": "json",
+	"bash": "bash",
+	"sh":   "shell",
+}
+
+// Notion publishes pages to Notion through the API of an integration
+type Notion struct {
+	Token        string // Integration token
+	ParentPageID string // Page under which new pages are created
+	PageID       string // Existing page whose title and content are replaced (instead of creating one)
+	BaseURL      string // API URL (default: https://api.notion.com/v1)
+	HTTPClient   *http.Client
+}
+
+// notionPage is the subset of the Notion page and block resources used for publishing
+type notionPage struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// Publish creates a page under the parent page, or replaces the content of PageID
+func (n *Notion) Publish(page Page) (string, error) {
+	if n.Token == "" || (n.ParentPageID == "" && n.PageID == "") {
+		return "", fmt.Errorf("notion publishing requires an integration token and a parent page ID or page ID")
+	}
+
+	api := &client{
+		httpClient: n.HTTPClient,
+		authorize: func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+n.Token)
+			req.Header.Set("Notion-Version", notionVersion)
+		},
+	}
+	base := n.BaseURL
+	if base == "" {
+		base = notionAPIURL
+	}
+	base = strings.TrimSuffix(base, "/")
+
+	blocks := NotionBlocks(page.Markdown)
+	title := map[string]interface{}{
+		"title": map[string]interface{}{"title": notionRichText([]span{{text: page.Title}})},
+	}
+
+	var result notionPage
+	if n.PageID != "" {
+		if err := api.do(http.MethodPatch, base+"/pages/"+n.PageID, map[string]interface{}{"properties": title}, &result); err != nil {
+			return "", err
+		}
+		if err := n.clearPage(api, base, n.PageID); err != nil {
+			return "", err
+		}
+	} else {
+		first := blocks
+		if len(first) > notionMaxBatch {
+			first = first[:notionMaxBatch]
+		}
+		body := map[string]interface{}{
+			"parent":     map[string]string{"page_id": n.ParentPageID},
+			"properties": title,
+			"children":   first,
+		}
+		if err := api.do(http.MethodPost, base+"/pages", body, &result); err != nil {
+			return "", err
+		}
+		blocks = blocks[len(first):]
+	}
+
+	for len(blocks) > 0 {
+		batch := blocks
+		if len(batch) > notionMaxBatch {
+			batch = batch[:notionMaxBatch]
+		}
+		if err := api.do(http.MethodPatch, base+"/blocks/"+result.ID+"/children", map[string]interface{}{"children": batch}, nil); err != nil {
+			return "", err
+		}
+		blocks = blocks[len(batch):]
+	}
+
+	return result.URL, nil
+}
+
+// clearPage deletes all blocks of a page
+func (n *Notion) clearPage(api *client, base, pageID string) error {
+	var children []notionPage
+	cursor := ""
+	for {
+		url := base + "/blocks/" + pageID + "/children?page_size=100"
+		if cursor != "" {
+			url += "&start_cursor=" + cursor
+		}
+		var list struct {
+			Results    []notionPage `json:"results"`
+			HasMore    bool         `json:"has_more"`
+			NextCursor string       `json:"next_cursor"`
+		}
+		if err := api.do(http.MethodGet, url, nil, &list); err != nil {
+			return err
+		}
+		children = append(children, list.Results...)
+		if !list.HasMore || list.NextCursor == "" {
+			break
+		}
+		cursor = list.NextCursor
+	}
+
+	for _, child := range children {
+		if err := api.do(http.MethodDelete, base+"/blocks/"+child.ID, nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotionBlocks converts markdown to Notion block objects. Nested list items become
+// children of the enclosing top-level item (deeper levels are flattened into it).
+func NotionBlocks(markdown string) []map[string]interface{} {
+	var blocks []map[string]interface{}
+	var lastItem map[string]interface{}
+
+	for _, b := range parseMarkdown(markdown) {
+		switch b.kind {
+		case blockHeading:
+			level := b.level
+			if level > 3 {
+				level = 3
+			}
+			blocks = append(blocks, notionBlock(fmt.Sprintf("heading_%d", level), notionRichText(parseInline(b.text))))
+		case blockParagraph:
+			blocks = append(blocks, notionBlock("paragraph", notionRichText(parseInline(b.text))))
+		case blockListItem:
+			item := notionBlock("bulleted_list_item", notionRichText(parseInline(b.text)))
+			if b.level > 0 && lastItem != nil {
+				content := lastItem["bulleted_list_item"].(map[string]interface{})
+				children, _ := content["children"].([]map[string]interface{})
+				content["children"] = append(children, item)
+				continue
+			}
+			blocks = append(blocks, item)
+			lastItem = item
+			continue
+		case blockCode:
+			language, ok := notionLanguages[b.language]
+			if !ok {
+				language = "plain text"
+			}
+			code := notionBlock("code", notionRichText([]span{{text: b.text}}))
+			code["code"].(map[string]interface{})["language"] = language
+			blocks = append(blocks, code)
+		case blockDivider:
+			blocks = append(blocks, map[string]interface{}{"object": "block", "type": "divider", "divider": map[string]interface{}{}})
+		}
+		lastItem = nil
+	}
+
+	return blocks
+}
+
+// notionBlock creates a text block of the given type
+func notionBlock(blockType string, richText []map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"object":  "block",
+		"type":    blockType,
+		blockType: map[string]interface{}{"rich_text": richText},
+	}
+}
+
+// notionRichText converts spans to Notion rich text objects. Only absolute URLs
+// become links; images are shown as their alt text.
+func notionRichText(spans []span) []map[string]interface{} {
+	var richText []map[string]interface{}
+	for _, s := range spans {
+		text := s.text
+		if s.image && text == "" {
+			text = s.link
+		}
+		for len(text) > 0 {
+			chunk := text
+			if len(chunk) > notionMaxText {
+				end := notionMaxText
+				for !utf8.RuneStart(chunk[end]) {
+					end--
+				}
+				chunk = chunk[:end]
+			}
+			text = text[len(chunk):]
+
+			content := map[string]interface{}{"content": chunk}
+			if strings.HasPrefix(s.link, "http://") || strings.HasPrefix(s.link, "https://") {
+				content["link"] = map[string]string{"url": s.link}
+			}
+			richText = append(richText, map[string]interface{}{
+				"type": "text",
+				"text": content,
+				"annotations": map[string]bool{
+					"bold":   s.bold,
+					"italic": s.italic,
+					"code":   s.code,
+				},
+			})
+		}
+	}
+	if richText == nil {
+		richText = []map[string]interface{}{}
+	}
+	return richText
+}
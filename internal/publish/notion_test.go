@@ -0,0 +1,197 @@
+package publish_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/publish"
+)
+
+func TestNotionBlocks(t *testing.T) {
+	markdown := "# Title\n\n" +
+		"#### Deep heading\n\n" +
+		"Text with **bold** and a [link](https://example.com) and [local](pkg.md).\n\n" +
+		"- item\n" +
+		"  - nested\n" +
+		"    - deeper\n" +
+		"- other\n\n" +
+		"```go\npackage main\n```\n\n" +
+		"```\nplain\n```\n\n" +
+		"---\n"
+
+	blocks := publish.NotionBlocks(markdown)
+
+	var types []string
+	for _, b := range blocks {
+		types = append(types, b["type"].(string))
+	}
+	expected := "heading_1,heading_3,paragraph,bulleted_list_item,bulleted_list_item,code,code,divider"
+	if got := strings.Join(types, ","); got != expected {
+		t.Fatalf("expected block types %s, got %s", expected, got)
+	}
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []struct {
+		Paragraph struct {
+			RichText []struct {
+				Text struct {
+					Content string `json:"content"`
+					Link    *struct {
+						URL string `json:"url"`
+					} `json:"link"`
+				} `json:"text"`
+				Annotations struct {
+					Bold bool `json:"bold"`
+				} `json:"annotations"`
+			} `json:"rich_text"`
+		} `json:"paragraph"`
+		Item struct {
+			Children []interface{} `json:"children"`
+		} `json:"bulleted_list_item"`
+		Code struct {
+			Language string `json:"language"`
+		} `json:"code"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	richText := decoded[2].Paragraph.RichText
+	if len(richText) != 7 {
+		t.Fatalf("expected 7 rich text objects, got %d", len(richText))
+	}
+	if richText[1].Text.Content != "bold" || !richText[1].Annotations.Bold {
+		t.Errorf("expected bold span, got %+v", richText[1])
+	}
+	if richText[3].Text.Link == nil || richText[3].Text.Link.URL != "https://example.com" {
+		t.Errorf("expected absolute link, got %+v", richText[3])
+	}
+	if richText[5].Text.Content != "local" || richText[5].Text.Link != nil {
+		t.Errorf("expected relative link as plain text, got %+v", richText[5])
+	}
+
+	if len(decoded[3].Item.Children) != 2 {
+		t.Errorf("expected nested items as children of the first item, got %d", len(decoded[3].Item.Children))
+	}
+	if decoded[5].Code.Language != "go" || decoded[6].Code.Language != "plain text" {
+		t.Errorf("unexpected code languages %q, %q", decoded[5].Code.Language, decoded[6].Code.Language)
+	}
+}
+
+func TestNotionBlocks_SplitsLongText(t *testing.T) {
+	text := strings.Repeat("é", 1500) // 3000 bytes
+	blocks := publish.NotionBlocks(text)
+
+	richText := blocks[0]["paragraph"].(map[string]interface{})["rich_text"].([]map[string]interface{})
+	if len(richText) != 2 {
+		t.Fatalf("expected text split into 2 objects, got %d", len(richText))
+	}
+	var joined string
+	for _, rt := range richText {
+		content := rt["text"].(map[string]interface{})["content"].(string)
+		if len(content) > 2000 {
+			t.Errorf("rich text object exceeds 2000 bytes: %d", len(content))
+		}
+		joined += content
+	}
+	if joined != text {
+		t.Error("split text does not reassemble to the original")
+	}
+}
+
+func TestNotion_Publish(t *testing.T) {
+	var markdown strings.Builder
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&markdown, "- item %d\n", i)
+	}
+
+	var requests []string
+	var created map[string]interface{}
+	var appended int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Notion-Version") == "" {
+			t.Errorf("missing authentication headers")
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/pages":
+			json.NewDecoder(r.Body).Decode(&created)
+		case r.Method == http.MethodPatch && r.URL.Path == "/blocks/page-1/children":
+			var body struct {
+				Children []interface{} `json:"children"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			appended += len(body.Children)
+		}
+		w.Write([]byte(`{"id":"page-1","url":"https://www.notion.so/page-1"}`))
+	}))
+	defer server.Close()
+
+	n := &publish.Notion{Token: "secret", ParentPageID: "parent", BaseURL: server.URL}
+	url, err := n.Publish(publish.Page{Title: "Architecture", Markdown: markdown.String()})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if url != "https://www.notion.so/page-1" {
+		t.Errorf("unexpected page URL %q", url)
+	}
+
+	if strings.Join(requests, ",") != "POST /pages,PATCH /blocks/page-1/children" {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+	if children := created["children"].([]interface{}); len(children) != 100 {
+		t.Errorf("expected 100 blocks on creation, got %d", len(children))
+	}
+	if appended != 50 {
+		t.Errorf("expected 50 appended blocks, got %d", appended)
+	}
+	if parent := created["parent"].(map[string]interface{}); parent["page_id"] != "parent" {
+		t.Errorf("unexpected parent: %v", parent)
+	}
+}
+
+func TestNotion_PublishReplacesPage(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.URL.Query().Get("start_cursor"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("start_cursor") == "":
+			w.Write([]byte(`{"results":[{"id":"b1"}],"has_more":true,"next_cursor":"c2"}`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"results":[{"id":"b2"}],"has_more":false}`))
+		default:
+			w.Write([]byte(`{"id":"page-1","url":"https://www.notion.so/page-1"}`))
+		}
+	}))
+	defer server.Close()
+
+	n := &publish.Notion{Token: "secret", PageID: "page-1", BaseURL: server.URL}
+	if _, err := n.Publish(publish.Page{Title: "Architecture", Markdown: "# Hello"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	expected := []string{
+		"PATCH /pages/page-1 ",
+		"GET /blocks/page-1/children ",
+		"GET /blocks/page-1/children c2",
+		"DELETE /blocks/b1 ",
+		"DELETE /blocks/b2 ",
+		"PATCH /blocks/page-1/children ",
+	}
+	if strings.Join(requests, "|") != strings.Join(expected, "|") {
+		t.Errorf("unexpected requests:\ngot:  %v\nwant: %v", requests, expected)
+	}
+}
+
+func TestNotion_PublishRequiresCredentials(t *testing.T) {
+	if _, err := (&publish.Notion{Token: "secret"}).Publish(publish.Page{Title: "x"}); err == nil {
+		t.Error("expected error without parent page or page ID")
+	}
+}
@@ -0,0 +1,82 @@
+package publish
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// requestTimeout bounds each request to a documentation API, so a slow endpoint cannot
+// hang a CI job
+const requestTimeout = 30 * time.Second
+
+// Targets lists the supported publishing targets
+var Targets = []string{"confluence", "notion"}
+
+// Page is a markdown document to publish
+type Page struct {
+	Title    string
+	Markdown string
+}
+
+// Publisher pushes documentation pages to an external documentation system
+type Publisher interface {
+	// Publish creates the page or replaces its content and returns its URL
+	Publish(page Page) (string, error)
+}
+
+// client sends JSON requests to a documentation API
+type client struct {
+	httpClient *http.Client
+	authorize  func(req *http.Request)
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into result
+func (c *client) do(method, url string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.authorize(req)
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: requestTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: %s: %s", method, url, resp.Status, bytes.TrimSpace(data))
+	}
+
+	if result != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, result); err != nil {
+			return fmt.Errorf("%s %s: invalid response: %w", method, url, err)
+		}
+	}
+	return nil
+}
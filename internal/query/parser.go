@@ -0,0 +1,236 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenOp     // & | -
+	tokenLParen // (
+	tokenRParen // )
+	tokenComma  // ,
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // 1-based column in the expression
+}
+
+// tokenize splits an expression into words, operators and punctuation. A - starts an
+// operator only at the beginning of a token; inside a word it is part of the path.
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '&' || c == '|' || c == '-':
+			tokens = append(tokens, token{kind: tokenOp, text: string(c), pos: i + 1})
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i + 1})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i + 1})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i + 1})
+			i++
+		default:
+			start := i
+			for i < len(expression) && !strings.ContainsRune(" \t\n&|(),", rune(expression[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: expression[start:i], pos: start + 1})
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+	return append(tokens, token{kind: tokenEOF, text: "end of query", pos: len(expression) + 1}), nil
+}
+
+// parser evaluates the expression while parsing it:
+//
+//	expr   = term { ("|" | "-") term }
+//	term   = factor { "&" factor }
+//	factor = word "(" [ expr { "," expr } ] ")" | word | "(" expr ")"
+type parser struct {
+	tokens []token
+	pos    int
+	graph  *graph
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind, what string) error {
+	if tok := p.next(); tok.kind != kind {
+		return fmt.Errorf("position %d: expected %s, got %q", tok.pos, what, tok.text)
+	}
+	return nil
+}
+
+func (p *parser) parseExpr() (set, error) {
+	result, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok.kind == tokenOp && tok.text != "&"; tok = p.peek() {
+		p.next()
+		operand, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		combined := make(set)
+		for pkg := range result {
+			if tok.text == "|" || !operand[pkg] {
+				combined[pkg] = true
+			}
+		}
+		if tok.text == "|" {
+			for pkg := range operand {
+				combined[pkg] = true
+			}
+		}
+		result = combined
+	}
+	return result, nil
+}
+
+func (p *parser) parseTerm() (set, error) {
+	result, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok.kind == tokenOp && tok.text == "&"; tok = p.peek() {
+		p.next()
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		combined := make(set)
+		for pkg := range result {
+			if operand[pkg] {
+				combined[pkg] = true
+			}
+		}
+		result = combined
+	}
+	return result, nil
+}
+
+func (p *parser) parseFactor() (set, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenLParen:
+		result, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case tokenWord:
+		if p.peek().kind == tokenLParen {
+			p.next()
+			return p.parseCall(tok)
+		}
+		result, err := p.graph.match(tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("position %d: %w", tok.pos, err)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("position %d: expected a package pattern, function or \"(\", got %q", tok.pos, tok.text)
+	}
+}
+
+// parseCall evaluates a function call after its opening parenthesis
+func (p *parser) parseCall(name token) (set, error) {
+	g := p.graph
+
+	// layer() takes a layer name, not an expression
+	if name.text == "layer" {
+		arg := p.next()
+		if arg.kind != tokenWord {
+			return nil, fmt.Errorf("position %d: layer() expects a layer name, got %q", arg.pos, arg.text)
+		}
+		if err := p.expect(tokenRParen, `")"`); err != nil {
+			return nil, err
+		}
+		result, err := g.layer(arg.text)
+		if err != nil {
+			return nil, fmt.Errorf("position %d: %w", arg.pos, err)
+		}
+		return result, nil
+	}
+
+	var args []set
+	if p.peek().kind != tokenRParen {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if err := p.expect(tokenRParen, `")"`); err != nil {
+		return nil, err
+	}
+
+	var filter func(Package) bool
+	var edges func(string) []string
+	transitive := false
+	switch name.text {
+	case "all":
+		filter = func(Package) bool { return true }
+	case "local", "stdlib", "external":
+		filter = func(pkg Package) bool { return pkg.Kind == name.text }
+	case "main":
+		filter = func(pkg Package) bool { return pkg.Main }
+	case "deps":
+		edges = g.imports
+	case "rdeps":
+		edges = g.importedBy
+	case "closure":
+		edges, transitive = g.imports, true
+	case "rclosure":
+		edges, transitive = g.importedBy, true
+	default:
+		return nil, fmt.Errorf("position %d: unknown function %q", name.pos, name.text)
+	}
+
+	if filter != nil {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("position %d: %s() takes no arguments", name.pos, name.text)
+		}
+		return g.filter(filter), nil
+	}
+	if len(args) != 1 {
+		return nil, fmt.Errorf("position %d: %s() takes one argument", name.pos, name.text)
+	}
+	return g.neighbors(args[0], edges, transitive), nil
+}
@@ -0,0 +1,164 @@
+package query
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Package is a node of the package-level dependency graph queried by expressions
+type Package struct {
+	Path    string   // Directory of a local package, or the import path of a stdlib or external one
+	Kind    string   // "local", "stdlib" or "external"
+	Main    bool     // Whether this is a main package
+	Imports []string // Paths of the packages it imports
+}
+
+// Run evaluates expression against the packages and returns the paths of the matching
+// packages, sorted. layers are the directories_import keys used by layer().
+//
+// Expressions combine path patterns (internal/app, internal/..., cmd/*) and function
+// calls with & (intersection), | (union) and - (difference); & binds tighter than | and -,
+// which apply left to right. A - inside a word belongs to the path, so difference needs
+// a space before it: "closure(cmd/...) - local()".
+func Run(expression string, packages []Package, layers []string) ([]string, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, graph: newGraph(packages, layers)}
+	result, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("position %d: unexpected %q", tok.pos, tok.text)
+	}
+	return result.sorted(), nil
+}
+
+// set is a set of package paths
+type set map[string]bool
+
+func (s set) sorted() []string {
+	paths := make([]string, 0, len(s))
+	for p := range s {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// graph indexes the packages for evaluation
+type graph struct {
+	packages   map[string]Package
+	dependents map[string][]string // package -> packages importing it
+	layers     []string
+}
+
+func newGraph(packages []Package, layers []string) *graph {
+	g := &graph{packages: make(map[string]Package), dependents: make(map[string][]string), layers: layers}
+	for _, pkg := range packages {
+		g.packages[pkg.Path] = pkg
+	}
+	for _, pkg := range packages {
+		for _, imp := range pkg.Imports {
+			g.dependents[imp] = append(g.dependents[imp], pkg.Path)
+		}
+	}
+	return g
+}
+
+// filter returns the packages accepted by keep
+func (g *graph) filter(keep func(Package) bool) set {
+	result := make(set)
+	for p, pkg := range g.packages {
+		if keep(pkg) {
+			result[p] = true
+		}
+	}
+	return result
+}
+
+// neighbors returns the packages one step away from from, or every package reachable
+// from it with transitive (excluding from itself unless it is reachable through a cycle)
+func (g *graph) neighbors(from set, edges func(string) []string, transitive bool) set {
+	result := make(set)
+	queue := from.sorted()
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range edges(current) {
+			if result[next] {
+				continue
+			}
+			result[next] = true
+			if transitive {
+				queue = append(queue, next)
+			}
+		}
+	}
+	return result
+}
+
+func (g *graph) imports(p string) []string {
+	return g.packages[p].Imports
+}
+
+func (g *graph) importedBy(p string) []string {
+	return g.dependents[p]
+}
+
+// match returns the packages matching a path pattern: an exact path, a path ending in
+// /... for a directory and everything below it, or a path.Match glob
+func (g *graph) match(pattern string) (set, error) {
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return g.filter(func(pkg Package) bool {
+			return pkg.Path == prefix || strings.HasPrefix(pkg.Path, prefix+"/")
+		}), nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return g.filter(func(pkg Package) bool {
+		matched, _ := path.Match(pattern, pkg.Path)
+		return matched
+	}), nil
+}
+
+// layer returns the local packages whose most specific directories_import key is name,
+// or ends in /name
+func (g *graph) layer(name string) (set, error) {
+	name = strings.TrimSuffix(name, "/")
+	known := false
+	for _, key := range g.layers {
+		if key == name || path.Base(key) == name {
+			known = true
+		}
+	}
+	if !known {
+		layers := append([]string(nil), g.layers...)
+		sort.Strings(layers)
+		return nil, fmt.Errorf("unknown layer %q (layers: %s)", name, strings.Join(layers, ", "))
+	}
+
+	return g.filter(func(pkg Package) bool {
+		if pkg.Kind != "local" {
+			return false
+		}
+		key := g.layerOf(pkg.Path)
+		return key != "" && (key == name || path.Base(key) == name)
+	}), nil
+}
+
+// layerOf returns the most specific directories_import key containing a package
+func (g *graph) layerOf(pkgPath string) string {
+	best := ""
+	for _, key := range g.layers {
+		if (pkgPath == key || strings.HasPrefix(pkgPath, key+"/")) && len(key) > len(best) {
+			best = key
+		}
+	}
+	return best
+}
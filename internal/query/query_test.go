@@ -0,0 +1,84 @@
+package query_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/query"
+)
+
+func testPackages() []query.Package {
+	return []query.Package{
+		{Path: "cmd/api", Kind: "local", Main: true, Imports: []string{"internal/app", "internal/infra/db", "fmt"}},
+		{Path: "internal/app", Kind: "local", Imports: []string{"internal/domain", "context"}},
+		{Path: "internal/domain", Kind: "local"},
+		{Path: "internal/infra/db", Kind: "local", Imports: []string{"internal/domain", "github.com/lib/pq"}},
+		{Path: "fmt", Kind: "stdlib"},
+		{Path: "context", Kind: "stdlib"},
+		{Path: "github.com/lib/pq", Kind: "external"},
+	}
+}
+
+var testLayers = []string{"cmd", "internal/app", "internal/domain", "internal/infra"}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"internal/app", "internal/app"},
+		{"internal/...", "internal/app,internal/domain,internal/infra/db"},
+		{"internal/*", "internal/app,internal/domain"},
+		{"deps(cmd/api)", "fmt,internal/app,internal/infra/db"},
+		{"rdeps(internal/domain)", "internal/app,internal/infra/db"},
+		{"closure(cmd/api) & local()", "internal/app,internal/domain,internal/infra/db"},
+		{"rclosure(internal/domain)", "cmd/api,internal/app,internal/infra/db"},
+		{"deps(internal/app) & layer(infra)", ""},
+		{"deps(cmd/api) & layer(infra)", "internal/infra/db"},
+		{"layer(internal/domain) | layer(app)", "internal/app,internal/domain"},
+		{"closure(cmd/api) - local()", "context,fmt,github.com/lib/pq"},
+		{"external() | stdlib() & deps(internal/app)", "context,github.com/lib/pq"},
+		{"(external() | stdlib()) & deps(internal/app)", "context"},
+		{"main()", "cmd/api"},
+		{"rdeps(external())", "internal/infra/db"},
+		{"all() - local() - stdlib()", "github.com/lib/pq"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			result, err := query.Run(tt.expression, testPackages(), testLayers)
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if got := strings.Join(result, ","); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"", "empty query"},
+		{"deps(cmd/api", `position 13: expected ")", got "end of query"`},
+		{"deps()", "deps() takes one argument"},
+		{"local(cmd/api)", "local() takes no arguments"},
+		{"imports(cmd/api)", `unknown function "imports"`},
+		{"layer(web)", `unknown layer "web" (layers: cmd, internal/app, internal/domain, internal/infra)`},
+		{"cmd/api internal/app", `position 9: unexpected "internal/app"`},
+		{"& cmd/api", `position 1: expected a package pattern, function or "(", got "&"`},
+		{"internal/[", "invalid pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := query.Run(tt.expression, testPackages(), testLayers)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Package release finds published go-arch-lint releases that are newer than the running
+// binary, and the rule changes their release notes describe.
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Release is a published GitHub release
+type Release struct {
+	Tag        string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"` // Release notes (markdown)
+	URL        string `json:"html_url"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// Client lists the releases of a GitHub repository
+type Client struct {
+	BaseURL    string // GitHub API URL (default: https://api.github.com)
+	Repo       string // Repository as owner/name
+	HTTPClient *http.Client
+}
+
+// Newer returns the published releases newer than version, newest first. Drafts,
+// pre-releases and tags that are not semantic versions are skipped.
+func (c *Client) Newer(version string) ([]Release, error) {
+	if _, ok := parseVersion(version); !ok {
+		return nil, fmt.Errorf("cannot compare version %q with releases", version)
+	}
+
+	releases, err := c.list()
+	if err != nil {
+		return nil, err
+	}
+
+	var newer []Release
+	for _, r := range releases {
+		if r.Draft || r.Prerelease {
+			continue
+		}
+		if _, ok := parseVersion(r.Tag); !ok {
+			continue
+		}
+		if Compare(r.Tag, version) > 0 {
+			newer = append(newer, r)
+		}
+	}
+	sort.SliceStable(newer, func(i, j int) bool {
+		return Compare(newer[i].Tag, newer[j].Tag) > 0
+	})
+	return newer, nil
+}
+
+// list fetches the most recent releases of the repository
+func (c *Client) list() ([]Release, error) {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=100", strings.TrimSuffix(baseURL, "/"), c.Repo)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	var releases []Release
+	if err := json.Unmarshal(data, &releases); err != nil {
+		return nil, fmt.Errorf("GET %s: invalid response: %w", url, err)
+	}
+	return releases, nil
+}
+
+// Compare compares two semantic versions such as v1.2.3 or 1.2.3-rc.1 and returns -1, 0
+// or 1. A pre-release sorts before the release it precedes. Versions that cannot be
+// parsed sort first.
+func Compare(a, b string) int {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+
+	for i := 0; i < 3; i++ {
+		if va.numbers[i] != vb.numbers[i] {
+			if va.numbers[i] < vb.numbers[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case va.pre == vb.pre:
+		return 0
+	case va.pre == "":
+		return 1
+	case vb.pre == "":
+		return -1
+	case va.pre < vb.pre:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// version is a parsed semantic version
+type version struct {
+	numbers [3]int
+	pre     string // Pre-release suffix (empty for releases)
+}
+
+// parseVersion parses major[.minor[.patch]][-pre][+build] with an optional leading v
+func parseVersion(s string) (version, bool) {
+	var v version
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.Index(s, "+"); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.Index(s, "-"); i >= 0 {
+		s, v.pre = s[:i], s[i+1:]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.numbers[i] = n
+	}
+	return v, true
+}
+
+// RuleChanges extracts the rule changes from release notes: the lines of sections whose
+// heading mentions rules, or when there is no such section, the list items that mention
+// a rule
+func RuleChanges(notes string) []string {
+	var section, items []string
+	inSection := false
+	for _, line := range strings.Split(notes, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			inSection = strings.Contains(strings.ToLower(trimmed), "rule")
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+		if inSection {
+			section = append(section, trimmed)
+		}
+		if isListItem(trimmed) && strings.Contains(strings.ToLower(trimmed), "rule") {
+			items = append(items, trimmed)
+		}
+	}
+
+	if len(section) > 0 {
+		return section
+	}
+	return items
+}
+
+// isListItem reports whether a trimmed markdown line is a bullet list item
+func isListItem(line string) bool {
+	return strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ")
+}
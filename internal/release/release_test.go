@@ -0,0 +1,112 @@
+package release_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/release"
+)
+
+func TestClient_Newer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/tool/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[
+			{"tag_name": "v1.3.0-rc.1", "prerelease": true},
+			{"tag_name": "v1.2.0", "html_url": "https://example.com/v1.2.0"},
+			{"tag_name": "nightly"},
+			{"tag_name": "v1.10.0", "draft": true},
+			{"tag_name": "v1.1.1"},
+			{"tag_name": "v1.1.0"},
+			{"tag_name": "v1.0.0"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := &release.Client{BaseURL: server.URL, Repo: "owner/tool"}
+	newer, err := client.Newer("1.1.0")
+	if err != nil {
+		t.Fatalf("Newer failed: %v", err)
+	}
+
+	var tags []string
+	for _, r := range newer {
+		tags = append(tags, r.Tag)
+	}
+	if want := []string{"v1.2.0", "v1.1.1"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("expected %v, got %v", want, tags)
+	}
+	if newer[0].URL != "https://example.com/v1.2.0" {
+		t.Errorf("expected the release URL, got %q", newer[0].URL)
+	}
+
+	if _, err := client.Newer("dev"); err == nil {
+		t.Error("expected an error for a version that is not semantic")
+	}
+}
+
+func TestClient_Newer_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := &release.Client{BaseURL: server.URL, Repo: "owner/tool"}
+	if _, err := client.Newer("v1.0.0"); err == nil {
+		t.Error("expected an error for a failed request")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.2.3", "1.2.3", 0},
+		{"v1.2.3", "v1.10.0", -1},
+		{"v2", "v1.9.9", 1},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0-rc.2", "v1.0.0-rc.1", 1},
+		{"v1.0.0+build.5", "v1.0.0", 0},
+		{"dev", "v0.0.1", -1},
+	}
+	for _, tt := range tests {
+		if got := release.Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRuleChanges(t *testing.T) {
+	withSection := `## Features
+- New query command
+- Rule profiles
+
+## Rule changes
+- detect_unused now ignores generated files
+- directories_import keys accept wildcards
+
+## Fixes
+- Crash on empty files`
+	want := []string{"- detect_unused now ignores generated files", "- directories_import keys accept wildcards"}
+	if got := release.RuleChanges(withSection); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the rule section %v, got %v", want, got)
+	}
+
+	withoutSection := `- New query command
+- Stricter rule for blackbox tests
+* default_policy rule added
+Some prose mentioning rules`
+	want = []string{"- Stricter rule for blackbox tests", "* default_policy rule added"}
+	if got := release.RuleChanges(withoutSection); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the list items mentioning rules %v, got %v", want, got)
+	}
+
+	if got := release.RuleChanges("Bug fixes only"); len(got) != 0 {
+		t.Errorf("expected no rule changes, got %v", got)
+	}
+}
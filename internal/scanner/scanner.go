@@ -2,40 +2,357 @@ package scanner
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"go/ast"
+	"go/build"
 	"go/parser"
+	goscanner "go/scanner"
 	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 )
 
 // ScanOptions configures what information to include in scan results
 type ScanOptions struct {
-	IncludeImportUsages bool // Include detailed import usage information
-	IncludeExportedAPI  bool // Include exported API declarations
+	IncludeImportUsages      bool     // Include detailed import usage information
+	IncludeExportedAPI       bool     // Include exported API declarations
+	IncludeDefinitions       bool     // Include struct and constant block definitions
+	IncludeAPIReferences     bool     // Include imported symbols referenced by exported declarations
+	IncludeSignatureRefs     bool     // Include imported symbols referenced by any function signature
+	IncludeStability         bool     // Include the archlint:stability package doc annotation
+	IncludePackageDoc        bool     // Include the package doc comment text
+	IncludeDeprecations      bool     // Include exported package-level symbols marked "Deprecated:"
+	IncludeSymbolRefs        bool     // Include every reference to an imported symbol
+	IncludeFileReads         bool     // Include file paths passed as string literals to os/ioutil readers
+	IncludeSymbolUses        bool     // Include every use of an imported or same-package symbol, classified by kind
+	IncludeFuncDecls         bool     // Include the names of package-level functions
+	IncludeDynamicRefs       bool     // Include string keys of registry calls, reflect lookups by name and exported methods
+	RegisterFuncs            []string // With IncludeDynamicRefs: function and method names that register a string key
+	LookupFuncs              []string // With IncludeDynamicRefs: function and method names that look up a string key
+	IncludeInitRegistrations bool     // Include what init functions register (calls to Register* or Handle*, map assignments)
+	IncludeConstructions     bool     // Include the components main packages and DI wiring construct and the components injected into them
+	IncludeExamples          bool     // Include the Example functions of test files, even when test files are not linted
+	IncludeSuppressions      bool     // Include "//archlint:ignore" directives
 }
 
 // FileInfo contains information about a scanned Go file
 // Optional fields are populated based on ScanOptions
 type FileInfo struct {
-	Path          string         // Absolute path to the file
-	RelPath       string         // Path relative to project root
-	Package       string         // Package name
-	Imports       []string       // Import paths
-	ImportUsages  []ImportUsage  // Detailed import usage (nil if not requested)
-	ExportedDecls []ExportedDecl // Exported API declarations (nil if not requested)
-	IsTest        bool           // Whether this is a test file (*_test.go)
-	BaseName      string         // Base name without extension and _test suffix (e.g., "foo" from "foo.go" or "foo_test.go")
-	LineCount     int            // Number of lines in the file
+	Path              string         // Absolute path to the file
+	RelPath           string         // Path relative to project root
+	Package           string         // Package name
+	Imports           []string       // Import paths
+	ImportLines       []int          // Line of each import, parallel to Imports
+	ImportColumns     []int          // Column of each import path, parallel to Imports
+	PackageLine       int            // Line of the package clause name
+	PackageColumn     int            // Column of the package clause name
+	ImportUsages      []ImportUsage  // Detailed import usage (nil if not requested)
+	ExportedDecls     []ExportedDecl // Exported API declarations (nil if not requested)
+	IsTest            bool           // Whether this is a test file (*_test.go)
+	BaseName          string         // Base name without extension and _test suffix (e.g., "foo" from "foo.go" or "foo_test.go")
+	LineCount         int            // Number of lines in the file
+	Size              int64          // Size of the file in bytes
+	StructDefs        []StructDef    // Struct type definitions (nil if not requested)
+	ConstBlocks       []ConstBlock   // Grouped constant declarations (nil if not requested)
+	APIReferences     []APIReference // Imported symbols exposed by exported declarations (nil if not requested)
+	SignatureRefs     []APIReference // Imported symbols used in function signatures (nil if not requested)
+	Stability         string         // Package stability from "// archlint:stability <level>" (empty if absent or not requested)
+	PackageDoc        string         // Package doc comment without archlint annotations (empty if absent or not requested)
+	Deprecated        []string       // Exported package-level symbols marked deprecated (nil if not requested)
+	SymbolRefs        []APIReference // All references to imported symbols (nil if not requested)
+	FileReads         []FileRead     // Literal file paths read via os/ioutil (nil if not requested)
+	SymbolUses        []SymbolUse    // Uses of imported and same-package symbols with their kind (nil if not requested)
+	FuncDecls         []string       // Package-level functions declared in the file, without methods (nil if not requested)
+	DynamicRefs       []DynamicRef   // Registry keys, reflect lookups and exported methods (nil if not requested)
+	BlankImports      []string       // Import paths imported for side effects only (import _ "path")
+	InitRegistrations []string       // What init functions register, e.g. "sql.Register" or "drivers[...]" (nil if not requested)
+	Constructions     []Construction // Components constructed by main packages and DI wiring (nil if not requested)
+	DIFramework       string         // DI framework whose wiring the file holds: DIFrameworkWire, DIFrameworkFx, DIFrameworkDig or empty
+	Examples          []Example      // Example functions of the package's test files, set on the first non-test file of each directory (nil if not requested)
+	Suppressions      []Suppression  // "//archlint:ignore" directives (nil if none or not requested)
+}
+
+// StructDef represents a struct type definition with its field layout
+type StructDef struct {
+	Name   string
+	Line   int
+	Column int
+	Fields []string // "Name Type" in declaration order (embedded fields as type only)
+}
+
+// GetName implements validator.StructDef interface
+func (sd StructDef) GetName() string {
+	return sd.Name
+}
+
+// GetLine implements validator.StructDef interface
+func (sd StructDef) GetLine() int {
+	return sd.Line
+}
+
+// GetColumn implements validator.StructDef interface
+func (sd StructDef) GetColumn() int {
+	return sd.Column
+}
+
+// GetFields implements validator.StructDef interface
+func (sd StructDef) GetFields() []string {
+	return sd.Fields
+}
+
+// ConstBlock represents a parenthesized group of constants
+type ConstBlock struct {
+	Line    int
+	Column  int
+	Entries []string // "Name [Type] [= Value]" in declaration order
+}
+
+// GetLine implements validator.ConstBlock interface
+func (cb ConstBlock) GetLine() int {
+	return cb.Line
+}
+
+// GetColumn implements validator.ConstBlock interface
+func (cb ConstBlock) GetColumn() int {
+	return cb.Column
+}
+
+// GetEntries implements validator.ConstBlock interface
+func (cb ConstBlock) GetEntries() []string {
+	return cb.Entries
+}
+
+// APIReference records an imported symbol that appears in an exported declaration
+type APIReference struct {
+	Decl       string // Exported declaration (methods as "Type.Method")
+	ImportPath string // Import path of the referenced package
+	Symbol     string // Referenced symbol (e.g., "Client")
+	Line       int
+	Column     int
+}
+
+// GetDecl implements validator.APIReference interface
+func (r APIReference) GetDecl() string {
+	return r.Decl
+}
+
+// GetImportPath implements validator.APIReference interface
+func (r APIReference) GetImportPath() string {
+	return r.ImportPath
+}
+
+// GetSymbol implements validator.APIReference interface
+func (r APIReference) GetSymbol() string {
+	return r.Symbol
+}
+
+// GetLine implements validator.APIReference interface
+func (r APIReference) GetLine() int {
+	return r.Line
+}
+
+// GetColumn implements validator.APIReference interface
+func (r APIReference) GetColumn() int {
+	return r.Column
+}
+
+// FileRead is a file path passed as a string literal to a file reader such as os.ReadFile
+type FileRead struct {
+	Function string // Qualified reader, e.g. "os.ReadFile"
+	Path     string // Slash-separated path as written
+	Line     int
+	Column   int
+}
+
+// GetFunction returns the qualified reader function
+func (r FileRead) GetFunction() string {
+	return r.Function
+}
+
+// GetPath returns the path read
+func (r FileRead) GetPath() string {
+	return r.Path
+}
+
+// GetLine returns the line of the path argument
+func (r FileRead) GetLine() int {
+	return r.Line
+}
+
+// GetColumn returns the column of the path argument
+func (r FileRead) GetColumn() int {
+	return r.Column
+}
+
+// Kinds of DynamicRef
+const (
+	DynamicRefRegister = "register" // Key passed to a registration function, e.g. registry.Register("billing", ...)
+	DynamicRefLookup   = "lookup"   // Key passed to a lookup function, e.g. registry.Get("billing")
+	DynamicRefReflect  = "reflect"  // Name passed to a reflect lookup, e.g. v.MethodByName("Charge")
+	DynamicRefMethod   = "method"   // Exported method a reflect lookup can reach by name
+)
+
+// DynamicRef is a dependency the import graph does not show: a string key registered in
+// or looked up from a registry, a name looked up via reflect, or a method reachable that way
+type DynamicRef struct {
+	Kind     string // DynamicRefRegister, DynamicRefLookup, DynamicRefReflect or DynamicRefMethod
+	Function string // Call as written, e.g. "registry.Get", or the method as "Type.Method"
+	Key      string // Registry key, or name of the method or field
+	Line     int
+	Column   int
+}
+
+// GetKind returns the kind of the reference
+func (r DynamicRef) GetKind() string {
+	return r.Kind
+}
+
+// GetFunction returns the call or method
+func (r DynamicRef) GetFunction() string {
+	return r.Function
+}
+
+// GetKey returns the registry key or name
+func (r DynamicRef) GetKey() string {
+	return r.Key
+}
+
+// GetLine returns the line of the key or method name
+func (r DynamicRef) GetLine() int {
+	return r.Line
+}
+
+// GetColumn returns the column of the key or method name
+func (r DynamicRef) GetColumn() int {
+	return r.Column
+}
+
+// DI frameworks whose wiring code is recognized
+const (
+	DIFrameworkWire = "wire" // github.com/google/wire provider sets and injectors, and the wire_gen.go it generates
+	DIFrameworkFx   = "fx"   // go.uber.org/fx modules
+	DIFrameworkDig  = "dig"  // go.uber.org/dig containers
+)
+
+// diFrameworkImports maps the import paths of the DI frameworks to their names
+var diFrameworkImports = map[string]string{
+	"github.com/google/wire": DIFrameworkWire,
+	"go.uber.org/fx":         DIFrameworkFx,
+	"go.uber.org/dig":        DIFrameworkDig,
+}
+
+// detectDIFramework returns the DI framework whose wiring a file holds: code generated by
+// Wire, which always writes wire_gen.go and does not import wire itself, or a file
+// importing one of the frameworks
+func detectDIFramework(fileName string, imports []string) string {
+	if fileName == "wire_gen.go" {
+		return DIFrameworkWire
+	}
+	for _, imp := range imports {
+		if framework, ok := diFrameworkImports[imp]; ok {
+			return framework
+		}
+	}
+	return ""
+}
+
+// Construction is a component a main package or DI wiring builds: the result of a
+// constructor (a function named New* or Open*), a composite literal of an imported type,
+// or a provider registered with a DI container
+type Construction struct {
+	ImportPath string   // Package of the constructor or type; empty for a provider of the file's own package
+	Name       string   // Constructor or type name, e.g. "NewOrderService" or "Server"
+	Injected   []string // Constructions passed in as arguments or field values, as "importpath.Name"
+	Provided   bool     // Registered with a DI container instead of called; the container injects its dependencies by type
+	Line       int
+	Column     int
+}
+
+// GetImportPath returns the package of the constructor or type
+func (c Construction) GetImportPath() string {
+	return c.ImportPath
+}
+
+// GetName returns the constructor or type name
+func (c Construction) GetName() string {
+	return c.Name
+}
+
+// GetInjected returns the constructions injected into this one
+func (c Construction) GetInjected() []string {
+	return c.Injected
+}
+
+// GetLine returns the line of the call or literal
+func (c Construction) GetLine() int {
+	return c.Line
+}
+
+// IsProvided reports whether the construction is a provider registered with a DI container
+func (c Construction) IsProvided() bool {
+	return c.Provided
+}
+
+// GetColumn returns the column of the call or literal
+func (c Construction) GetColumn() int {
+	return c.Column
+}
+
+// Kinds of SymbolUse
+const (
+	SymbolUseLiteral = "literal" // Type of a composite literal, e.g. pkg.T{...}
+	SymbolUseCall    = "call"    // Called function or conversion, e.g. pkg.New(...)
+	SymbolUseRef     = "ref"     // Any other reference (type in a declaration, variable, constant)
+)
+
+// SymbolUse is a use of an imported symbol, or of a package-level symbol declared in
+// another file of the same package, in a file
+type SymbolUse struct {
+	ImportPath string // Empty for symbols of the file's own package
+	Symbol     string
+	Kind       string // SymbolUseLiteral, SymbolUseCall or SymbolUseRef
+	Line       int
+	Column     int
+}
+
+// GetImportPath returns the import path of the used symbol (empty for the own package)
+func (u SymbolUse) GetImportPath() string {
+	return u.ImportPath
+}
+
+// GetSymbol returns the name of the used symbol
+func (u SymbolUse) GetSymbol() string {
+	return u.Symbol
+}
+
+// GetKind returns how the symbol is used
+func (u SymbolUse) GetKind() string {
+	return u.Kind
+}
+
+// GetLine returns the line of the use
+func (u SymbolUse) GetLine() int {
+	return u.Line
+}
+
+// GetColumn returns the column of the use
+func (u SymbolUse) GetColumn() int {
+	return u.Column
 }
 
 // ImportUsage tracks which symbols are used from an import
 type ImportUsage struct {
 	ImportPath  string   // Full import path
 	UsedSymbols []string // Symbols used from this import (e.g., ["Run", "New"])
+	CallSites   int      // References to the used symbols in the file
 }
 
 // GetImportPath implements graph.ImportUsage interface
@@ -48,6 +365,11 @@ func (iu ImportUsage) GetUsedSymbols() []string {
 	return iu.UsedSymbols
 }
 
+// GetCallSites returns the number of references to the used symbols
+func (iu ImportUsage) GetCallSites() int {
+	return iu.CallSites
+}
+
 // GetImportUsages returns the import usages
 // This method allows FileInfo to satisfy interfaces via structural typing
 func (f FileInfo) GetImportUsages() []ImportUsage {
@@ -60,6 +382,10 @@ type ExportedDecl struct {
 	Kind       string   // "func", "type", "const", "var"
 	Signature  string   // Function signature or type definition
 	Properties []string // Struct fields for types
+	TypeKind   string   // For types: "struct", "interface" or "other"
+	Embeds     []string // For structs and interfaces: embedded types as written (e.g. "Reader", "*Base", "io.Writer")
+	Doc        string   // Doc comment text (empty if undocumented)
+	Line       int      // Line of the declared name
 }
 
 // GetName implements output.ExportedDecl interface
@@ -82,6 +408,26 @@ func (e ExportedDecl) GetProperties() []string {
 	return e.Properties
 }
 
+// GetTypeKind implements output.ExportedDecl interface
+func (e ExportedDecl) GetTypeKind() string {
+	return e.TypeKind
+}
+
+// GetEmbeds implements output.ExportedDecl interface
+func (e ExportedDecl) GetEmbeds() []string {
+	return e.Embeds
+}
+
+// GetDoc implements output.ExportedDecl interface
+func (e ExportedDecl) GetDoc() string {
+	return e.Doc
+}
+
+// GetLine implements output.ExportedDecl interface
+func (e ExportedDecl) GetLine() int {
+	return e.Line
+}
+
 // GetRelPath implements graph.FileInfo interface
 func (f FileInfo) GetRelPath() string {
 	return f.RelPath
@@ -97,6 +443,26 @@ func (f FileInfo) GetImports() []string {
 	return f.Imports
 }
 
+// GetImportLines implements graph.FileInfo interface
+func (f FileInfo) GetImportLines() []int {
+	return f.ImportLines
+}
+
+// GetImportColumns implements graph.FileInfo interface
+func (f FileInfo) GetImportColumns() []int {
+	return f.ImportColumns
+}
+
+// GetPackageLine implements graph.FileInfo interface
+func (f FileInfo) GetPackageLine() int {
+	return f.PackageLine
+}
+
+// GetPackageColumn implements graph.FileInfo interface
+func (f FileInfo) GetPackageColumn() int {
+	return f.PackageColumn
+}
+
 // GetBaseName implements graph.FileInfo interface
 func (f FileInfo) GetBaseName() string {
 	return f.BaseName
@@ -107,164 +473,1608 @@ func (f FileInfo) GetIsTest() bool {
 	return f.IsTest
 }
 
-// GetLineCount returns the number of lines in the file
-func (f FileInfo) GetLineCount() int {
-	return f.LineCount
-}
+// GetLineCount returns the number of lines in the file
+func (f FileInfo) GetLineCount() int {
+	return f.LineCount
+}
+
+// GetSize returns the size of the file in bytes
+func (f FileInfo) GetSize() int64 {
+	return f.Size
+}
+
+// GetStability returns the package stability annotation of the file
+func (f FileInfo) GetStability() string {
+	return f.Stability
+}
+
+// GetBlankImports implements graph.FileInfo interface
+func (f FileInfo) GetBlankImports() []string {
+	return f.BlankImports
+}
+
+// GetInitRegistrations implements graph.FileInfo interface
+func (f FileInfo) GetInitRegistrations() []string {
+	return f.InitRegistrations
+}
+
+// GetDIFramework implements graph.FileInfo interface
+func (f FileInfo) GetDIFramework() string {
+	return f.DIFramework
+}
+
+// GetPackageDoc returns the package doc comment of the file
+func (f FileInfo) GetPackageDoc() string {
+	return f.PackageDoc
+}
+
+// Example is a runnable Example function declared in a test file
+type Example struct {
+	Name    string // Function name, e.g. "ExampleCache_Get_second"
+	Symbol  string // Documented symbol, e.g. "Cache.Get" or "Sum"; empty for package examples
+	RelPath string // Test file declaring the example
+	Line    int
+}
+
+// GetName implements output.Example interface
+func (e Example) GetName() string {
+	return e.Name
+}
+
+// GetSymbol implements output.Example interface
+func (e Example) GetSymbol() string {
+	return e.Symbol
+}
+
+// GetRelPath implements output.Example interface
+func (e Example) GetRelPath() string {
+	return e.RelPath
+}
+
+// GetLine implements output.Example interface
+func (e Example) GetLine() int {
+	return e.Line
+}
+
+// Suppression is an "//archlint:ignore <rule> [until=YYYY-MM-DD] [reason=...]" directive.
+// It covers violations on its own line and the next one, or the whole file when it
+// precedes the package clause.
+type Suppression struct {
+	Rule      string // Dotted key of the suppressed rule, e.g. "rules.directories_import" (empty if missing)
+	Until     string // Expiry date as written, empty if the directive does not expire
+	Reason    string // Justification, empty if none is given
+	Line      int    // Line of the directive
+	WholeFile bool   // Whether the directive precedes the package clause
+}
+
+// ParseError describes a Go file that could not be parsed and was skipped
+type ParseError struct {
+	RelPath string // Path relative to project root
+	Line    int    // Line of the first syntax error
+	Column  int    // Column of the first syntax error
+	Message string // Description of the first syntax error
+}
+
+// GetRelPath implements validator.ParseError interface
+func (pe ParseError) GetRelPath() string {
+	return pe.RelPath
+}
+
+// GetLine implements validator.ParseError interface
+func (pe ParseError) GetLine() int {
+	return pe.Line
+}
+
+// GetColumn implements validator.ParseError interface
+func (pe ParseError) GetColumn() int {
+	return pe.Column
+}
+
+// GetMessage implements validator.ParseError interface
+func (pe ParseError) GetMessage() string {
+	return pe.Message
+}
+
+type Scanner struct {
+	projectPath   string
+	module        string
+	ignorePaths   []string
+	lintTestFiles bool
+	strictParse   bool
+	parseErrors   []ParseError
+	buildContext  *build.Context
+	fsys          fs.FS // Project files, if not read from disk
+}
+
+func New(projectPath, module string, ignorePaths []string, lintTestFiles bool) *Scanner {
+	return &Scanner{
+		projectPath:   projectPath,
+		module:        module,
+		ignorePaths:   ignorePaths,
+		lintTestFiles: lintTestFiles,
+	}
+}
+
+// SetStrictParse makes Scan abort on the first file with syntax errors
+// instead of recording it in ParseErrors and continuing
+func (s *Scanner) SetStrictParse(strict bool) {
+	s.strictParse = strict
+}
+
+// SetBuildContext restricts Scan to the files built for goos/goarch with the given tags,
+// honoring file name suffixes and //go:build lines. Empty goos or goarch default to the
+// host platform. Without a build context every Go file is scanned, whatever its constraints.
+func (s *Scanner) SetBuildContext(goos, goarch string, tags []string) {
+	ctx := build.Default
+	if goos != "" {
+		ctx.GOOS = goos
+	}
+	if goarch != "" {
+		ctx.GOARCH = goarch
+	}
+	ctx.BuildTags = tags
+	// File constraints are read from wherever the project is
+	ctx.OpenFile = s.openFile
+	s.buildContext = &ctx
+}
+
+// SetFS makes Scan read the project from fsys, rooted at the project directory, instead
+// of from disk (nil), e.g. to scan a past revision without checking it out. Paths in the
+// scan results are still below the project path.
+func (s *Scanner) SetFS(fsys fs.FS) {
+	s.fsys = fsys
+}
+
+// FS returns the file system set with SetFS, or nil if the project is read from disk
+func (s *Scanner) FS() fs.FS {
+	return s.fsys
+}
+
+// fsName returns the name in s.fsys of a path below the project path
+func (s *Scanner) fsName(path string) string {
+	rel, err := filepath.Rel(s.projectPath, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// stat returns the file info of a path below the project path
+func (s *Scanner) stat(path string) (fs.FileInfo, error) {
+	if s.fsys == nil {
+		return os.Stat(path)
+	}
+	return fs.Stat(s.fsys, s.fsName(path))
+}
+
+// readFile returns the content of a file below the project path
+func (s *Scanner) readFile(path string) ([]byte, error) {
+	if s.fsys == nil {
+		return os.ReadFile(path)
+	}
+	return fs.ReadFile(s.fsys, s.fsName(path))
+}
+
+// openFile opens a file below the project path
+func (s *Scanner) openFile(path string) (io.ReadCloser, error) {
+	if s.fsys == nil {
+		return os.Open(path)
+	}
+	return s.fsys.Open(s.fsName(path))
+}
+
+// walk walks the file tree rooted at a path below the project path, like filepath.Walk
+func (s *Scanner) walk(root string, fn filepath.WalkFunc) error {
+	if s.fsys == nil {
+		return filepath.Walk(root, fn)
+	}
+	return fs.WalkDir(s.fsys, s.fsName(root), func(name string, d fs.DirEntry, err error) error {
+		path := filepath.Join(s.projectPath, filepath.FromSlash(name))
+		if err != nil {
+			return fn(path, nil, err)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return fn(path, nil, err)
+		}
+		return fn(path, info, nil)
+	})
+}
+
+// ParseErrors returns the files skipped by the last Scan because of syntax errors, as
+// updated by ScanFiles since
+func (s *Scanner) ParseErrors() []ParseError {
+	return s.parseErrors
+}
+
+// Scan walks the specified paths and parses all Go files with optional detailed information
+func (s *Scanner) Scan(scanPaths []string, opts ScanOptions) ([]FileInfo, error) {
+	var files []FileInfo
+	s.parseErrors = nil
+	examplesByDir := make(map[string][]Example)
+
+	for _, scanPath := range scanPaths {
+		fullPath := filepath.Join(s.projectPath, scanPath)
+
+		// Check if path exists
+		if _, err := s.stat(fullPath); os.IsNotExist(err) {
+			continue // Skip non-existent paths
+		}
+
+		err := s.walk(fullPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			// Skip directories
+			if info.IsDir() {
+				// Check if directory should be ignored
+				if s.shouldIgnore(path) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			// Only process .go files
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			// Skip test files unless lintTestFiles is enabled or their examples are requested
+			isTestFile := strings.HasSuffix(path, "_test.go")
+			if !s.lintTestFiles && isTestFile && !opts.IncludeExamples {
+				return nil
+			}
+			// Skip files excluded by the build context (files whose constraints cannot be
+			// read are parsed, so their syntax errors are reported as usual)
+			if s.buildContext != nil {
+				if match, err := s.buildContext.MatchFile(filepath.Dir(path), filepath.Base(path)); err == nil && !match {
+					return nil
+				}
+			}
+
+			if isTestFile && opts.IncludeExamples {
+				// Test files that do not parse have no examples to document
+				if examples, err := s.parseExamples(path); err == nil {
+					examplesByDir[filepath.Dir(path)] = append(examplesByDir[filepath.Dir(path)], examples...)
+				}
+				if !s.lintTestFiles {
+					return nil
+				}
+			}
+
+			fileInfo, err := s.parseFileWithOptions(path, opts)
+			if err != nil {
+				var syntaxErrs goscanner.ErrorList
+				if s.strictParse || !errors.As(err, &syntaxErrs) || len(syntaxErrs) == 0 {
+					return fmt.Errorf("parsing %s: %w", path, err)
+				}
+				// Record the malformed file and continue with the remaining ones
+				s.parseErrors = append(s.parseErrors, s.newParseError(path, syntaxErrs))
+				return nil
+			}
+
+			files = append(files, fileInfo)
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Attach the examples of each directory to its first non-test file
+	for i := range files {
+		dir := filepath.Dir(files[i].Path)
+		if files[i].IsTest || examplesByDir[dir] == nil {
+			continue
+		}
+		files[i].Examples = examplesByDir[dir]
+		delete(examplesByDir, dir)
+	}
+
+	return files, nil
+}
+
+// ScanFiles parses the given files again after a Scan of scanPaths, for incremental
+// updates. Paths are relative to the project root. It returns the files that parse, and
+// the paths of the files that Scan would no longer return: deleted, outside scanPaths,
+// ignored, excluded by the build context, or not parsing anymore. ParseErrors is updated
+// for the given files, but examples are not collected.
+func (s *Scanner) ScanFiles(scanPaths, relPaths []string, opts ScanOptions) ([]FileInfo, []string, error) {
+	var files []FileInfo
+	var removed []string
+	for _, relPath := range relPaths {
+		relPath = filepath.ToSlash(filepath.Clean(relPath))
+		path := filepath.Join(s.projectPath, filepath.FromSlash(relPath))
+
+		// Syntax errors of the file are reported again below if it still has them
+		kept := s.parseErrors[:0]
+		for _, parseErr := range s.parseErrors {
+			if parseErr.RelPath != relPath {
+				kept = append(kept, parseErr)
+			}
+		}
+		s.parseErrors = kept
+
+		if !s.inScope(scanPaths, relPath) {
+			removed = append(removed, relPath)
+			continue
+		}
+		if info, err := s.stat(path); err != nil || info.IsDir() {
+			removed = append(removed, relPath)
+			continue
+		}
+
+		fileInfo, err := s.parseFileWithOptions(path, opts)
+		if err != nil {
+			var syntaxErrs goscanner.ErrorList
+			if s.strictParse || !errors.As(err, &syntaxErrs) || len(syntaxErrs) == 0 {
+				return nil, nil, fmt.Errorf("parsing %s: %w", path, err)
+			}
+			s.parseErrors = append(s.parseErrors, s.newParseError(path, syntaxErrs))
+			removed = append(removed, relPath)
+			continue
+		}
+		files = append(files, fileInfo)
+	}
+	return files, removed, nil
+}
+
+// inScope reports whether Scan of scanPaths would parse the file at relPath
+func (s *Scanner) inScope(scanPaths []string, relPath string) bool {
+	if !strings.HasSuffix(relPath, ".go") {
+		return false
+	}
+	isTestFile := strings.HasSuffix(relPath, "_test.go")
+	if isTestFile && !s.lintTestFiles {
+		return false
+	}
+
+	inScanPath := false
+	for _, scanPath := range scanPaths {
+		scanPath = filepath.ToSlash(filepath.Clean(scanPath))
+		inScanPath = inScanPath || scanPath == "." || strings.HasPrefix(relPath, scanPath+"/")
+	}
+	if !inScanPath {
+		return false
+	}
+
+	// Ignored directories, as Scan skips them while walking
+	path := filepath.Join(s.projectPath, filepath.FromSlash(relPath))
+	for dir := filepath.Dir(path); len(dir) > len(s.projectPath); dir = filepath.Dir(dir) {
+		if s.shouldIgnore(dir) {
+			return false
+		}
+	}
+
+	if s.buildContext != nil {
+		if match, err := s.buildContext.MatchFile(filepath.Dir(path), filepath.Base(path)); err == nil && !match {
+			return false
+		}
+	}
+	return true
+}
+
+// parseExamples returns the Example functions declared in a test file
+func (s *Scanner) parseExamples(path string) ([]Example, error) {
+	relPath, err := filepath.Rel(s.projectPath, path)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := s.readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var examples []Example
+	for _, decl := range node.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "Example") {
+			continue
+		}
+		// Examples take no parameters and return nothing
+		if fn.Type.Params.NumFields() > 0 || fn.Type.Results.NumFields() > 0 {
+			continue
+		}
+		symbol, ok := exampleSymbol(fn.Name.Name)
+		if !ok {
+			continue
+		}
+		examples = append(examples, Example{
+			Name:    fn.Name.Name,
+			Symbol:  symbol,
+			RelPath: filepath.ToSlash(relPath),
+			Line:    fset.Position(fn.Name.Pos()).Line,
+		})
+	}
+	return examples, nil
+}
+
+// exampleSymbol returns the symbol an Example function documents, following the go
+// doc naming convention: Example, ExampleF, ExampleT, ExampleT_M, each optionally
+// followed by a suffix starting with "_" and a lowercase letter. Returns false for
+// functions that are not examples (e.g. "Examples").
+func exampleSymbol(name string) (string, bool) {
+	rest := strings.TrimPrefix(name, "Example")
+	if rest == "" {
+		return "", true
+	}
+	if !strings.HasPrefix(rest, "_") && !token.IsExported(rest) {
+		return "", false
+	}
+
+	var parts []string
+	for i, part := range strings.Split(rest, "_") {
+		if i == 0 && part == "" {
+			continue // package example with suffix
+		}
+		if !token.IsExported(part) {
+			break // suffix
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, "."), true
+}
+
+// newParseError summarizes the syntax errors of a file
+func (s *Scanner) newParseError(path string, syntaxErrs goscanner.ErrorList) ParseError {
+	relPath, err := filepath.Rel(s.projectPath, path)
+	if err != nil {
+		relPath = path
+	}
+
+	message := syntaxErrs[0].Msg
+	if len(syntaxErrs) > 1 {
+		message = fmt.Sprintf("%s (and %d more errors)", message, len(syntaxErrs)-1)
+	}
+
+	return ParseError{
+		RelPath: filepath.ToSlash(relPath),
+		Line:    syntaxErrs[0].Pos.Line,
+		Column:  syntaxErrs[0].Pos.Column,
+		Message: message,
+	}
+}
+
+// parseFileWithOptions parses a file with optional detailed information based on ScanOptions
+func (s *Scanner) parseFileWithOptions(path string, opts ScanOptions) (FileInfo, error) {
+	relPath, err := filepath.Rel(s.projectPath, path)
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	// Determine parser mode based on options
+	parserMode := parser.ImportsOnly
+	if opts.IncludeImportUsages || opts.IncludeExportedAPI || opts.IncludeDefinitions || opts.IncludeAPIReferences || opts.IncludeSignatureRefs ||
+		opts.IncludeDeprecations || opts.IncludeSymbolRefs || opts.IncludeFileReads || opts.IncludeSymbolUses || opts.IncludeFuncDecls ||
+		opts.IncludeDynamicRefs || opts.IncludeConstructions {
+		parserMode = parser.ParseComments
+	}
+	if (opts.IncludeStability || opts.IncludePackageDoc) && parserMode == parser.ImportsOnly {
+		// Package doc comments precede the imports, so a full parse is not needed
+		parserMode |= parser.ParseComments
+	}
+
+	src, err := s.readFile(path)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, path, src, parserMode)
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	// Count lines in the file
+	lineCount, err := countLines(src)
+	if err != nil {
+		// If counting lines fails, don't fail the whole parse - just set to 0
+		lineCount = 0
+	}
+	size := int64(len(src))
+
+	// Build import list
+	var imports, blankImports []string
+	var importLines, importColumns []int
+	for _, imp := range node.Imports {
+		// Remove quotes from import path
+		importPath := imp.Path.Value[1 : len(imp.Path.Value)-1]
+		imports = append(imports, importPath)
+		if imp.Name != nil && imp.Name.Name == "_" {
+			blankImports = append(blankImports, importPath)
+		}
+		pos := fset.Position(imp.Path.Pos())
+		importLines = append(importLines, pos.Line)
+		importColumns = append(importColumns, pos.Column)
+	}
+	packagePos := fset.Position(node.Name.Pos())
+
+	// Determine if this is a test file and extract base name
+	fileName := filepath.Base(path)
+	isTest := strings.HasSuffix(fileName, "_test.go")
+	baseName := extractBaseName(fileName)
+
+	fileInfo := FileInfo{
+		Path:          path,
+		RelPath:       filepath.ToSlash(relPath),
+		Package:       node.Name.Name,
+		Imports:       imports,
+		ImportLines:   importLines,
+		ImportColumns: importColumns,
+		PackageLine:   packagePos.Line,
+		PackageColumn: packagePos.Column,
+		IsTest:        isTest,
+		BaseName:      baseName,
+		LineCount:     lineCount,
+		Size:          size,
+		BlankImports:  blankImports,
+		DIFramework:   detectDIFramework(fileName, imports),
+	}
+
+	// Optionally extract import usages
+	if opts.IncludeImportUsages {
+		fileInfo.ImportUsages = extractImportUsages(node, imports)
+	}
+
+	// Optionally extract exported API
+	if opts.IncludeExportedAPI {
+		fileInfo.ExportedDecls = extractExportedDecls(fset, node)
+	}
+
+	// Optionally extract struct and constant definitions
+	if opts.IncludeDefinitions {
+		fileInfo.StructDefs, fileInfo.ConstBlocks = extractDefinitions(fset, node)
+	}
+
+	// Optionally extract imported symbols exposed by the public API
+	if opts.IncludeAPIReferences {
+		fileInfo.APIReferences = extractAPIReferences(fset, node)
+	}
+
+	// Optionally extract imported symbols used in function signatures
+	if opts.IncludeSignatureRefs {
+		fileInfo.SignatureRefs = extractSignatureReferences(fset, node)
+	}
+
+	// Optionally extract the package stability annotation
+	if opts.IncludeStability {
+		fileInfo.Stability = extractStability(node)
+	}
+
+	// Optionally extract the package doc comment
+	if opts.IncludePackageDoc {
+		fileInfo.PackageDoc = extractPackageDoc(node)
+	}
+
+	// Optionally extract deprecated symbols
+	if opts.IncludeDeprecations {
+		fileInfo.Deprecated = extractDeprecatedSymbols(node)
+	}
+
+	// Optionally extract all references to imported symbols
+	if opts.IncludeSymbolRefs {
+		fileInfo.SymbolRefs = extractSymbolReferences(fset, node)
+	}
+
+	// Optionally extract literal file paths passed to file readers
+	if opts.IncludeFileReads {
+		fileInfo.FileReads = extractFileReads(fset, node)
+	}
+
+	// Optionally extract classified uses of imported symbols
+	if opts.IncludeSymbolUses {
+		fileInfo.SymbolUses = extractSymbolUses(fset, node)
+	}
+
+	// Optionally extract the names of package-level functions
+	if opts.IncludeFuncDecls {
+		fileInfo.FuncDecls = extractFuncDecls(node)
+	}
+
+	// Optionally extract registry keys, reflect lookups and exported methods
+	if opts.IncludeDynamicRefs {
+		fileInfo.DynamicRefs = extractDynamicRefs(fset, node, opts.RegisterFuncs, opts.LookupFuncs)
+	}
+
+	// Optionally extract what init functions register. An imports-only parse has no
+	// function bodies, so files declaring an init function are parsed again in full.
+	if opts.IncludeInitRegistrations {
+		initNode := node
+		if parserMode == parser.ImportsOnly {
+			initNode = nil
+			if bytes.Contains(src, []byte("func init(")) {
+				initNode, _ = parser.ParseFile(fset, path, src, 0)
+			}
+		}
+		if initNode != nil {
+			fileInfo.InitRegistrations = extractInitRegistrations(initNode)
+		}
+	}
+
+	// Optionally extract suppression directives. Only comments up to the imports are parsed
+	// unless the file is parsed in full, so files with a directive are parsed again.
+	if opts.IncludeSuppressions {
+		commentNode := node
+		if parserMode != parser.ParseComments {
+			commentNode = nil
+			if bytes.Contains(src, []byte(suppressionDirective)) {
+				commentNode, _ = parser.ParseFile(fset, path, src, parser.ParseComments)
+			}
+		}
+		if commentNode != nil {
+			fileInfo.Suppressions = extractSuppressions(fset, commentNode)
+		}
+	}
+
+	// Optionally extract the composition root of main packages and DI wiring
+	if opts.IncludeConstructions && (fileInfo.Package == "main" || fileInfo.DIFramework != "") {
+		fileInfo.Constructions = extractConstructions(fset, node, fileInfo.DIFramework)
+	}
+
+	return fileInfo, nil
+}
+
+// stabilityLevels are the recognized values of the archlint:stability annotation
+var stabilityLevels = map[string]bool{
+	"experimental": true,
+	"stable":       true,
+	"deprecated":   true,
+}
+
+// extractStability reads "// archlint:stability <level>" from the package doc comment
+func extractStability(file *ast.File) string {
+	if file.Doc == nil {
+		return ""
+	}
+
+	for _, comment := range file.Doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		fields := strings.Fields(text)
+		if len(fields) == 2 && fields[0] == "archlint:stability" && stabilityLevels[fields[1]] {
+			return fields[1]
+		}
+	}
+
+	return ""
+}
+
+// suppressionDirective starts a comment suppressing violations of a rule
+const suppressionDirective = "archlint:ignore"
+
+// extractSuppressions reads the "//archlint:ignore" directives of a file. Everything after
+// "reason=" is the reason, so it may contain spaces.
+func extractSuppressions(fset *token.FileSet, file *ast.File) []Suppression {
+	var suppressions []Suppression
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			if text != suppressionDirective && !strings.HasPrefix(text, suppressionDirective+" ") {
+				continue
+			}
+			args := strings.TrimPrefix(text, suppressionDirective)
+
+			suppression := Suppression{
+				Line:      fset.Position(comment.Pos()).Line,
+				WholeFile: comment.Pos() < file.Package,
+			}
+			if i := strings.Index(args, "reason="); i >= 0 {
+				suppression.Reason = strings.Trim(strings.TrimSpace(args[i+len("reason="):]), `"`)
+				args = args[:i]
+			}
+			for _, field := range strings.Fields(args) {
+				if until, ok := strings.CutPrefix(field, "until="); ok {
+					suppression.Until = until
+				} else if suppression.Rule == "" {
+					suppression.Rule = field
+				}
+			}
+			suppressions = append(suppressions, suppression)
+		}
+	}
+	return suppressions
+}
+
+// extractPackageDoc returns the package doc comment text without archlint annotations
+func extractPackageDoc(file *ast.File) string {
+	if file.Doc == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, line := range strings.Split(file.Doc.Text(), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "archlint:") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// extractDefinitions extracts struct type definitions and grouped constant blocks
+func extractDefinitions(fset *token.FileSet, file *ast.File) ([]StructDef, []ConstBlock) {
+	var structs []StructDef
+	var consts []ConstBlock
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+
+		switch genDecl.Tok {
+		case token.TYPE:
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				var fields []string
+				for _, field := range structType.Fields.List {
+					typeStr := types.ExprString(field.Type)
+					if len(field.Names) == 0 {
+						fields = append(fields, typeStr)
+						continue
+					}
+					for _, name := range field.Names {
+						fields = append(fields, name.Name+" "+typeStr)
+					}
+				}
+
+				structs = append(structs, StructDef{
+					Name:   typeSpec.Name.Name,
+					Line:   fset.Position(typeSpec.Pos()).Line,
+					Column: fset.Position(typeSpec.Pos()).Column,
+					Fields: fields,
+				})
+			}
+
+		case token.CONST:
+			// Only parenthesized groups form a block
+			if !genDecl.Lparen.IsValid() {
+				continue
+			}
+
+			var entries []string
+			for _, spec := range genDecl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, name := range valueSpec.Names {
+					entry := name.Name
+					if valueSpec.Type != nil {
+						entry += " " + types.ExprString(valueSpec.Type)
+					}
+					if i < len(valueSpec.Values) {
+						entry += " = " + types.ExprString(valueSpec.Values[i])
+					}
+					entries = append(entries, entry)
+				}
+			}
+
+			consts = append(consts, ConstBlock{
+				Line:    fset.Position(genDecl.Pos()).Line,
+				Column:  fset.Position(genDecl.Pos()).Column,
+				Entries: entries,
+			})
+		}
+	}
+
+	return structs, consts
+}
+
+// buildImportMap maps the package names used in a file to their import paths
+func buildImportMap(node *ast.File) map[string]string {
+	importMap := make(map[string]string) // package name -> import path
+
+	for _, imp := range node.Imports {
+		importPath := imp.Path.Value[1 : len(imp.Path.Value)-1] // Remove quotes
+
+		// Determine package name (either explicit alias or last segment of import path)
+		var pkgName string
+		if imp.Name != nil {
+			pkgName = imp.Name.Name
+		} else {
+			// Use last segment of import path as package name
+			parts := strings.Split(importPath, "/")
+			pkgName = parts[len(parts)-1]
+		}
+		importMap[pkgName] = importPath
+	}
+
+	return importMap
+}
+
+// extractAPIReferences finds imported symbols that appear in exported declarations:
+// function signatures, exported struct fields, interface methods, type definitions
+// and explicitly typed exported vars/consts
+func extractAPIReferences(fset *token.FileSet, file *ast.File) []APIReference {
+	importMap := buildImportMap(file)
+	var refs []APIReference
+
+	collect := func(declName string, expr ast.Node) {
+		refs = append(refs, collectImportRefs(fset, importMap, declName, expr)...)
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if !d.Name.IsExported() {
+				continue
+			}
+			declName := d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				if !isReceiverTypeExported(d.Recv.List[0].Type) {
+					continue
+				}
+				declName = receiverTypeName(d.Recv.List[0].Type) + "." + declName
+			}
+			collect(declName, d.Type)
+
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if !s.Name.IsExported() {
+						continue
+					}
+					if s.TypeParams != nil {
+						collect(s.Name.Name, s.TypeParams)
+					}
+					switch t := s.Type.(type) {
+					case *ast.StructType:
+						for _, field := range t.Fields.List {
+							if isExportedField(field) {
+								collect(s.Name.Name, field.Type)
+							}
+						}
+					case *ast.InterfaceType:
+						for _, method := range t.Methods.List {
+							if isExportedField(method) {
+								collect(s.Name.Name, method.Type)
+							}
+						}
+					default:
+						collect(s.Name.Name, s.Type)
+					}
+
+				case *ast.ValueSpec:
+					if s.Type == nil {
+						continue
+					}
+					for _, name := range s.Names {
+						if name.IsExported() {
+							collect(name.Name, s.Type)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// extractSignatureReferences finds imported symbols used in the signatures of all
+// function declarations, function types and interface methods, exported or not
+func extractSignatureReferences(fset *token.FileSet, file *ast.File) []APIReference {
+	importMap := buildImportMap(file)
+	var refs []APIReference
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			declName := d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				declName = receiverTypeName(d.Recv.List[0].Type) + "." + declName
+			}
+			refs = append(refs, collectImportRefs(fset, importMap, declName, d.Type)...)
+
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				switch t := typeSpec.Type.(type) {
+				case *ast.FuncType:
+					refs = append(refs, collectImportRefs(fset, importMap, typeSpec.Name.Name, t)...)
+				case *ast.InterfaceType:
+					for _, method := range t.Methods.List {
+						if _, ok := method.Type.(*ast.FuncType); ok {
+							refs = append(refs, collectImportRefs(fset, importMap, typeSpec.Name.Name, method.Type)...)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// extractSymbolReferences finds all references to imported symbols, keyed by their enclosing declaration
+func extractSymbolReferences(fset *token.FileSet, file *ast.File) []APIReference {
+	importMap := buildImportMap(file)
+	var refs []APIReference
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			declName := d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				declName = receiverTypeName(d.Recv.List[0].Type) + "." + declName
+			}
+			refs = append(refs, collectImportRefs(fset, importMap, declName, d)...)
+
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				continue
+			}
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					refs = append(refs, collectImportRefs(fset, importMap, s.Name.Name, s)...)
+				case *ast.ValueSpec:
+					refs = append(refs, collectImportRefs(fset, importMap, s.Names[0].Name, s)...)
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// extractSymbolUses finds every use of an imported symbol, and of an identifier the
+// parser could not resolve within the file (a package-level symbol declared in another
+// file of the package), in source order. Each use records whether it is the type of a
+// composite literal, a call, or another reference.
+func extractSymbolUses(fset *token.FileSet, file *ast.File) []SymbolUse {
+	importMap := buildImportMap(file)
+
+	// Classify expressions by their position in composite literals and calls, and
+	// collect struct literal keys, which are field names rather than symbols
+	kinds := make(map[ast.Expr]string)
+	fieldKeys := make(map[*ast.Ident]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch e := n.(type) {
+		case *ast.CompositeLit:
+			if typ := typeName(e.Type); typ != nil {
+				kinds[typ] = SymbolUseLiteral
+			}
+			for _, elt := range e.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						fieldKeys[key] = true
+					}
+				}
+			}
+		case *ast.CallExpr:
+			if fun := typeName(e.Fun); fun != nil {
+				kinds[fun] = SymbolUseCall
+			}
+		}
+		return true
+	})
+	kindOf := func(expr ast.Expr) string {
+		if kind, classified := kinds[expr]; classified {
+			return kind
+		}
+		return SymbolUseRef
+	}
+
+	unresolved := make(map[*ast.Ident]bool)
+	for _, ident := range file.Unresolved {
+		unresolved[ident] = true
+	}
+
+	var uses []SymbolUse
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch e := n.(type) {
+		case *ast.SelectorExpr:
+			ident, ok := e.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			importPath, exists := importMap[ident.Name]
+			if !exists {
+				return true
+			}
+			pos := fset.Position(e.Pos())
+			uses = append(uses, SymbolUse{
+				ImportPath: importPath,
+				Symbol:     e.Sel.Name,
+				Kind:       kindOf(e),
+				Line:       pos.Line,
+				Column:     pos.Column,
+			})
+			return false
+		case *ast.Ident:
+			if !unresolved[e] || fieldKeys[e] || types.Universe.Lookup(e.Name) != nil {
+				return true
+			}
+			pos := fset.Position(e.Pos())
+			uses = append(uses, SymbolUse{
+				Symbol: e.Name,
+				Kind:   kindOf(e),
+				Line:   pos.Line,
+				Column: pos.Column,
+			})
+		}
+		return true
+	})
+
+	return uses
+}
+
+// typeName returns the identifier or package-qualified selector naming a type or
+// function, unwrapping generic instantiations such as pkg.T[int]
+func typeName(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+		return e
+	case *ast.IndexExpr:
+		return typeName(e.X)
+	case *ast.IndexListExpr:
+		return typeName(e.X)
+	}
+	return nil
+}
+
+// extractFuncDecls returns the names of the package-level functions declared in a file
+func extractFuncDecls(file *ast.File) []string {
+	var funcs []string
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+			funcs = append(funcs, fn.Name.Name)
+		}
+	}
+	return funcs
+}
+
+// extractInitRegistrations returns what the init functions of a file register, in order
+// of appearance: calls to functions or methods named Register* or Handle* (sql.Register,
+// image.RegisterFormat, http.HandleFunc) and assignments to map entries (drivers["pg"] = ...)
+func extractInitRegistrations(file *ast.File) []string {
+	var registrations []string
+	seen := make(map[string]bool)
+	add := func(registration string) {
+		if !seen[registration] {
+			seen[registration] = true
+			registrations = append(registrations, registration)
+		}
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Name.Name != "init" || fn.Body == nil {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CallExpr:
+				name, written, ok := calledName(node)
+				if ok && (strings.HasPrefix(name, "Register") || strings.HasPrefix(name, "MustRegister") || strings.HasPrefix(name, "Handle")) {
+					add(written)
+				}
+			case *ast.AssignStmt:
+				for _, lhs := range node.Lhs {
+					if index, ok := lhs.(*ast.IndexExpr); ok {
+						add(exprToString(index.X) + "[...]")
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	return registrations
+}
+
+// calledName returns the name of the function or method a call invokes, and the call as
+// written when the receiver or package is a plain identifier (e.g. "registry.Get")
+func calledName(call *ast.CallExpr) (string, string, bool) {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return fun.Name, fun.Name, true
+	case *ast.SelectorExpr:
+		if ident, ok := fun.X.(*ast.Ident); ok {
+			return fun.Sel.Name, ident.Name + "." + fun.Sel.Name, true
+		}
+		return fun.Sel.Name, fun.Sel.Name, true
+	}
+	return "", "", false
+}
+
+// extractConstructions finds the components the functions of a main package or of DI
+// wiring construct: calls to imported functions named New* or Open* and composite
+// literals of imported types. A construction passed to another one, directly or through a
+// local variable, is recorded as injected into it. Variables are tracked per function by
+// name only. Providers registered with the DI framework are recorded as provided.
+func extractConstructions(fset *token.FileSet, file *ast.File, framework string) []Construction {
+	importMap := buildImportMap(file)
+	var constructions []Construction
+
+	// Code generated by Wire assigns the result of each provider, whatever its name
+	generatedWiring := framework == DIFrameworkWire && ast.IsGenerated(file)
+
+	// Provider sets are often package-level variables (var Set = wire.NewSet(...))
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.VAR {
+			constructions = append(constructions, extractProviders(fset, importMap, framework, gen)...)
+		}
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		constructions = append(constructions, extractProviders(fset, importMap, framework, fn.Body)...)
+		bound := make(map[string]string)   // variable -> construction it holds
+		built := make(map[ast.Expr]string) // constructions already recorded
+
+		// construct records expr if it is a construction and returns it as "importpath.Name".
+		// With anyCall, calls of any function count, including those of the file's package.
+		var construct func(expr ast.Expr, anyCall bool) (string, bool)
+		// source returns the construction an argument or field value holds
+		source := func(expr ast.Expr) (string, bool) {
+			if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+				expr = unary.X
+			}
+			if ident, ok := expr.(*ast.Ident); ok {
+				key, ok := bound[ident.Name]
+				return key, ok
+			}
+			return construct(expr, false)
+		}
+		construct = func(expr ast.Expr, anyCall bool) (string, bool) {
+			if key, ok := built[expr]; ok {
+				return key, true
+			}
+
+			var importPath, name string
+			var inputs []ast.Expr
+			switch e := expr.(type) {
+			case *ast.ParenExpr:
+				return construct(e.X, anyCall)
+			case *ast.UnaryExpr:
+				if e.Op != token.AND {
+					return "", false
+				}
+				return construct(e.X, anyCall)
+			case *ast.CallExpr:
+				path, symbol, ok := qualifiedCall(importMap, e)
+				if ident, isIdent := e.Fun.(*ast.Ident); !ok && anyCall && isIdent {
+					path, symbol, ok = "", ident.Name, true
+				}
+				if !ok || diFrameworkImports[path] != "" ||
+					!(anyCall || strings.HasPrefix(symbol, "New") || strings.HasPrefix(symbol, "Open")) {
+					return "", false
+				}
+				importPath, name, inputs = path, symbol, e.Args
+			case *ast.CompositeLit:
+				sel, ok := e.Type.(*ast.SelectorExpr)
+				if !ok {
+					return "", false
+				}
+				ident, ok := sel.X.(*ast.Ident)
+				if !ok || importMap[ident.Name] == "" {
+					return "", false
+				}
+				importPath, name = importMap[ident.Name], sel.Sel.Name
+				for _, elt := range e.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						elt = kv.Value
+					}
+					inputs = append(inputs, elt)
+				}
+			default:
+				return "", false
+			}
+
+			// Nested constructions are recorded first, in evaluation order
+			var injected []string
+			for _, input := range inputs {
+				if key, ok := source(input); ok && !containsSymbol(injected, key) {
+					injected = append(injected, key)
+				}
+			}
+			key := importPath + "." + name
+			built[expr] = key
+			pos := fset.Position(expr.Pos())
+			constructions = append(constructions, Construction{ImportPath: importPath, Name: name, Injected: injected, Line: pos.Line, Column: pos.Column})
+			return key, true
+		}
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				// x, err := pkg.NewX() binds x
+				for i, rhs := range node.Rhs {
+					key, ok := construct(rhs, generatedWiring)
+					if !ok || i >= len(node.Lhs) {
+						continue
+					}
+					if ident, isIdent := node.Lhs[i].(*ast.Ident); isIdent && ident.Name != "_" {
+						bound[ident.Name] = key
+					}
+				}
+			case *ast.ValueSpec:
+				for i, value := range node.Values {
+					if key, ok := construct(value, false); ok && i < len(node.Names) {
+						bound[node.Names[i].Name] = key
+					}
+				}
+			case ast.Expr:
+				// Constructions not assigned to a variable, e.g. server.Run(app.NewService(repo))
+				construct(node, false)
+			}
+			return true
+		})
+	}
+
+	return constructions
+}
+
+// extractProviders finds the providers registered with a DI framework below node: the
+// arguments of wire.Build and wire.NewSet, of fx.Provide (also wrapped in fx.Annotate),
+// and of the Provide method of dig containers. Only references to functions are providers;
+// values and nested calls such as wire.Bind are not.
+func extractProviders(fset *token.FileSet, importMap map[string]string, framework string, node ast.Node) []Construction {
+	var providers []Construction
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !isProviderCall(importMap, framework, call) {
+			return true
+		}
+		for _, arg := range call.Args {
+			if wrapped, ok := arg.(*ast.CallExpr); ok {
+				if importPath, symbol, ok := qualifiedCall(importMap, wrapped); ok && importPath == "go.uber.org/fx" && symbol == "Annotate" && len(wrapped.Args) > 0 {
+					arg = wrapped.Args[0]
+				}
+			}
+
+			var provider Construction
+			switch e := arg.(type) {
+			case *ast.Ident:
+				provider = Construction{Name: e.Name}
+			case *ast.SelectorExpr:
+				ident, ok := e.X.(*ast.Ident)
+				if !ok || importMap[ident.Name] == "" {
+					continue
+				}
+				provider = Construction{ImportPath: importMap[ident.Name], Name: e.Sel.Name}
+			default:
+				continue
+			}
+			pos := fset.Position(arg.Pos())
+			provider.Provided, provider.Line, provider.Column = true, pos.Line, pos.Column
+			providers = append(providers, provider)
+		}
+		return true
+	})
 
-type Scanner struct {
-	projectPath   string
-	module        string
-	ignorePaths   []string
-	lintTestFiles bool
+	return providers
 }
 
-func New(projectPath, module string, ignorePaths []string, lintTestFiles bool) *Scanner {
-	return &Scanner{
-		projectPath:   projectPath,
-		module:        module,
-		ignorePaths:   ignorePaths,
-		lintTestFiles: lintTestFiles,
+// isProviderCall reports whether a call registers providers with the file's DI framework
+func isProviderCall(importMap map[string]string, framework string, call *ast.CallExpr) bool {
+	importPath, symbol, qualified := qualifiedCall(importMap, call)
+	switch framework {
+	case DIFrameworkWire:
+		return qualified && importPath == "github.com/google/wire" && (symbol == "Build" || symbol == "NewSet")
+	case DIFrameworkFx:
+		return qualified && importPath == "go.uber.org/fx" && symbol == "Provide"
+	case DIFrameworkDig:
+		// container.Provide(db.NewRepo); the receiver is a variable, not a package
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		return ok && !qualified && sel.Sel.Name == "Provide"
 	}
+	return false
 }
 
-// Scan walks the specified paths and parses all Go files with optional detailed information
-func (s *Scanner) Scan(scanPaths []string, opts ScanOptions) ([]FileInfo, error) {
-	var files []FileInfo
+// reflectLookups are the reflect.Value and reflect.Type methods that find a member by name
+var reflectLookups = []string{"MethodByName", "FieldByName"}
 
-	for _, scanPath := range scanPaths {
-		fullPath := filepath.Join(s.projectPath, scanPath)
+// extractDynamicRefs finds calls to the register and lookup functions whose first argument
+// is a string literal, reflect lookups of literal member names, and exported methods.
+// Functions are matched by name only, whatever package or receiver they belong to.
+func extractDynamicRefs(fset *token.FileSet, file *ast.File, registerFuncs, lookupFuncs []string) []DynamicRef {
+	var refs []DynamicRef
 
-		// Check if path exists
-		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-			continue // Skip non-existent paths
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || !fn.Name.IsExported() {
+			continue
 		}
+		receiver := receiverTypeName(fn.Recv.List[0].Type)
+		pos := fset.Position(fn.Name.Pos())
+		refs = append(refs, DynamicRef{
+			Kind:     DynamicRefMethod,
+			Function: receiver + "." + fn.Name.Name,
+			Key:      fn.Name.Name,
+			Line:     pos.Line,
+			Column:   pos.Column,
+		})
+	}
 
-		err := filepath.Walk(fullPath, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-
-			// Skip directories
-			if info.IsDir() {
-				// Check if directory should be ignored
-				if s.shouldIgnore(path) {
-					return filepath.SkipDir
-				}
-				return nil
-			}
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
 
-			// Only process .go files
-			if !strings.HasSuffix(path, ".go") {
-				return nil
-			}
-			// Skip test files unless lintTestFiles is enabled
-			if !s.lintTestFiles && strings.HasSuffix(path, "_test.go") {
-				return nil
-			}
+		name, written, ok := calledName(call)
+		if !ok {
+			return true
+		}
 
-			fileInfo, err := s.parseFileWithOptions(path, opts)
-			if err != nil {
-				return fmt.Errorf("parsing %s: %w", path, err)
-			}
+		var kind string
+		switch {
+		case containsSymbol(reflectLookups, name):
+			kind = DynamicRefReflect
+		case containsSymbol(registerFuncs, name):
+			kind = DynamicRefRegister
+		case containsSymbol(lookupFuncs, name):
+			kind = DynamicRefLookup
+		default:
+			return true
+		}
 
-			files = append(files, fileInfo)
-			return nil
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		key, err := strconv.Unquote(lit.Value)
+		if err != nil || key == "" {
+			return true
+		}
+		pos := fset.Position(lit.Pos())
+		refs = append(refs, DynamicRef{
+			Kind:     kind,
+			Function: written,
+			Key:      key,
+			Line:     pos.Line,
+			Column:   pos.Column,
 		})
+		return true
+	})
 
-		if err != nil {
-			return nil, err
-		}
-	}
+	return refs
+}
 
-	return files, nil
+// fileReaders are the standard library functions whose first argument is a file path
+var fileReaders = map[string][]string{
+	"os":        {"ReadFile", "Open", "OpenFile", "ReadDir"},
+	"io/ioutil": {"ReadFile", "ReadDir"},
 }
 
+// extractFileReads finds calls to file readers whose path is a string literal, or a
+// filepath.Join/path.Join of string literals. Paths built at runtime are not detected.
+func extractFileReads(fset *token.FileSet, file *ast.File) []FileRead {
+	importMap := buildImportMap(file)
+	var reads []FileRead
 
-// parseFileWithOptions parses a file with optional detailed information based on ScanOptions
-func (s *Scanner) parseFileWithOptions(path string, opts ScanOptions) (FileInfo, error) {
-	relPath, err := filepath.Rel(s.projectPath, path)
-	if err != nil {
-		return FileInfo{}, err
-	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		importPath, symbol, ok := qualifiedCall(importMap, call)
+		if !ok {
+			return true
+		}
+		readers, isReaderPkg := fileReaders[importPath]
+		if !isReaderPkg || !containsSymbol(readers, symbol) {
+			return true
+		}
+		path, ok := literalPath(importMap, call.Args[0])
+		if !ok {
+			return true
+		}
+		pos := fset.Position(call.Args[0].Pos())
+		reads = append(reads, FileRead{
+			Function: importPath + "." + symbol,
+			Path:     path,
+			Line:     pos.Line,
+			Column:   pos.Column,
+		})
+		return true
+	})
 
-	// Determine parser mode based on options
-	parserMode := parser.ImportsOnly
-	if opts.IncludeImportUsages || opts.IncludeExportedAPI {
-		parserMode = parser.ParseComments
+	return reads
+}
+
+// qualifiedCall returns the import path and symbol of a call to an imported function
+func qualifiedCall(importMap map[string]string, call *ast.CallExpr) (string, string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
 	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+	importPath, ok := importMap[ident.Name]
+	if !ok {
+		return "", "", false
+	}
+	return importPath, sel.Sel.Name, true
+}
 
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, path, nil, parserMode)
-	if err != nil {
-		return FileInfo{}, err
+// literalPath evaluates a string literal or a filepath.Join/path.Join of string literals
+func literalPath(importMap map[string]string, expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		if err != nil || value == "" {
+			return "", false
+		}
+		return filepath.ToSlash(value), true
+	case *ast.CallExpr:
+		importPath, symbol, ok := qualifiedCall(importMap, e)
+		if !ok || symbol != "Join" || (importPath != "path/filepath" && importPath != "path") {
+			return "", false
+		}
+		parts := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			part, ok := literalPath(importMap, arg)
+			if !ok {
+				return "", false
+			}
+			parts[i] = part
+		}
+		return strings.Join(parts, "/"), true
 	}
+	return "", false
+}
 
-	// Count lines in the file
-	lineCount, err := countLines(path)
-	if err != nil {
-		// If counting lines fails, don't fail the whole parse - just set to 0
-		lineCount = 0
+// containsSymbol checks if a list of symbols contains the given one
+func containsSymbol(symbols []string, symbol string) bool {
+	for _, s := range symbols {
+		if s == symbol {
+			return true
+		}
 	}
+	return false
+}
 
-	// Build import list
-	var imports []string
-	for _, imp := range node.Imports {
-		// Remove quotes from import path
-		importPath := imp.Path.Value[1 : len(imp.Path.Value)-1]
-		imports = append(imports, importPath)
+// extractDeprecatedSymbols finds exported package-level functions, types, constants and
+// variables whose doc comment contains a "Deprecated:" paragraph
+func extractDeprecatedSymbols(file *ast.File) []string {
+	var symbols []string
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil && d.Name.IsExported() && isDeprecatedDoc(d.Doc) {
+				symbols = append(symbols, d.Name.Name)
+			}
+
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if s.Name.IsExported() && (isDeprecatedDoc(s.Doc) || isDeprecatedDoc(d.Doc)) {
+						symbols = append(symbols, s.Name.Name)
+					}
+				case *ast.ValueSpec:
+					// A deprecated group doc applies to every name in the group
+					if !isDeprecatedDoc(s.Doc) && !isDeprecatedDoc(d.Doc) {
+						continue
+					}
+					for _, name := range s.Names {
+						if name.IsExported() {
+							symbols = append(symbols, name.Name)
+						}
+					}
+				}
+			}
+		}
 	}
 
-	// Determine if this is a test file and extract base name
-	fileName := filepath.Base(path)
-	isTest := strings.HasSuffix(fileName, "_test.go")
-	baseName := extractBaseName(fileName)
+	return symbols
+}
 
-	fileInfo := FileInfo{
-		Path:      path,
-		RelPath:   relPath,
-		Package:   node.Name.Name,
-		Imports:   imports,
-		IsTest:    isTest,
-		BaseName:  baseName,
-		LineCount: lineCount,
+// isDeprecatedDoc checks if a doc comment has a paragraph starting with "Deprecated: "
+func isDeprecatedDoc(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
 	}
 
-	// Optionally extract import usages
-	if opts.IncludeImportUsages {
-		fileInfo.ImportUsages = extractImportUsages(node, imports)
+	lines := strings.Split(doc.Text(), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "Deprecated: ") && (i == 0 || strings.TrimSpace(lines[i-1]) == "") {
+			return true
+		}
 	}
+	return false
+}
 
-	// Optionally extract exported API
-	if opts.IncludeExportedAPI {
-		fileInfo.ExportedDecls = extractExportedDecls(node)
+// collectImportRefs finds qualified identifiers (pkg.Symbol) referring to imports within node
+func collectImportRefs(fset *token.FileSet, importMap map[string]string, declName string, node ast.Node) []APIReference {
+	if node == nil {
+		return nil
 	}
 
-	return fileInfo, nil
+	var refs []APIReference
+	ast.Inspect(node, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			// Not a package qualifier (e.g. a method on a call result); keep looking inside
+			return true
+		}
+		if importPath, exists := importMap[ident.Name]; exists {
+			refs = append(refs, APIReference{
+				Decl:       declName,
+				ImportPath: importPath,
+				Symbol:     sel.Sel.Name,
+				Line:       fset.Position(sel.Pos()).Line,
+				Column:     fset.Position(sel.Pos()).Column,
+			})
+		}
+		return false
+	})
+	return refs
+}
+
+// isExportedField reports whether a struct field or interface method is visible outside
+// the package. Embedded fields are visible when the embedded type name is exported.
+func isExportedField(field *ast.Field) bool {
+	if len(field.Names) == 0 {
+		typeExpr := field.Type
+		if star, ok := typeExpr.(*ast.StarExpr); ok {
+			typeExpr = star.X
+		}
+		switch t := typeExpr.(type) {
+		case *ast.Ident:
+			return t.IsExported()
+		case *ast.SelectorExpr:
+			return t.Sel.IsExported()
+		}
+		// Embedded constraint unions and other forms are always part of the method set
+		return true
+	}
+	for _, name := range field.Names {
+		if name.IsExported() {
+			return true
+		}
+	}
+	return false
 }
 
 // extractImportUsages extracts which symbols are used from each import
 func extractImportUsages(node *ast.File, imports []string) []ImportUsage {
 	// Build map of package names to import paths
-	importMap := make(map[string]string) // package name -> import path
-
-	for _, imp := range node.Imports {
-		importPath := imp.Path.Value[1 : len(imp.Path.Value)-1] // Remove quotes
-
-		// Determine package name (either explicit alias or last segment of import path)
-		var pkgName string
-		if imp.Name != nil {
-			pkgName = imp.Name.Name
-		} else {
-			// Use last segment of import path as package name
-			parts := strings.Split(importPath, "/")
-			pkgName = parts[len(parts)-1]
-		}
-		importMap[pkgName] = importPath
-	}
+	importMap := buildImportMap(node)
 
 	// Extract used symbols from each import
 	usageMap := make(map[string]map[string]bool) // import path -> set of used symbols
+	callSites := make(map[string]int)            // import path -> references
 	for _, importPath := range imports {
 		usageMap[importPath] = make(map[string]bool)
 	}
@@ -278,6 +2088,7 @@ func extractImportUsages(node *ast.File, imports []string) []ImportUsage {
 				if importPath, exists := importMap[ident.Name]; exists {
 					// Record the used symbol
 					usageMap[importPath][sel.Sel.Name] = true
+					callSites[importPath]++
 				}
 			}
 		}
@@ -297,6 +2108,7 @@ func extractImportUsages(node *ast.File, imports []string) []ImportUsage {
 			importUsages = append(importUsages, ImportUsage{
 				ImportPath:  importPath,
 				UsedSymbols: usedSymbols,
+				CallSites:   callSites[importPath],
 			})
 		}
 	}
@@ -343,7 +2155,7 @@ func extractBaseName(fileName string) string {
 }
 
 
-func extractExportedDecls(file *ast.File) []ExportedDecl {
+func extractExportedDecls(fset *token.FileSet, file *ast.File) []ExportedDecl {
 	var decls []ExportedDecl
 
 	for _, decl := range file.Decls {
@@ -365,6 +2177,8 @@ func extractExportedDecls(file *ast.File) []ExportedDecl {
 					Name:      d.Name.Name,
 					Kind:      "func",
 					Signature: sig,
+					Doc:       docText(d.Doc),
+					Line:      fset.Position(d.Name.Pos()).Line,
 				})
 			}
 
@@ -374,11 +2188,16 @@ func extractExportedDecls(file *ast.File) []ExportedDecl {
 				case *ast.TypeSpec:
 					if s.Name.IsExported() {
 						properties := extractStructFields(s.Type)
+						properties = append(properties, extractTypeElements(s.Type)...)
 						decls = append(decls, ExportedDecl{
 							Name:       s.Name.Name,
 							Kind:       "type",
-							Signature:  s.Name.Name,
+							Signature:  s.Name.Name + typeParamsString(s.TypeParams),
 							Properties: properties,
+							TypeKind:   typeKind(s.Type),
+							Embeds:     extractEmbeds(s.Type),
+							Doc:        specDoc(s.Doc, d),
+							Line:       fset.Position(s.Name.Pos()).Line,
 						})
 					}
 
@@ -393,6 +2212,8 @@ func extractExportedDecls(file *ast.File) []ExportedDecl {
 								Name:      name.Name,
 								Kind:      kind,
 								Signature: name.Name,
+								Doc:       specDoc(s.Doc, d),
+								Line:      fset.Position(name.Pos()).Line,
 							})
 						}
 					}
@@ -404,30 +2225,121 @@ func extractExportedDecls(file *ast.File) []ExportedDecl {
 	return decls
 }
 
-// isReceiverTypeExported checks if the receiver type is exported
-// For a method to be part of the public API, both the method name and receiver type must be exported
-func isReceiverTypeExported(typeExpr ast.Expr) bool {
-	// Extract the base type name from the receiver type
-	var typeName string
+// extractTypeElements returns the type set terms of a constraint interface,
+// e.g. "~int | ~string" for interface{ ~int | ~string }
+func extractTypeElements(typeExpr ast.Expr) []string {
+	var elements []string
+	iface, ok := typeExpr.(*ast.InterfaceType)
+	if !ok || iface.Methods == nil {
+		return elements
+	}
+	for _, field := range iface.Methods.List {
+		if len(field.Names) > 0 || isEmbeddedInterface(field.Type) {
+			continue
+		}
+		elements = append(elements, exprToString(field.Type))
+	}
+	return elements
+}
 
+// extractEmbeds returns the embedded types of a struct or interface definition
+func extractEmbeds(typeExpr ast.Expr) []string {
+	var embeds []string
 	switch t := typeExpr.(type) {
-	case *ast.Ident:
-		// Simple type: MyType or myType
-		typeName = t.Name
-	case *ast.StarExpr:
-		// Pointer type: *MyType or *myType
-		if ident, ok := t.X.(*ast.Ident); ok {
-			typeName = ident.Name
+	case *ast.StructType:
+		if t.Fields == nil {
+			return embeds
+		}
+		for _, field := range t.Fields.List {
+			if len(field.Names) == 0 {
+				embeds = append(embeds, exprToString(field.Type))
+			}
 		}
+	case *ast.InterfaceType:
+		if t.Methods == nil {
+			return embeds
+		}
+		for _, field := range t.Methods.List {
+			if len(field.Names) == 0 && isEmbeddedInterface(field.Type) {
+				embeds = append(embeds, exprToString(field.Type))
+			}
+		}
+	}
+	return embeds
+}
+
+// isEmbeddedInterface reports whether an unnamed interface element names another
+// interface (Reader, io.Writer, Set[T]) rather than a type set term (~int, int | string)
+func isEmbeddedInterface(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		// Predeclared types like int are type set terms; comparable and any are interfaces
+		obj := types.Universe.Lookup(e.Name)
+		return obj == nil || e.Name == "comparable" || e.Name == "any"
+	case *ast.SelectorExpr:
+		return true
+	case *ast.IndexExpr:
+		return isEmbeddedInterface(e.X)
+	case *ast.IndexListExpr:
+		return isEmbeddedInterface(e.X)
+	}
+	return false
+}
+
+// typeKind classifies a type definition as "struct", "interface" or "other"
+func typeKind(expr ast.Expr) string {
+	switch expr.(type) {
+	case *ast.StructType:
+		return "struct"
+	case *ast.InterfaceType:
+		return "interface"
 	default:
-		// Other types (rare for receivers) - be conservative and exclude
-		return false
+		return "other"
+	}
+}
+
+// specDoc returns the doc comment of a spec, falling back to the doc comment of
+// its declaration when the declaration is not grouped (like go/doc does)
+func specDoc(doc *ast.CommentGroup, decl *ast.GenDecl) string {
+	if doc == nil && !decl.Lparen.IsValid() {
+		doc = decl.Doc
 	}
+	return docText(doc)
+}
+
+// docText returns the text of a doc comment without trailing whitespace
+func docText(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Text())
+}
+
+// isReceiverTypeExported checks if the receiver type is exported
+// For a method to be part of the public API, both the method name and receiver type must be exported
+func isReceiverTypeExported(typeExpr ast.Expr) bool {
+	typeName := receiverTypeName(typeExpr)
 
 	// Check if the type name starts with uppercase (exported)
 	return len(typeName) > 0 && typeName[0] >= 'A' && typeName[0] <= 'Z'
 }
 
+// receiverTypeName returns the name of a receiver's type without pointer and type
+// parameters: "List" for (l *List[T])
+func receiverTypeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(e.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(e.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
 func buildFuncSignature(fn *ast.FuncDecl) string {
 	var sb strings.Builder
 
@@ -444,6 +2356,7 @@ func buildFuncSignature(fn *ast.FuncDecl) string {
 	}
 
 	sb.WriteString(fn.Name.Name)
+	sb.WriteString(typeParamsString(fn.Type.TypeParams))
 
 	// Add parameters
 	sb.WriteString("(")
@@ -482,6 +2395,23 @@ func buildFuncSignature(fn *ast.FuncDecl) string {
 	return sb.String()
 }
 
+// typeParamsString renders a type parameter list with its constraints, e.g.
+// "[K comparable, V any]"; empty for non-generic declarations
+func typeParamsString(params *ast.FieldList) string {
+	if params == nil || len(params.List) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(params.List))
+	for _, field := range params.List {
+		names := make([]string, len(field.Names))
+		for i, name := range field.Names {
+			names[i] = name.Name
+		}
+		parts = append(parts, strings.Join(names, ", ")+" "+exprToString(field.Type))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
 func exprToString(expr ast.Expr) string {
 	switch e := expr.(type) {
 	case *ast.Ident:
@@ -495,7 +2425,33 @@ func exprToString(expr ast.Expr) string {
 	case *ast.MapType:
 		return "map[" + exprToString(e.Key) + "]" + exprToString(e.Value)
 	case *ast.InterfaceType:
-		return "interface{}"
+		// Constraints list their type elements, e.g. interface{ ~int | ~string }
+		var elements []string
+		for _, field := range e.Methods.List {
+			if len(field.Names) == 0 {
+				elements = append(elements, exprToString(field.Type))
+			}
+		}
+		if len(elements) == 0 {
+			return "interface{}"
+		}
+		return "interface{ " + strings.Join(elements, "; ") + " }"
+	case *ast.IndexExpr:
+		return exprToString(e.X) + "[" + exprToString(e.Index) + "]"
+	case *ast.IndexListExpr:
+		indices := make([]string, len(e.Indices))
+		for i, index := range e.Indices {
+			indices[i] = exprToString(index)
+		}
+		return exprToString(e.X) + "[" + strings.Join(indices, ", ") + "]"
+	case *ast.BinaryExpr:
+		// Union of constraint terms
+		return exprToString(e.X) + " " + e.Op.String() + " " + exprToString(e.Y)
+	case *ast.UnaryExpr:
+		// Underlying type term, e.g. ~int
+		return e.Op.String() + exprToString(e.X)
+	case *ast.ParenExpr:
+		return "(" + exprToString(e.X) + ")"
 	case *ast.Ellipsis:
 		return "..." + exprToString(e.Elt)
 	case *ast.FuncType:
@@ -542,16 +2498,10 @@ func extractStructFields(typeExpr ast.Expr) []string {
 	return fields
 }
 
-// countLines counts the number of lines in a file
-func countLines(path string) (int, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
-
+// countLines counts the number of lines in the source of a file
+func countLines(src []byte) (int, error) {
 	lineCount := 0
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(bytes.NewReader(src))
 	for scanner.Scan() {
 		lineCount++
 	}
@@ -1,9 +1,14 @@
 package scanner_test
 
 import (
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/kgatilin/go-arch-lint/internal/scanner"
 )
@@ -63,6 +68,19 @@ func Hello() {
 			t.Errorf("unexpected import: %s", imp)
 		}
 	}
+
+	if fmt.Sprint(file.ImportLines) != "[4 5 6]" {
+		t.Errorf("expected import lines [4 5 6], got %v", file.ImportLines)
+	}
+	if fmt.Sprint(file.ImportColumns) != "[2 2 2]" {
+		t.Errorf("expected import columns [2 2 2], got %v", file.ImportColumns)
+	}
+	if file.PackageLine != 1 || file.PackageColumn != 9 {
+		t.Errorf("expected package clause at 1:9, got %d:%d", file.PackageLine, file.PackageColumn)
+	}
+	if file.LineCount != 11 || file.Size != int64(len(serviceGo)) {
+		t.Errorf("expected 11 lines and %d bytes, got %d and %d", len(serviceGo), file.LineCount, file.Size)
+	}
 }
 
 func TestScan_IgnoresPaths(t *testing.T) {
@@ -163,6 +181,34 @@ func TestScan_NonExistentPath(t *testing.T) {
 	}
 }
 
+func TestScan_FS(t *testing.T) {
+	// The project files of a past revision: the working tree on disk is empty
+	tmpDir := t.TempDir()
+	fsys := fstest.MapFS{
+		"pkg/service/service.go": {Data: []byte("package service\n\nimport \"github.com/test/project/pkg/store\"\n\nfunc Run() { store.Save() }\n")},
+		"pkg/store/store.go":     {Data: []byte("package store\n\nfunc Save() {}\n")},
+		"vendor/lib/lib.go":      {Data: []byte("package lib\n")},
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", []string{"vendor"}, false)
+	s.SetFS(fsys)
+	files, err := s.Scan([]string{"pkg", "vendor", "nonexistent"}, scanner.ScanOptions{})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+	service := files[0]
+	if service.Path != filepath.Join(tmpDir, "pkg", "service", "service.go") || service.RelPath != "pkg/service/service.go" {
+		t.Errorf("expected paths below the project path, got %s (%s)", service.Path, service.RelPath)
+	}
+	if service.LineCount != 5 || service.Size != 89 || len(service.Imports) != 1 || service.Imports[0] != "github.com/test/project/pkg/store" {
+		t.Errorf("unexpected file info: %+v", service)
+	}
+}
+
 func TestScanWithAPI_ExtractsExportedDeclarations(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -334,6 +380,15 @@ const Version = "1.0"
 			t.Error("GetSignature() should not be empty")
 		}
 	}
+
+	// Declarations record the line of their name
+	lines := make(map[string]int)
+	for _, decl := range file.ExportedDecls {
+		lines[decl.Name] = decl.GetLine()
+	}
+	if lines["User"] != 3 || lines["Version"] != 7 {
+		t.Errorf("expected User on line 3 and Version on line 7, got %v", lines)
+	}
 }
 
 func TestScanWithAPI_ComplexSignatures(t *testing.T) {
@@ -397,6 +452,233 @@ func (*Handler) Handle() {}
 	}
 }
 
+func TestScanWithAPI_GenericSignatures(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "pkg")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	genericGo := `package pkg
+
+// Number is a constraint for numeric types
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Cache stores values by key
+type Cache[K comparable, V any] struct {
+	Items map[K]V
+}
+
+// Get returns the value for key
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	v, ok := c.Items[key]
+	return v, ok
+}
+
+// Sum adds up numbers
+func Sum[T Number](values []T) T {
+	var total T
+	return total
+}
+
+// Keys returns the keys of a cache
+func Keys[K comparable, V any](c *Cache[K, V]) []K {
+	return nil
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "generic.go"), []byte(genericGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludeExportedAPI: true})
+	if err != nil {
+		t.Fatalf("ScanWithAPI failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	signatures := make(map[string]string)
+	for _, decl := range files[0].ExportedDecls {
+		signatures[decl.Name] = decl.Signature
+	}
+
+	expected := map[string]string{
+		"Cache": "Cache[K comparable, V any]",
+		"Get":   "(*Cache[K, V]) Get(K) (V, bool)",
+		"Sum":   "Sum[T Number]([]T) T",
+		"Keys":  "Keys[K comparable, V any](*Cache[K, V]) []K",
+	}
+	for name, want := range expected {
+		got, ok := signatures[name]
+		if !ok {
+			t.Errorf("expected exported declaration %s", name)
+			continue
+		}
+		if got != want {
+			t.Errorf("signature of %s = %q, want %q", name, got, want)
+		}
+	}
+
+	// Constraint interfaces list their type set as a property
+	for _, decl := range files[0].ExportedDecls {
+		if decl.Name != "Number" {
+			continue
+		}
+		if len(decl.Properties) != 1 || decl.Properties[0] != "~int | ~int64 | ~float64" {
+			t.Errorf("expected type set property for Number, got %v", decl.Properties)
+		}
+	}
+}
+
+func TestScanWithAPI_RecordsEmbeddedTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "pkg")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	embedGo := `package pkg
+
+import "io"
+
+type Reader interface {
+	Read() string
+}
+
+type ReadCloser interface {
+	Reader
+	io.Closer
+}
+
+type Integer interface {
+	comparable
+	~int | ~int64
+}
+
+type base struct{}
+
+type User struct {
+	*base
+	Reader
+	Name string
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "embed.go"), []byte(embedGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludeExportedAPI: true})
+	if err != nil {
+		t.Fatalf("ScanWithAPI failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	embeds := make(map[string]string)
+	for _, decl := range files[0].ExportedDecls {
+		embeds[decl.Name] = strings.Join(decl.GetEmbeds(), ", ")
+	}
+
+	expected := map[string]string{
+		"Reader":     "",
+		"ReadCloser": "Reader, io.Closer",
+		"Integer":    "comparable",
+		"User":       "*base, Reader",
+	}
+	for name, want := range expected {
+		if got := embeds[name]; got != want {
+			t.Errorf("embeds of %s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestScanWithExamples_AttachesExamplesToPackage(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "pkg")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"cache.go": "package pkg\n\ntype Cache struct{}\n\nfunc (c *Cache) Get(key string) string { return key }\n",
+		"sum.go":   "package pkg\n\nfunc Sum(a, b int) int { return a + b }\n",
+		"example_test.go": `package pkg_test
+
+func Example() {}
+
+func ExampleCache_Get() {}
+
+func ExampleCache_Get_missing() {}
+
+func ExampleSum_negative() {}
+
+func Examples() {}
+
+func ExampleHelper(n int) {}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(pkgDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	scanned, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludeExamples: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	// Test files are not returned unless they are linted
+	if len(scanned) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(scanned))
+	}
+
+	var examples []scanner.Example
+	for _, file := range scanned {
+		if len(file.Examples) > 0 && examples != nil {
+			t.Errorf("expected examples on a single file, also found on %s", file.RelPath)
+		}
+		if len(file.Examples) > 0 {
+			examples = file.Examples
+		}
+	}
+
+	var got []string
+	for _, example := range examples {
+		got = append(got, fmt.Sprintf("%s=%s@%s:%d", example.Name, example.Symbol, example.RelPath, example.Line))
+	}
+	expected := []string{
+		"Example=@pkg/example_test.go:3",
+		"ExampleCache_Get=Cache.Get@pkg/example_test.go:5",
+		"ExampleCache_Get_missing=Cache.Get@pkg/example_test.go:7",
+		"ExampleSum_negative=Sum@pkg/example_test.go:9",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("examples:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+
+	// Without the option no examples are collected
+	scanned, err = s.Scan([]string{"pkg"}, scanner.ScanOptions{})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	for _, file := range scanned {
+		if file.Examples != nil {
+			t.Errorf("expected no examples without IncludeExamples, got %v", file.Examples)
+		}
+	}
+}
+
 func TestScan_LintTestFiles_Enabled(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -601,6 +883,14 @@ func Process(ctx context.Context) {
 	if !hasValidate {
 		t.Error("expected Validate in used symbols")
 	}
+
+	// Every reference counts as a call site
+	if fmtUsage.GetCallSites() != 2 || typesUsage.GetCallSites() != 2 {
+		t.Errorf("expected 2 call sites of fmt and types, got %d and %d", fmtUsage.GetCallSites(), typesUsage.GetCallSites())
+	}
+	if contextUsage := findImportUsage(usages, "context"); contextUsage == nil || contextUsage.GetCallSites() != 1 {
+		t.Errorf("expected 1 call site of context, got %+v", contextUsage)
+	}
 }
 
 func TestScanDetailed_NonExistentPath(t *testing.T) {
@@ -886,6 +1176,66 @@ type Extended struct {
 	}
 }
 
+func TestScanWithAPI_ExtractsDocCommentsAndTypeKinds(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "pkg")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	domainGo := `package pkg
+
+// Order is a customer's request to buy products.
+//
+// Orders are immutable once placed.
+type Order struct {
+	ID int
+}
+
+type (
+	// Repository stores orders.
+	Repository interface{}
+
+	// Grouped declarations keep their own doc comments
+	Status string
+)
+
+// Place creates an order
+func Place() {}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "domain.go"), []byte(domainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludeExportedAPI: true})
+	if err != nil {
+		t.Fatalf("ScanWithAPI failed: %v", err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	expected := map[string]struct{ typeKind, doc string }{
+		"Order":      {"struct", "Order is a customer's request to buy products.\n\nOrders are immutable once placed."},
+		"Repository": {"interface", "Repository stores orders."},
+		"Status":     {"other", "Grouped declarations keep their own doc comments"},
+		"Place":      {"", "Place creates an order"},
+	}
+	for _, decl := range files[0].ExportedDecls {
+		want, ok := expected[decl.GetName()]
+		if !ok {
+			t.Errorf("unexpected declaration %s", decl.GetName())
+			continue
+		}
+		if decl.GetTypeKind() != want.typeKind || decl.GetDoc() != want.doc {
+			t.Errorf("%s: expected type kind %q and doc %q, got %q and %q", decl.GetName(), want.typeKind, want.doc, decl.GetTypeKind(), decl.GetDoc())
+		}
+	}
+}
+
 func TestScanDetailed_WithImportAliases(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -972,14 +1322,74 @@ func TestScan_ErrorHandlingForInvalidPath(t *testing.T) {
 	}
 
 	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	s.SetStrictParse(true)
 	_, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{})
 
-	// Should return error for invalid Go file
+	// Should return error for invalid Go file in strict mode
 	if err == nil {
 		t.Error("expected error for invalid Go file, got nil")
 	}
 }
 
+func TestScan_CollectsParseErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "pkg")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	invalidGo := `package pkg
+
+import "fmt"
+
+func Broken( {
+`
+	validGo := `package pkg
+
+import "strings"
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "broken.go"), []byte(invalidGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pkgDir, "valid.go"), []byte(validGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludeExportedAPI: true})
+	if err != nil {
+		t.Fatalf("Scan should continue past parse errors, got: %v", err)
+	}
+
+	// The remaining files are still scanned
+	if len(files) != 1 || files[0].RelPath != "pkg/valid.go" {
+		t.Fatalf("expected only pkg/valid.go to be scanned, got %v", files)
+	}
+
+	parseErrors := s.ParseErrors()
+	if len(parseErrors) != 1 {
+		t.Fatalf("expected 1 parse error, got %d", len(parseErrors))
+	}
+	if parseErrors[0].GetRelPath() != "pkg/broken.go" {
+		t.Errorf("expected parse error in pkg/broken.go, got %s", parseErrors[0].GetRelPath())
+	}
+	if parseErrors[0].GetLine() != 5 {
+		t.Errorf("expected parse error on line 5, got %d", parseErrors[0].GetLine())
+	}
+	if parseErrors[0].GetMessage() == "" {
+		t.Error("expected parse error message")
+	}
+
+	// Parse errors are reset between scans
+	if _, err := s.Scan([]string{"missing"}, scanner.ScanOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.ParseErrors()) != 0 {
+		t.Errorf("expected parse errors to be reset, got %d", len(s.ParseErrors()))
+	}
+}
+
 func TestScanWithAPI_ErrorHandlingForInvalidGo(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -997,9 +1407,10 @@ func Invalid( {  // unclosed parenthesis
 	}
 
 	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	s.SetStrictParse(true)
 	_, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludeExportedAPI: true})
 
-	// Should return error for invalid Go file
+	// Should return error for invalid Go file in strict mode
 	if err == nil {
 		t.Error("expected error for invalid Go file, got nil")
 	}
@@ -1023,9 +1434,10 @@ func ( { // invalid
 	}
 
 	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	s.SetStrictParse(true)
 	_, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludeImportUsages: true})
 
-	// Should return error for invalid Go file
+	// Should return error for invalid Go file in strict mode
 	if err == nil {
 		t.Error("expected error for invalid Go file, got nil")
 	}
@@ -1040,3 +1452,1084 @@ func findImportUsage(usages []scanner.ImportUsage, importPath string) *scanner.I
 	}
 	return nil
 }
+
+func TestScanWithDefinitions_ExtractsStructsAndConstBlocks(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "internal", "model")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	modelGo := `package model
+
+import "time"
+
+type Order struct {
+	ID, Customer string
+	CreatedAt    time.Time
+	*Base
+}
+
+type Base struct{}
+
+type Handler func()
+
+const (
+	StatusNew  Status = "new"
+	StatusDone Status = "done"
+)
+
+const Single = 1
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "model.go"), []byte(modelGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"internal"}, scanner.ScanOptions{IncludeDefinitions: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	file := files[0]
+	if len(file.StructDefs) != 2 {
+		t.Fatalf("expected 2 struct definitions, got %d", len(file.StructDefs))
+	}
+
+	order := file.StructDefs[0]
+	if order.Name != "Order" {
+		t.Errorf("expected first struct Order, got %s", order.Name)
+	}
+	if order.Line != 5 {
+		t.Errorf("expected Order on line 5, got %d", order.Line)
+	}
+	expectedFields := []string{"ID string", "Customer string", "CreatedAt time.Time", "*Base"}
+	if len(order.Fields) != len(expectedFields) {
+		t.Fatalf("expected fields %v, got %v", expectedFields, order.Fields)
+	}
+	for i, field := range expectedFields {
+		if order.Fields[i] != field {
+			t.Errorf("field %d: expected %q, got %q", i, field, order.Fields[i])
+		}
+	}
+
+	// Ungrouped constants are not blocks
+	if len(file.ConstBlocks) != 1 {
+		t.Fatalf("expected 1 const block, got %d", len(file.ConstBlocks))
+	}
+	block := file.ConstBlocks[0]
+	if len(block.Entries) != 2 || block.Entries[0] != `StatusNew Status = "new"` {
+		t.Errorf("unexpected const block entries: %v", block.Entries)
+	}
+}
+
+func TestScan_DefinitionsNotExtractedByDefault(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "internal", "model")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	modelGo := `package model
+
+type Order struct {
+	ID   string
+	Name string
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "model.go"), []byte(modelGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"internal"}, scanner.ScanOptions{})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(files) != 1 || files[0].StructDefs != nil {
+		t.Error("expected no struct definitions without IncludeDefinitions")
+	}
+}
+
+func TestScanWithAPIReferences_ExtractsExposedImports(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "internal", "storage")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	storageGo := `package storage
+
+import (
+	"context"
+
+	s3sdk "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+type Client = s3sdk.Client
+
+type Store struct {
+	Options s3sdk.Options
+	client  *s3sdk.Client
+}
+
+func (s *Store) Put(ctx context.Context, key string) error {
+	var _ s3sdk.PutObjectInput
+	return nil
+}
+
+func newStore(c *s3sdk.Client) *Store {
+	return &Store{client: c}
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "storage.go"), []byte(storageGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"internal"}, scanner.ScanOptions{IncludeAPIReferences: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	// Function bodies, unexported fields and unexported functions are not part of the API
+	expected := []scanner.APIReference{
+		{Decl: "Client", ImportPath: "github.com/aws/aws-sdk-go-v2/service/s3", Symbol: "Client", Line: 9, Column: 15},
+		{Decl: "Store", ImportPath: "github.com/aws/aws-sdk-go-v2/service/s3", Symbol: "Options", Line: 12, Column: 10},
+		{Decl: "Store.Put", ImportPath: "context", Symbol: "Context", Line: 16, Column: 25},
+	}
+
+	refs := files[0].APIReferences
+	if len(refs) != len(expected) {
+		t.Fatalf("expected %d references, got %d: %v", len(expected), len(refs), refs)
+	}
+	for i, want := range expected {
+		if refs[i] != want {
+			t.Errorf("reference %d: expected %+v, got %+v", i, want, refs[i])
+		}
+	}
+}
+
+func TestScanWithSignatureRefs_IncludesUnexportedFunctions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "internal", "order")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	orderGo := `package order
+
+import "github.com/gin-gonic/gin"
+
+type Handler func(c *gin.Context)
+
+type Binder interface {
+	Bind(c *gin.Context) error
+}
+
+type service struct{}
+
+func (s *service) create(c *gin.Context) {
+	var _ gin.H
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "order.go"), []byte(orderGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"internal"}, scanner.ScanOptions{IncludeSignatureRefs: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	// Function bodies are not part of the signature
+	expected := []scanner.APIReference{
+		{Decl: "service.create", ImportPath: "github.com/gin-gonic/gin", Symbol: "Context", Line: 13, Column: 29},
+		{Decl: "Handler", ImportPath: "github.com/gin-gonic/gin", Symbol: "Context", Line: 5, Column: 22},
+		{Decl: "Binder", ImportPath: "github.com/gin-gonic/gin", Symbol: "Context", Line: 8, Column: 10},
+	}
+
+	refs := files[0].SignatureRefs
+	if len(refs) != len(expected) {
+		t.Fatalf("expected %d references, got %d: %v", len(expected), len(refs), refs)
+	}
+	for _, want := range expected {
+		found := false
+		for _, ref := range refs {
+			if ref == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected reference %+v, got %+v", want, refs)
+		}
+	}
+}
+
+func TestScanWithStability_ReadsPackageDocAnnotation(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "pkg", "client")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"doc.go": `// Package client talks to the API.
+//
+// archlint:stability experimental
+package client
+`,
+		// Directive style and unknown levels
+		"client.go": `//archlint:stability stable
+package client
+
+import "net/http"
+
+var _ = http.Get
+`,
+		"retry.go": `// archlint:stability beta
+package client
+`,
+		// Comments separated from the package clause are not package docs
+		"pool.go": `// archlint:stability deprecated
+
+package client
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(pkgDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	scanned, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludeStability: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"pkg/client/doc.go":    "experimental",
+		"pkg/client/client.go": "stable",
+		"pkg/client/retry.go":  "",
+		"pkg/client/pool.go":   "",
+	}
+	for _, file := range scanned {
+		if file.GetStability() != expected[file.RelPath] {
+			t.Errorf("%s: expected stability %q, got %q", file.RelPath, expected[file.RelPath], file.GetStability())
+		}
+	}
+
+	// Not extracted unless requested
+	scanned, err = s.Scan([]string{"pkg"}, scanner.ScanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range scanned {
+		if file.Stability != "" {
+			t.Errorf("%s: expected no stability by default, got %q", file.RelPath, file.Stability)
+		}
+	}
+}
+
+func TestScanWithPackageDoc_ExtractsDocWithoutAnnotations(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "pkg", "client")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"doc.go": `// Package client talks to the API.
+//
+// Requests are retried with backoff.
+//
+// archlint:stability experimental
+package client
+`,
+		"client.go": `package client
+
+// Client is not a package doc
+type Client struct{}
+`,
+		"gen.go": `//go:generate stringer -type=Mode
+package client
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(pkgDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	scanned, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludePackageDoc: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"pkg/client/doc.go":    "Package client talks to the API.\n\nRequests are retried with backoff.",
+		"pkg/client/client.go": "",
+		"pkg/client/gen.go":    "",
+	}
+	for _, file := range scanned {
+		if file.GetPackageDoc() != expected[file.RelPath] {
+			t.Errorf("%s: expected package doc %q, got %q", file.RelPath, expected[file.RelPath], file.GetPackageDoc())
+		}
+	}
+
+	// Not extracted unless requested
+	scanned, err = s.Scan([]string{"pkg"}, scanner.ScanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range scanned {
+		if file.PackageDoc != "" {
+			t.Errorf("%s: expected no package doc without IncludePackageDoc, got %q", file.RelPath, file.PackageDoc)
+		}
+	}
+}
+
+func TestScanWithDeprecations_ExtractsDeprecatedSymbolsAndReferences(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "internal", "order")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	orderGo := `package order
+
+import "github.com/test/project/internal/legacy"
+
+// OldFind looks up an order.
+//
+// Deprecated: use Find instead.
+func OldFind() {}
+
+// Find looks up an order. Deprecated: is not a paragraph start here.
+func Find() {}
+
+// Deprecated: use Service.
+type Manager struct{}
+
+// Deprecated: limits moved to config.
+const (
+	MaxItems = 10
+	minItems = 1
+)
+
+var Store = legacy.NewStore().Client()
+
+func (m *Manager) Load() {
+	legacy.Load(legacy.Default)
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "order.go"), []byte(orderGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"internal"}, scanner.ScanOptions{IncludeDeprecations: true, IncludeSymbolRefs: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	deprecated := strings.Join(files[0].Deprecated, ",")
+	if deprecated != "OldFind,Manager,MaxItems" {
+		t.Errorf("expected OldFind,Manager,MaxItems to be deprecated, got %s", deprecated)
+	}
+
+	var refs []string
+	for _, ref := range files[0].SymbolRefs {
+		refs = append(refs, fmt.Sprintf("%s:%s.%s:%d", ref.GetDecl(), filepath.Base(ref.GetImportPath()), ref.GetSymbol(), ref.GetLine()))
+	}
+	expected := "Store:legacy.NewStore:22,Manager.Load:legacy.Load:25,Manager.Load:legacy.Default:25"
+	if strings.Join(refs, ",") != expected {
+		t.Errorf("expected refs %s, got %s", expected, strings.Join(refs, ","))
+	}
+}
+
+func TestScanWithFileReads_ExtractsLiteralPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "internal", "parser")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	parserTestGo := `package parser_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	os.ReadFile("testdata/input.json")
+	f, _ := os.Open(filepath.Join("fixtures", "golden.txt"))
+	_ = f
+	ioutil.ReadFile("./sample.yaml")
+	name := "dynamic.json"
+	os.ReadFile(name)
+	os.ReadFile(filepath.Join(t.TempDir(), "out.json"))
+	os.WriteFile("testdata/out.json", nil, 0644)
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "parser_test.go"), []byte(parserTestGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, true)
+	files, err := s.Scan([]string{"internal"}, scanner.ScanOptions{IncludeFileReads: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	var reads []string
+	for _, read := range files[0].FileReads {
+		reads = append(reads, fmt.Sprintf("%s:%s:%d:%d", read.GetFunction(), read.GetPath(), read.GetLine(), read.GetColumn()))
+	}
+	expected := "os.ReadFile:testdata/input.json:11:14,os.Open:fixtures/golden.txt:12:18,io/ioutil.ReadFile:./sample.yaml:14:18"
+	if strings.Join(reads, ",") != expected {
+		t.Errorf("expected reads %s, got %s", expected, strings.Join(reads, ","))
+	}
+}
+
+func TestScanWithDynamicRefs_ExtractsRegistryKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "internal", "app")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	appGo := `package app
+
+import (
+	"reflect"
+
+	"github.com/test/project/internal/registry"
+)
+
+type Service struct{}
+
+func (s *Service) Charge() {}
+
+func (s *Service) refund() {}
+
+func init() {
+	registry.Register("orders", &Service{})
+}
+
+func Run(svc any) {
+	registry.Get("billing")
+	reflect.ValueOf(svc).MethodByName("Charge")
+	key := "dynamic"
+	registry.Get(key)
+	Lookup("ledger")
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "app.go"), []byte(appGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"internal"}, scanner.ScanOptions{
+		IncludeDynamicRefs: true,
+		RegisterFuncs:      []string{"Register"},
+		LookupFuncs:        []string{"Get", "Lookup"},
+	})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	var refs []string
+	for _, ref := range files[0].DynamicRefs {
+		refs = append(refs, fmt.Sprintf("%s:%s:%s:%d:%d", ref.GetKind(), ref.GetFunction(), ref.GetKey(), ref.GetLine(), ref.GetColumn()))
+	}
+	expected := "method:Service.Charge:Charge:11:19,register:registry.Register:orders:16:20,lookup:registry.Get:billing:20:15," +
+		"reflect:MethodByName:Charge:21:36,lookup:Lookup:ledger:24:9"
+	if strings.Join(refs, ",") != expected {
+		t.Errorf("expected refs %s, got %s", expected, strings.Join(refs, ","))
+	}
+}
+
+func TestScanWithInitRegistrations_RecordsBlankImportsAndRegistrations(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	driverDir := filepath.Join(tmpDir, "internal", "driver")
+	if err := os.MkdirAll(driverDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	driverGo := `package driver
+
+import (
+	"database/sql"
+	_ "embed"
+	"net/http"
+	_ "image/png"
+)
+
+var codecs = map[string]func(){}
+
+func init() {
+	sql.Register("custom", nil)
+	codecs["gzip"] = func() {}
+	http.HandleFunc("/debug", nil)
+	sql.Register("other", nil)
+}
+
+func setup() {
+	codecs["zstd"] = func() {}
+}
+`
+	if err := os.WriteFile(filepath.Join(driverDir, "driver.go"), []byte(driverGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"internal"}, scanner.ScanOptions{IncludeInitRegistrations: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	if blank := strings.Join(files[0].BlankImports, ","); blank != "embed,image/png" {
+		t.Errorf("expected blank imports embed,image/png, got %s", blank)
+	}
+	// Only init functions count, and each registration is listed once
+	if registrations := strings.Join(files[0].InitRegistrations, ","); registrations != "sql.Register,codecs[...],http.HandleFunc" {
+		t.Errorf("unexpected init registrations: %s", registrations)
+	}
+}
+
+func TestScanWithConstructions_TracksInjectedComponents(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	mainDir := filepath.Join(tmpDir, "cmd", "api")
+	if err := os.MkdirAll(mainDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	mainGo := `package main
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/test/project/internal/app"
+	pg "github.com/test/project/internal/infra/postgres"
+)
+
+func main() {
+	db, err := sql.Open("postgres", "")
+	if err != nil {
+		panic(err)
+	}
+	repo := pg.NewRepo(db)
+	var svc = app.NewService(repo, &pg.Config{Pool: 4})
+	http.ListenAndServe(":8080", app.NewHandler(svc))
+}
+`
+	if err := os.WriteFile(filepath.Join(mainDir, "main.go"), []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	libDir := filepath.Join(tmpDir, "internal", "app")
+	if err := os.MkdirAll(libDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	libGo := "package app\n\nimport \"errors\"\n\nvar ErrNotFound = errors.New(\"not found\")\n"
+	if err := os.WriteFile(filepath.Join(libDir, "app.go"), []byte(libGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"cmd", "internal"}, scanner.ScanOptions{IncludeConstructions: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	var constructions []string
+	for _, file := range files {
+		if file.Package != "main" && len(file.Constructions) > 0 {
+			t.Errorf("expected no constructions outside main packages, got %+v", file.Constructions)
+		}
+		for _, c := range file.Constructions {
+			constructions = append(constructions, fmt.Sprintf("%s.%s%v@%d", path.Base(c.ImportPath), c.Name, c.Injected, c.Line))
+		}
+	}
+
+	// Nested constructions come first, in evaluation order
+	expected := []string{
+		"sql.Open[]@12",
+		"postgres.NewRepo[database/sql.Open]@16",
+		"postgres.Config[]@17",
+		"app.NewService[github.com/test/project/internal/infra/postgres.NewRepo github.com/test/project/internal/infra/postgres.Config]@17",
+		"app.NewHandler[github.com/test/project/internal/app.NewService]@18",
+	}
+	if strings.Join(constructions, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected constructions:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(constructions, "\n"))
+	}
+}
+
+func TestScanWithConstructions_DIWiring(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	sources := map[string]string{
+		// Wire provider set and injector
+		"pkg/app/wire.go": `//go:build wireinject
+
+package app
+
+import (
+	"github.com/google/wire"
+
+	"github.com/test/project/pkg/app/infra/db"
+)
+
+var Set = wire.NewSet(db.NewRepo, NewApp, wire.Bind(new(Repo), new(*db.Repo)))
+
+func Initialize() *App {
+	wire.Build(Set, db.ProvideConfig)
+	return nil
+}
+`,
+		// Code generated by Wire does not import wire and calls providers of any name
+		"pkg/app/wire_gen.go": `// Code generated by Wire. DO NOT EDIT.
+
+package app
+
+import "github.com/test/project/pkg/app/infra/db"
+
+func Initialize() *App {
+	config := db.ProvideConfig()
+	repo := db.NewRepo(config)
+	app := NewApp(repo)
+	return app
+}
+`,
+		"pkg/orders/module.go": `package orders
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/test/project/pkg/orders/store"
+)
+
+var Module = fx.Module("orders", fx.Provide(store.NewStore, fx.Annotate(NewService, fx.As(new(Orders)))))
+`,
+		"pkg/billing/container.go": `package billing
+
+import (
+	"go.uber.org/dig"
+
+	"github.com/test/project/pkg/billing/stripe"
+)
+
+func Container() *dig.Container {
+	c := dig.New()
+	c.Provide(stripe.NewClient)
+	return c
+}
+`,
+		"pkg/plain/plain.go": "package plain\n\nimport \"github.com/test/project/pkg/app/infra/db\"\n\nvar repo = db.NewRepo(nil)\n",
+	}
+	for relPath, content := range sources {
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	files, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludeConstructions: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	frameworks := make(map[string]string)
+	constructions := make(map[string][]string)
+	for _, file := range files {
+		frameworks[file.RelPath] = file.DIFramework
+		for _, c := range file.Constructions {
+			entry := c.Name
+			if c.ImportPath != "" {
+				entry = path.Base(c.ImportPath) + "." + c.Name
+			}
+			if c.Provided {
+				entry += " (provided)"
+			}
+			if len(c.Injected) > 0 {
+				entry += fmt.Sprintf(" <- %v", c.Injected)
+			}
+			constructions[file.RelPath] = append(constructions[file.RelPath], entry)
+		}
+	}
+
+	expectedFrameworks := map[string]string{
+		"pkg/app/wire.go":          scanner.DIFrameworkWire,
+		"pkg/app/wire_gen.go":      scanner.DIFrameworkWire,
+		"pkg/orders/module.go":     scanner.DIFrameworkFx,
+		"pkg/billing/container.go": scanner.DIFrameworkDig,
+		"pkg/plain/plain.go":       "",
+	}
+	for relPath, want := range expectedFrameworks {
+		if frameworks[relPath] != want {
+			t.Errorf("%s: expected DI framework %q, got %q", relPath, want, frameworks[relPath])
+		}
+	}
+
+	// Providers of the file's own package have no import path
+	expected := map[string]string{
+		"pkg/app/wire.go":          "db.NewRepo (provided), NewApp (provided), Set (provided), db.ProvideConfig (provided)",
+		"pkg/app/wire_gen.go":      "db.ProvideConfig, db.NewRepo <- [github.com/test/project/pkg/app/infra/db.ProvideConfig], NewApp <- [github.com/test/project/pkg/app/infra/db.NewRepo]",
+		"pkg/orders/module.go":     "store.NewStore (provided), NewService (provided)",
+		"pkg/billing/container.go": "stripe.NewClient (provided)",
+		"pkg/plain/plain.go":       "",
+	}
+	for relPath, want := range expected {
+		if got := strings.Join(constructions[relPath], ", "); got != want {
+			t.Errorf("%s: expected constructions:\n%s\ngot:\n%s", relPath, want, got)
+		}
+	}
+}
+
+func TestScanWithSymbolUses_ClassifiesUses(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "internal", "billing")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	invoiceTestGo := `package billing_test
+
+import (
+	"testing"
+
+	"github.com/test/project/internal/store"
+)
+
+func TestInvoice(t *testing.T) {
+	var items []store.Item
+	order := &store.Order{Items: items}
+	page := store.Page[int]{}
+	customer := store.NewCustomer("acme")
+	store.Save(order, customer, page, store.DefaultLimit)
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "invoice_test.go"), []byte(invoiceTestGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, true)
+	files, err := s.Scan([]string{"internal"}, scanner.ScanOptions{IncludeSymbolUses: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	var uses []string
+	for _, u := range files[0].SymbolUses {
+		uses = append(uses, fmt.Sprintf("%s.%s:%s:%d", filepath.Base(u.GetImportPath()), u.GetSymbol(), u.GetKind(), u.GetLine()))
+	}
+	expected := "testing.T:ref:9,store.Item:ref:10,store.Order:literal:11,store.Page:literal:12,store.NewCustomer:call:13," +
+		"store.Save:call:14,store.DefaultLimit:ref:14"
+	if strings.Join(uses, ",") != expected {
+		t.Errorf("expected uses %s, got %s", expected, strings.Join(uses, ","))
+	}
+}
+
+func TestScanWithFuncDecls_RecordsSamePackageUses(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "internal", "billing")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	invoiceGo := `package billing
+
+type Invoice struct{ Total int }
+
+func (i Invoice) Due() int { return i.Total }
+
+func NewInvoice(total int) Invoice { return Invoice{Total: total} }
+
+func total(items []int) int { return len(items) }
+`
+	invoiceTestGo := `package billing
+
+import "testing"
+
+func TestInvoice(t *testing.T) {
+	inv := Invoice{Total: total(nil)}
+	got := applyTax(inv.Due())
+	if got != len(taxRates) {
+		t.Fatal(inv)
+	}
+}
+`
+	for name, content := range map[string]string{"invoice.go": invoiceGo, "invoice_test.go": invoiceTestGo} {
+		if err := os.WriteFile(filepath.Join(pkgDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, true)
+	files, err := s.Scan([]string{"internal"}, scanner.ScanOptions{IncludeSymbolUses: true, IncludeFuncDecls: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+
+	for _, f := range files {
+		if f.IsTest {
+			if len(f.FuncDecls) != 1 || f.FuncDecls[0] != "TestInvoice" {
+				t.Errorf("expected test FuncDecls [TestInvoice], got %v", f.FuncDecls)
+			}
+
+			// Builtins, field keys and locally declared identifiers are not uses
+			var uses []string
+			for _, u := range f.SymbolUses {
+				if u.GetImportPath() == "" {
+					uses = append(uses, fmt.Sprintf("%s:%s:%d", u.GetSymbol(), u.GetKind(), u.GetLine()))
+				}
+			}
+			expected := "Invoice:literal:6,total:call:6,applyTax:call:7,taxRates:ref:8"
+			if strings.Join(uses, ",") != expected {
+				t.Errorf("expected same-package uses %s, got %s", expected, strings.Join(uses, ","))
+			}
+			continue
+		}
+
+		// Methods are not package-level functions
+		if strings.Join(f.FuncDecls, ",") != "NewInvoice,total" {
+			t.Errorf("expected FuncDecls NewInvoice,total, got %v", f.FuncDecls)
+		}
+	}
+}
+
+func TestScan_BuildContext(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "internal", "platform")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"platform.go":         "package platform\n",
+		"platform_windows.go": "package platform\n",
+		"platform_linux.go":   "package platform\n",
+		"debug.go":            "//go:build debug\n\npackage platform\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(pkgDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanned := func(s *scanner.Scanner) string {
+		result, err := s.Scan([]string{"internal"}, scanner.ScanOptions{})
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		var names []string
+		for _, f := range result {
+			names = append(names, filepath.Base(f.RelPath))
+		}
+		sort.Strings(names)
+		return strings.Join(names, ",")
+	}
+
+	// Without a build context every file is scanned
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	if got := scanned(s); got != "debug.go,platform.go,platform_linux.go,platform_windows.go" {
+		t.Errorf("expected all files, got %s", got)
+	}
+
+	s.SetBuildContext("linux", "amd64", nil)
+	if got := scanned(s); got != "platform.go,platform_linux.go" {
+		t.Errorf("expected linux files, got %s", got)
+	}
+
+	s.SetBuildContext("windows", "amd64", []string{"debug"})
+	if got := scanned(s); got != "debug.go,platform.go,platform_windows.go" {
+		t.Errorf("expected windows files with debug tag, got %s", got)
+	}
+}
+
+func TestScanWithSuppressions_ReadsIgnoreDirectives(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pkgDir := filepath.Join(tmpDir, "pkg", "client")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	src := `//archlint:ignore rules.package_docs reason=Generated code
+package client
+
+import (
+	// archlint:ignore rules.directories_import until=2025-12-31 reason=Migrating to the port, see #42
+	"github.com/test/project/internal/store"
+	"github.com/test/project/internal/cache" //archlint:ignore rules.directories_import.pkg
+)
+
+func Get() {
+	//archlint:ignore
+	store.Get()
+	cache.Get() // archlint:ignored is not a directive
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "client.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := scanner.New(tmpDir, "github.com/test/project", nil, false)
+	scanned, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{IncludeSuppressions: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(scanned) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(scanned))
+	}
+
+	expected := []scanner.Suppression{
+		{Rule: "rules.package_docs", Reason: "Generated code", Line: 1, WholeFile: true},
+		{Rule: "rules.directories_import", Until: "2025-12-31", Reason: "Migrating to the port, see #42", Line: 5},
+		{Rule: "rules.directories_import.pkg", Line: 7},
+		{Line: 11},
+	}
+	got := scanned[0].Suppressions
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d directives, got %+v", len(expected), got)
+	}
+	for i, want := range expected {
+		if got[i] != want {
+			t.Errorf("directive %d: expected %+v, got %+v", i, want, got[i])
+		}
+	}
+
+	// Not extracted unless requested
+	scanned, err = s.Scan([]string{"pkg"}, scanner.ScanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scanned[0].Suppressions != nil {
+		t.Errorf("expected no directives by default, got %+v", scanned[0].Suppressions)
+	}
+}
+
+func TestScanFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(relPath, content string) {
+		t.Helper()
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("pkg/api/api.go", "package api\n")
+	write("pkg/api/broken.go", "package api\n\nimport (\n")
+	write("pkg/api/gone.go", "package api\n")
+	s := scanner.New(tmpDir, "github.com/test/project", []string{"pkg/gen"}, false)
+	if _, err := s.Scan([]string{"pkg"}, scanner.ScanOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.ParseErrors()) != 1 {
+		t.Fatalf("expected one parse error, got %v", s.ParseErrors())
+	}
+
+	write("pkg/api/api.go", "package api\n\nimport \"fmt\"\n\nvar _ = fmt.Sprint\n")
+	write("pkg/api/broken.go", "package api\n")
+	write("pkg/api/api_test.go", "package api\n")
+	write("pkg/gen/gen.go", "package gen\n")
+	write("cmd/app/main.go", "package main\n")
+	if err := os.Remove(filepath.Join(tmpDir, "pkg/api/gone.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	files, removed, err := s.ScanFiles([]string{"pkg"}, []string{
+		"pkg/api/api.go", "pkg/api/broken.go", "pkg/api/gone.go", "pkg/api/api_test.go", "pkg/gen/gen.go", "cmd/app/main.go",
+	}, scanner.ScanOptions{})
+	if err != nil {
+		t.Fatalf("ScanFiles failed: %v", err)
+	}
+
+	var parsed []string
+	for _, file := range files {
+		parsed = append(parsed, file.RelPath)
+	}
+	if strings.Join(parsed, ",") != "pkg/api/api.go,pkg/api/broken.go" {
+		t.Errorf("expected the files of the scan to be parsed, got %v", parsed)
+	}
+	if len(files) > 0 && (len(files[0].Imports) != 1 || files[0].Imports[0] != "fmt") {
+		t.Errorf("expected the new imports, got %v", files[0].Imports)
+	}
+	// Deleted, test files without lintTestFiles, ignored and out of the scan paths
+	if strings.Join(removed, ",") != "pkg/api/gone.go,pkg/api/api_test.go,pkg/gen/gen.go,cmd/app/main.go" {
+		t.Errorf("unexpected removed files: %v", removed)
+	}
+	if len(s.ParseErrors()) != 0 {
+		t.Errorf("expected the fixed file to lose its parse error, got %v", s.ParseErrors())
+	}
+
+	write("pkg/api/api.go", "package api\n\nimport (\n")
+	_, removed, err = s.ScanFiles([]string{"pkg"}, []string{"pkg/api/api.go"}, scanner.ScanOptions{})
+	if err != nil {
+		t.Fatalf("ScanFiles failed: %v", err)
+	}
+	if len(removed) != 1 || len(s.ParseErrors()) != 1 || s.ParseErrors()[0].RelPath != "pkg/api/api.go" {
+		t.Errorf("expected the broken file to be removed and reported, got %v and %v", removed, s.ParseErrors())
+	}
+}
@@ -0,0 +1,227 @@
+package testrun
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxOutputLines limits the output kept for a failure to its first lines
+const maxOutputLines = 10
+
+// Failure is a test that failed in a go test run, or a package whose tests failed
+// without a failing test (build errors, panics outside tests, timeouts)
+type Failure struct {
+	PackagePath string // Import path of the package
+	Test        string // Failing top-level test (empty for failures of the whole package)
+	Seed        string // Seed of the test order with -shuffle (empty without)
+	Output      string // First lines of the output of the test, without the === and --- lines
+	Race        bool   // The race detector reported a data race (with -race)
+}
+
+// GetPackagePath implements validator.TestFailure interface
+func (f Failure) GetPackagePath() string {
+	return f.PackagePath
+}
+
+// GetTest implements validator.TestFailure interface
+func (f Failure) GetTest() string {
+	return f.Test
+}
+
+// GetSeed implements validator.TestFailure interface
+func (f Failure) GetSeed() string {
+	return f.Seed
+}
+
+// GetOutput implements validator.TestFailure interface
+func (f Failure) GetOutput() string {
+	return f.Output
+}
+
+// IsRace implements validator.TestFailure interface
+func (f Failure) IsRace() bool {
+	return f.Race
+}
+
+// Runner runs go test for the packages of a project
+type Runner struct {
+	projectPath string
+}
+
+// New creates a runner for the project at projectPath
+func New(projectPath string) *Runner {
+	return &Runner{projectPath: projectPath}
+}
+
+// Run runs go test with flags for the packages in and below dirs (relative to the
+// project root) and returns the failures, sorted by package and test. Paths of the
+// project in the output are made relative to its root. Failing tests are not an error;
+// it fails if go test produced no results or a directory cannot be tested.
+func (r *Runner) Run(dirs []string, flags ...string) ([]Failure, error) {
+	if len(dirs) == 0 {
+		return nil, nil
+	}
+
+	args := append([]string{"test", "-json"}, flags...)
+	for _, dir := range dirs {
+		args = append(args, "./"+path.Clean(dir)+"/...")
+	}
+	cmd := exec.Command("go", args...)
+	cmd.Dir = r.projectPath
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	failures, ok := parseEvents(output)
+	if !ok && err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("go test %s: %s", strings.Join(flags, " "), message)
+	}
+	root := filepath.ToSlash(r.projectPath) + "/"
+	for i, failure := range failures {
+		// Patterns that cannot be resolved, such as missing directories, fail as a package
+		if strings.HasPrefix(failure.PackagePath, "./") {
+			return nil, fmt.Errorf("go test %s: %s", strings.Join(flags, " "), failure.Output)
+		}
+		failures[i].Output = strings.ReplaceAll(failure.Output, root, "")
+	}
+	return failures, nil
+}
+
+// event is a line of go test -json output
+type event struct {
+	Action      string
+	Package     string
+	Test        string
+	Output      string
+	ImportPath  string // Package being built, for build-output events
+	FailedBuild string // Import path of the build that failed, for package fail events
+}
+
+// packageRun collects the events of one package
+type packageRun struct {
+	seed   string
+	output []string            // Output of the package outside of tests
+	tests  map[string][]string // Top-level test -> output of it and its subtests
+	failed []string            // Failed top-level tests, in the order they failed
+	fail   bool                // The package failed
+}
+
+// parseEvents extracts the failures from go test -json output. It reports false if the
+// output holds no test events.
+func parseEvents(data []byte) ([]Failure, bool) {
+	runs := make(map[string]*packageRun)
+	builds := make(map[string][]string) // Build output by import path
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if e.Action == "build-output" {
+			builds[e.ImportPath] = append(builds[e.ImportPath], e.Output)
+		}
+		if e.Package == "" {
+			continue
+		}
+		run, ok := runs[e.Package]
+		if !ok {
+			run = &packageRun{tests: make(map[string][]string)}
+			runs[e.Package] = run
+		}
+
+		test := strings.SplitN(e.Test, "/", 2)[0]
+		switch e.Action {
+		case "output":
+			if seed, ok := strings.CutPrefix(strings.TrimSpace(e.Output), "-test.shuffle "); ok && test == "" {
+				run.seed = seed
+			} else if test == "" {
+				run.output = append(run.output, e.Output)
+			} else {
+				run.tests[test] = append(run.tests[test], e.Output)
+			}
+		case "fail":
+			if e.Test == "" {
+				run.fail = true
+				run.output = append(run.output, builds[e.FailedBuild]...)
+			} else if e.Test == test && !contains(run.failed, test) {
+				run.failed = append(run.failed, test)
+			}
+		}
+	}
+	if len(runs) == 0 {
+		return nil, false
+	}
+
+	packages := make([]string, 0, len(runs))
+	for pkg := range runs {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+
+	var failures []Failure
+	for _, pkg := range packages {
+		run := runs[pkg]
+		tests := append([]string(nil), run.failed...)
+		sort.Strings(tests)
+		for _, test := range tests {
+			output := run.tests[test]
+			failures = append(failures, Failure{PackagePath: pkg, Test: test, Seed: run.seed, Output: excerpt(output), Race: reportsRace(output)})
+		}
+		if run.fail && len(tests) == 0 {
+			failures = append(failures, Failure{PackagePath: pkg, Seed: run.seed, Output: excerpt(run.output), Race: reportsRace(run.output)})
+		}
+	}
+	return failures, true
+}
+
+// excerpt joins the first lines of test output, leaving out the progress lines of the
+// testing package and the summary lines of go test
+func excerpt(output []string) string {
+	var lines []string
+	for _, chunk := range output {
+		for _, line := range strings.Split(strings.TrimRight(chunk, "\n"), "\n") {
+			trimmed := strings.TrimSpace(line)
+			if strings.Trim(trimmed, "=") == "" || trimmed == "FAIL" || trimmed == "PASS" || strings.HasPrefix(trimmed, "=== ") || strings.HasPrefix(trimmed, "# ") ||
+				strings.HasPrefix(trimmed, "--- ") || strings.HasPrefix(trimmed, "FAIL\t") || strings.HasPrefix(trimmed, "ok  \t") {
+				continue
+			}
+			if len(lines) == maxOutputLines {
+				return strings.Join(append(lines, "..."), "\n")
+			}
+			lines = append(lines, trimmed)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// reportsRace reports whether test output holds a report of the race detector
+func reportsRace(output []string) bool {
+	for _, line := range output {
+		if strings.Contains(line, "WARNING: DATA RACE") || strings.Contains(line, "race detected during execution of test") {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether list contains s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
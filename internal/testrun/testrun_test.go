@@ -0,0 +1,157 @@
+package testrun_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/testrun"
+)
+
+func TestRunner_Run(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":                  "module github.com/test/project\n\ngo 1.21\n",
+		"internal/store/store.go": "package store\n\nvar Count int\n",
+		"internal/store/store_test.go": `package store_test
+
+import (
+	"testing"
+
+	"github.com/test/project/internal/store"
+)
+
+func TestPasses(t *testing.T) {}
+
+func TestFails(t *testing.T) {
+	t.Run("nested", func(t *testing.T) {
+		t.Errorf("count is %d", store.Count)
+	})
+}
+`,
+		"internal/broken/broken.go":      "package broken\n\nfunc Value() int { return \"x\" }\n",
+		"internal/broken/broken_test.go": "package broken\n\nimport \"testing\"\n\nfunc TestValue(t *testing.T) {}\n",
+		"internal/clean/clean_test.go":   "package clean\n\nimport \"testing\"\n\nfunc TestClean(t *testing.T) {}\n",
+	}
+	for relPath, content := range files {
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	failures, err := testrun.New(tmpDir).Run([]string{"internal"}, "-shuffle=42")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("expected a failure for the broken package and the failing test, got %+v", failures)
+	}
+
+	broken := failures[0]
+	if broken.PackagePath != "github.com/test/project/internal/broken" || broken.Test != "" {
+		t.Errorf("expected a package failure for internal/broken first, got %+v", broken)
+	}
+	if !strings.Contains(broken.Output, "internal/broken/broken.go:3") {
+		t.Errorf("expected the build error in the output, got %q", broken.Output)
+	}
+
+	failing := failures[1]
+	if failing.PackagePath != "github.com/test/project/internal/store" || failing.Test != "TestFails" || failing.Seed != "42" {
+		t.Errorf("expected TestFails of internal/store with seed 42, got %+v", failing)
+	}
+	if failing.Output != "store_test.go:13: count is 0" {
+		t.Errorf("expected only the message of the nested test as output, got %q", failing.Output)
+	}
+}
+
+func TestRunner_Run_NoPackages(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module github.com/test/project\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := testrun.New(tmpDir).Run([]string{"missing"}, "-shuffle=on"); err == nil {
+		t.Error("expected an error for a directory without packages")
+	}
+}
+
+func TestRunner_Run_Race(t *testing.T) {
+	if out, err := exec.Command("go", "env", "CGO_ENABLED").Output(); err != nil || strings.TrimSpace(string(out)) != "1" {
+		t.Skip("the race detector needs cgo")
+	}
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod": "module github.com/test/project\n\ngo 1.21\n",
+		"internal/worker/worker.go": `package worker
+
+import "sync"
+
+type Pool struct{ done int }
+
+func (p *Pool) Run(jobs int) int {
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.done++
+		}()
+	}
+	wg.Wait()
+	return p.done
+}
+`,
+		"internal/worker/worker_test.go": `package worker_test
+
+import (
+	"testing"
+
+	"github.com/test/project/internal/worker"
+)
+
+func TestRun(t *testing.T) {
+	(&worker.Pool{}).Run(4)
+}
+
+func TestFails(t *testing.T) {
+	t.Error("not a race")
+}
+`,
+	}
+	for relPath, content := range files {
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	failures, err := testrun.New(tmpDir).Run([]string{"internal/worker"}, "-race")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("expected TestFails and TestRun to fail, got %+v", failures)
+	}
+	if failures[0].Test != "TestFails" || failures[0].Race {
+		t.Errorf("expected TestFails without a race first, got %+v", failures[0])
+	}
+
+	race := failures[1]
+	if race.Test != "TestRun" || !race.Race {
+		t.Errorf("expected a data race in TestRun, got %+v", race)
+	}
+	if !strings.Contains(race.Output, "internal/worker/worker.go:13") || strings.Contains(race.Output, tmpDir) {
+		t.Errorf("expected project-relative locations in the race report, got %q", race.Output)
+	}
+}
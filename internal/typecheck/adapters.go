@@ -0,0 +1,320 @@
+package typecheck
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// How an adapter method reaches the target package
+const (
+	DelegateMethod = "method" // Method of the adapted type
+	DelegateFunc   = "func"   // Package-level function of the target package
+)
+
+// AdapterSpec describes adapters implementing the interfaces of a consumer package by
+// delegating to a target package
+type AdapterSpec struct {
+	Consumer string            // Name of the consumer package
+	Target   string            // Name of the target package
+	Adapters []Adapter         // Sorted by interface
+	Skipped  []string          // Consumer interfaces without an adapter, with the reason
+	Imports  map[string]string // Import path -> package name, for the packages the adapters refer to
+}
+
+// Adapter implements one consumer interface
+type Adapter struct {
+	Interface string          // Name of the consumer interface
+	Delegate  string          // Type of the target package the methods delegate to (empty if only functions match)
+	Methods   []AdapterMethod // Sorted by name
+}
+
+// AdapterMethod is a method of an adapter
+type AdapterMethod struct {
+	Name     string
+	Params   string   // Parameters with names, e.g. "(ctx context.Context, ids ...int)"
+	Results  string   // Results without names, e.g. "([]app.Invoice, error)" (empty if none)
+	Args     []string // Arguments passing the parameters on, e.g. ["ctx", "ids..."]
+	Via      string   // DelegateMethod, DelegateFunc or empty if nothing in the target matches
+	Converts []string // Per result: element type to convert a returned slice to, or empty if the result is assignable
+}
+
+// AdapterSpec type-checks the packages in consumerDir and targetDir (relative to the
+// project root) and matches each exported interface of the consumer with the exported
+// type of the target whose methods can serve most of it: same name, parameters the
+// target accepts and results assignable to the interface's, or slices of assignable
+// elements. Methods no type has fall back to package-level functions of the same name.
+// Interfaces the target already implements, and those nothing in the target serves,
+// are skipped. Signatures are written for adapters declared in the package adapterDir.
+func (c *Checker) AdapterSpec(consumerDir, targetDir, adapterDir string) (AdapterSpec, error) {
+	target, err := c.Import(c.importPath(targetDir))
+	if err != nil {
+		return AdapterSpec{}, err
+	}
+	if len(target.Scope().Names()) == 0 {
+		return AdapterSpec{}, fmt.Errorf("no Go declarations in %s", targetDir)
+	}
+
+	checked, err := c.load(consumerDir)
+	if err != nil {
+		return AdapterSpec{}, err
+	}
+	if checked.pkg == nil {
+		return AdapterSpec{}, fmt.Errorf("no Go files in %s", consumerDir)
+	}
+	files, info, consumer := checked.files, checked.info, checked.pkg
+
+	spec := AdapterSpec{Consumer: consumer.Name(), Target: target.Name(), Imports: make(map[string]string)}
+	adapterPath := c.importPath(adapterDir)
+	q := qualifier{pkg: consumer, portPath: adapterPath, info: info, imports: make(map[string]string)}
+	render := func(t types.Type) string {
+		return types.TypeString(t, func(pkg *types.Package) string {
+			if pkg.Path() == adapterPath {
+				return ""
+			}
+			q.imports[pkg.Path()] = pkg.Name()
+			return pkg.Name()
+		})
+	}
+
+	delegates := exportedConcreteTypes(target)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gen.Specs {
+				typeSpec := s.(*ast.TypeSpec)
+				astIface, ok := typeSpec.Type.(*ast.InterfaceType)
+				if !ok || !typeSpec.Name.IsExported() || typeSpec.TypeParams != nil {
+					continue
+				}
+				iface, ok := info.Defs[typeSpec.Name].Type().Underlying().(*types.Interface)
+				if !ok || iface.NumMethods() == 0 || !iface.IsMethodSet() {
+					continue
+				}
+
+				adapter, skipped, err := c.adapter(typeSpec.Name.Name, astIface, iface, target, delegates, q, render)
+				if err != nil {
+					return AdapterSpec{}, err
+				}
+				if skipped != "" {
+					spec.Skipped = append(spec.Skipped, fmt.Sprintf("%s: %s", typeSpec.Name.Name, skipped))
+					continue
+				}
+				spec.Adapters = append(spec.Adapters, adapter)
+			}
+		}
+	}
+
+	if len(spec.Adapters) > 0 {
+		spec.Imports = q.imports
+		spec.Imports[target.Path()] = target.Name()
+		spec.Imports[consumer.Path()] = consumer.Name()
+	}
+	sort.Slice(spec.Adapters, func(i, j int) bool {
+		return spec.Adapters[i].Interface < spec.Adapters[j].Interface
+	})
+	sort.Strings(spec.Skipped)
+	return spec, nil
+}
+
+// adapter matches one consumer interface with the target package. It returns the reason
+// if the interface gets no adapter.
+func (c *Checker) adapter(name string, astIface *ast.InterfaceType, iface *types.Interface, target *types.Package,
+	delegates []*types.Named, q qualifier, render func(types.Type) string) (Adapter, string, error) {
+	for _, typ := range delegates {
+		if types.Implements(typ, iface) || types.Implements(types.NewPointer(typ), iface) {
+			return Adapter{}, fmt.Sprintf("already implemented by %s.%s, pass it directly", target.Name(), typ.Obj().Name()), nil
+		}
+	}
+
+	// Declared methods, to render the signatures as written
+	declared := make(map[string]*ast.FuncType)
+	for _, field := range astIface.Methods.List {
+		if fnType, ok := field.Type.(*ast.FuncType); ok && len(field.Names) == 1 {
+			declared[field.Names[0].Name] = fnType
+		}
+	}
+	if len(declared) != iface.NumMethods() {
+		return Adapter{}, "embeds other interfaces, write its adapter by hand", nil
+	}
+
+	// The type serving most methods becomes the delegate
+	best, bestCount := (*types.Named)(nil), 0
+	for _, typ := range delegates {
+		count := 0
+		for i := 0; i < iface.NumMethods(); i++ {
+			if _, ok := delegation(iface.Method(i), methodOf(typ, iface.Method(i).Name()), render); ok {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = typ, count
+		}
+	}
+
+	adapter := Adapter{Interface: name}
+	if best != nil {
+		adapter.Delegate = best.Obj().Name()
+	}
+	delegated := 0
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		am, err := adapterMethod(method.Name(), declared[method.Name()], q)
+		if err != nil {
+			return Adapter{}, "", fmt.Errorf("%s.%s: %w", name, method.Name(), err)
+		}
+
+		if best != nil {
+			if converts, ok := delegation(method, methodOf(best, method.Name()), render); ok {
+				am.Via, am.Converts = DelegateMethod, converts
+			}
+		}
+		if am.Via == "" {
+			if fn, ok := target.Scope().Lookup(method.Name()).(*types.Func); ok {
+				if converts, ok := delegation(method, fn, render); ok {
+					am.Via, am.Converts = DelegateFunc, converts
+				}
+			}
+		}
+		if am.Via != "" {
+			delegated++
+		}
+		adapter.Methods = append(adapter.Methods, am)
+	}
+	if delegated == 0 {
+		return Adapter{}, fmt.Sprintf("no method or function of %s matches", target.Name()), nil
+	}
+
+	sort.Slice(adapter.Methods, func(i, j int) bool {
+		return adapter.Methods[i].Name < adapter.Methods[j].Name
+	})
+	return adapter, "", nil
+}
+
+// adapterMethod renders the parameters, results and arguments of an interface method,
+// naming unnamed and blank parameters p0, p1, ...
+func adapterMethod(name string, fnType *ast.FuncType, q qualifier) (AdapterMethod, error) {
+	qualified, err := q.funcType(fnType)
+	if err != nil {
+		return AdapterMethod{}, err
+	}
+
+	am := AdapterMethod{Name: name}
+	params := &ast.FieldList{}
+	index := 0
+	for _, field := range qualified.Params.List {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent("_")}
+		}
+		for _, ident := range names {
+			paramName := ident.Name
+			if paramName == "_" {
+				paramName = fmt.Sprintf("p%d", index)
+			}
+			index++
+			params.List = append(params.List, &ast.Field{Names: []*ast.Ident{ast.NewIdent(paramName)}, Type: field.Type})
+			if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+				paramName += "..."
+			}
+			am.Args = append(am.Args, paramName)
+		}
+	}
+
+	results := &ast.FieldList{}
+	if qualified.Results != nil {
+		for _, field := range qualified.Results.List {
+			for range max(len(field.Names), 1) {
+				results.List = append(results.List, &ast.Field{Type: field.Type})
+			}
+		}
+	}
+
+	if am.Params, err = printFuncType(&ast.FuncType{Params: params}); err != nil {
+		return AdapterMethod{}, err
+	}
+	am.Params = strings.TrimPrefix(am.Params, "func")
+	if len(results.List) > 0 {
+		if am.Results, err = printFuncType(&ast.FuncType{Params: &ast.FieldList{}, Results: results}); err != nil {
+			return AdapterMethod{}, err
+		}
+		am.Results = strings.TrimPrefix(am.Results, "func() ")
+	}
+	return am, nil
+}
+
+// delegation reports whether the interface method want can be served by calling have,
+// and for each result the element type to convert a returned slice to (empty if the
+// result is assignable as is)
+func delegation(want *types.Func, have *types.Func, render func(types.Type) string) ([]string, bool) {
+	if have == nil || !have.Exported() {
+		return nil, false
+	}
+	wantSig, haveSig := want.Type().(*types.Signature), have.Type().(*types.Signature)
+	if wantSig.Params().Len() != haveSig.Params().Len() || wantSig.Variadic() != haveSig.Variadic() ||
+		wantSig.Results().Len() != haveSig.Results().Len() {
+		return nil, false
+	}
+	for i := 0; i < wantSig.Params().Len(); i++ {
+		if !types.AssignableTo(wantSig.Params().At(i).Type(), haveSig.Params().At(i).Type()) {
+			return nil, false
+		}
+	}
+
+	converts := make([]string, wantSig.Results().Len())
+	for i := 0; i < wantSig.Results().Len(); i++ {
+		got, wanted := haveSig.Results().At(i).Type(), wantSig.Results().At(i).Type()
+		if types.AssignableTo(got, wanted) {
+			continue
+		}
+		gotSlice, ok1 := got.Underlying().(*types.Slice)
+		wantedSlice, ok2 := wanted.Underlying().(*types.Slice)
+		if !ok1 || !ok2 || !types.AssignableTo(gotSlice.Elem(), wantedSlice.Elem()) {
+			return nil, false
+		}
+		converts[i] = render(wantedSlice.Elem())
+	}
+	return converts, true
+}
+
+// methodOf returns the method of a type or a pointer to it with the given name
+func methodOf(typ *types.Named, name string) *types.Func {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(typ), true, typ.Obj().Pkg(), name)
+	fn, _ := obj.(*types.Func)
+	return fn
+}
+
+// exportedConcreteTypes returns the exported non-generic named types of a package that
+// are not interfaces, sorted by name
+func exportedConcreteTypes(pkg *types.Package) []*types.Named {
+	var result []*types.Named
+	for _, name := range pkg.Scope().Names() {
+		typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok || !typeName.Exported() || typeName.IsAlias() {
+			continue
+		}
+		named, ok := typeName.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+			continue
+		}
+		result = append(result, named)
+	}
+	return result
+}
+
+// printFuncType renders a function type built without positions
+func printFuncType(fnType *ast.FuncType) (string, error) {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), fnType); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,133 @@
+package typecheck_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
+)
+
+func TestAdapterSpec(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "pkg", "app", "app.go"), `package app
+
+import "context"
+
+type Invoice interface{ Total() int }
+
+type InvoiceStore interface {
+	Find(ctx context.Context, id int) (Invoice, error)
+	List(context.Context) ([]Invoice, error)
+	Refund(id int) error
+}
+
+type Taxes interface {
+	Rate(country string, _ ...int) float64
+}
+
+type Mailer interface{ Send(to string) error }
+
+type Closer interface {
+	Invoice
+	Close()
+}
+
+type internalOnly interface{ Total() int }
+`)
+	writeFile(t, filepath.Join(tmpDir, "internal", "billing", "billing.go"), `package billing
+
+import "context"
+
+type Invoice struct{ Amount int }
+
+func (i *Invoice) Total() int { return i.Amount }
+
+type Ledger struct{}
+
+func (l *Ledger) Refund(id int) error { return nil }
+
+type Service struct{}
+
+func (s *Service) Find(ctx context.Context, id int) (*Invoice, error) { return nil, nil }
+func (s *Service) List(ctx context.Context) ([]*Invoice, error)       { return nil, nil }
+func (s *Service) Refund(id string) error                             { return nil }
+
+func Rate(country string, amounts ...int) float64 { return 0 }
+`)
+
+	checker := typecheck.New(tmpDir, "github.com/test/project")
+	spec, err := checker.AdapterSpec("pkg/app", "internal/billing", "pkg/billingadapter")
+	if err != nil {
+		t.Fatalf("AdapterSpec failed: %v", err)
+	}
+
+	if spec.Consumer != "app" || spec.Target != "billing" {
+		t.Errorf("expected consumer app and target billing, got %q and %q", spec.Consumer, spec.Target)
+	}
+
+	var adapters []string
+	for _, adapter := range spec.Adapters {
+		adapters = append(adapters, adapter.Interface+" -> "+adapter.Delegate)
+		for _, method := range adapter.Methods {
+			adapters = append(adapters, "  "+method.Name+method.Params+" "+method.Results+" ["+method.Via+"] "+
+				strings.Join(method.Args, ",")+" "+strings.Join(method.Converts, ","))
+		}
+	}
+	// Service serves more InvoiceStore methods than Ledger, whose Refund is left out
+	// since Service's takes a string
+	expected := []string{
+		"InvoiceStore -> Service",
+		"  Find(ctx context.Context, id int) (app.Invoice, error) [method] ctx,id ,",
+		"  List(p0 context.Context) ([]app.Invoice, error) [method] p0 app.Invoice,",
+		"  Refund(id int) error [] id ",
+		"Taxes -> ",
+		"  Rate(country string, p1 ...int) float64 [func] country,p1... ",
+	}
+	if strings.Join(adapters, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected adapters:\n%s\nexpected:\n%s", strings.Join(adapters, "\n"), strings.Join(expected, "\n"))
+	}
+
+	expectedSkipped := []string{
+		"Closer: embeds other interfaces, write its adapter by hand",
+		"Invoice: already implemented by billing.Invoice, pass it directly",
+		"Mailer: no method or function of billing matches",
+	}
+	if strings.Join(spec.Skipped, "\n") != strings.Join(expectedSkipped, "\n") {
+		t.Errorf("unexpected skipped interfaces:\n%s", strings.Join(spec.Skipped, "\n"))
+	}
+
+	if len(spec.Imports) != 3 || spec.Imports["context"] != "context" ||
+		spec.Imports["github.com/test/project/pkg/app"] != "app" ||
+		spec.Imports["github.com/test/project/internal/billing"] != "billing" {
+		t.Errorf("unexpected imports: %v", spec.Imports)
+	}
+}
+
+func TestAdapterSpec_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "pkg", "app", "app.go"), `package app
+
+type secret struct{}
+
+type Store interface{ Get() secret }
+`)
+	writeFile(t, filepath.Join(tmpDir, "internal", "store", "store.go"), `package store
+
+type Store struct{}
+
+func Get() int { return 0 }
+`)
+
+	checker := typecheck.New(tmpDir, "github.com/test/project")
+	if _, err := checker.AdapterSpec("pkg/app", "internal/store", "pkg/storeadapter"); err == nil || !strings.Contains(err.Error(), "uses unexported secret") {
+		t.Errorf("expected an error for the unexported type, got %v", err)
+	}
+	for _, dirs := range [][2]string{{"pkg/missing", "internal/store"}, {"pkg/app", "internal/missing"}} {
+		if _, err := checker.AdapterSpec(dirs[0], dirs[1], "pkg/storeadapter"); err == nil {
+			t.Errorf("expected an error for %s and %s", dirs[0], dirs[1])
+		}
+	}
+}
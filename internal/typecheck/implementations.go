@@ -0,0 +1,196 @@
+package typecheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+)
+
+// Implementation is a concrete type satisfying an interface declared in another package
+type Implementation struct {
+	File      string // Path relative to project root
+	Line      int    // Line of the type name
+	Column    int    // Column of the type name
+	Type      string // Implementing type as "dir.Type"
+	Interface string // Implemented interface as "dir.Name"
+	Asserted  bool   // Whether the package of the type asserts it at compile time
+}
+
+// GetFile implements validator.PortImplementation interface
+func (i Implementation) GetFile() string {
+	return i.File
+}
+
+// GetLine implements validator.PortImplementation interface
+func (i Implementation) GetLine() int {
+	return i.Line
+}
+
+// GetColumn implements validator.PortImplementation interface
+func (i Implementation) GetColumn() int {
+	return i.Column
+}
+
+// GetType implements validator.PortImplementation interface
+func (i Implementation) GetType() string {
+	return i.Type
+}
+
+// GetInterface implements validator.PortImplementation interface
+func (i Implementation) GetInterface() string {
+	return i.Interface
+}
+
+// IsAsserted implements validator.PortImplementation interface
+func (i Implementation) IsAsserted() bool {
+	return i.Asserted
+}
+
+// Implementations type-checks the packages in interfaceDirs and typeDirs (relative to
+// the project root) and returns, for each package-level concrete type of typeDirs, the
+// interfaces of interfaceDirs it satisfies by value or by pointer, sorted by position.
+// An implementation is asserted when its package declares a package-level blank variable
+// of the interface type initialized with a value of the type, as in
+// "var _ ports.Store = (*Store)(nil)" or "var _ ports.Store = Store{}". Interfaces are
+// filtered like in Interfaces, and a type never implements an interface of its own
+// package.
+//
+// Like ExportedSignatures, this is best-effort: types from external modules cannot be
+// resolved, so methods mentioning them only match methods mentioning them the same way.
+func (c *Checker) Implementations(interfaceDirs, typeDirs []string) ([]Implementation, error) {
+	type port struct {
+		pkgPath string
+		name    string
+		iface   *types.Interface
+	}
+	var ports []port
+	for _, dir := range interfaceDirs {
+		pkg, err := c.Import(c.importPath(dir))
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range pkg.Scope().Names() {
+			typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+			if !ok || typeName.IsAlias() {
+				continue
+			}
+			named, ok := typeName.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			iface, ok := named.Underlying().(*types.Interface)
+			if !ok || iface.NumMethods() == 0 || !iface.IsMethodSet() {
+				continue
+			}
+			ports = append(ports, port{pkgPath: pkg.Path(), name: c.namedKey(named), iface: iface})
+		}
+	}
+
+	var implementations []Implementation
+	for _, dir := range typeDirs {
+		checked, err := c.load(dir)
+		if err != nil {
+			return nil, err
+		}
+		if checked.pkg == nil {
+			continue
+		}
+
+		pkg := checked.pkg
+		asserted := c.assertions(checked.files, checked.info)
+
+		for _, name := range pkg.Scope().Names() {
+			typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+			if !ok || typeName.IsAlias() {
+				continue
+			}
+			named, ok := typeName.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+				continue
+			}
+
+			typeKey := c.namedKey(named)
+			pos := c.fset.Position(typeName.Pos())
+			relPath, err := filepath.Rel(c.projectPath, pos.Filename)
+			if err != nil {
+				relPath = pos.Filename
+			}
+			for _, p := range ports {
+				if p.pkgPath == pkg.Path() {
+					continue
+				}
+				if !types.Implements(named, p.iface) && !types.Implements(types.NewPointer(named), p.iface) {
+					continue
+				}
+				implementations = append(implementations, Implementation{
+					File:      filepath.ToSlash(relPath),
+					Line:      pos.Line,
+					Column:    pos.Column,
+					Type:      typeKey,
+					Interface: p.name,
+					Asserted:  asserted[[2]string{p.name, typeKey}],
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(implementations, func(i, j int) bool {
+		a, b := implementations[i], implementations[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Interface < b.Interface
+	})
+
+	return implementations, nil
+}
+
+// assertions returns the interface and type pairs asserted by package-level blank
+// variables, as "dir.Name" keys
+func (c *Checker) assertions(files []*ast.File, info *types.Info) map[[2]string]bool {
+	asserted := make(map[[2]string]bool)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, s := range gen.Specs {
+				spec := s.(*ast.ValueSpec)
+				if spec.Type == nil {
+					continue
+				}
+				iface, ok := types.Unalias(info.Types[spec.Type].Type).(*types.Named)
+				if !ok || !types.IsInterface(iface) {
+					continue
+				}
+				for i, name := range spec.Names {
+					if name.Name != "_" || i >= len(spec.Values) {
+						continue
+					}
+					valueType := info.Types[spec.Values[i]].Type
+					if ptr, ok := valueType.(*types.Pointer); ok {
+						valueType = ptr.Elem()
+					}
+					if named, ok := types.Unalias(valueType).(*types.Named); ok {
+						asserted[[2]string{c.namedKey(iface), c.namedKey(named)}] = true
+					}
+				}
+			}
+		}
+	}
+	return asserted
+}
+
+// namedKey identifies a named type of a package as "dir.Name"
+func (c *Checker) namedKey(named *types.Named) string {
+	if named.Obj().Pkg() == nil {
+		return named.Obj().Name()
+	}
+	return c.packageDir(named.Obj().Pkg().Path()) + "." + named.Obj().Name()
+}
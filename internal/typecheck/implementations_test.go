@@ -0,0 +1,68 @@
+package typecheck_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
+)
+
+func TestImplementations(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "internal", "ports", "ports.go"), `package ports
+
+type Store interface{ Save(id string) error }
+
+type Notifier interface{ Notify(msg string) }
+
+type Empty interface{}
+
+type Local struct{}
+
+func (Local) Notify(msg string) {}
+`)
+	writeFile(t, filepath.Join(tmpDir, "internal", "adapters", "store", "store.go"), `package store
+
+import p "github.com/test/project/internal/ports"
+
+var (
+	_ p.Store    = (*Repo)(nil)
+	_ p.Notifier = Mailer{}
+)
+
+type Repo struct{}
+
+func (r *Repo) Save(id string) error { return nil }
+func (r *Repo) Notify(msg string)    {}
+
+type Mailer struct{}
+
+func (Mailer) Notify(msg string) {}
+
+type Unrelated struct{}
+`)
+
+	checker := typecheck.New(tmpDir, "github.com/test/project")
+	implementations, err := checker.Implementations([]string{"internal/ports"}, []string{"internal/adapters/store", "internal/ports"})
+	if err != nil {
+		t.Fatalf("Implementations failed: %v", err)
+	}
+
+	var got []string
+	for _, impl := range implementations {
+		got = append(got, fmt.Sprintf("%s:%d %s %s %v", impl.File, impl.Line, impl.Type, impl.Interface, impl.Asserted))
+	}
+	// Repo is asserted as a Store but not as a Notifier; ports.Local implements an
+	// interface of its own package
+	expected := []string{
+		"internal/adapters/store/store.go:10 internal/adapters/store.Repo internal/ports.Notifier false",
+		"internal/adapters/store/store.go:10 internal/adapters/store.Repo internal/ports.Store true",
+		"internal/adapters/store/store.go:15 internal/adapters/store.Mailer internal/ports.Notifier true",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected implementations:\n%s\nexpected:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+}
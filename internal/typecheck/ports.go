@@ -0,0 +1,279 @@
+package typecheck
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// PortSpec describes an interface covering the methods of a concrete type that code
+// outside its package calls
+type PortSpec struct {
+	Package string            // Name of the package declaring the type
+	Methods []PortMethod      // Methods called by other packages, sorted by name
+	Imports map[string]string // Import path -> package name, for the packages the signatures refer to
+}
+
+// PortMethod is a method of a concrete type called from other packages
+type PortMethod struct {
+	Name      string
+	Signature string   // Parameters and results, e.g. "(ctx context.Context, id int) (*postgres.User, error)"
+	Callers   []string // Directories of the packages calling the method, sorted
+}
+
+// PortSpec type-checks the package in dir and the packages in callerDirs (relative to the
+// project root) and returns the exported methods of the type typeName that the callers
+// call, directly or through method values. Signatures are written for an interface
+// declared in the package portDir: types of the package in dir are qualified with its
+// name and types of the package in portDir are not.
+//
+// Like ExportedSignatures, this is best-effort: calls through values whose type comes
+// from an external module cannot be resolved.
+func (c *Checker) PortSpec(dir, typeName, portDir string, callerDirs []string) (PortSpec, error) {
+	checked, err := c.load(dir)
+	if err != nil {
+		return PortSpec{}, err
+	}
+	if checked.pkg == nil {
+		return PortSpec{}, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	pkgPath := c.importPath(dir)
+	files, info, pkg := checked.files, checked.info, checked.pkg
+
+	typeObj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return PortSpec{}, fmt.Errorf("type %s not found in %s", typeName, dir)
+	}
+	named, ok := typeObj.Type().(*types.Named)
+	if !ok || types.IsInterface(named) {
+		return PortSpec{}, fmt.Errorf("%s.%s is not a concrete named type", dir, typeName)
+	}
+	if named.TypeParams().Len() > 0 {
+		return PortSpec{}, fmt.Errorf("%s.%s is generic, which is not supported", dir, typeName)
+	}
+
+	callers, err := c.methodCallers(pkgPath, typeName, callerDirs)
+	if err != nil {
+		return PortSpec{}, err
+	}
+
+	spec := PortSpec{Package: pkg.Name(), Imports: make(map[string]string)}
+	q := qualifier{pkg: pkg, portPath: c.importPath(portDir), info: info, imports: spec.Imports}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || receiverTypeName(fn.Recv.List[0].Type) != typeName {
+				continue
+			}
+			methodCallers, called := callers[fn.Name.Name]
+			if !called {
+				continue
+			}
+
+			fnType, err := q.funcType(fn.Type)
+			if err != nil {
+				return PortSpec{}, fmt.Errorf("%s.%s: %w", typeName, fn.Name.Name, err)
+			}
+			var buf bytes.Buffer
+			if err := printer.Fprint(&buf, token.NewFileSet(), fnType); err != nil {
+				return PortSpec{}, err
+			}
+			spec.Methods = append(spec.Methods, PortMethod{
+				Name:      fn.Name.Name,
+				Signature: buf.String()[len("func"):],
+				Callers:   methodCallers,
+			})
+		}
+	}
+
+	sort.Slice(spec.Methods, func(i, j int) bool {
+		return spec.Methods[i].Name < spec.Methods[j].Name
+	})
+	return spec, nil
+}
+
+// methodCallers returns the methods of a named type called from the packages in
+// callerDirs, each with the sorted directories calling it
+func (c *Checker) methodCallers(pkgPath, typeName string, callerDirs []string) (map[string][]string, error) {
+	callerSets := make(map[string]map[string]bool)
+	for _, callerDir := range callerDirs {
+		if c.importPath(callerDir) == pkgPath {
+			continue
+		}
+		checked, err := c.load(callerDir)
+		if err != nil {
+			return nil, err
+		}
+		if checked.pkg == nil {
+			continue
+		}
+
+		for _, sel := range checked.info.Selections {
+			fn, ok := sel.Obj().(*types.Func)
+			if !ok {
+				continue
+			}
+			recv := fn.Type().(*types.Signature).Recv()
+			if recv == nil {
+				continue
+			}
+			recvType := recv.Type()
+			if ptr, ok := recvType.(*types.Pointer); ok {
+				recvType = ptr.Elem()
+			}
+			named, ok := recvType.(*types.Named)
+			if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != pkgPath || named.Obj().Name() != typeName {
+				continue
+			}
+			if callerSets[fn.Name()] == nil {
+				callerSets[fn.Name()] = make(map[string]bool)
+			}
+			callerSets[fn.Name()][callerDir] = true
+		}
+	}
+
+	callers := make(map[string][]string, len(callerSets))
+	for method, set := range callerSets {
+		for dir := range set {
+			callers[method] = append(callers[method], dir)
+		}
+		sort.Strings(callers[method])
+	}
+	return callers, nil
+}
+
+// qualifier rewrites type expressions of a package for use in another package
+type qualifier struct {
+	pkg      *types.Package // Package the expressions are written in
+	portPath string         // Import path of the package the expressions are written for
+	info     *types.Info
+	imports  map[string]string // Collects import path -> name of the packages referred to
+}
+
+// funcType returns a copy of a function type with qualified parameter and result types
+func (q qualifier) funcType(fnType *ast.FuncType) (*ast.FuncType, error) {
+	params, err := q.fieldList(fnType.Params)
+	if err != nil {
+		return nil, err
+	}
+	results, err := q.fieldList(fnType.Results)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.FuncType{Params: params, Results: results}, nil
+}
+
+// fieldList returns a copy of a parameter or result list with qualified types
+func (q qualifier) fieldList(fields *ast.FieldList) (*ast.FieldList, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	result := &ast.FieldList{}
+	for _, field := range fields.List {
+		typ, err := q.expr(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		copied := &ast.Field{Type: typ}
+		for _, name := range field.Names {
+			copied.Names = append(copied.Names, ast.NewIdent(name.Name))
+		}
+		result.List = append(result.List, copied)
+	}
+	return result, nil
+}
+
+// expr returns a copy of a type expression in which the package-level symbols of q.pkg
+// are qualified with its name and those of the port package are not
+func (q qualifier) expr(expr ast.Expr) (ast.Expr, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		obj := q.info.Uses[e]
+		if obj == nil || obj.Pkg() != q.pkg || obj.Parent() != q.pkg.Scope() {
+			return ast.NewIdent(e.Name), nil // Predeclared or not resolved
+		}
+		if !obj.Exported() {
+			return nil, fmt.Errorf("uses unexported %s", e.Name)
+		}
+		if q.pkg.Path() == q.portPath {
+			return ast.NewIdent(e.Name), nil
+		}
+		q.imports[q.pkg.Path()] = q.pkg.Name()
+		return &ast.SelectorExpr{X: ast.NewIdent(q.pkg.Name()), Sel: ast.NewIdent(e.Name)}, nil
+	case *ast.SelectorExpr:
+		ident, ok := e.X.(*ast.Ident)
+		if !ok {
+			return e, nil
+		}
+		pkgName, ok := q.info.Uses[ident].(*types.PkgName)
+		if !ok {
+			return e, nil
+		}
+		if pkgName.Imported().Path() == q.portPath {
+			return ast.NewIdent(e.Sel.Name), nil
+		}
+		q.imports[pkgName.Imported().Path()] = ident.Name
+		return &ast.SelectorExpr{X: ast.NewIdent(ident.Name), Sel: ast.NewIdent(e.Sel.Name)}, nil
+	case *ast.StarExpr:
+		x, err := q.expr(e.X)
+		return &ast.StarExpr{X: x}, err
+	case *ast.ArrayType:
+		elt, err := q.expr(e.Elt)
+		if err != nil {
+			return nil, err
+		}
+		var length ast.Expr
+		if e.Len != nil {
+			if length, err = q.expr(e.Len); err != nil {
+				return nil, err
+			}
+		}
+		return &ast.ArrayType{Len: length, Elt: elt}, nil
+	case *ast.MapType:
+		key, err := q.expr(e.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := q.expr(e.Value)
+		return &ast.MapType{Key: key, Value: value}, err
+	case *ast.ChanType:
+		value, err := q.expr(e.Value)
+		return &ast.ChanType{Dir: e.Dir, Value: value}, err
+	case *ast.Ellipsis:
+		elt, err := q.expr(e.Elt)
+		return &ast.Ellipsis{Elt: elt}, err
+	case *ast.FuncType:
+		return q.funcType(e)
+	case *ast.ParenExpr:
+		x, err := q.expr(e.X)
+		return &ast.ParenExpr{X: x}, err
+	case *ast.IndexExpr:
+		x, err := q.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		index, err := q.expr(e.Index)
+		return &ast.IndexExpr{X: x, Index: index}, err
+	case *ast.IndexListExpr:
+		x, err := q.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		copied := &ast.IndexListExpr{X: x}
+		for _, index := range e.Indices {
+			qualified, err := q.expr(index)
+			if err != nil {
+				return nil, err
+			}
+			copied.Indices = append(copied.Indices, qualified)
+		}
+		return copied, nil
+	}
+	// Literals and inline struct or interface types are kept as written
+	return expr, nil
+}
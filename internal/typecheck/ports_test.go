@@ -0,0 +1,128 @@
+package typecheck_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
+)
+
+func TestPortSpec_UsedMethods(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "internal", "domain", "user.go"), `package domain
+
+type User struct{ Name string }
+`)
+	writeFile(t, filepath.Join(tmpDir, "internal", "infra", "postgres", "repo.go"), `package postgres
+
+import (
+	"context"
+
+	dom "github.com/test/project/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+type Row struct{}
+
+type UserRepo struct{ conn *pgx.Conn }
+
+func (r *UserRepo) Find(ctx context.Context, ids ...int) ([]*dom.User, error) { return nil, nil }
+func (r *UserRepo) Rows(filter map[string]Row) (chan<- Row, error)              { return nil, nil }
+func (r UserRepo) Close() error                                                 { return nil }
+func (r *UserRepo) Unused()                                                     {}
+func (r *UserRepo) internal()                                                   {}
+`)
+	writeFile(t, filepath.Join(tmpDir, "internal", "app", "service.go"), `package app
+
+import (
+	"context"
+
+	"github.com/test/project/internal/infra/postgres"
+)
+
+type Service struct{ Repo *postgres.UserRepo }
+
+func (s *Service) Run(ctx context.Context) {
+	s.Repo.Find(ctx, 1)
+	closer := s.Repo.Close
+	_ = closer
+}
+`)
+	writeFile(t, filepath.Join(tmpDir, "internal", "jobs", "jobs.go"), `package jobs
+
+import "github.com/test/project/internal/app"
+
+func Sync(s *app.Service) {
+	s.Repo.Rows(nil)
+	s.Repo.Find(nil)
+}
+`)
+
+	checker := typecheck.New(tmpDir, "github.com/test/project")
+	spec, err := checker.PortSpec("internal/infra/postgres", "UserRepo", "internal/domain",
+		[]string{"internal/app", "internal/domain", "internal/infra/postgres", "internal/jobs"})
+	if err != nil {
+		t.Fatalf("PortSpec failed: %v", err)
+	}
+
+	if spec.Package != "postgres" {
+		t.Errorf("expected package postgres, got %q", spec.Package)
+	}
+
+	var methods []string
+	for _, method := range spec.Methods {
+		methods = append(methods, method.Name+method.Signature+" "+strings.Join(method.Callers, ","))
+	}
+	expected := []string{
+		"Close() error internal/app",
+		"Find(ctx context.Context, ids ...int) ([]*User, error) internal/app,internal/jobs",
+		"Rows(filter map[string]postgres.Row) (chan<- postgres.Row, error) internal/jobs",
+	}
+	if strings.Join(methods, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected methods:\n%s\nexpected:\n%s", strings.Join(methods, "\n"), strings.Join(expected, "\n"))
+	}
+
+	// Types of the port package are not qualified, so domain is not imported
+	if len(spec.Imports) != 2 || spec.Imports["context"] != "context" || spec.Imports["github.com/test/project/internal/infra/postgres"] != "postgres" {
+		t.Errorf("unexpected imports: %v", spec.Imports)
+	}
+}
+
+func TestPortSpec_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "internal", "infra", "repo.go"), `package infra
+
+type Repo interface{ Find() }
+
+type secret struct{}
+
+type Store struct{}
+
+func (s *Store) Get() secret { return secret{} }
+`)
+	writeFile(t, filepath.Join(tmpDir, "internal", "app", "app.go"), `package app
+
+import "github.com/test/project/internal/infra"
+
+func Run(s *infra.Store) { s.Get() }
+`)
+
+	checker := typecheck.New(tmpDir, "github.com/test/project")
+	tests := []struct {
+		typeName string
+		wantErr  string
+	}{
+		{"Missing", "not found"},
+		{"Repo", "not a concrete named type"},
+		{"Store", "uses unexported secret"},
+	}
+	for _, tt := range tests {
+		_, err := checker.PortSpec("internal/infra", tt.typeName, "internal/domain", []string{"internal/app"})
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.typeName, tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,572 @@
+package typecheck
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TypeRef identifies a named type by its package path and name
+type TypeRef struct {
+	PackagePath string
+	Name        string
+}
+
+// GetPackagePath implements validator.SignatureType interface
+func (t TypeRef) GetPackagePath() string {
+	return t.PackagePath
+}
+
+// GetName implements validator.SignatureType interface
+func (t TypeRef) GetName() string {
+	return t.Name
+}
+
+// Signature describes the types mentioned in an exported function or method signature
+type Signature struct {
+	File   string    // Path relative to project root
+	Line   int       // Line of the declaration
+	Column int       // Column of the declaration
+	Name   string    // Function name (methods as "Type.Method")
+	Types  []TypeRef // Named types in parameters, results and type parameters (aliases resolved)
+}
+
+// GetFile implements validator.ExportedSignature interface
+func (s Signature) GetFile() string {
+	return s.File
+}
+
+// GetLine implements validator.ExportedSignature interface
+func (s Signature) GetLine() int {
+	return s.Line
+}
+
+// GetColumn implements validator.ExportedSignature interface
+func (s Signature) GetColumn() int {
+	return s.Column
+}
+
+// GetName implements validator.ExportedSignature interface
+func (s Signature) GetName() string {
+	return s.Name
+}
+
+// GetTypes returns the named types mentioned in the signature
+func (s Signature) GetTypes() []TypeRef {
+	return s.Types
+}
+
+// Interface is a package-level interface type declared in the scanned code
+type Interface struct {
+	File         string   // Path relative to project root
+	Line         int      // Line of the type name
+	Column       int      // Column of the type name
+	Name         string   // Interface name
+	Implementers []string // Concrete types satisfying it as "dir.Type", or "*dir.Type" when only the pointer does (stdlib types by import path)
+}
+
+// GetFile implements validator.InterfaceDecl interface
+func (i Interface) GetFile() string {
+	return i.File
+}
+
+// GetLine implements validator.InterfaceDecl interface
+func (i Interface) GetLine() int {
+	return i.Line
+}
+
+// GetColumn implements validator.InterfaceDecl interface
+func (i Interface) GetColumn() int {
+	return i.Column
+}
+
+// GetName implements validator.InterfaceDecl interface
+func (i Interface) GetName() string {
+	return i.Name
+}
+
+// IsImplemented implements validator.InterfaceDecl interface
+func (i Interface) IsImplemented() bool {
+	return len(i.Implementers) > 0
+}
+
+// GetImplementers implements validator.InterfaceDecl interface
+func (i Interface) GetImplementers() []string {
+	return i.Implementers
+}
+
+// Checker type-checks packages of a module from source. Every local package is parsed
+// and checked once, so one checker can serve all queries of a run.
+type Checker struct {
+	projectPath string
+	module      string
+	fset        *token.FileSet
+	std         types.Importer
+	packages    map[string]*types.Package
+	local       map[string]*checkedPackage // Import path -> local package checked from source
+	fsys        fs.FS                      // Project files, if not read from disk
+}
+
+// checkedPackage is a local package type-checked from source
+type checkedPackage struct {
+	pkg   *types.Package // nil if the directory has no Go files or failed to parse
+	files []*ast.File
+	info  *types.Info
+	err   error // Parse error
+}
+
+// New creates a checker for the module rooted at projectPath
+func New(projectPath, module string) *Checker {
+	return &Checker{
+		projectPath: projectPath,
+		module:      module,
+		fset:        token.NewFileSet(),
+		std:         importer.Default(),
+		packages:    make(map[string]*types.Package),
+		local:       make(map[string]*checkedPackage),
+	}
+}
+
+// ExportedSignatures type-checks the packages in dirs (relative to the project root) and
+// returns the signatures of their exported functions and methods.
+//
+// Type checking is best-effort: external modules are not loaded, so references to them
+// are resolved through the import declaration only and type errors are ignored.
+func (c *Checker) ExportedSignatures(dirs []string) ([]Signature, error) {
+	var signatures []Signature
+
+	for _, dir := range dirs {
+		checked, err := c.load(dir)
+		if err != nil {
+			return nil, err
+		}
+		if checked.pkg == nil {
+			continue
+		}
+
+		for _, file := range checked.files {
+			signatures = append(signatures, c.fileSignatures(file, checked.info)...)
+		}
+	}
+
+	sort.SliceStable(signatures, func(i, j int) bool {
+		if signatures[i].File != signatures[j].File {
+			return signatures[i].File < signatures[j].File
+		}
+		return signatures[i].Line < signatures[j].Line
+	})
+
+	return signatures, nil
+}
+
+// Interfaces type-checks the packages in dirs and implementationDirs (relative to the
+// project root) and returns the package-level interfaces declared in dirs, each with the
+// concrete package-level types of the loaded packages that satisfy it, sorted. Loaded
+// packages are those of implementationDirs and the standard library packages they
+// import. Empty interfaces, type constraints and generic interfaces are skipped.
+//
+// Like ExportedSignatures, this is best-effort: types from external modules cannot be
+// resolved, so methods mentioning them only match methods mentioning them the same way.
+func (c *Checker) Interfaces(dirs, implementationDirs []string) ([]Interface, error) {
+	for _, dir := range append(append([]string(nil), dirs...), implementationDirs...) {
+		if _, err := c.Import(c.importPath(dir)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Concrete types that may implement the interfaces, in a stable order
+	paths := make([]string, 0, len(c.packages))
+	for path := range c.packages {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var concrete []*types.Named
+	for _, path := range paths {
+		scope := c.packages[path].Scope()
+		for _, name := range scope.Names() {
+			typeName, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || typeName.IsAlias() {
+				continue
+			}
+			named, ok := typeName.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+				continue
+			}
+			concrete = append(concrete, named)
+		}
+	}
+
+	var interfaces []Interface
+	for _, dir := range dirs {
+		scope := c.packages[c.importPath(dir)].Scope()
+		for _, name := range scope.Names() {
+			typeName, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || typeName.IsAlias() {
+				continue
+			}
+			named, ok := typeName.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			iface, ok := named.Underlying().(*types.Interface)
+			if !ok || iface.NumMethods() == 0 || !iface.IsMethodSet() {
+				continue
+			}
+
+			pos := c.fset.Position(typeName.Pos())
+			relPath, err := filepath.Rel(c.projectPath, pos.Filename)
+			if err != nil {
+				relPath = pos.Filename
+			}
+			interfaces = append(interfaces, Interface{
+				File:         filepath.ToSlash(relPath),
+				Line:         pos.Line,
+				Column:       pos.Column,
+				Name:         name,
+				Implementers: c.implementers(iface, concrete),
+			})
+		}
+	}
+
+	sort.SliceStable(interfaces, func(i, j int) bool {
+		if interfaces[i].File != interfaces[j].File {
+			return interfaces[i].File < interfaces[j].File
+		}
+		return interfaces[i].Line < interfaces[j].Line
+	})
+
+	return interfaces, nil
+}
+
+// implementers returns the concrete types whose values, or pointers, satisfy iface
+func (c *Checker) implementers(iface *types.Interface, concrete []*types.Named) []string {
+	var result []string
+	for _, typ := range concrete {
+		pointer := ""
+		if !types.Implements(typ, iface) {
+			if !types.Implements(types.NewPointer(typ), iface) {
+				continue
+			}
+			pointer = "*"
+		}
+		result = append(result, pointer+c.packageDir(typ.Obj().Pkg().Path())+"."+typ.Obj().Name())
+	}
+	return result
+}
+
+// packageDir returns the directory of a local package relative to the project root, or
+// the import path of any other package
+func (c *Checker) packageDir(path string) string {
+	if dir, ok := strings.CutPrefix(path, c.module+"/"); ok {
+		return dir
+	}
+	return path
+}
+
+// Import implements types.Importer interface.
+// Local packages are type-checked from source, standard library packages are loaded
+// from export data and everything else is replaced with an empty placeholder package.
+func (c *Checker) Import(path string) (*types.Package, error) {
+	if pkg, ok := c.packages[path]; ok {
+		return pkg, nil
+	}
+
+	if path == c.module || strings.HasPrefix(path, c.module+"/") {
+		// Packages that fail to parse are imported as their placeholder
+		_, _ = c.load(strings.TrimPrefix(strings.TrimPrefix(path, c.module), "/"))
+		return c.packages[path], nil
+	}
+
+	if isStdLib(path) {
+		if pkg, err := c.std.Import(path); err == nil {
+			c.packages[path] = pkg
+			return pkg, nil
+		}
+	}
+
+	pkg := types.NewPackage(path, packageNameFromPath(path))
+	pkg.MarkComplete()
+	c.packages[path] = pkg
+	return pkg, nil
+}
+
+// load parses and type-checks the local package in dir (relative to the project root)
+// the first time it is needed and returns it, with the parse error if any
+func (c *Checker) load(dir string) (*checkedPackage, error) {
+	path := c.importPath(dir)
+	if checked, ok := c.local[path]; ok {
+		return checked, checked.err
+	}
+
+	// Register a placeholder first to break import cycles
+	checked := &checkedPackage{}
+	c.local[path] = checked
+	c.packages[path] = types.NewPackage(path, packageNameFromPath(path))
+
+	files, err := c.parseDir(dir)
+	if err != nil {
+		checked.err = err
+		return checked, err
+	}
+	if len(files) == 0 {
+		return checked, nil
+	}
+
+	checked.files = files
+	checked.info = &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	checked.pkg = c.check(path, files, checked.info)
+	c.packages[path] = checked.pkg
+	return checked, nil
+}
+
+// importPath returns the import path of a package directory relative to the project root
+func (c *Checker) importPath(dir string) string {
+	dir = filepath.ToSlash(filepath.Clean(dir))
+	if dir == "." {
+		return c.module
+	}
+	return c.module + "/" + dir
+}
+
+// check type-checks files as package path, ignoring type errors
+func (c *Checker) check(path string, files []*ast.File, info *types.Info) *types.Package {
+	conf := types.Config{
+		Importer: c,
+		Error:    func(error) {}, // Collect as much information as possible
+	}
+	pkg, _ := conf.Check(path, c.fset, files, info)
+	return pkg
+}
+
+// SetFS makes the checker read the project from fsys, rooted at the project directory,
+// instead of from disk (nil), e.g. to check a simulated change. Positions are still
+// reported below the project path. Call it before checking any package.
+func (c *Checker) SetFS(fsys fs.FS) {
+	c.fsys = fsys
+}
+
+// fsName returns the name in c.fsys of a path below the project path
+func (c *Checker) fsName(path string) string {
+	rel, err := filepath.Rel(c.projectPath, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// buildContext returns the build context reading the project from wherever it is
+func (c *Checker) buildContext() *build.Context {
+	ctxt := build.Default
+	if c.fsys == nil {
+		return &ctxt
+	}
+	ctxt.IsDir = func(path string) bool {
+		info, err := fs.Stat(c.fsys, c.fsName(path))
+		return err == nil && info.IsDir()
+	}
+	ctxt.ReadDir = func(dir string) ([]fs.FileInfo, error) {
+		entries, err := fs.ReadDir(c.fsys, c.fsName(dir))
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]fs.FileInfo, 0, len(entries))
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, info)
+		}
+		return infos, nil
+	}
+	ctxt.OpenFile = func(path string) (io.ReadCloser, error) {
+		return c.fsys.Open(c.fsName(path))
+	}
+	return &ctxt
+}
+
+// parseDir parses the non-test Go files of a package directory that match the build context
+func (c *Checker) parseDir(dir string) ([]*ast.File, error) {
+	fullPath := filepath.Join(c.projectPath, dir)
+
+	buildPkg, err := c.buildContext().ImportDir(fullPath, 0)
+	if err != nil {
+		if _, ok := err.(*build.NoGoError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		filePath := filepath.Join(fullPath, name)
+		var src any // nil: read by the parser from disk
+		if c.fsys != nil {
+			content, err := fs.ReadFile(c.fsys, c.fsName(filePath))
+			if err != nil {
+				return nil, err
+			}
+			src = content
+		}
+		file, err := parser.ParseFile(c.fset, filePath, src, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// fileSignatures extracts signatures of exported functions and methods declared in file
+func (c *Checker) fileSignatures(file *ast.File, info *types.Info) []Signature {
+	var signatures []Signature
+
+	pos := c.fset.Position(file.Pos())
+	relPath, err := filepath.Rel(c.projectPath, pos.Filename)
+	if err != nil {
+		relPath = pos.Filename
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || !fn.Name.IsExported() {
+			continue
+		}
+
+		name := fn.Name.Name
+		if fn.Recv != nil && len(fn.Recv.List) > 0 {
+			recvName := receiverTypeName(fn.Recv.List[0].Type)
+			if !ast.IsExported(recvName) {
+				continue
+			}
+			name = recvName + "." + name
+		}
+
+		pos := c.fset.Position(fn.Pos())
+		signatures = append(signatures, Signature{
+			File:   relPath,
+			Line:   pos.Line,
+			Column: pos.Column,
+			Name:   name,
+			Types:  collectTypeRefs(fn.Type, info),
+		})
+	}
+
+	return signatures
+}
+
+// collectTypeRefs returns the named types referenced by a function type expression
+func collectTypeRefs(fnType *ast.FuncType, info *types.Info) []TypeRef {
+	seen := make(map[TypeRef]bool)
+	var refs []TypeRef
+
+	add := func(ref TypeRef) {
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	ast.Inspect(fnType, func(n ast.Node) bool {
+		switch e := n.(type) {
+		case *ast.SelectorExpr:
+			ident, ok := e.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			pkgName, ok := info.Uses[ident].(*types.PkgName)
+			if !ok {
+				return true
+			}
+			// The import is known even when the package itself could not be loaded
+			add(TypeRef{PackagePath: pkgName.Imported().Path(), Name: e.Sel.Name})
+			if obj, ok := info.Uses[e.Sel].(*types.TypeName); ok {
+				collectFromType(obj.Type(), add)
+			}
+			return false
+
+		case *ast.Ident:
+			if obj, ok := info.Uses[e].(*types.TypeName); ok {
+				collectFromType(obj.Type(), add)
+			}
+		}
+		return true
+	})
+
+	return refs
+}
+
+// collectFromType records the named types making up t, following aliases.
+// Underlying types of named types are not visited: wrapping a type hides it.
+func collectFromType(t types.Type, add func(TypeRef)) {
+	switch typ := t.(type) {
+	case *types.Alias:
+		collectFromType(types.Unalias(typ), add)
+	case *types.Named:
+		if obj := typ.Obj(); obj.Pkg() != nil {
+			add(TypeRef{PackagePath: obj.Pkg().Path(), Name: obj.Name()})
+		}
+		for i := 0; i < typ.TypeArgs().Len(); i++ {
+			collectFromType(typ.TypeArgs().At(i), add)
+		}
+	case *types.Pointer:
+		collectFromType(typ.Elem(), add)
+	case *types.Slice:
+		collectFromType(typ.Elem(), add)
+	case *types.Array:
+		collectFromType(typ.Elem(), add)
+	case *types.Map:
+		collectFromType(typ.Key(), add)
+		collectFromType(typ.Elem(), add)
+	case *types.Chan:
+		collectFromType(typ.Elem(), add)
+	}
+}
+
+// receiverTypeName returns the base type name of a method receiver
+func receiverTypeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(e.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(e.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+// packageNameFromPath guesses a package name from its import path
+func packageNameFromPath(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	// Major version suffixes (e.g., github.com/foo/bar/v2) are not part of the name
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = parts[len(parts)-2]
+	}
+	name = strings.SplitN(name, ".", 2)[0]
+	return strings.TrimPrefix(strings.TrimSuffix(name, "-go"), "go-")
+}
+
+// isStdLib checks if an import path belongs to the standard library
+func isStdLib(path string) bool {
+	firstPart := strings.SplitN(path, "/", 2)[0]
+	return !strings.Contains(firstPart, ".")
+}
@@ -0,0 +1,295 @@
+package typecheck_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func hasType(sig typecheck.Signature, pkgPath, name string) bool {
+	for _, ref := range sig.Types {
+		if ref.PackagePath == pkgPath && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExportedSignatures_ResolvesTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "internal", "infra", "db.go"), `package infra
+
+type DB struct{}
+`)
+
+	writeFile(t, filepath.Join(tmpDir, "internal", "domain", "order.go"), `package domain
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/test/project/internal/infra"
+)
+
+// Conn hides the infra type behind an alias
+type Conn = infra.DB
+
+type Order struct{}
+
+func Save(ctx context.Context, tx pgx.Tx, o *Order) error {
+	return nil
+}
+
+func (o *Order) Store(c *Conn) []Order {
+	return nil
+}
+
+func (o *Order) internalUse() {
+	var _ infra.DB
+}
+
+func load(tx pgx.Tx) {}
+
+type repo struct{}
+
+func (r repo) Exported(c Conn) {}
+`)
+
+	checker := typecheck.New(tmpDir, "github.com/test/project")
+	signatures, err := checker.ExportedSignatures([]string{"internal/domain"})
+	if err != nil {
+		t.Fatalf("ExportedSignatures failed: %v", err)
+	}
+
+	if len(signatures) != 2 {
+		t.Fatalf("expected 2 exported signatures, got %d: %+v", len(signatures), signatures)
+	}
+
+	save := signatures[0]
+	if save.Name != "Save" || save.File != "internal/domain/order.go" || save.Line != 15 {
+		t.Errorf("unexpected signature location: %+v", save)
+	}
+	// External module is not loaded but resolved through its import
+	if !hasType(save, "github.com/jackc/pgx/v5", "Tx") {
+		t.Errorf("expected pgx.Tx in Save signature, got %+v", save.Types)
+	}
+	if !hasType(save, "context", "Context") {
+		t.Errorf("expected context.Context in Save signature, got %+v", save.Types)
+	}
+	if !hasType(save, "github.com/test/project/internal/domain", "Order") {
+		t.Errorf("expected domain.Order in Save signature, got %+v", save.Types)
+	}
+
+	store := signatures[1]
+	if store.Name != "Order.Store" {
+		t.Errorf("expected method name Order.Store, got %s", store.Name)
+	}
+	// The alias is resolved to the infra type
+	if !hasType(store, "github.com/test/project/internal/infra", "DB") {
+		t.Errorf("expected alias to resolve to infra.DB, got %+v", store.Types)
+	}
+}
+
+func TestExportedSignatures_EmptyAndMissingDirs(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "internal", "empty", "README.md"), "no go files\n")
+
+	checker := typecheck.New(tmpDir, "github.com/test/project")
+	signatures, err := checker.ExportedSignatures([]string{"internal/empty"})
+	if err != nil {
+		t.Fatalf("ExportedSignatures failed: %v", err)
+	}
+	if len(signatures) != 0 {
+		t.Errorf("expected no signatures, got %d", len(signatures))
+	}
+
+	if _, err := checker.ExportedSignatures([]string{"internal/missing"}); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+func TestExportedSignatures_InvalidSyntax(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "internal", "broken", "broken.go"), `package broken
+
+func Broken( {
+`)
+
+	checker := typecheck.New(tmpDir, "github.com/test/project")
+	if _, err := checker.ExportedSignatures([]string{"internal/broken"}); err == nil {
+		t.Error("expected error for invalid Go file")
+	}
+}
+
+func TestInterfaces_FindsImplementers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "internal", "ports", "ports.go"), `package ports
+
+import "github.com/jackc/pgx/v5"
+
+type Order struct{}
+
+type OrderRepository interface {
+	Save(o Order) error
+}
+
+type Notifier interface {
+	Notify(msg string) error
+}
+
+type Sink interface {
+	Write(p []byte) (int, error)
+}
+
+type TxRunner interface {
+	Run(tx pgx.Tx) error
+}
+
+type Number interface {
+	~int | ~float64
+}
+
+type Any interface{}
+`)
+
+	writeFile(t, filepath.Join(tmpDir, "internal", "adapters", "db", "repo.go"), `package db
+
+import (
+	"bytes"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/test/project/internal/ports"
+)
+
+var _ = bytes.NewBuffer
+
+type Repo struct{}
+
+func (r *Repo) Save(o ports.Order) error { return nil }
+
+func (r *Repo) Run(tx pgx.Tx) error { return nil }
+`)
+
+	interfaces, err := typecheck.New(tmpDir, "github.com/test/project").Interfaces(
+		[]string{"internal/ports"}, []string{"internal/adapters/db"})
+	if err != nil {
+		t.Fatalf("Interfaces failed: %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, iface := range interfaces {
+		if iface.File != "internal/ports/ports.go" {
+			t.Errorf("unexpected file %s", iface.File)
+		}
+		got[iface.Name] = strings.Join(iface.Implementers, ",")
+	}
+
+	// Constraints and empty interfaces are skipped; bytes.Buffer implements Sink
+	expected := map[string]string{
+		"OrderRepository": "*internal/adapters/db.Repo",
+		"Notifier":        "",
+		"Sink":            "*bytes.Buffer",
+		"TxRunner":        "*internal/adapters/db.Repo",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected interfaces %v, got %v", expected, got)
+	}
+	for name, implementers := range expected {
+		if got[name] != implementers {
+			t.Errorf("%s: expected implementers %q, got %q", name, implementers, got[name])
+		}
+	}
+	for _, iface := range interfaces {
+		if iface.IsImplemented() != (iface.Name != "Notifier") {
+			t.Errorf("%s: unexpected IsImplemented() = %v", iface.Name, iface.IsImplemented())
+		}
+	}
+}
+
+func TestChecker_ChecksEachPackageOnce(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "internal", "ports", "ports.go"), "package ports\n\ntype Store interface{ Save(id string) error }\n")
+	writeFile(t, filepath.Join(tmpDir, "internal", "store", "store.go"), `package store
+
+import "github.com/test/project/internal/ports"
+
+var _ ports.Store = (*DB)(nil)
+
+type DB struct{}
+
+func (d *DB) Save(id string) error { return nil }
+
+func Open() *DB { return &DB{} }
+`)
+
+	checker := typecheck.New(tmpDir, "github.com/test/project")
+	interfaces, err := checker.Interfaces([]string{"internal/ports"}, []string{"internal/store"})
+	if err != nil || len(interfaces) != 1 {
+		t.Fatalf("Interfaces = %v, %v", interfaces, err)
+	}
+
+	// Later queries reuse the packages checked for the first one instead of reading the
+	// files again
+	if err := os.RemoveAll(filepath.Join(tmpDir, "internal")); err != nil {
+		t.Fatal(err)
+	}
+
+	signatures, err := checker.ExportedSignatures([]string{"internal/store"})
+	if err != nil {
+		t.Fatalf("ExportedSignatures failed: %v", err)
+	}
+	if len(signatures) != 2 || !hasType(signatures[1], "github.com/test/project/internal/store", "DB") {
+		t.Errorf("expected the signatures of the checked package, got %+v", signatures)
+	}
+
+	implementations, err := checker.Implementations([]string{"internal/ports"}, []string{"internal/store"})
+	if err != nil {
+		t.Fatalf("Implementations failed: %v", err)
+	}
+	if len(implementations) != 1 || implementations[0].Type != "internal/store.DB" || !implementations[0].Asserted {
+		t.Errorf("expected the asserted implementation of the checked package, got %+v", implementations)
+	}
+}
+
+func TestChecker_SetFS(t *testing.T) {
+	// The project directory does not exist: everything is read from the file system
+	projectPath := filepath.Join(t.TempDir(), "project")
+	fsys := fstest.MapFS{
+		"internal/infra/db.go":     {Data: []byte("package infra\n\ntype DB struct{}\n")},
+		"internal/domain/order.go": {Data: []byte("package domain\n\nimport \"github.com/test/project/internal/infra\"\n\nfunc Open() *infra.DB { return nil }\n")},
+		"internal/domain/skip.go":  {Data: []byte("//go:build ignore\n\npackage domain\n\nfunc Skipped() {}\n")},
+	}
+
+	checker := typecheck.New(projectPath, "github.com/test/project")
+	checker.SetFS(fsys)
+	signatures, err := checker.ExportedSignatures([]string{"internal/domain"})
+	if err != nil {
+		t.Fatalf("ExportedSignatures failed: %v", err)
+	}
+
+	if len(signatures) != 1 || signatures[0].Name != "Open" || signatures[0].File != "internal/domain/order.go" {
+		t.Fatalf("expected only Open from order.go, got %+v", signatures)
+	}
+	if !hasType(signatures[0], "github.com/test/project/internal/infra", "DB") {
+		t.Errorf("expected infra.DB read from the file system, got %+v", signatures[0].Types)
+	}
+}
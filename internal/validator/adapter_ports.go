@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// detectPortlessAdapters reports packages in the adapter layers that implement none of the
+// interfaces declared in the port layers. An adapter that satisfies no port either hides
+// an abstraction the core should own, or is a helper that belongs somewhere else. The
+// findings are warnings: a package may legitimately support the adapters next to it.
+func (v *Validator) detectPortlessAdapters() []Violation {
+	var violations []Violation
+
+	// Collect the packages that implement at least one port
+	implementing := make(map[string]bool)
+	for _, iface := range v.interfaces {
+		fileDir := path.Dir(iface.GetFile())
+		if !isWithinAnyLayer(fileDir, v.cfg.GetAdapterPortLayers()) {
+			continue
+		}
+		for _, implementer := range iface.GetImplementers() {
+			if dot := strings.LastIndex(implementer, "."); dot > 0 {
+				implementing[strings.TrimPrefix(implementer[:dot], "*")] = true
+			}
+		}
+	}
+
+	reported := make(map[string]bool)
+	for _, node := range v.graph.GetNodes() {
+		relPath := node.GetRelPath()
+		if node.GetPackage() == "main" || strings.HasSuffix(relPath, "_test.go") {
+			continue
+		}
+		dir := path.Dir(relPath)
+		if reported[dir] || implementing[dir] || !isWithinAnyLayer(dir, v.cfg.GetAdapterLayers()) {
+			continue
+		}
+		reported[dir] = true
+
+		violations = append(violations, Violation{
+			Type:     ViolationPortlessAdapter,
+			File:     relPath,
+			Line:     node.GetPackageLine(),
+			Column:   node.GetPackageColumn(),
+			Severity: SeverityWarning,
+		}.withText(
+			NewMessage("violation.portless_adapter.issue", "Adapter package %s does not implement any port", dir),
+			NewMessage("violation.portless_adapter.rule", "Adapter packages should implement an interface from: %s", strings.Join(v.cfg.GetAdapterPortLayers(), ", ")),
+			NewMessage("violation.portless_adapter.fix", "Declare the port this adapter serves in the core, or move the package out of the adapter layer"),
+		))
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].File < violations[j].File
+	})
+
+	return violations
+}
+
+// detectUnassertedAdapters reports adapter types that implement a port without a
+// compile-time assertion such as "var _ ports.Store = (*Store)(nil)" in their package.
+// Without one, a change to the port or the adapter that breaks the implementation is
+// only caught where the adapter is wired in, possibly at runtime through a type
+// assertion, instead of in the adapter package itself.
+func (v *Validator) detectUnassertedAdapters() []Violation {
+	var violations []Violation
+
+	for _, impl := range v.implementations {
+		if impl.IsAsserted() || !isWithinAnyLayer(path.Dir(impl.GetFile()), v.cfg.GetAdapterLayers()) {
+			continue
+		}
+		portDir, portName := splitQualified(impl.GetInterface())
+		if !isWithinAnyLayer(portDir, v.cfg.GetAdapterPortLayers()) {
+			continue
+		}
+		typeDir, typeName := splitQualified(impl.GetType())
+		port := path.Base(portDir) + "." + portName
+
+		violations = append(violations, Violation{
+			Type:   ViolationUnassertedAdapter,
+			File:   impl.GetFile(),
+			Line:   impl.GetLine(),
+			Column: impl.GetColumn(),
+		}.withText(
+			NewMessage("violation.unasserted_adapter.issue", "%s implements port %s without a compile-time assertion", impl.GetType(), impl.GetInterface()),
+			NewMessage("violation.unasserted_adapter.rule", "Adapter types must assert at compile time the ports they implement"),
+			NewMessage("violation.unasserted_adapter.fix", "Add var _ %s = (*%s)(nil) to %s", port, typeName, typeDir),
+		))
+	}
+
+	return violations
+}
+
+// splitQualified splits a "dir.Name" reference into the directory and the name
+func splitQualified(qualified string) (string, string) {
+	dot := strings.LastIndex(qualified, ".")
+	if dot < 0 {
+		return "", qualified
+	}
+	return qualified[:dot], qualified[dot+1:]
+}
+
+// isWithinAnyLayer checks if a directory is one of the layer directories or below one
+func isWithinAnyLayer(dir string, layers []string) bool {
+	for _, layer := range layers {
+		if isWithinDir(dir, strings.TrimSuffix(layer, "/")) {
+			return true
+		}
+	}
+	return false
+}
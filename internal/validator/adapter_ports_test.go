@@ -0,0 +1,118 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestDetectPortlessAdapters(t *testing.T) {
+	cfg := &testConfig{
+		directoriesImport: map[string][]string{"internal": {}},
+		adapterLayers:     []string{"internal/adapters/"},
+		adapterPortLayers: []string{"internal/ports"},
+	}
+	g := &testGraph{nodes: []validator.FileNode{
+		&testFileNode{relPath: "internal/adapters/postgres/repo.go", pkg: "postgres"},
+		&testFileNode{relPath: "internal/adapters/smtp/mailer.go", pkg: "smtp"},
+		&testFileNode{relPath: "internal/adapters/util/strings.go", pkg: "util", packageLine: 1, packageColumn: 1},
+		&testFileNode{relPath: "internal/adapters/util/strings_test.go", pkg: "util_test"},
+		// Outside the adapter layers
+		&testFileNode{relPath: "internal/app/service.go", pkg: "app"},
+	}}
+	v := validator.New(cfg, g)
+	v.SetInterfaces([]validator.InterfaceDecl{
+		&testInterfaceDecl{file: "internal/ports/repository.go", name: "OrderRepository", implementers: []string{"internal/adapters/postgres.Repo"}},
+		&testInterfaceDecl{file: "internal/ports/notifier.go", name: "Notifier", implementers: []string{"*internal/adapters/smtp.Mailer"}},
+		// Not a port: implementing it does not count
+		&testInterfaceDecl{file: "internal/app/app.go", name: "Clock", implementers: []string{"internal/adapters/util.Clock"}},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Type != validator.ViolationPortlessAdapter || viol.File != "internal/adapters/util/strings.go" || viol.Line != 1 {
+		t.Errorf("expected portless adapter at internal/adapters/util/strings.go:1, got %s at %s:%d", viol.Type, viol.File, viol.Line)
+	}
+	if viol.Issue != "Adapter package internal/adapters/util does not implement any port" {
+		t.Errorf("unexpected issue: %s", viol.Issue)
+	}
+	if viol.IsError() || viol.RuleKey != "rules.adapter_ports" {
+		t.Errorf("expected a warning of rules.adapter_ports, got %s (%s)", viol.GetSeverity(), viol.RuleKey)
+	}
+}
+
+func TestDetectPortlessAdapters_SkippedWithoutTypeInformation(t *testing.T) {
+	cfg := &testConfig{
+		directoriesImport: map[string][]string{"internal": {}},
+		adapterLayers:     []string{"internal/adapters"},
+		adapterPortLayers: []string{"internal/ports"},
+	}
+	g := &testGraph{nodes: []validator.FileNode{
+		&testFileNode{relPath: "internal/adapters/util/strings.go", pkg: "util"},
+	}}
+
+	if violations := validator.New(cfg, g).Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations when type checking did not run, got %v", violations)
+	}
+}
+
+type testPortImplementation struct {
+	file     string
+	line     int
+	typeName string
+	iface    string
+	asserted bool
+}
+
+func (ti *testPortImplementation) GetFile() string      { return ti.file }
+func (ti *testPortImplementation) GetLine() int         { return ti.line }
+func (ti *testPortImplementation) GetColumn() int       { return 6 }
+func (ti *testPortImplementation) GetType() string      { return ti.typeName }
+func (ti *testPortImplementation) GetInterface() string { return ti.iface }
+func (ti *testPortImplementation) IsAsserted() bool     { return ti.asserted }
+
+func TestDetectUnassertedAdapters(t *testing.T) {
+	cfg := &testConfig{
+		directoriesImport:        map[string][]string{"internal": {}},
+		adapterLayers:            []string{"internal/adapters"},
+		adapterPortLayers:        []string{"internal/ports"},
+		requireAdapterAssertions: true,
+	}
+	v := validator.New(cfg, &testGraph{})
+	v.SetPortImplementations([]validator.PortImplementation{
+		&testPortImplementation{file: "internal/adapters/postgres/repo.go", line: 8, typeName: "internal/adapters/postgres.Repo", iface: "internal/ports.OrderRepository", asserted: true},
+		&testPortImplementation{file: "internal/adapters/smtp/mailer.go", line: 12, typeName: "internal/adapters/smtp.Mailer", iface: "internal/ports.Notifier"},
+		// Outside the adapter layers, or not a port
+		&testPortImplementation{file: "internal/app/clock.go", line: 3, typeName: "internal/app.Clock", iface: "internal/ports.Clock"},
+		&testPortImplementation{file: "internal/adapters/smtp/mailer.go", line: 12, typeName: "internal/adapters/smtp.Mailer", iface: "internal/app.Sender"},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Type != validator.ViolationUnassertedAdapter || viol.File != "internal/adapters/smtp/mailer.go" || viol.Line != 12 {
+		t.Errorf("expected missing adapter assertion at internal/adapters/smtp/mailer.go:12, got %s at %s:%d", viol.Type, viol.File, viol.Line)
+	}
+	if viol.Issue != "internal/adapters/smtp.Mailer implements port internal/ports.Notifier without a compile-time assertion" {
+		t.Errorf("unexpected issue: %s", viol.Issue)
+	}
+	if viol.Fix != "Add var _ ports.Notifier = (*Mailer)(nil) to internal/adapters/smtp" {
+		t.Errorf("unexpected fix: %s", viol.Fix)
+	}
+	if !viol.IsError() || viol.RuleKey != "rules.adapter_ports.require_assertions" {
+		t.Errorf("expected an error of rules.adapter_ports.require_assertions, got %s (%s)", viol.GetSeverity(), viol.RuleKey)
+	}
+
+	// Disabled unless required
+	cfg.requireAdapterAssertions = false
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations when assertions are not required, got %v", violations)
+	}
+}
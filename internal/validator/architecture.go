@@ -2,7 +2,7 @@ package validator
 
 import (
 	"fmt"
-	"path/filepath"
+	"path"
 	"strings"
 )
 
@@ -10,11 +10,18 @@ import (
 func (v *Validator) validateFile(node FileNode) []Violation {
 	var violations []Violation
 
-	fileDir := filepath.Dir(node.GetRelPath())
-	fileDir = filepath.ToSlash(fileDir)
+	fileDir := path.Dir(node.GetRelPath())
 
 	// Check if this is a black-box test file
 	isBlackBoxTest := v.isBlackBoxTest(node)
+	isTestFile := strings.HasSuffix(node.GetRelPath(), "_test.go")
+
+	// Forbidden imports are collected and reported as one violation per file
+	var forbidden []Span
+	var forbiddenPaths []string
+	var forbiddenRule Message
+	var forbiddenRuleKey string
+	forbiddenFix := NewMessage("violation.forbidden.fix", "Restructure dependencies according to allowed imports")
 
 	for _, dep := range node.GetDependencies() {
 		// Skip standard library and external dependencies for most rules
@@ -28,12 +35,27 @@ func (v *Validator) validateFile(node FileNode) []Violation {
 			continue // Skip all validation for parent package import
 		}
 
+		// Test files may import the blessed test support packages from any layer
+		if isTestFile && v.isTestSupportPackage(dep.GetLocalPath()) {
+			continue
+		}
+
+		// Test files may use their exempt_imports regardless of layer rules
+		if isTestFile && v.isExemptTestImport(fileDir, dep) {
+			continue
+		}
+
 		// Determine the top-level directory (cmd, pkg, internal)
 		fileTopDir := getTopLevelDir(fileDir)
 		depTopDir := getTopLevelDir(dep.GetLocalPath())
 
 		localPath := dep.GetLocalPath()
 
+		// DI wiring (wire provider sets and injectors, fx modules, dig containers, code
+		// generated by Wire) has to reference the concrete constructors below it, however
+		// deeply they are nested
+		isDIWiringBelow := node.GetDIFramework() != "" && strings.HasPrefix(localPath, fileDir+"/")
+
 		// Rule 1: Check cross-cmd dependencies
 		if fileTopDir == "cmd" && depTopDir == "cmd" {
 			// cmd/X cannot import cmd/Y
@@ -44,36 +66,42 @@ func (v *Validator) validateFile(node FileNode) []Violation {
 				}
 
 				violations = append(violations, Violation{
-					Type:  ViolationCrossCmd,
-					File:  node.GetRelPath(),
-					Issue: fmt.Sprintf("%s imports %s", fileDir, localPath),
-					Rule:  "cmd packages must not import other cmd packages",
-					Fix:   "Extract shared code to pkg/ or internal/",
-				})
+					Type:   ViolationCrossCmd,
+					File:   node.GetRelPath(),
+					Line:   dep.GetLine(),
+					Column: dep.GetColumn(),
+				}.withText(
+					NewMessage("violation.import.issue", "%s imports %s", fileDir, localPath),
+					NewMessage("violation.cross_cmd.rule", "cmd packages must not import other cmd packages"),
+					NewMessage("violation.cross_cmd.fix", "Extract shared code to pkg/ or internal/"),
+				))
 			}
 		}
 
 		// Rule 2: Check pkg-to-pkg dependencies
 		if fileTopDir == "pkg" && depTopDir == "pkg" {
 			// pkg/A can only import its direct subpackages pkg/A/*
-			if !v.isDirectSubpackage(fileDir, localPath) {
+			if !v.isDirectSubpackage(fileDir, localPath) && !isDIWiringBelow {
 				// Check if this import is explicitly allowed via directories_import
 				if v.isImportExplicitlyAllowed(fileDir, localPath) {
 					continue
 				}
 
 				violations = append(violations, Violation{
-					Type:  ViolationPkgToPkg,
-					File:  node.GetRelPath(),
-					Issue: fmt.Sprintf("%s imports %s", fileDir, localPath),
-					Rule:  "pkg packages must not import other pkg packages (except own subpackages)",
-					Fix:   "Import from internal/ or define interface locally",
-				})
+					Type:   ViolationPkgToPkg,
+					File:   node.GetRelPath(),
+					Line:   dep.GetLine(),
+					Column: dep.GetColumn(),
+				}.withText(
+					NewMessage("violation.import.issue", "%s imports %s", fileDir, localPath),
+					NewMessage("violation.pkg_to_pkg.rule", "pkg packages must not import other pkg packages (except own subpackages)"),
+					NewMessage("violation.pkg_to_pkg.fix", "Import from internal/ or define interface locally"),
+				))
 			}
 		}
 
 		// Rule 3: Check skip-level imports for pkg
-		if fileTopDir == "pkg" && depTopDir == "pkg" {
+		if fileTopDir == "pkg" && depTopDir == "pkg" && !isDIWiringBelow {
 			if v.isSkipLevelImport(fileDir, localPath) {
 				// Check if this import is explicitly allowed via directories_import
 				if v.isImportExplicitlyAllowed(fileDir, localPath) {
@@ -81,51 +109,69 @@ func (v *Validator) validateFile(node FileNode) []Violation {
 				}
 
 				violations = append(violations, Violation{
-					Type:  ViolationSkipLevel,
-					File:  node.GetRelPath(),
-					Issue: fmt.Sprintf("%s imports %s", fileDir, localPath),
-					Rule:  "Can only import direct subpackages, not nested ones",
-					Fix:   fmt.Sprintf("Import %s instead", getDirectSubpackage(fileDir, localPath)),
-				})
+					Type:   ViolationSkipLevel,
+					File:   node.GetRelPath(),
+					Line:   dep.GetLine(),
+					Column: dep.GetColumn(),
+				}.withText(
+					NewMessage("violation.import.issue", "%s imports %s", fileDir, localPath),
+					NewMessage("violation.skip_level.rule", "Can only import direct subpackages, not nested ones"),
+					NewMessage("violation.skip_level.fix", "Import %s instead", getDirectSubpackage(fileDir, localPath)),
+				))
 			}
 		}
 
 		// Rule 4: Check directory import rules from config
-		dirImports := v.cfg.GetDirectoriesImport()
-
-		// Check for most specific rule first (exact directory match), then fall back to top-level
-		var allowed []string
-		var ruleKey string
-		var exists bool
-
-		// Try exact directory match first (e.g., "cmd/dw")
-		if allowed, exists = dirImports[fileDir]; exists {
-			ruleKey = fileDir
-		} else if allowed, exists = dirImports[fileTopDir]; exists {
-			// Fall back to top-level directory (e.g., "cmd")
-			ruleKey = fileTopDir
-		}
+		// The most specific rule wins: the key of the directory itself, then of its parents
+		ruleKey, allowed, exists := v.directoryRule(fileDir)
 
 		if exists {
 			// Check if the import is allowed (using full path, not just top-level dir)
 			if !v.isImportAllowed(localPath, allowed) {
 				// Determine appropriate fix message
-				fixMsg := "Restructure dependencies according to allowed imports"
 				if fileTopDir == "internal" && depTopDir == "internal" {
-					fixMsg = "Use interfaces and dependency inversion instead of direct imports"
+					forbiddenFix = NewMessage("violation.forbidden.fix_internal", "Use interfaces and dependency inversion instead of direct imports")
 				}
 
-				violations = append(violations, Violation{
-					Type:  ViolationForbidden,
-					File:  node.GetRelPath(),
-					Issue: fmt.Sprintf("%s imports %s", fileDir, localPath),
-					Rule:  fmt.Sprintf("%s can only import from: %v", ruleKey, allowed),
-					Fix:   fixMsg,
-				})
+				forbidden = append(forbidden, Span{
+					Line:   dep.GetLine(),
+					Column: dep.GetColumn(),
+				}.withIssue(NewMessage("violation.import.issue", "%s imports %s", fileDir, localPath)))
+				forbiddenPaths = append(forbiddenPaths, localPath)
+				forbiddenRule = NewMessage("violation.forbidden.rule", "%s can only import from: %v", ruleKey, allowed)
+				forbiddenRuleKey = "rules.directories_import." + ruleKey
 			}
+		} else if v.cfg.GetDefaultPolicy() == "deny" {
+			// Directories without a rule fail closed
+			forbidden = append(forbidden, Span{
+				Line:   dep.GetLine(),
+				Column: dep.GetColumn(),
+			}.withIssue(NewMessage("violation.import.issue", "%s imports %s", fileDir, localPath)))
+			forbiddenPaths = append(forbiddenPaths, localPath)
+			forbiddenRule = NewMessage("violation.forbidden.rule_default_policy", "%s has no directories_import rule and default_policy is deny", fileDir)
+			forbiddenRuleKey = "rules.default_policy"
+			forbiddenFix = NewMessage("violation.forbidden.fix_default_policy", "Add a directories_import rule for %s listing what it may import", fileDir)
 		}
 	}
 
+	if len(forbidden) > 0 {
+		violation := Violation{
+			Type:    ViolationForbidden,
+			File:    node.GetRelPath(),
+			Line:    forbidden[0].Line,
+			Column:  forbidden[0].Column,
+			RuleKey: forbiddenRuleKey,
+		}.withText(
+			NewMessage("violation.import.issue", "%s imports %s", fileDir, strings.Join(forbiddenPaths, ", ")),
+			forbiddenRule,
+			forbiddenFix,
+		)
+		if len(forbidden) > 1 {
+			violation.Spans = forbidden
+		}
+		violations = append(violations, violation)
+	}
+
 	return violations
 }
 
@@ -137,8 +183,7 @@ func (v *Validator) detectUnusedPackages() []Violation {
 	// Start with all cmd files
 	var cmdFiles []FileNode
 	for _, node := range v.graph.GetNodes() {
-		fileDir := filepath.Dir(node.GetRelPath())
-		fileDir = filepath.ToSlash(fileDir)
+		fileDir := path.Dir(node.GetRelPath())
 		if getTopLevelDir(fileDir) == "cmd" {
 			cmdFiles = append(cmdFiles, node)
 		}
@@ -162,8 +207,7 @@ func (v *Validator) detectUnusedPackages() []Violation {
 
 		// Find all files in this package and add their dependencies
 		for _, node := range v.graph.GetNodes() {
-			fileDir := filepath.Dir(node.GetRelPath())
-			fileDir = filepath.ToSlash(fileDir)
+			fileDir := path.Dir(node.GetRelPath())
 
 			if strings.HasPrefix(fileDir, current) {
 				for _, dep := range node.GetDependencies() {
@@ -182,8 +226,7 @@ func (v *Validator) detectUnusedPackages() []Violation {
 	pkgDirs := make(map[string]bool)
 
 	for _, node := range v.graph.GetNodes() {
-		fileDir := filepath.Dir(node.GetRelPath())
-		fileDir = filepath.ToSlash(fileDir)
+		fileDir := path.Dir(node.GetRelPath())
 		if getTopLevelDir(fileDir) == "pkg" {
 			pkgDirs[fileDir] = true
 		}
@@ -192,11 +235,12 @@ func (v *Validator) detectUnusedPackages() []Violation {
 	for pkg := range pkgDirs {
 		if !used[pkg] {
 			violations = append(violations, Violation{
-				Type:  ViolationUnused,
-				Issue: fmt.Sprintf("Package %s not imported by any cmd/ package", pkg),
-				Rule:  "All packages should be transitively imported from cmd/",
-				Fix:   "Remove package or add import from cmd/",
-			})
+				Type: ViolationUnused,
+			}.withText(
+				NewMessage("violation.unused.issue", "Package %s not imported by any cmd/ package", pkg),
+				NewMessage("violation.unused.rule", "All packages should be transitively imported from cmd/"),
+				NewMessage("violation.unused.fix", "Remove package or add import from cmd/"),
+			))
 		}
 	}
 
@@ -292,18 +336,29 @@ func (v *Validator) isParentPackageImport(fileDir string, importPath string) boo
 // This enables explicit rules to override hardcoded checks (pkg-to-pkg, cross-cmd, skip-level).
 // Returns true if there's an explicit rule that permits this import.
 func (v *Validator) isImportExplicitlyAllowed(fileDir string, importPath string) bool {
-	dirImports := v.cfg.GetDirectoriesImport()
-	fileTopDir := getTopLevelDir(fileDir)
-
-	// Try exact directory match first (e.g., "pkg/plugins/claude_code")
-	if allowed, exists := dirImports[fileDir]; exists {
+	if _, allowed, exists := v.directoryRule(fileDir); exists {
 		return v.isImportAllowed(importPath, allowed)
 	}
+	return false
+}
 
-	// Fall back to top-level directory (e.g., "pkg")
-	if allowed, exists := dirImports[fileTopDir]; exists {
-		return v.isImportAllowed(importPath, allowed)
+// directoryRule returns the directories_import key governing fileDir and its allowed list.
+// Keys cover the directories below them, and the most specific key wins: the rule of the
+// deepest matching directory, preferring a plain key over a pattern at the same depth.
+// Key segments may be path.Match patterns (e.g. "internal/modules/*"); the directory
+// segments they match are substituted for $1, $2, ... in the allowed list.
+func (v *Validator) directoryRule(fileDir string) (string, []string, bool) {
+	if v.rules == nil {
+		v.rules = newRuleMatcher(v.cfg.GetDirectoriesImport())
 	}
+	match := v.rules.lookup(fileDir)
+	return match.key, match.allowed, match.exists
+}
 
-	return false
+// expandCaptures replaces $1, $2, ... in an allowed entry with the captured segments
+func expandCaptures(entry string, captures []string) string {
+	for i := len(captures); i > 0; i-- {
+		entry = strings.ReplaceAll(entry, fmt.Sprintf("$%d", i), captures[i-1])
+	}
+	return entry
 }
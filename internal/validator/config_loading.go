@@ -0,0 +1,126 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// configLibraries are well-known configuration loading libraries
+var configLibraries = []string{
+	"github.com/spf13/viper",
+	"github.com/kelseyhightower/envconfig",
+	"github.com/caarlos0/env",
+	"github.com/joho/godotenv",
+	"github.com/ilyakaznacheev/cleanenv",
+	"github.com/knadh/koanf",
+}
+
+// envReaders are standard library functions reading the process environment
+var envReaders = map[string][]string{
+	"os":      {"Getenv", "LookupEnv", "Environ", "ExpandEnv"},
+	"syscall": {"Getenv", "Environ"},
+}
+
+// validateConfigLoading checks that configuration libraries and environment reads are
+// confined to the allowed directories (cmd/ and designated config packages by default)
+func (v *Validator) validateConfigLoading() []Violation {
+	var violations []Violation
+
+	allowedList := strings.Join(v.cfg.GetConfigLoadingAllowed(), ", ")
+
+	// Configuration libraries are detected from imports
+	for _, node := range v.graph.GetNodes() {
+		fileDir := path.Dir(node.GetRelPath())
+		if v.isConfigLoadingAllowed(fileDir) || strings.HasSuffix(node.GetRelPath(), "_test.go") {
+			continue
+		}
+
+		for _, dep := range node.GetDependencies() {
+			if dep.IsLocalDep() || !v.isConfigLibrary(dep.GetImportPath()) {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				Type:   ViolationConfigLoading,
+				File:   node.GetRelPath(),
+				Line:   dep.GetLine(),
+				Column: dep.GetColumn(),
+			}.withText(
+				NewMessage("violation.config_loading.issue", "%s imports configuration library %s", fileDir, dep.GetImportPath()),
+				NewMessage("violation.config_loading.rule", "Configuration must only be loaded in: %s", allowedList),
+				NewMessage("violation.config_loading.fix", "Load configuration at startup and pass the values in through constructors or a typed config struct"),
+			))
+		}
+	}
+
+	// Environment reads are detected from references to the reader functions
+	for _, file := range v.sourceFiles {
+		fileDir := path.Dir(file.GetRelPath())
+		if file.GetIsTest() || v.isConfigLoadingAllowed(fileDir) {
+			continue
+		}
+
+		// Report the first read of each reader function per file
+		seen := make(map[string]bool)
+		for _, ref := range file.GetSymbolRefs() {
+			qualified := ref.GetImportPath() + "." + ref.GetSymbol()
+			if !containsString(envReaders[ref.GetImportPath()], ref.GetSymbol()) || seen[qualified] {
+				continue
+			}
+			seen[qualified] = true
+
+			violations = append(violations, Violation{
+				Type:   ViolationConfigLoading,
+				File:   file.GetRelPath(),
+				Line:   ref.GetLine(),
+				Column: ref.GetColumn(),
+			}.withText(
+				NewMessage("violation.config_loading.issue_env", "%s reads the environment via %s", fileDir, qualified),
+				NewMessage("violation.config_loading.rule", "Configuration must only be loaded in: %s", allowedList),
+				NewMessage("violation.config_loading.fix_env", "Read environment variables at startup and pass the values in through constructors or a typed config struct"),
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].File < violations[j].File
+	})
+
+	return violations
+}
+
+// isConfigLoadingAllowed checks if a directory may load configuration
+func (v *Validator) isConfigLoadingAllowed(fileDir string) bool {
+	for _, allowed := range v.cfg.GetConfigLoadingAllowed() {
+		if isWithinDir(fileDir, strings.TrimSuffix(allowed, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// isConfigLibrary checks if an import path belongs to a configuration loading library
+func (v *Validator) isConfigLibrary(importPath string) bool {
+	for _, lib := range configLibraries {
+		if isWithinDir(importPath, lib) {
+			return true
+		}
+	}
+	for _, lib := range v.cfg.GetConfigLoadingPackages() {
+		if isWithinDir(importPath, lib) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString checks if a slice contains the given string
+func containsString(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// osRef creates a reference to a function of package os
+func osRef(symbol string, line, column int) validator.APIReference {
+	return &testAPIReference{importPath: "os", symbol: symbol, line: line, column: column}
+}
+
+func configLoadingConfig() *testConfig {
+	return &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"cmd":      {"internal"},
+			"internal": {},
+		},
+		confineConfigLoading:  true,
+		configLoadingAllowed:  []string{"cmd", "internal/config"},
+		configLoadingPackages: []string{"github.com/acme/settings"},
+	}
+}
+
+func TestConfigLoading_LibraryImportsOutsideAllowedDirs(t *testing.T) {
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{
+				relPath: "cmd/api/main.go",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/spf13/viper"},
+				},
+			},
+			&testFileNode{
+				relPath: "internal/config/config.go",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/kelseyhightower/envconfig"},
+				},
+			},
+			&testFileNode{
+				relPath: "internal/billing/invoice.go",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/spf13/viper/remote"},
+					&testDependency{importPath: "github.com/acme/settings"},
+					&testDependency{importPath: "github.com/spf13/viperx"},
+				},
+			},
+		},
+	}
+
+	v := validator.New(configLoadingConfig(), g)
+	violations := v.Validate()
+
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %d: %v", len(violations), violations)
+	}
+	for _, viol := range violations {
+		if viol.Type != validator.ViolationConfigLoading {
+			t.Errorf("expected ViolationConfigLoading, got %s", viol.Type)
+		}
+		if viol.File != "internal/billing/invoice.go" {
+			t.Errorf("expected violation in internal/billing, got %s", viol.File)
+		}
+		if !strings.Contains(viol.Rule, "cmd, internal/config") {
+			t.Errorf("expected rule to list allowed directories, got: %s", viol.Rule)
+		}
+	}
+}
+
+func TestConfigLoading_EnvironmentReads(t *testing.T) {
+	v := validator.New(configLoadingConfig(), &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath:    "internal/billing/invoice.go",
+			symbolRefs: []validator.APIReference{osRef("Getenv", 12, 9), osRef("ReadFile", 14, 2), osRef("Getenv", 20, 9)},
+		},
+		&testSourceFile{
+			relPath:    "internal/config/env.go",
+			symbolRefs: []validator.APIReference{osRef("LookupEnv", 5, 7)},
+		},
+		&testSourceFile{
+			relPath:    "internal/billing/invoice_test.go",
+			isTest:     true,
+			symbolRefs: []validator.APIReference{osRef("Getenv", 8, 2)},
+		},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation (first read of each function per file), got %d: %v", len(violations), violations)
+	}
+	if !strings.Contains(violations[0].Issue, "os.Getenv") {
+		t.Errorf("expected os.Getenv in issue, got: %s", violations[0].Issue)
+	}
+	if violations[0].Line != 12 || violations[0].Column != 9 {
+		t.Errorf("expected position of the first read (12:9), got %d:%d", violations[0].Line, violations[0].Column)
+	}
+}
+
+func TestConfigLoading_Disabled(t *testing.T) {
+	cfg := configLoadingConfig()
+	cfg.confineConfigLoading = false
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath:    "internal/billing/invoice.go",
+			symbolRefs: []validator.APIReference{osRef("Getenv", 3, 2)},
+		},
+	})
+
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations when disabled, got %d", len(violations))
+	}
+}
@@ -1,9 +1,6 @@
 package validator
 
-import (
-	"fmt"
-	"strings"
-)
+import "strings"
 
 // validateCoverage checks that test coverage meets configured thresholds
 func (v *Validator) validateCoverage() []Violation {
@@ -23,17 +20,17 @@ func (v *Validator) validateCoverage() []Violation {
 
 		// Check if coverage is below threshold
 		if coverage < threshold {
-			var issue, fix string
+			var issue, fix Message
 
 			if !hasTests {
-				issue = fmt.Sprintf("Package has no tests (0%% coverage, threshold: %.0f%%)", threshold)
-				fix = fmt.Sprintf(`Add test files for this package:
+				issue = NewMessage("violation.low_coverage.issue_no_tests", "Package has no tests (0%% coverage, threshold: %.0f%%)", threshold)
+				fix = NewMessage("violation.low_coverage.fix_no_tests", `Add test files for this package:
 1. Create %s_test.go files in the package directory
 2. Write tests for the exported API
 3. Run 'go test ./...' to verify`, pkgPath)
 			} else {
-				issue = fmt.Sprintf("Package coverage %.1f%% is below threshold %.0f%%", coverage, threshold)
-				fix = fmt.Sprintf(`Improve test coverage for this package:
+				issue = NewMessage("violation.low_coverage.issue", "Package coverage %.1f%% is below threshold %.0f%%", coverage, threshold)
+				fix = NewMessage("violation.low_coverage.fix", `Improve test coverage for this package:
 1. Run 'go test -cover %s' to see current coverage
 2. Run 'go test -coverprofile=coverage.out %s && go tool cover -html=coverage.out' to see detailed coverage
 3. Add tests for uncovered code paths
@@ -41,12 +38,13 @@ func (v *Validator) validateCoverage() []Violation {
 			}
 
 			violations = append(violations, Violation{
-				Type:  ViolationLowCoverage,
-				File:  pkgPath,
-				Issue: issue,
-				Rule:  fmt.Sprintf("Minimum test coverage: %.0f%% (hierarchical threshold)", threshold),
-				Fix:   fix,
-			})
+				Type: ViolationLowCoverage,
+				File: pkgPath,
+			}.withText(
+				issue,
+				NewMessage("violation.low_coverage.rule", "Minimum test coverage: %.0f%% (hierarchical threshold)", threshold),
+				fix,
+			))
 		}
 	}
 
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// detectDeprecatedUsages finds references to deprecated symbols of other local packages on
+// lines added since the base revision. Existing usages are tolerated so that a deprecation
+// can be introduced without fixing every caller first, but they must not grow.
+func (v *Validator) detectDeprecatedUsages() []Violation {
+	var violations []Violation
+
+	// Deprecated symbols per package directory
+	deprecated := make(map[string]map[string]bool)
+	for _, file := range v.sourceFiles {
+		if file.GetIsTest() || len(file.GetDeprecatedSymbols()) == 0 {
+			continue
+		}
+		dir := path.Dir(file.GetRelPath())
+		if deprecated[dir] == nil {
+			deprecated[dir] = make(map[string]bool)
+		}
+		for _, symbol := range file.GetDeprecatedSymbols() {
+			deprecated[dir][symbol] = true
+		}
+	}
+	if len(deprecated) == 0 {
+		return nil
+	}
+
+	// Resolve import paths to local directories as the graph does
+	localDirs := make(map[string]string)
+	for _, node := range v.graph.GetNodes() {
+		for _, dep := range node.GetDependencies() {
+			if dep.IsLocalDep() {
+				localDirs[dep.GetImportPath()] = dep.GetLocalPath()
+			}
+		}
+	}
+
+	for _, file := range v.sourceFiles {
+		if file.GetIsTest() {
+			continue
+		}
+		fileDir := path.Dir(file.GetRelPath())
+
+		for _, ref := range file.GetSymbolRefs() {
+			depDir, isLocal := localDirs[ref.GetImportPath()]
+			if !isLocal || depDir == fileDir || !deprecated[depDir][ref.GetSymbol()] {
+				continue
+			}
+			if !v.changes.IsLineAdded(file.GetRelPath(), ref.GetLine()) {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				Type:   ViolationDeprecatedUsage,
+				File:   file.GetRelPath(),
+				Line:   ref.GetLine(),
+				Column: ref.GetColumn(),
+			}.withText(
+				NewMessage("violation.deprecated_usage.issue", "New usage of deprecated %s.%s (from %s)", filepath.Base(depDir), ref.GetSymbol(), depDir),
+				NewMessage("violation.deprecated_usage.rule", "Deprecated symbols must not gain new callers in other packages"),
+				NewMessage("violation.deprecated_usage.fix", "Use the replacement named in the Deprecated: comment of %s.%s", filepath.Base(depDir), ref.GetSymbol()),
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+
+	return violations
+}
@@ -0,0 +1,98 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testChangeSet struct {
+	added map[string][]int
+}
+
+func (tcs *testChangeSet) IsLineAdded(relPath string, line int) bool {
+	for _, l := range tcs.added[relPath] {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
+
+func deprecationsConfig() *testConfig {
+	return &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal": {"internal"},
+		},
+		detectDeprecatedUsages: true,
+	}
+}
+
+func deprecationsGraph() *testGraph {
+	legacy := &testDependency{importPath: "github.com/test/project/internal/legacy", localPath: "internal/legacy", isLocal: true}
+	return &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{relPath: "internal/order/order.go", dependencies: []validator.Dependency{legacy}},
+			&testFileNode{relPath: "internal/order/order_test.go", dependencies: []validator.Dependency{legacy}},
+		},
+	}
+}
+
+func legacyRef(decl, symbol string, line int) validator.APIReference {
+	return &testAPIReference{decl: decl, importPath: "github.com/test/project/internal/legacy", symbol: symbol, line: line}
+}
+
+func deprecationsSourceFiles() []validator.SourceFile {
+	return []validator.SourceFile{
+		&testSourceFile{relPath: "internal/legacy/legacy.go", deprecated: []string{"OldFind"}},
+		&testSourceFile{
+			relPath: "internal/order/order.go",
+			symbolRefs: []validator.APIReference{
+				legacyRef("Existing", "OldFind", 10),
+				legacyRef("Create", "OldFind", 20),
+				legacyRef("Create", "Find", 21),
+			},
+		},
+		&testSourceFile{
+			relPath:    "internal/order/order_test.go",
+			isTest:     true,
+			symbolRefs: []validator.APIReference{legacyRef("TestCreate", "OldFind", 5)},
+		},
+	}
+}
+
+func TestDetectDeprecatedUsages_OnlyNewLines(t *testing.T) {
+	v := validator.New(deprecationsConfig(), deprecationsGraph())
+	v.SetSourceFiles(deprecationsSourceFiles())
+	v.SetChangeSet(&testChangeSet{added: map[string][]int{
+		"internal/order/order.go":      {20, 21},
+		"internal/order/order_test.go": {5},
+	}})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Type != validator.ViolationDeprecatedUsage {
+		t.Errorf("expected ViolationDeprecatedUsage, got %s", viol.Type)
+	}
+	if viol.File != "internal/order/order.go" || viol.Line != 20 {
+		t.Errorf("expected location internal/order/order.go:20, got %s:%d", viol.File, viol.Line)
+	}
+	if !strings.Contains(viol.Issue, "legacy.OldFind") {
+		t.Errorf("expected issue to name the symbol, got: %s", viol.Issue)
+	}
+}
+
+func TestDetectDeprecatedUsages_RequiresChangeSet(t *testing.T) {
+	v := validator.New(deprecationsConfig(), deprecationsGraph())
+	v.SetSourceFiles(deprecationsSourceFiles())
+
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations without change information, got %d", len(violations))
+	}
+}
@@ -0,0 +1,153 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// definitionCopy is one occurrence of a duplicated definition
+type definitionCopy struct {
+	file   string
+	dir    string
+	layer  string
+	name   string
+	line   int
+	column int
+}
+
+// detectDuplicateDefinitions finds structurally identical structs and constant blocks
+// defined in more than one layer. Such copies are usually a symptom of copy-paste used
+// to work around a forbidden import, so they are reported as informational findings.
+func (v *Validator) detectDuplicateDefinitions() []Violation {
+	structCopies := make(map[string][]definitionCopy) // fingerprint -> copies
+	constCopies := make(map[string][]definitionCopy)
+
+	for _, file := range v.sourceFiles {
+		if file.GetIsTest() {
+			continue
+		}
+
+		fileDir := path.Dir(file.GetRelPath())
+		layer := v.getDefinitionLayer(fileDir)
+
+		for _, def := range file.GetStructDefs() {
+			fields := def.GetFields()
+			// Tiny structs match too easily to be meaningful
+			if len(fields) < 2 {
+				continue
+			}
+			key := strings.Join(fields, ";")
+			structCopies[key] = append(structCopies[key], definitionCopy{
+				file:   file.GetRelPath(),
+				dir:    fileDir,
+				layer:  layer,
+				name:   def.GetName(),
+				line:   def.GetLine(),
+				column: def.GetColumn(),
+			})
+		}
+
+		for _, block := range file.GetConstBlocks() {
+			entries := block.GetEntries()
+			if len(entries) < 2 {
+				continue
+			}
+			key := strings.Join(entries, ";")
+			constCopies[key] = append(constCopies[key], definitionCopy{
+				file:   file.GetRelPath(),
+				dir:    fileDir,
+				layer:  layer,
+				name:   strings.SplitN(entries[0], " ", 2)[0],
+				line:   block.GetLine(),
+				column: block.GetColumn(),
+			})
+		}
+	}
+
+	var violations []Violation
+	violations = append(violations, v.reportDuplicates(NewMessage("violation.duplicate_definition.struct", "struct"), structCopies)...)
+	violations = append(violations, v.reportDuplicates(NewMessage("violation.duplicate_definition.constant_block", "constant block"), constCopies)...)
+
+	// Sort for deterministic output
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+
+	return violations
+}
+
+// reportDuplicates creates one violation per group of copies spanning multiple layers
+func (v *Validator) reportDuplicates(kind Message, groups map[string][]definitionCopy) []Violation {
+	var violations []Violation
+
+	for _, copies := range groups {
+		layerSet := make(map[string]bool)
+		for _, c := range copies {
+			layerSet[c.layer] = true
+		}
+		if len(layerSet) < 2 {
+			continue
+		}
+
+		sort.Slice(copies, func(i, j int) bool {
+			return copies[i].file < copies[j].file
+		})
+
+		var locations []Message
+		for _, c := range copies {
+			locations = append(locations, NewMessage("violation.duplicate_definition.location", "%s:%d %s (layer: %s)", c.file, c.line, c.name, c.layer))
+		}
+
+		fix := NewMessage("violation.duplicate_definition.fix", "Define the %s once in a package all copies may import and reuse it", kind)
+		if home := v.suggestCanonicalHome(copies); home != "" {
+			fix = NewMessage("violation.duplicate_definition.fix_home", "Keep the definition in %s and import it from the other locations", home)
+		}
+
+		violations = append(violations, Violation{
+			Type:     ViolationDuplicateDefinition,
+			File:     copies[0].file,
+			Line:     copies[0].line,
+			Column:   copies[0].column,
+			Severity: SeverityInfo,
+		}.withText(
+			NewMessage("violation.duplicate_definition.issue", "Identical %s defined in %d layers\n  Defined in:\n    - %s", kind, len(layerSet), joinMessages(locations, "\n    - ")),
+			NewMessage("violation.duplicate_definition.rule", "Definitions should have a single canonical home instead of being copied across layers"),
+			fix,
+		))
+	}
+
+	return violations
+}
+
+// suggestCanonicalHome returns the package directory that every other copy is allowed to import
+func (v *Validator) suggestCanonicalHome(copies []definitionCopy) string {
+	for _, candidate := range copies {
+		importableByAll := true
+		for _, other := range copies {
+			if other.dir == candidate.dir {
+				continue
+			}
+			if !v.isImportExplicitlyAllowed(other.dir, candidate.dir) {
+				importableByAll = false
+				break
+			}
+		}
+		if importableByAll {
+			return candidate.dir
+		}
+	}
+	return ""
+}
+
+// getDefinitionLayer returns the directories_import key governing fileDir, falling back
+// to the top-level directory when no rule matches
+func (v *Validator) getDefinitionLayer(fileDir string) string {
+	if key, _, exists := v.directoryRule(fileDir); exists {
+		return key
+	}
+	return getTopLevelDir(fileDir)
+}
@@ -0,0 +1,207 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testStructDef struct {
+	name   string
+	line   int
+	column int
+	fields []string
+}
+
+func (tsd *testStructDef) GetName() string     { return tsd.name }
+func (tsd *testStructDef) GetLine() int        { return tsd.line }
+func (tsd *testStructDef) GetColumn() int      { return tsd.column }
+func (tsd *testStructDef) GetFields() []string { return tsd.fields }
+
+type testConstBlock struct {
+	line    int
+	column  int
+	entries []string
+}
+
+func (tcb *testConstBlock) GetLine() int         { return tcb.line }
+func (tcb *testConstBlock) GetColumn() int       { return tcb.column }
+func (tcb *testConstBlock) GetEntries() []string { return tcb.entries }
+
+type testSourceFile struct {
+	relPath     string
+	pkg         string
+	isTest      bool
+	structDefs  []validator.StructDef
+	constBlocks []validator.ConstBlock
+	apiRefs     []validator.APIReference
+	sigRefs     []validator.APIReference
+	stability   string
+	deprecated  []string
+	symbolRefs  []validator.APIReference
+	packageDoc  string
+	fileReads   []validator.FileRead
+	symbolUses  []validator.SymbolUse
+	funcDecls   []string
+	dynamicRefs []validator.DynamicRef
+}
+
+func (tsf *testSourceFile) GetRelPath() string                         { return tsf.relPath }
+func (tsf *testSourceFile) GetPackage() string                         { return tsf.pkg }
+func (tsf *testSourceFile) GetIsTest() bool                            { return tsf.isTest }
+func (tsf *testSourceFile) GetStructDefs() []validator.StructDef       { return tsf.structDefs }
+func (tsf *testSourceFile) GetConstBlocks() []validator.ConstBlock     { return tsf.constBlocks }
+func (tsf *testSourceFile) GetAPIReferences() []validator.APIReference { return tsf.apiRefs }
+func (tsf *testSourceFile) GetSignatureRefs() []validator.APIReference { return tsf.sigRefs }
+func (tsf *testSourceFile) GetStability() string                       { return tsf.stability }
+func (tsf *testSourceFile) GetDeprecatedSymbols() []string             { return tsf.deprecated }
+func (tsf *testSourceFile) GetSymbolRefs() []validator.APIReference    { return tsf.symbolRefs }
+func (tsf *testSourceFile) GetPackageDoc() string                      { return tsf.packageDoc }
+func (tsf *testSourceFile) GetFileReads() []validator.FileRead         { return tsf.fileReads }
+func (tsf *testSourceFile) GetSymbolUses() []validator.SymbolUse       { return tsf.symbolUses }
+func (tsf *testSourceFile) GetFuncDecls() []string                     { return tsf.funcDecls }
+func (tsf *testSourceFile) GetDynamicRefs() []validator.DynamicRef     { return tsf.dynamicRefs }
+
+func duplicatesConfig() *testConfig {
+	return &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"cmd":      {"pkg"},
+			"pkg":      {"internal"},
+			"internal": {},
+		},
+		detectDuplicates: true,
+	}
+}
+
+func TestDetectDuplicates_StructAcrossLayers(t *testing.T) {
+	fields := []string{"ID string", "Name string", "Email string"}
+
+	v := validator.New(duplicatesConfig(), &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath:    "internal/user/user.go",
+			pkg:        "user",
+			structDefs: []validator.StructDef{&testStructDef{name: "User", line: 5, fields: fields}},
+		},
+		&testSourceFile{
+			relPath:    "pkg/api/dto.go",
+			pkg:        "api",
+			structDefs: []validator.StructDef{&testStructDef{name: "UserDTO", line: 12, fields: fields}},
+		},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Type != validator.ViolationDuplicateDefinition {
+		t.Errorf("expected ViolationDuplicateDefinition, got %s", viol.Type)
+	}
+	if viol.Severity != validator.SeverityInfo || viol.IsError() {
+		t.Errorf("expected informational severity, got %q", viol.Severity)
+	}
+	if viol.File != "internal/user/user.go" || viol.Line != 5 {
+		t.Errorf("expected location internal/user/user.go:5, got %s:%d", viol.File, viol.Line)
+	}
+	if !strings.Contains(viol.Issue, "pkg/api/dto.go:12 UserDTO") {
+		t.Errorf("expected issue to list the pkg copy, got: %s", viol.Issue)
+	}
+	// pkg may import internal, so internal/user is the canonical home
+	if !strings.Contains(viol.Fix, "internal/user") {
+		t.Errorf("expected fix to suggest internal/user, got: %s", viol.Fix)
+	}
+}
+
+func TestDetectDuplicates_ConstBlockAcrossLayers(t *testing.T) {
+	entries := []string{`StatusActive = "active"`, `StatusBlocked = "blocked"`}
+
+	v := validator.New(duplicatesConfig(), &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath:     "cmd/tool/main.go",
+			pkg:         "main",
+			constBlocks: []validator.ConstBlock{&testConstBlock{line: 8, entries: entries}},
+		},
+		&testSourceFile{
+			relPath:     "internal/status/status.go",
+			pkg:         "status",
+			constBlocks: []validator.ConstBlock{&testConstBlock{line: 3, entries: entries}},
+		},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d", len(violations))
+	}
+	if !strings.Contains(violations[0].Issue, "constant block") {
+		t.Errorf("expected constant block issue, got: %s", violations[0].Issue)
+	}
+	// cmd cannot import internal and vice versa, so no canonical home exists
+	if strings.Contains(violations[0].Fix, "Keep the definition in") {
+		t.Errorf("expected generic fix, got: %s", violations[0].Fix)
+	}
+}
+
+func TestDetectDuplicates_IgnoresSameLayerTestsAndSmallDefinitions(t *testing.T) {
+	fields := []string{"ID string", "Name string"}
+
+	v := validator.New(duplicatesConfig(), &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		// Same layer copies are left to the package owners
+		&testSourceFile{
+			relPath:    "internal/a/a.go",
+			structDefs: []validator.StructDef{&testStructDef{name: "A", line: 1, fields: fields}},
+		},
+		&testSourceFile{
+			relPath:    "internal/b/b.go",
+			structDefs: []validator.StructDef{&testStructDef{name: "B", line: 1, fields: fields}},
+		},
+		// Test fixtures often mirror production types
+		&testSourceFile{
+			relPath:    "pkg/api/api_test.go",
+			isTest:     true,
+			structDefs: []validator.StructDef{&testStructDef{name: "A", line: 1, fields: fields}},
+		},
+		// Single-field structs match too easily
+		&testSourceFile{
+			relPath:    "cmd/tool/main.go",
+			structDefs: []validator.StructDef{&testStructDef{name: "ID", line: 1, fields: []string{"Value string"}}},
+		},
+		&testSourceFile{
+			relPath:    "pkg/api/id.go",
+			structDefs: []validator.StructDef{&testStructDef{name: "ID", line: 1, fields: []string{"Value string"}}},
+		},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 0 {
+		t.Errorf("expected no violations, got %d: %v", len(violations), violations)
+	}
+}
+
+func TestDetectDuplicates_DisabledByDefault(t *testing.T) {
+	fields := []string{"ID string", "Name string"}
+
+	cfg := duplicatesConfig()
+	cfg.detectDuplicates = false
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath:    "internal/user/user.go",
+			structDefs: []validator.StructDef{&testStructDef{name: "User", line: 1, fields: fields}},
+		},
+		&testSourceFile{
+			relPath:    "pkg/api/dto.go",
+			structDefs: []validator.StructDef{&testStructDef{name: "User", line: 1, fields: fields}},
+		},
+	})
+
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations when disabled, got %d", len(violations))
+	}
+}
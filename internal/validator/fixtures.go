@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// validateFixtureLocations checks that test fixtures read via literal paths live under
+// testdata/ and that production code does not read files from testdata/. Detection is
+// best-effort: only paths written as string literals are seen.
+func (v *Validator) validateFixtureLocations() []Violation {
+	var violations []Violation
+
+	for _, file := range v.sourceFiles {
+		for _, read := range file.GetFileReads() {
+			readPath := path.Clean(read.GetPath())
+			if path.IsAbs(readPath) {
+				continue
+			}
+			inTestdata := isUnderTestdata(readPath)
+
+			switch {
+			case file.GetIsTest() && !inTestdata:
+				violations = append(violations, Violation{
+					Type:   ViolationFixtureLocation,
+					File:   file.GetRelPath(),
+					Line:   read.GetLine(),
+					Column: read.GetColumn(),
+				}.withText(
+					NewMessage("violation.fixture_location.issue", "test reads fixture %q outside testdata/ via %s", readPath, read.GetFunction()),
+					NewMessage("violation.fixture_location.rule", "Test fixtures must live under a testdata/ directory"),
+					NewMessage("violation.fixture_location.fix", "Move %s into testdata/ next to the test; the go tool ignores testdata directories", path.Base(readPath)),
+				))
+			case !file.GetIsTest() && inTestdata:
+				violations = append(violations, Violation{
+					Type:   ViolationFixtureLocation,
+					File:   file.GetRelPath(),
+					Line:   read.GetLine(),
+					Column: read.GetColumn(),
+				}.withText(
+					NewMessage("violation.fixture_location.issue_production", "production code reads test fixture %q via %s", readPath, read.GetFunction()),
+					NewMessage("violation.fixture_location.rule_production", "Production code must not depend on files under testdata/"),
+					NewMessage("violation.fixture_location.fix_production", "Embed the file with go:embed from a non-testdata location or take its path from configuration"),
+				))
+			}
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].File < violations[j].File
+	})
+
+	return violations
+}
+
+// isUnderTestdata reports whether a slash-separated path has a testdata element
+func isUnderTestdata(p string) bool {
+	for _, element := range strings.Split(p, "/") {
+		if element == "testdata" {
+			return true
+		}
+	}
+	return false
+}
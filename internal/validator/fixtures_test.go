@@ -0,0 +1,89 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testFileRead struct {
+	function string
+	path     string
+	line     int
+	column   int
+}
+
+func (tfr *testFileRead) GetFunction() string { return tfr.function }
+func (tfr *testFileRead) GetPath() string     { return tfr.path }
+func (tfr *testFileRead) GetLine() int        { return tfr.line }
+func (tfr *testFileRead) GetColumn() int      { return tfr.column }
+
+func TestValidateFixtureLocations(t *testing.T) {
+	cfg := &testConfig{
+		module:                  "github.com/test/project",
+		requireTestdataFixtures: true,
+	}
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath: "internal/parser/parser_test.go",
+			isTest:  true,
+			fileReads: []validator.FileRead{
+				&testFileRead{function: "os.ReadFile", path: "testdata/input.json", line: 10, column: 14},
+				&testFileRead{function: "os.ReadFile", path: "../shared/testdata/common.json", line: 11, column: 14},
+				&testFileRead{function: "os.Open", path: "fixtures/golden.txt", line: 12, column: 18},
+				&testFileRead{function: "os.ReadFile", path: "/etc/hosts", line: 13, column: 14},
+			},
+		},
+		&testSourceFile{
+			relPath: "internal/loader/loader.go",
+			fileReads: []validator.FileRead{
+				&testFileRead{function: "os.ReadFile", path: "./testdata/defaults.yaml", line: 7, column: 23},
+				&testFileRead{function: "os.ReadFile", path: "config.yaml", line: 8, column: 23},
+			},
+		},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %d: %+v", len(violations), violations)
+	}
+
+	loader := violations[0]
+	if loader.Type != validator.ViolationFixtureLocation || loader.File != "internal/loader/loader.go" || loader.Line != 7 || loader.Column != 23 {
+		t.Errorf("unexpected production violation: %+v", loader)
+	}
+	if loader.Issue != `production code reads test fixture "testdata/defaults.yaml" via os.ReadFile` {
+		t.Errorf("unexpected issue: %s", loader.Issue)
+	}
+
+	parser := violations[1]
+	if parser.File != "internal/parser/parser_test.go" || parser.Line != 12 {
+		t.Errorf("unexpected test violation: %+v", parser)
+	}
+	if !strings.Contains(parser.Issue, `"fixtures/golden.txt" outside testdata/ via os.Open`) || !strings.Contains(parser.Fix, "golden.txt") {
+		t.Errorf("expected fixture path in issue and fix, got: %+v", parser)
+	}
+	if parser.RuleKey != "rules.test_files.require_testdata" {
+		t.Errorf("expected rule key rules.test_files.require_testdata, got %q", parser.RuleKey)
+	}
+}
+
+func TestValidateFixtureLocations_Disabled(t *testing.T) {
+	cfg := &testConfig{module: "github.com/test/project"}
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath:   "internal/parser/parser_test.go",
+			isTest:    true,
+			fileReads: []validator.FileRead{&testFileRead{function: "os.ReadFile", path: "fixture.json", line: 10}},
+		},
+	})
+
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations when disabled, got: %+v", violations)
+	}
+}
@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// adapterDirNames are directory names treated as adapters when no allowed list is configured
+var adapterDirNames = map[string]bool{
+	"adapter":  true,
+	"adapters": true,
+	"handler":  true,
+	"handlers": true,
+}
+
+// detectFrameworkLockIn finds function signatures mentioning web framework types outside
+// adapter/handler directories. Import rules cannot express this: the framework may be
+// imported legitimately for routing while its request context must not spread inwards.
+func (v *Validator) detectFrameworkLockIn() []Violation {
+	var violations []Violation
+
+	frameworkTypes := make(map[string]bool)
+	for _, typ := range v.cfg.GetFrameworkLockInTypes() {
+		frameworkTypes[typ] = true
+	}
+
+	for _, file := range v.sourceFiles {
+		fileDir := path.Dir(file.GetRelPath())
+		if file.GetIsTest() || v.isFrameworkAllowedDir(fileDir) {
+			continue
+		}
+
+		for _, ref := range file.GetSignatureRefs() {
+			qualified := ref.GetImportPath() + "." + ref.GetSymbol()
+			if !frameworkTypes[qualified] {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				Type:   ViolationFrameworkLockIn,
+				File:   file.GetRelPath(),
+				Line:   ref.GetLine(),
+				Column: ref.GetColumn(),
+			}.withText(
+				NewMessage("violation.framework_lock_in.issue", "%s uses framework type %s in its signature", ref.GetDecl(), qualified),
+				NewMessage("violation.framework_lock_in.rule", "Web framework types must stay in adapter/handler packages"),
+				NewMessage("violation.framework_lock_in.fix", "Extract the values you need in the handler and pass plain parameters or domain types instead"),
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+
+	return violations
+}
+
+// isFrameworkAllowedDir checks if framework types may be used in a directory
+func (v *Validator) isFrameworkAllowedDir(fileDir string) bool {
+	allowed := v.cfg.GetFrameworkLockInAllowed()
+	if len(allowed) == 0 {
+		// Default: any directory below an adapter/handler directory
+		for _, segment := range strings.Split(fileDir, "/") {
+			if adapterDirNames[segment] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, dir := range allowed {
+		if isWithinDir(fileDir, strings.TrimSuffix(dir, "/")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func frameworkLockInConfig() *testConfig {
+	return &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal": {},
+		},
+		detectFrameworkLockIn: true,
+		frameworkLockInTypes:  []string{"github.com/gin-gonic/gin.Context", "github.com/labstack/echo/v4.Context"},
+	}
+}
+
+func ginRef(decl string, line int) validator.APIReference {
+	return &testAPIReference{decl: decl, importPath: "github.com/gin-gonic/gin", symbol: "Context", line: line}
+}
+
+func TestFrameworkLockIn_DefaultAdapterDirectories(t *testing.T) {
+	v := validator.New(frameworkLockInConfig(), &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath: "internal/order/service.go",
+			sigRefs: []validator.APIReference{
+				ginRef("Service.Create", 12),
+				// Other symbols from the framework package are fine
+				&testAPIReference{decl: "Routes", importPath: "github.com/gin-gonic/gin", symbol: "IRouter", line: 20},
+			},
+		},
+		&testSourceFile{
+			relPath: "internal/adapters/http/order.go",
+			sigRefs: []validator.APIReference{ginRef("createOrder", 8)},
+		},
+		&testSourceFile{
+			relPath: "internal/order/handlers/order.go",
+			sigRefs: []validator.APIReference{ginRef("Create", 8)},
+		},
+		&testSourceFile{
+			relPath: "internal/order/service_test.go",
+			isTest:  true,
+			sigRefs: []validator.APIReference{ginRef("newTestContext", 5)},
+		},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Type != validator.ViolationFrameworkLockIn {
+		t.Errorf("expected ViolationFrameworkLockIn, got %s", viol.Type)
+	}
+	if viol.File != "internal/order/service.go" || viol.Line != 12 {
+		t.Errorf("expected location internal/order/service.go:12, got %s:%d", viol.File, viol.Line)
+	}
+	if !strings.Contains(viol.Issue, "Service.Create") {
+		t.Errorf("expected issue to name the function, got: %s", viol.Issue)
+	}
+}
+
+func TestFrameworkLockIn_ConfiguredAllowedDirectories(t *testing.T) {
+	cfg := frameworkLockInConfig()
+	cfg.frameworkLockInAllowed = []string{"internal/transport"}
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath: "internal/transport/rest/order.go",
+			sigRefs: []validator.APIReference{ginRef("createOrder", 8)},
+		},
+		// Default adapter names no longer apply once directories are configured
+		&testSourceFile{
+			relPath: "internal/handlers/order.go",
+			sigRefs: []validator.APIReference{ginRef("Create", 4)},
+		},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 || violations[0].File != "internal/handlers/order.go" {
+		t.Errorf("expected 1 violation in internal/handlers/order.go, got %v", violations)
+	}
+}
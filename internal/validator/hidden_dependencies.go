@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"fmt"
+	"path"
+	"sort"
+)
+
+// registrationSite is a place a hidden dependency leads to: a registration of a registry
+// key, or the declaration of a method reflect lookups can reach by name
+type registrationSite struct {
+	dir         string
+	description Message // e.g. "registered at internal/infra/billing/module.go:12 (registry.Register)"
+}
+
+// detectHiddenDependencies reports lookups through string-keyed registries and reflect
+// that reach code in directories the looking-up directory may not import. These edges do
+// not appear in the import graph, so the directories_import rules cannot see them. The
+// detection is heuristic (calls are matched by name and literal key only), so the
+// findings are warnings.
+func (v *Validator) detectHiddenDependencies() []Violation {
+	var violations []Violation
+
+	// Index the registrations and the exported methods by key
+	registered := make(map[string][]registrationSite)
+	methods := make(map[string][]registrationSite)
+	for _, file := range v.sourceFiles {
+		if file.GetIsTest() {
+			continue
+		}
+		dir := path.Dir(file.GetRelPath())
+		for _, ref := range file.GetDynamicRefs() {
+			location := fmt.Sprintf("%s:%d (%s)", file.GetRelPath(), ref.GetLine(), ref.GetFunction())
+			switch ref.GetKind() {
+			case "register":
+				registered[ref.GetKey()] = append(registered[ref.GetKey()], registrationSite{dir: dir, description: NewMessage("violation.hidden_dependency.registered_at", "registered at %s", location)})
+			case "method":
+				methods[ref.GetKey()] = append(methods[ref.GetKey()], registrationSite{dir: dir, description: NewMessage("violation.hidden_dependency.declared_at", "declared at %s", location)})
+			}
+		}
+	}
+
+	for _, file := range v.sourceFiles {
+		if file.GetIsTest() {
+			continue
+		}
+		fileDir := path.Dir(file.GetRelPath())
+		for _, ref := range file.GetDynamicRefs() {
+			var candidates []registrationSite
+			switch ref.GetKind() {
+			case "lookup":
+				candidates = registered[ref.GetKey()]
+			case "reflect":
+				candidates = append(append(candidates, registered[ref.GetKey()]...), methods[ref.GetKey()]...)
+			default:
+				continue
+			}
+
+			// Only edges the import rules would forbid are reported
+			var sites []Message
+			for _, site := range candidates {
+				if site.dir != fileDir && !v.mayImport(fileDir, site.dir) {
+					sites = append(sites, site.description)
+				}
+			}
+			if len(sites) == 0 {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				Type:     ViolationHiddenDependency,
+				File:     file.GetRelPath(),
+				Line:     ref.GetLine(),
+				Column:   ref.GetColumn(),
+				Severity: SeverityWarning,
+			}.withText(
+				NewMessage("violation.hidden_dependency.issue", "%s looks up %q via %s, %s", fileDir, ref.GetKey(), ref.GetFunction(), joinMessages(sites, ", ")),
+				v.hiddenDependencyRule(fileDir),
+				NewMessage("violation.hidden_dependency.fix", "Pass the dependency in through an interface the consumer owns, or allow the import in directories_import if the coupling is intended"),
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].File < violations[j].File
+	})
+
+	return violations
+}
+
+// mayImport checks if the directories_import rules let fileDir import targetDir
+func (v *Validator) mayImport(fileDir, targetDir string) bool {
+	if _, allowed, exists := v.directoryRule(fileDir); exists {
+		return v.isImportAllowed(targetDir, allowed)
+	}
+	return v.cfg.GetDefaultPolicy() != "deny"
+}
+
+// hiddenDependencyRule describes the import rule a hidden dependency of fileDir bypasses
+func (v *Validator) hiddenDependencyRule(fileDir string) Message {
+	if ruleKey, allowed, exists := v.directoryRule(fileDir); exists {
+		return NewMessage("violation.hidden_dependency.rule", "Registries and reflection must not bypass import rules: %s can only import from: %v", ruleKey, allowed)
+	}
+	return NewMessage("violation.hidden_dependency.rule_default_policy", "Registries and reflection must not bypass import rules: %s has no directories_import rule and default_policy is deny", fileDir)
+}
@@ -0,0 +1,111 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testDynamicRef struct {
+	kind     string
+	function string
+	key      string
+	line     int
+	column   int
+}
+
+func (tdr *testDynamicRef) GetKind() string     { return tdr.kind }
+func (tdr *testDynamicRef) GetFunction() string { return tdr.function }
+func (tdr *testDynamicRef) GetKey() string      { return tdr.key }
+func (tdr *testDynamicRef) GetLine() int        { return tdr.line }
+func (tdr *testDynamicRef) GetColumn() int      { return tdr.column }
+
+func hiddenDependencySources() []validator.SourceFile {
+	return []validator.SourceFile{
+		&testSourceFile{
+			relPath: "internal/infra/billing/module.go",
+			dynamicRefs: []validator.DynamicRef{
+				&testDynamicRef{kind: "register", function: "registry.Register", key: "billing", line: 12, column: 20},
+				&testDynamicRef{kind: "method", function: "Client.Charge", key: "Charge", line: 20, column: 18},
+			},
+		},
+		&testSourceFile{
+			relPath: "internal/domain/catalog.go",
+			dynamicRefs: []validator.DynamicRef{
+				&testDynamicRef{kind: "register", function: "registry.Register", key: "catalog", line: 5, column: 20},
+			},
+		},
+		&testSourceFile{
+			relPath: "internal/app/checkout.go",
+			dynamicRefs: []validator.DynamicRef{
+				&testDynamicRef{kind: "lookup", function: "registry.Get", key: "billing", line: 8, column: 15},
+				&testDynamicRef{kind: "lookup", function: "registry.Get", key: "catalog", line: 9, column: 15},
+				&testDynamicRef{kind: "lookup", function: "registry.Get", key: "unknown", line: 10, column: 15},
+				&testDynamicRef{kind: "reflect", function: "MethodByName", key: "Charge", line: 11, column: 36},
+			},
+		},
+		&testSourceFile{
+			relPath: "internal/app/checkout_test.go",
+			isTest:  true,
+			dynamicRefs: []validator.DynamicRef{
+				&testDynamicRef{kind: "lookup", function: "registry.Get", key: "billing", line: 8, column: 15},
+			},
+		},
+	}
+}
+
+func TestDetectHiddenDependencies(t *testing.T) {
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal/app":    {"internal/domain"},
+			"internal/domain": {},
+			"internal/infra":  {"internal/domain", "internal/app"},
+		},
+		detectHiddenDependencies: true,
+	}
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles(hiddenDependencySources())
+
+	violations := v.Validate()
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %d: %+v", len(violations), violations)
+	}
+
+	lookup := violations[0]
+	if lookup.Type != validator.ViolationHiddenDependency || lookup.File != "internal/app/checkout.go" || lookup.Line != 8 || lookup.Column != 15 {
+		t.Errorf("unexpected registry violation: %+v", lookup)
+	}
+	if lookup.Issue != `internal/app looks up "billing" via registry.Get, registered at internal/infra/billing/module.go:12 (registry.Register)` {
+		t.Errorf("unexpected issue: %s", lookup.Issue)
+	}
+	if !strings.Contains(lookup.Rule, "internal/app can only import from: [internal/domain]") {
+		t.Errorf("expected the bypassed import rule, got %q", lookup.Rule)
+	}
+	if lookup.Severity != validator.SeverityWarning || lookup.RuleKey != "rules.hidden_dependencies" {
+		t.Errorf("expected a warning with rule key rules.hidden_dependencies, got %+v", lookup)
+	}
+
+	reflected := violations[1]
+	if reflected.Line != 11 || !strings.Contains(reflected.Issue, `"Charge" via MethodByName, declared at internal/infra/billing/module.go:20 (Client.Charge)`) {
+		t.Errorf("unexpected reflect violation: %+v", reflected)
+	}
+}
+
+func TestDetectHiddenDependencies_Disabled(t *testing.T) {
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal/app": {"internal/domain"},
+		},
+	}
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles(hiddenDependencySources())
+
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations when disabled, got %+v", violations)
+	}
+}
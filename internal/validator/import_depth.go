@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// validateExternalImportDepth checks that the production code of each layer with depth
+// limits imports external modules no deeper than allowed, so deep packages such as
+// generated SDK types are reached through local facades. The depth of an import is the
+// number of path elements below the module it belongs to.
+func (v *Validator) validateExternalImportDepth() []Violation {
+	var violations []Violation
+
+	limits := v.cfg.GetExternalImportDepthLayers()
+	for _, node := range v.graph.GetNodes() {
+		if strings.HasSuffix(node.GetRelPath(), "_test.go") {
+			continue
+		}
+		fileDir := path.Dir(node.GetRelPath())
+		layer := depthLayerOf(fileDir, limits)
+		if layer == "" {
+			continue
+		}
+
+		for _, dep := range node.GetDependencies() {
+			if dep.IsLocalDep() {
+				continue
+			}
+			module, maxDepth, ok := depthLimitOf(dep.GetImportPath(), limits[layer])
+			if !ok {
+				continue
+			}
+			elements := importElementsBelow(dep.GetImportPath(), module)
+			if len(elements) <= maxDepth {
+				continue
+			}
+
+			allowed := path.Join(append([]string{module}, elements[:maxDepth]...)...)
+			rule := NewMessage("violation.import_depth.rule", "%s may import packages of %s at most %s below the module", layer, module, pluralLevels(maxDepth))
+			if maxDepth == 0 {
+				rule = NewMessage("violation.import_depth.rule_root", "%s may only import the root package of %s", layer, module)
+			}
+
+			violations = append(violations, Violation{
+				Type:    ViolationImportDepth,
+				File:    node.GetRelPath(),
+				Line:    dep.GetLine(),
+				Column:  dep.GetColumn(),
+				RuleKey: "rules.external_import_depth.layers." + layer,
+			}.withText(
+				NewMessage("violation.import_depth.issue", "%s imports %s, %s below %s", fileDir, dep.GetImportPath(), pluralLevels(len(elements)), module),
+				rule,
+				NewMessage("violation.import_depth.fix", "Reach %s through a local facade package, or import %s instead", dep.GetImportPath(), allowed),
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+
+	return violations
+}
+
+// depthLayerOf returns the most specific directory with depth limits containing dir,
+// or "" if there is none
+func depthLayerOf(dir string, limits map[string]map[string]int) string {
+	best := ""
+	for layer := range limits {
+		if isWithinDir(dir, layer) && len(layer) > len(best) {
+			best = layer
+		}
+	}
+	return best
+}
+
+// depthLimitOf returns the longest module of a layer's limits containing importPath
+// and its depth limit
+func depthLimitOf(importPath string, limits map[string]int) (string, int, bool) {
+	best := ""
+	for module := range limits {
+		if (importPath == module || strings.HasPrefix(importPath, module+"/")) && len(module) > len(best) {
+			best = module
+		}
+	}
+	if best == "" {
+		return "", 0, false
+	}
+	return best, limits[best], true
+}
+
+// importElementsBelow returns the path elements of importPath below module
+func importElementsBelow(importPath, module string) []string {
+	rest := strings.TrimPrefix(strings.TrimPrefix(importPath, module), "/")
+	if rest == "" {
+		return nil
+	}
+	return strings.Split(rest, "/")
+}
+
+// pluralLevels formats a number of path levels
+func pluralLevels(levels int) Message {
+	if levels == 1 {
+		return NewMessage("violation.import_depth.level", "1 level")
+	}
+	return NewMessage("violation.import_depth.levels", "%d levels", levels)
+}
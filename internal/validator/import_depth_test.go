@@ -0,0 +1,87 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestValidateExternalImportDepth(t *testing.T) {
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		externalImportDepthLayers: map[string]map[string]int{
+			"internal": {"github.com/aws/aws-sdk-go-v2": 2},
+			"internal/app": { // Most specific directory wins
+				"github.com/aws/aws-sdk-go-v2":            1,
+				"github.com/aws/aws-sdk-go-v2/service/s3": 0, // Longest module wins
+			},
+		},
+	}
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			externalImportNode("internal/app/upload/upload.go",
+				"fmt",
+				"github.com/aws/aws-sdk-go-v2/aws",
+				"github.com/aws/aws-sdk-go-v2/service/s3",
+				"github.com/aws/aws-sdk-go-v2/service/s3/types",
+				"github.com/aws/aws-sdk-go-v2/feature/s3/manager",
+				"github.com/google/uuid",
+			),
+			externalImportNode("internal/infra/storage/s3.go",
+				"github.com/aws/aws-sdk-go-v2/service/s3",
+				"github.com/aws/aws-sdk-go-v2/service/s3/types",
+			),
+			externalImportNode("internal/app/upload/upload_test.go", "github.com/aws/aws-sdk-go-v2/service/s3/types"),
+			externalImportNode("cmd/app/main.go", "github.com/aws/aws-sdk-go-v2/service/s3/types"),
+		},
+	}
+
+	var violations []validator.Violation
+	for _, viol := range validator.New(cfg, g).Validate() {
+		if viol.Type == validator.ViolationImportDepth {
+			violations = append(violations, viol)
+		}
+	}
+
+	tests := []struct {
+		file    string
+		line    int
+		issue   string
+		rule    string
+		fix     string
+		ruleKey string
+	}{
+		{
+			file:    "internal/app/upload/upload.go",
+			line:    6,
+			issue:   "internal/app/upload imports github.com/aws/aws-sdk-go-v2/service/s3/types, 1 level below github.com/aws/aws-sdk-go-v2/service/s3",
+			rule:    "internal/app may only import the root package of github.com/aws/aws-sdk-go-v2/service/s3",
+			fix:     "Reach github.com/aws/aws-sdk-go-v2/service/s3/types through a local facade package, or import github.com/aws/aws-sdk-go-v2/service/s3 instead",
+			ruleKey: "rules.external_import_depth.layers.internal/app",
+		},
+		{
+			file:    "internal/app/upload/upload.go",
+			line:    7,
+			issue:   "internal/app/upload imports github.com/aws/aws-sdk-go-v2/feature/s3/manager, 3 levels below github.com/aws/aws-sdk-go-v2",
+			rule:    "internal/app may import packages of github.com/aws/aws-sdk-go-v2 at most 1 level below the module",
+			fix:     "Reach github.com/aws/aws-sdk-go-v2/feature/s3/manager through a local facade package, or import github.com/aws/aws-sdk-go-v2/feature instead",
+			ruleKey: "rules.external_import_depth.layers.internal/app",
+		},
+		{
+			file:    "internal/infra/storage/s3.go",
+			line:    4,
+			issue:   "internal/infra/storage imports github.com/aws/aws-sdk-go-v2/service/s3/types, 3 levels below github.com/aws/aws-sdk-go-v2",
+			rule:    "internal may import packages of github.com/aws/aws-sdk-go-v2 at most 2 levels below the module",
+			ruleKey: "rules.external_import_depth.layers.internal",
+		},
+	}
+	if len(violations) != len(tests) {
+		t.Fatalf("expected %d import depth violations, got %d: %+v", len(tests), len(violations), violations)
+	}
+	for i, tt := range tests {
+		viol := violations[i]
+		if viol.File != tt.file || viol.Line != tt.line || viol.Issue != tt.issue || viol.Rule != tt.rule || viol.RuleKey != tt.ruleKey || (tt.fix != "" && viol.Fix != tt.fix) {
+			t.Errorf("violation %d: unexpected %+v", i, viol)
+		}
+	}
+}
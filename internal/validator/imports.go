@@ -1,8 +1,7 @@
 package validator
 
 import (
-	"fmt"
-	"path/filepath"
+	"path"
 	"strings"
 )
 
@@ -19,15 +18,15 @@ func (v *Validator) detectSharedExternalImports() []Violation {
 
 	// Build map: external package → [{file, layer, line}]
 	type importLocation struct {
-		file  string
-		layer string
-		line  int
+		file   string
+		layer  string
+		line   int
+		column int
 	}
 	externalImports := make(map[string][]importLocation)
 
 	for _, node := range v.graph.GetNodes() {
-		fileDir := filepath.Dir(node.GetRelPath())
-		fileDir = filepath.ToSlash(fileDir)
+		fileDir := path.Dir(node.GetRelPath())
 
 		// Determine which layer this file belongs to
 		fileLayer := v.getFileLayer(fileDir, layers)
@@ -35,12 +34,18 @@ func (v *Validator) detectSharedExternalImports() []Violation {
 			continue // File not in any configured layer
 		}
 
+		isTestFile := strings.HasSuffix(node.GetRelPath(), "_test.go")
 		for _, dep := range node.GetDependencies() {
 			// Only track external dependencies
 			if dep.IsLocalDep() {
 				continue
 			}
 
+			// Test frameworks exempted for test files do not make a package shared
+			if isTestFile && v.isExemptTestImport(fileDir, dep) {
+				continue
+			}
+
 			importPath := dep.GetImportPath()
 
 			// Skip standard library
@@ -49,9 +54,10 @@ func (v *Validator) detectSharedExternalImports() []Violation {
 			}
 
 			externalImports[importPath] = append(externalImports[importPath], importLocation{
-				file:  node.GetRelPath(),
-				layer: fileLayer,
-				line:  0, // Line number not available from current graph
+				file:   node.GetRelPath(),
+				layer:  fileLayer,
+				line:   dep.GetLine(),
+				column: dep.GetColumn(),
 			})
 		}
 	}
@@ -75,23 +81,22 @@ func (v *Validator) detectSharedExternalImports() []Violation {
 		}
 
 		// Create violation
-		var fileList []string
+		var fileList []Message
 		for _, loc := range locations {
-			fileList = append(fileList, fmt.Sprintf("%s (layer: %s)", loc.file, loc.layer))
+			fileList = append(fileList, NewMessage("violation.shared_external_import.location", "%s (layer: %s)", loc.file, loc.layer))
 		}
 
-		issue := fmt.Sprintf("External package '%s' imported by %d layers", pkg, len(layerSet))
-		rule := "External packages should typically be owned by a single layer"
-		fix := fmt.Sprintf("Consider: (1) Add '%s' to shared_external_imports.exclusions if it's a utility, or (2) Refactor to centralize usage in one layer", pkg)
-
 		violations = append(violations, Violation{
-			Type:  ViolationSharedExternalImport,
-			File:  locations[0].file, // First file for reference
-			Line:  0,
-			Issue: issue + "\n  Imported by:\n    - " + strings.Join(fileList, "\n    - "),
-			Rule:  rule,
-			Fix:   fix,
-		})
+			Type:   ViolationSharedExternalImport,
+			File:   locations[0].file, // First file for reference
+			Line:   locations[0].line,
+			Column: locations[0].column,
+		}.withText(
+			NewMessage("violation.shared_external_import.issue", "External package '%s' imported by %d layers\n  Imported by:\n    - %s",
+				pkg, len(layerSet), joinMessages(fileList, "\n    - ")),
+			NewMessage("violation.shared_external_import.rule", "External packages should typically be owned by a single layer"),
+			NewMessage("violation.shared_external_import.fix", "Consider: (1) Add '%s' to shared_external_imports.exclusions if it's a utility, or (2) Refactor to centralize usage in one layer", pkg),
+		))
 	}
 
 	return violations
@@ -126,7 +131,7 @@ func (v *Validator) isExcludedExternalPackage(pkg string) bool {
 
 	// Check glob patterns
 	for _, pattern := range v.cfg.GetSharedExternalImportsExclusionPatterns() {
-		matched, err := filepath.Match(pattern, pkg)
+		matched, err := path.Match(pattern, pkg)
 		if err != nil {
 			// Invalid pattern, skip
 			continue
@@ -0,0 +1,141 @@
+package validator
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// captureRef matches a capture reference ($1, $2, ...) in an allowed entry
+var captureRef = regexp.MustCompile(`\$[0-9]+`)
+
+// detectLayerCycles reports groups of directories_import keys that allow each other in a
+// cycle (A may import B, B may import A). Such rules are internally consistent, so no
+// import ever violates them, but the layers they describe have no direction left to
+// enforce. Each group is reported once, with one of its cycles spelled out.
+func (v *Validator) detectLayerCycles() []Violation {
+	var violations []Violation
+
+	rules := v.cfg.GetDirectoriesImport()
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, strings.TrimSuffix(key, "/"))
+	}
+	sort.Strings(keys)
+
+	edges := make(map[string][]string)
+	for key, allowed := range rules {
+		key = strings.TrimSuffix(key, "/")
+		edges[key] = layerRuleTargets(key, allowed, keys)
+	}
+
+	reaches := make(map[string]map[string]bool)
+	for _, key := range keys {
+		reaches[key] = reachableLayers(key, edges)
+	}
+
+	grouped := make(map[string]bool)
+	for _, key := range keys {
+		if grouped[key] || !reaches[key][key] {
+			continue
+		}
+
+		// The layers on a cycle through key are those it reaches that reach it back
+		var group []string
+		for _, other := range keys {
+			if other == key || (reaches[key][other] && reaches[other][key]) {
+				group = append(group, other)
+				grouped[other] = true
+			}
+		}
+
+		violations = append(violations, Violation{
+			Type: ViolationLayerCycle,
+			File: ".goarchlint",
+		}.withText(
+			NewMessage("violation.layer_cycle.issue", "directories_import rules form a cycle: %s", strings.Join(layerCycle(key, group, edges), " → ")),
+			NewMessage("violation.layer_cycle.rule", "Layers must form a hierarchy: if A may import B, B must not be allowed to import A, directly or through other layers"),
+			NewMessage("violation.layer_cycle.fix", "Decide which of %s depends on the other and remove the allowance in the opposite direction", strings.Join(group, ", ")),
+		))
+	}
+
+	return violations
+}
+
+// layerRuleTargets returns the keys a rule's allowed entries point at: the keys at or below
+// an allowed directory, or else the most specific key containing it. Captures ($1) stand
+// for the wildcard segments of pattern keys.
+func layerRuleTargets(from string, allowed []string, keys []string) []string {
+	targets := make(map[string]bool)
+	for _, entry := range allowed {
+		entry = captureRef.ReplaceAllString(strings.TrimSuffix(entry, "/"), "*")
+		containing := ""
+		covered := false
+		for _, key := range keys {
+			if isWithinDir(key, entry) {
+				targets[key] = true
+				covered = covered || key == entry
+			} else if isWithinDir(entry, key) && len(key) > len(containing) {
+				containing = key
+			}
+		}
+		if containing != "" && !covered {
+			targets[containing] = true
+		}
+	}
+	delete(targets, from)
+
+	result := make([]string, 0, len(targets))
+	for key := range targets {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// reachableLayers returns every key reachable from key through one or more rules
+func reachableLayers(key string, edges map[string][]string) map[string]bool {
+	reached := make(map[string]bool)
+	queue := []string{key}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range edges[current] {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return reached
+}
+
+// layerCycle returns a shortest cycle from key back to itself within group, starting and
+// ending with key
+func layerCycle(key string, group []string, edges map[string][]string) []string {
+	inGroup := make(map[string]bool)
+	for _, member := range group {
+		inGroup[member] = true
+	}
+
+	previous := make(map[string]string)
+	queue := []string{key}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range edges[current] {
+			if next == key {
+				cycle := []string{key}
+				for step := current; step != key; step = previous[step] {
+					cycle = append([]string{step}, cycle...)
+				}
+				return append([]string{key}, cycle...)
+			}
+			if _, seen := previous[next]; !seen && inGroup[next] {
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+	return []string{key}
+}
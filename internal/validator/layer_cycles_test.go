@@ -0,0 +1,96 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestDetectLayerCycles(t *testing.T) {
+	tests := []struct {
+		name              string
+		directoriesImport map[string][]string
+		wantIssues        []string
+	}{
+		{
+			name: "acyclic layering",
+			directoriesImport: map[string][]string{
+				"cmd":             {"internal/app", "internal/infra"},
+				"internal/app":    {"internal/domain"},
+				"internal/infra":  {"internal/domain"},
+				"internal/domain": {},
+			},
+		},
+		{
+			name: "direct cycle",
+			directoriesImport: map[string][]string{
+				"internal/app":    {"internal/domain"},
+				"internal/domain": {"internal/app"},
+			},
+			wantIssues: []string{"directories_import rules form a cycle: internal/app → internal/domain → internal/app"},
+		},
+		{
+			name: "cycle through a third layer",
+			directoriesImport: map[string][]string{
+				"cmd":             {"internal/app"},
+				"internal/app":    {"internal/domain"},
+				"internal/domain": {"internal/infra"},
+				"internal/infra":  {"internal/app"},
+			},
+			wantIssues: []string{"directories_import rules form a cycle: internal/app → internal/domain → internal/infra → internal/app"},
+		},
+		{
+			name: "allowing a parent directory reaches the layers below it",
+			directoriesImport: map[string][]string{
+				"internal/app":    {"internal"},
+				"internal/domain": {"internal/app/orders"},
+			},
+			wantIssues: []string{"directories_import rules form a cycle: internal/app → internal/domain → internal/app"},
+		},
+		{
+			name: "captures resolve to pattern keys",
+			directoriesImport: map[string][]string{
+				"internal/modules/*":     {"internal/modules/$1/api", "internal/shared"},
+				"internal/modules/*/api": {"internal/modules"},
+				"internal/shared":        {},
+			},
+			wantIssues: []string{"directories_import rules form a cycle: internal/modules/* → internal/modules/*/api → internal/modules/*"},
+		},
+		{
+			name: "separate cycles are reported separately",
+			directoriesImport: map[string][]string{
+				"internal/a": {"internal/b"},
+				"internal/b": {"internal/a"},
+				"pkg/x":      {"pkg/y/"},
+				"pkg/y":      {"pkg/x"},
+			},
+			wantIssues: []string{
+				"directories_import rules form a cycle: internal/a → internal/b → internal/a",
+				"directories_import rules form a cycle: pkg/x → pkg/y → pkg/x",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &testConfig{directoriesImport: tt.directoriesImport}
+			violations := validator.New(cfg, &testGraph{}).Validate()
+
+			var issues []string
+			for _, viol := range violations {
+				if viol.Type != validator.ViolationLayerCycle {
+					t.Errorf("unexpected violation: %s", viol.Type)
+					continue
+				}
+				if viol.File != ".goarchlint" || viol.RuleKey != "rules.directories_import" || !viol.IsError() {
+					t.Errorf("expected an error on .goarchlint for rules.directories_import, got %s %s (%s)", viol.GetSeverity(), viol.File, viol.RuleKey)
+				}
+				issues = append(issues, viol.Issue)
+			}
+			if strings.Join(issues, "\n") != strings.Join(tt.wantIssues, "\n") {
+				t.Errorf("expected issues:\n%s\ngot:\n%s", strings.Join(tt.wantIssues, "\n"), strings.Join(issues, "\n"))
+			}
+		})
+	}
+}
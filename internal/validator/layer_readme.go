@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// validateLayerReadmes checks that every directory listed in require_readme has a non-empty
+// README.md, so the intent of each layer is written down next to the rules that enforce it.
+// Directories that do not exist are left to required_directories.
+func (v *Validator) validateLayerReadmes() []Violation {
+	var violations []Violation
+
+	dirs := append([]string(nil), v.cfg.GetReadmeDirectories()...)
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		dir = strings.TrimSuffix(dir, "/")
+		entries, err := v.readDir(dir)
+		if err != nil {
+			continue
+		}
+
+		issue := NewMessage("violation.missing_readme.issue", "Directory '%s' has no README.md describing the layer", dir)
+		documented := false
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.EqualFold(entry.Name(), "README.md") {
+				continue
+			}
+			content, err := v.readFile(filepath.Join(dir, entry.Name()))
+			if err == nil && strings.TrimSpace(string(content)) != "" {
+				documented = true
+			} else {
+				issue = NewMessage("violation.missing_readme.issue_empty", "README.md of '%s' is empty", dir)
+			}
+			break
+		}
+		if documented {
+			continue
+		}
+
+		violations = append(violations, Violation{
+			Type: ViolationMissingReadme,
+			File: dir,
+		}.withText(
+			issue,
+			NewMessage("violation.missing_readme.rule", "Layers listed in require_readme must document their purpose in a README.md"),
+			NewMessage("violation.missing_readme.fix", "Write %s/README.md explaining what belongs in %s and what it may depend on", dir, filepath.Base(dir)),
+		))
+	}
+
+	return violations
+}
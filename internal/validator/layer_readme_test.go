@@ -0,0 +1,51 @@
+package validator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestValidateLayerReadmes(t *testing.T) {
+	projectPath := t.TempDir()
+	files := map[string]string{
+		"internal/domain/README.md": "# Domain\n\nEntities and business rules. Imports nothing.\n",
+		"internal/app/app.go":       "package app\n",
+		"internal/infra/readme.md":  "  \n",
+	}
+	for relPath, content := range files {
+		fullPath := filepath.Join(projectPath, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &testConfig{
+		directoriesImport: map[string][]string{"internal": {}},
+		// internal/ports does not exist: that is for required_directories to report
+		readmeDirectories: []string{"internal/domain", "internal/app/", "internal/infra", "internal/ports"},
+	}
+	violations := validator.NewWithPath(cfg, &testGraph{}, projectPath).Validate()
+
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %d: %v", len(violations), violations)
+	}
+	want := []struct{ file, issue string }{
+		{"internal/app", "Directory 'internal/app' has no README.md describing the layer"},
+		{"internal/infra", "README.md of 'internal/infra' is empty"},
+	}
+	for i, w := range want {
+		viol := violations[i]
+		if viol.Type != validator.ViolationMissingReadme || viol.File != w.file || viol.Issue != w.issue {
+			t.Errorf("violation %d: expected %q for %s, got %s %q for %s", i, w.issue, w.file, viol.Type, viol.Issue, viol.File)
+		}
+		if viol.RuleKey != "structure.require_readme" || !viol.IsError() {
+			t.Errorf("violation %d: expected an error of structure.require_readme, got %s (%s)", i, viol.GetSeverity(), viol.RuleKey)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// validateLicensePolicy checks that the production code of each layer with a license
+// policy only imports modules under an allowed license. A policy entry allows a license
+// category ("permissive", "weak-copyleft", "copyleft", "unknown") or an SPDX identifier.
+func (v *Validator) validateLicensePolicy() []Violation {
+	var violations []Violation
+
+	policy := v.cfg.GetLicensePolicyLayers()
+	exceptions := make(map[string]bool)
+	for _, module := range v.cfg.GetLicensePolicyExceptions() {
+		exceptions[module] = true
+	}
+
+	for _, node := range v.graph.GetNodes() {
+		if strings.HasSuffix(node.GetRelPath(), "_test.go") {
+			continue
+		}
+		fileDir := path.Dir(node.GetRelPath())
+		layer := policyLayerOf(fileDir, policy)
+		if layer == "" {
+			continue
+		}
+		allowed := policy[layer]
+
+		for _, dep := range node.GetDependencies() {
+			if dep.IsLocalDep() || isStdLib(dep.GetImportPath()) {
+				continue
+			}
+			license, ok := v.moduleLicenseOf(dep.GetImportPath())
+			if !ok || exceptions[license.GetModule()] || licenseAllowed(license, allowed) {
+				continue
+			}
+
+			issue := NewMessage("violation.license_policy.issue", "%s imports %s, licensed %s (%s)", fileDir, dep.GetImportPath(), license.GetLicense(), license.GetCategory())
+			fix := NewMessage("violation.license_policy.fix", "Replace %s with a module under an allowed license, move its use to a layer whose policy allows %s, or add it to license_policy.exceptions after review",
+				license.GetModule(), license.GetLicense())
+			if license.GetLicense() == "" {
+				issue = NewMessage("violation.license_policy.issue_unknown", "%s imports %s, whose license could not be detected", fileDir, dep.GetImportPath())
+				fix = NewMessage("violation.license_policy.fix_unknown", "Run 'go mod download' so the license of %s can be read from the module cache, or review it and add the module to license_policy.exceptions",
+					license.GetModule())
+			}
+
+			violations = append(violations, Violation{
+				Type:    ViolationLicensePolicy,
+				File:    node.GetRelPath(),
+				Line:    dep.GetLine(),
+				Column:  dep.GetColumn(),
+				RuleKey: "rules.license_policy.layers." + layer,
+			}.withText(
+				issue,
+				NewMessage("violation.license_policy.rule", "%s may only depend on modules licensed: %s", layer, strings.Join(allowed, ", ")),
+				fix,
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+
+	return violations
+}
+
+// moduleLicenseOf returns the license of the longest required module containing
+// importPath. It reports false for imports of modules that are not required.
+func (v *Validator) moduleLicenseOf(importPath string) (ModuleLicense, bool) {
+	var best ModuleLicense
+	for _, license := range v.licenses {
+		module := license.GetModule()
+		if (importPath == module || strings.HasPrefix(importPath, module+"/")) && (best == nil || len(module) > len(best.GetModule())) {
+			best = license
+		}
+	}
+	return best, best != nil
+}
+
+// policyLayerOf returns the most specific policy directory containing dir, or "" if no
+// policy applies
+func policyLayerOf(dir string, policy map[string][]string) string {
+	best := ""
+	for layer := range policy {
+		if isWithinDir(dir, layer) && len(layer) > len(best) {
+			best = layer
+		}
+	}
+	return best
+}
+
+// licenseAllowed reports whether a policy entry names the category or the SPDX
+// identifier of a license
+func licenseAllowed(license ModuleLicense, allowed []string) bool {
+	for _, entry := range allowed {
+		if strings.EqualFold(entry, license.GetCategory()) || (license.GetLicense() != "" && strings.EqualFold(entry, license.GetLicense())) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testModuleLicense struct {
+	module   string
+	license  string
+	category string
+}
+
+func (tml *testModuleLicense) GetModule() string   { return tml.module }
+func (tml *testModuleLicense) GetLicense() string  { return tml.license }
+func (tml *testModuleLicense) GetCategory() string { return tml.category }
+
+func externalImportNode(relPath string, importPaths ...string) *testFileNode {
+	node := &testFileNode{relPath: relPath}
+	for i, importPath := range importPaths {
+		node.dependencies = append(node.dependencies, &testDependency{importPath: importPath, line: 3 + i, column: 2})
+	}
+	return node
+}
+
+func TestValidateLicensePolicy(t *testing.T) {
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		licensePolicyLayers: map[string][]string{
+			"internal":        {"permissive", "weak-copyleft"},
+			"internal/domain": {"permissive"}, // Most specific directory wins
+			"internal/infra":  {"permissive", "GPL-3.0"},
+		},
+		licensePolicyExceptions: []string{"example.com/reviewed"},
+	}
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			externalImportNode("internal/domain/order/order.go",
+				"fmt",
+				"github.com/shopspring/decimal",
+				"github.com/hashicorp/go-memdb",
+				"example.com/gpl/v2/sub",
+				"example.com/reviewed",
+				"example.com/unknown",
+				"example.com/unrequired",
+			),
+			externalImportNode("internal/app/app.go", "github.com/hashicorp/go-memdb", "example.com/gpl/v2"),
+			externalImportNode("internal/infra/db.go", "example.com/gpl/v2"),
+			externalImportNode("internal/domain/order/order_test.go", "example.com/gpl/v2"),
+			externalImportNode("cmd/app/main.go", "example.com/gpl/v2"),
+		},
+	}
+
+	v := validator.New(cfg, g)
+	v.SetModuleLicenses([]validator.ModuleLicense{
+		&testModuleLicense{module: "github.com/shopspring/decimal", license: "MIT", category: "permissive"},
+		&testModuleLicense{module: "github.com/hashicorp/go-memdb", license: "MPL-2.0", category: "weak-copyleft"},
+		&testModuleLicense{module: "example.com/gpl", license: "MIT", category: "permissive"},
+		&testModuleLicense{module: "example.com/gpl/v2", license: "GPL-3.0", category: "copyleft"}, // Longest module wins
+		&testModuleLicense{module: "example.com/reviewed", license: "AGPL-3.0", category: "copyleft"},
+		&testModuleLicense{module: "example.com/unknown", category: "unknown"},
+	})
+
+	var violations []validator.Violation
+	for _, viol := range v.Validate() {
+		if viol.Type == validator.ViolationLicensePolicy {
+			violations = append(violations, viol)
+		}
+	}
+
+	if len(violations) != 4 {
+		t.Fatalf("expected 4 license violations, got %d: %+v", len(violations), violations)
+	}
+
+	tests := []struct {
+		file    string
+		issue   string
+		rule    string
+		ruleKey string
+	}{
+		{"internal/app/app.go", "internal/app imports example.com/gpl/v2, licensed GPL-3.0 (copyleft)", "internal may only depend on modules licensed: permissive, weak-copyleft", "rules.license_policy.layers.internal"},
+		{"internal/domain/order/order.go", "internal/domain/order imports github.com/hashicorp/go-memdb, licensed MPL-2.0 (weak-copyleft)", "internal/domain may only depend on modules licensed: permissive", "rules.license_policy.layers.internal/domain"},
+		{"internal/domain/order/order.go", "internal/domain/order imports example.com/gpl/v2/sub, licensed GPL-3.0 (copyleft)", "", ""},
+		{"internal/domain/order/order.go", "internal/domain/order imports example.com/unknown, whose license could not be detected", "", ""},
+	}
+	for i, tt := range tests {
+		viol := violations[i]
+		if viol.File != tt.file || viol.Issue != tt.issue || (tt.rule != "" && viol.Rule != tt.rule) || (tt.ruleKey != "" && viol.RuleKey != tt.ruleKey) {
+			t.Errorf("violation %d: unexpected %+v", i, viol)
+		}
+	}
+	if !strings.Contains(violations[1].Fix, "license_policy.exceptions") || !strings.Contains(violations[3].Fix, "go mod download") {
+		t.Errorf("expected fixes to name exceptions and go mod download, got: %q, %q", violations[1].Fix, violations[3].Fix)
+	}
+}
@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// validateMainPackageLocations checks that every main package lives in one of the approved
+// locations of main_packages, so stray binaries (debug tools, copied examples) do not
+// accumulate outside the places the team expects them.
+func (v *Validator) validateMainPackageLocations() []Violation {
+	var violations []Violation
+
+	locations := v.cfg.GetMainPackageLocations()
+	reported := make(map[string]bool)
+
+	for _, node := range v.graph.GetNodes() {
+		relPath := node.GetRelPath()
+		if node.GetPackage() != "main" || strings.HasSuffix(relPath, "_test.go") {
+			continue
+		}
+		dir := path.Dir(relPath)
+		if reported[dir] || isApprovedMainLocation(dir, locations) {
+			continue
+		}
+		reported[dir] = true
+
+		violations = append(violations, Violation{
+			Type:   ViolationMainPackageLocation,
+			File:   relPath,
+			Line:   node.GetPackageLine(),
+			Column: node.GetPackageColumn(),
+		}.withText(
+			NewMessage("violation.main_package_location.issue", "main package %s is outside the approved locations", dir),
+			NewMessage("violation.main_package_location.rule", "Main packages may only live in: %s", strings.Join(locations, ", ")),
+			NewMessage("violation.main_package_location.fix", "Move the program to an approved location (e.g. cmd/%s), or add %s to main_packages", filepath.Base(dir), dir),
+		))
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].File < violations[j].File
+	})
+
+	return violations
+}
+
+// isApprovedMainLocation reports whether dir is within an approved directory or matches
+// an approved glob pattern with **
+func isApprovedMainLocation(dir string, locations []string) bool {
+	for _, location := range locations {
+		location = strings.TrimSuffix(location, "/")
+		if isWithinDir(dir, location) || matchSegments(strings.Split(location, "/"), strings.Split(dir, "/")) {
+			return true
+		}
+	}
+	return false
+}
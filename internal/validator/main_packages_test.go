@@ -0,0 +1,42 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestValidateMainPackageLocations(t *testing.T) {
+	cfg := &testConfig{
+		module:               "github.com/test/project",
+		mainPackageLocations: []string{"cmd", "tools/**/gen"},
+	}
+
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{relPath: "cmd/api/main.go", pkg: "main"},
+			&testFileNode{relPath: "tools/proto/gen/main.go", pkg: "main"},
+			&testFileNode{relPath: "internal/debug/main.go", pkg: "main", packageLine: 1, packageColumn: 1},
+			&testFileNode{relPath: "internal/debug/flags.go", pkg: "main"},    // Reported once per package
+			&testFileNode{relPath: "examples/demo/main_test.go", pkg: "main"}, // Test files alone are no program
+			&testFileNode{relPath: "internal/app/app.go", pkg: "app"},
+		},
+	}
+
+	var violations []validator.Violation
+	for _, violation := range validator.New(cfg, g).Validate() {
+		if violation.Type == validator.ViolationMainPackageLocation {
+			violations = append(violations, violation)
+		}
+	}
+
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 main package violation, got %d: %+v", len(violations), violations)
+	}
+	if violations[0].File != "internal/debug/main.go" || violations[0].Line != 1 {
+		t.Errorf("expected violation at internal/debug/main.go:1, got %s:%d", violations[0].File, violations[0].Line)
+	}
+	if violations[0].RuleKey != "rules.main_packages" {
+		t.Errorf("expected rule key rules.main_packages, got %s", violations[0].RuleKey)
+	}
+}
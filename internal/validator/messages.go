@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Message is one translatable part of a violation text: its English format and
+// arguments, and the ID under which the output catalogs translate the format. Arguments
+// that are Messages themselves are translated too. Texts taken from the configuration
+// have no ID and are printed as they are.
+type Message struct {
+	ID     string
+	Format string
+	Args   []any
+}
+
+// NewMessage returns the message with the given ID, English format and arguments
+func NewMessage(id, format string, args ...any) Message {
+	return Message{ID: id, Format: format, Args: args}
+}
+
+// Literal returns a message that is never translated, for texts from the configuration
+func Literal(text string) Message {
+	return Message{Format: text}
+}
+
+// String returns the message in English
+func (m Message) String() string {
+	return m.render(nil)
+}
+
+// render formats the message with the format translate returns for its ID, or with the
+// English format if translate is nil or the message has no ID
+func (m Message) render(translate func(id, format string) string) string {
+	format := m.Format
+	if translate != nil && m.ID != "" {
+		format = translate(m.ID, m.Format)
+	}
+	if len(m.Args) == 0 {
+		return format
+	}
+
+	args := make([]any, len(m.Args))
+	for i, arg := range m.Args {
+		if nested, ok := arg.(Message); ok {
+			arg = nested.render(translate)
+		}
+		args[i] = arg
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// joinMessages returns a message of the messages separated by sep, which is not translated
+func joinMessages(messages []Message, sep string) Message {
+	args := make([]any, len(messages))
+	for i, m := range messages {
+		args[i] = m
+	}
+	sep = strings.ReplaceAll(sep, "%", "%%")
+	return Message{Format: strings.TrimSuffix(strings.Repeat("%s"+sep, len(messages)), sep), Args: args}
+}
+
+// localize renders the concatenation of messages with translate. text is returned as it
+// is if the messages do not make it up in English, e.g. because it was set without
+// messages or rewritten after the violation was reported.
+func localize(text string, messages []Message, translate func(id, format string) string) string {
+	if len(messages) == 0 {
+		return text
+	}
+
+	var english, translated strings.Builder
+	for _, m := range messages {
+		english.WriteString(m.String())
+		translated.WriteString(m.render(translate))
+	}
+	if english.String() != text {
+		return text
+	}
+	return translated.String()
+}
+
+// withText returns the violation with its issue, rule and fix set from messages
+func (v Violation) withText(issue, rule, fix Message) Violation {
+	v.Issue, v.IssueMessages = issue.String(), []Message{issue}
+	v.Rule, v.RuleMessages = rule.String(), []Message{rule}
+	v.Fix, v.FixMessages = fix.String(), []Message{fix}
+	return v
+}
+
+// AppendIssue appends a message to the issue of the violation
+func (v *Violation) AppendIssue(m Message) {
+	v.Issue += m.String()
+	v.IssueMessages = append(v.IssueMessages, m)
+}
+
+// AppendRule appends a message to the rule of the violation
+func (v *Violation) AppendRule(m Message) {
+	v.Rule += m.String()
+	v.RuleMessages = append(v.RuleMessages, m)
+}
+
+// Localize implements output.Violation interface
+func (v Violation) Localize(translate func(id, format string) string) (string, string, string) {
+	return localize(v.Issue, v.IssueMessages, translate),
+		localize(v.Rule, v.RuleMessages, translate),
+		localize(v.Fix, v.FixMessages, translate)
+}
+
+// withIssue returns the span with its issue set from a message
+func (s Span) withIssue(issue Message) Span {
+	s.Issue, s.IssueMessage = issue.String(), issue
+	return s
+}
+
+// Localize implements output.Span interface
+func (s Span) Localize(translate func(id, format string) string) string {
+	if s.IssueMessage.Format == "" {
+		return s.Issue
+	}
+	return localize(s.Issue, []Message{s.IssueMessage}, translate)
+}
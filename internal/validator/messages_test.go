@@ -0,0 +1,54 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestViolation_Localize(t *testing.T) {
+	german := map[string]string{
+		"violation.import.issue":  "%s importiert %s",
+		"violation.in_day":        "in 1 Tag",
+		"violation.enforced_from": " (durchgesetzt ab %s, %s)",
+	}
+	translate := func(id, format string) string {
+		if text, ok := german[id]; ok {
+			return text
+		}
+		return format
+	}
+
+	viol := validator.Violation{
+		Issue:         "internal/order imports internal/store",
+		IssueMessages: []validator.Message{validator.NewMessage("violation.import.issue", "%s imports %s", "internal/order", "internal/store")},
+		Rule:          "internal can only import from: []",
+		RuleMessages:  []validator.Message{validator.Literal("internal can only import from: []")},
+		Fix:           "Use interfaces",
+	}
+	viol.AppendRule(validator.NewMessage("violation.enforced_from", " (enforced from %s, %s)", "2030-01-01",
+		validator.NewMessage("violation.in_day", "in 1 day")))
+
+	if viol.Rule != "internal can only import from: [] (enforced from 2030-01-01, in 1 day)" {
+		t.Errorf("expected the appended message in the English rule, got %q", viol.Rule)
+	}
+
+	issue, rule, fix := viol.Localize(translate)
+	if issue != "internal/order importiert internal/store" {
+		t.Errorf("unexpected issue %q", issue)
+	}
+	// Literals stay as they are, nested messages are translated
+	if rule != "internal can only import from: [] (durchgesetzt ab 2030-01-01, in 1 Tag)" {
+		t.Errorf("unexpected rule %q", rule)
+	}
+	// Texts without messages stay English
+	if fix != "Use interfaces" {
+		t.Errorf("unexpected fix %q", fix)
+	}
+
+	// A text rewritten after the violation was reported no longer matches its messages
+	viol.Issue = "internal/billing imports internal/store"
+	if issue, _, _ := viol.Localize(translate); issue != "internal/billing imports internal/store" {
+		t.Errorf("expected the rewritten issue in English, got %q", issue)
+	}
+}
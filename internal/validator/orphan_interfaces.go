@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// detectOrphanInterfaces reports interfaces declared in the configured consumer layers that
+// no concrete type satisfies. Ports without an adapter are dead code that still shapes the
+// design, and they accumulate quietly as implementations are removed or renamed. The
+// findings are informational: an implementation may live outside the scanned code.
+func (v *Validator) detectOrphanInterfaces() []Violation {
+	var violations []Violation
+
+	for _, iface := range v.interfaces {
+		fileDir := path.Dir(iface.GetFile())
+		if iface.IsImplemented() || !v.isOrphanInterfaceLayer(fileDir) {
+			continue
+		}
+
+		violations = append(violations, Violation{
+			Type:     ViolationOrphanInterface,
+			File:     iface.GetFile(),
+			Line:     iface.GetLine(),
+			Column:   iface.GetColumn(),
+			Severity: SeverityInfo,
+		}.withText(
+			NewMessage("violation.orphan_interface.issue", "Interface %s.%s is not implemented by any type in the codebase", filepath.Base(fileDir), iface.GetName()),
+			NewMessage("violation.orphan_interface.rule", "Interfaces in consumer layers should have at least one implementation"),
+			NewMessage("violation.orphan_interface.fix", "Remove %s if it is no longer needed, or add the adapter that implements it", iface.GetName()),
+		))
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+
+	return violations
+}
+
+// isOrphanInterfaceLayer checks if a directory belongs to one of the layers checked for orphan interfaces
+func (v *Validator) isOrphanInterfaceLayer(fileDir string) bool {
+	for _, layer := range v.cfg.GetOrphanInterfaceLayers() {
+		if isWithinDir(fileDir, strings.TrimSuffix(layer, "/")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testInterfaceDecl struct {
+	file         string
+	line         int
+	name         string
+	implemented  bool
+	implementers []string
+}
+
+func (ti *testInterfaceDecl) GetFile() string           { return ti.file }
+func (ti *testInterfaceDecl) GetLine() int              { return ti.line }
+func (ti *testInterfaceDecl) GetColumn() int            { return 6 }
+func (ti *testInterfaceDecl) GetName() string           { return ti.name }
+func (ti *testInterfaceDecl) IsImplemented() bool       { return ti.implemented }
+func (ti *testInterfaceDecl) GetImplementers() []string { return ti.implementers }
+
+func TestDetectOrphanInterfaces(t *testing.T) {
+	cfg := &testConfig{
+		directoriesImport:     map[string][]string{"internal": {}},
+		orphanInterfaceLayers: []string{"internal/ports/"},
+	}
+	v := validator.New(cfg, &testGraph{})
+	v.SetInterfaces([]validator.InterfaceDecl{
+		&testInterfaceDecl{file: "internal/ports/notifier.go", line: 12, name: "Notifier"},
+		&testInterfaceDecl{file: "internal/ports/repository.go", line: 5, name: "OrderRepository", implemented: true},
+		// Outside the configured layers
+		&testInterfaceDecl{file: "internal/infra/db/db.go", line: 3, name: "Querier"},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Type != validator.ViolationOrphanInterface || viol.File != "internal/ports/notifier.go" || viol.Line != 12 {
+		t.Errorf("expected orphan interface at internal/ports/notifier.go:12, got %s at %s:%d", viol.Type, viol.File, viol.Line)
+	}
+	if viol.Issue != "Interface ports.Notifier is not implemented by any type in the codebase" {
+		t.Errorf("unexpected issue: %s", viol.Issue)
+	}
+	if viol.IsError() || viol.RuleKey != "rules.orphan_interfaces" {
+		t.Errorf("expected an informational finding of rules.orphan_interfaces, got %s (%s)", viol.GetSeverity(), viol.RuleKey)
+	}
+}
@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// validateOwnershipBoundaries checks that packages only reach into packages owned by
+// another team through that team's public contract packages
+func (v *Validator) validateOwnershipBoundaries() []Violation {
+	var violations []Violation
+
+	teams := v.cfg.GetOwnershipTeams()
+	contracts := v.cfg.GetOwnershipContracts()
+
+	for _, node := range v.graph.GetNodes() {
+		fileDir := path.Dir(node.GetRelPath())
+		fileTeam := OwnerOf(fileDir, teams)
+		if fileTeam == "" {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, dep := range node.GetDependencies() {
+			depDir := dep.GetLocalPath()
+			if !dep.IsLocalDep() || seen[depDir] {
+				continue
+			}
+			seen[depDir] = true
+
+			depTeam := OwnerOf(depDir, teams)
+			if depTeam == "" || depTeam == fileTeam || isContractPackage(depDir, contracts) {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				Type:   ViolationCrossTeamImport,
+				File:   node.GetRelPath(),
+				Line:   dep.GetLine(),
+				Column: dep.GetColumn(),
+			}.withText(
+				NewMessage("violation.cross_team_import.issue", "%s (team %s) imports %s, an internal package of team %s", fileDir, fileTeam, depDir, depTeam),
+				NewMessage("violation.cross_team_import.rule", "Packages of other teams may only be used through public contract packages (%s)", strings.Join(contracts, ", ")),
+				NewMessage("violation.cross_team_import.fix", "Use a contract package of team %s instead, or ask them to expose what you need in one", depTeam),
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].File < violations[j].File
+	})
+
+	return violations
+}
+
+// OwnerOf returns the team owning dir according to teams (directory subtree -> team);
+// the most specific configured directory wins. It returns "" for unowned directories.
+func OwnerOf(dir string, teams map[string]string) string {
+	owner := ""
+	ownerDir := ""
+	for teamDir, team := range teams {
+		teamDir = strings.TrimSuffix(teamDir, "/")
+		if isWithinDir(dir, teamDir) && len(teamDir) > len(ownerDir) {
+			owner = team
+			ownerDir = teamDir
+		}
+	}
+	return owner
+}
+
+// isContractPackage reports whether dir or one of its parents matches a contract pattern
+func isContractPackage(dir string, patterns []string) bool {
+	segments := strings.Split(dir, "/")
+	for i := len(segments); i > 0; i-- {
+		candidate := segments[:i]
+		for _, pattern := range patterns {
+			if matchSegments(strings.Split(pattern, "/"), candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against a glob pattern where "**" spans any
+// number of segments (including none) and other segments use path.Match syntax
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(pattern[0], segments[0]); err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,120 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func ownershipConfig() *testConfig {
+	return &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal": {"internal"},
+		},
+		ownershipTeams: map[string]string{
+			"internal/payments":        "payments",
+			"internal/identity":        "identity",
+			"internal/identity/shared": "platform", // Most specific directory wins
+		},
+		ownershipContracts: []string{"**/api", "**/contract"},
+	}
+}
+
+func ownershipNode(relPath string, localPaths ...string) *testFileNode {
+	node := &testFileNode{relPath: relPath}
+	for _, localPath := range localPaths {
+		node.dependencies = append(node.dependencies, &testDependency{
+			importPath: "github.com/test/project/" + localPath,
+			localPath:  localPath,
+			isLocal:    true,
+		})
+	}
+	return node
+}
+
+func TestValidateOwnershipBoundaries(t *testing.T) {
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			// Direct access to another team's internals
+			ownershipNode("internal/payments/charge/charge.go",
+				"internal/identity/users",
+				"internal/identity/users", // Reported once per package
+				"internal/identity/api",
+				"internal/identity/contract/v1",
+				"internal/payments/ledger",
+			),
+			// A nested directory owned by another team
+			ownershipNode("internal/identity/users/users.go", "internal/identity/shared/crypto"),
+			// Unowned packages are not checked, in either direction
+			ownershipNode("internal/tools/tool.go", "internal/payments/ledger"),
+			ownershipNode("internal/payments/ledger/ledger.go", "internal/tools"),
+		},
+	}
+
+	v := validator.New(ownershipConfig(), g)
+	var violations []validator.Violation
+	for _, viol := range v.Validate() {
+		if viol.Type == validator.ViolationCrossTeamImport {
+			violations = append(violations, viol)
+		}
+	}
+
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 cross-team violations, got %d: %+v", len(violations), violations)
+	}
+
+	first := violations[0]
+	if first.File != "internal/identity/users/users.go" || !strings.Contains(first.Issue, "internal/identity/shared/crypto, an internal package of team platform") {
+		t.Errorf("unexpected violation: %+v", first)
+	}
+
+	second := violations[1]
+	if second.File != "internal/payments/charge/charge.go" || !strings.Contains(second.Issue, "(team payments) imports internal/identity/users") {
+		t.Errorf("unexpected violation: %+v", second)
+	}
+	if !strings.Contains(second.Rule, "**/api, **/contract") || !strings.Contains(second.Fix, "team identity") {
+		t.Errorf("expected contract patterns in rule and owning team in fix, got: %+v", second)
+	}
+}
+
+func TestValidateOwnershipBoundaries_CustomContracts(t *testing.T) {
+	cfg := ownershipConfig()
+	cfg.ownershipContracts = []string{"internal/identity/users", "internal/*/public*"}
+
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			ownershipNode("internal/payments/charge/charge.go",
+				"internal/identity/users",
+				"internal/identity/publicapi",
+				"internal/identity/api",
+			),
+		},
+	}
+
+	v := validator.New(cfg, g)
+	violations := v.Validate()
+
+	if len(violations) != 1 || violations[0].Type != validator.ViolationCrossTeamImport || !strings.Contains(violations[0].Issue, "imports internal/identity/api,") {
+		t.Errorf("expected only the non-contract api package to be reported, got: %+v", violations)
+	}
+}
+
+func TestValidateOwnershipBoundaries_Disabled(t *testing.T) {
+	cfg := ownershipConfig()
+	cfg.ownershipTeams = nil
+
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			ownershipNode("internal/payments/charge/charge.go", "internal/identity/users"),
+		},
+	}
+
+	v := validator.New(cfg, g)
+	for _, viol := range v.Validate() {
+		if viol.Type == validator.ViolationCrossTeamImport {
+			t.Errorf("expected no ownership violations without teams, got: %+v", viol)
+		}
+	}
+}
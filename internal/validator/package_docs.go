@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// validatePackageDocs checks that every package in the configured layers has a package doc comment
+func (v *Validator) validatePackageDocs() []Violation {
+	var violations []Violation
+
+	layers := v.cfg.GetPackageDocLayers()
+
+	// A package is documented if any of its non-test files carries the doc comment
+	packageNames := make(map[string]string)
+	documented := make(map[string]bool)
+	for _, file := range v.sourceFiles {
+		if file.GetIsTest() {
+			continue
+		}
+		dir := path.Dir(file.GetRelPath())
+		packageNames[dir] = file.GetPackage()
+		if file.GetPackageDoc() != "" {
+			documented[dir] = true
+		}
+	}
+
+	for dir, pkg := range packageNames {
+		if documented[dir] {
+			continue
+		}
+
+		layer := ""
+		for _, l := range layers {
+			if isWithinDir(dir, strings.TrimSuffix(l, "/")) {
+				layer = l
+				break
+			}
+		}
+		if layer == "" {
+			continue
+		}
+
+		violations = append(violations, Violation{
+			Type: ViolationMissingPackageDoc,
+			File: dir,
+		}.withText(
+			NewMessage("violation.missing_package_doc.issue", "Package %s has no package doc comment", pkg),
+			NewMessage("violation.missing_package_doc.rule", "Packages in %s must describe their purpose in a package doc comment", layer),
+			NewMessage("violation.missing_package_doc.fix", "Add a comment starting with \"// Package %s\" directly above the package clause (e.g. in %s/doc.go)", pkg, dir),
+		))
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].File < violations[j].File
+	})
+
+	return violations
+}
@@ -0,0 +1,58 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestValidatePackageDocs(t *testing.T) {
+	cfg := &testConfig{
+		module:           "github.com/test/project",
+		packageDocLayers: []string{"pkg", "internal/domain/"},
+	}
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		// Documented by one of its files
+		&testSourceFile{relPath: "pkg/client/doc.go", pkg: "client", packageDoc: "Package client talks to the API."},
+		&testSourceFile{relPath: "pkg/client/client.go", pkg: "client"},
+		// Only the test file is documented
+		&testSourceFile{relPath: "pkg/server/server.go", pkg: "server"},
+		&testSourceFile{relPath: "pkg/server/server_test.go", pkg: "server_test", isTest: true, packageDoc: "Package server_test."},
+		// Nested layer directory
+		&testSourceFile{relPath: "internal/domain/order/order.go", pkg: "order"},
+		// Not in a documented layer
+		&testSourceFile{relPath: "internal/store/store.go", pkg: "store"},
+		&testSourceFile{relPath: "cmd/app/main.go", pkg: "main"},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %d: %+v", len(violations), violations)
+	}
+
+	if violations[0].Type != validator.ViolationMissingPackageDoc || violations[0].File != "internal/domain/order" {
+		t.Errorf("unexpected first violation: %+v", violations[0])
+	}
+	if !strings.Contains(violations[0].Rule, "internal/domain/") || !strings.Contains(violations[0].Fix, "// Package order") {
+		t.Errorf("expected layer in rule and package name in fix, got: %+v", violations[0])
+	}
+	if violations[1].File != "pkg/server" || violations[1].Issue != "Package server has no package doc comment" {
+		t.Errorf("unexpected second violation: %+v", violations[1])
+	}
+}
+
+func TestValidatePackageDocs_Disabled(t *testing.T) {
+	cfg := &testConfig{module: "github.com/test/project"}
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{relPath: "pkg/server/server.go", pkg: "server"},
+	})
+
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations without layers, got: %+v", violations)
+	}
+}
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// majorVersionDir matches module major version directories (v2, v3, ...)
+var majorVersionDir = regexp.MustCompile(`^v[0-9]+$`)
+
+// validatePackageNaming checks that each directory holds a single package whose name
+// matches the directory. Mixed or mismatched names confuse readers and make the
+// directory-based dependency graph misleading.
+func (v *Validator) validatePackageNaming() []Violation {
+	var violations []Violation
+
+	// Collect package names per directory (external test packages count as their package)
+	dirPackages := make(map[string]map[string]bool)
+	for _, node := range v.graph.GetNodes() {
+		dir := path.Dir(node.GetRelPath())
+		pkgName := node.GetPackage()
+		if strings.HasSuffix(node.GetRelPath(), "_test.go") {
+			pkgName = strings.TrimSuffix(pkgName, "_test")
+		}
+
+		if dirPackages[dir] == nil {
+			dirPackages[dir] = make(map[string]bool)
+		}
+		dirPackages[dir][pkgName] = true
+	}
+
+	dirs := make([]string, 0, len(dirPackages))
+	for dir := range dirPackages {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	exceptions := v.cfg.GetPackageNamingExceptions()
+
+	for _, dir := range dirs {
+		names := make([]string, 0, len(dirPackages[dir]))
+		for name := range dirPackages[dir] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if len(names) > 1 {
+			violations = append(violations, Violation{
+				Type: ViolationMultiplePackages,
+				File: dir,
+			}.withText(
+				NewMessage("violation.multiple_packages.issue", "%s contains multiple packages: %s", dir, strings.Join(names, ", ")),
+				NewMessage("violation.multiple_packages.rule", "Each directory must contain exactly one package (plus its _test package)"),
+				NewMessage("violation.multiple_packages.fix", "Move each package into its own directory"),
+			))
+			continue
+		}
+
+		name := names[0]
+		if dir == "." || containsString(exceptions, name) || packageNameMatchesDir(name, dir) {
+			continue
+		}
+
+		violations = append(violations, Violation{
+			Type: ViolationPackageName,
+			File: dir,
+		}.withText(
+			NewMessage("violation.package_name.issue", "package %s does not match directory %s", name, path.Base(dir)),
+			NewMessage("violation.package_name.rule", "Package names must match their directory name"),
+			NewMessage("violation.package_name.fix", "Rename the package to %s or move it to a directory named %s", expectedPackageName(dir), name),
+		))
+	}
+
+	return violations
+}
+
+// packageNameMatchesDir checks if a package name is the conventional name for a directory,
+// ignoring separators and case (go-arch-lint -> goarchlint) and major version directories
+func packageNameMatchesDir(name, dir string) bool {
+	base := path.Base(dir)
+	if majorVersionDir.MatchString(base) && path.Dir(dir) != "." {
+		if normalizePackageName(name) == normalizePackageName(path.Base(path.Dir(dir))) {
+			return true
+		}
+	}
+	return normalizePackageName(name) == normalizePackageName(base)
+}
+
+// expectedPackageName returns the conventional package name for a directory
+func expectedPackageName(dir string) string {
+	base := path.Base(dir)
+	if majorVersionDir.MatchString(base) && path.Dir(dir) != "." {
+		base = path.Base(path.Dir(dir))
+	}
+	return normalizePackageName(base)
+}
+
+// normalizePackageName lowercases a name and strips characters not allowed in package names
+func normalizePackageName(name string) string {
+	return strings.NewReplacer("-", "", "_", "", ".", "").Replace(strings.ToLower(name))
+}
@@ -0,0 +1,92 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func packageNamingConfig() *testConfig {
+	return &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"cmd":      {"internal"},
+			"internal": {},
+		},
+		checkPackageNaming:      true,
+		packageNamingExceptions: []string{"main"},
+	}
+}
+
+func TestValidatePackageNaming_MultiplePackages(t *testing.T) {
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{relPath: "internal/order/order.go", pkg: "order"},
+			&testFileNode{relPath: "internal/order/legacy.go", pkg: "orders"},
+			// External test packages belong to the package under test
+			&testFileNode{relPath: "internal/order/order_test.go", pkg: "order_test"},
+			&testFileNode{relPath: "internal/billing/billing.go", pkg: "billing"},
+			&testFileNode{relPath: "internal/billing/billing_test.go", pkg: "billing_test"},
+		},
+	}
+
+	v := validator.New(packageNamingConfig(), g)
+	violations := v.Validate()
+
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+	if violations[0].Type != validator.ViolationMultiplePackages {
+		t.Errorf("expected ViolationMultiplePackages, got %s", violations[0].Type)
+	}
+	if violations[0].File != "internal/order" || !strings.Contains(violations[0].Issue, "order, orders") {
+		t.Errorf("expected internal/order to list both packages, got %s: %s", violations[0].File, violations[0].Issue)
+	}
+}
+
+func TestValidatePackageNaming_NameMismatch(t *testing.T) {
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{relPath: "internal/payment/pay.go", pkg: "billing"},
+			// Conventional names for directories with separators and major versions
+			&testFileNode{relPath: "internal/http-client/client.go", pkg: "httpclient"},
+			&testFileNode{relPath: "internal/store/v2/store.go", pkg: "store"},
+			// Exceptions and the project root are not checked
+			&testFileNode{relPath: "cmd/go-arch-lint/main.go", pkg: "main"},
+			&testFileNode{relPath: "doc.go", pkg: "project"},
+		},
+	}
+
+	v := validator.New(packageNamingConfig(), g)
+	violations := v.Validate()
+
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+	viol := violations[0]
+	if viol.Type != validator.ViolationPackageName {
+		t.Errorf("expected ViolationPackageName, got %s", viol.Type)
+	}
+	if viol.File != "internal/payment" {
+		t.Errorf("expected violation for internal/payment, got %s", viol.File)
+	}
+	if !strings.Contains(viol.Fix, "Rename the package to payment") {
+		t.Errorf("expected fix to suggest the directory name, got: %s", viol.Fix)
+	}
+}
+
+func TestValidatePackageNaming_Disabled(t *testing.T) {
+	cfg := packageNamingConfig()
+	cfg.checkPackageNaming = false
+
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{relPath: "internal/payment/pay.go", pkg: "billing"},
+		},
+	}
+
+	if violations := validator.New(cfg, g).Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations when disabled, got %d", len(violations))
+	}
+}
@@ -0,0 +1,22 @@
+package validator
+
+// reportParseErrors turns files skipped by the scanner into violations so that a
+// single malformed file is visible without hiding the results for the rest of the project
+func (v *Validator) reportParseErrors() []Violation {
+	var violations []Violation
+
+	for _, parseErr := range v.parseErrors {
+		violations = append(violations, Violation{
+			Type:   ViolationParseError,
+			File:   parseErr.GetRelPath(),
+			Line:   parseErr.GetLine(),
+			Column: parseErr.GetColumn(),
+		}.withText(
+			NewMessage("violation.parse_error.issue", "File could not be parsed: %s", parseErr.GetMessage()),
+			NewMessage("violation.parse_error.rule", "All Go files must be syntactically valid to be checked against architecture rules"),
+			NewMessage("violation.parse_error.fix", "Fix the syntax error (run 'go build' or 'gofmt' for details); rules for this file were skipped"),
+		))
+	}
+
+	return violations
+}
@@ -0,0 +1,53 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testParseError struct {
+	relPath string
+	line    int
+	column  int
+	message string
+}
+
+func (tpe *testParseError) GetRelPath() string { return tpe.relPath }
+func (tpe *testParseError) GetLine() int       { return tpe.line }
+func (tpe *testParseError) GetColumn() int     { return tpe.column }
+func (tpe *testParseError) GetMessage() string { return tpe.message }
+
+func TestReportParseErrors(t *testing.T) {
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal": {},
+		},
+	}
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetParseErrors([]validator.ParseError{
+		&testParseError{relPath: "internal/order/broken.go", line: 7, message: "expected ')', found '{'"},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Type != validator.ViolationParseError {
+		t.Errorf("expected ViolationParseError, got %s", viol.Type)
+	}
+	if viol.File != "internal/order/broken.go" || viol.Line != 7 {
+		t.Errorf("expected location internal/order/broken.go:7, got %s:%d", viol.File, viol.Line)
+	}
+	if !strings.Contains(viol.Issue, "expected ')'") {
+		t.Errorf("expected issue to include the parser message, got: %s", viol.Issue)
+	}
+	if !viol.IsError() {
+		t.Error("expected parse errors to fail the build")
+	}
+}
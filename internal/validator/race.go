@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// raceAccess matches the first access of a race report, e.g. "Read at 0x00c0000182a8 by
+// goroutine 8:", followed by the function that made it
+var raceAccess = regexp.MustCompile(`(?m)^(\S.*?) at 0x[0-9a-f]+ by [^\n]*:\n(\S+)`)
+
+// validateRaceClean reports the data races the race detector found in the tests of the
+// require_race_clean directories (go test -race). Tests that fail for other reasons are
+// left to the test run itself.
+func (v *Validator) validateRaceClean() []Violation {
+	var violations []Violation
+
+	for _, failure := range v.raceFailures {
+		if !failure.IsRace() {
+			continue
+		}
+		dir := v.moduleRelativeDir(failure.GetPackagePath())
+		root := testRunRoot(dir, v.cfg.GetRaceCleanDirectories())
+		output := failure.GetOutput()
+		file, line := failureLocation(output, dir)
+
+		access := Literal("")
+		if match := raceAccess.FindStringSubmatch(output); match != nil {
+			function := match[2][strings.LastIndex(match[2], "/")+1:]
+			access = NewMessage("violation.data_race.access", ": %s in %s", match[1], function)
+		}
+
+		var issue Message
+		var run string
+		if test := failure.GetTest(); test != "" {
+			issue = NewMessage("violation.data_race.issue", "%s of %s has a data race%s", test, dir, access)
+			run = fmt.Sprintf("go test -race -run '^%s$' ./%s", test, dir)
+		} else {
+			issue = NewMessage("violation.data_race.issue_outside_test", "The tests of %s have a data race outside of a test%s", dir, access)
+			run = fmt.Sprintf("go test -race ./%s", dir)
+		}
+
+		violations = append(violations, Violation{
+			Type: ViolationDataRace,
+			File: file,
+			Line: line,
+		}.withText(
+			issue,
+			NewMessage("violation.data_race.rule", "Tests in %s must pass the race detector (go test -race)", root),
+			NewMessage("violation.data_race.fix", `Synchronize the conflicting accesses:
+1. Reproduce the race with '%s' and read both stacks of the report
+2. Find the variable or field the goroutines share
+3. Guard it with a sync.Mutex, hand it over through a channel or use sync/atomic`, run),
+		))
+	}
+
+	return violations
+}
@@ -0,0 +1,55 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestValidateRaceClean(t *testing.T) {
+	cfg := &testConfig{
+		module:               "github.com/test/project",
+		raceCleanDirectories: []string{"internal/worker"},
+	}
+	v := validator.New(cfg, &testGraph{})
+	v.SetRaceFailures([]validator.TestFailure{
+		&testTestFailure{
+			pkgPath: "github.com/test/project/internal/worker",
+			test:    "TestRun",
+			output: "WARNING: DATA RACE\nRead at 0x00c0000182a8 by goroutine 8:\n" +
+				"github.com/test/project/internal/worker.(*Pool).Run.func1()\n" +
+				"internal/worker/pool.go:13 +0x7d\ntesting.go:1865: race detected during execution of test",
+			race: true,
+		},
+		&testTestFailure{
+			pkgPath: "github.com/test/project/internal/worker",
+			test:    "TestStop",
+			output:  "pool_test.go:30: expected stopped pool",
+		},
+	})
+
+	var violations []validator.Violation
+	for _, viol := range v.Validate() {
+		if viol.Type == validator.ViolationDataRace {
+			violations = append(violations, viol)
+		}
+	}
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 data race violation (failures without a race are not reported), got %d: %+v", len(violations), violations)
+	}
+
+	race := violations[0]
+	if race.File != "internal/worker/pool.go" || race.Line != 13 {
+		t.Errorf("expected the racing access as location, got %s:%d", race.File, race.Line)
+	}
+	if race.Issue != "TestRun of internal/worker has a data race: Read in worker.(*Pool).Run.func1()" {
+		t.Errorf("unexpected issue: %s", race.Issue)
+	}
+	if race.Rule != "Tests in internal/worker must pass the race detector (go test -race)" {
+		t.Errorf("unexpected rule: %s", race.Rule)
+	}
+	if !strings.Contains(race.Fix, "go test -race -run '^TestRun$' ./internal/worker") {
+		t.Errorf("expected the fix to reproduce the race, got: %s", race.Fix)
+	}
+}
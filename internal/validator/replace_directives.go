@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"path"
+	"strings"
+)
+
+// validateReplaceDirectives checks the replace directives of go.mod for replacements with
+// local directories or forks, shortcuts that work on one machine or hide changes from
+// the upstream module. Replaces that only pin another version of the same module are
+// fine, as are those of allowed modules.
+func (v *Validator) validateReplaceDirectives() []Violation {
+	var violations []Violation
+
+	for _, replace := range v.replaces {
+		module := replace.GetModule()
+		replacement := replace.GetReplacement()
+		if replacement == module || isAllowedReplace(module, v.cfg.GetReplaceDirectivesAllowed()) {
+			continue
+		}
+
+		issue := NewMessage("violation.replace_directive.issue", "go.mod replaces %s with the fork %s", module, replacement)
+		fix := NewMessage("violation.replace_directive.fix", "Upstream the changes to %s and drop the replace, or add %s to replace_directives.allowed if the fork is maintained deliberately", module, module)
+		if isLocalReplacement(replacement) {
+			issue = NewMessage("violation.replace_directive.issue_local", "go.mod replaces %s with the local directory %s", module, replacement)
+			fix = NewMessage("violation.replace_directive.fix_local", "Release the changes to %s and require the new version, or add %s to replace_directives.allowed", module, module)
+		}
+
+		violations = append(violations, Violation{
+			Type: ViolationReplaceDirective,
+			File: "go.mod",
+			Line: replace.GetLine(),
+		}.withText(
+			issue,
+			NewMessage("violation.replace_directive.rule", "Replace directives pointing to local directories or forks must not ship"),
+			fix,
+		))
+	}
+
+	return violations
+}
+
+// isLocalReplacement reports whether a replacement is a directory rather than a module
+// path: go.mod requires those to start with ./ or ../, or to be absolute
+func isLocalReplacement(replacement string) bool {
+	return strings.HasPrefix(replacement, "./") || strings.HasPrefix(replacement, "../") ||
+		strings.HasPrefix(replacement, "/") || strings.HasPrefix(replacement, `.\`) || strings.HasPrefix(replacement, `..\`) ||
+		(len(replacement) > 2 && replacement[1] == ':' && (replacement[2] == '\\' || replacement[2] == '/'))
+}
+
+// isAllowedReplace reports whether a module matches an allowed module path or pattern
+func isAllowedReplace(module string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if module == pattern {
+			return true
+		}
+		if matched, err := path.Match(pattern, module); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testReplaceDirective struct {
+	module      string
+	replacement string
+	line        int
+}
+
+func (trd *testReplaceDirective) GetModule() string      { return trd.module }
+func (trd *testReplaceDirective) GetReplacement() string { return trd.replacement }
+func (trd *testReplaceDirective) GetLine() int           { return trd.line }
+
+func TestValidateReplaceDirectives(t *testing.T) {
+	cfg := &testConfig{
+		module:                   "github.com/test/project",
+		checkReplaceDirectives:   true,
+		replaceDirectivesAllowed: []string{"github.com/test/tools", "github.com/ourorg/*"},
+	}
+	v := validator.New(cfg, &testGraph{})
+	v.SetReplaceDirectives([]validator.ReplaceDirective{
+		&testReplaceDirective{module: "github.com/test/shared", replacement: "../shared", line: 12},
+		&testReplaceDirective{module: "github.com/lib/pq", replacement: "github.com/someone/pq", line: 13},
+		&testReplaceDirective{module: "golang.org/x/net", replacement: "golang.org/x/net", line: 14}, // Version pin
+		&testReplaceDirective{module: "github.com/test/tools", replacement: "./tools", line: 15},
+		&testReplaceDirective{module: "github.com/ourorg/auth", replacement: "/src/auth", line: 16},
+		&testReplaceDirective{module: "github.com/test/win", replacement: `C:\src\win`, line: 17},
+	})
+
+	var violations []validator.Violation
+	for _, viol := range v.Validate() {
+		if viol.Type == validator.ViolationReplaceDirective {
+			violations = append(violations, viol)
+		}
+	}
+
+	want := []struct {
+		line  int
+		issue string
+	}{
+		{12, "go.mod replaces github.com/test/shared with the local directory ../shared"},
+		{13, "go.mod replaces github.com/lib/pq with the fork github.com/someone/pq"},
+		{17, `go.mod replaces github.com/test/win with the local directory C:\src\win`},
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("expected %d replace violations, got %d: %+v", len(want), len(violations), violations)
+	}
+	for i, w := range want {
+		if violations[i].File != "go.mod" || violations[i].Line != w.line || violations[i].Issue != w.issue {
+			t.Errorf("violation %d: expected go.mod:%d %q, got %+v", i, w.line, w.issue, violations[i])
+		}
+	}
+}
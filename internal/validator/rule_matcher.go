@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"path"
+	"strings"
+)
+
+// ruleMatcher finds the directories_import rule governing a directory. The keys are
+// compiled once per run into a trie of path segments, so a lookup walks the segments of
+// the directory instead of comparing it with every key, and the rule of each directory
+// is remembered because every file of a package asks for it.
+type ruleMatcher struct {
+	root  *ruleNode
+	rules map[string][]string
+	cache map[string]ruleMatch
+}
+
+// ruleNode is a segment of directories_import keys
+type ruleNode struct {
+	literal  map[string]*ruleNode // Children by plain segment
+	patterns []patternEdge        // Children by wildcard segment
+	key      string               // Key ending at this node ("" if none)
+	plain    bool                 // Whether the key has no wildcard segment
+}
+
+// patternEdge leads to the child of a wildcard segment, e.g. "*"
+type patternEdge struct {
+	segment string
+	node    *ruleNode
+}
+
+// ruleMatch is the rule of a directory: its key, its allowed list with the captures of
+// wildcard segments substituted, and whether a rule applies at all
+type ruleMatch struct {
+	key     string
+	allowed []string
+	exists  bool
+}
+
+// newRuleMatcher compiles directories_import rules
+func newRuleMatcher(rules map[string][]string) *ruleMatcher {
+	m := &ruleMatcher{root: &ruleNode{}, rules: rules, cache: make(map[string]ruleMatch)}
+	for key := range rules {
+		plain := !strings.ContainsAny(key, "*?[")
+		segments := key
+		if !plain {
+			segments = strings.TrimSuffix(key, "/")
+		}
+		node := m.root
+		for _, segment := range strings.Split(segments, "/") {
+			node = node.child(segment)
+		}
+		// Pattern keys differing in a trailing slash end at the same node: the first
+		// in sorted order wins
+		if node.key == "" || key < node.key {
+			node.key, node.plain = key, plain
+		}
+	}
+	return m
+}
+
+// child returns the child node of a segment, adding it if needed
+func (n *ruleNode) child(segment string) *ruleNode {
+	if !strings.ContainsAny(segment, "*?[") {
+		if n.literal == nil {
+			n.literal = make(map[string]*ruleNode)
+		}
+		if n.literal[segment] == nil {
+			n.literal[segment] = &ruleNode{}
+		}
+		return n.literal[segment]
+	}
+	for _, edge := range n.patterns {
+		if edge.segment == segment {
+			return edge.node
+		}
+	}
+	node := &ruleNode{}
+	n.patterns = append(n.patterns, patternEdge{segment: segment, node: node})
+	return node
+}
+
+// lookup returns the rule governing dir. Keys cover the directories below them, and the
+// most specific key wins: the rule of the deepest matching directory, preferring a plain
+// key over a pattern at the same depth and the first pattern in sorted order among
+// patterns.
+func (m *ruleMatcher) lookup(dir string) ruleMatch {
+	if match, ok := m.cache[dir]; ok {
+		return match
+	}
+
+	var best struct {
+		node     *ruleNode
+		depth    int
+		captures []string
+	}
+	segments := strings.Split(dir, "/")
+	var walk func(node *ruleNode, depth int, captures []string)
+	walk = func(node *ruleNode, depth int, captures []string) {
+		if node.key != "" && depth > 0 {
+			better := best.node == nil || depth > best.depth ||
+				(depth == best.depth && (node.plain && !best.node.plain ||
+					node.plain == best.node.plain && node.key < best.node.key))
+			if better {
+				best.node, best.depth, best.captures = node, depth, captures
+			}
+		}
+		if depth == len(segments) {
+			return
+		}
+		segment := segments[depth]
+		if child := node.literal[segment]; child != nil {
+			walk(child, depth+1, captures)
+		}
+		for _, edge := range node.patterns {
+			if matched, err := path.Match(edge.segment, segment); err == nil && matched {
+				walk(edge.node, depth+1, append(captures[:len(captures):len(captures)], segment))
+			}
+		}
+	}
+	walk(m.root, 0, nil)
+
+	var match ruleMatch
+	if best.node != nil {
+		match = ruleMatch{key: best.node.key, allowed: m.rules[best.node.key], exists: true}
+		if !best.node.plain {
+			match.allowed = make([]string, len(m.rules[best.node.key]))
+			for i, entry := range m.rules[best.node.key] {
+				match.allowed[i] = expandCaptures(entry, best.captures)
+			}
+		}
+	}
+	m.cache[dir] = match
+	return match
+}
@@ -0,0 +1,90 @@
+package validator_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestValidate_DirectoryRulePrecedence(t *testing.T) {
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal":                 {},
+			"internal/modules/*":       {"internal/modules/$1/api"},
+			"internal/modules/billing": {"internal/shared"}, // A plain key beats a pattern at the same depth
+			"internal/modules/*/api":   {},
+			"internal/modules/?sers/*": {"internal/modules/$1sers/api"},
+			"internal/modules/*/store": {"internal/modules/$1/api"},
+		},
+	}
+	forbidden := func(relPath string) *testFileNode {
+		return &testFileNode{
+			relPath: relPath,
+			pkg:     "pkg",
+			dependencies: []validator.Dependency{
+				&testDependency{importPath: "github.com/test/project/cmd/app", localPath: "cmd/app", isLocal: true, line: 3, column: 2},
+			},
+		}
+	}
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			forbidden("internal/util/util.go"),
+			forbidden("internal/modules/orders/service/service.go"),
+			forbidden("internal/modules/billing/service/service.go"),
+			forbidden("internal/modules/orders/api/api.go"),
+			forbidden("internal/modules/users/api/api.go"), // Among patterns the first in sorted order wins
+			forbidden("internal/modules/users/store/store.go"),
+			forbidden("internal/modules/users/store/store_extra.go"),
+		},
+	}
+
+	want := map[string]string{
+		"internal/util/util.go":                       "internal can only import from: []",
+		"internal/modules/orders/service/service.go":  "internal/modules/* can only import from: [internal/modules/orders/api]",
+		"internal/modules/billing/service/service.go": "internal/modules/billing can only import from: [internal/shared]",
+		"internal/modules/orders/api/api.go":          "internal/modules/*/api can only import from: []",
+		"internal/modules/users/api/api.go":           "internal/modules/*/api can only import from: []",
+		"internal/modules/users/store/store.go":       "internal/modules/*/store can only import from: [internal/modules/users/api]",
+		"internal/modules/users/store/store_extra.go": "internal/modules/*/store can only import from: [internal/modules/users/api]",
+	}
+	violations := validator.New(cfg, g).Validate()
+	if len(violations) != len(want) {
+		t.Fatalf("expected %d violations, got %d: %v", len(want), len(violations), violations)
+	}
+	for _, viol := range violations {
+		if viol.Rule != want[viol.File] {
+			t.Errorf("%s: expected rule %q, got %q", viol.File, want[viol.File], viol.Rule)
+		}
+	}
+}
+
+func BenchmarkValidate_DirectoryRules(b *testing.B) {
+	directoriesImport := make(map[string][]string)
+	var nodes []validator.FileNode
+	for i := 0; i < 200; i++ {
+		dir := fmt.Sprintf("internal/modules/m%d", i)
+		directoriesImport[dir] = []string{dir + "/api"}
+		directoriesImport[dir+"/api"] = []string{}
+		for j := 0; j < 10; j++ {
+			node := &testFileNode{relPath: fmt.Sprintf("%s/service/file%d.go", dir, j), pkg: "service"}
+			for k := 0; k < 10; k++ {
+				node.dependencies = append(node.dependencies, &testDependency{
+					importPath: fmt.Sprintf("github.com/test/project/%s/api", dir),
+					localPath:  dir + "/api",
+					isLocal:    true,
+				})
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	directoriesImport["internal/modules/*/store"] = []string{"internal/modules/$1/api"}
+	cfg := &testConfig{module: "github.com/test/project", directoriesImport: directoriesImport}
+	g := &testGraph{nodes: nodes}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		validator.New(cfg, g).Validate()
+	}
+}
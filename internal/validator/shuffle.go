@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateShuffledTests reports the tests in the require_shuffle_clean directories that
+// failed when run in random order (go test -shuffle=on): tests that only pass after other
+// tests have prepared shared state, which defeats testing each package in isolation
+func (v *Validator) validateShuffledTests() []Violation {
+	var violations []Violation
+
+	for _, failure := range v.shuffleFailures {
+		dir := v.moduleRelativeDir(failure.GetPackagePath())
+		root := testRunRoot(dir, v.cfg.GetShuffleCleanDirectories())
+		output := failure.GetOutput()
+		firstLine := strings.SplitN(output, "\n", 2)[0]
+		file, line := failureLocation(output, dir)
+
+		order := NewMessage("violation.order_dependent_test.random_order", "in random order")
+		reproduce := fmt.Sprintf("go test -shuffle=on ./%s", dir)
+		if seed := failure.GetSeed(); seed != "" {
+			order = NewMessage("violation.order_dependent_test.random_order_seed", "in random order (seed %s)", seed)
+			reproduce = fmt.Sprintf("go test -shuffle=%s ./%s", seed, dir)
+		}
+
+		var issue, fix Message
+		if test := failure.GetTest(); test != "" {
+			issue = NewMessage("violation.order_dependent_test.issue", "%s fails when the tests of %s run %s", test, dir, order)
+			fix = NewMessage("violation.order_dependent_test.fix", `Make %s independent of the other tests:
+1. Reproduce the failing order with '%s'
+2. Find the state it shares with them: package-level variables, files, environment variables, registered handlers
+3. Let each test build its own fixtures (t.TempDir, t.Setenv) and undo its changes with t.Cleanup`, test, reproduce)
+		} else {
+			issue = NewMessage("violation.order_dependent_test.issue_outside_test", "The tests of %s fail outside of a test when run %s", dir, order)
+			fix = NewMessage("violation.order_dependent_test.fix_outside_test", "Run '%s' and fix the build error, panic or timeout it reports", reproduce)
+		}
+		if firstLine != "" {
+			issue = joinMessages([]Message{issue, Literal(firstLine)}, ": ")
+		}
+
+		violations = append(violations, Violation{
+			Type: ViolationOrderDependentTest,
+			File: file,
+			Line: line,
+		}.withText(
+			issue,
+			NewMessage("violation.order_dependent_test.rule", "Tests in %s must pass in any order (go test -shuffle=on)", root),
+			fix,
+		))
+	}
+
+	return violations
+}
@@ -0,0 +1,75 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testTestFailure struct {
+	pkgPath string
+	test    string
+	seed    string
+	output  string
+	race    bool
+}
+
+func (ttf *testTestFailure) GetPackagePath() string { return ttf.pkgPath }
+func (ttf *testTestFailure) GetTest() string        { return ttf.test }
+func (ttf *testTestFailure) GetSeed() string        { return ttf.seed }
+func (ttf *testTestFailure) GetOutput() string      { return ttf.output }
+func (ttf *testTestFailure) IsRace() bool           { return ttf.race }
+
+func TestValidateShuffledTests(t *testing.T) {
+	cfg := &testConfig{
+		module:                  "github.com/test/project",
+		shuffleCleanDirectories: []string{"internal/domain"},
+	}
+	v := validator.New(cfg, &testGraph{})
+	v.SetShuffleFailures([]validator.TestFailure{
+		&testTestFailure{
+			pkgPath: "github.com/test/project/internal/domain/order",
+			test:    "TestTotal",
+			seed:    "1698",
+			output:  "order_test.go:19: expected 2 items, got 1\norder_test.go:20: total mismatch",
+		},
+		&testTestFailure{
+			pkgPath: "github.com/test/project/internal/domain",
+			seed:    "1698",
+			output:  "internal/domain/money.go:3:23: undefined: amount",
+		},
+	})
+
+	var violations []validator.Violation
+	for _, viol := range v.Validate() {
+		if viol.Type == validator.ViolationOrderDependentTest {
+			violations = append(violations, viol)
+		}
+	}
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 order-dependent test violations, got %d: %+v", len(violations), violations)
+	}
+
+	test := violations[0]
+	if test.File != "internal/domain/order/order_test.go" || test.Line != 19 {
+		t.Errorf("expected the failing assertion as location, got %s:%d", test.File, test.Line)
+	}
+	if test.Issue != "TestTotal fails when the tests of internal/domain/order run in random order (seed 1698): order_test.go:19: expected 2 items, got 1" {
+		t.Errorf("unexpected issue: %s", test.Issue)
+	}
+	if test.Rule != "Tests in internal/domain must pass in any order (go test -shuffle=on)" {
+		t.Errorf("expected the rule to name the configured directory, got: %s", test.Rule)
+	}
+	if !strings.Contains(test.Fix, "go test -shuffle=1698 ./internal/domain/order") {
+		t.Errorf("expected the fix to reproduce the order, got: %s", test.Fix)
+	}
+
+	build := violations[1]
+	if build.File != "internal/domain/money.go" || build.Line != 3 {
+		t.Errorf("expected the build error as location, got %s:%d", build.File, build.Line)
+	}
+	if !strings.HasPrefix(build.Issue, "The tests of internal/domain fail outside of a test") {
+		t.Errorf("unexpected issue for a package failure: %s", build.Issue)
+	}
+}
@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// validateStabilityImports checks that packages annotated as stable do not import
+// packages annotated as experimental, which would silently make their API unstable
+func (v *Validator) validateStabilityImports() []Violation {
+	var violations []Violation
+
+	// Stability is declared per package, by any of its files
+	dirStability := make(map[string]string)
+	for _, file := range v.sourceFiles {
+		if file.GetIsTest() || file.GetStability() == "" {
+			continue
+		}
+		dir := path.Dir(file.GetRelPath())
+		dirStability[dir] = file.GetStability()
+	}
+
+	for _, node := range v.graph.GetNodes() {
+		if strings.HasSuffix(node.GetRelPath(), "_test.go") {
+			continue
+		}
+		fileDir := path.Dir(node.GetRelPath())
+		if dirStability[fileDir] != "stable" {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, dep := range node.GetDependencies() {
+			depDir := dep.GetLocalPath()
+			if !dep.IsLocalDep() || dirStability[depDir] != "experimental" || seen[depDir] {
+				continue
+			}
+			seen[depDir] = true
+
+			violations = append(violations, Violation{
+				Type:   ViolationUnstableDependency,
+				File:   node.GetRelPath(),
+				Line:   dep.GetLine(),
+				Column: dep.GetColumn(),
+			}.withText(
+				NewMessage("violation.unstable_dependency.issue", "stable package %s imports experimental package %s", fileDir, depDir),
+				NewMessage("violation.unstable_dependency.rule", "Packages annotated as stable must not depend on experimental packages"),
+				NewMessage("violation.unstable_dependency.fix", "Stabilize %s (// archlint:stability stable) or remove the dependency", depDir),
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].File < violations[j].File
+	})
+
+	return violations
+}
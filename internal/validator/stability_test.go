@@ -0,0 +1,86 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func stabilityConfig() *testConfig {
+	return &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"pkg": {"pkg"},
+		},
+		enforceStability: true,
+	}
+}
+
+func stabilityGraph() *testGraph {
+	return &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{
+				relPath: "pkg/api/api.go",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/test/project/pkg/client", localPath: "pkg/client", isLocal: true},
+					&testDependency{importPath: "github.com/test/project/pkg/store", localPath: "pkg/store", isLocal: true},
+				},
+			},
+			// Experimental packages may use each other
+			&testFileNode{
+				relPath: "pkg/client/client.go",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/test/project/pkg/store", localPath: "pkg/store", isLocal: true},
+				},
+			},
+			&testFileNode{
+				relPath: "pkg/api/api_test.go",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/test/project/pkg/client", localPath: "pkg/client", isLocal: true},
+				},
+			},
+		},
+	}
+}
+
+func stabilitySourceFiles() []validator.SourceFile {
+	return []validator.SourceFile{
+		&testSourceFile{relPath: "pkg/api/doc.go", stability: "stable"},
+		&testSourceFile{relPath: "pkg/client/doc.go", stability: "experimental"},
+		&testSourceFile{relPath: "pkg/store/doc.go", stability: "deprecated"},
+	}
+}
+
+func TestValidateStabilityImports_StableImportsExperimental(t *testing.T) {
+	v := validator.New(stabilityConfig(), stabilityGraph())
+	v.SetSourceFiles(stabilitySourceFiles())
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Type != validator.ViolationUnstableDependency {
+		t.Errorf("expected ViolationUnstableDependency, got %s", viol.Type)
+	}
+	if viol.File != "pkg/api/api.go" {
+		t.Errorf("expected violation in pkg/api/api.go, got %s", viol.File)
+	}
+	if !strings.Contains(viol.Issue, "stable package pkg/api imports experimental package pkg/client") {
+		t.Errorf("unexpected issue: %s", viol.Issue)
+	}
+}
+
+func TestValidateStabilityImports_Disabled(t *testing.T) {
+	cfg := stabilityConfig()
+	cfg.enforceStability = false
+
+	v := validator.New(cfg, stabilityGraph())
+	v.SetSourceFiles(stabilitySourceFiles())
+
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations when disabled, got %d", len(violations))
+	}
+}
@@ -1,9 +1,9 @@
 package validator
 
 import (
-	"fmt"
+	"io/fs"
 	"os"
-	"path/filepath"
+	"path"
 	"strings"
 )
 
@@ -18,48 +18,55 @@ func (v *Validator) validateStructure() []Violation {
 	}
 
 	// Check that all required directories exist and are not empty
+	empty := make(map[string]bool)
 	for dirPath, description := range requiredDirs {
-		fullPath := filepath.Join(v.projectPath, dirPath)
-		info, err := os.Stat(fullPath)
+		info, err := v.stat(dirPath)
 
 		if err != nil {
 			if os.IsNotExist(err) {
 				violations = append(violations, Violation{
-					Type:  ViolationMissingDirectory,
-					File:  dirPath,
-					Issue: fmt.Sprintf("Required directory '%s' does not exist", dirPath),
-					Rule:  fmt.Sprintf("Directory purpose: %s", description),
-					Fix:   fmt.Sprintf("Create the directory: mkdir -p %s", dirPath),
-				})
+					Type: ViolationMissingDirectory,
+					File: dirPath,
+				}.withText(
+					NewMessage("violation.missing_directory.issue", "Required directory '%s' does not exist", dirPath),
+					NewMessage("violation.required_directory.rule", "Directory purpose: %s", description),
+					NewMessage("violation.missing_directory.fix", "Create the directory: mkdir -p %s", dirPath),
+				))
 			}
 			continue
 		}
 
 		if !info.IsDir() {
 			violations = append(violations, Violation{
-				Type:  ViolationMissingDirectory,
-				File:  dirPath,
-				Issue: fmt.Sprintf("'%s' exists but is not a directory", dirPath),
-				Rule:  fmt.Sprintf("Directory purpose: %s", description),
-				Fix:   fmt.Sprintf("Remove the file and create directory: rm %s && mkdir -p %s", dirPath, dirPath),
-			})
+				Type: ViolationMissingDirectory,
+				File: dirPath,
+			}.withText(
+				NewMessage("violation.missing_directory.issue_not_directory", "'%s' exists but is not a directory", dirPath),
+				NewMessage("violation.required_directory.rule", "Directory purpose: %s", description),
+				NewMessage("violation.missing_directory.fix_not_directory", "Remove the file and create directory: rm %s && mkdir -p %s", dirPath, dirPath),
+			))
 			continue
 		}
 
-		// Check if directory contains any Go files
-		if !v.directoryContainsGoFiles(fullPath) {
+		// Check if directory contains any Go packages. An empty layer (e.g. created by init
+		// and never filled) is architecture on paper only, which is worth a warning but
+		// not a failed build.
+		if !v.directoryContainsGoFiles(dirPath) {
+			empty[dirPath] = true
 			violations = append(violations, Violation{
-				Type:  ViolationEmptyDirectory,
-				File:  dirPath,
-				Issue: fmt.Sprintf("Required directory '%s' exists but contains no .go files", dirPath),
-				Rule:  fmt.Sprintf("Directory purpose: %s", description),
-				Fix:   fmt.Sprintf("Add Go code to %s or remove it from required_directories", dirPath),
-			})
+				Type:     ViolationEmptyDirectory,
+				File:     dirPath,
+				Severity: SeverityWarning,
+			}.withText(
+				NewMessage("violation.empty_directory.issue", "Required directory '%s' exists but contains no Go packages", dirPath),
+				NewMessage("violation.required_directory.rule", "Directory purpose: %s", description),
+				NewMessage("violation.empty_directory.fix", "Add the first package to %s, or remove it from required_directories if the layer is not needed", dirPath),
+			))
 		}
 	}
 
 	// Check that required directories are actually used (packages imported)
-	violations = append(violations, v.detectUnusedRequiredDirectories(requiredDirs)...)
+	violations = append(violations, v.detectUnusedRequiredDirectories(requiredDirs, empty)...)
 
 	// If strict mode, check for unexpected directories
 	if !v.cfg.ShouldAllowOtherDirectories() {
@@ -69,12 +76,13 @@ func (v *Validator) validateStructure() []Violation {
 	return violations
 }
 
-// detectUnexpectedDirectories finds top-level directories that are not in the required list
+// detectUnexpectedDirectories finds top-level directories with Go code that are not in the
+// required list. Directories without Go code (docs, scripts, assets) are left alone.
 func (v *Validator) detectUnexpectedDirectories(requiredDirs map[string]string) []Violation {
 	var violations []Violation
 
 	// Read top-level directories
-	entries, err := os.ReadDir(v.projectPath)
+	entries, err := v.readDir(".")
 	if err != nil {
 		return violations // Silently skip if we can't read directory
 	}
@@ -103,14 +111,15 @@ func (v *Validator) detectUnexpectedDirectories(requiredDirs map[string]string)
 				}
 			}
 
-			if !isPartOfRequired {
+			if !isPartOfRequired && v.directoryContainsGoFiles(dirName) {
 				violations = append(violations, Violation{
-					Type:  ViolationUnexpectedDirectory,
-					File:  dirName,
-					Issue: fmt.Sprintf("Directory '%s' is not in the required structure", dirName),
-					Rule:  "allow_other_directories is set to false - only required directories are allowed",
-					Fix:   "Remove directory or add to required_directories in .goarchlint",
-				})
+					Type: ViolationUnexpectedDirectory,
+					File: dirName,
+				}.withText(
+					NewMessage("violation.unexpected_directory.issue", "Directory '%s' contains Go code but is not in the required structure", dirName),
+					NewMessage("violation.unexpected_directory.rule", "allow_other_directories is set to false - only required directories are allowed"),
+					NewMessage("violation.unexpected_directory.fix", "Move the code into a required directory, or add '%s: \"<purpose>\"' to structure.required_directories and a directories_import rule for %s in .goarchlint", dirName, dirName),
+				))
 			}
 		}
 	}
@@ -118,19 +127,20 @@ func (v *Validator) detectUnexpectedDirectories(requiredDirs map[string]string)
 	return violations
 }
 
-// directoryContainsGoFiles recursively checks if a directory contains any .go files
+// directoryContainsGoFiles recursively checks if a directory (relative to the project
+// directory) contains any .go files
 func (v *Validator) directoryContainsGoFiles(dirPath string) bool {
 	var hasGoFiles bool
 
-	filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+	v.walkDir(dirPath, func(path string, entry fs.DirEntry, err error) error {
 		if err != nil {
 			return nil // Skip errors, continue walking
 		}
-		if !info.IsDir() && strings.HasSuffix(info.Name(), ".go") {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".go") {
 			// Skip test files for this check
-			if !strings.HasSuffix(info.Name(), "_test.go") {
+			if !strings.HasSuffix(entry.Name(), "_test.go") {
 				hasGoFiles = true
-				return filepath.SkipAll // Found a Go file, can stop
+				return fs.SkipAll // Found a Go file, can stop
 			}
 		}
 		return nil
@@ -139,8 +149,9 @@ func (v *Validator) directoryContainsGoFiles(dirPath string) bool {
 	return hasGoFiles
 }
 
-// detectUnusedRequiredDirectories finds required directories whose packages are never imported
-func (v *Validator) detectUnusedRequiredDirectories(requiredDirs map[string]string) []Violation {
+// detectUnusedRequiredDirectories finds required directories whose packages are never imported.
+// Directories already reported as empty are skipped.
+func (v *Validator) detectUnusedRequiredDirectories(requiredDirs map[string]string, empty map[string]bool) []Violation {
 	var violations []Violation
 
 	// Build a map of all local paths that are imported
@@ -166,13 +177,15 @@ func (v *Validator) detectUnusedRequiredDirectories(requiredDirs map[string]stri
 	// Check if each required directory is actually used in the codebase
 	// A directory is "used" if it has Go files that are part of the dependency graph
 	for dirPath, description := range requiredDirs {
+		if empty[dirPath] {
+			continue
+		}
 		isUsed := false
 
 		// Check if any file nodes exist in this directory or its subdirectories
 		// This covers both entry points (like cmd) and imported packages
 		for _, node := range v.graph.GetNodes() {
-			nodeDir := filepath.Dir(node.GetRelPath())
-			nodeDir = filepath.ToSlash(nodeDir)
+			nodeDir := path.Dir(node.GetRelPath())
 			if nodeDir == dirPath || strings.HasPrefix(nodeDir, dirPath+"/") {
 				isUsed = true
 				break
@@ -181,12 +194,13 @@ func (v *Validator) detectUnusedRequiredDirectories(requiredDirs map[string]stri
 
 		if !isUsed {
 			violations = append(violations, Violation{
-				Type:  ViolationUnusedDirectory,
-				File:  dirPath,
-				Issue: fmt.Sprintf("Required directory '%s' contains no scanned Go files", dirPath),
-				Rule:  fmt.Sprintf("Directory purpose: %s", description),
-				Fix:   fmt.Sprintf("Add Go code to %s or remove it from required_directories", dirPath),
-			})
+				Type: ViolationUnusedDirectory,
+				File: dirPath,
+			}.withText(
+				NewMessage("violation.unused_directory.issue", "Required directory '%s' contains no scanned Go files", dirPath),
+				NewMessage("violation.required_directory.rule", "Directory purpose: %s", description),
+				NewMessage("violation.unused_directory.fix", "Add Go code to %s or remove it from required_directories", dirPath),
+			))
 		}
 	}
 
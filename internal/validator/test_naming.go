@@ -1,8 +1,7 @@
 package validator
 
 import (
-	"fmt"
-	"path/filepath"
+	"path"
 	"strings"
 )
 
@@ -38,7 +37,7 @@ func (v *Validator) validateTestNaming() []Violation {
 		relPath := fileInfo.GetRelPath()
 		baseName := fileInfo.GetBaseName()
 		isTest := fileInfo.GetIsTest()
-		dir := filepath.Dir(relPath)
+		dir := path.Dir(relPath)
 
 		// Exclude special files that don't need tests
 		if shouldExcludeFromTestNaming(baseName) {
@@ -93,12 +92,13 @@ func validateFileGroup(dir, baseName string, group *fileGroup) []Violation {
 	if implCount > 1 {
 		for _, implFile := range group.implFiles {
 			violations = append(violations, Violation{
-				Type:  ViolationTestNaming,
-				File:  implFile,
-				Issue: fmt.Sprintf("Multiple implementation files found with base name '%s' in directory '%s'", baseName, dir),
-				Rule:  "strict_test_naming: Each base name should have exactly one implementation file per directory",
-				Fix:   fmt.Sprintf("Rename or consolidate duplicate implementation files with base name '%s'", baseName),
-			})
+				Type: ViolationTestNaming,
+				File: implFile,
+			}.withText(
+				NewMessage("violation.test_naming.issue", "Multiple implementation files found with base name '%s' in directory '%s'", baseName, dir),
+				NewMessage("violation.test_naming.rule", "strict_test_naming: Each base name should have exactly one implementation file per directory"),
+				NewMessage("violation.test_naming.fix", "Rename or consolidate duplicate implementation files with base name '%s'", baseName),
+			))
 		}
 	}
 
@@ -106,12 +106,13 @@ func validateFileGroup(dir, baseName string, group *fileGroup) []Violation {
 	if testCount > 1 {
 		for _, testFile := range group.testFiles {
 			violations = append(violations, Violation{
-				Type:  ViolationTestNaming,
-				File:  testFile,
-				Issue: fmt.Sprintf("Multiple test files found with base name '%s' in directory '%s'", baseName, dir),
-				Rule:  "strict_test_naming: Each base name should have at most one test file (foo_test.go)",
-				Fix:   fmt.Sprintf("Consolidate test files into single '%s_test.go' file, or rename to use different base names", baseName),
-			})
+				Type: ViolationTestNaming,
+				File: testFile,
+			}.withText(
+				NewMessage("violation.test_naming.issue_tests", "Multiple test files found with base name '%s' in directory '%s'", baseName, dir),
+				NewMessage("violation.test_naming.rule_tests", "strict_test_naming: Each base name should have at most one test file (foo_test.go)"),
+				NewMessage("violation.test_naming.fix_tests", "Consolidate test files into single '%s_test.go' file, or rename to use different base names", baseName),
+			))
 		}
 	}
 
@@ -120,12 +121,13 @@ func validateFileGroup(dir, baseName string, group *fileGroup) []Violation {
 	if implCount == 0 && testCount >= 1 {
 		for _, testFile := range group.testFiles {
 			violations = append(violations, Violation{
-				Type:  ViolationTestNaming,
-				File:  testFile,
-				Issue: fmt.Sprintf("Test file '%s' has no corresponding implementation file", filepath.Base(testFile)),
-				Rule:  "strict_test_naming: Each test file must have a corresponding implementation file (foo_test.go -> foo.go)",
-				Fix:   fmt.Sprintf("Create implementation file '%s.go' in the same directory, or remove/rename the orphaned test file", baseName),
-			})
+				Type: ViolationTestNaming,
+				File: testFile,
+			}.withText(
+				NewMessage("violation.test_naming.issue_orphan", "Test file '%s' has no corresponding implementation file", path.Base(testFile)),
+				NewMessage("violation.test_naming.rule_orphan", "strict_test_naming: Each test file must have a corresponding implementation file (foo_test.go -> foo.go)"),
+				NewMessage("violation.test_naming.fix_orphan", "Create implementation file '%s.go' in the same directory, or remove/rename the orphaned test file", baseName),
+			))
 		}
 	}
 
@@ -52,6 +52,10 @@ func (c *testNamingConfig) GetTestExemptImports() []string {
 	return nil
 }
 
+func (c *testNamingConfig) GetTestExemptImportsByDir() map[string][]string {
+	return nil
+}
+
 func (c *testNamingConfig) GetTestFileLocation() string {
 	return "colocated"
 }
@@ -60,6 +64,34 @@ func (c *testNamingConfig) ShouldRequireBlackboxTests() bool {
 	return false
 }
 
+func (c *testNamingConfig) GetWhiteboxExemptions() map[string]string {
+	return nil
+}
+
+func (c *testNamingConfig) GetTestSupportPackages() []string {
+	return nil
+}
+
+func (c *testNamingConfig) ShouldRequireTestdataFixtures() bool {
+	return false
+}
+
+func (c *testNamingConfig) ShouldDetectTestSetupImports() bool {
+	return false
+}
+
+func (c *testNamingConfig) GetTestSetupHelpers() []string {
+	return nil
+}
+
+func (c *testNamingConfig) ShouldEnforceTestScope() bool {
+	return false
+}
+
+func (c *testNamingConfig) GetTestScopeShared() []string {
+	return nil
+}
+
 func (c *testNamingConfig) IsCoverageEnabled() bool {
 	return false
 }
@@ -80,6 +112,138 @@ func (c *testNamingConfig) ShouldEnforceStrictTestNaming() bool {
 	return c.strictTestNaming
 }
 
+func (c *testNamingConfig) ShouldDetectDuplicates() bool {
+	return false
+}
+
+func (c *testNamingConfig) GetWrapIn() map[string]string {
+	return nil
+}
+
+func (c *testNamingConfig) GetTypeLeakLayers() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetTypeLeakForbidden() []string {
+	return nil
+}
+
+func (c *testNamingConfig) ShouldConfineConfigLoading() bool {
+	return false
+}
+
+func (c *testNamingConfig) GetConfigLoadingAllowed() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetConfigLoadingPackages() []string {
+	return nil
+}
+
+func (c *testNamingConfig) ShouldDetectFrameworkLockIn() bool {
+	return false
+}
+
+func (c *testNamingConfig) GetFrameworkLockInAllowed() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetFrameworkLockInTypes() []string {
+	return nil
+}
+
+func (c *testNamingConfig) ShouldCheckPackageNaming() bool {
+	return false
+}
+
+func (c *testNamingConfig) GetPackageNamingExceptions() []string {
+	return nil
+}
+
+func (c *testNamingConfig) ShouldEnforceStability() bool {
+	return false
+}
+
+func (c *testNamingConfig) ShouldDetectDeprecatedUsages() bool {
+	return false
+}
+
+func (c *testNamingConfig) GetOwnershipTeams() map[string]string {
+	return nil
+}
+
+func (c *testNamingConfig) GetOwnershipContracts() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetPackageDocLayers() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetMainPackageLocations() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetReadmeDirectories() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetDefaultPolicy() string {
+	return "allow"
+}
+
+func (c *testNamingConfig) GetOrphanInterfaceLayers() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetAdapterLayers() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetAdapterPortLayers() []string {
+	return nil
+}
+
+func (c *testNamingConfig) ShouldRequireAdapterAssertions() bool {
+	return false
+}
+
+func (c *testNamingConfig) ShouldDetectHiddenDependencies() bool {
+	return false
+}
+
+func (c *testNamingConfig) GetLicensePolicyLayers() map[string][]string {
+	return nil
+}
+
+func (c *testNamingConfig) GetLicensePolicyExceptions() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetExternalImportDepthLayers() map[string]map[string]int {
+	return nil
+}
+
+func (c *testNamingConfig) ShouldDetectVersionSprawl() bool {
+	return false
+}
+
+func (c *testNamingConfig) ShouldCheckReplaceDirectives() bool {
+	return false
+}
+
+func (c *testNamingConfig) GetReplaceDirectivesAllowed() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetShuffleCleanDirectories() []string {
+	return nil
+}
+
+func (c *testNamingConfig) GetRaceCleanDirectories() []string {
+	return nil
+}
+
 // Mock file node with test info
 type mockFileNodeWithTestInfo struct {
 	relPath  string
@@ -99,6 +263,18 @@ func (m *mockFileNodeWithTestInfo) GetDependencies() []validator.Dependency {
 	return nil
 }
 
+func (m *mockFileNodeWithTestInfo) GetPackageLine() int {
+	return 1
+}
+
+func (m *mockFileNodeWithTestInfo) GetPackageColumn() int {
+	return 9
+}
+
+func (m *mockFileNodeWithTestInfo) GetDIFramework() string {
+	return ""
+}
+
 func (m *mockFileNodeWithTestInfo) GetBaseName() string {
 	return m.baseName
 }
@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// outputLocation matches file:line in test or build output, e.g. "store_test.go:19:
+// expected 2 items", "internal/store/store.go:3:23: undefined: x" or the frame
+// "internal/worker/pool.go:42 +0x7d" of a race report
+var outputLocation = regexp.MustCompile(`([\w./-]+\.go):(\d+)`)
+
+// failureLocation returns the first location of the project in the output of a failed
+// test of the package in dir, or dir if there is none. Absolute paths (the standard
+// library, the module cache) and the testing package are skipped; plain file names are
+// relative to the package.
+func failureLocation(output, dir string) (string, int) {
+	for _, match := range outputLocation.FindAllStringSubmatch(output, -1) {
+		file := match[1]
+		if strings.HasPrefix(file, "/") || file == "testing.go" {
+			continue
+		}
+		if !strings.Contains(file, "/") {
+			file = path.Join(dir, file)
+		}
+		line, _ := strconv.Atoi(match[2])
+		return file, line
+	}
+	return dir, 0
+}
+
+// testRunRoot returns the directory of a test run rule containing the package in dir,
+// or dir if none does
+func testRunRoot(dir string, configured []string) string {
+	root := dir
+	for _, candidate := range configured {
+		if isWithinDir(dir, candidate) && len(candidate) < len(root) {
+			root = candidate
+		}
+	}
+	return root
+}
+
+// moduleRelativeDir returns the directory of a package of the module relative to the
+// project root ("." for the module root)
+func (v *Validator) moduleRelativeDir(pkgPath string) string {
+	module := v.cfg.GetModule()
+	if pkgPath == module {
+		return "."
+	}
+	return strings.TrimPrefix(pkgPath, module+"/")
+}
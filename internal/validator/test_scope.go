@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// validateTestScope checks that foo_test.go only calls functions of its own package
+// declared in foo.go. A test that reaches into functions of other files has grown into
+// an integration test of the package and belongs in that file's test or a dedicated one.
+// Constructors and files listed in test_scope.shared may be used by any test.
+func (v *Validator) validateTestScope() []Violation {
+	var violations []Violation
+
+	shared := make(map[string]bool)
+	for _, name := range v.cfg.GetTestScopeShared() {
+		shared[strings.TrimSuffix(name, ".go")] = true
+	}
+
+	// map[directory]map[function]declaring file base name, from production files only
+	declaredIn := make(map[string]map[string]string)
+	implFiles := make(map[string]bool)
+	productionPackage := make(map[string]string)
+	for _, file := range v.sourceFiles {
+		if file.GetIsTest() {
+			continue
+		}
+		dir := path.Dir(file.GetRelPath())
+		baseName := strings.TrimSuffix(path.Base(filepath.ToSlash(file.GetRelPath())), ".go")
+		implFiles[path.Join(dir, baseName)] = true
+		productionPackage[dir] = file.GetPackage()
+		if declaredIn[dir] == nil {
+			declaredIn[dir] = make(map[string]string)
+		}
+		for _, fn := range file.GetFuncDecls() {
+			declaredIn[dir][fn] = baseName
+		}
+	}
+
+	for _, file := range v.sourceFiles {
+		if !file.GetIsTest() {
+			continue
+		}
+		relPath := filepath.ToSlash(file.GetRelPath())
+		dir := path.Dir(relPath)
+		baseName := strings.TrimSuffix(path.Base(relPath), "_test.go")
+
+		// Orphaned and excluded tests are reported, or deliberately skipped, by strict_test_naming
+		if shouldExcludeFromTestNaming(baseName) || !implFiles[path.Join(dir, baseName)] {
+			continue
+		}
+
+		// Whitebox tests use the package's functions unqualified, blackbox tests through its import path
+		ownImport := ""
+		if file.GetPackage() != productionPackage[dir] {
+			ownImport = v.cfg.GetModule() + "/" + dir
+		}
+
+		var spans []Span
+		byFile := make(map[string][]string)
+		seen := make(map[string]bool)
+		for _, use := range file.GetSymbolUses() {
+			if use.GetImportPath() != ownImport {
+				continue
+			}
+			declaringFile, declared := declaredIn[dir][use.GetSymbol()]
+			if !declared || declaringFile == baseName || shared[declaringFile] || isConstructorName(use.GetSymbol()) {
+				continue
+			}
+
+			spans = append(spans, Span{
+				Line:   use.GetLine(),
+				Column: use.GetColumn(),
+			}.withIssue(NewMessage("violation.test_scope.declared_in", "%s is declared in %s.go", use.GetSymbol(), declaringFile)))
+			if !seen[use.GetSymbol()] {
+				seen[use.GetSymbol()] = true
+				byFile[declaringFile] = append(byFile[declaringFile], use.GetSymbol())
+			}
+		}
+		if len(spans) == 0 {
+			continue
+		}
+
+		var outside []string
+		for declaringFile, symbols := range byFile {
+			outside = append(outside, fmt.Sprintf("%s.go (%s)", declaringFile, strings.Join(symbols, ", ")))
+		}
+		sort.Strings(outside)
+
+		violation := Violation{
+			Type:   ViolationTestScope,
+			File:   file.GetRelPath(),
+			Line:   spans[0].Line,
+			Column: spans[0].Column,
+		}.withText(
+			NewMessage("violation.test_scope.issue", "%s_test.go references declarations outside %s.go: %s", baseName, baseName, strings.Join(outside, "; ")),
+			NewMessage("violation.test_scope.rule", "strict_test_naming: %s_test.go should only test the functions declared in %s.go", baseName, baseName),
+			NewMessage("violation.test_scope.fix", "Move these tests into the test file of the declaring file, or list shared files in test_scope.shared"),
+		)
+		if len(spans) > 1 {
+			violation.Spans = spans
+		}
+		violations = append(violations, violation)
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].File < violations[j].File
+	})
+
+	return violations
+}
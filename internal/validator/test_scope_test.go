@@ -0,0 +1,118 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// localUse creates a use of a function declared in another file of the same package
+func localUse(symbol string) validator.SymbolUse {
+	return &testSymbolUse{symbol: symbol, kind: "call"}
+}
+
+func testScopeSourceFiles() []validator.SourceFile {
+	return []validator.SourceFile{
+		&testSourceFile{relPath: "internal/billing/invoice.go", pkg: "billing", funcDecls: []string{"NewInvoice", "total"}},
+		&testSourceFile{relPath: "internal/billing/tax.go", pkg: "billing", funcDecls: []string{"applyTax", "rate"}},
+		&testSourceFile{relPath: "internal/billing/helpers.go", pkg: "billing", funcDecls: []string{"round"}},
+		// Whitebox test reaching into tax.go
+		&testSourceFile{
+			relPath: "internal/billing/invoice_test.go",
+			pkg:     "billing",
+			isTest:  true,
+			symbolUses: []validator.SymbolUse{
+				localUse("total"),
+				localUse("NewInvoice"),
+				localUse("applyTax"),
+				localUse("round"),
+				localUse("rate"),
+				localUse("applyTax"),
+			},
+		},
+		// Blackbox test staying within tax.go
+		&testSourceFile{
+			relPath:    "internal/billing/tax_test.go",
+			pkg:        "billing_test",
+			isTest:     true,
+			symbolUses: []validator.SymbolUse{use("internal/billing", "applyTax", "call")},
+		},
+		// Blackbox test calling invoice.go
+		&testSourceFile{
+			relPath:    "internal/billing/helpers_test.go",
+			pkg:        "billing_test",
+			isTest:     true,
+			symbolUses: []validator.SymbolUse{use("internal/billing", "total", "call"), localUse("total")},
+		},
+		// Orphaned test, left to strict_test_naming
+		&testSourceFile{
+			relPath:    "internal/billing/report_test.go",
+			pkg:        "billing",
+			isTest:     true,
+			symbolUses: []validator.SymbolUse{localUse("applyTax")},
+		},
+	}
+}
+
+func TestValidateTestScope(t *testing.T) {
+	cfg := &testConfig{
+		module:           "github.com/test/project",
+		strictTestNaming: true,
+		enforceTestScope: true,
+		testScopeShared:  []string{"helpers.go"},
+	}
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles(testScopeSourceFiles())
+	violations := v.Validate()
+
+	var scope []validator.Violation
+	for _, violation := range violations {
+		if violation.Type == validator.ViolationTestScope {
+			scope = append(scope, violation)
+		}
+	}
+	if len(scope) != 2 {
+		t.Fatalf("expected 2 test scope violations, got %d: %+v", len(scope), scope)
+	}
+
+	helpers := scope[0]
+	if helpers.File != "internal/billing/helpers_test.go" {
+		t.Errorf("expected first violation in helpers_test.go, got %s", helpers.File)
+	}
+	if !strings.Contains(helpers.Issue, "invoice.go (total)") {
+		t.Errorf("expected blackbox use of total to be reported, got %q", helpers.Issue)
+	}
+
+	invoice := scope[1]
+	if invoice.File != "internal/billing/invoice_test.go" {
+		t.Errorf("expected second violation in invoice_test.go, got %s", invoice.File)
+	}
+	expected := "invoice_test.go references declarations outside invoice.go: tax.go (applyTax, rate)"
+	if invoice.Issue != expected {
+		t.Errorf("expected issue %q, got %q", expected, invoice.Issue)
+	}
+	if len(invoice.Spans) != 3 {
+		t.Errorf("expected 3 spans (one per use), got %d", len(invoice.Spans))
+	}
+	if invoice.RuleKey != "rules.test_scope" {
+		t.Errorf("expected rule key rules.test_scope, got %s", invoice.RuleKey)
+	}
+}
+
+func TestValidateTestScope_RequiresStrictTestNaming(t *testing.T) {
+	cfg := &testConfig{
+		module:           "github.com/test/project",
+		enforceTestScope: true,
+	}
+
+	v := validator.New(cfg, &testGraph{})
+	v.SetSourceFiles(testScopeSourceFiles())
+
+	for _, violation := range v.Validate() {
+		if violation.Type == validator.ViolationTestScope {
+			t.Errorf("expected no test scope violations without strict_test_naming, got %+v", violation)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// constructorPrefixes mark functions that build values rather than exercise behavior
+var constructorPrefixes = []string{"New", "Make", "Build", "Create"}
+
+// validateTestSetupImports flags test files that import another local package only to
+// assemble its values for fixtures: every use is a composite literal of one of its types
+// or a constructor call, and none of its behavior is exercised. Such tests couple to the
+// package's internals; exported test helpers or builders keep that knowledge in one place.
+func (v *Validator) validateTestSetupImports() []Violation {
+	var violations []Violation
+
+	usesByFile := make(map[string][]SymbolUse)
+	for _, file := range v.sourceFiles {
+		if file.GetIsTest() {
+			usesByFile[file.GetRelPath()] = file.GetSymbolUses()
+		}
+	}
+
+	for _, node := range v.graph.GetNodes() {
+		uses, isTest := usesByFile[node.GetRelPath()]
+		if !isTest {
+			continue
+		}
+		fileDir := path.Dir(node.GetRelPath())
+
+		for _, dep := range node.GetDependencies() {
+			localPath := dep.GetLocalPath()
+			if !dep.IsLocalDep() || localPath == fileDir || v.isTestHelperPackage(localPath) {
+				continue
+			}
+
+			setup, exercised := classifySetupUses(uses, dep.GetImportPath())
+			if exercised || len(setup) == 0 {
+				continue
+			}
+
+			pkgName := path.Base(localPath)
+			violations = append(violations, Violation{
+				Type:   ViolationTestSetupImport,
+				File:   node.GetRelPath(),
+				Line:   dep.GetLine(),
+				Column: dep.GetColumn(),
+			}.withText(
+				NewMessage("violation.test_setup_import.issue", "test imports %s only to build fixtures (%s)", localPath, strings.Join(setup, ", ")),
+				NewMessage("violation.test_setup_import.rule", "Tests must not assemble another package's values from its internals for setup"),
+				NewMessage("violation.test_setup_import.fix", "Use exported test helpers or builders (e.g. a %stest package) instead of constructing %s values directly", pkgName, pkgName),
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].File < violations[j].File
+	})
+
+	return violations
+}
+
+// classifySetupUses returns the distinct setup uses (composite literals and constructor
+// calls) of an import and whether any other call exercises its behavior
+func classifySetupUses(uses []SymbolUse, importPath string) ([]string, bool) {
+	var setup []string
+	seen := make(map[string]bool)
+	for _, use := range uses {
+		if use.GetImportPath() != importPath {
+			continue
+		}
+
+		var description string
+		switch use.GetKind() {
+		case "literal":
+			description = use.GetSymbol() + "{}"
+		case "call":
+			if !isConstructorName(use.GetSymbol()) {
+				return nil, true
+			}
+			description = use.GetSymbol() + "()"
+		default:
+			continue // Types in declarations, constants and variables are neutral
+		}
+
+		if !seen[description] {
+			seen[description] = true
+			setup = append(setup, description)
+		}
+	}
+	return setup, false
+}
+
+// isConstructorName reports whether a function name follows a constructor convention
+func isConstructorName(name string) bool {
+	for _, prefix := range constructorPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTestHelperPackage checks if a package provides test helpers: a package named like
+// "storetest", "testutil", "fixtures" or "builders", or a configured helper package
+func (v *Validator) isTestHelperPackage(localPath string) bool {
+	name := path.Base(localPath)
+	if strings.Contains(name, "test") || name == "fixtures" || name == "builders" {
+		return true
+	}
+	for _, helper := range v.cfg.GetTestSetupHelpers() {
+		if isWithinDir(localPath, strings.TrimSuffix(helper, "/")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testSymbolUse struct {
+	importPath string
+	symbol     string
+	kind       string
+}
+
+func (tsu *testSymbolUse) GetImportPath() string { return tsu.importPath }
+func (tsu *testSymbolUse) GetSymbol() string     { return tsu.symbol }
+func (tsu *testSymbolUse) GetKind() string       { return tsu.kind }
+func (tsu *testSymbolUse) GetLine() int          { return 0 }
+func (tsu *testSymbolUse) GetColumn() int        { return 0 }
+
+// localDep creates a dependency on a package of the test project
+func localDep(localPath string, line int) validator.Dependency {
+	return &testDependency{importPath: "github.com/test/project/" + localPath, localPath: localPath, isLocal: true, line: line, column: 2}
+}
+
+// use creates a use of a symbol of a package of the test project
+func use(localPath, symbol, kind string) validator.SymbolUse {
+	return &testSymbolUse{importPath: "github.com/test/project/" + localPath, symbol: symbol, kind: kind}
+}
+
+func TestValidateTestSetupImports(t *testing.T) {
+	cfg := &testConfig{
+		module:                 "github.com/test/project",
+		detectTestSetupImports: true,
+		testSetupHelpers:       []string{"internal/seed"},
+	}
+
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{
+				relPath: "internal/billing/invoice_test.go",
+				dependencies: []validator.Dependency{
+					localDep("internal/billing", 4),     // package under test
+					localDep("internal/store", 5),       // only literals and constructors
+					localDep("internal/pricing", 6),     // behavior is exercised
+					localDep("internal/storetest", 7),   // helper package by name
+					localDep("internal/seed/orders", 8), // configured helper package
+				},
+			},
+			// Production files are not checked
+			&testFileNode{
+				relPath:      "internal/billing/invoice.go",
+				dependencies: []validator.Dependency{localDep("internal/store", 3)},
+			},
+		},
+	}
+
+	v := validator.New(cfg, g)
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath: "internal/billing/invoice_test.go",
+			isTest:  true,
+			symbolUses: []validator.SymbolUse{
+				use("internal/billing", "Invoice", "literal"),
+				use("internal/store", "Order", "literal"),
+				use("internal/store", "Item", "ref"),
+				use("internal/store", "NewCustomer", "call"),
+				use("internal/store", "Order", "literal"),
+				use("internal/pricing", "Rate", "literal"),
+				use("internal/pricing", "Apply", "call"),
+				use("internal/storetest", "Order", "literal"),
+				use("internal/seed/orders", "Default", "literal"),
+			},
+		},
+		&testSourceFile{
+			relPath:    "internal/billing/invoice.go",
+			symbolUses: []validator.SymbolUse{use("internal/store", "Order", "literal")},
+		},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %+v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Type != validator.ViolationTestSetupImport || viol.File != "internal/billing/invoice_test.go" || viol.Line != 5 || viol.Column != 2 {
+		t.Errorf("unexpected violation: %+v", viol)
+	}
+	if viol.Issue != "test imports internal/store only to build fixtures (Order{}, NewCustomer())" {
+		t.Errorf("unexpected issue: %s", viol.Issue)
+	}
+	if viol.Fix != "Use exported test helpers or builders (e.g. a storetest package) instead of constructing store values directly" {
+		t.Errorf("unexpected fix: %s", viol.Fix)
+	}
+	if viol.RuleKey != "rules.test_setup_imports" {
+		t.Errorf("expected rule key rules.test_setup_imports, got %q", viol.RuleKey)
+	}
+}
+
+func TestValidateTestSetupImports_Disabled(t *testing.T) {
+	cfg := &testConfig{module: "github.com/test/project"}
+
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{
+				relPath:      "internal/billing/invoice_test.go",
+				dependencies: []validator.Dependency{localDep("internal/store", 5)},
+			},
+		},
+	}
+
+	v := validator.New(cfg, g)
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath:    "internal/billing/invoice_test.go",
+			isTest:     true,
+			symbolUses: []validator.SymbolUse{use("internal/store", "Order", "literal")},
+		},
+	})
+
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations when disabled, got: %+v", violations)
+	}
+}
@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"path"
+	"strings"
+)
+
+// fixturePackageNames are package names that mark shared test fixture packages
+var fixturePackageNames = []string{"fixtures", "builders", "testsupport", "testutil", "testhelpers"}
+
+// validateTestSupportPackages checks the blessed test support packages listed in
+// test_files.support_packages: production code must not import them, and tests must not
+// pull fixtures from packages that look like fixture packages but are not listed, so
+// shared fixtures stay in the places everyone knows to look.
+func (v *Validator) validateTestSupportPackages() []Violation {
+	var violations []Violation
+
+	// Package names of the project, to recognize "<pkg>test" helpers such as storetest
+	packageNames := make(map[string]bool)
+	for _, node := range v.graph.GetNodes() {
+		packageNames[path.Base(path.Dir(node.GetRelPath()))] = true
+	}
+
+	for _, node := range v.graph.GetNodes() {
+		relPath := node.GetRelPath()
+		fileDir := path.Dir(relPath)
+		isTest := strings.HasSuffix(relPath, "_test.go")
+
+		for _, dep := range node.GetDependencies() {
+			localPath := dep.GetLocalPath()
+			if !dep.IsLocalDep() || localPath == fileDir {
+				continue
+			}
+
+			switch {
+			case !isTest && v.isTestSupportPackage(localPath) && !v.isTestSupportPackage(fileDir):
+				violations = append(violations, Violation{
+					Type:   ViolationTestSupportImport,
+					File:   relPath,
+					Line:   dep.GetLine(),
+					Column: dep.GetColumn(),
+				}.withText(
+					NewMessage("violation.test_support_import.issue", "%s imports test support package %s", fileDir, localPath),
+					NewMessage("violation.test_support_import.rule", "Test support packages (test_files.support_packages) may only be imported by _test.go files"),
+					NewMessage("violation.test_support_import.fix", "Move the code production needs out of %s, or import it only from tests", localPath),
+				))
+
+			case isTest && isFixturePackageName(path.Base(localPath), packageNames) && !v.isTestSupportPackage(localPath):
+				violations = append(violations, Violation{
+					Type:   ViolationUnlistedFixtures,
+					File:   relPath,
+					Line:   dep.GetLine(),
+					Column: dep.GetColumn(),
+				}.withText(
+					NewMessage("violation.unlisted_fixtures.issue", "test imports fixture package %s, which is not a listed test support package", localPath),
+					NewMessage("violation.unlisted_fixtures.rule", "Shared test fixtures must come from test_files.support_packages: %v", v.cfg.GetTestSupportPackages()),
+					NewMessage("violation.unlisted_fixtures.fix", "Move the fixtures into a listed support package, or add %s to test_files.support_packages", localPath),
+				))
+			}
+		}
+	}
+
+	return violations
+}
+
+// isTestSupportPackage checks if a directory is, or is inside, a listed test support package
+func (v *Validator) isTestSupportPackage(localPath string) bool {
+	for _, pkg := range v.cfg.GetTestSupportPackages() {
+		if isWithinDir(localPath, strings.TrimSuffix(pkg, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFixturePackageName reports whether a package name marks a shared fixture package:
+// a conventional name such as "fixtures" or "testutil", or "<pkg>test" for a package
+// of the project, e.g. "storetest" next to "store"
+func isFixturePackageName(name string, packageNames map[string]bool) bool {
+	for _, fixtureName := range fixturePackageNames {
+		if name == fixtureName {
+			return true
+		}
+	}
+	base, isHelper := strings.CutSuffix(name, "test")
+	return isHelper && packageNames[base]
+}
@@ -0,0 +1,74 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestValidateTestSupportPackages(t *testing.T) {
+	cfg := &testConfig{
+		module:              "github.com/test/project",
+		directoriesImport:   map[string][]string{"internal": {}},
+		testSupportPackages: []string{"internal/testsupport"},
+	}
+
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{relPath: "internal/store/store.go"},
+			&testFileNode{relPath: "internal/latest/latest.go"},
+			&testFileNode{relPath: "internal/testsupport/orders.go"},
+			&testFileNode{
+				relPath:      "internal/testsupport/fakes/clock.go",
+				dependencies: []validator.Dependency{localDep("internal/testsupport", 3)}, // support importing support
+			},
+			// Production code importing the support package
+			&testFileNode{
+				relPath:      "internal/billing/invoice.go",
+				dependencies: []validator.Dependency{localDep("internal/testsupport/fakes", 3)},
+			},
+			&testFileNode{
+				relPath: "internal/billing/invoice_test.go",
+				dependencies: []validator.Dependency{
+					localDep("internal/testsupport", 4), // blessed, exempt from directories_import
+					localDep("internal/storetest", 5),   // unlisted helper of internal/store
+					localDep("internal/fixtures", 6),    // unlisted fixtures package
+					localDep("internal/latest", 7),      // ends in "test" but is no helper
+				},
+			},
+		},
+	}
+
+	violations := validator.New(cfg, g).Validate()
+
+	counts := make(map[validator.ViolationType]int)
+	for _, violation := range violations {
+		counts[violation.Type]++
+		switch violation.Type {
+		case validator.ViolationTestSupportImport:
+			if violation.File != "internal/billing/invoice.go" || violation.Line != 3 {
+				t.Errorf("expected production import at internal/billing/invoice.go:3, got %s:%d", violation.File, violation.Line)
+			}
+		case validator.ViolationUnlistedFixtures:
+			if violation.File != "internal/billing/invoice_test.go" {
+				t.Errorf("expected unlisted fixtures in invoice_test.go, got %s", violation.File)
+			}
+			if violation.RuleKey != "rules.test_files.support_packages" {
+				t.Errorf("expected rule key rules.test_files.support_packages, got %s", violation.RuleKey)
+			}
+		case validator.ViolationForbidden:
+			for _, span := range append(violation.Spans, validator.Span{Issue: violation.Issue}) {
+				if span.Issue == "internal/billing imports internal/testsupport" {
+					t.Errorf("expected test import of a support package to be exempt from directories_import, got %q", violation.Issue)
+				}
+			}
+		}
+	}
+
+	if counts[validator.ViolationTestSupportImport] != 1 {
+		t.Errorf("expected 1 production import of test support, got %d", counts[validator.ViolationTestSupportImport])
+	}
+	if counts[validator.ViolationUnlistedFixtures] != 2 {
+		t.Errorf("expected 2 unlisted fixture imports (storetest, fixtures), got %d", counts[validator.ViolationUnlistedFixtures])
+	}
+}
@@ -1,7 +1,7 @@
 package validator
 
 import (
-	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 )
@@ -25,24 +25,26 @@ func (v *Validator) validateTestFileLocations() []Violation {
 			// Test files should be next to the code they're testing (not in a separate tests/ directory)
 			if strings.HasPrefix(relPath, "tests/") || strings.Contains(relPath, "/tests/") {
 				violations = append(violations, Violation{
-					Type:  ViolationTestFileLocation,
-					File:  relPath,
-					Issue: "Test file is in separate tests/ directory",
-					Rule:  "Test files should be colocated with the code they test (location: colocated)",
-					Fix:   "Move test file to the same directory as the code it tests",
-				})
+					Type: ViolationTestFileLocation,
+					File: relPath,
+				}.withText(
+					NewMessage("violation.test_file_location.issue", "Test file is in separate tests/ directory"),
+					NewMessage("violation.test_file_location.rule", "Test files should be colocated with the code they test (location: colocated)"),
+					NewMessage("violation.test_file_location.fix", "Move test file to the same directory as the code it tests"),
+				))
 			}
 
 		case "separate":
 			// Test files should be in a tests/ directory
 			if !strings.HasPrefix(relPath, "tests/") && !strings.Contains(relPath, "/tests/") {
 				violations = append(violations, Violation{
-					Type:  ViolationTestFileLocation,
-					File:  relPath,
-					Issue: "Test file is colocated with code instead of in tests/ directory",
-					Rule:  "Test files should be in a separate tests/ directory (location: separate)",
-					Fix:   "Move test file to tests/ directory mirroring the source structure",
-				})
+					Type: ViolationTestFileLocation,
+					File: relPath,
+				}.withText(
+					NewMessage("violation.test_file_location.issue_separate", "Test file is colocated with code instead of in tests/ directory"),
+					NewMessage("violation.test_file_location.rule_separate", "Test files should be in a separate tests/ directory (location: separate)"),
+					NewMessage("violation.test_file_location.fix_separate", "Move test file to tests/ directory mirroring the source structure"),
+				))
 			}
 		}
 	}
@@ -64,10 +66,9 @@ func (v *Validator) validateBlackboxTests() []Violation {
 		}
 
 		// Check if this is a whitebox test (package without _test suffix)
-		if !strings.HasSuffix(packageName, "_test") {
+		if !strings.HasSuffix(packageName, "_test") && !v.isWhiteboxExempt(relPath) {
 			// Determine the expected package name
-			fileDir := filepath.Dir(relPath)
-			fileDir = filepath.ToSlash(fileDir)
+			fileDir := path.Dir(relPath)
 
 			// Get the base package name from the directory
 			parts := strings.Split(fileDir, "/")
@@ -78,15 +79,71 @@ func (v *Validator) validateBlackboxTests() []Violation {
 			expectedPkg := basePkgName + "_test"
 
 			violations = append(violations, Violation{
-				Type:  ViolationWhiteboxTest,
-				File:  relPath,
-				Line:  1, // Package declaration is typically on line 1
-				Issue: fmt.Sprintf("Test file uses whitebox testing (package %s instead of %s)", packageName, expectedPkg),
-				Rule:  "Blackbox testing is enforced to ensure tests validate the public API, not internal implementation",
-				Fix:   fmt.Sprintf("Change package declaration from 'package %s' to 'package %s'", packageName, expectedPkg),
-			})
+				Type:   ViolationWhiteboxTest,
+				File:   relPath,
+				Line:   node.GetPackageLine(),
+				Column: node.GetPackageColumn(),
+			}.withText(
+				NewMessage("violation.whitebox_test.issue", "Test file uses whitebox testing (package %s instead of %s)", packageName, expectedPkg),
+				NewMessage("violation.whitebox_test.rule", "Blackbox testing is enforced to ensure tests validate the public API, not internal implementation"),
+				NewMessage("violation.whitebox_test.fix", "Change package declaration from 'package %s' to 'package %s', or exempt the package in test_files.allow_whitebox with a reason", packageName, expectedPkg),
+			))
 		}
 	}
 
 	return violations
 }
+
+// isWhiteboxExempt checks if a test file is exempt from require_blackbox: its directory
+// is within an allow_whitebox path, or the directory or file matches its glob pattern
+func (v *Validator) isWhiteboxExempt(relPath string) bool {
+	relPath = filepath.ToSlash(relPath)
+	fileDir := path.Dir(relPath)
+	for pattern := range v.cfg.GetWhiteboxExemptions() {
+		pattern = strings.TrimSuffix(pattern, "/")
+		segments := strings.Split(pattern, "/")
+		if isWithinDir(fileDir, pattern) || matchSegments(segments, strings.Split(fileDir, "/")) ||
+			matchSegments(segments, strings.Split(relPath, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExemptTestImport checks if a test file in fileDir may use an import regardless of
+// layer rules. The list of the most specific directory pattern in exempt_imports that
+// matches fileDir applies, or the global list if none does. "*" exempts every import.
+func (v *Validator) isExemptTestImport(fileDir string, dep Dependency) bool {
+	exempt := v.cfg.GetTestExemptImports()
+	best, specificity := "", -1
+	for pattern, imports := range v.cfg.GetTestExemptImportsByDir() {
+		dirPattern := strings.TrimSuffix(pattern, "/")
+		if !isWithinDir(fileDir, dirPattern) && !matchSegments(strings.Split(dirPattern, "/"), strings.Split(fileDir, "/")) {
+			continue
+		}
+		// Ties are broken by pattern so the choice does not depend on map order
+		if s := literalSegments(dirPattern); s > specificity || (s == specificity && pattern < best) {
+			exempt, best, specificity = imports, pattern, s
+		}
+	}
+
+	for _, entry := range exempt {
+		entry = strings.TrimSuffix(entry, "/")
+		if entry == "*" || isWithinDir(dep.GetImportPath(), entry) ||
+			(dep.IsLocalDep() && isWithinDir(dep.GetLocalPath(), entry)) {
+			return true
+		}
+	}
+	return false
+}
+
+// literalSegments counts the path segments of a pattern that are not wildcards
+func literalSegments(pattern string) int {
+	count := 0
+	for _, segment := range strings.Split(pattern, "/") {
+		if !strings.Contains(segment, "*") {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// detectTypeLeaks finds exported functions in the configured layers whose signatures
+// mention types from forbidden packages. Importing such a package may be fine for the
+// implementation, but exposing its types forces every caller to depend on it too.
+func (v *Validator) detectTypeLeaks() []Violation {
+	var violations []Violation
+
+	for _, sig := range v.signatures {
+		fileDir := path.Dir(sig.GetFile())
+		if !v.isTypeLeakLayer(fileDir) {
+			continue
+		}
+
+		for _, typ := range sig.GetTypes() {
+			forbidden, ok := v.matchForbiddenTypePackage(typ.GetPackagePath())
+			if !ok {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				Type:   ViolationTypeLeak,
+				File:   sig.GetFile(),
+				Line:   sig.GetLine(),
+				Column: sig.GetColumn(),
+			}.withText(
+				NewMessage("violation.type_leak.issue", "Exported %s exposes %s.%s (forbidden: %s)", sig.GetName(), typ.GetPackagePath(), typ.GetName(), forbidden),
+				NewMessage("violation.type_leak.rule", "Exported signatures in core layers must not mention types from forbidden packages"),
+				NewMessage("violation.type_leak.fix", "Replace %s with a type or interface owned by %s and adapt it internally", typ.GetName(), fileDir),
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+
+	return violations
+}
+
+// isTypeLeakLayer checks if a directory belongs to one of the layers checked for type leaks
+func (v *Validator) isTypeLeakLayer(fileDir string) bool {
+	for _, layer := range v.cfg.GetTypeLeakLayers() {
+		if isWithinDir(fileDir, strings.TrimSuffix(layer, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchForbiddenTypePackage returns the forbidden entry matching pkgPath.
+// Entries are either import paths (external modules, stdlib packages) or local directories.
+func (v *Validator) matchForbiddenTypePackage(pkgPath string) (string, bool) {
+	for _, forbidden := range v.cfg.GetTypeLeakForbidden() {
+		forbidden = strings.TrimSuffix(forbidden, "/")
+		localPath := v.cfg.GetModule() + "/" + forbidden
+		if isWithinDir(pkgPath, forbidden) || isWithinDir(pkgPath, localPath) {
+			return forbidden, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,119 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testSignatureType struct {
+	pkgPath string
+	name    string
+}
+
+func (tst *testSignatureType) GetPackagePath() string { return tst.pkgPath }
+func (tst *testSignatureType) GetName() string        { return tst.name }
+
+type testSignature struct {
+	file   string
+	line   int
+	column int
+	name   string
+	types  []validator.SignatureType
+}
+
+func (ts *testSignature) GetFile() string                     { return ts.file }
+func (ts *testSignature) GetLine() int                        { return ts.line }
+func (ts *testSignature) GetColumn() int                      { return ts.column }
+func (ts *testSignature) GetName() string                     { return ts.name }
+func (ts *testSignature) GetTypes() []validator.SignatureType { return ts.types }
+
+func typeLeakConfig() *testConfig {
+	return &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal": {},
+		},
+		typeLeakLayers:    []string{"internal/domain"},
+		typeLeakForbidden: []string{"github.com/jackc/pgx", "internal/infra", "database/sql"},
+	}
+}
+
+func TestDetectTypeLeaks_ForbiddenTypesInDomainSignatures(t *testing.T) {
+	v := validator.New(typeLeakConfig(), &testGraph{})
+	v.SetExportedSignatures([]validator.ExportedSignature{
+		&testSignature{
+			file: "internal/domain/order/repo.go",
+			line: 10,
+			name: "Save",
+			types: []validator.SignatureType{
+				&testSignatureType{pkgPath: "context", name: "Context"},
+				&testSignatureType{pkgPath: "github.com/jackc/pgx/v5", name: "Tx"},
+			},
+		},
+		&testSignature{
+			file: "internal/domain/order/repo.go",
+			line: 20,
+			name: "Order.Store",
+			types: []validator.SignatureType{
+				&testSignatureType{pkgPath: "github.com/test/project/internal/infra", name: "DB"},
+				&testSignatureType{pkgPath: "database/sql", name: "Rows"},
+			},
+		},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 3 {
+		t.Fatalf("expected 3 violations, got %d: %v", len(violations), violations)
+	}
+
+	for _, viol := range violations {
+		if viol.Type != validator.ViolationTypeLeak {
+			t.Errorf("expected ViolationTypeLeak, got %s", viol.Type)
+		}
+	}
+	if violations[0].Line != 10 || !strings.Contains(violations[0].Issue, "github.com/jackc/pgx/v5.Tx") {
+		t.Errorf("expected pgx leak on line 10, got %d: %s", violations[0].Line, violations[0].Issue)
+	}
+	if !strings.Contains(violations[1].Issue, "forbidden: internal/infra") {
+		t.Errorf("expected local directory match, got: %s", violations[1].Issue)
+	}
+}
+
+func TestDetectTypeLeaks_IgnoresOtherLayersAndAllowedTypes(t *testing.T) {
+	v := validator.New(typeLeakConfig(), &testGraph{})
+	v.SetExportedSignatures([]validator.ExportedSignature{
+		// Infra may expose its own and external types
+		&testSignature{
+			file: "internal/infra/db.go",
+			line: 5,
+			name: "Open",
+			types: []validator.SignatureType{
+				&testSignatureType{pkgPath: "github.com/jackc/pgx/v5", name: "Conn"},
+			},
+		},
+		// Prefix matches must end on a path boundary
+		&testSignature{
+			file: "internal/domainevents/events.go",
+			line: 5,
+			name: "Publish",
+			types: []validator.SignatureType{
+				&testSignatureType{pkgPath: "github.com/jackc/pgx/v5", name: "Conn"},
+			},
+		},
+		&testSignature{
+			file: "internal/domain/order.go",
+			line: 5,
+			name: "New",
+			types: []validator.SignatureType{
+				&testSignatureType{pkgPath: "github.com/jackc/pgxpool", name: "Pool"},
+				&testSignatureType{pkgPath: "github.com/test/project/internal/infrastructure", name: "Config"},
+			},
+		},
+	})
+
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations, got %d: %v", len(violations), violations)
+	}
+}
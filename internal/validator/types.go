@@ -3,22 +3,63 @@ package validator
 // Config interface defines what validator needs from configuration
 type Config interface {
 	GetDirectoriesImport() map[string][]string
+	GetDefaultPolicy() string // "allow" or "deny" for directories without a directories_import rule
 	ShouldDetectUnused() bool
 	GetRequiredDirectories() map[string]string
 	ShouldAllowOtherDirectories() bool
+	GetReadmeDirectories() []string // directories that must have a README.md describing the layer
 	ShouldDetectSharedExternalImports() bool
 	GetSharedExternalImportsMode() string
 	GetSharedExternalImportsExclusions() []string
 	GetSharedExternalImportsExclusionPatterns() []string
 	ShouldLintTestFiles() bool
 	GetTestExemptImports() []string
+	GetTestExemptImportsByDir() map[string][]string // directory pattern -> imports exempt for test files there
 	GetTestFileLocation() string
 	ShouldRequireBlackboxTests() bool
+	GetWhiteboxExemptions() map[string]string // path pattern -> reason whitebox tests are allowed
+	GetTestSupportPackages() []string         // blessed fixture packages only test files may import
+	ShouldRequireTestdataFixtures() bool
+	ShouldDetectTestSetupImports() bool
+	GetTestSetupHelpers() []string // packages providing exported test helpers or builders
+	ShouldEnforceTestScope() bool
+	GetTestScopeShared() []string // file base names any test may reference
 	IsCoverageEnabled() bool
 	GetCoverageThreshold() float64
 	GetPackageThresholds() map[string]float64
 	GetModule() string
 	ShouldEnforceStrictTestNaming() bool
+	ShouldDetectDuplicates() bool
+	GetWrapIn() map[string]string // external module -> wrapper package directory
+	GetTypeLeakLayers() []string
+	GetTypeLeakForbidden() []string
+	ShouldConfineConfigLoading() bool
+	GetConfigLoadingAllowed() []string
+	GetConfigLoadingPackages() []string
+	ShouldDetectFrameworkLockIn() bool
+	GetFrameworkLockInAllowed() []string
+	GetFrameworkLockInTypes() []string // "import/path.Type"
+	ShouldCheckPackageNaming() bool
+	GetPackageNamingExceptions() []string // package names exempt from the directory name check
+	ShouldEnforceStability() bool
+	ShouldDetectDeprecatedUsages() bool
+	GetOwnershipTeams() map[string]string // directory subtree -> owning team
+	GetOwnershipContracts() []string      // glob patterns of public contract packages
+	GetPackageDocLayers() []string        // directories whose packages need a doc comment
+	GetMainPackageLocations() []string    // directories or ** globs where main packages may live
+	GetOrphanInterfaceLayers() []string   // consumer directories whose interfaces need an implementation
+	GetAdapterLayers() []string           // directories whose packages must implement a port
+	GetAdapterPortLayers() []string       // directories whose interfaces are ports
+	ShouldRequireAdapterAssertions() bool // whether adapter types must assert the ports they implement
+	ShouldDetectHiddenDependencies() bool
+	GetLicensePolicyLayers() map[string][]string             // directory subtree -> allowed license categories or SPDX identifiers
+	GetLicensePolicyExceptions() []string                    // modules allowed in every layer
+	GetExternalImportDepthLayers() map[string]map[string]int // directory subtree -> external module -> path elements allowed below it
+	ShouldDetectVersionSprawl() bool
+	ShouldCheckReplaceDirectives() bool
+	GetReplaceDirectivesAllowed() []string // replaced modules (or path.Match patterns) whose replaces are accepted
+	GetShuffleCleanDirectories() []string  // directories whose tests must pass in random order
+	GetRaceCleanDirectories() []string     // directories whose tests must pass the race detector
 }
 
 // PackageCoverage interface for accessing package coverage information
@@ -28,11 +69,36 @@ type PackageCoverage interface {
 	HasTests() bool
 }
 
+// TestFailure interface for accessing a test that failed in a go test run a rule requires
+type TestFailure interface {
+	GetPackagePath() string // import path of the package
+	GetTest() string        // failing top-level test (empty for failures of the whole package, e.g. build errors)
+	GetSeed() string        // seed of the test order with -shuffle (empty without)
+	GetOutput() string      // first lines of the test output
+	IsRace() bool           // the race detector reported a data race (with -race)
+}
+
+// ModuleLicense interface for accessing the detected license of a required module
+type ModuleLicense interface {
+	GetModule() string
+	GetLicense() string  // SPDX identifier (empty if not detected)
+	GetCategory() string // "permissive", "weak-copyleft", "copyleft" or "unknown"
+}
+
+// ReplaceDirective interface for accessing a replace directive of go.mod
+type ReplaceDirective interface {
+	GetModule() string      // Replaced module path
+	GetReplacement() string // Module path or local directory it is replaced with
+	GetLine() int           // Line of the directive in go.mod
+}
+
 // Dependency interface for accessing dependency information
 type Dependency interface {
 	GetImportPath() string
 	GetLocalPath() string
 	IsLocalDep() bool
+	GetLine() int   // Line of the import (0 if unknown)
+	GetColumn() int // Column of the import path (0 if unknown)
 }
 
 // FileNode interface for accessing file node information
@@ -40,6 +106,9 @@ type FileNode interface {
 	GetRelPath() string
 	GetPackage() string
 	GetDependencies() []Dependency
+	GetPackageLine() int    // Line of the package clause (0 if unknown)
+	GetPackageColumn() int  // Column of the package clause (0 if unknown)
+	GetDIFramework() string // DI framework whose wiring the file holds ("wire", "fx", "dig"; empty if none)
 }
 
 // Graph interface defines what validator needs from the dependency graph
@@ -47,6 +116,125 @@ type Graph interface {
 	GetNodes() []FileNode
 }
 
+// StructDef interface for accessing a struct type definition
+type StructDef interface {
+	GetName() string
+	GetLine() int
+	GetColumn() int
+	GetFields() []string
+}
+
+// ConstBlock interface for accessing a grouped constant declaration
+type ConstBlock interface {
+	GetLine() int
+	GetColumn() int
+	GetEntries() []string
+}
+
+// SourceFile interface for accessing declaration-level information about a scanned file
+type SourceFile interface {
+	GetRelPath() string
+	GetPackage() string
+	GetIsTest() bool
+	GetStructDefs() []StructDef
+	GetConstBlocks() []ConstBlock
+	GetAPIReferences() []APIReference
+	GetSignatureRefs() []APIReference
+	GetStability() string // archlint:stability annotation, empty if absent
+	GetDeprecatedSymbols() []string
+	GetSymbolRefs() []APIReference
+	GetPackageDoc() string // package doc comment, empty if absent
+	GetFileReads() []FileRead
+	GetSymbolUses() []SymbolUse
+	GetFuncDecls() []string // package-level functions, without methods
+	GetDynamicRefs() []DynamicRef
+}
+
+// SymbolUse interface for accessing a use of an imported or same-package symbol and how it is used
+type SymbolUse interface {
+	GetImportPath() string // empty for symbols declared in another file of the same package
+	GetSymbol() string
+	GetKind() string // "literal" (composite literal type), "call" or "ref"
+	GetLine() int
+	GetColumn() int
+}
+
+// FileRead interface for accessing a file path passed as a string literal to a file reader
+type FileRead interface {
+	GetFunction() string // qualified reader, e.g. "os.ReadFile"
+	GetPath() string
+	GetLine() int
+	GetColumn() int
+}
+
+// DynamicRef interface for accessing a registry key, a reflect lookup by name or an
+// exported method a reflect lookup can reach
+type DynamicRef interface {
+	GetKind() string     // "register", "lookup", "reflect" or "method"
+	GetFunction() string // call as written, e.g. "registry.Get", or the method as "Type.Method"
+	GetKey() string
+	GetLine() int
+	GetColumn() int
+}
+
+// APIReference interface for accessing an imported symbol used in an exported declaration
+type APIReference interface {
+	GetDecl() string
+	GetImportPath() string
+	GetSymbol() string
+	GetLine() int
+	GetColumn() int
+}
+
+// SignatureType interface for accessing a named type mentioned in a signature
+type SignatureType interface {
+	GetPackagePath() string
+	GetName() string
+}
+
+// ExportedSignature interface for accessing the resolved types of an exported function
+type ExportedSignature interface {
+	GetFile() string
+	GetLine() int
+	GetColumn() int
+	GetName() string
+	GetTypes() []SignatureType
+}
+
+// InterfaceDecl interface for accessing a package-level interface and whether it is implemented
+type InterfaceDecl interface {
+	GetFile() string
+	GetLine() int
+	GetColumn() int
+	GetName() string
+	IsImplemented() bool       // whether a concrete type of the codebase (or an imported stdlib package) satisfies it
+	GetImplementers() []string // implementing types as "dir.Type" or "*dir.Type" (stdlib types by import path)
+}
+
+// PortImplementation interface for accessing a concrete type satisfying an interface of
+// another package
+type PortImplementation interface {
+	GetFile() string
+	GetLine() int
+	GetColumn() int
+	GetType() string      // implementing type as "dir.Type"
+	GetInterface() string // implemented interface as "dir.Name"
+	IsAsserted() bool     // whether the package of the type asserts the implementation at compile time
+}
+
+// ChangeSet interface for checking which lines were added relative to a base revision
+type ChangeSet interface {
+	IsLineAdded(relPath string, line int) bool
+}
+
+// ParseError interface for accessing a file skipped because of syntax errors
+type ParseError interface {
+	GetRelPath() string
+	GetLine() int
+	GetColumn() int
+	GetMessage() string
+}
+
 // ViolationType represents the type of architectural violation
 type ViolationType string
 
@@ -65,16 +253,196 @@ const (
 	ViolationWhiteboxTest         ViolationType = "Whitebox Test"
 	ViolationLowCoverage          ViolationType = "Insufficient Test Coverage"
 	ViolationTestNaming           ViolationType = "Test Naming Convention"
+	ViolationDuplicateDefinition  ViolationType = "Duplicate Definition"
+	ViolationUnwrappedImport      ViolationType = "Unwrapped External Import"
+	ViolationWrapperBypass        ViolationType = "Anti-corruption Layer Bypass"
+	ViolationTypeLeak             ViolationType = "Type Leak in Exported Signature"
+	ViolationConfigLoading        ViolationType = "Scattered Configuration Loading"
+	ViolationFrameworkLockIn      ViolationType = "Framework Lock-in"
+	ViolationParseError           ViolationType = "Parse Error"
+	ViolationMultiplePackages     ViolationType = "Multiple Packages in Directory"
+	ViolationPackageName          ViolationType = "Package Name Mismatch"
+	ViolationUnstableDependency   ViolationType = "Stable Package Imports Experimental"
+	ViolationDeprecatedUsage      ViolationType = "New Usage of Deprecated Symbol"
+	ViolationCrossTeamImport      ViolationType = "Cross-team Internal Access"
+	ViolationMissingPackageDoc    ViolationType = "Missing Package Documentation"
+	ViolationFixtureLocation      ViolationType = "Test Fixture Outside testdata"
+	ViolationTestSetupImport      ViolationType = "Test Setup via Package Internals"
+	ViolationTestScope            ViolationType = "Test Exceeds Unit Scope"
+	ViolationTestSupportImport    ViolationType = "Production Import of Test Support"
+	ViolationUnlistedFixtures     ViolationType = "Unlisted Test Fixture Package"
+	ViolationMainPackageLocation  ViolationType = "Main Package Outside Approved Locations"
+	ViolationOrphanInterface      ViolationType = "Orphan Interface"
+	ViolationPortlessAdapter      ViolationType = "Adapter Implements No Port"
+	ViolationUnassertedAdapter    ViolationType = "Missing Adapter Assertion"
+	ViolationLayerCycle           ViolationType = "Cyclic Layer Rules"
+	ViolationMissingReadme        ViolationType = "Missing Layer README"
+	ViolationHiddenDependency     ViolationType = "Hidden Dependency"
+	ViolationLicensePolicy        ViolationType = "Disallowed Dependency License"
+	ViolationVersionSprawl        ViolationType = "Multiple Major Versions"
+	ViolationReplaceDirective     ViolationType = "Local or Forked Module Replace"
+	ViolationImportDepth          ViolationType = "External Import Too Deep"
+	ViolationOrderDependentTest   ViolationType = "Order-dependent Test"
+	ViolationDataRace             ViolationType = "Data Race"
+)
+
+// ruleKeys maps violation types to the configuration key of the rule that produces
+// them. Hardcoded rules (cross-cmd, pkg-to-pkg, skip-level, parse errors) have no key.
+var ruleKeys = map[ViolationType]string{
+	ViolationUnused:               "rules.detect_unused",
+	ViolationForbidden:            "rules.directories_import",
+	ViolationMissingDirectory:     "structure.required_directories",
+	ViolationUnexpectedDirectory:  "structure.allow_other_directories",
+	ViolationEmptyDirectory:       "structure.required_directories",
+	ViolationUnusedDirectory:      "structure.required_directories",
+	ViolationSharedExternalImport: "rules.shared_external_imports",
+	ViolationTestFileLocation:     "rules.test_files.location",
+	ViolationWhiteboxTest:         "rules.test_files.require_blackbox",
+	ViolationLowCoverage:          "rules.test_coverage",
+	ViolationTestNaming:           "rules.strict_test_naming",
+	ViolationDuplicateDefinition:  "rules.detect_duplicates",
+	ViolationUnwrappedImport:      "rules.wrap_in",
+	ViolationWrapperBypass:        "rules.wrap_in",
+	ViolationTypeLeak:             "rules.type_leaks",
+	ViolationConfigLoading:        "rules.config_loading",
+	ViolationFrameworkLockIn:      "rules.framework_lock_in",
+	ViolationMultiplePackages:     "rules.package_naming",
+	ViolationPackageName:          "rules.package_naming",
+	ViolationUnstableDependency:   "rules.enforce_stability",
+	ViolationDeprecatedUsage:      "rules.deprecations",
+	ViolationCrossTeamImport:      "rules.ownership",
+	ViolationMissingPackageDoc:    "rules.package_docs",
+	ViolationFixtureLocation:      "rules.test_files.require_testdata",
+	ViolationTestSetupImport:      "rules.test_setup_imports",
+	ViolationTestScope:            "rules.test_scope",
+	ViolationTestSupportImport:    "rules.test_files.support_packages",
+	ViolationUnlistedFixtures:     "rules.test_files.support_packages",
+	ViolationMainPackageLocation:  "rules.main_packages",
+	ViolationOrphanInterface:      "rules.orphan_interfaces",
+	ViolationPortlessAdapter:      "rules.adapter_ports",
+	ViolationUnassertedAdapter:    "rules.adapter_ports.require_assertions",
+	ViolationLayerCycle:           "rules.directories_import",
+	ViolationMissingReadme:        "structure.require_readme",
+	ViolationHiddenDependency:     "rules.hidden_dependencies",
+	ViolationLicensePolicy:        "rules.license_policy",
+	ViolationVersionSprawl:        "rules.detect_version_sprawl",
+	ViolationReplaceDirective:     "rules.replace_directives",
+	ViolationImportDepth:          "rules.external_import_depth",
+	ViolationOrderDependentTest:   "rules.require_shuffle_clean",
+	ViolationDataRace:             "rules.require_race_clean",
+}
+
+// violationCodes gives every violation type a short, stable code that reports, docs,
+// searches and suppressions can refer to. Codes are never renumbered or reused: a new
+// violation type takes the next free number.
+var violationCodes = map[ViolationType]string{
+	ViolationPkgToPkg:             "ARCH001",
+	ViolationSkipLevel:            "ARCH002",
+	ViolationCrossCmd:             "ARCH003",
+	ViolationUnused:               "ARCH004",
+	ViolationForbidden:            "ARCH005",
+	ViolationMissingDirectory:     "ARCH006",
+	ViolationUnexpectedDirectory:  "ARCH007",
+	ViolationEmptyDirectory:       "ARCH008",
+	ViolationUnusedDirectory:      "ARCH009",
+	ViolationSharedExternalImport: "ARCH010",
+	ViolationTestFileLocation:     "ARCH011",
+	ViolationWhiteboxTest:         "ARCH012",
+	ViolationLowCoverage:          "ARCH013",
+	ViolationTestNaming:           "ARCH014",
+	ViolationDuplicateDefinition:  "ARCH015",
+	ViolationUnwrappedImport:      "ARCH016",
+	ViolationWrapperBypass:        "ARCH017",
+	ViolationTypeLeak:             "ARCH018",
+	ViolationConfigLoading:        "ARCH019",
+	ViolationFrameworkLockIn:      "ARCH020",
+	ViolationParseError:           "ARCH021",
+	ViolationMultiplePackages:     "ARCH022",
+	ViolationPackageName:          "ARCH023",
+	ViolationUnstableDependency:   "ARCH024",
+	ViolationDeprecatedUsage:      "ARCH025",
+	ViolationCrossTeamImport:      "ARCH026",
+	ViolationMissingPackageDoc:    "ARCH027",
+	ViolationFixtureLocation:      "ARCH028",
+	ViolationTestSetupImport:      "ARCH029",
+	ViolationTestScope:            "ARCH030",
+	ViolationTestSupportImport:    "ARCH031",
+	ViolationUnlistedFixtures:     "ARCH032",
+	ViolationMainPackageLocation:  "ARCH033",
+	ViolationOrphanInterface:      "ARCH034",
+	ViolationPortlessAdapter:      "ARCH035",
+	ViolationUnassertedAdapter:    "ARCH036",
+	ViolationLayerCycle:           "ARCH037",
+	ViolationMissingReadme:        "ARCH038",
+	ViolationHiddenDependency:     "ARCH039",
+	ViolationLicensePolicy:        "ARCH040",
+	ViolationVersionSprawl:        "ARCH041",
+	ViolationReplaceDirective:     "ARCH042",
+	ViolationImportDepth:          "ARCH043",
+	ViolationOrderDependentTest:   "ARCH044",
+	ViolationDataRace:             "ARCH045",
+}
+
+// Severity represents how serious a violation is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // Fails the build (default)
+	SeverityWarning Severity = "warning" // Reported but does not fail the build
+	SeverityInfo    Severity = "info"    // Informational finding, does not fail the build
 )
 
 // Violation represents an architectural rule violation
 type Violation struct {
-	Type  ViolationType
-	File  string // File path where violation occurs
-	Line  int    // Line number (0 if not applicable)
-	Issue string // Description of the issue
-	Rule  string // Rule that was violated
-	Fix   string // Suggested fix
+	Type   ViolationType
+	File   string // File path where violation occurs
+	Line   int    // Line number (0 if not applicable)
+	Column int    // Column number (0 if not applicable)
+	Issue  string // Description of the issue
+	Rule   string // Rule that was violated
+	Fix    string // Suggested fix
+	// Severity of the violation (empty means SeverityError)
+	Severity Severity
+	// Link to the offending source line (empty unless source links are configured)
+	Link string
+	// RuleKey is the configuration key of the rule that produced the violation, e.g.
+	// "rules.directories_import.cmd" (empty for hardcoded rules)
+	RuleKey string
+	// Source is the configuration layer that defined the rule, e.g. "preset ddd" or
+	// "overrides" (empty until set by the caller)
+	Source string
+	// Spans lists every offending location when several findings in a file are
+	// collapsed into one violation (empty otherwise)
+	Spans []Span
+	// IssueMessages, RuleMessages and FixMessages make up Issue, Rule and Fix as
+	// catalog messages, so reports can translate them (empty if not translatable)
+	IssueMessages []Message
+	RuleMessages  []Message
+	FixMessages   []Message
+}
+
+// Span is one offending location of a collapsed violation
+type Span struct {
+	Line   int    // Line number (0 if unknown)
+	Column int    // Column number (0 if unknown)
+	Issue  string // What is at the location, e.g. the offending import
+	// IssueMessage is Issue as a catalog message (zero if not translatable)
+	IssueMessage Message
+}
+
+// GetLine implements output.Span interface
+func (s Span) GetLine() int {
+	return s.Line
+}
+
+// GetColumn implements output.Span interface
+func (s Span) GetColumn() int {
+	return s.Column
+}
+
+// GetIssue implements output.Span interface
+func (s Span) GetIssue() string {
+	return s.Issue
 }
 
 // GetType implements output.Violation interface
@@ -92,6 +460,11 @@ func (v Violation) GetLine() int {
 	return v.Line
 }
 
+// GetColumn implements output.Violation interface
+func (v Violation) GetColumn() int {
+	return v.Column
+}
+
 // GetIssue implements output.Violation interface
 func (v Violation) GetIssue() string {
 	return v.Issue
@@ -106,3 +479,39 @@ func (v Violation) GetRule() string {
 func (v Violation) GetFix() string {
 	return v.Fix
 }
+
+// GetSeverity implements output.Violation interface
+func (v Violation) GetSeverity() string {
+	if v.Severity == "" {
+		return string(SeverityError)
+	}
+	return string(v.Severity)
+}
+
+// GetLink implements output.Violation interface
+func (v Violation) GetLink() string {
+	return v.Link
+}
+
+// GetSource implements output.Violation interface
+func (v Violation) GetSource() string {
+	if v.Source == "" || v.RuleKey == "" {
+		return v.Source
+	}
+	return v.Source + " (" + v.RuleKey + ")"
+}
+
+// GetRuleKey implements output.Violation interface
+func (v Violation) GetRuleKey() string {
+	return v.RuleKey
+}
+
+// GetCode implements output.Violation interface
+func (v Violation) GetCode() string {
+	return violationCodes[v.Type]
+}
+
+// IsError reports whether the violation should fail the build
+func (v Violation) IsError() bool {
+	return v.GetSeverity() == string(SeverityError)
+}
@@ -1,11 +1,31 @@
 package validator
 
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
 // Validator orchestrates all architectural validations
 type Validator struct {
 	cfg             Config
 	graph           Graph
 	projectPath     string
+	fsys            fs.FS // Project files, if not read from disk below projectPath
 	coverageResults []PackageCoverage
+	sourceFiles     []SourceFile
+	signatures      []ExportedSignature
+	interfaces      []InterfaceDecl
+	implementations []PortImplementation
+	parseErrors     []ParseError
+	licenses        []ModuleLicense
+	replaces        []ReplaceDirective
+	changes         ChangeSet
+	shuffleFailures []TestFailure
+	raceFailures    []TestFailure
+	rules           *ruleMatcher         // directories_import rules, compiled on first use
+	failFast        func(Violation) bool // Stop at the first violation it reports true for (nil: check everything)
 }
 
 // New creates a validator for dependency validation
@@ -30,49 +50,277 @@ func (v *Validator) SetCoverageResults(results []PackageCoverage) {
 	v.coverageResults = results
 }
 
-// Validate checks all rules and returns violations
-func (v *Validator) Validate() []Violation {
-	var violations []Violation
+// SetSourceFiles sets declaration-level file information for source analyses
+func (v *Validator) SetSourceFiles(files []SourceFile) {
+	v.sourceFiles = files
+}
 
-	// Check project structure if projectPath is set
-	if v.projectPath != "" {
-		violations = append(violations, v.validateStructure()...)
-	}
+// SetExportedSignatures sets type-checked exported signatures for type leak detection
+func (v *Validator) SetExportedSignatures(signatures []ExportedSignature) {
+	v.signatures = signatures
+}
 
-	// Check each file's dependencies (architecture rules)
-	for _, node := range v.graph.GetNodes() {
-		violations = append(violations, v.validateFile(node)...)
-	}
+// SetInterfaces sets type-checked interface declarations for orphan interface and
+// portless adapter detection
+func (v *Validator) SetInterfaces(interfaces []InterfaceDecl) {
+	v.interfaces = interfaces
+}
+
+// SetPortImplementations sets type-checked implementations of ports by adapter types for
+// adapter assertion checks
+func (v *Validator) SetPortImplementations(implementations []PortImplementation) {
+	v.implementations = implementations
+}
+
+// SetModuleLicenses sets the detected licenses of the required modules for license
+// policy checks
+func (v *Validator) SetModuleLicenses(licenses []ModuleLicense) {
+	v.licenses = licenses
+}
+
+// SetReplaceDirectives sets the replace directives of go.mod for replace hygiene checks
+func (v *Validator) SetReplaceDirectives(replaces []ReplaceDirective) {
+	v.replaces = replaces
+}
+
+// SetShuffleFailures sets the failures of the tests run in random order for shuffle
+// checks
+func (v *Validator) SetShuffleFailures(failures []TestFailure) {
+	v.shuffleFailures = failures
+}
+
+// SetRaceFailures sets the failures of the tests run with the race detector for data
+// race checks
+func (v *Validator) SetRaceFailures(failures []TestFailure) {
+	v.raceFailures = failures
+}
+
+// SetParseErrors sets files that were skipped during scanning because of syntax errors
+func (v *Validator) SetParseErrors(parseErrors []ParseError) {
+	v.parseErrors = parseErrors
+}
 
-	// Check for unused packages
-	if v.cfg.ShouldDetectUnused() {
-		violations = append(violations, v.detectUnusedPackages()...)
+// SetChangeSet sets the lines changed relative to a base revision for diff-based checks
+func (v *Validator) SetChangeSet(changes ChangeSet) {
+	v.changes = changes
+}
+
+// SetFS makes the structure and README checks read the project from fsys, rooted at
+// the project directory, instead of from disk below the project path
+func (v *Validator) SetFS(fsys fs.FS) {
+	v.fsys = fsys
+}
+
+// stat returns the file info of a path relative to the project directory
+func (v *Validator) stat(rel string) (fs.FileInfo, error) {
+	if v.fsys == nil {
+		return os.Stat(filepath.Join(v.projectPath, rel))
 	}
+	return fs.Stat(v.fsys, fsName(rel))
+}
 
-	// Check for shared external imports
-	if v.cfg.ShouldDetectSharedExternalImports() {
-		violations = append(violations, v.detectSharedExternalImports()...)
+// readDir returns the entries of a directory relative to the project directory
+func (v *Validator) readDir(rel string) ([]fs.DirEntry, error) {
+	if v.fsys == nil {
+		return os.ReadDir(filepath.Join(v.projectPath, rel))
 	}
+	return fs.ReadDir(v.fsys, fsName(rel))
+}
 
-	// Check test file locations
-	if v.cfg.ShouldLintTestFiles() && v.cfg.GetTestFileLocation() != "any" {
-		violations = append(violations, v.validateTestFileLocations()...)
+// readFile returns the content of a file relative to the project directory
+func (v *Validator) readFile(rel string) ([]byte, error) {
+	if v.fsys == nil {
+		return os.ReadFile(filepath.Join(v.projectPath, rel))
 	}
+	return fs.ReadFile(v.fsys, fsName(rel))
+}
 
-	// Check for whitebox tests (require blackbox tests)
-	if v.cfg.ShouldRequireBlackboxTests() {
-		violations = append(violations, v.validateBlackboxTests()...)
+// walkDir walks the file tree rooted at a directory relative to the project directory
+func (v *Validator) walkDir(rel string, fn fs.WalkDirFunc) error {
+	if v.fsys == nil {
+		return filepath.WalkDir(filepath.Join(v.projectPath, rel), fn)
 	}
+	return fs.WalkDir(v.fsys, fsName(rel), fn)
+}
+
+// fsName returns the name of a path relative to the project directory in a file system
+func fsName(rel string) string {
+	return path.Clean(filepath.ToSlash(rel))
+}
 
-	// Check test coverage
-	if v.cfg.IsCoverageEnabled() && len(v.coverageResults) > 0 {
-		violations = append(violations, v.validateCoverage()...)
+// SetFailFast makes Validate stop after the first violation for which fails reports
+// true, e.g. the first one that fails the build. The checks after it are skipped.
+func (v *Validator) SetFailFast(fails func(Violation) bool) {
+	v.failFast = fails
+}
+
+// check is one validation step of Validate, run only when enabled
+type check struct {
+	enabled bool
+	run     func() []Violation
+}
+
+// Validate checks all rules and returns violations. With SetFailFast, the violations
+// end with the first failing one.
+func (v *Validator) Validate() []Violation {
+	var violations []Violation
+	for _, c := range v.checks() {
+		if !c.enabled {
+			continue
+		}
+		found := c.run()
+		setRuleKeys(found)
+
+		if v.failFast != nil {
+			for i := range found {
+				if v.failFast(found[i]) {
+					return append(violations, found[:i+1]...)
+				}
+			}
+		}
+		violations = append(violations, found...)
 	}
+	return violations
+}
+
+// checks lists the validation steps in the order their violations are reported
+func (v *Validator) checks() []check {
+	return []check{
+		// Report files that could not be parsed (their rules were not checked)
+		{enabled: true, run: v.reportParseErrors},
 
-	// Check strict test naming convention
-	if v.cfg.ShouldEnforceStrictTestNaming() {
-		violations = append(violations, v.validateTestNaming()...)
+		// Check project structure if projectPath is set
+		{enabled: v.projectPath != "", run: v.validateStructure},
+
+		// Check that the listed layers document their purpose
+		{enabled: v.projectPath != "" && len(v.cfg.GetReadmeDirectories()) > 0, run: v.validateLayerReadmes},
+
+		// Check that the layering rules themselves are acyclic
+		{enabled: true, run: v.detectLayerCycles},
+
+		// Check each file's dependencies (architecture rules)
+		{enabled: true, run: v.validateFiles},
+
+		// Check for unused packages
+		{enabled: v.cfg.ShouldDetectUnused(), run: v.detectUnusedPackages},
+
+		// Check for shared external imports
+		{enabled: v.cfg.ShouldDetectSharedExternalImports(), run: v.detectSharedExternalImports},
+
+		// Check test file locations
+		{enabled: v.cfg.ShouldLintTestFiles() && v.cfg.GetTestFileLocation() != "any", run: v.validateTestFileLocations},
+
+		// Check for whitebox tests (require blackbox tests)
+		{enabled: v.cfg.ShouldRequireBlackboxTests(), run: v.validateBlackboxTests},
+
+		// Check that blessed test support packages stay out of production code
+		{enabled: len(v.cfg.GetTestSupportPackages()) > 0, run: v.validateTestSupportPackages},
+
+		// Check test coverage
+		{enabled: v.cfg.IsCoverageEnabled() && len(v.coverageResults) > 0, run: v.validateCoverage},
+
+		// Check that the tests of critical packages pass in random order
+		{enabled: len(v.cfg.GetShuffleCleanDirectories()) > 0 && len(v.shuffleFailures) > 0, run: v.validateShuffledTests},
+
+		// Check that the tests of critical packages pass the race detector
+		{enabled: len(v.cfg.GetRaceCleanDirectories()) > 0 && len(v.raceFailures) > 0, run: v.validateRaceClean},
+
+		// Check strict test naming convention
+		{enabled: v.cfg.ShouldEnforceStrictTestNaming(), run: v.validateTestNaming},
+
+		// Check for definitions duplicated across layers
+		{enabled: v.cfg.ShouldDetectDuplicates() && len(v.sourceFiles) > 0, run: v.detectDuplicateDefinitions},
+
+		// Check that wrapped external modules stay behind their wrapper packages
+		{enabled: len(v.cfg.GetWrapIn()) > 0, run: v.validateWrappedImports},
+		{enabled: len(v.cfg.GetWrapIn()) > 0, run: v.detectWrapperBypasses},
+
+		// Check exported signatures for leaked forbidden types
+		{enabled: len(v.cfg.GetTypeLeakForbidden()) > 0 && len(v.signatures) > 0, run: v.detectTypeLeaks},
+
+		// Check that layers only import modules under licenses their policy allows
+		{enabled: len(v.cfg.GetLicensePolicyLayers()) > 0 && len(v.licenses) > 0, run: v.validateLicensePolicy},
+
+		// Check that layers import external modules no deeper than their limits allow
+		{enabled: len(v.cfg.GetExternalImportDepthLayers()) > 0, run: v.validateExternalImportDepth},
+
+		// Check for external modules imported in several major versions
+		{enabled: v.cfg.ShouldDetectVersionSprawl(), run: v.detectVersionSprawl},
+
+		// Check go.mod for replaces with local directories or forks
+		{enabled: v.cfg.ShouldCheckReplaceDirectives() && len(v.replaces) > 0, run: v.validateReplaceDirectives},
+
+		// Check that configuration is only loaded by entry points and config packages
+		{enabled: v.cfg.ShouldConfineConfigLoading(), run: v.validateConfigLoading},
+
+		// Check for web framework types outside adapters
+		{enabled: v.cfg.ShouldDetectFrameworkLockIn() && len(v.sourceFiles) > 0, run: v.detectFrameworkLockIn},
+
+		// Check one package per directory, named after the directory
+		{enabled: v.cfg.ShouldCheckPackageNaming(), run: v.validatePackageNaming},
+
+		// Check that stable packages do not depend on experimental ones
+		{enabled: v.cfg.ShouldEnforceStability() && len(v.sourceFiles) > 0, run: v.validateStabilityImports},
+
+		// Check for new usages of deprecated symbols from other packages
+		{enabled: v.cfg.ShouldDetectDeprecatedUsages() && v.changes != nil && len(v.sourceFiles) > 0, run: v.detectDeprecatedUsages},
+
+		// Check that cross-team imports go through public contract packages
+		{enabled: len(v.cfg.GetOwnershipTeams()) > 0, run: v.validateOwnershipBoundaries},
+
+		// Check that packages in documented layers have a package doc comment
+		{enabled: len(v.cfg.GetPackageDocLayers()) > 0 && len(v.sourceFiles) > 0, run: v.validatePackageDocs},
+
+		// Check that test fixtures live under testdata/ and production code does not read them
+		{enabled: v.cfg.ShouldRequireTestdataFixtures() && len(v.sourceFiles) > 0, run: v.validateFixtureLocations},
+
+		// Check that tests do not assemble other packages' values from their internals
+		{enabled: v.cfg.ShouldDetectTestSetupImports() && len(v.sourceFiles) > 0, run: v.validateTestSetupImports},
+
+		// Check that each test file stays within the declarations of its implementation file
+		{enabled: v.cfg.ShouldEnforceStrictTestNaming() && v.cfg.ShouldEnforceTestScope() && len(v.sourceFiles) > 0, run: v.validateTestScope},
+
+		// Check that main packages live in approved locations
+		{enabled: len(v.cfg.GetMainPackageLocations()) > 0, run: v.validateMainPackageLocations},
+
+		// Check for interfaces in consumer layers that nothing implements
+		{enabled: len(v.cfg.GetOrphanInterfaceLayers()) > 0 && len(v.interfaces) > 0, run: v.detectOrphanInterfaces},
+
+		// Check for adapter packages that implement none of the ports
+		{enabled: len(v.cfg.GetAdapterLayers()) > 0 && len(v.cfg.GetAdapterPortLayers()) > 0 && v.interfaces != nil, run: v.detectPortlessAdapters},
+
+		// Check that adapter types assert the ports they implement at compile time
+		{enabled: v.cfg.ShouldRequireAdapterAssertions() && len(v.implementations) > 0, run: v.detectUnassertedAdapters},
+
+		// Check for registry and reflect lookups that bypass the import rules
+		{enabled: v.cfg.ShouldDetectHiddenDependencies() && len(v.sourceFiles) > 0, run: v.detectHiddenDependencies},
 	}
+}
 
+// validateFiles checks the dependencies of every file. With fail-fast, the files after
+// the first one with a failing violation are skipped.
+func (v *Validator) validateFiles() []Violation {
+	var violations []Violation
+	for _, node := range v.graph.GetNodes() {
+		found := v.validateFile(node)
+		setRuleKeys(found)
+		violations = append(violations, found...)
+		if v.failFast != nil {
+			for i := range found {
+				if v.failFast(found[i]) {
+					return violations
+				}
+			}
+		}
+	}
 	return violations
 }
+
+// setRuleKeys records the configuration key of the rule behind each violation
+func setRuleKeys(violations []Violation) {
+	for i := range violations {
+		if violations[i].RuleKey == "" {
+			violations[i].RuleKey = ruleKeys[violations[i].Type]
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package validator_test
 
 import (
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/kgatilin/go-arch-lint/internal/validator"
 )
@@ -12,34 +16,86 @@ import (
 type testConfig struct {
 	module                                string
 	directoriesImport                     map[string][]string
+	defaultPolicy                         string
 	detectUnused                          bool
 	requiredDirectories                   map[string]string
 	allowOtherDirectories                 bool
+	readmeDirectories                     []string
 	detectSharedExternalImports           bool
 	sharedExternalImportsMode             string
 	sharedExternalImportsExclusions       []string
 	sharedExternalImportsExclusionPatterns []string
 	lintTestFiles                         bool
 	testExemptImports                     []string
+	testExemptImportsByDir                map[string][]string
 	testFileLocation                      string
 	requireBlackboxTests                  bool
+	whiteboxExemptions                    map[string]string
+	testSupportPackages                   []string
+	requireTestdataFixtures               bool
+	detectTestSetupImports                bool
+	testSetupHelpers                      []string
+	strictTestNaming                      bool
+	enforceTestScope                      bool
+	testScopeShared                       []string
 	coverageEnabled                       bool
 	coverageThreshold                     float64
 	packageThresholds                     map[string]float64
+	detectDuplicates                      bool
+	wrapIn                                map[string]string
+	typeLeakLayers                        []string
+	typeLeakForbidden                     []string
+	confineConfigLoading                  bool
+	configLoadingAllowed                  []string
+	configLoadingPackages                 []string
+	detectFrameworkLockIn                 bool
+	frameworkLockInAllowed                []string
+	frameworkLockInTypes                  []string
+	checkPackageNaming                    bool
+	packageNamingExceptions               []string
+	enforceStability                      bool
+	detectDeprecatedUsages                bool
+	ownershipTeams                        map[string]string
+	ownershipContracts                    []string
+	packageDocLayers                      []string
+	mainPackageLocations                  []string
+	orphanInterfaceLayers                 []string
+	adapterLayers                         []string
+	adapterPortLayers                     []string
+	requireAdapterAssertions              bool
+	detectHiddenDependencies              bool
+	licensePolicyLayers                   map[string][]string
+	licensePolicyExceptions               []string
+	externalImportDepthLayers             map[string]map[string]int
+	detectVersionSprawl                   bool
+	checkReplaceDirectives                bool
+	replaceDirectivesAllowed              []string
+	shuffleCleanDirectories               []string
+	raceCleanDirectories                  []string
 }
 
 func (tc *testConfig) GetDirectoriesImport() map[string][]string                 { return tc.directoriesImport }
+func (tc *testConfig) GetDefaultPolicy() string                                  { return tc.defaultPolicy }
 func (tc *testConfig) ShouldDetectUnused() bool                                  { return tc.detectUnused }
 func (tc *testConfig) GetRequiredDirectories() map[string]string                 { return tc.requiredDirectories }
 func (tc *testConfig) ShouldAllowOtherDirectories() bool                         { return tc.allowOtherDirectories }
+func (tc *testConfig) GetReadmeDirectories() []string                            { return tc.readmeDirectories }
 func (tc *testConfig) ShouldDetectSharedExternalImports() bool                   { return tc.detectSharedExternalImports }
 func (tc *testConfig) GetSharedExternalImportsMode() string                      { return tc.sharedExternalImportsMode }
 func (tc *testConfig) GetSharedExternalImportsExclusions() []string              { return tc.sharedExternalImportsExclusions }
 func (tc *testConfig) GetSharedExternalImportsExclusionPatterns() []string       { return tc.sharedExternalImportsExclusionPatterns }
 func (tc *testConfig) ShouldLintTestFiles() bool                                 { return tc.lintTestFiles }
 func (tc *testConfig) GetTestExemptImports() []string                            { return tc.testExemptImports }
+func (tc *testConfig) GetTestExemptImportsByDir() map[string][]string            { return tc.testExemptImportsByDir }
 func (tc *testConfig) GetTestFileLocation() string                               { return tc.testFileLocation }
 func (tc *testConfig) ShouldRequireBlackboxTests() bool                          { return tc.requireBlackboxTests }
+func (tc *testConfig) GetWhiteboxExemptions() map[string]string                  { return tc.whiteboxExemptions }
+func (tc *testConfig) GetTestSupportPackages() []string                          { return tc.testSupportPackages }
+func (tc *testConfig) ShouldRequireTestdataFixtures() bool                       { return tc.requireTestdataFixtures }
+func (tc *testConfig) ShouldDetectTestSetupImports() bool                        { return tc.detectTestSetupImports }
+func (tc *testConfig) GetTestSetupHelpers() []string                             { return tc.testSetupHelpers }
+func (tc *testConfig) ShouldEnforceTestScope() bool                              { return tc.enforceTestScope }
+func (tc *testConfig) GetTestScopeShared() []string                              { return tc.testScopeShared }
 func (tc *testConfig) IsCoverageEnabled() bool                                   { return tc.coverageEnabled }
 func (tc *testConfig) GetCoverageThreshold() float64                             { return tc.coverageThreshold }
 func (tc *testConfig) GetPackageThresholds() map[string]float64 {
@@ -49,27 +105,72 @@ func (tc *testConfig) GetPackageThresholds() map[string]float64 {
 	return tc.packageThresholds
 }
 func (tc *testConfig) GetModule() string                 { return tc.module }
-func (tc *testConfig) ShouldEnforceStrictTestNaming() bool { return false }
+func (tc *testConfig) ShouldEnforceStrictTestNaming() bool  { return tc.strictTestNaming }
+func (tc *testConfig) ShouldDetectDuplicates() bool         { return tc.detectDuplicates }
+func (tc *testConfig) GetWrapIn() map[string]string         { return tc.wrapIn }
+func (tc *testConfig) GetTypeLeakLayers() []string          { return tc.typeLeakLayers }
+func (tc *testConfig) GetTypeLeakForbidden() []string       { return tc.typeLeakForbidden }
+func (tc *testConfig) ShouldConfineConfigLoading() bool     { return tc.confineConfigLoading }
+func (tc *testConfig) GetConfigLoadingAllowed() []string    { return tc.configLoadingAllowed }
+func (tc *testConfig) GetConfigLoadingPackages() []string   { return tc.configLoadingPackages }
+func (tc *testConfig) ShouldDetectFrameworkLockIn() bool    { return tc.detectFrameworkLockIn }
+func (tc *testConfig) GetFrameworkLockInAllowed() []string  { return tc.frameworkLockInAllowed }
+func (tc *testConfig) GetFrameworkLockInTypes() []string    { return tc.frameworkLockInTypes }
+func (tc *testConfig) ShouldCheckPackageNaming() bool       { return tc.checkPackageNaming }
+func (tc *testConfig) GetPackageNamingExceptions() []string { return tc.packageNamingExceptions }
+func (tc *testConfig) ShouldEnforceStability() bool         { return tc.enforceStability }
+func (tc *testConfig) ShouldDetectDeprecatedUsages() bool   { return tc.detectDeprecatedUsages }
+func (tc *testConfig) GetOwnershipTeams() map[string]string { return tc.ownershipTeams }
+func (tc *testConfig) GetOwnershipContracts() []string      { return tc.ownershipContracts }
+func (tc *testConfig) GetPackageDocLayers() []string        { return tc.packageDocLayers }
+func (tc *testConfig) GetMainPackageLocations() []string    { return tc.mainPackageLocations }
+func (tc *testConfig) GetOrphanInterfaceLayers() []string   { return tc.orphanInterfaceLayers }
+func (tc *testConfig) GetAdapterLayers() []string           { return tc.adapterLayers }
+func (tc *testConfig) GetAdapterPortLayers() []string       { return tc.adapterPortLayers }
+func (tc *testConfig) ShouldRequireAdapterAssertions() bool { return tc.requireAdapterAssertions }
+func (tc *testConfig) ShouldDetectHiddenDependencies() bool { return tc.detectHiddenDependencies }
+func (tc *testConfig) GetLicensePolicyLayers() map[string][]string {
+	return tc.licensePolicyLayers
+}
+func (tc *testConfig) GetLicensePolicyExceptions() []string { return tc.licensePolicyExceptions }
+func (tc *testConfig) GetExternalImportDepthLayers() map[string]map[string]int {
+	return tc.externalImportDepthLayers
+}
+func (tc *testConfig) ShouldDetectVersionSprawl() bool       { return tc.detectVersionSprawl }
+func (tc *testConfig) ShouldCheckReplaceDirectives() bool    { return tc.checkReplaceDirectives }
+func (tc *testConfig) GetReplaceDirectivesAllowed() []string { return tc.replaceDirectivesAllowed }
+func (tc *testConfig) GetShuffleCleanDirectories() []string  { return tc.shuffleCleanDirectories }
+func (tc *testConfig) GetRaceCleanDirectories() []string     { return tc.raceCleanDirectories }
 
 type testDependency struct {
 	importPath string
 	localPath  string
 	isLocal    bool
+	line       int
+	column     int
 }
 
 func (td *testDependency) GetImportPath() string { return td.importPath }
 func (td *testDependency) GetLocalPath() string  { return td.localPath }
 func (td *testDependency) IsLocalDep() bool      { return td.isLocal }
+func (td *testDependency) GetLine() int          { return td.line }
+func (td *testDependency) GetColumn() int        { return td.column }
 
 type testFileNode struct {
-	relPath      string
-	pkg          string
-	dependencies []validator.Dependency
+	relPath       string
+	pkg           string
+	dependencies  []validator.Dependency
+	packageLine   int
+	packageColumn int
+	diFramework   string
 }
 
 func (tfn *testFileNode) GetRelPath() string                      { return tfn.relPath }
 func (tfn *testFileNode) GetPackage() string                      { return tfn.pkg }
 func (tfn *testFileNode) GetDependencies() []validator.Dependency { return tfn.dependencies }
+func (tfn *testFileNode) GetPackageLine() int                     { return tfn.packageLine }
+func (tfn *testFileNode) GetPackageColumn() int                   { return tfn.packageColumn }
+func (tfn *testFileNode) GetDIFramework() string                  { return tfn.diFramework }
 
 type testGraph struct {
 	nodes []validator.FileNode
@@ -262,6 +363,52 @@ func TestValidate_DirectSubpackageAllowed(t *testing.T) {
 	}
 }
 
+func TestValidate_DIWiringMayImportNestedPackages(t *testing.T) {
+	nested := func() []validator.Dependency {
+		return []validator.Dependency{
+			&testDependency{importPath: "github.com/test/project/pkg/orders/infra/db", localPath: "pkg/orders/infra/db", isLocal: true},
+			&testDependency{importPath: "github.com/test/project/pkg/billing", localPath: "pkg/billing", isLocal: true},
+		}
+	}
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{relPath: "pkg/orders/wire_gen.go", pkg: "orders", diFramework: "wire", dependencies: nested()},
+			&testFileNode{relPath: "pkg/orders/module.go", pkg: "orders", diFramework: "fx", dependencies: nested()},
+			&testFileNode{relPath: "pkg/orders/service.go", pkg: "orders", dependencies: nested()[:1]},
+		},
+	}
+
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"pkg": {"internal"},
+		},
+	}
+
+	counts := make(map[string]int)
+	for _, viol := range validator.New(cfg, g).Validate() {
+		if viol.Type == validator.ViolationPkgToPkg || viol.Type == validator.ViolationSkipLevel {
+			counts[viol.File+" "+string(viol.Type)]++
+		}
+	}
+
+	// Plain code still may not skip levels, and DI wiring may not reach other pkg packages
+	expected := map[string]int{
+		"pkg/orders/service.go " + string(validator.ViolationPkgToPkg):  1,
+		"pkg/orders/service.go " + string(validator.ViolationSkipLevel): 1,
+		"pkg/orders/wire_gen.go " + string(validator.ViolationPkgToPkg): 1,
+		"pkg/orders/module.go " + string(validator.ViolationPkgToPkg):   1,
+	}
+	if len(counts) != len(expected) {
+		t.Errorf("expected violations %v, got %v", expected, counts)
+	}
+	for key, count := range expected {
+		if counts[key] != count {
+			t.Errorf("expected %d %s, got %d", count, key, counts[key])
+		}
+	}
+}
+
 func TestValidate_UnusedPackage(t *testing.T) {
 	g := &testGraph{
 		nodes: []validator.FileNode{
@@ -303,6 +450,9 @@ func TestValidate_UnusedPackage(t *testing.T) {
 			if viol.Issue == "" {
 				t.Error("expected issue description")
 			}
+			if viol.RuleKey != "rules.detect_unused" {
+				t.Errorf("expected rule key rules.detect_unused, got %q", viol.RuleKey)
+			}
 			break
 		}
 	}
@@ -320,7 +470,7 @@ func TestValidate_InternalToInternalViolation(t *testing.T) {
 				relPath: "internal/output/markdown.go",
 				pkg:     "output",
 				dependencies: []validator.Dependency{
-					&testDependency{importPath: "github.com/test/project/internal/graph", localPath: "internal/graph", isLocal: true},
+					&testDependency{importPath: "github.com/test/project/internal/graph", localPath: "internal/graph", isLocal: true, line: 7},
 				},
 			},
 			&testFileNode{
@@ -358,6 +508,9 @@ func TestValidate_InternalToInternalViolation(t *testing.T) {
 			if viol.Fix != "Use interfaces and dependency inversion instead of direct imports" {
 				t.Errorf("expected specific fix message for internal-to-internal, got %q", viol.Fix)
 			}
+			if viol.Line != 7 {
+				t.Errorf("expected line of the import (7), got %d", viol.Line)
+			}
 			break
 		}
 	}
@@ -496,6 +649,139 @@ func TestValidate_SubdirectorySpecificRule(t *testing.T) {
 	}
 }
 
+func TestValidate_RuleCoversSubdirectories(t *testing.T) {
+	// A key governs the directories below it; the most specific key wins over the top level
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{
+				relPath: "internal/app/orders/service.go",
+				pkg:     "orders",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/test/project/internal/domain", localPath: "internal/domain", isLocal: true},
+				},
+			},
+			&testFileNode{
+				relPath: "internal/domain/order/order.go",
+				pkg:     "order",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/test/project/internal/app/orders", localPath: "internal/app/orders", isLocal: true, line: 3, column: 2},
+				},
+			},
+		},
+	}
+
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal":        {"internal"},
+			"internal/app":    {"internal/domain"},
+			"internal/domain": {},
+		},
+	}
+
+	violations := validator.New(cfg, g).Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+	if violations[0].File != "internal/domain/order/order.go" || violations[0].RuleKey != "rules.directories_import.internal/domain" {
+		t.Errorf("expected internal/domain/order to be governed by internal/domain, got %s (%s)", violations[0].File, violations[0].RuleKey)
+	}
+}
+
+func TestValidate_WildcardRuleKeys(t *testing.T) {
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{
+				relPath: "internal/modules/billing/service/service.go",
+				pkg:     "service",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/test/project/internal/modules/billing/api", localPath: "internal/modules/billing/api", isLocal: true},
+					&testDependency{importPath: "github.com/test/project/internal/shared/money", localPath: "internal/shared/money", isLocal: true},
+					&testDependency{importPath: "github.com/test/project/internal/modules/users/store", localPath: "internal/modules/users/store", isLocal: true, line: 6, column: 2},
+				},
+			},
+			&testFileNode{
+				relPath: "internal/modules/users/api/api.go",
+				pkg:     "api",
+				dependencies: []validator.Dependency{
+					// The deeper pattern governs the api packages
+					&testDependency{importPath: "github.com/test/project/internal/shared/money", localPath: "internal/shared/money", isLocal: true},
+				},
+			},
+		},
+	}
+
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal/shared":        {},
+			"internal/modules/*":     {"internal/modules/$1/api", "internal/shared"},
+			"internal/modules/*/api": {"internal/shared"},
+		},
+	}
+
+	violations := validator.New(cfg, g).Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Issue != "internal/modules/billing/service imports internal/modules/users/store" {
+		t.Errorf("unexpected issue: %s", viol.Issue)
+	}
+	if viol.Rule != "internal/modules/* can only import from: [internal/modules/billing/api internal/shared]" {
+		t.Errorf("expected the captured module in the rule, got: %s", viol.Rule)
+	}
+	if viol.RuleKey != "rules.directories_import.internal/modules/*" {
+		t.Errorf("unexpected rule key: %s", viol.RuleKey)
+	}
+}
+
+func TestValidate_DefaultPolicy(t *testing.T) {
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{
+				relPath: "internal/app/service.go",
+				pkg:     "app",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/test/project/internal/domain", localPath: "internal/domain", isLocal: true},
+				},
+			},
+			&testFileNode{
+				relPath: "tools/gen/gen.go",
+				pkg:     "gen",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "fmt", localPath: "", isLocal: false},
+					&testDependency{importPath: "github.com/test/project/internal/domain", localPath: "internal/domain", isLocal: true, line: 5, column: 2},
+				},
+			},
+		},
+	}
+	directoriesImport := map[string][]string{
+		"internal/app":    {"internal/domain"},
+		"internal/domain": {},
+	}
+
+	// Allow (the default): directories without a rule may import anything
+	if violations := validator.New(&testConfig{directoriesImport: directoriesImport}, g).Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations with the allow policy, got %v", violations)
+	}
+
+	// Deny: directories without a rule may import nothing local
+	cfg := &testConfig{directoriesImport: directoriesImport, defaultPolicy: "deny"}
+	violations := validator.New(cfg, g).Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation with the deny policy, got %d: %v", len(violations), violations)
+	}
+	viol := violations[0]
+	if viol.Type != validator.ViolationForbidden || viol.File != "tools/gen/gen.go" || viol.Line != 5 {
+		t.Errorf("expected forbidden import at tools/gen/gen.go:5, got %s at %s:%d", viol.Type, viol.File, viol.Line)
+	}
+	if viol.RuleKey != "rules.default_policy" || viol.Rule != "tools/gen has no directories_import rule and default_policy is deny" {
+		t.Errorf("unexpected rule: %s (%s)", viol.Rule, viol.RuleKey)
+	}
+}
+
 func TestValidate_PrefixMatchingForAllowedImports(t *testing.T) {
 	// Test that if "internal/app" is allowed, then "internal/app/user" is also allowed
 	g := &testGraph{
@@ -586,6 +872,64 @@ func TestValidate_ForbiddenImportNotInAllowedList(t *testing.T) {
 	}
 }
 
+func TestValidate_MultipleForbiddenImportsCollapsed(t *testing.T) {
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{
+				relPath: "internal/order/service.go",
+				pkg:     "order",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/test/project/internal/store", localPath: "internal/store", isLocal: true, line: 4, column: 2},
+					&testDependency{importPath: "github.com/test/project/internal/billing", localPath: "internal/billing", isLocal: true, line: 5, column: 2},
+				},
+			},
+		},
+	}
+
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal": {},
+		},
+	}
+
+	v := validator.New(cfg, g)
+	violations := v.Validate()
+
+	var forbidden []validator.Violation
+	for _, viol := range violations {
+		if viol.Type == validator.ViolationForbidden {
+			forbidden = append(forbidden, viol)
+		}
+	}
+
+	if len(forbidden) != 1 {
+		t.Fatalf("expected 1 collapsed violation, got %d: %v", len(forbidden), forbidden)
+	}
+	viol := forbidden[0]
+	if viol.Line != 4 || viol.Column != 2 {
+		t.Errorf("expected violation at first import 4:2, got %d:%d", viol.Line, viol.Column)
+	}
+	if viol.Issue != "internal/order imports internal/store, internal/billing" {
+		t.Errorf("unexpected issue %q", viol.Issue)
+	}
+	if viol.RuleKey != "rules.directories_import.internal" {
+		t.Errorf("expected rule key of the matched directory rule, got %q", viol.RuleKey)
+	}
+	expected := []validator.Span{
+		{Line: 4, Column: 2, Issue: "internal/order imports internal/store"},
+		{Line: 5, Column: 2, Issue: "internal/order imports internal/billing"},
+	}
+	if len(viol.Spans) != len(expected) {
+		t.Fatalf("expected %d spans, got %v", len(expected), viol.Spans)
+	}
+	for i, want := range expected {
+		if got := viol.Spans[i]; got.Line != want.Line || got.Column != want.Column || got.Issue != want.Issue {
+			t.Errorf("span %d: expected %+v, got %+v", i, want, viol.Spans[i])
+		}
+	}
+}
+
 func TestDetectSharedExternalImports_MultipleLayersImportSamePackage(t *testing.T) {
 	// Create graph with cmd and internal both importing github.com/pkg/errors (external non-stdlib)
 	g := &testGraph{
@@ -974,6 +1318,79 @@ func TestBlackBoxTest_InPkgLayer(t *testing.T) {
 // Blackbox tests only test exported API
 // Tests for internal helper functions (isBlackBoxTest, isParentPackageImport) have been removed
 
+// TestExemptImports_PerDirectory tests that the most specific exempt_imports pattern applies to test files
+func TestExemptImports_PerDirectory(t *testing.T) {
+	dep := func(localPath string) validator.Dependency {
+		return &testDependency{importPath: "github.com/test/project/" + localPath, localPath: localPath, isLocal: true}
+	}
+
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			// tests/ may import anything
+			&testFileNode{relPath: "tests/e2e/checkout_test.go", pkg: "e2e", dependencies: []validator.Dependency{dep("internal/infra/db")}},
+			// internal/domain falls back to nothing beyond its own list
+			&testFileNode{relPath: "internal/domain/order_test.go", pkg: "domain_test", dependencies: []validator.Dependency{dep("internal/testkit")}},
+			// internal/app uses the global list
+			&testFileNode{relPath: "internal/app/service_test.go", pkg: "app_test", dependencies: []validator.Dependency{dep("internal/testkit")}},
+			// Production files are never exempt
+			&testFileNode{relPath: "tests/e2e/client.go", pkg: "e2e", dependencies: []validator.Dependency{dep("internal/infra/db")}},
+		},
+	}
+
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"tests":    {},
+			"internal": {},
+		},
+		testExemptImports: []string{"internal/testkit"},
+		testExemptImportsByDir: map[string][]string{
+			"tests":           {"*"},
+			"internal/domain": {"testing", "github.com/stretchr/testify"},
+		},
+	}
+
+	var forbidden []string
+	for _, viol := range validator.New(cfg, g).Validate() {
+		if viol.Type == validator.ViolationForbidden {
+			forbidden = append(forbidden, viol.File)
+		}
+	}
+
+	expected := []string{"internal/domain/order_test.go", "tests/e2e/client.go"}
+	if strings.Join(forbidden, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected forbidden imports in %v, got %v", expected, forbidden)
+	}
+}
+
+// TestDetectSharedExternalImports_ExemptTestImports tests that exempt test frameworks do not make a package shared
+func TestDetectSharedExternalImports_ExemptTestImports(t *testing.T) {
+	testify := &testDependency{importPath: "github.com/stretchr/testify/assert"}
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{relPath: "internal/app/app_test.go", dependencies: []validator.Dependency{testify}},
+			&testFileNode{relPath: "internal/infra/db_test.go", dependencies: []validator.Dependency{testify}},
+		},
+	}
+
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"internal/app":   {},
+			"internal/infra": {},
+		},
+		detectSharedExternalImports: true,
+		sharedExternalImportsMode:   "error",
+		testExemptImports:           []string{"github.com/stretchr/testify"},
+	}
+
+	for _, viol := range validator.New(cfg, g).Validate() {
+		if viol.Type == validator.ViolationSharedExternalImport {
+			t.Errorf("expected exempt test import not to be reported as shared, got: %v", viol)
+		}
+	}
+}
+
 // TestValidateTestFileLocations_Colocated tests that colocated policy requires tests next to code
 func TestValidateTestFileLocations_Colocated(t *testing.T) {
 	g := &testGraph{
@@ -1250,6 +1667,42 @@ func TestValidateBlackboxTests_BlackboxAllowed(t *testing.T) {
 	}
 }
 
+// TestValidateBlackboxTests_AllowWhitebox tests that exempted paths may use whitebox tests
+func TestValidateBlackboxTests_AllowWhitebox(t *testing.T) {
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{relPath: "internal/algo/sort_test.go", pkg: "algo"},
+			&testFileNode{relPath: "internal/algo/heap/heap_test.go", pkg: "heap"},
+			&testFileNode{relPath: "internal/store/cache/lru_test.go", pkg: "cache"},
+			&testFileNode{relPath: "internal/parser/lexer_internal_test.go", pkg: "parser"},
+			&testFileNode{relPath: "internal/parser/parser_test.go", pkg: "parser"},
+		},
+	}
+
+	cfg := &testConfig{
+		module:               "github.com/test/project",
+		requireBlackboxTests: true,
+		whiteboxExemptions: map[string]string{
+			"internal/algo":                  "tight algorithmic internals",
+			"**/cache":                       "eviction order is not observable",
+			"internal/**/*_internal_test.go": "lexer state machine",
+		},
+	}
+
+	v := validator.New(cfg, g)
+	violations := v.Validate()
+
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %+v", len(violations), violations)
+	}
+	if violations[0].File != "internal/parser/parser_test.go" {
+		t.Errorf("expected only the non-exempt test to be reported, got: %+v", violations[0])
+	}
+	if !strings.Contains(violations[0].Fix, "test_files.allow_whitebox") {
+		t.Errorf("expected fix to mention allow_whitebox, got: %s", violations[0].Fix)
+	}
+}
+
 // TestValidateBlackboxTests_DisabledByDefault tests that the rule is disabled when not configured
 func TestValidateBlackboxTests_DisabledByDefault(t *testing.T) {
 	g := &testGraph{
@@ -1317,6 +1770,30 @@ func TestViolation_InterfaceMethods(t *testing.T) {
 	if viol.GetFix() != "Move shared logic to internal/" {
 		t.Errorf("GetFix() = %s, want 'Move shared logic to internal/'", viol.GetFix())
 	}
+
+	if viol.GetCode() != "ARCH001" {
+		t.Errorf("GetCode() = %s, want ARCH001", viol.GetCode())
+	}
+}
+
+// TestViolation_GetCode tests that codes are fixed per violation type
+func TestViolation_GetCode(t *testing.T) {
+	tests := []struct {
+		violationType validator.ViolationType
+		want          string
+	}{
+		{validator.ViolationForbidden, "ARCH005"},
+		{validator.ViolationMissingDirectory, "ARCH006"},
+		{validator.ViolationMissingPackageDoc, "ARCH027"},
+		{validator.ViolationType("Unknown"), ""},
+	}
+
+	for _, tt := range tests {
+		viol := validator.Violation{Type: tt.violationType}
+		if code := viol.GetCode(); code != tt.want {
+			t.Errorf("GetCode() for %q = %q, want %q", tt.violationType, code, tt.want)
+		}
+	}
 }
 
 // TestNewWithPath tests NewWithPath constructor
@@ -1342,6 +1819,135 @@ func TestNewWithPath(t *testing.T) {
 	_ = v.Validate()
 }
 
+func TestValidateStructure_UnexpectedDirectories(t *testing.T) {
+	projectPath := t.TempDir()
+	files := map[string]string{
+		"cmd/app/main.go":     "package main\n",
+		"tools/gen/gen.go":    "package gen\n",
+		"docs/README.md":      "# Docs\n",
+		"e2e/flow_test.go":    "package e2e_test\n",
+		".github/tools/x.go":  "package x\n",
+		"internal/app/app.go": "package app\n",
+	}
+	for relPath, content := range files {
+		fullPath := filepath.Join(projectPath, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := &testGraph{nodes: []validator.FileNode{
+		&testFileNode{relPath: "cmd/app/main.go", pkg: "main"},
+		&testFileNode{relPath: "internal/app/app.go", pkg: "app"},
+	}}
+	cfg := &testConfig{
+		requiredDirectories: map[string]string{"cmd": "Entry points", "internal/app": "Use cases"},
+	}
+
+	var unexpected []validator.Violation
+	for _, viol := range validator.NewWithPath(cfg, g, projectPath).Validate() {
+		if viol.Type == validator.ViolationUnexpectedDirectory {
+			unexpected = append(unexpected, viol)
+		}
+	}
+
+	// Only tools has non-test Go code outside the required structure
+	if len(unexpected) != 1 || unexpected[0].File != "tools" {
+		t.Fatalf("expected only tools to be reported, got %v", unexpected)
+	}
+	if !strings.Contains(unexpected[0].Fix, `add 'tools: "<purpose>"' to structure.required_directories`) {
+		t.Errorf("expected the fix to suggest a required_directories entry, got: %s", unexpected[0].Fix)
+	}
+
+	// Other directories are accepted when allow_other_directories is true
+	cfg.allowOtherDirectories = true
+	for _, viol := range validator.NewWithPath(cfg, g, projectPath).Validate() {
+		if viol.Type == validator.ViolationUnexpectedDirectory {
+			t.Errorf("unexpected violation with allow_other_directories: %v", viol)
+		}
+	}
+}
+
+func TestValidateStructure_EmptyRequiredDirectories(t *testing.T) {
+	projectPath := t.TempDir()
+	for _, dir := range []string{"internal/domain", "internal/app", "internal/infra"} {
+		if err := os.MkdirAll(filepath.Join(projectPath, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		"internal/app/app.go":       "package app\n",
+		"internal/infra/db_test.go": "package infra_test\n",
+	}
+	for relPath, content := range files {
+		if err := os.WriteFile(filepath.Join(projectPath, relPath), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := &testGraph{nodes: []validator.FileNode{
+		&testFileNode{relPath: "internal/app/app.go", pkg: "app"},
+	}}
+	cfg := &testConfig{
+		requiredDirectories: map[string]string{
+			"internal/domain": "Domain model",
+			"internal/app":    "Use cases",
+			"internal/infra":  "Adapters",
+		},
+		allowOtherDirectories: true,
+	}
+
+	violations := validator.NewWithPath(cfg, g, projectPath).Validate()
+
+	// domain is empty and infra only has tests: both are warned about once, nothing fails
+	var empty []string
+	for _, viol := range violations {
+		if viol.Type != validator.ViolationEmptyDirectory {
+			t.Errorf("unexpected violation: %s %s", viol.Type, viol.File)
+			continue
+		}
+		if viol.IsError() {
+			t.Errorf("expected an empty directory to be a warning, got %s", viol.GetSeverity())
+		}
+		empty = append(empty, viol.File)
+	}
+	sort.Strings(empty)
+	if strings.Join(empty, ",") != "internal/domain,internal/infra" {
+		t.Errorf("expected internal/domain and internal/infra to be reported, got %v", empty)
+	}
+}
+
+func TestValidateStructure_FS(t *testing.T) {
+	// The project files of a past revision: the working tree on disk is empty
+	projectPath := t.TempDir()
+	fsys := fstest.MapFS{
+		"internal/app/app.go": {Data: []byte("package app\n")},
+		"tools/gen/main.go":   {Data: []byte("package main\n")},
+	}
+
+	g := &testGraph{nodes: []validator.FileNode{
+		&testFileNode{relPath: "internal/app/app.go", pkg: "app"},
+	}}
+	cfg := &testConfig{
+		requiredDirectories: map[string]string{"internal/app": "Use cases"},
+	}
+
+	v := validator.NewWithPath(cfg, g, projectPath)
+	v.SetFS(fsys)
+	var types []string
+	for _, viol := range v.Validate() {
+		types = append(types, string(viol.Type)+" "+viol.File)
+	}
+
+	// internal/app exists in the file system, tools is unexpected there
+	if len(types) != 1 || types[0] != string(validator.ViolationUnexpectedDirectory)+" tools" {
+		t.Errorf("expected only tools to be unexpected, got %v", types)
+	}
+}
+
 // TestSetCoverageResults tests SetCoverageResults method
 func TestSetCoverageResults(t *testing.T) {
 	g := &testGraph{
@@ -1602,3 +2208,52 @@ func TestValidate_SkipLevelViolation_ExplicitlyAllowed(t *testing.T) {
 		}
 	}
 }
+
+func TestValidate_FailFast(t *testing.T) {
+	newValidator := func() *validator.Validator {
+		g := &testGraph{
+			nodes: []validator.FileNode{
+				&testFileNode{
+					relPath: "pkg/http/server.go",
+					pkg:     "http",
+					dependencies: []validator.Dependency{
+						&testDependency{importPath: "github.com/test/project/pkg/database", localPath: "pkg/database", isLocal: true},
+					},
+				},
+				&testFileNode{
+					relPath: "pkg/database/db.go",
+					pkg:     "database",
+					dependencies: []validator.Dependency{
+						&testDependency{importPath: "github.com/test/project/pkg/http", localPath: "pkg/http", isLocal: true},
+					},
+				},
+			},
+		}
+		cfg := &testConfig{
+			module:            "github.com/test/project",
+			directoriesImport: map[string][]string{"pkg": {"internal"}},
+			detectUnused:      true,
+		}
+		return validator.New(cfg, g)
+	}
+
+	all := newValidator().Validate()
+	if len(all) < 3 {
+		t.Fatalf("expected violations in both files and unused packages, got %d", len(all))
+	}
+
+	v := newValidator()
+	v.SetFailFast(validator.Violation.IsError)
+	violations := v.Validate()
+	if len(violations) != 1 || violations[0].File != "pkg/http/server.go" {
+		t.Fatalf("expected validation to stop at the first error, got %+v", violations)
+	}
+
+	// Violations that do not fail are kept, and the check sees their rule keys
+	v = newValidator()
+	v.SetFailFast(func(viol validator.Violation) bool { return viol.RuleKey == "rules.directories_import.pkg" })
+	violations = v.Validate()
+	if len(violations) != 2 || violations[0].Type != validator.ViolationPkgToPkg || violations[1].Type != validator.ViolationForbidden {
+		t.Errorf("expected the violations up to the first failing one, got %+v", violations)
+	}
+}
@@ -0,0 +1,157 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// detectVersionSprawl finds external modules imported in more than one major version,
+// e.g. github.com/go-redis/redis/v8 and /v9 or gopkg.in/yaml.v2 and yaml.v3. Each
+// version carries its own types and global state, so values do not mix between them.
+func (v *Validator) detectVersionSprawl() []Violation {
+	type importLocation struct {
+		file   string
+		layer  string
+		line   int
+		column int
+	}
+
+	// Module path without major version -> major version -> import locations
+	imports := make(map[string]map[int][]importLocation)
+	var unversioned []struct {
+		importPath string
+		location   importLocation
+	}
+
+	for _, node := range v.graph.GetNodes() {
+		fileDir := path.Dir(node.GetRelPath())
+		for _, dep := range node.GetDependencies() {
+			if dep.IsLocalDep() || isStdLib(dep.GetImportPath()) {
+				continue
+			}
+			location := importLocation{
+				file:   node.GetRelPath(),
+				layer:  v.getDefinitionLayer(fileDir),
+				line:   dep.GetLine(),
+				column: dep.GetColumn(),
+			}
+			base, major, ok := splitMajorVersion(dep.GetImportPath())
+			if !ok {
+				unversioned = append(unversioned, struct {
+					importPath string
+					location   importLocation
+				}{dep.GetImportPath(), location})
+				continue
+			}
+			if imports[base] == nil {
+				imports[base] = make(map[int][]importLocation)
+			}
+			imports[base][major] = append(imports[base][major], location)
+		}
+	}
+
+	// Imports without a version suffix are v0/v1 of a versioned module they belong to
+	for _, imp := range unversioned {
+		for base, versions := range imports {
+			if imp.importPath == base || strings.HasPrefix(imp.importPath, base+"/") {
+				versions[1] = append(versions[1], imp.location)
+				break
+			}
+		}
+	}
+
+	var violations []Violation
+	for base, versions := range imports {
+		if len(versions) < 2 {
+			continue
+		}
+
+		majors := make([]int, 0, len(versions))
+		for major := range versions {
+			majors = append(majors, major)
+		}
+		sort.Ints(majors)
+		newest := majors[len(majors)-1]
+
+		var labels, older []string
+		var importedBy []Message
+		for _, major := range majors {
+			label := "v" + strconv.Itoa(major)
+			labels = append(labels, label)
+			if major != newest {
+				older = append(older, label)
+			}
+			locations := versions[major]
+			sort.SliceStable(locations, func(i, j int) bool {
+				if locations[i].file != locations[j].file {
+					return locations[i].file < locations[j].file
+				}
+				return locations[i].line < locations[j].line
+			})
+			for _, loc := range locations {
+				importedBy = append(importedBy, NewMessage("violation.version_sprawl.location", "%s: %s (layer: %s)", label, loc.file, loc.layer))
+			}
+		}
+
+		// Point at the first import of the oldest version, the one to migrate
+		first := versions[majors[0]][0]
+		violations = append(violations, Violation{
+			Type:   ViolationVersionSprawl,
+			File:   first.file,
+			Line:   first.line,
+			Column: first.column,
+		}.withText(
+			NewMessage("violation.version_sprawl.issue", "External module '%s' imported in %d major versions (%s)\n  Imported by:\n    - %s",
+				base, len(majors), strings.Join(labels, ", "), joinMessages(importedBy, "\n    - ")),
+			NewMessage("violation.version_sprawl.rule", "Each external module should be used in a single major version"),
+			NewMessage("violation.version_sprawl.fix", "Migrate the imports of %s to v%d so only one version of %s remains", strings.Join(older, ", "), newest, base),
+		))
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+
+	return violations
+}
+
+// splitMajorVersion splits an import path into the module path without its major
+// version and the major version, from a /vN element (N >= 2) or a gopkg.in .vN suffix.
+// It reports false for import paths without a major version.
+func splitMajorVersion(importPath string) (string, int, bool) {
+	elements := strings.Split(importPath, "/")
+	for i, element := range elements {
+		if i == 0 {
+			continue
+		}
+		if elements[0] == "gopkg.in" {
+			if dot := strings.LastIndex(element, ".v"); dot > 0 {
+				if major, err := strconv.Atoi(element[dot+2:]); err == nil {
+					return strings.Join(append(elements[:i:i], element[:dot]), "/"), major, true
+				}
+			}
+			continue
+		}
+		if major, ok := parseMajor(element); ok && major >= 2 {
+			return strings.Join(elements[:i], "/"), major, true
+		}
+	}
+	return "", 0, false
+}
+
+// parseMajor parses a major version element like "v2"
+func parseMajor(element string) (int, bool) {
+	if len(element) < 2 || element[0] != 'v' {
+		return 0, false
+	}
+	major, err := strconv.Atoi(element[1:])
+	if err != nil || strconv.Itoa(major) != element[1:] {
+		return 0, false
+	}
+	return major, true
+}
@@ -0,0 +1,62 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+func TestDetectVersionSprawl(t *testing.T) {
+	cfg := &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"cmd":      {"internal"},
+			"internal": {},
+		},
+		detectVersionSprawl: true,
+	}
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			externalImportNode("cmd/app/main.go", "github.com/go-redis/redis/v9", "gopkg.in/yaml.v2", "github.com/google/uuid"),
+			externalImportNode("internal/cache/cache.go", "github.com/go-redis/redis/v8", "github.com/go-redis/redis/v8/internal/pool"),
+			externalImportNode("internal/cache/legacy.go", "github.com/go-redis/redis"),
+			externalImportNode("internal/config/config.go", "gopkg.in/yaml.v3", "github.com/google/uuid"),
+			externalImportNode("tools/gen/gen.go", "github.com/jackc/pgx/v5", "github.com/jackc/pgx/v5/pgxpool", "github.com/vendor/v2client"),
+		},
+	}
+
+	v := validator.New(cfg, g)
+	var violations []validator.Violation
+	for _, viol := range v.Validate() {
+		if viol.Type == validator.ViolationVersionSprawl {
+			violations = append(violations, viol)
+		}
+	}
+
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 version sprawl violations, got %d: %+v", len(violations), violations)
+	}
+
+	yaml := violations[0]
+	if yaml.File != "cmd/app/main.go" || yaml.Line != 4 ||
+		!strings.HasPrefix(yaml.Issue, "External module 'gopkg.in/yaml' imported in 2 major versions (v2, v3)") ||
+		!strings.Contains(yaml.Issue, "- v2: cmd/app/main.go (layer: cmd)\n    - v3: internal/config/config.go (layer: internal)") {
+		t.Errorf("unexpected yaml violation: %+v", yaml)
+	}
+
+	redis := violations[1]
+	if redis.File != "internal/cache/legacy.go" {
+		t.Errorf("expected the oldest version's import to be reported, got: %+v", redis)
+	}
+	wantLines := "- v1: internal/cache/legacy.go (layer: internal)\n" +
+		"    - v8: internal/cache/cache.go (layer: internal)\n" +
+		"    - v8: internal/cache/cache.go (layer: internal)\n" +
+		"    - v9: cmd/app/main.go (layer: cmd)"
+	if !strings.Contains(redis.Issue, "'github.com/go-redis/redis' imported in 3 major versions (v1, v8, v9)") || !strings.Contains(redis.Issue, wantLines) {
+		t.Errorf("unexpected redis issue:\n%s", redis.Issue)
+	}
+	if redis.Fix != "Migrate the imports of v1, v8 to v9 so only one version of github.com/go-redis/redis remains" {
+		t.Errorf("unexpected fix: %q", redis.Fix)
+	}
+}
@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// validateWrappedImports checks that external modules declared in wrap_in are only
+// imported by their designated wrapper package
+func (v *Validator) validateWrappedImports() []Violation {
+	var violations []Violation
+
+	for _, node := range v.graph.GetNodes() {
+		fileDir := path.Dir(node.GetRelPath())
+
+		for _, dep := range node.GetDependencies() {
+			if dep.IsLocalDep() {
+				continue
+			}
+
+			module, wrapper, ok := v.findWrappedModule(dep.GetImportPath())
+			if !ok || isWithinDir(fileDir, wrapper) {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				Type:   ViolationUnwrappedImport,
+				File:   node.GetRelPath(),
+				Line:   dep.GetLine(),
+				Column: dep.GetColumn(),
+			}.withText(
+				NewMessage("violation.unwrapped_import.issue", "%s imports %s directly instead of using %s", fileDir, dep.GetImportPath(), wrapper),
+				NewMessage("violation.unwrapped_import.rule", "%s must only be imported by its wrapper package %s", module, wrapper),
+				NewMessage("violation.unwrapped_import.fix", "Use the abstractions provided by %s instead", wrapper),
+			))
+		}
+	}
+
+	return violations
+}
+
+// detectWrapperBypasses finds wrapper packages whose exported API exposes symbols of the
+// module they wrap. Callers of such a wrapper end up depending on the external module
+// without importing it, which silently defeats the anti-corruption layer.
+func (v *Validator) detectWrapperBypasses() []Violation {
+	var violations []Violation
+
+	for _, file := range v.sourceFiles {
+		if file.GetIsTest() {
+			continue
+		}
+
+		fileDir := path.Dir(file.GetRelPath())
+
+		for _, ref := range file.GetAPIReferences() {
+			module, wrapper, ok := v.findWrappedModule(ref.GetImportPath())
+			// Imports outside the wrapper are already reported by validateWrappedImports
+			if !ok || !isWithinDir(fileDir, wrapper) {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				Type:   ViolationWrapperBypass,
+				File:   file.GetRelPath(),
+				Line:   ref.GetLine(),
+				Column: ref.GetColumn(),
+			}.withText(
+				NewMessage("violation.wrapper_bypass.issue", "Exported %s exposes %s.%s from wrapped module %s", ref.GetDecl(), ref.GetImportPath(), ref.GetSymbol(), module),
+				NewMessage("violation.wrapper_bypass.rule", "The public API of %s must not leak types of the module it wraps", wrapper),
+				NewMessage("violation.wrapper_bypass.fix", "Define a local type in %s and translate to/from %s.%s internally", wrapper, ref.GetImportPath(), ref.GetSymbol()),
+			))
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+
+	return violations
+}
+
+// findWrappedModule returns the wrap_in entry matching importPath.
+// The longest module path wins when entries are nested.
+func (v *Validator) findWrappedModule(importPath string) (string, string, bool) {
+	bestModule := ""
+	for module := range v.cfg.GetWrapIn() {
+		if (importPath == module || strings.HasPrefix(importPath, module+"/")) && len(module) > len(bestModule) {
+			bestModule = module
+		}
+	}
+	if bestModule == "" {
+		return "", "", false
+	}
+	return bestModule, strings.TrimSuffix(v.cfg.GetWrapIn()[bestModule], "/"), true
+}
+
+// isWithinDir checks if dir is the same as parent or nested below it
+func isWithinDir(dir, parent string) bool {
+	return dir == parent || strings.HasPrefix(dir, parent+"/")
+}
@@ -0,0 +1,135 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+type testAPIReference struct {
+	decl       string
+	importPath string
+	symbol     string
+	line       int
+	column     int
+}
+
+func (tar *testAPIReference) GetDecl() string       { return tar.decl }
+func (tar *testAPIReference) GetImportPath() string { return tar.importPath }
+func (tar *testAPIReference) GetSymbol() string     { return tar.symbol }
+func (tar *testAPIReference) GetLine() int          { return tar.line }
+func (tar *testAPIReference) GetColumn() int        { return tar.column }
+
+func wrapInConfig() *testConfig {
+	return &testConfig{
+		module: "github.com/test/project",
+		directoriesImport: map[string][]string{
+			"pkg":      {"internal"},
+			"internal": {},
+		},
+		wrapIn: map[string]string{
+			"github.com/aws/aws-sdk-go-v2": "internal/storage",
+		},
+	}
+}
+
+func TestWrapIn_ImportOutsideWrapper(t *testing.T) {
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{
+				relPath: "internal/storage/s3.go",
+				pkg:     "storage",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/aws/aws-sdk-go-v2/service/s3"},
+				},
+			},
+			&testFileNode{
+				relPath: "pkg/upload/upload.go",
+				pkg:     "upload",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/aws/aws-sdk-go-v2/service/s3"},
+					&testDependency{importPath: "github.com/aws/aws-sdk-go-v2-extras"},
+				},
+			},
+		},
+	}
+
+	v := validator.New(wrapInConfig(), g)
+	violations := v.Validate()
+
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+	if violations[0].Type != validator.ViolationUnwrappedImport {
+		t.Errorf("expected ViolationUnwrappedImport, got %s", violations[0].Type)
+	}
+	if violations[0].File != "pkg/upload/upload.go" {
+		t.Errorf("expected violation in pkg/upload/upload.go, got %s", violations[0].File)
+	}
+}
+
+func TestWrapIn_WrapperReExportsExternalTypes(t *testing.T) {
+	v := validator.New(wrapInConfig(), &testGraph{})
+	v.SetSourceFiles([]validator.SourceFile{
+		&testSourceFile{
+			relPath: "internal/storage/s3.go",
+			apiRefs: []validator.APIReference{
+				&testAPIReference{decl: "Client", importPath: "github.com/aws/aws-sdk-go-v2/service/s3", symbol: "Client", line: 9},
+				&testAPIReference{decl: "Store.Put", importPath: "context", symbol: "Context", line: 14},
+			},
+		},
+		// Leaks from tests and from unrelated packages are not bypasses of the wrapper
+		&testSourceFile{
+			relPath: "internal/storage/s3_test.go",
+			isTest:  true,
+			apiRefs: []validator.APIReference{
+				&testAPIReference{decl: "Fake", importPath: "github.com/aws/aws-sdk-go-v2/service/s3", symbol: "Client", line: 3},
+			},
+		},
+		&testSourceFile{
+			relPath: "internal/cache/cache.go",
+			apiRefs: []validator.APIReference{
+				&testAPIReference{decl: "Cache", importPath: "github.com/redis/go-redis/v9", symbol: "Client", line: 5},
+			},
+		},
+	})
+
+	violations := v.Validate()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+
+	viol := violations[0]
+	if viol.Type != validator.ViolationWrapperBypass {
+		t.Errorf("expected ViolationWrapperBypass, got %s", viol.Type)
+	}
+	if viol.File != "internal/storage/s3.go" || viol.Line != 9 {
+		t.Errorf("expected location internal/storage/s3.go:9, got %s:%d", viol.File, viol.Line)
+	}
+	if !strings.Contains(viol.Issue, "github.com/aws/aws-sdk-go-v2/service/s3.Client") {
+		t.Errorf("expected issue to name the leaked symbol, got: %s", viol.Issue)
+	}
+}
+
+func TestWrapIn_Disabled(t *testing.T) {
+	cfg := wrapInConfig()
+	cfg.wrapIn = nil
+
+	g := &testGraph{
+		nodes: []validator.FileNode{
+			&testFileNode{
+				relPath: "pkg/upload/upload.go",
+				pkg:     "upload",
+				dependencies: []validator.Dependency{
+					&testDependency{importPath: "github.com/aws/aws-sdk-go-v2/service/s3"},
+				},
+			},
+		},
+	}
+
+	v := validator.New(cfg, g)
+	if violations := v.Validate(); len(violations) != 0 {
+		t.Errorf("expected no violations without wrap_in, got %d", len(violations))
+	}
+}
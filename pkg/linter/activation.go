@@ -0,0 +1,34 @@
+package linter
+
+import (
+	"time"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// applyRuleActivation downgrades violations of rules that are scheduled with
+// rules.active_from but not yet active at now to warnings, so upcoming tightenings
+// are reported without failing the build until their date.
+func applyRuleActivation(cfg *config.Config, violations []validator.Violation, now time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for i := range violations {
+		activeFrom, ok := cfg.GetRuleActivation(violations[i].RuleKey)
+		if !ok || !today.Before(activeFrom) {
+			continue
+		}
+		if violations[i].IsError() {
+			violations[i].Severity = validator.SeverityWarning
+		}
+		days := int(activeFrom.Sub(today).Hours() / 24)
+		violations[i].AppendRule(validator.NewMessage("violation.enforced_from", " (enforced from %s, %s)", activeFrom.Format("2006-01-02"), inDays(days)))
+	}
+}
+
+// inDays returns "in N days" as a message, for notes appended to violation rules
+func inDays(days int) validator.Message {
+	if days == 1 {
+		return validator.NewMessage("violation.in_day", "in 1 day")
+	}
+	return validator.NewMessage("violation.in_days", "in %d days", days)
+}
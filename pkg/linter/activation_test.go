@@ -0,0 +1,67 @@
+package linter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func writeActivationProject(t *testing.T, activeFrom string) string {
+	t.Helper()
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: []
+    internal: []
+  active_from:
+    rules.directories_import.cmd: ` + activeFrom + `
+scan_paths:
+  - cmd
+  - internal
+`,
+		"cmd/app/main.go":         "package main\n\nimport \"github.com/test/project/internal/store\"\n\nfunc main() { store.Save() }\n",
+		"internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	return writeProject(t, files)
+}
+
+func TestRun_RuleActivation_Scheduled(t *testing.T) {
+	tmpDir := writeActivationProject(t, "2999-01-01")
+
+	_, violations, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(violations, "[WARNING] Forbidden Import") {
+		t.Errorf("expected forbidden import reported as warning before activation, got:\n%s", violations)
+	}
+	if !strings.Contains(violations, "(enforced from 2999-01-01, in ") {
+		t.Errorf("expected activation date in rule, got:\n%s", violations)
+	}
+	if shouldFail {
+		t.Error("expected scheduled rule not to fail the build before its date")
+	}
+}
+
+func TestRun_RuleActivation_Active(t *testing.T) {
+	tmpDir := writeActivationProject(t, "2000-01-01")
+
+	_, violations, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(violations, "[ERROR] Forbidden Import") {
+		t.Errorf("expected forbidden import reported as error after activation, got:\n%s", violations)
+	}
+	if strings.Contains(violations, "enforced from") {
+		t.Errorf("expected no activation note after the date, got:\n%s", violations)
+	}
+	if !shouldFail {
+		t.Error("expected active rule to fail the build")
+	}
+}
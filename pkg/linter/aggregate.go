@@ -0,0 +1,216 @@
+package linter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/coverage"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// Aggregate merges the JSON reports written by -format=json in many repositories into
+// one dataset: violations by owning team and type, preset adoption and coverage. Patterns
+// are expanded as globs, so reports/*.json works without a shell.
+func Aggregate(patterns []string, format string) (string, error) {
+	if !containsFormat(output.AggregateFormats, format) {
+		return "", fmt.Errorf("unsupported aggregate format %q (supported: %s)", format, strings.Join(output.AggregateFormats, ", "))
+	}
+
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return "", fmt.Errorf("no reports match %s", pattern)
+		}
+		paths = append(paths, matches...)
+	}
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no reports given")
+	}
+
+	reports := make([]output.Report, len(paths))
+	for i, path := range paths {
+		report, err := readReport(path)
+		if err != nil {
+			return "", err
+		}
+		reports[i] = report
+	}
+
+	return output.FormatAggregate(aggregateReports(paths, reports), format)
+}
+
+// readReport reads a JSON report and checks that this build understands its layout
+func readReport(path string) (output.Report, error) {
+	var report output.Report
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report, fmt.Errorf("reading report: %w", err)
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return report, fmt.Errorf("%s: invalid report: %w", path, err)
+	}
+	switch {
+	case report.ReportVersion == 0:
+		return report, fmt.Errorf("%s: not a go-arch-lint report (write one with go-arch-lint -format=json)", path)
+	case report.ReportVersion > output.ReportVersion:
+		return report, fmt.Errorf("%s: report version %d is newer than this go-arch-lint supports (%d)", path, report.ReportVersion, output.ReportVersion)
+	}
+	return report, nil
+}
+
+// aggregateReports builds the cross-repository dataset; names identify the reports
+func aggregateReports(names []string, reports []output.Report) output.AggregateDataset {
+	dataset := output.AggregateDataset{
+		RepositoryCount:  len(reports),
+		PresetAdoption:   make(map[string]int),
+		ViolationsByType: make(map[string]int),
+		Teams:            []output.TeamSummary{},
+		Repositories:     []output.RepositorySummary{},
+	}
+
+	teams := make(map[string]*output.TeamSummary)
+	var coverageSum, scoreSum float64
+	measured, scored := 0, 0
+	for i, report := range reports {
+		preset := report.Preset
+		if preset == "" {
+			preset = "custom"
+		}
+		dataset.PresetAdoption[preset]++
+
+		repo := output.RepositorySummary{
+			Report:           names[i],
+			Module:           report.Module,
+			Preset:           preset,
+			Failed:           report.Failed,
+			Violations:       len(report.Violations),
+			ViolationsByType: make(map[string]int),
+		}
+		if report.Coverage != nil {
+			overall := report.Coverage.Overall
+			repo.Coverage = &overall
+			coverageSum += overall
+			measured++
+		}
+		if report.Score != nil {
+			score := report.Score.Score
+			repo.Score = &score
+			scoreSum += float64(score)
+			scored++
+		}
+
+		repoTeams := make(map[string]bool)
+		for _, viol := range report.Violations {
+			isError := viol.Severity == "" || viol.Severity == string(validator.SeverityError)
+			team := viol.Team
+			if team == "" {
+				team = output.UnownedTeam
+			}
+			summary := teams[team]
+			if summary == nil {
+				summary = &output.TeamSummary{Team: team, ViolationsByType: make(map[string]int)}
+				teams[team] = summary
+			}
+			if !repoTeams[team] {
+				repoTeams[team] = true
+				summary.Repositories++
+			}
+
+			summary.Violations++
+			summary.ViolationsByType[viol.Type]++
+			repo.ViolationsByType[viol.Type]++
+			dataset.ViolationsByType[viol.Type]++
+			if isError {
+				summary.Errors++
+				repo.Errors++
+			}
+		}
+
+		dataset.ViolationCount += repo.Violations
+		dataset.ErrorCount += repo.Errors
+		dataset.Repositories = append(dataset.Repositories, repo)
+	}
+
+	if measured > 0 {
+		average := coverageSum / float64(measured)
+		dataset.AverageCoverage = &average
+	}
+	if scored > 0 {
+		average := scoreSum / float64(scored)
+		dataset.AverageScore = &average
+	}
+
+	for _, summary := range teams {
+		dataset.Teams = append(dataset.Teams, *summary)
+	}
+	sort.Slice(dataset.Teams, func(i, j int) bool {
+		if dataset.Teams[i].Violations != dataset.Teams[j].Violations {
+			return dataset.Teams[i].Violations > dataset.Teams[j].Violations
+		}
+		return dataset.Teams[i].Team < dataset.Teams[j].Team
+	})
+	sort.SliceStable(dataset.Repositories, func(i, j int) bool {
+		return dataset.Repositories[i].Module < dataset.Repositories[j].Module
+	})
+
+	return dataset
+}
+
+// buildReport converts the results of a run into the JSON report read by Aggregate
+func buildReport(cfg *config.Config, profile string, violations []validator.Violation, coverageResults []coverage.PackageCoverage, failed bool) output.Report {
+	report := output.Report{
+		ReportVersion: output.ReportVersion,
+		Module:        cfg.Module,
+		Preset:        cfg.GetPresetUsed(),
+		Profile:       profile,
+		Failed:        failed,
+		Violations:    make([]output.ReportViolation, len(violations)),
+	}
+
+	teams := cfg.GetOwnershipTeams()
+	for i, viol := range violations {
+		dir := viol.File
+		if strings.HasSuffix(dir, ".go") {
+			dir = path.Dir(dir)
+		}
+		report.Violations[i] = output.ReportViolation{
+			Type:     string(viol.Type),
+			File:     viol.File,
+			Line:     viol.Line,
+			Column:   viol.Column,
+			Issue:    viol.Issue,
+			Rule:     viol.Rule,
+			Fix:      viol.Fix,
+			Severity: viol.GetSeverity(),
+			RuleKey:  viol.RuleKey,
+			Code:     viol.GetCode(),
+			FixCode:  output.FixCode(violationAdapter{viol}),
+			Source:   viol.Source,
+			Team:     validator.OwnerOf(dir, teams),
+		}
+	}
+
+	if cfg.IsCoverageEnabled() && coverageResults != nil {
+		report.Coverage = &output.ReportCoverage{Overall: coverage.CalculateOverallCoverage(coverageResults)}
+		for _, result := range coverageResults {
+			report.Coverage.Packages = append(report.Coverage.Packages, output.ReportPackageCoverage{
+				Package:  result.PackagePath,
+				Coverage: result.Coverage,
+				HasTests: result.HasTests(),
+			})
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,144 @@
+package linter_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestRunWithOptions_JSONReport(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module github.com/test/billing\n\ngo 1.21\n",
+		".goarchlint": `module: github.com/test/billing
+rules:
+  directories_import:
+    internal/order: []
+    internal/store: []
+  ownership:
+    teams:
+      internal/order: checkout
+scan_paths:
+  - internal
+`,
+		"internal/order/order.go": "package order\n\nimport _ \"github.com/test/billing/internal/store\"\n",
+		"internal/store/store.go": "package store\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	graphOutput, violationsOutput, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "json"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if violationsOutput != "" {
+		t.Errorf("expected the violations to be part of the report only, got:\n%s", violationsOutput)
+	}
+	if !shouldFail {
+		t.Error("expected the forbidden import to fail the build")
+	}
+
+	var report output.Report
+	if err := json.Unmarshal([]byte(graphOutput), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, graphOutput)
+	}
+	if report.ReportVersion != output.ReportVersion || report.Module != "github.com/test/billing" || !report.Failed {
+		t.Errorf("unexpected report header: %+v", report)
+	}
+	if len(report.Violations) != 1 {
+		t.Fatalf("expected 1 violation, got %+v", report.Violations)
+	}
+	viol := report.Violations[0]
+	if viol.File != "internal/order/order.go" || viol.Team != "checkout" || viol.Severity != "error" || viol.RuleKey != "rules.directories_import.internal/order" || viol.Code != "ARCH005" || !strings.HasPrefix(viol.FixCode, "ARCH005-") {
+		t.Errorf("unexpected violation: %+v", viol)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	tmpDir := t.TempDir()
+	coverage := &output.ReportCoverage{Overall: 80}
+	reports := map[string]output.Report{
+		"billing.json": {ReportVersion: 1, Module: "github.com/org/billing", Preset: "ddd", Failed: true, Coverage: coverage, Violations: []output.ReportViolation{
+			{Type: "Forbidden Import", File: "internal/order/order.go", Severity: "error", Team: "checkout"},
+			{Type: "Missing Package Doc", File: "internal/order/doc.go", Severity: "warning", Team: "checkout"},
+			{Type: "Forbidden Import", File: "cmd/main.go", Severity: "error"},
+		}},
+		"search.json": {ReportVersion: 1, Module: "github.com/org/search", Preset: "ddd", Coverage: &output.ReportCoverage{Overall: 60}, Violations: []output.ReportViolation{
+			{Type: "Forbidden Import", File: "internal/cart/cart.go", Severity: "error", Team: "checkout"},
+		}},
+		"tools.json": {ReportVersion: 1, Module: "github.com/org/tools", Violations: []output.ReportViolation{}},
+	}
+	for name, report := range reports {
+		data, err := json.Marshal(report)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := linter.Aggregate([]string{filepath.Join(tmpDir, "*.json")}, "json")
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+
+	var dataset output.AggregateDataset
+	if err := json.Unmarshal([]byte(result), &dataset); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+	if dataset.RepositoryCount != 3 || dataset.ViolationCount != 4 || dataset.ErrorCount != 3 {
+		t.Errorf("unexpected totals: %d repositories, %d violations, %d errors", dataset.RepositoryCount, dataset.ViolationCount, dataset.ErrorCount)
+	}
+	if dataset.AverageCoverage == nil || *dataset.AverageCoverage != 70 {
+		t.Errorf("expected an average coverage of 70 over the measured repositories, got %v", dataset.AverageCoverage)
+	}
+	if dataset.PresetAdoption["ddd"] != 2 || dataset.PresetAdoption["custom"] != 1 {
+		t.Errorf("unexpected preset adoption: %v", dataset.PresetAdoption)
+	}
+	if dataset.ViolationsByType["Forbidden Import"] != 3 {
+		t.Errorf("unexpected violations by type: %v", dataset.ViolationsByType)
+	}
+
+	if len(dataset.Teams) != 2 {
+		t.Fatalf("expected the checkout team and unowned violations, got %+v", dataset.Teams)
+	}
+	checkout, unowned := dataset.Teams[0], dataset.Teams[1]
+	if checkout.Team != "checkout" || checkout.Repositories != 2 || checkout.Violations != 3 || checkout.Errors != 2 {
+		t.Errorf("unexpected checkout summary: %+v", checkout)
+	}
+	if unowned.Team != output.UnownedTeam || unowned.Violations != 1 {
+		t.Errorf("unexpected unowned summary: %+v", unowned)
+	}
+
+	if len(dataset.Repositories) != 3 || dataset.Repositories[0].Module != "github.com/org/billing" || !dataset.Repositories[0].Failed {
+		t.Errorf("expected repositories sorted by module, got %+v", dataset.Repositories)
+	}
+}
+
+func TestAggregate_RejectsInvalidReports(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{
+		"other.json": `{"name": "not a report"}`,
+		"newer.json": `{"report_version": 99, "module": "github.com/org/next"}`,
+	})
+
+	tests := []struct {
+		pattern string
+		format  string
+		wantErr string
+	}{
+		{pattern: "other.json", format: "json", wantErr: "not a go-arch-lint report"},
+		{pattern: "newer.json", format: "json", wantErr: "report version 99 is newer"},
+		{pattern: "missing-*.json", format: "json", wantErr: "no reports match"},
+		{pattern: "other.json", format: "csv", wantErr: "unsupported aggregate format"},
+	}
+	for _, tt := range tests {
+		_, err := linter.Aggregate([]string{filepath.Join(tmpDir, tt.pattern)}, tt.format)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Aggregate(%s, %s): expected error containing %q, got %v", tt.pattern, tt.format, tt.wantErr, err)
+		}
+	}
+}
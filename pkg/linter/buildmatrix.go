@@ -0,0 +1,70 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// matrixValidators scans the project once per build target, keeping only the files
+// built for it, and creates a validator for each scan
+func matrixValidators(projectPath string, cfg *config.Config, strictParse, detailed bool, targets []config.BuildTarget) ([]*validator.Validator, error) {
+	validators := make([]*validator.Validator, len(targets))
+	for i, target := range targets {
+		s := newScanner(projectPath, cfg, strictParse)
+		s.SetBuildContext(target.GOOS, target.GOARCH, target.Tags)
+		files, g, err := scanWith(s, cfg, detailed)
+		if err != nil {
+			return nil, fmt.Errorf("scanning for %s: %w", target, err)
+		}
+		validators[i] = newValidator(projectPath, cfg, s, files, g)
+	}
+	return validators, nil
+}
+
+// matrixKey identifies a violation across the results of several build targets
+type matrixKey struct {
+	violationType validator.ViolationType
+	file          string
+	line          int
+	column        int
+	issue         string
+}
+
+// validateBuildMatrix validates every build target and merges the results in order of
+// first appearance. Violations found under every target are reported once as usual;
+// the others come from platform-specific files or tags, so their issue names the
+// targets they appear under.
+func validateBuildMatrix(validators []*validator.Validator, targets []config.BuildTarget) []validator.Violation {
+	var violations []validator.Violation
+	index := make(map[matrixKey]int)     // violation -> position in violations
+	foundIn := make(map[matrixKey][]int) // violation -> targets reporting it
+
+	for i, v := range validators {
+		for _, viol := range v.Validate() {
+			key := matrixKey{viol.Type, viol.File, viol.Line, viol.Column, viol.Issue}
+			if _, ok := index[key]; !ok {
+				index[key] = len(violations)
+				violations = append(violations, viol)
+			}
+			if found := foundIn[key]; len(found) == 0 || found[len(found)-1] != i {
+				foundIn[key] = append(found, i)
+			}
+		}
+	}
+
+	for key, pos := range index {
+		if len(foundIn[key]) == len(targets) {
+			continue
+		}
+		names := make([]string, len(foundIn[key]))
+		for i, target := range foundIn[key] {
+			names[i] = targets[target].String()
+		}
+		violations[pos].AppendIssue(validator.NewMessage("violation.only_under", " (only under %s)", strings.Join(names, "; ")))
+	}
+
+	return violations
+}
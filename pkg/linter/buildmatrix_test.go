@@ -0,0 +1,74 @@
+package linter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func writeBuildMatrixProject(t *testing.T, buildMatrix string) string {
+	t.Helper()
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal/domain: []
+    internal/store: []
+    internal/syscalls: []
+scan_paths:
+  - internal
+` + buildMatrix,
+		"internal/domain/order.go":         "package domain\n\nimport \"github.com/test/project/internal/store\"\n\nvar _ = store.Name\n",
+		"internal/domain/order_windows.go": "package domain\n\nimport \"github.com/test/project/internal/syscalls\"\n\nvar _ = syscalls.Name\n",
+		"internal/store/store.go":          "package store\n\nconst Name = \"store\"\n",
+		"internal/syscalls/syscalls.go":    "package syscalls\n\nconst Name = \"syscalls\"\n",
+	}
+	return writeProject(t, files)
+}
+
+func TestRunWithOptions_BuildMatrix(t *testing.T) {
+	tmpDir := writeBuildMatrixProject(t, `build_matrix:
+  - goos: linux
+    goarch: amd64
+  - goos: windows
+    goarch: amd64
+    tags: [debug]
+`)
+
+	_, violations, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{BuildMatrix: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected build matrix violations to fail the build")
+	}
+
+	// Found under every target: reported once, without targets
+	if strings.Count(violations, "internal/domain imports internal/store") != 1 ||
+		strings.Contains(violations, "internal/domain imports internal/store (only under") {
+		t.Errorf("expected the shared violation once without targets, got:\n%s", violations)
+	}
+	// Found in a platform-specific file: reported once, with its target
+	if strings.Count(violations, "internal/domain imports internal/syscalls") != 1 ||
+		!strings.Contains(violations, "internal/domain imports internal/syscalls (only under windows/amd64 with tags debug)") {
+		t.Errorf("expected the windows-only violation with its target, got:\n%s", violations)
+	}
+}
+
+func TestRunWithOptions_BuildMatrixRequiresTargets(t *testing.T) {
+	tmpDir := writeBuildMatrixProject(t, "")
+
+	if _, _, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{BuildMatrix: true}); err == nil || !strings.Contains(err.Error(), "build_matrix") {
+		t.Errorf("expected an error about missing build_matrix targets, got %v", err)
+	}
+
+	// Without the mode, files of every platform are checked together
+	_, violations, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !strings.Contains(violations, "internal/domain imports internal/syscalls") || strings.Contains(violations, "only under") {
+		t.Errorf("expected platform-specific violations without targets, got:\n%s", violations)
+	}
+}
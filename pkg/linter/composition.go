@@ -0,0 +1,103 @@
+package linter
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/internal/scanner"
+)
+
+// collectCompositionRoots returns what the main packages and the DI wiring (wire, fx,
+// dig) construct for the docs, with the components injected into each construction.
+// Constructions are named by the directory of local packages. Constructions of other
+// packages are listed only if something is injected into them (an http.Server given a
+// handler), so the plumbing of a main function does not drown the wiring.
+func collectCompositionRoots(files []scanner.FileInfo, g *graph.Graph) []output.CompositionRoot {
+	localPaths := make(map[string]string) // import path -> directory of local packages
+	for _, node := range g.Nodes {
+		for _, dep := range node.Dependencies {
+			if dep.IsLocal {
+				localPaths[dep.ImportPath] = dep.LocalPath
+			}
+		}
+	}
+
+	byPackage := make(map[string]*output.CompositionRoot)
+	for _, file := range files {
+		if file.IsTest || len(file.Constructions) == 0 {
+			continue
+		}
+		pkgPath := path.Dir(file.RelPath)
+		root := byPackage[pkgPath]
+		if root == nil {
+			root = &output.CompositionRoot{Package: pkgPath}
+			byPackage[pkgPath] = root
+		}
+
+		// Providers of the file's own package have no import path
+		componentName := func(importPath, name string) string {
+			if importPath == "" {
+				return pkgPath + "." + name
+			}
+			if localPath, ok := localPaths[importPath]; ok {
+				return localPath + "." + name
+			}
+			return importPath + "." + name
+		}
+
+		for _, construction := range file.Constructions {
+			_, local := localPaths[construction.ImportPath]
+			if construction.ImportPath != "" && !local && len(construction.Injected) == 0 {
+				continue
+			}
+			var injected []string
+			for _, key := range construction.Injected {
+				dot := strings.LastIndex(key, ".")
+				injected = append(injected, componentName(key[:dot], key[dot+1:]))
+			}
+			addComponent(root, output.Component{
+				Name:     componentName(construction.ImportPath, construction.Name),
+				Injected: injected,
+				Provided: construction.Provided,
+			})
+		}
+	}
+
+	var roots []output.CompositionRoot
+	for _, root := range byPackage {
+		if len(root.Components) > 0 {
+			roots = append(roots, *root)
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		return roots[i].Package < roots[j].Package
+	})
+	return roots
+}
+
+// addComponent adds a component to a composition root, merging what is injected into
+// a component constructed more than once. A provider that is also called, as in the
+// code Wire generates, counts as constructed.
+func addComponent(root *output.CompositionRoot, component output.Component) {
+	for i := range root.Components {
+		existing := &root.Components[i]
+		if existing.Name != component.Name {
+			continue
+		}
+		existing.Provided = existing.Provided && component.Provided
+	merge:
+		for _, dep := range component.Injected {
+			for _, known := range existing.Injected {
+				if known == dep {
+					continue merge
+				}
+			}
+			existing.Injected = append(existing.Injected, dep)
+		}
+		return
+	}
+	root.Components = append(root.Components, component)
+}
@@ -0,0 +1,188 @@
+package linter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/internal/scanner"
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// ConfigMatrixOptions configures a validation of the project with several configs
+type ConfigMatrixOptions struct {
+	Configs []string // Paths of the config files, in column order
+	Format  string   // Output format: "markdown" or "json"
+}
+
+// ConfigMatrix validates the project once per config file and reports the results side
+// by side, e.g. to evaluate a planned tightening of the rules before adopting it. The
+// project is scanned once, with the scan settings (module, scan_paths, ignore paths and
+// test file linting) of the first config, collecting what the rules of every config
+// need. Coverage, staticcheck and the checks reading git history are left out, as in a
+// refresh preview.
+func ConfigMatrix(projectPath string, opts ConfigMatrixOptions) (string, error) {
+	if !containsFormat(output.ConfigMatrixFormats, opts.Format) {
+		return "", fmt.Errorf("unsupported matrix format %q", opts.Format)
+	}
+	if len(opts.Configs) == 0 {
+		return "", fmt.Errorf("-configs is required")
+	}
+
+	configs := make([]*config.Config, len(opts.Configs))
+	for i, configPath := range opts.Configs {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", configPath, err)
+		}
+		if configs[i], err = config.Parse(projectPath, data); err != nil {
+			return "", fmt.Errorf("%s: %w", configPath, err)
+		}
+	}
+
+	matrix := output.ConfigMatrix{Configs: make([]output.MatrixConfig, len(configs))}
+	names := configNames(opts.Configs)
+	base := configs[0]
+	for i := range configs {
+		matrix.Configs[i] = output.MatrixConfig{Name: names[i], Path: opts.Configs[i]}
+		if i > 0 && !sameScanSettings(base, configs[i]) {
+			matrix.Notes = append(matrix.Notes, fmt.Sprintf("%s scans the project differently than %s (module, scan_paths, ignore paths or test file linting); the scan of %s was used for both", names[i], names[0], names[0]))
+		}
+	}
+
+	// One scan collecting what any of the configs needs
+	scanOpts := scanOptions(base, false)
+	for _, cfg := range configs[1:] {
+		scanOpts = unionScanOptions(scanOpts, scanOptions(cfg, false))
+	}
+	s := newScanner(projectPath, base, false)
+	files, g, err := scanWithOptions(s, base, scanOpts)
+	if err != nil {
+		return "", err
+	}
+	suppressions, _ := inlineSuppressions(files)
+
+	now := time.Now()
+	checker := typecheck.New(projectPath, base.Module)
+	index := make(map[matrixKey]int) // violation -> position in matrix.Violations
+	for i, cfg := range configs {
+		v := newValidator(projectPath, cfg, s, files, g)
+		attachTypeInformation(checker, cfg, g, v)
+
+		violations := applyInlineSuppressions(v.Validate(), suppressions, now)
+		addRuleSources(cfg, violations)
+		applyRuleActivation(cfg, violations, now)
+		applyReplaceDirectiveMode(violations, false)
+		matrix.Configs[i].Fails = shouldFailBuild(violations, cfg)
+
+		for _, viol := range violations {
+			switch viol.GetSeverity() {
+			case string(validator.SeverityError):
+				matrix.Configs[i].Errors++
+			case string(validator.SeverityWarning):
+				matrix.Configs[i].Warnings++
+			default:
+				matrix.Configs[i].Infos++
+			}
+
+			key := matrixKey{viol.Type, viol.File, viol.Line, viol.Column, viol.Issue}
+			pos, ok := index[key]
+			if !ok {
+				pos = len(matrix.Violations)
+				index[key] = pos
+				matrix.Violations = append(matrix.Violations, output.MatrixViolation{
+					Type:       string(viol.Type),
+					File:       viol.File,
+					Line:       viol.Line,
+					Issue:      viol.Issue,
+					Severities: make([]string, len(configs)),
+				})
+			}
+			matrix.Violations[pos].Severities[i] = viol.GetSeverity()
+		}
+	}
+
+	sort.SliceStable(matrix.Violations, func(i, j int) bool {
+		a, b := matrix.Violations[i], matrix.Violations[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Type < b.Type
+	})
+
+	return output.FormatConfigMatrix(matrix, opts.Format)
+}
+
+// configNames returns the column labels of config files: their file names, or the
+// paths as given if several share a file name
+func configNames(paths []string) []string {
+	count := make(map[string]int)
+	for _, p := range paths {
+		count[filepath.Base(p)]++
+	}
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = filepath.Base(p)
+		if count[names[i]] > 1 {
+			names[i] = p
+		}
+	}
+	return names
+}
+
+// sameScanSettings reports whether two configs scan the same files the same way
+func sameScanSettings(a, b *config.Config) bool {
+	return a.Module == b.Module &&
+		reflect.DeepEqual(a.GetScanPaths(), b.GetScanPaths()) &&
+		reflect.DeepEqual(a.IgnorePaths, b.IgnorePaths) &&
+		a.ShouldLintTestFiles() == b.ShouldLintTestFiles()
+}
+
+// unionScanOptions returns scan options collecting everything a or b collects
+func unionScanOptions(a, b scanner.ScanOptions) scanner.ScanOptions {
+	return scanner.ScanOptions{
+		IncludeImportUsages:      a.IncludeImportUsages || b.IncludeImportUsages,
+		IncludeExportedAPI:       a.IncludeExportedAPI || b.IncludeExportedAPI,
+		IncludeDefinitions:       a.IncludeDefinitions || b.IncludeDefinitions,
+		IncludeAPIReferences:     a.IncludeAPIReferences || b.IncludeAPIReferences,
+		IncludeSignatureRefs:     a.IncludeSignatureRefs || b.IncludeSignatureRefs,
+		IncludeStability:         a.IncludeStability || b.IncludeStability,
+		IncludePackageDoc:        a.IncludePackageDoc || b.IncludePackageDoc,
+		IncludeDeprecations:      a.IncludeDeprecations || b.IncludeDeprecations,
+		IncludeSymbolRefs:        a.IncludeSymbolRefs || b.IncludeSymbolRefs,
+		IncludeFileReads:         a.IncludeFileReads || b.IncludeFileReads,
+		IncludeSymbolUses:        a.IncludeSymbolUses || b.IncludeSymbolUses,
+		IncludeFuncDecls:         a.IncludeFuncDecls || b.IncludeFuncDecls,
+		IncludeDynamicRefs:       a.IncludeDynamicRefs || b.IncludeDynamicRefs,
+		RegisterFuncs:            mergeNames(a.RegisterFuncs, b.RegisterFuncs),
+		LookupFuncs:              mergeNames(a.LookupFuncs, b.LookupFuncs),
+		IncludeInitRegistrations: a.IncludeInitRegistrations || b.IncludeInitRegistrations,
+		IncludeConstructions:     a.IncludeConstructions || b.IncludeConstructions,
+		IncludeExamples:          a.IncludeExamples || b.IncludeExamples,
+		IncludeSuppressions:      a.IncludeSuppressions || b.IncludeSuppressions,
+	}
+}
+
+// mergeNames appends the names of b missing from a
+func mergeNames(a, b []string) []string {
+	result := append([]string(nil), a...)
+	for _, name := range b {
+		found := false
+		for _, existing := range result {
+			found = found || existing == name
+		}
+		if !found {
+			result = append(result, name)
+		}
+	}
+	return result
+}
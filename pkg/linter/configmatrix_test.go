@@ -0,0 +1,102 @@
+package linter_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestConfigMatrix(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module github.com/test/project\n\ngo 1.21\n",
+		"strict/rules.yaml": `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: []
+    internal: []
+`,
+		"loose/rules.yaml": `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: [internal]
+    internal: []
+`,
+		"other.yaml": `module: github.com/test/project
+scan_paths:
+  - pkg
+rules:
+  directories_import:
+    pkg: [internal]
+`,
+		"cmd/app/main.go":     "package main\n\nimport \"github.com/test/project/pkg/api\"\n\nfunc main() { api.Serve() }\n",
+		"pkg/api/api.go":      "package api\n\nimport \"github.com/test/project/internal/store\"\n\nfunc Serve() { store.Save() }\n",
+		"internal/store/s.go": "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	configs := []string{
+		filepath.Join(tmpDir, "loose/rules.yaml"),
+		filepath.Join(tmpDir, "strict/rules.yaml"),
+		filepath.Join(tmpDir, "other.yaml"),
+	}
+	result, err := linter.ConfigMatrix(tmpDir, linter.ConfigMatrixOptions{Configs: configs, Format: "json"})
+	if err != nil {
+		t.Fatalf("ConfigMatrix failed: %v", err)
+	}
+
+	var matrix output.ConfigMatrix
+	if err := json.Unmarshal([]byte(result), &matrix); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, result)
+	}
+
+	if len(matrix.Configs) != 3 {
+		t.Fatalf("expected 3 configs, got %+v", matrix.Configs)
+	}
+	// The two rules.yaml files share a name, so they are labelled with their paths
+	if matrix.Configs[0].Name != configs[0] || matrix.Configs[2].Name != "other.yaml" {
+		t.Errorf("unexpected config names: %+v", matrix.Configs)
+	}
+	if matrix.Configs[0].Fails || matrix.Configs[0].Errors != 0 {
+		t.Errorf("expected the loose config to pass, got %+v", matrix.Configs[0])
+	}
+	if !matrix.Configs[1].Fails || matrix.Configs[1].Errors != 1 {
+		t.Errorf("expected the strict config to fail with one error, got %+v", matrix.Configs[1])
+	}
+
+	var forbidden *output.MatrixViolation
+	for i, viol := range matrix.Violations {
+		if viol.Type == "Forbidden Import" {
+			forbidden = &matrix.Violations[i]
+		}
+	}
+	if forbidden == nil {
+		t.Fatalf("expected a forbidden import violation, got %+v", matrix.Violations)
+	}
+	if forbidden.File != "pkg/api/api.go" || strings.Join(forbidden.Severities, ",") != ",error," {
+		t.Errorf("expected only the strict config to report the import, got %+v", forbidden)
+	}
+
+	if len(matrix.Notes) != 1 || !strings.Contains(matrix.Notes[0], "other.yaml scans the project differently") {
+		t.Errorf("expected a note about the scan settings of other.yaml, got %v", matrix.Notes)
+	}
+}
+
+func TestConfigMatrix_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if _, err := linter.ConfigMatrix(tmpDir, linter.ConfigMatrixOptions{Format: "markdown"}); err == nil {
+		t.Error("expected an error without configs")
+	}
+	if _, err := linter.ConfigMatrix(tmpDir, linter.ConfigMatrixOptions{Configs: []string{"missing.yaml"}, Format: "markdown"}); err == nil {
+		t.Error("expected an error for a missing config file")
+	}
+	if _, err := linter.ConfigMatrix(tmpDir, linter.ConfigMatrixOptions{Configs: []string{"a.yaml"}, Format: "html"}); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
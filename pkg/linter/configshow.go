@@ -0,0 +1,37 @@
+package linter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+)
+
+// ShowConfig returns the .goarchlint of the project as written, or with effective set,
+// the fully merged configuration with the source of each value as a YAML comment.
+// profile selects a named profile to merge into the effective configuration.
+func ShowConfig(projectPath string, effective bool, profile string) (string, error) {
+	if profile != "" && !effective {
+		return "", fmt.Errorf("--profile requires --effective")
+	}
+	if effective {
+		cfg, err := config.Load(projectPath)
+		if err != nil {
+			return "", err
+		}
+		if err := cfg.SelectProfile(profile); err != nil {
+			return "", err
+		}
+		return cfg.Effective()
+	}
+
+	data, err := os.ReadFile(filepath.Join(projectPath, ".goarchlint"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("no .goarchlint in %s (use --effective to see the defaults)", projectPath)
+		}
+		return "", fmt.Errorf("reading config file: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,103 @@
+package linter_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestShowConfig_Effective(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+preset:
+  name: custom
+  rules:
+    directories_import:
+      cmd: [pkg]
+overrides:
+  rules:
+    directories_import:
+      pkg: [internal]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := linter.ShowConfig(tmpDir, false, "")
+	if err != nil {
+		t.Fatalf("ShowConfig failed: %v", err)
+	}
+	if raw != configYAML {
+		t.Errorf("expected config file as written, got:\n%s", raw)
+	}
+
+	effective, err := linter.ShowConfig(tmpDir, true, "")
+	if err != nil {
+		t.Fatalf("ShowConfig --effective failed: %v", err)
+	}
+	for _, expected := range []string{
+		"module: github.com/test/project # .goarchlint\n",
+		"scan_paths: [cmd, pkg, internal] # defaults\n",
+		"    cmd: [pkg] # preset custom\n",
+		"    pkg: [internal] # overrides\n",
+	} {
+		if !strings.Contains(effective, expected) {
+			t.Errorf("expected %q in effective config, got:\n%s", expected, effective)
+		}
+	}
+}
+
+func TestShowConfig_NoConfigFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module github.com/test/project\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := linter.ShowConfig(tmpDir, false, ""); err == nil || !strings.Contains(err.Error(), "--effective") {
+		t.Errorf("expected error pointing to --effective, got %v", err)
+	}
+
+	effective, err := linter.ShowConfig(tmpDir, true, "")
+	if err != nil {
+		t.Fatalf("ShowConfig --effective failed: %v", err)
+	}
+	if !strings.Contains(effective, "module: github.com/test/project # go.mod\n") || !strings.Contains(effective, "internal: [internal] # defaults\n") {
+		t.Errorf("expected defaults in effective config, got:\n%s", effective)
+	}
+}
+
+func TestShowConfig_EffectiveProfile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+preset:
+  name: custom
+  rules:
+    directories_import:
+      cmd: [pkg, internal]
+profiles:
+  strict:
+    rules:
+      directories_import:
+        cmd: [pkg]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	effective, err := linter.ShowConfig(tmpDir, true, "strict")
+	if err != nil {
+		t.Fatalf("ShowConfig --effective --profile failed: %v", err)
+	}
+	if !strings.Contains(effective, "    cmd: [pkg] # profile strict\n") {
+		t.Errorf("expected profile value in effective config, got:\n%s", effective)
+	}
+
+	if _, err := linter.ShowConfig(tmpDir, false, "strict"); err == nil || !strings.Contains(err.Error(), "--effective") {
+		t.Errorf("expected error requiring --effective, got %v", err)
+	}
+}
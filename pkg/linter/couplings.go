@@ -0,0 +1,63 @@
+package linter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+// maxCouplings is the number of couplings listed in the strongest couplings report
+const maxCouplings = 10
+
+// strongestCouplings returns the heaviest dependencies between packages of different
+// layers, by call sites, then used symbols and importing files. A package belongs to the
+// layer of the most specific directories_import key containing it.
+func strongestCouplings(cfg *config.Config, g *graph.Graph) []output.Coupling {
+	var couplings []output.Coupling
+	for _, coupling := range g.Couplings() {
+		fromLayer := layerOf(coupling.From, cfg.GetDirectoriesImport())
+		toLayer := layerOf(coupling.To, cfg.GetDirectoriesImport())
+		if fromLayer == toLayer {
+			continue
+		}
+		couplings = append(couplings, output.Coupling{
+			From:      coupling.From,
+			FromLayer: fromLayer,
+			To:        coupling.To,
+			ToLayer:   toLayer,
+			Files:     coupling.Files,
+			Symbols:   len(coupling.Symbols),
+			CallSites: coupling.CallSites,
+		})
+	}
+
+	// Couplings come sorted by package, so ties keep that order
+	sort.SliceStable(couplings, func(i, j int) bool {
+		if couplings[i].CallSites != couplings[j].CallSites {
+			return couplings[i].CallSites > couplings[j].CallSites
+		}
+		if couplings[i].Symbols != couplings[j].Symbols {
+			return couplings[i].Symbols > couplings[j].Symbols
+		}
+		return couplings[i].Files > couplings[j].Files
+	})
+	if len(couplings) > maxCouplings {
+		couplings = couplings[:maxCouplings]
+	}
+	return couplings
+}
+
+// layerOf returns the most specific directories_import key containing a package, or an
+// empty string for packages outside every layer
+func layerOf(pkgPath string, rules map[string][]string) string {
+	best := ""
+	for key := range rules {
+		if (pkgPath == key || strings.HasPrefix(pkgPath, key+"/")) && len(key) > len(best) {
+			best = key
+		}
+	}
+	return best
+}
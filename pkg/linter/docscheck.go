@@ -0,0 +1,110 @@
+package linter
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/githistory"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/internal/scanner"
+)
+
+// docsDate matches generation dates, which change daily without the architecture changing
+var docsDate = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`)
+
+// CheckDocs regenerates the architecture index and compares it with the file at docsPath
+// (relative to the project unless absolute), ignoring generation dates. If they differ,
+// the exported API of the commit that last changed the file is compared with the working
+// tree, so the report lists which signatures changed, were added or were removed instead
+// of only saying the documentation is out of date. Returns the report and whether the
+// documentation is stale.
+func CheckDocs(projectPath, docsPath string) (string, bool, error) {
+	generated, _, _, err := Run(projectPath, "index", false, false, "")
+	if err != nil {
+		return "", false, err
+	}
+
+	fullPath := docsPath
+	if !filepath.IsAbs(fullPath) {
+		fullPath = filepath.Join(projectPath, docsPath)
+	}
+
+	check := output.DocsCheck{Path: docsPath}
+	existing, err := os.ReadFile(fullPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		check.Stale = true
+		check.Missing = true
+	case err != nil:
+		return "", false, err
+	default:
+		check.Stale = docsDate.ReplaceAllString(string(existing), "") != docsDate.ReplaceAllString(generated, "")
+	}
+
+	if check.Stale && !check.Missing {
+		check.Commit, check.Changes, err = apiChangesSince(projectPath, fullPath)
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	return output.FormatDocsCheck(check), check.Stale, nil
+}
+
+// apiChangesSince returns the commit that last changed the documentation file and the
+// exported API changes between that commit and the working tree. Outside a git
+// repository, or for documentation that was never committed, the commit is empty.
+func apiChangesSince(projectPath, docsFile string) (string, []output.SignatureChange, error) {
+	relPath, err := filepath.Rel(projectPath, docsFile)
+	if err != nil {
+		return "", nil, nil
+	}
+
+	repo := githistory.New(projectPath)
+	commit, err := repo.LastCommit(relPath)
+	if err != nil || commit == "" {
+		return "", nil, nil
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	files, err := repo.Files(commit)
+	if err != nil {
+		return "", nil, err
+	}
+
+	before, err := scanExportedAPI(projectPath, cfg, files)
+	if err != nil {
+		return "", nil, err
+	}
+	after, err := scanExportedAPI(projectPath, cfg, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return commit, output.DiffExportedAPI(before, after), nil
+}
+
+// scanExportedAPI scans the exported declarations of a project with the current
+// configuration, reading the project from fsys or from disk if fsys is nil
+func scanExportedAPI(projectPath string, cfg *config.Config, fsys fs.FS) ([]output.FileWithAPI, error) {
+	s := newScanner(projectPath, cfg, false)
+	s.SetFS(fsys)
+	files, err := s.Scan(cfg.GetScanPaths(), scanner.ScanOptions{IncludeExportedAPI: true})
+	if err != nil {
+		return nil, err
+	}
+
+	outFiles := make([]output.FileWithAPI, len(files))
+	for i := range files {
+		outFiles[i] = &fileWithAPIAdapter{file: &files[i]}
+	}
+	return outFiles, nil
+}
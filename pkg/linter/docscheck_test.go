@@ -0,0 +1,91 @@
+package linter_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestCheckDocs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = tmpDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	write := func(relPath, content string) {
+		t.Helper()
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(".goarchlint", "module: github.com/test/project\nscan_paths:\n  - pkg\n")
+	write("pkg/service/service.go", "package service\n\nfunc Run() {}\n\nfunc Stop() {}\n")
+
+	// Without documentation the check fails
+	report, stale, err := linter.CheckDocs(tmpDir, "docs/arch-index.md")
+	if err != nil {
+		t.Fatalf("CheckDocs failed: %v", err)
+	}
+	if !stale || !strings.Contains(report, "Documentation is missing") {
+		t.Errorf("expected missing documentation, got stale=%v:\n%s", stale, report)
+	}
+
+	// Freshly committed documentation is up to date, even on another day
+	index, _, _, err := linter.Run(tmpDir, "index", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	write("docs/arch-index.md", strings.Replace(index, "Generated by go-arch-lint on 2", "Generated by go-arch-lint on 1", 1))
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "docs")
+
+	report, stale, err = linter.CheckDocs(tmpDir, "docs/arch-index.md")
+	if err != nil {
+		t.Fatalf("CheckDocs failed: %v", err)
+	}
+	if stale {
+		t.Errorf("expected up to date documentation, got:\n%s", report)
+	}
+
+	// Changing the exported API makes the documentation stale and is listed
+	write("pkg/service/service.go", "package service\n\nfunc Run(name string) {}\n\nfunc Start() {}\n")
+	write("pkg/store/store.go", "package store\n\nfunc Save() {}\n")
+
+	report, stale, err = linter.CheckDocs(tmpDir, "docs/arch-index.md")
+	if err != nil {
+		t.Fatalf("CheckDocs failed: %v", err)
+	}
+	if !stale {
+		t.Fatalf("expected stale documentation, got:\n%s", report)
+	}
+	expected := []string{
+		"Documentation is out of date: docs/arch-index.md",
+		"Changed:\n  ~ pkg/service.Run\n      - Run()\n      + Run(string)\n",
+		"  + pkg/service.Start: Start()\n",
+		"  + pkg/store.Save: Save()\n",
+		"Removed:\n  - pkg/service.Stop: Stop()\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, report)
+		}
+	}
+}
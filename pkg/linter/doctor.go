@@ -0,0 +1,218 @@
+package linter
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+)
+
+// doctorCheck is the outcome of one doctor check
+type doctorCheck struct {
+	name        string
+	ok          bool
+	optional    bool   // a failed optional check is a warning
+	detail      string // what was found
+	remediation string // how to fix a failed check
+}
+
+// Doctor checks what go-arch-lint needs to work on the project: the Go toolchain,
+// staticcheck, go.mod, the .goarchlint config, the Go build cache and write access to
+// the project and its docs. It returns a report with remediation steps for every failed
+// check, and whether a required check failed.
+func Doctor(projectPath string) (string, bool) {
+	cfg, configCheck := checkConfig(projectPath)
+	checks := []doctorCheck{
+		checkGoToolchain(),
+		checkStaticcheck(cfg),
+		checkGoMod(projectPath),
+		configCheck,
+		checkBuildCache(),
+		checkWritable("Project directory", projectPath, "init, refresh and config migrate write .goarchlint there"),
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "docs")); err == nil {
+		checks = append(checks, checkWritable("Docs directory", filepath.Join(projectPath, "docs"), "docs are generated there"))
+	}
+
+	var b strings.Builder
+	failed, warnings := 0, 0
+	for _, c := range checks {
+		switch {
+		case c.ok:
+			fmt.Fprintf(&b, "✓ %s: %s\n", c.name, c.detail)
+		case c.optional:
+			warnings++
+			fmt.Fprintf(&b, "⚠ %s: %s\n", c.name, c.detail)
+		default:
+			failed++
+			fmt.Fprintf(&b, "✗ %s: %s\n", c.name, c.detail)
+		}
+		if !c.ok && c.remediation != "" {
+			fmt.Fprintf(&b, "    → %s\n", c.remediation)
+		}
+	}
+
+	b.WriteString("\n")
+	switch {
+	case failed > 0:
+		fmt.Fprintf(&b, "%d problem(s) found, %d warning(s)\n", failed, warnings)
+	case warnings > 0:
+		fmt.Fprintf(&b, "No problems found, %d warning(s)\n", warnings)
+	default:
+		b.WriteString("All checks passed\n")
+	}
+	return b.String(), failed > 0
+}
+
+// checkGoToolchain checks that go is on PATH; coverage and type-based rules run it
+func checkGoToolchain() doctorCheck {
+	c := doctorCheck{name: "Go toolchain"}
+	out, err := exec.Command("go", "version").Output()
+	if err != nil {
+		c.detail = "go not found in PATH"
+		c.remediation = "Install Go from https://go.dev/dl/ and add its bin directory to PATH"
+		return c
+	}
+	c.ok = true
+	c.detail = strings.TrimSpace(string(out))
+	return c
+}
+
+// checkStaticcheck checks that staticcheck is on PATH, which is only required when the
+// config enables it
+func checkStaticcheck(cfg *config.Config) doctorCheck {
+	c := doctorCheck{name: "staticcheck", optional: cfg == nil || !cfg.ShouldRunStaticcheck()}
+	path, err := exec.LookPath("staticcheck")
+	if err != nil {
+		c.detail = "not found in PATH"
+		if c.optional {
+			c.detail += " (only needed for -staticcheck)"
+		} else {
+			c.detail += " but .goarchlint enables it"
+		}
+		c.remediation = "go install honnef.co/go/tools/cmd/staticcheck@latest"
+		return c
+	}
+	c.ok = true
+	c.detail = path
+	return c
+}
+
+// checkGoMod checks that go.mod exists and declares the module
+func checkGoMod(projectPath string) doctorCheck {
+	c := doctorCheck{name: "go.mod"}
+	module, err := detectModuleFromGoMod(projectPath)
+	if err != nil {
+		c.detail = err.Error()
+		c.remediation = "Run go mod init <module-path> in the project root, or set module in .goarchlint"
+		return c
+	}
+	c.ok = true
+	c.detail = "module " + module
+	return c
+}
+
+// checkConfig loads .goarchlint and checks that its scan paths exist. A missing file is
+// a warning since the defaults apply. The config is returned when it loads.
+func checkConfig(projectPath string) (*config.Config, doctorCheck) {
+	c := doctorCheck{name: "Config"}
+	if _, err := os.Stat(filepath.Join(projectPath, ".goarchlint")); os.IsNotExist(err) {
+		c.optional = true
+		c.detail = "no .goarchlint, the default rules apply"
+		c.remediation = "Run go-arch-lint init to choose a preset"
+		cfg, _ := config.Load(projectPath)
+		return cfg, c
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		c.detail = err.Error()
+		c.remediation = "Fix .goarchlint, then check the result with go-arch-lint config show --effective"
+		return nil, c
+	}
+
+	if warnings := cfg.GetWarnings(); len(warnings) > 0 {
+		c.optional = true
+		c.detail = strings.Join(warnings, "; ")
+		return cfg, c
+	}
+
+	var missing []string
+	for _, scanPath := range cfg.GetScanPaths() {
+		if _, err := os.Stat(filepath.Join(projectPath, scanPath)); os.IsNotExist(err) {
+			missing = append(missing, scanPath)
+		}
+	}
+	if len(missing) > 0 {
+		c.optional = true
+		c.detail = fmt.Sprintf(".goarchlint is valid, but scan paths do not exist: %s", strings.Join(missing, ", "))
+		c.remediation = "Create the directories or remove them from scan_paths in .goarchlint"
+		return cfg, c
+	}
+
+	c.ok = true
+	c.detail = ".goarchlint is valid"
+	if preset := cfg.GetPresetUsed(); preset != "" {
+		c.detail += fmt.Sprintf(" (preset %s)", preset)
+	}
+	return cfg, c
+}
+
+// checkBuildCache checks that the Go build cache is enabled and writable; coverage runs
+// go test, which needs it
+func checkBuildCache() doctorCheck {
+	c := doctorCheck{name: "Go build cache"}
+	out, err := exec.Command("go", "env", "GOCACHE").Output()
+	if err != nil {
+		c.detail = "cannot run go env GOCACHE"
+		c.remediation = "Install Go and make sure go env works"
+		return c
+	}
+
+	cacheDir := strings.TrimSpace(string(out))
+	if cacheDir == "" || cacheDir == "off" {
+		c.detail = "the build cache is disabled (GOCACHE=off), go test cannot run for coverage"
+		c.remediation = "Unset GOCACHE or point it to a writable directory"
+		return c
+	}
+	// go creates the cache on first use, so does the probe
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		c.detail = fmt.Sprintf("cannot create %s: %v", cacheDir, err)
+		c.remediation = "Point GOCACHE to a writable directory"
+		return c
+	}
+	if err := probeWrite(cacheDir); err != nil {
+		c.detail = fmt.Sprintf("%s is not writable: %v", cacheDir, err)
+		c.remediation = "Fix the permissions of the directory, point GOCACHE to a writable directory, or run go clean -cache"
+		return c
+	}
+	c.ok = true
+	c.detail = cacheDir
+	return c
+}
+
+// checkWritable checks that files can be created in dir; purpose says why it matters
+func checkWritable(name, dir, purpose string) doctorCheck {
+	c := doctorCheck{name: name}
+	if err := probeWrite(dir); err != nil {
+		c.detail = fmt.Sprintf("%s is not writable (%s): %v", dir, purpose, err)
+		c.remediation = "Fix the directory permissions or run go-arch-lint as a user who can write there"
+		return c
+	}
+	c.ok = true
+	c.detail = dir + " is writable"
+	return c
+}
+
+// probeWrite creates and removes a temporary file in dir
+func probeWrite(dir string) error {
+	f, err := os.CreateTemp(dir, ".go-arch-lint-doctor-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
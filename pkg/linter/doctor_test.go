@@ -0,0 +1,67 @@
+package linter_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestDoctor(t *testing.T) {
+	tmpDir := writeProject(t, map[string]string{
+		"go.mod": "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint": `module: github.com/test/project
+scan_paths: [internal, pkg]
+rules:
+  directories_import:
+    internal: []
+`,
+	})
+	if err := os.MkdirAll(filepath.Join(tmpDir, "internal"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Whether a required check failed depends on the toolchain of the machine running the tests
+	report, _ := linter.Doctor(tmpDir)
+	for _, want := range []string{
+		"✓ go.mod: module github.com/test/project",
+		"⚠ Config: .goarchlint is valid, but scan paths do not exist: pkg",
+		"→ Create the directories or remove them from scan_paths in .goarchlint",
+		"✓ Project directory: " + tmpDir + " is writable",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "Docs directory") {
+		t.Errorf("expected no docs check without a docs directory, got:\n%s", report)
+	}
+	if strings.Contains(report, "✗ Config") || strings.Contains(report, "✗ go.mod") {
+		t.Errorf("expected config and go.mod checks to pass, got:\n%s", report)
+	}
+}
+
+func TestDoctor_InvalidConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte("rules:\n  directories_imports: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, failed := linter.Doctor(tmpDir)
+	if !failed {
+		t.Error("expected doctor to fail")
+	}
+	for _, want := range []string{
+		"✗ go.mod:",
+		"→ Run go mod init <module-path>",
+		"✗ Config: parsing config file:",
+		"→ Fix .goarchlint, then check the result with go-arch-lint config show --effective",
+		"problem(s) found",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+}
@@ -0,0 +1,648 @@
+package linter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// presetExamples holds, per preset, minimal example files for the required directories:
+// a domain entity, an application service, an infrastructure adapter and a cmd main,
+// each with a blackbox test. Together they build and satisfy the preset's rules,
+// including its coverage thresholds. {{module}} is replaced with the module path.
+var presetExamples = map[string]map[string]string{
+	"ddd": {
+		"internal/domain/greeting.go": `// Package domain holds the entities, value objects and business rules of the project.
+// It imports nothing from the rest of the project.
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrEmptyName is returned when a greeting is requested for an empty name
+var ErrEmptyName = errors.New("name must not be empty")
+
+// Greeting is an example entity: a message addressed to someone
+type Greeting struct {
+	Name string
+	Text string
+}
+
+// NewGreeting creates a greeting for name, enforcing the business rules
+func NewGreeting(name string) (Greeting, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Greeting{}, ErrEmptyName
+	}
+	return Greeting{Name: name, Text: "Hello, " + name + "!"}, nil
+}
+
+// GreetingRepository stores greetings. The domain defines it; infrastructure implements it.
+type GreetingRepository interface {
+	Save(greeting Greeting) error
+}
+`,
+		"internal/domain/greeting_test.go": `package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"{{module}}/internal/domain"
+)
+
+func TestNewGreeting(t *testing.T) {
+	greeting, err := domain.NewGreeting(" Ada ")
+	if err != nil {
+		t.Fatalf("NewGreeting failed: %v", err)
+	}
+	if greeting.Name != "Ada" || greeting.Text != "Hello, Ada!" {
+		t.Errorf("unexpected greeting: %+v", greeting)
+	}
+
+	if _, err := domain.NewGreeting(" "); !errors.Is(err, domain.ErrEmptyName) {
+		t.Errorf("expected ErrEmptyName, got %v", err)
+	}
+}
+`,
+		"internal/app/greeter.go": `// Package app holds the application services (use cases) that orchestrate the domain.
+package app
+
+import "{{module}}/internal/domain"
+
+// Greeter is an example application service: it creates greetings and stores them
+type Greeter struct {
+	repo domain.GreetingRepository
+}
+
+// NewGreeter creates a Greeter storing greetings in repo
+func NewGreeter(repo domain.GreetingRepository) *Greeter {
+	return &Greeter{repo: repo}
+}
+
+// Greet creates and stores a greeting for name and returns its text
+func (g *Greeter) Greet(name string) (string, error) {
+	greeting, err := domain.NewGreeting(name)
+	if err != nil {
+		return "", err
+	}
+	if err := g.repo.Save(greeting); err != nil {
+		return "", err
+	}
+	return greeting.Text, nil
+}
+`,
+		"internal/app/greeter_test.go": `package app_test
+
+import (
+	"errors"
+	"testing"
+
+	"{{module}}/internal/app"
+	"{{module}}/internal/domain"
+)
+
+type fakeRepository struct {
+	saved []domain.Greeting
+	err   error
+}
+
+func (r *fakeRepository) Save(greeting domain.Greeting) error {
+	r.saved = append(r.saved, greeting)
+	return r.err
+}
+
+func TestGreeter_Greet(t *testing.T) {
+	repo := &fakeRepository{}
+	text, err := app.NewGreeter(repo).Greet("Ada")
+	if err != nil || text != "Hello, Ada!" || len(repo.saved) != 1 {
+		t.Errorf("unexpected result %q, %v (saved %d)", text, err, len(repo.saved))
+	}
+
+	if _, err := app.NewGreeter(repo).Greet(""); !errors.Is(err, domain.ErrEmptyName) {
+		t.Errorf("expected ErrEmptyName, got %v", err)
+	}
+
+	repo.err = errors.New("disk full")
+	if _, err := app.NewGreeter(repo).Greet("Ada"); !errors.Is(err, repo.err) {
+		t.Errorf("expected the repository error, got %v", err)
+	}
+}
+`,
+		"internal/infra/memory/repository.go": `// Package memory is an example infrastructure adapter keeping greetings in memory.
+package memory
+
+import "{{module}}/internal/domain"
+
+var _ domain.GreetingRepository = (*GreetingRepository)(nil)
+
+// GreetingRepository implements domain.GreetingRepository in memory
+type GreetingRepository struct {
+	greetings []domain.Greeting
+}
+
+// NewGreetingRepository creates an empty repository
+func NewGreetingRepository() *GreetingRepository {
+	return &GreetingRepository{}
+}
+
+// Save stores a greeting
+func (r *GreetingRepository) Save(greeting domain.Greeting) error {
+	r.greetings = append(r.greetings, greeting)
+	return nil
+}
+
+// All returns the stored greetings in the order they were saved
+func (r *GreetingRepository) All() []domain.Greeting {
+	return r.greetings
+}
+`,
+		"internal/infra/memory/repository_test.go": `package memory_test
+
+import (
+	"testing"
+
+	"{{module}}/internal/domain"
+	"{{module}}/internal/infra/memory"
+)
+
+func TestGreetingRepository(t *testing.T) {
+	repo := memory.NewGreetingRepository()
+	if err := repo.Save(domain.Greeting{Name: "Ada", Text: "Hello, Ada!"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if all := repo.All(); len(all) != 1 || all[0].Name != "Ada" {
+		t.Errorf("unexpected greetings: %+v", all)
+	}
+}
+`,
+		"cmd/app/main.go": `// Command app is an example entry point wiring the infrastructure into the application.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"{{module}}/internal/app"
+	"{{module}}/internal/infra/memory"
+)
+
+func main() {
+	if err := Run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Run greets the first argument, or the world
+func Run(args []string, out io.Writer) error {
+	name := "world"
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	greeter := app.NewGreeter(memory.NewGreetingRepository())
+	text, err := greeter.Greet(name)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, text)
+	return err
+}
+`,
+		"cmd/app/main_test.go": `package main_test
+
+import (
+	"bytes"
+	"testing"
+
+	main "{{module}}/cmd/app"
+)
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	if err := main.Run([]string{"Ada"}, &out); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if out.String() != "Hello, Ada!\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+
+	if err := main.Run([]string{""}, &out); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+}
+`,
+	},
+	"simple": {
+		"internal/greeting/greeting.go": `// Package greeting holds the private implementation behind the public API in pkg.
+package greeting
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrEmptyName is returned when a greeting is requested for an empty name
+var ErrEmptyName = errors.New("name must not be empty")
+
+// Text returns the greeting for name
+func Text(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrEmptyName
+	}
+	return "Hello, " + name + "!", nil
+}
+`,
+		"internal/greeting/greeting_test.go": `package greeting_test
+
+import (
+	"errors"
+	"testing"
+
+	"{{module}}/internal/greeting"
+)
+
+func TestText(t *testing.T) {
+	if text, err := greeting.Text(" Ada "); err != nil || text != "Hello, Ada!" {
+		t.Errorf("unexpected result %q, %v", text, err)
+	}
+	if _, err := greeting.Text(" "); !errors.Is(err, greeting.ErrEmptyName) {
+		t.Errorf("expected ErrEmptyName, got %v", err)
+	}
+}
+`,
+		"pkg/greeter/greeter.go": `// Package greeter is an example public API built on internal packages.
+package greeter
+
+import "{{module}}/internal/greeting"
+
+// Greet returns the greeting for name
+func Greet(name string) (string, error) {
+	return greeting.Text(name)
+}
+`,
+		"pkg/greeter/greeter_test.go": `package greeter_test
+
+import (
+	"testing"
+
+	"{{module}}/pkg/greeter"
+)
+
+func TestGreet(t *testing.T) {
+	if text, err := greeter.Greet("Ada"); err != nil || text != "Hello, Ada!" {
+		t.Errorf("unexpected result %q, %v", text, err)
+	}
+}
+`,
+		"cmd/app/main.go": `// Command app is an example entry point using the public API in pkg.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"{{module}}/pkg/greeter"
+)
+
+func main() {
+	if err := Run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Run greets the first argument, or the world
+func Run(args []string, out io.Writer) error {
+	name := "world"
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	text, err := greeter.Greet(name)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, text)
+	return err
+}
+`,
+		"cmd/app/main_test.go": `package main_test
+
+import (
+	"bytes"
+	"testing"
+
+	main "{{module}}/cmd/app"
+)
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	if err := main.Run([]string{"Ada"}, &out); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if out.String() != "Hello, Ada!\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+
+	if err := main.Run([]string{""}, &out); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+}
+`,
+	},
+	"hexagonal": {
+		"internal/core/greeting.go": `// Package core holds the business logic. It imports nothing from the rest of the project
+// and states what it needs from the outside world as its own interfaces.
+package core
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrEmptyName is returned when a greeting is requested for an empty name
+var ErrEmptyName = errors.New("name must not be empty")
+
+// Greeting is an example domain model: a message addressed to someone
+type Greeting struct {
+	Name string
+	Text string
+}
+
+// Store is what the core needs to keep greetings
+type Store interface {
+	Save(greeting Greeting) error
+}
+
+// Service creates greetings and stores them
+type Service struct {
+	store Store
+}
+
+// NewService creates a Service storing greetings in store
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// Greet creates and stores a greeting for name and returns its text
+func (s *Service) Greet(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrEmptyName
+	}
+	greeting := Greeting{Name: name, Text: "Hello, " + name + "!"}
+	if err := s.store.Save(greeting); err != nil {
+		return "", err
+	}
+	return greeting.Text, nil
+}
+`,
+		"internal/core/greeting_test.go": `package core_test
+
+import (
+	"errors"
+	"testing"
+
+	"{{module}}/internal/core"
+)
+
+type fakeStore struct {
+	saved []core.Greeting
+	err   error
+}
+
+func (s *fakeStore) Save(greeting core.Greeting) error {
+	s.saved = append(s.saved, greeting)
+	return s.err
+}
+
+func TestService_Greet(t *testing.T) {
+	store := &fakeStore{}
+	text, err := core.NewService(store).Greet(" Ada ")
+	if err != nil || text != "Hello, Ada!" || len(store.saved) != 1 {
+		t.Errorf("unexpected result %q, %v (saved %d)", text, err, len(store.saved))
+	}
+
+	if _, err := core.NewService(store).Greet(" "); !errors.Is(err, core.ErrEmptyName) {
+		t.Errorf("expected ErrEmptyName, got %v", err)
+	}
+
+	store.err = errors.New("disk full")
+	if _, err := core.NewService(store).Greet("Ada"); !errors.Is(err, store.err) {
+		t.Errorf("expected the store error, got %v", err)
+	}
+}
+`,
+		"internal/ports/greeting.go": `// Package ports defines the interfaces between the core and the outside world.
+package ports
+
+import "{{module}}/internal/core"
+
+// Greeter is the inbound port: what drivers (CLI, HTTP) can ask of the application
+type Greeter interface {
+	Greet(name string) (string, error)
+}
+
+// GreetingStore is the outbound port: where the application keeps greetings
+type GreetingStore interface {
+	Save(greeting core.Greeting) error
+}
+
+// NewGreeter returns the core implementation of the Greeter port, using store
+func NewGreeter(store GreetingStore) Greeter {
+	return core.NewService(store)
+}
+`,
+		"internal/ports/greeting_test.go": `package ports_test
+
+import (
+	"testing"
+
+	"{{module}}/internal/core"
+	"{{module}}/internal/ports"
+)
+
+type fakeStore struct{}
+
+func (fakeStore) Save(core.Greeting) error { return nil }
+
+func TestNewGreeter(t *testing.T) {
+	if text, err := ports.NewGreeter(fakeStore{}).Greet("Ada"); err != nil || text != "Hello, Ada!" {
+		t.Errorf("unexpected result %q, %v", text, err)
+	}
+}
+`,
+		"internal/adapters/memory/store.go": `// Package memory is an example outbound adapter keeping greetings in memory.
+package memory
+
+import (
+	"{{module}}/internal/core"
+	"{{module}}/internal/ports"
+)
+
+var _ ports.GreetingStore = (*Store)(nil)
+
+// Store implements ports.GreetingStore in memory
+type Store struct {
+	greetings []core.Greeting
+}
+
+// NewStore creates an empty store
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Save stores a greeting
+func (s *Store) Save(greeting core.Greeting) error {
+	s.greetings = append(s.greetings, greeting)
+	return nil
+}
+
+// All returns the stored greetings in the order they were saved
+func (s *Store) All() []core.Greeting {
+	return s.greetings
+}
+`,
+		"internal/adapters/memory/store_test.go": `package memory_test
+
+import (
+	"testing"
+
+	"{{module}}/internal/adapters/memory"
+	"{{module}}/internal/core"
+)
+
+func TestStore(t *testing.T) {
+	store := memory.NewStore()
+	if err := store.Save(core.Greeting{Name: "Ada", Text: "Hello, Ada!"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if all := store.All(); len(all) != 1 || all[0].Name != "Ada" {
+		t.Errorf("unexpected greetings: %+v", all)
+	}
+}
+`,
+		"cmd/app/main.go": `// Command app is an example entry point plugging the adapters into the ports.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"{{module}}/internal/adapters/memory"
+	"{{module}}/internal/ports"
+)
+
+func main() {
+	if err := Run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Run greets the first argument, or the world
+func Run(args []string, out io.Writer) error {
+	name := "world"
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	text, err := ports.NewGreeter(memory.NewStore()).Greet(name)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, text)
+	return err
+}
+`,
+		"cmd/app/main_test.go": `package main_test
+
+import (
+	"bytes"
+	"testing"
+
+	main "{{module}}/cmd/app"
+)
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	if err := main.Run([]string{"Ada"}, &out); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if out.String() != "Hello, Ada!\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+
+	if err := main.Run([]string{""}, &out); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+}
+`,
+	},
+}
+
+// writeExamples writes the example files of a preset into the project and returns their
+// paths, sorted. Existing files are left untouched.
+func writeExamples(projectPath, module, presetName string) ([]string, error) {
+	examples, ok := presetExamples[presetName]
+	if !ok {
+		return nil, fmt.Errorf("no examples for preset '%s'", presetName)
+	}
+
+	paths := make([]string, 0, len(examples))
+	for relPath := range examples {
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+
+	var written []string
+	for _, relPath := range paths {
+		fullPath := filepath.Join(projectPath, relPath)
+		if _, err := os.Stat(fullPath); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return written, fmt.Errorf("creating directory for %s: %w", relPath, err)
+		}
+		content := strings.ReplaceAll(examples[relPath], "{{module}}", module)
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			return written, fmt.Errorf("writing %s: %w", relPath, err)
+		}
+		written = append(written, relPath)
+	}
+	return written, nil
+}
+
+// initExamples writes the preset's example code during init, printing what was created.
+// Examples need a preset and go.mod (for the module path); without them it only explains why.
+func initExamples(projectPath, preset string) error {
+	if _, ok := presetExamples[preset]; !ok {
+		fmt.Println("ℹ Examples are only available for the ddd, simple and hexagonal presets - skipping examples")
+		return nil
+	}
+
+	module, err := detectModuleFromGoMod(projectPath)
+	if err != nil {
+		fmt.Printf("ℹ %v - skipping examples (run go mod init first)\n", err)
+		return nil
+	}
+
+	written, err := writeExamples(projectPath, module, preset)
+	for _, relPath := range written {
+		fmt.Printf("✓ Created %s\n", relPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write examples: %w", err)
+	}
+	return nil
+}
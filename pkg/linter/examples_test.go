@@ -0,0 +1,59 @@
+package linter_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestInit_WithExamples(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	for _, preset := range []string{"ddd", "simple", "hexagonal"} {
+		t.Run(preset, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module github.com/test/project\n\ngo 1.21\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := linter.InitWithOptions(tmpDir, linter.InitOptions{Preset: preset, CreateDirs: true, WithExamples: true}); err != nil {
+				t.Fatalf("Init failed: %v", err)
+			}
+
+			if _, err := os.Stat(filepath.Join(tmpDir, "cmd", "app", "main.go")); err != nil {
+				t.Errorf("expected cmd/app/main.go to be created: %v", err)
+			}
+
+			cmd := exec.Command("go", "vet", "./...")
+			cmd.Dir = tmpDir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("examples do not build: %v\n%s", err, out)
+			}
+
+			_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "markdown", false, false, "")
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if shouldFail || violationsOutput != "" {
+				t.Errorf("expected the examples to lint cleanly, got:\n%s", violationsOutput)
+			}
+		})
+	}
+}
+
+func TestInit_WithExamples_CustomPreset(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := linter.InitWithOptions(tmpDir, linter.InitOptions{Preset: "custom", WithExamples: true}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "cmd")); !os.IsNotExist(err) {
+		t.Error("expected no examples for the custom preset")
+	}
+}
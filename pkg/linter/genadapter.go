@@ -0,0 +1,247 @@
+package linter
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
+)
+
+// GenAdapterOptions configures the generation of adapters between two packages
+type GenAdapterOptions struct {
+	From   string // Directory of the consumer package declaring the interfaces, relative to the project root
+	To     string // Directory of the package the adapters delegate to
+	Out    string // Directory of the adapter package (default: "<dir of From>/<base of To>adapter")
+	DryRun bool   // Print the generated files instead of writing them
+}
+
+// GenAdapter scaffolds an adapter package bridging a consumer and a target package, the
+// refactoring the presets suggest: the consumer defines interfaces, the adapter
+// implements them by delegating to the target, and neither imports the other. Each
+// exported interface of the consumer that the target serves gets an adapter struct
+// wrapping the target type serving most of its methods, with a constructor and a
+// compile-time assertion. Slices of assignable elements are converted, and methods the
+// target has nothing for are left as TODOs that panic. Existing files are never
+// overwritten.
+func GenAdapter(projectPath string, opts GenAdapterOptions) (string, error) {
+	if opts.From == "" || opts.To == "" {
+		return "", fmt.Errorf("-from and -to are required")
+	}
+	from, to := path.Clean(filepath.ToSlash(opts.From)), path.Clean(filepath.ToSlash(opts.To))
+	if from == to {
+		return "", fmt.Errorf("-from and -to must be different packages")
+	}
+	out := path.Join(path.Dir(from), path.Base(to)+"adapter")
+	if opts.Out != "" {
+		out = path.Clean(filepath.ToSlash(opts.Out))
+	}
+	if out == from || out == to {
+		return "", fmt.Errorf("the adapters must be declared outside %s and %s", from, to)
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	spec, err := typecheck.New(projectPath, cfg.Module).AdapterSpec(from, to, out)
+	if err != nil {
+		return "", err
+	}
+	if len(spec.Adapters) == 0 {
+		var b strings.Builder
+		fmt.Fprintf(&b, "no interface of %s can delegate to %s", from, to)
+		if len(spec.Skipped) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(spec.Skipped, "; "))
+		}
+		return "", fmt.Errorf("%s", b.String())
+	}
+
+	adapterPackage, err := packageNameIn(filepath.Join(projectPath, filepath.FromSlash(out)))
+	if err != nil {
+		return "", err
+	}
+
+	files := make(map[string]string)
+	var paths []string
+	for _, adapter := range spec.Adapters {
+		file := path.Join(out, strings.ToLower(adapter.Interface)+".go")
+		formatted, err := format.Source([]byte(adapterSource(adapterPackage, spec, adapter)))
+		if err != nil {
+			return "", fmt.Errorf("formatting %s: %w", file, err)
+		}
+		if _, err := os.Stat(filepath.Join(projectPath, filepath.FromSlash(file))); err == nil {
+			return "", fmt.Errorf("%s already exists", file)
+		}
+		files[file] = string(formatted)
+		paths = append(paths, file)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Adapters in %s implementing %s interfaces with %s:\n", out, spec.Consumer, spec.Target)
+	for _, adapter := range spec.Adapters {
+		delegate := spec.Target + " functions"
+		if adapter.Delegate != "" {
+			delegate = spec.Target + "." + adapter.Delegate
+		}
+		var todo []string
+		for _, method := range adapter.Methods {
+			if method.Via == "" {
+				todo = append(todo, method.Name)
+			}
+		}
+		fmt.Fprintf(&b, "  %s → %s (%d of %d methods delegated", adapter.Interface, delegate,
+			len(adapter.Methods)-len(todo), len(adapter.Methods))
+		if len(todo) > 0 {
+			fmt.Fprintf(&b, "; TODO: %s", strings.Join(todo, ", "))
+		}
+		b.WriteString(")\n")
+	}
+	if len(spec.Skipped) > 0 {
+		b.WriteString("Skipped:\n")
+		for _, skipped := range spec.Skipped {
+			fmt.Fprintf(&b, "  %s\n", skipped)
+		}
+	}
+
+	for _, file := range paths {
+		if opts.DryRun {
+			fmt.Fprintf(&b, "\n// %s\n%s", file, files[file])
+			continue
+		}
+		fullPath := filepath.Join(projectPath, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(fullPath, []byte(files[file]), 0644); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "Wrote %s\n", file)
+	}
+	return b.String(), nil
+}
+
+// adapterSource renders the file declaring the adapter of one consumer interface
+func adapterSource(adapterPackage string, spec typecheck.AdapterSpec, adapter typecheck.Adapter) string {
+	// Only the imports this file refers to
+	source := adapterMethods(spec, adapter)
+	imports := make(map[string]string)
+	for importPath, name := range spec.Imports {
+		if refersTo(source, name) {
+			imports[importPath] = name
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n\n", adapterPackage)
+	b.WriteString(importBlock(imports))
+	b.WriteString(source)
+	return b.String()
+}
+
+// adapterMethods renders the declarations of an adapter: the struct, its constructor,
+// the compile-time assertion and the delegating methods
+func adapterMethods(spec typecheck.AdapterSpec, adapter typecheck.Adapter) string {
+	var b strings.Builder
+	name := adapter.Interface
+	iface := spec.Consumer + "." + name
+
+	if adapter.Delegate != "" {
+		delegate := spec.Target + "." + adapter.Delegate
+		fmt.Fprintf(&b, "// %s adapts %s to %s\n", name, delegate, iface)
+		fmt.Fprintf(&b, "type %s struct {\n\ttarget *%s\n}\n\n", name, delegate)
+		fmt.Fprintf(&b, "// New%s returns an adapter delegating to target\n", name)
+		fmt.Fprintf(&b, "func New%s(target *%s) *%s {\n\treturn &%s{target: target}\n}\n\n", name, delegate, name, name)
+	} else {
+		fmt.Fprintf(&b, "// %s adapts the functions of %s to %s\n", name, spec.Target, iface)
+		fmt.Fprintf(&b, "type %s struct{}\n\n", name)
+		fmt.Fprintf(&b, "// New%s returns an adapter delegating to %s\n", name, spec.Target)
+		fmt.Fprintf(&b, "func New%s() *%s {\n\treturn &%s{}\n}\n\n", name, name, name)
+	}
+	fmt.Fprintf(&b, "// Compile-time check that %s implements %s\n", name, iface)
+	fmt.Fprintf(&b, "var _ %s = (*%s)(nil)\n", iface, name)
+
+	for _, method := range adapter.Methods {
+		receiver := "a"
+		for _, arg := range method.Args {
+			if strings.TrimSuffix(arg, "...") == receiver {
+				receiver = "adapter"
+			}
+		}
+
+		fmt.Fprintf(&b, "\nfunc (%s *%s) %s%s %s {\n", receiver, name, method.Name, method.Params, method.Results)
+		switch method.Via {
+		case "":
+			fmt.Fprintf(&b, "\t// TODO: %s has no method or function %s with a compatible signature\n", spec.Target, method.Name)
+			fmt.Fprintf(&b, "\tpanic(%q)\n", "not implemented: "+name+"."+method.Name)
+		default:
+			call := fmt.Sprintf("%s.%s(%s)", spec.Target, method.Name, strings.Join(method.Args, ", "))
+			if method.Via == typecheck.DelegateMethod {
+				call = fmt.Sprintf("%s.target.%s(%s)", receiver, method.Name, strings.Join(method.Args, ", "))
+			}
+			writeDelegation(&b, call, method.Converts)
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// writeDelegation renders the body of a delegating method, converting the slices whose
+// element type differs from the interface's one element at a time
+func writeDelegation(b *strings.Builder, call string, converts []string) {
+	if len(converts) == 0 {
+		fmt.Fprintf(b, "\t%s\n", call)
+		return
+	}
+	converted := false
+	for _, elem := range converts {
+		converted = converted || elem != ""
+	}
+	if !converted {
+		fmt.Fprintf(b, "\treturn %s\n", call)
+		return
+	}
+
+	results := make([]string, len(converts))
+	returned := make([]string, len(converts))
+	for i := range converts {
+		results[i] = fmt.Sprintf("r%d", i)
+		returned[i] = results[i]
+	}
+	fmt.Fprintf(b, "\t%s := %s\n", strings.Join(results, ", "), call)
+	for i, elem := range converts {
+		if elem == "" {
+			continue
+		}
+		returned[i] = fmt.Sprintf("result%d", i)
+		fmt.Fprintf(b, "\t%s := make([]%s, len(%s))\n", returned[i], elem, results[i])
+		fmt.Fprintf(b, "\tfor i := range %s {\n\t\t%s[i] = %s[i]\n\t}\n", results[i], returned[i], results[i])
+	}
+	fmt.Fprintf(b, "\treturn %s\n", strings.Join(returned, ", "))
+}
+
+// refersTo reports whether Go source qualifies an identifier with the package name
+func refersTo(source, name string) bool {
+	for i := strings.Index(source, name+"."); i >= 0; {
+		if i == 0 || !isIdentChar(source[i-1]) {
+			return true
+		}
+		next := strings.Index(source[i+1:], name+".")
+		if next < 0 {
+			return false
+		}
+		i += next + 1
+	}
+	return false
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
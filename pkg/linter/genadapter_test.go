@@ -0,0 +1,155 @@
+package linter_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func writeGenAdapterProject(t *testing.T) string {
+	t.Helper()
+	files := map[string]string{
+		"go.mod": "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint": `module: github.com/test/project
+scan_paths:
+  - pkg
+  - internal
+`,
+		"pkg/app/app.go": `package app
+
+type Invoice interface{ Total() int }
+
+type InvoiceStore interface {
+	Find(id int) (Invoice, error)
+	List() ([]Invoice, error)
+	Refund(id int) error
+}
+
+type App struct{ Store InvoiceStore }
+`,
+		"internal/billing/billing.go": `package billing
+
+type Invoice struct{ Amount int }
+
+func (i *Invoice) Total() int { return i.Amount }
+
+type Service struct{}
+
+func (s *Service) Find(id int) (*Invoice, error) { return &Invoice{}, nil }
+func (s *Service) List() ([]*Invoice, error)     { return nil, nil }
+`,
+	}
+	return writeProject(t, files)
+}
+
+func TestGenAdapter(t *testing.T) {
+	tmpDir := writeGenAdapterProject(t)
+
+	result, err := linter.GenAdapter(tmpDir, linter.GenAdapterOptions{From: "pkg/app", To: "internal/billing"})
+	if err != nil {
+		t.Fatalf("GenAdapter failed: %v", err)
+	}
+	for _, want := range []string{
+		"Adapters in pkg/billingadapter implementing app interfaces with billing:",
+		"  InvoiceStore → billing.Service (2 of 3 methods delegated; TODO: Refund)",
+		"  Invoice: already implemented by billing.Invoice, pass it directly",
+		"Wrote pkg/billingadapter/invoicestore.go",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+
+	adapter, err := os.ReadFile(filepath.Join(tmpDir, "pkg", "billingadapter", "invoicestore.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAdapter := `package billingadapter
+
+import (
+	"github.com/test/project/internal/billing"
+	"github.com/test/project/pkg/app"
+)
+
+// InvoiceStore adapts billing.Service to app.InvoiceStore
+type InvoiceStore struct {
+	target *billing.Service
+}
+
+// NewInvoiceStore returns an adapter delegating to target
+func NewInvoiceStore(target *billing.Service) *InvoiceStore {
+	return &InvoiceStore{target: target}
+}
+
+// Compile-time check that InvoiceStore implements app.InvoiceStore
+var _ app.InvoiceStore = (*InvoiceStore)(nil)
+
+func (a *InvoiceStore) Find(id int) (app.Invoice, error) {
+	return a.target.Find(id)
+}
+
+func (a *InvoiceStore) List() ([]app.Invoice, error) {
+	r0, r1 := a.target.List()
+	result0 := make([]app.Invoice, len(r0))
+	for i := range r0 {
+		result0[i] = r0[i]
+	}
+	return result0, r1
+}
+
+func (a *InvoiceStore) Refund(id int) error {
+	// TODO: billing has no method or function Refund with a compatible signature
+	panic("not implemented: InvoiceStore.Refund")
+}
+`
+	if string(adapter) != wantAdapter {
+		t.Errorf("unexpected adapter file:\n%s\nexpected:\n%s", adapter, wantAdapter)
+	}
+
+	if _, err := exec.LookPath("go"); err == nil {
+		cmd := exec.Command("go", "build", "./...")
+		cmd.Dir = tmpDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("generated code does not build: %v\n%s", err, output)
+		}
+	}
+
+	// Existing files are never overwritten
+	if _, err := linter.GenAdapter(tmpDir, linter.GenAdapterOptions{From: "pkg/app", To: "internal/billing"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected an error for existing files, got %v", err)
+	}
+}
+
+func TestGenAdapter_DryRunAndErrors(t *testing.T) {
+	tmpDir := writeGenAdapterProject(t)
+
+	result, err := linter.GenAdapter(tmpDir, linter.GenAdapterOptions{From: "pkg/app", To: "internal/billing", Out: "pkg/adapters/billing", DryRun: true})
+	if err != nil {
+		t.Fatalf("GenAdapter failed: %v", err)
+	}
+	if !strings.Contains(result, "// pkg/adapters/billing/invoicestore.go\npackage billing\n") {
+		t.Errorf("expected the generated file, got:\n%s", result)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "pkg", "adapters")); !os.IsNotExist(err) {
+		t.Errorf("expected nothing to be written in dry-run mode, got %v", err)
+	}
+
+	tests := []struct {
+		opts    linter.GenAdapterOptions
+		wantErr string
+	}{
+		{linter.GenAdapterOptions{From: "pkg/app"}, "-from and -to are required"},
+		{linter.GenAdapterOptions{From: "pkg/app", To: "pkg/app"}, "must be different"},
+		{linter.GenAdapterOptions{From: "pkg/app", To: "internal/billing", Out: "pkg/app"}, "outside"},
+		{linter.GenAdapterOptions{From: "internal/billing", To: "pkg/app"}, "no interface of internal/billing can delegate to pkg/app"},
+	}
+	for _, tt := range tests {
+		if _, err := linter.GenAdapter(tmpDir, tt.opts); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%+v: expected error containing %q, got %v", tt.opts, tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package linter
+
+import (
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
+)
+
+// GenPortOptions configures the generation of a port interface
+type GenPortOptions struct {
+	Into   string // Directory of the package to declare the interface in, relative to the project root
+	Name   string // Interface name (default: the name of the type)
+	DryRun bool   // Print the generated files instead of writing them
+}
+
+// GenPort generates an interface covering the methods of a concrete type that other
+// packages call, for inverting a dependency on it. target names the type as
+// "<package dir>.<Type>", e.g. "internal/infra/postgres.UserRepo". The interface is
+// written to opts.Into, and a compile-time assertion that the type implements it to the
+// package of the type, or to the interface file if the interface needs to import that
+// package anyway. Existing files are never overwritten.
+func GenPort(projectPath, target string, opts GenPortOptions) (string, error) {
+	dot := strings.LastIndex(target, ".")
+	if dot <= strings.LastIndex(target, "/") || dot == len(target)-1 {
+		return "", fmt.Errorf("invalid type %q (expected <package dir>.<Type>, e.g. internal/infra/postgres.UserRepo)", target)
+	}
+	dir, typeName := path.Clean(filepath.ToSlash(target[:dot])), target[dot+1:]
+	if opts.Into == "" {
+		return "", fmt.Errorf("-into is required")
+	}
+	into := path.Clean(filepath.ToSlash(opts.Into))
+	if into == dir {
+		return "", fmt.Errorf("the port must be declared outside %s", dir)
+	}
+	name := opts.Name
+	if name == "" {
+		name = typeName
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", err
+	}
+	_, _, g, err := scanProject(projectPath, cfg, false, false)
+	if err != nil {
+		return "", err
+	}
+
+	spec, err := typecheck.New(projectPath, cfg.Module).PortSpec(dir, typeName, into, localPackageDirs(g))
+	if err != nil {
+		return "", err
+	}
+	if len(spec.Methods) == 0 {
+		return "", fmt.Errorf("no other package calls methods of %s.%s", dir, typeName)
+	}
+
+	portPackage, err := packageNameIn(filepath.Join(projectPath, filepath.FromSlash(into)))
+	if err != nil {
+		return "", err
+	}
+	typeImport := cfg.Module + "/" + dir
+	portImport := cfg.Module + "/" + into
+
+	// The assertion goes next to the type, which then imports the port. If the port
+	// package imports the package of the type, directly or not, or will through the
+	// signatures, that would be an import cycle.
+	_, efferent := packageCoupling(g)
+	_, portImportsType := spec.Imports[typeImport]
+	portImportsType = portImportsType || dependsOn(efferent, into, dir)
+	if portImportsType {
+		spec.Imports[typeImport] = spec.Package
+	}
+	portFile := path.Join(into, strings.ToLower(name)+".go")
+	assertionFile := path.Join(dir, strings.ToLower(typeName)+"_port.go")
+	files := map[string]string{
+		portFile: portSource(portPackage, name, dir, typeName, spec, portImportsType),
+	}
+	if !portImportsType {
+		files[assertionFile] = assertionSource(spec.Package, name, typeName, portPackage, portImport)
+	}
+
+	paths := make([]string, 0, len(files))
+	for file, content := range files {
+		formatted, err := format.Source([]byte(content))
+		if err != nil {
+			return "", fmt.Errorf("formatting %s: %w", file, err)
+		}
+		files[file] = string(formatted)
+		if _, err := os.Stat(filepath.Join(projectPath, filepath.FromSlash(file))); err == nil {
+			return "", fmt.Errorf("%s already exists", file)
+		}
+		paths = append(paths, file)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Port %s.%s for %s.%s with %d methods:\n", portPackage, name, spec.Package, typeName, len(spec.Methods))
+	for _, method := range spec.Methods {
+		fmt.Fprintf(&b, "  %s (called from %s)\n", method.Name, strings.Join(method.Callers, ", "))
+	}
+	if portImportsType {
+		fmt.Fprintf(&b, "Note: %s depends on %s, so the compile-time check is in the port file. Move the types the port uses out of %s to complete the inversion.\n", into, dir, dir)
+	}
+
+	for _, file := range paths {
+		if opts.DryRun {
+			fmt.Fprintf(&b, "\n// %s\n%s", file, files[file])
+			continue
+		}
+		fullPath := filepath.Join(projectPath, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(fullPath, []byte(files[file]), 0644); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "Wrote %s\n", file)
+	}
+	return b.String(), nil
+}
+
+// portSource renders the file declaring the port interface. With assert, it also holds
+// the compile-time assertion that the type implements the interface.
+func portSource(portPackage, name, dir, typeName string, spec typecheck.PortSpec, assert bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n\n", portPackage)
+	b.WriteString(importBlock(spec.Imports))
+
+	fmt.Fprintf(&b, "// %s is the port of %s.%s: the methods other packages call, so that\n", name, spec.Package, typeName)
+	b.WriteString("// they can depend on this interface instead of the concrete type.\n")
+	fmt.Fprintf(&b, "type %s interface {\n", name)
+	for _, method := range spec.Methods {
+		fmt.Fprintf(&b, "\t%s%s\n", method.Name, method.Signature)
+	}
+	b.WriteString("}\n")
+
+	if assert {
+		fmt.Fprintf(&b, "\n// Compile-time check that %s.%s implements %s\n", spec.Package, typeName, name)
+		fmt.Fprintf(&b, "var _ %s = (*%s.%s)(nil)\n", name, spec.Package, typeName)
+	}
+	return b.String()
+}
+
+// assertionSource renders the file asserting at compile time that a type implements
+// its port
+func assertionSource(typePackage, name, typeName, portPackage, portImport string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n\n", typePackage)
+	fmt.Fprintf(&b, "import %q\n\n", portImport)
+	fmt.Fprintf(&b, "// Compile-time check that %s implements %s.%s\n", typeName, portPackage, name)
+	fmt.Fprintf(&b, "var _ %s.%s = (*%s)(nil)\n", portPackage, name, typeName)
+	return b.String()
+}
+
+// importBlock renders the imports of a generated file, standard library packages first.
+// Imports named differently from the last element of their path get an explicit name.
+func importBlock(imports map[string]string) string {
+	if len(imports) == 0 {
+		return ""
+	}
+
+	var std, other []string
+	for importPath, name := range imports {
+		spec := fmt.Sprintf("%q", importPath)
+		if name != path.Base(importPath) {
+			spec = name + " " + spec
+		}
+		if graph.IsStdLib(importPath) {
+			std = append(std, spec)
+		} else {
+			other = append(other, spec)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	if len(imports) == 1 {
+		return fmt.Sprintf("import %s\n\n", strings.Join(append(std, other...), ""))
+	}
+
+	var b strings.Builder
+	b.WriteString("import (\n")
+	for _, spec := range std {
+		fmt.Fprintf(&b, "\t%s\n", spec)
+	}
+	if len(std) > 0 && len(other) > 0 {
+		b.WriteString("\n")
+	}
+	for _, spec := range other {
+		fmt.Fprintf(&b, "\t%s\n", spec)
+	}
+	b.WriteString(")\n\n")
+	return b.String()
+}
+
+// packageNameIn returns the package name of the Go files in dir, or the last element of
+// dir if it has none yet
+func packageNameIn(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, entry.Name()), nil, parser.PackageClauseOnly)
+		if err != nil {
+			return "", err
+		}
+		return file.Name.Name, nil
+	}
+	return strings.NewReplacer("-", "", ".", "").Replace(filepath.Base(dir)), nil
+}
+
+// dependsOn reports whether the package from imports the package to, directly or through
+// other local packages
+func dependsOn(efferent map[string]map[string]bool, from, to string) bool {
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		for dep := range efferent[pkg] {
+			if dep == to {
+				return true
+			}
+			if !visited[dep] {
+				visited[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return false
+}
+
+// localPackageDirs returns the sorted directories of the scanned non-test packages
+func localPackageDirs(g *graph.Graph) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, node := range g.Nodes {
+		dir := path.Dir(node.RelPath)
+		if !node.IsTest && !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
@@ -0,0 +1,159 @@
+package linter_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func writeGenPortProject(t *testing.T) string {
+	t.Helper()
+	files := map[string]string{
+		"go.mod": "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal/app: [internal/domain, internal/infra]
+    internal/domain: []
+    internal/infra: [internal/domain]
+scan_paths:
+  - internal
+`,
+		"internal/domain/user.go": "package domain\n\ntype User struct{ Name string }\n",
+		"internal/infra/postgres/repo.go": `package postgres
+
+import (
+	"context"
+
+	"github.com/test/project/internal/domain"
+)
+
+type UserRepo struct{}
+
+func (r *UserRepo) Find(ctx context.Context, id int) (*domain.User, error) { return nil, nil }
+func (r *UserRepo) Save(u domain.User) error                              { return nil }
+func (r *UserRepo) Migrate() error                                         { return nil }
+`,
+		"internal/app/service.go": `package app
+
+import (
+	"context"
+
+	"github.com/test/project/internal/infra/postgres"
+)
+
+type Service struct{ repo *postgres.UserRepo }
+
+func (s *Service) Rename(ctx context.Context, id int, name string) error {
+	u, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+	u.Name = name
+	return s.repo.Save(*u)
+}
+`,
+	}
+	return writeProject(t, files)
+}
+
+func TestGenPort(t *testing.T) {
+	tmpDir := writeGenPortProject(t)
+
+	result, err := linter.GenPort(tmpDir, "internal/infra/postgres.UserRepo", linter.GenPortOptions{Into: "internal/domain"})
+	if err != nil {
+		t.Fatalf("GenPort failed: %v", err)
+	}
+	for _, want := range []string{
+		"Port domain.UserRepo for postgres.UserRepo with 2 methods:",
+		"  Find (called from internal/app)",
+		"Wrote internal/domain/userrepo.go",
+		"Wrote internal/infra/postgres/userrepo_port.go",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+
+	port, err := os.ReadFile(filepath.Join(tmpDir, "internal", "domain", "userrepo.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Migrate is not called from another package
+	wantPort := `package domain
+
+import "context"
+
+// UserRepo is the port of postgres.UserRepo: the methods other packages call, so that
+// they can depend on this interface instead of the concrete type.
+type UserRepo interface {
+	Find(ctx context.Context, id int) (*User, error)
+	Save(u User) error
+}
+`
+	if string(port) != wantPort {
+		t.Errorf("unexpected port file:\n%s\nexpected:\n%s", port, wantPort)
+	}
+
+	assertion, err := os.ReadFile(filepath.Join(tmpDir, "internal", "infra", "postgres", "userrepo_port.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(assertion), "var _ domain.UserRepo = (*UserRepo)(nil)\n") {
+		t.Errorf("expected a compile-time assertion, got:\n%s", assertion)
+	}
+
+	if _, err := exec.LookPath("go"); err == nil {
+		cmd := exec.Command("go", "build", "./...")
+		cmd.Dir = tmpDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("generated code does not build: %v\n%s", err, output)
+		}
+	}
+
+	// Existing files are never overwritten
+	if _, err := linter.GenPort(tmpDir, "internal/infra/postgres.UserRepo", linter.GenPortOptions{Into: "internal/domain"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected an error for existing files, got %v", err)
+	}
+}
+
+func TestGenPort_DryRunAndCycle(t *testing.T) {
+	tmpDir := writeGenPortProject(t)
+
+	// A port in internal/app refers to domain types through their package, and the
+	// assertion can stay in the port file since app already imports postgres
+	result, err := linter.GenPort(tmpDir, "internal/infra/postgres.UserRepo", linter.GenPortOptions{Into: "internal/app", Name: "Users", DryRun: true})
+	if err != nil {
+		t.Fatalf("GenPort failed: %v", err)
+	}
+	for _, want := range []string{
+		"// internal/app/users.go\npackage app\n",
+		"Find(ctx context.Context, id int) (*domain.User, error)",
+		"\t\"github.com/test/project/internal/infra/postgres\"\n",
+		"var _ Users = (*postgres.UserRepo)(nil)",
+		"Note: internal/app depends on internal/infra/postgres",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "userrepo_port.go") {
+		t.Errorf("expected no assertion file next to the type, got:\n%s", result)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "internal", "app", "users.go")); !os.IsNotExist(err) {
+		t.Errorf("expected nothing to be written in dry-run mode, got %v", err)
+	}
+
+	for _, target := range []string{"internal/infra/postgres", "internal/infra/postgres.Missing"} {
+		if _, err := linter.GenPort(tmpDir, target, linter.GenPortOptions{Into: "internal/domain"}); err == nil {
+			t.Errorf("expected an error for %q", target)
+		}
+	}
+	if _, err := linter.GenPort(tmpDir, "internal/infra/postgres.UserRepo", linter.GenPortOptions{}); err == nil {
+		t.Error("expected an error without -into")
+	}
+}
@@ -0,0 +1,111 @@
+package linter
+
+import (
+	"io/fs"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// goModDirective is a require or replace directive of go.mod, from a single line or a
+// block, with the line comment stripped
+type goModDirective struct {
+	line   int
+	fields []string // Fields after the verb
+}
+
+// readGoModDirectives reads the directives with the given verb from the go.mod of the
+// project files. A missing or unreadable go.mod yields no directives.
+func readGoModDirectives(project fs.FS, verb string) []goModDirective {
+	data, err := fs.ReadFile(project, "go.mod")
+	if err != nil {
+		return nil
+	}
+
+	var directives []goModDirective
+	inBlock := false
+	for i, line := range strings.Split(string(data), "\n") {
+		if comment := strings.Index(line, "//"); comment >= 0 {
+			line = line[:comment]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case fields[0] == verb && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+			continue
+		case fields[0] == verb:
+			fields = fields[1:]
+		case !inBlock:
+			continue
+		}
+		directives = append(directives, goModDirective{line: i + 1, fields: fields})
+	}
+	return directives
+}
+
+// goModRequirements reads the required modules and their versions from the go.mod of the
+// project files, both from single-line requires and require blocks
+func goModRequirements(project fs.FS) map[string]string {
+	requirements := make(map[string]string)
+	for _, directive := range readGoModDirectives(project, "require") {
+		if len(directive.fields) >= 2 {
+			requirements[directive.fields[0]] = directive.fields[1]
+		}
+	}
+	return requirements
+}
+
+// replaceDirective is a replace directive of go.mod
+type replaceDirective struct {
+	module      string
+	replacement string
+	line        int
+}
+
+// Methods for adapter pattern (structural typing - no imports needed)
+func (r replaceDirective) GetModule() string {
+	return r.module
+}
+
+func (r replaceDirective) GetReplacement() string {
+	return r.replacement
+}
+
+func (r replaceDirective) GetLine() int {
+	return r.line
+}
+
+// goModReplaces reads the replace directives ("old [version] => new [version]") from
+// the go.mod of the project files
+func goModReplaces(project fs.FS) []replaceDirective {
+	var replaces []replaceDirective
+	for _, directive := range readGoModDirectives(project, "replace") {
+		for i, field := range directive.fields {
+			if field == "=>" && i > 0 && i+1 < len(directive.fields) {
+				replaces = append(replaces, replaceDirective{module: directive.fields[0], replacement: directive.fields[i+1], line: directive.line})
+				break
+			}
+		}
+	}
+	return replaces
+}
+
+// applyReplaceDirectiveMode downgrades replace directive violations to warnings outside
+// CI: replacing a module with a local checkout is fine while developing, but must not ship.
+func applyReplaceDirectiveMode(violations []validator.Violation, ci bool) {
+	if ci {
+		return
+	}
+	for i := range violations {
+		if violations[i].Type != validator.ViolationReplaceDirective || !violations[i].IsError() {
+			continue
+		}
+		violations[i].Severity = validator.SeverityWarning
+		violations[i].AppendRule(validator.NewMessage("violation.replace_directive.outside_ci", " (fails the build with -ci or when CI is set)"))
+	}
+}
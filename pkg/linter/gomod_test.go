@@ -0,0 +1,76 @@
+package linter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestRunWithOptions_ReplaceDirectives(t *testing.T) {
+	files := map[string]string{
+		"go.mod": `module github.com/test/project
+
+go 1.22
+
+require github.com/test/shared v1.0.0
+
+replace github.com/test/shared => ../shared // local checkout
+
+replace (
+	golang.org/x/net v0.20.0 => golang.org/x/net v0.21.0
+	github.com/lib/pq v1.10.9 => github.com/someone/pq v1.10.10
+	github.com/ourorg/auth => ./auth
+)
+`,
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    pkg: []
+  replace_directives:
+    enabled: true
+    allowed: [github.com/ourorg/*]
+scan_paths:
+  - pkg
+`,
+		"pkg/api/api.go": "package api\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violations, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	expected := []string{
+		"[WARNING] Local or Forked Module Replace\n  File: go.mod:7\n  Issue: go.mod replaces github.com/test/shared with the local directory ../shared",
+		"File: go.mod:11\n  Issue: go.mod replaces github.com/lib/pq with the fork github.com/someone/pq",
+		"(fails the build with -ci or when CI is set)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(violations, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, violations)
+		}
+	}
+	if strings.Count(violations, "Local or Forked Module Replace") != 2 {
+		t.Errorf("expected the version pin and the allowed module not to be reported, got:\n%s", violations)
+	}
+	if shouldFail {
+		t.Error("expected replace directives not to fail the build outside CI")
+	}
+
+	_, violations, shouldFail, err = linter.RunWithOptions(tmpDir, linter.RunOptions{CI: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !shouldFail || !strings.Contains(violations, "[ERROR] Local or Forked Module Replace") {
+		t.Errorf("expected replace directives to fail the build in CI, got shouldFail=%v:\n%s", shouldFail, violations)
+	}
+
+	_, _, shouldFail, err = linter.RunWithOptions(tmpDir, linter.RunOptions{CI: true, FailFast: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected fail-fast to stop at a replace directive in CI")
+	}
+}
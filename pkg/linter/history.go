@@ -0,0 +1,116 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/githistory"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+// HistoryOptions configures a historical analysis
+type HistoryOptions struct {
+	Since    string // First revision to analyze (tag, branch or commit); empty for the whole history
+	Interval string // "tag" (each release) or "commit" (each first-parent commit)
+	Format   string // "markdown", "json" or "csv"
+}
+
+// History analyzes past revisions of a project and returns a time series of violations
+// and metrics, showing architectural drift over releases. Every revision is checked
+// against the current .goarchlint rules, so changes in the numbers reflect changes in
+// the code. Revisions are read from the git repository in memory, without touching the
+// working tree. Coverage, type leak and deprecation checks are skipped since they need
+// to build or diff a revision.
+func History(projectPath string, opts HistoryOptions) (string, error) {
+	if !containsFormat(output.HistoryFormats, opts.Format) {
+		return "", fmt.Errorf("unsupported history format %q (supported: %s)", opts.Format, strings.Join(output.HistoryFormats, ", "))
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	repo := githistory.New(projectPath)
+	revisions, err := repo.Revisions(opts.Since, opts.Interval)
+	if err != nil {
+		return "", err
+	}
+
+	entries := make([]output.HistoryEntry, 0, len(revisions))
+	for _, rev := range revisions {
+		entries = append(entries, analyzeRevision(projectPath, repo, rev, cfg))
+	}
+
+	return output.FormatHistory(entries, opts.Format)
+}
+
+// analyzeRevision validates the project files of a revision, read in memory. Failures
+// are recorded in the entry so one broken revision does not stop the series.
+func analyzeRevision(projectPath string, repo *githistory.Repository, rev githistory.Revision, cfg *config.Config) output.HistoryEntry {
+	entry := output.HistoryEntry{Revision: rev.Name, Commit: rev.Commit, Date: rev.Date}
+
+	revisionFiles, err := repo.Files(rev.Commit)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+
+	s := newScanner(projectPath, cfg, false)
+	s.SetFS(revisionFiles)
+	files, g, err := scanWith(s, cfg, false)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+
+	violations := newValidator(projectPath, cfg, s, files, g).Validate()
+
+	entry.FileCount = len(g.Nodes)
+	entry.PackageCount, entry.LocalDependencyCount, entry.ExternalDependencyCount = graphMetrics(g)
+	entry.ViolationCount = len(violations)
+	entry.ViolationsByType = make(map[string]int)
+	for _, viol := range violations {
+		entry.ViolationsByType[viol.GetType()]++
+		if viol.IsError() {
+			entry.ErrorCount++
+		}
+	}
+	entry.Score = conformanceScore(g, violations, nil).Score
+
+	return entry
+}
+
+// graphMetrics counts packages, package-level local dependencies and distinct external
+// (non-stdlib) import paths
+func graphMetrics(g *graph.Graph) (int, int, int) {
+	_, efferent := packageCoupling(g)
+
+	localDeps := 0
+	for _, imports := range efferent {
+		localDeps += len(imports)
+	}
+
+	externalDeps := make(map[string]bool)
+	for _, node := range g.Nodes {
+		for _, dep := range node.Dependencies {
+			if !dep.IsLocal && !graph.IsStdLib(dep.ImportPath) {
+				externalDeps[dep.ImportPath] = true
+			}
+		}
+	}
+
+	return len(efferent), localDeps, len(externalDeps)
+}
+
+// containsFormat reports whether format is one of the supported formats
+func containsFormat(formats []string, format string) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package linter_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = tmpDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	write := func(relPath, content string) {
+		t.Helper()
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// v1.0.0 follows the rules
+	write("cmd/app/main.go", "package main\n\nimport \"github.com/test/project/pkg/service\"\n\nfunc main() { service.Run() }\n")
+	write("pkg/service/service.go", "package service\n\nfunc Run() {}\n")
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "v1")
+	git("tag", "v1.0.0")
+
+	// v2.0.0 adds an internal package imported by cmd, which is forbidden
+	write("internal/store/store.go", "package store\n\nfunc Save() {}\n")
+	write("cmd/app/main.go", "package main\n\nimport (\n\t\"github.com/test/project/internal/store\"\n\t\"github.com/test/project/pkg/service\"\n)\n\nfunc main() { service.Run(); store.Save() }\n")
+	git("add", "-A")
+	git("commit", "-q", "-m", "v2")
+	git("tag", "v2.0.0")
+
+	// Current rules, not committed: history is checked against today's config
+	write(".goarchlint", `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: [internal]
+    internal: []
+scan_paths:
+  - cmd
+  - pkg
+  - internal
+`)
+
+	result, err := linter.History(tmpDir, linter.HistoryOptions{Interval: "tag", Format: "json"})
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+
+	var entries []struct {
+		Revision         string         `json:"revision"`
+		Error            string         `json:"error"`
+		Files            int            `json:"files"`
+		Packages         int            `json:"packages"`
+		LocalDeps        int            `json:"local_dependencies"`
+		Violations       int            `json:"violations"`
+		Errors           int            `json:"errors"`
+		ViolationsByType map[string]int `json:"violations_by_type"`
+	}
+	if err := json.Unmarshal([]byte(result), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 revisions (HEAD is tagged), got:\n%s", result)
+	}
+
+	v1, v2 := entries[0], entries[1]
+	if v1.Revision != "v1.0.0" || v1.Error != "" || v1.Files != 2 || v1.Packages != 2 || v1.LocalDeps != 1 || v1.Violations != 0 {
+		t.Errorf("unexpected v1.0.0 entry: %+v", v1)
+	}
+	if v2.Revision != "v2.0.0" || v2.Files != 3 || v2.Packages != 3 || v2.LocalDeps != 2 || v2.Errors == 0 {
+		t.Errorf("unexpected v2.0.0 entry: %+v", v2)
+	}
+	if v2.ViolationsByType["Forbidden Import"] == 0 && v2.ViolationsByType["Skip-level Import"] == 0 {
+		t.Errorf("expected cmd -> internal violation in v2.0.0, got: %v", v2.ViolationsByType)
+	}
+
+	// The working tree is not modified
+	if _, err := os.Stat(filepath.Join(tmpDir, "internal", "store", "store.go")); err != nil {
+		t.Errorf("expected working tree to be untouched: %v", err)
+	}
+
+	markdown, err := linter.History(tmpDir, linter.HistoryOptions{Since: "v2.0.0", Interval: "commit", Format: "markdown"})
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if !strings.Contains(markdown, "# Architecture History") || strings.Count(markdown, "| 20") != 1 {
+		t.Errorf("expected a single revision since v2.0.0, got:\n%s", markdown)
+	}
+
+	if _, err := linter.History(tmpDir, linter.HistoryOptions{Interval: "tag", Format: "xml"}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+	if _, err := linter.History(tmpDir, linter.HistoryOptions{Interval: "month", Format: "json"}); err == nil {
+		t.Error("expected error for unsupported interval")
+	}
+}
@@ -0,0 +1,143 @@
+package linter
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/githistory"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// HotspotOptions configures a hotspot analysis
+type HotspotOptions struct {
+	Since  string // Only count commits since this date (e.g. "2024-01-01" or "6 months ago"); empty for the whole history
+	Top    int    // Maximum number of packages to report; 0 for all
+	Format string // "markdown" or "json"
+}
+
+// Hotspots ranks packages by risk, combining git churn with coupling and violations of
+// the current tree, to show where refactoring investment pays off. Packages without
+// commits in the analyzed period are not reported.
+func Hotspots(projectPath string, opts HotspotOptions) (string, error) {
+	if !containsFormat(output.HotspotFormats, opts.Format) {
+		return "", fmt.Errorf("unsupported hotspots format %q (supported: %s)", opts.Format, strings.Join(output.HotspotFormats, ", "))
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	churn, err := githistory.New(projectPath).Churn(opts.Since)
+	if err != nil {
+		return "", err
+	}
+
+	s, files, g, err := scanProject(projectPath, cfg, false, false)
+	if err != nil {
+		return "", err
+	}
+	violations := newValidator(projectPath, cfg, s, files, g).Validate()
+
+	entries := rankHotspots(g, churn, violations)
+	if opts.Top > 0 && len(entries) > opts.Top {
+		entries = entries[:opts.Top]
+	}
+
+	return output.FormatHotspots(entries, opts.Since, opts.Format)
+}
+
+// rankHotspots aggregates churn, coupling and violations per package and sorts by score
+func rankHotspots(g *graph.Graph, churn map[string]githistory.FileChurn, violations []validator.Violation) []output.HotspotEntry {
+	afferent, efferent := packageCoupling(g)
+	lines := make(map[string]int)
+	for _, node := range g.Nodes {
+		lines[path.Dir(node.RelPath)] += node.LineCount
+	}
+
+	commits := make(map[string]map[string]bool) // package -> distinct commit hashes
+	linesChanged := make(map[string]int)
+	for relPath, fileChurn := range churn {
+		if !strings.HasSuffix(relPath, ".go") {
+			continue
+		}
+		dir := path.Dir(relPath)
+		if _, ok := efferent[dir]; !ok {
+			// Not a scanned package (or no longer exists)
+			continue
+		}
+		if commits[dir] == nil {
+			commits[dir] = make(map[string]bool)
+		}
+		for _, hash := range fileChurn.Commits {
+			commits[dir][hash] = true
+		}
+		linesChanged[dir] += fileChurn.LinesChanged
+	}
+
+	violationCounts := make(map[string]int)
+	for _, viol := range violations {
+		// Violations are reported on files or, for structure rules, on directories
+		dir := viol.File
+		if strings.HasSuffix(dir, ".go") {
+			dir = path.Dir(dir)
+		}
+		violationCounts[dir]++
+	}
+
+	entries := make([]output.HotspotEntry, 0, len(commits))
+	for pkg, hashes := range commits {
+		entry := output.HotspotEntry{
+			Package:      pkg,
+			Lines:        lines[pkg],
+			Commits:      len(hashes),
+			LinesChanged: linesChanged[pkg],
+			Afferent:     len(afferent[pkg]),
+			Efferent:     len(efferent[pkg]),
+			Violations:   violationCounts[pkg],
+		}
+		entry.Score = entry.Commits * (entry.Afferent + entry.Efferent + 1) * (entry.Violations + 1)
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].Package < entries[j].Package
+	})
+
+	return entries
+}
+
+// packageCoupling returns, for every scanned package directory, the local packages
+// importing it (afferent) and the local packages it imports (efferent)
+func packageCoupling(g *graph.Graph) (map[string]map[string]bool, map[string]map[string]bool) {
+	afferent := make(map[string]map[string]bool)
+	efferent := make(map[string]map[string]bool)
+
+	for _, node := range g.Nodes {
+		dir := path.Dir(node.RelPath)
+		if efferent[dir] == nil {
+			efferent[dir] = make(map[string]bool)
+		}
+
+		for _, dep := range node.Dependencies {
+			if !dep.IsLocal || dep.LocalPath == dir {
+				continue
+			}
+			efferent[dir][dep.LocalPath] = true
+			if afferent[dep.LocalPath] == nil {
+				afferent[dep.LocalPath] = make(map[string]bool)
+			}
+			afferent[dep.LocalPath][dir] = true
+		}
+	}
+
+	return afferent, efferent
+}
@@ -0,0 +1,128 @@
+package linter_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestHotspots(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = tmpDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	write := func(relPath, content string) {
+		t.Helper()
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(".goarchlint", `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: [internal]
+    internal: []
+scan_paths:
+  - cmd
+  - pkg
+  - internal
+`)
+	write("cmd/app/main.go", "package main\n\nimport \"github.com/test/project/pkg/service\"\n\nfunc main() { service.Run() }\n")
+	write("pkg/service/service.go", "package service\n\nimport \"github.com/test/project/internal/store\"\n\nfunc Run() { store.Save() }\n")
+	write("internal/store/store.go", "package store\n\nfunc Save() {}\n")
+	write("internal/report/report.go", "package report\n\nfunc Print() {}\n")
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "initial")
+
+	// Change pkg/service three times, in two files of one commit once
+	for i, body := range []string{"store.Save(); store.Save()", "store.Save()", "_ = 1; store.Save()"} {
+		write("pkg/service/service.go", "package service\n\nimport \"github.com/test/project/internal/store\"\n\nfunc Run() { "+body+" }\n")
+		if i == 2 {
+			write("pkg/service/helper.go", "package service\n\nfunc helper() {}\n")
+			git("add", "-A")
+		}
+		git("commit", "-q", "-am", "change service")
+	}
+
+	// Introduce a violation in the store package (internal imports internal)
+	write("internal/store/store.go", "package store\n\nimport \"github.com/test/project/internal/report\"\n\nfunc Save() { report.Print() }\n")
+	git("commit", "-q", "-am", "change store")
+
+	result, err := linter.Hotspots(tmpDir, linter.HotspotOptions{Format: "json"})
+	if err != nil {
+		t.Fatalf("Hotspots failed: %v", err)
+	}
+
+	var entries []struct {
+		Package    string `json:"package"`
+		Lines      int    `json:"lines"`
+		Commits    int    `json:"commits"`
+		Afferent   int    `json:"afferent"`
+		Efferent   int    `json:"efferent"`
+		Violations int    `json:"violations"`
+		Score      int    `json:"score"`
+	}
+	if err := json.Unmarshal([]byte(result), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+
+	byPackage := make(map[string]int)
+	for i, entry := range entries {
+		byPackage[entry.Package] = i
+	}
+
+	service := entries[byPackage["pkg/service"]]
+	if service.Commits != 4 || service.Afferent != 1 || service.Efferent != 1 || service.Violations != 0 || service.Score != 12 {
+		t.Errorf("unexpected pkg/service entry (distinct commits expected): %+v", service)
+	}
+	if service.Lines != 8 {
+		t.Errorf("expected the lines of both service files, got %d", service.Lines)
+	}
+
+	store := entries[byPackage["internal/store"]]
+	if store.Commits != 2 || store.Afferent != 1 || store.Efferent != 1 || store.Violations == 0 {
+		t.Errorf("unexpected internal/store entry: %+v", store)
+	}
+	if store.Score != store.Commits*3*(store.Violations+1) {
+		t.Errorf("unexpected score for internal/store: %+v", store)
+	}
+
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Score > entries[i-1].Score {
+			t.Errorf("expected entries sorted by score, got:\n%s", result)
+		}
+	}
+
+	top, err := linter.Hotspots(tmpDir, linter.HotspotOptions{Top: 1, Format: "markdown"})
+	if err != nil {
+		t.Fatalf("Hotspots failed: %v", err)
+	}
+	if !strings.Contains(top, "| 1 | "+entries[0].Package+" |") || strings.Contains(top, "\n| 2 | ") {
+		t.Errorf("expected only the top package, got:\n%s", top)
+	}
+
+	if _, err := linter.Hotspots(tmpDir, linter.HotspotOptions{Format: "csv"}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
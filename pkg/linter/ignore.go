@@ -0,0 +1,141 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kgatilin/go-arch-lint/internal/scanner"
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// ignoreWarningDays is how long before its expiry an inline suppression stops hiding the
+// violation and reports it as a warning instead
+const ignoreWarningDays = 30
+
+// inlineSuppression is an archlint:ignore directive of a scanned file
+type inlineSuppression struct {
+	scanner.Suppression
+	file  string    // File declaring the directive, relative to the project root
+	until time.Time // Parsed Until, zero if the directive does not expire
+}
+
+// location returns the file and line of the directive, e.g. "internal/app/app.go:12"
+func (s inlineSuppression) location() string {
+	return fmt.Sprintf("%s:%d", s.file, s.Line)
+}
+
+// covers reports whether the directive suppresses the rule with the given key at line of
+// file. The rule of the directive may be a parent key, so "rules.directories_import"
+// covers "rules.directories_import.cmd".
+func (s inlineSuppression) covers(file, ruleKey string, line int) bool {
+	if s.file != file || ruleKey == "" {
+		return false
+	}
+	if s.Rule != ruleKey && !strings.HasPrefix(ruleKey, s.Rule+".") {
+		return false
+	}
+	return s.WholeFile || line == s.Line || line == s.Line+1
+}
+
+// inlineSuppressions collects the archlint:ignore directives of the scanned files.
+// Directives without a rule or with an invalid until date are skipped with a warning.
+func inlineSuppressions(files []scanner.FileInfo) ([]inlineSuppression, []string) {
+	var suppressions []inlineSuppression
+	var warnings []string
+	for _, file := range files {
+		for _, directive := range file.Suppressions {
+			suppression := inlineSuppression{Suppression: directive, file: file.RelPath}
+			if directive.Rule == "" {
+				warnings = append(warnings, fmt.Sprintf("%s: archlint:ignore without a rule key (e.g. rules.directories_import) is ignored", suppression.location()))
+				continue
+			}
+			if directive.Until != "" {
+				until, err := time.Parse("2006-01-02", directive.Until)
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("%s: archlint:ignore with invalid date until=%s (expected YYYY-MM-DD) is ignored", suppression.location(), directive.Until))
+					continue
+				}
+				suppression.until = until
+			}
+			suppressions = append(suppressions, suppression)
+		}
+	}
+	return suppressions, warnings
+}
+
+// applyInlineSuppressions removes the violations covered by an archlint:ignore directive.
+// Within ignoreWarningDays of its until date the violation is reported as a warning, and
+// from that date on it fails the build again, so temporary exceptions cannot silently
+// become permanent.
+func applyInlineSuppressions(violations []validator.Violation, suppressions []inlineSuppression, now time.Time) []validator.Violation {
+	if len(suppressions) == 0 {
+		return violations
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	kept := make([]validator.Violation, 0, len(violations))
+	for _, viol := range violations {
+		suppression, ok := suppressionFor(viol, suppressions)
+		if !ok {
+			kept = append(kept, viol)
+			continue
+		}
+
+		if !suppression.until.IsZero() && !today.Before(suppression.until) {
+			viol.AppendRule(validator.NewMessage("violation.ignore_expired", " (archlint:ignore at %s expired on %s)", suppression.location(), suppression.Until))
+			kept = append(kept, viol)
+			continue
+		}
+		days := int(suppression.until.Sub(today).Hours() / 24)
+		if suppression.until.IsZero() || days > ignoreWarningDays {
+			continue
+		}
+		if viol.IsError() {
+			viol.Severity = validator.SeverityWarning
+		}
+		viol.AppendRule(validator.NewMessage("violation.ignore_expires", " (archlint:ignore at %s expires on %s, %s)", suppression.location(), suppression.Until, inDays(days)))
+		kept = append(kept, viol)
+	}
+	return kept
+}
+
+// suppressionFor returns the directive suppressing a violation. A violation collapsing
+// several locations is only suppressed if every location is covered, by the directive
+// that expires first.
+func suppressionFor(viol validator.Violation, suppressions []inlineSuppression) (inlineSuppression, bool) {
+	lines := []int{viol.Line}
+	if len(viol.Spans) > 0 {
+		lines = lines[:0]
+		for _, span := range viol.Spans {
+			lines = append(lines, span.Line)
+		}
+	}
+
+	var result inlineSuppression
+	for i, line := range lines {
+		// The longest lasting directive covering the line
+		var best inlineSuppression
+		found := false
+		for _, suppression := range suppressions {
+			if suppression.covers(viol.File, viol.RuleKey, line) && (!found || outlasts(suppression.until, best.until)) {
+				best, found = suppression, true
+			}
+		}
+		if !found {
+			return inlineSuppression{}, false
+		}
+		if i == 0 || outlasts(result.until, best.until) {
+			result = best
+		}
+	}
+	return result, true
+}
+
+// outlasts reports whether expiry date a is later than b, where a zero date never expires
+func outlasts(a, b time.Time) bool {
+	if b.IsZero() {
+		return false
+	}
+	return a.IsZero() || a.After(b)
+}
@@ -0,0 +1,108 @@
+package linter_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func writeIgnoreProject(t *testing.T, directive string) string {
+	t.Helper()
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: []
+    internal: []
+scan_paths:
+  - cmd
+  - internal
+`,
+		"cmd/app/main.go":         "package main\n\n" + directive + "\nimport \"github.com/test/project/internal/store\"\n\nfunc main() { store.Save() }\n",
+		"internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	return writeProject(t, files)
+}
+
+func TestRun_InlineSuppression(t *testing.T) {
+	day := func(offset int) string {
+		return time.Now().AddDate(0, 0, offset).Format("2006-01-02")
+	}
+
+	tests := []struct {
+		name       string
+		directive  string
+		wantReport []string // Empty if the violation is suppressed
+		wantFail   bool
+	}{
+		{
+			name:      "permanent",
+			directive: "//archlint:ignore rules.directories_import reason=Legacy wiring",
+		},
+		{
+			name:      "expires later",
+			directive: "//archlint:ignore rules.directories_import.cmd until=" + day(90) + " reason=Moving to pkg/app",
+		},
+		{
+			name:       "expires soon",
+			directive:  "//archlint:ignore rules.directories_import until=" + day(10),
+			wantReport: []string{"[WARNING] Forbidden Import", "(archlint:ignore at cmd/app/main.go:3 expires on " + day(10) + ", in 10 days)"},
+		},
+		{
+			name:       "expired",
+			directive:  "//archlint:ignore rules.directories_import until=" + day(-1),
+			wantReport: []string{"[ERROR] Forbidden Import", "(archlint:ignore at cmd/app/main.go:3 expired on " + day(-1) + ")"},
+			wantFail:   true,
+		},
+		{
+			name:       "other rule",
+			directive:  "//archlint:ignore rules.detect_unused",
+			wantReport: []string{"[ERROR] Forbidden Import"},
+			wantFail:   true,
+		},
+		{
+			name:       "invalid date",
+			directive:  "//archlint:ignore rules.directories_import until=31.12.2099",
+			wantReport: []string{"[ERROR] Forbidden Import"},
+			wantFail:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := writeIgnoreProject(t, tt.directive)
+
+			_, violations, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+
+			if len(tt.wantReport) == 0 && strings.Contains(violations, "Forbidden Import") {
+				t.Errorf("expected forbidden import to be suppressed, got:\n%s", violations)
+			}
+			for _, want := range tt.wantReport {
+				if !strings.Contains(violations, want) {
+					t.Errorf("expected %q in report, got:\n%s", want, violations)
+				}
+			}
+			if shouldFail != tt.wantFail {
+				t.Errorf("expected shouldFail=%v, got %v", tt.wantFail, shouldFail)
+			}
+		})
+	}
+}
+
+func TestRunWithOptions_FailFastHonorsInlineSuppression(t *testing.T) {
+	tmpDir := writeIgnoreProject(t, "//archlint:ignore rules.directories_import.cmd")
+
+	_, violations, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{FailFast: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if shouldFail || strings.Contains(violations, "Stopped at the first error") {
+		t.Errorf("expected suppressed violation not to stop the run, got:\n%s", violations)
+	}
+}
@@ -0,0 +1,86 @@
+package linter
+
+import (
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+)
+
+// dependencyInventory lists the external modules imported by the scanned files, with the
+// local packages and layers importing each. Imports are grouped by the longest module
+// required in go.mod that contains them; imports without one are listed by import path.
+func dependencyInventory(projectPath string, cfg *config.Config, g *graph.Graph) []output.InventoryModule {
+	requirements := goModRequirements(os.DirFS(projectPath))
+
+	// module -> importing package -> files and imported packages
+	type importerUse struct {
+		files   map[string]bool
+		imports map[string]bool
+	}
+	uses := make(map[string]map[string]*importerUse)
+	for _, node := range g.Nodes {
+		pkg := path.Dir(node.RelPath)
+		for _, dep := range node.Dependencies {
+			if dep.IsLocal || graph.IsStdLib(dep.ImportPath) {
+				continue
+			}
+			module := requiredModule(dep.ImportPath, requirements)
+			if uses[module] == nil {
+				uses[module] = make(map[string]*importerUse)
+			}
+			use := uses[module][pkg]
+			if use == nil {
+				use = &importerUse{files: make(map[string]bool), imports: make(map[string]bool)}
+				uses[module][pkg] = use
+			}
+			use.files[node.RelPath] = true
+			use.imports[dep.ImportPath] = true
+		}
+	}
+
+	modules := make([]output.InventoryModule, 0, len(uses))
+	for module, importers := range uses {
+		entry := output.InventoryModule{Path: module, Version: requirements[module]}
+		for pkg, use := range importers {
+			imports := make([]string, 0, len(use.imports))
+			for imp := range use.imports {
+				imports = append(imports, imp)
+			}
+			sort.Strings(imports)
+			entry.Importers = append(entry.Importers, output.InventoryImporter{
+				Package: pkg,
+				Layer:   layerOf(pkg, cfg.GetDirectoriesImport()),
+				Files:   len(use.files),
+				Imports: imports,
+			})
+		}
+		sort.Slice(entry.Importers, func(i, j int) bool {
+			return entry.Importers[i].Package < entry.Importers[j].Package
+		})
+		modules = append(modules, entry)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Path < modules[j].Path
+	})
+	return modules
+}
+
+// requiredModule returns the longest required module containing an import path, or the
+// import path itself
+func requiredModule(importPath string, requirements map[string]string) string {
+	best := ""
+	for module := range requirements {
+		if (importPath == module || strings.HasPrefix(importPath, module+"/")) && len(module) > len(best) {
+			best = module
+		}
+	}
+	if best == "" {
+		return importPath
+	}
+	return best
+}
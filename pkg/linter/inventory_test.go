@@ -0,0 +1,61 @@
+package linter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestRunWithOptions_InventoryFormat(t *testing.T) {
+	files := map[string]string{
+		"go.mod": `module github.com/test/project
+
+go 1.22
+
+require github.com/spf13/cobra v1.8.0
+
+require (
+	github.com/lib/pq v1.10.9 // indirect
+	golang.org/x/text v0.14.0
+)
+`,
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [internal]
+    internal: []
+scan_paths:
+  - cmd
+  - internal
+`,
+		"cmd/app/main.go":            "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/spf13/cobra\"\n\t\"github.com/lib/pq\"\n)\n\nvar _ = cobra.Command{}\nvar _ = pq.Error{}\n\nfunc main() { fmt.Println() }\n",
+		"internal/store/store.go":    "package store\n\nimport \"github.com/lib/pq\"\n\nvar _ = pq.Error{}\n",
+		"internal/store/hstore.go":   "package store\n\nimport \"github.com/lib/pq/hstore\"\n\nvar _ = hstore.Hstore{}\n",
+		"internal/text/collate.go":   "package text\n\nimport \"golang.org/x/text/collate\"\n\nvar _ = collate.Collator{}\n",
+		"internal/text/untracked.go": "package text\n\nimport \"example.com/untracked/pkg\"\n\nvar _ = pkg.Value\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	inventory, _, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "inventory"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+
+	expected := []string{
+		"4 external modules imported by 3 packages.\n",
+		"| `example.com/untracked/pkg` | - | internal | 1 | 1 | 1 |\n",
+		"| `github.com/lib/pq` | v1.10.9 | cmd, internal | 2 | 3 | 2 |\n",
+		"| `github.com/spf13/cobra` | v1.8.0 | cmd | 1 | 1 | 1 |\n",
+		"| `golang.org/x/text` | v0.14.0 | internal | 1 | 1 | 1 |\n",
+		"- `internal/store` (internal, 2 files): `github.com/lib/pq`, `github.com/lib/pq/hstore`\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(inventory, want) {
+			t.Errorf("expected %q in inventory, got:\n%s", want, inventory)
+		}
+	}
+	if strings.Contains(inventory, "`fmt`") {
+		t.Errorf("expected the standard library to be left out, got:\n%s", inventory)
+	}
+}
@@ -3,21 +3,30 @@ package linter
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/kgatilin/go-arch-lint/internal/config"
 	"github.com/kgatilin/go-arch-lint/internal/coverage"
+	"github.com/kgatilin/go-arch-lint/internal/gitdiff"
+	"github.com/kgatilin/go-arch-lint/internal/githistory"
 	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/license"
 	"github.com/kgatilin/go-arch-lint/internal/output"
 	"github.com/kgatilin/go-arch-lint/internal/scanner"
+	"github.com/kgatilin/go-arch-lint/internal/testrun"
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
 	"github.com/kgatilin/go-arch-lint/internal/validator"
 )
 
 // runStaticcheckTool executes staticcheck on the project and returns formatted output
-func runStaticcheckTool(projectPath string) (string, bool, error) {
+func runStaticcheckTool(projectPath string, quiet bool) (string, bool, error) {
 	// Check if staticcheck is available
 	if _, err := exec.LookPath("staticcheck"); err != nil {
 		return "", false, fmt.Errorf("staticcheck not found in PATH. Install with: go install honnef.co/go/tools/cmd/staticcheck@latest")
@@ -46,6 +55,14 @@ func runStaticcheckTool(projectPath string) (string, bool, error) {
 		}
 	}
 
+	// staticcheck already prints one line per issue, which is all quiet mode shows
+	if quiet {
+		if output == "" {
+			return "", hasIssues, nil
+		}
+		return output + "\n", hasIssues, nil
+	}
+
 	// Format output - always show results section
 	var formatted strings.Builder
 	formatted.WriteString("\n")
@@ -98,6 +115,14 @@ func (fna *fileNodeAdapter) GetDependencies() []validator.Dependency {
 	return deps
 }
 
+func (fna *fileNodeAdapter) GetPackageLine() int {
+	return fna.node.PackageLine
+}
+
+func (fna *fileNodeAdapter) GetPackageColumn() int {
+	return fna.node.PackageColumn
+}
+
 func (fna *fileNodeAdapter) GetBaseName() string {
 	return fna.node.BaseName
 }
@@ -106,6 +131,10 @@ func (fna *fileNodeAdapter) GetIsTest() bool {
 	return fna.node.IsTest
 }
 
+func (fna *fileNodeAdapter) GetDIFramework() string {
+	return fna.node.DIFramework
+}
+
 // outputGraphAdapter adapts graph.Graph to output.Graph interface
 type outputGraphAdapter struct {
 	g *graph.Graph
@@ -158,406 +187,1477 @@ func (fwa *fileWithAPIAdapter) GetExportedDecls() []output.ExportedDecl {
 	for i := range fwa.file.ExportedDecls {
 		decls[i] = &fwa.file.ExportedDecls[i] // scanner.ExportedDecl implements output.ExportedDecl
 	}
-	return decls
-}
+	return decls
+}
+
+func (fwa *fileWithAPIAdapter) GetLineCount() int {
+	return fwa.file.LineCount
+}
+
+func (fwa *fileWithAPIAdapter) GetStability() string {
+	return fwa.file.Stability
+}
+
+func (fwa *fileWithAPIAdapter) GetPackageDoc() string {
+	return fwa.file.PackageDoc
+}
+
+func (fwa *fileWithAPIAdapter) GetExamples() []output.Example {
+	examples := make([]output.Example, len(fwa.file.Examples))
+	for i := range fwa.file.Examples {
+		examples[i] = &fwa.file.Examples[i] // scanner.Example implements output.Example
+	}
+	return examples
+}
+
+// sourceFileAdapter adapts scanner.FileInfo to validator.SourceFile interface
+type sourceFileAdapter struct {
+	file *scanner.FileInfo
+}
+
+func (sfa *sourceFileAdapter) GetRelPath() string {
+	return sfa.file.RelPath
+}
+
+func (sfa *sourceFileAdapter) GetPackage() string {
+	return sfa.file.Package
+}
+
+func (sfa *sourceFileAdapter) GetIsTest() bool {
+	return sfa.file.IsTest
+}
+
+func (sfa *sourceFileAdapter) GetStructDefs() []validator.StructDef {
+	defs := make([]validator.StructDef, len(sfa.file.StructDefs))
+	for i := range sfa.file.StructDefs {
+		defs[i] = sfa.file.StructDefs[i] // scanner.StructDef implements validator.StructDef
+	}
+	return defs
+}
+
+func (sfa *sourceFileAdapter) GetConstBlocks() []validator.ConstBlock {
+	blocks := make([]validator.ConstBlock, len(sfa.file.ConstBlocks))
+	for i := range sfa.file.ConstBlocks {
+		blocks[i] = sfa.file.ConstBlocks[i] // scanner.ConstBlock implements validator.ConstBlock
+	}
+	return blocks
+}
+
+func (sfa *sourceFileAdapter) GetAPIReferences() []validator.APIReference {
+	refs := make([]validator.APIReference, len(sfa.file.APIReferences))
+	for i := range sfa.file.APIReferences {
+		refs[i] = sfa.file.APIReferences[i] // scanner.APIReference implements validator.APIReference
+	}
+	return refs
+}
+
+func (sfa *sourceFileAdapter) GetSignatureRefs() []validator.APIReference {
+	refs := make([]validator.APIReference, len(sfa.file.SignatureRefs))
+	for i := range sfa.file.SignatureRefs {
+		refs[i] = sfa.file.SignatureRefs[i] // scanner.APIReference implements validator.APIReference
+	}
+	return refs
+}
+
+func (sfa *sourceFileAdapter) GetStability() string {
+	return sfa.file.Stability
+}
+
+func (sfa *sourceFileAdapter) GetDeprecatedSymbols() []string {
+	return sfa.file.Deprecated
+}
+
+func (sfa *sourceFileAdapter) GetSymbolRefs() []validator.APIReference {
+	refs := make([]validator.APIReference, len(sfa.file.SymbolRefs))
+	for i := range sfa.file.SymbolRefs {
+		refs[i] = sfa.file.SymbolRefs[i] // scanner.APIReference implements validator.APIReference
+	}
+	return refs
+}
+
+func (sfa *sourceFileAdapter) GetPackageDoc() string {
+	return sfa.file.PackageDoc
+}
+
+func (sfa *sourceFileAdapter) GetSymbolUses() []validator.SymbolUse {
+	uses := make([]validator.SymbolUse, len(sfa.file.SymbolUses))
+	for i := range sfa.file.SymbolUses {
+		uses[i] = sfa.file.SymbolUses[i] // scanner.SymbolUse implements validator.SymbolUse
+	}
+	return uses
+}
+
+func (sfa *sourceFileAdapter) GetFuncDecls() []string {
+	return sfa.file.FuncDecls
+}
+
+func (sfa *sourceFileAdapter) GetFileReads() []validator.FileRead {
+	reads := make([]validator.FileRead, len(sfa.file.FileReads))
+	for i := range sfa.file.FileReads {
+		reads[i] = sfa.file.FileReads[i] // scanner.FileRead implements validator.FileRead
+	}
+	return reads
+}
+
+func (sfa *sourceFileAdapter) GetDynamicRefs() []validator.DynamicRef {
+	refs := make([]validator.DynamicRef, len(sfa.file.DynamicRefs))
+	for i := range sfa.file.DynamicRefs {
+		refs[i] = sfa.file.DynamicRefs[i] // scanner.DynamicRef implements validator.DynamicRef
+	}
+	return refs
+}
+
+// signatureAdapter adapts typecheck.Signature to validator.ExportedSignature interface
+type signatureAdapter struct {
+	sig *typecheck.Signature
+}
+
+func (sa *signatureAdapter) GetFile() string {
+	return sa.sig.File
+}
+
+func (sa *signatureAdapter) GetLine() int {
+	return sa.sig.Line
+}
+
+func (sa *signatureAdapter) GetColumn() int {
+	return sa.sig.Column
+}
+
+func (sa *signatureAdapter) GetName() string {
+	return sa.sig.Name
+}
+
+func (sa *signatureAdapter) GetTypes() []validator.SignatureType {
+	types := make([]validator.SignatureType, len(sa.sig.Types))
+	for i := range sa.sig.Types {
+		types[i] = sa.sig.Types[i] // typecheck.TypeRef implements validator.SignatureType
+	}
+	return types
+}
+
+// RunOptions configures a linter run
+type RunOptions struct {
+	Format         string // Output format (empty for violations only, "json" for a machine-readable report, "package-json" for one package)
+	Detailed       bool   // Show method-level dependencies (with "markdown" format)
+	RunStaticcheck bool   // Run staticcheck and include its results
+	PackagePath    string // Package to document (only used with "package" and "package-json" formats)
+	StrictParse    bool   // Abort on the first file with syntax errors instead of reporting it
+	Profile        string // Named profile from the config's profiles section (empty for none)
+	Mode           string // "fast" (default) skips the checks listed under modes.full in the config, "full" runs them
+	BuildMatrix    bool   // Validate each build_matrix target separately, honoring build constraints
+	CheckUpdate    bool   // Check for a newer release (also enabled by check_update in the config)
+	Version        string // Version of the running binary, for the update check
+	UpdateAPIURL   string // GitHub API URL for the update check (empty for https://api.github.com)
+	FailFast       bool   // Stop at the first violation that fails the build, skipping the remaining checks and docs
+	Quiet          bool   // Print one compact line per violation, without banners, summaries or guidance
+	Lang           string // Language of the violation report and full documentation (empty for English)
+	ASCII          bool   // Replace box-drawing characters, glyphs and emoji of the text output with ASCII
+	Focus          string // Restrict the "markdown" graph to packages matching this glob (e.g. internal/app/**)
+	Depth          int    // Imports around the focused packages to include, in either direction (with Focus)
+	MinScore       int    // Fail the build if the conformance score is below this (0 for no minimum)
+	CI             bool   // Running in CI: replace directive violations fail the build instead of warning
+	Cache          bool   // Reuse the result of an identical run on the same commit if the working tree is clean
+	CacheDir       string // Directory of the run cache (empty for go-arch-lint/runs in the user cache directory)
+}
+
+// LanguageFromLocale returns the report language for a POSIX locale such as "de_DE.UTF-8",
+// or English when there is no translation for it
+func LanguageFromLocale(locale string) string {
+	return output.LanguageFromLocale(locale)
+}
+
+// Run executes the linter on the specified project path
+// packagePath is only used when format is "package" to specify which package to document
+func Run(projectPath string, format string, detailed bool, runStaticcheck bool, packagePath string) (string, string, bool, error) {
+	return RunWithOptions(projectPath, RunOptions{
+		Format:         format,
+		Detailed:       detailed,
+		RunStaticcheck: runStaticcheck,
+		PackagePath:    packagePath,
+	})
+}
+
+// RunWithOptions executes the linter on the specified project path with the given options
+func RunWithOptions(projectPath string, opts RunOptions) (string, string, bool, error) {
+	run := runWithOptions
+	if opts.Cache {
+		run = runCached
+	}
+	graphOutput, violationsOutput, shouldFail, err := run(projectPath, opts)
+	if opts.ASCII && !isJSONFormat(opts.Format) {
+		graphOutput = output.ASCII(graphOutput)
+		violationsOutput = output.ASCII(violationsOutput)
+	}
+	return graphOutput, violationsOutput, shouldFail, err
+}
+
+// runWithOptions runs the linter; see RunWithOptions
+func runWithOptions(projectPath string, opts RunOptions) (string, string, bool, error) {
+	// Load configuration
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", "", false, err
+	}
+	if err := cfg.SelectProfile(opts.Profile); err != nil {
+		return "", "", false, err
+	}
+	if err := cfg.SelectMode(opts.Mode); err != nil {
+		return "", "", false, err
+	}
+	for _, warning := range cfg.GetWarnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	messages, err := output.NewMessages(opts.Lang)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	if opts.Focus != "" && opts.Format != "markdown" {
+		return "", "", false, fmt.Errorf("-focus is only supported with -format=markdown")
+	}
+
+	// The package formats document a single package, which must be given
+	if (opts.Format == "package" || opts.Format == "package-json") && opts.PackagePath == "" {
+		return "", "", false, fmt.Errorf("package path required for -format=%s", opts.Format)
+	}
+
+	// Handle API format separately
+	if opts.Format == "api" {
+		s := newScanner(projectPath, cfg, opts.StrictParse)
+		filesWithAPI, err := s.Scan(cfg.GetScanPaths(), documentationScanOptions)
+		if err != nil {
+			return "", "", false, err
+		}
+
+		// Convert to output.FileWithAPI interface
+		outFiles := make([]output.FileWithAPI, len(filesWithAPI))
+		for i := range filesWithAPI {
+			outFiles[i] = &fileWithAPIAdapter{file: &filesWithAPI[i]}
+		}
+
+		apiOutput := output.GenerateAPIMarkdown(outFiles)
+		return apiOutput, "", false, nil
+	}
+
+	// The symbols index lists the exported API as JSON Lines for retrieval pipelines
+	if opts.Format == "symbols-index" {
+		s := newScanner(projectPath, cfg, opts.StrictParse)
+		filesWithAPI, err := s.Scan(cfg.GetScanPaths(), scanner.ScanOptions{IncludeExportedAPI: true})
+		if err != nil {
+			return "", "", false, err
+		}
+
+		outFiles := make([]output.FileWithAPI, len(filesWithAPI))
+		for i := range filesWithAPI {
+			outFiles[i] = &fileWithAPIAdapter{file: &filesWithAPI[i]}
+		}
+
+		indexOutput, err := output.FormatSymbolsIndex(output.SymbolsIndex(cfg.Module, outFiles))
+		if err != nil {
+			return "", "", false, err
+		}
+		return indexOutput, "", false, nil
+	}
+
+	// Handle index format separately
+	if opts.Format == "index" {
+		indexDoc, _, _, err := indexDocumentation(projectPath, cfg, opts.StrictParse)
+		if err != nil {
+			return "", "", false, err
+		}
+
+		indexOutput, err := renderDocumentation(projectPath, cfg, "index", indexDoc)
+		if err != nil {
+			return "", "", false, err
+		}
+		return indexOutput, "", false, nil
+	}
+
+	// Scan files and build the dependency graph. The documentation formats take the
+	// exported API from the same scan, so they describe the snapshot that was validated.
+	scanOpts := scanOptions(cfg, opts.Detailed)
+	if documentsAPI(opts.Format) {
+		scanOpts = unionScanOptions(scanOpts, documentationScanOptions)
+	}
+	s := newScanner(projectPath, cfg, opts.StrictParse)
+	files, g, err := scanWithOptions(s, cfg, scanOpts)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	v := newValidator(projectPath, cfg, s, files, g)
+
+	suppressions, warnings := inlineSuppressions(files)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	// In build matrix mode, every build target is validated on its own scan
+	validators := []*validator.Validator{v}
+	var targets []config.BuildTarget
+	if opts.BuildMatrix {
+		targets = cfg.GetBuildMatrix()
+		if len(targets) == 0 {
+			return "", "", false, fmt.Errorf("-build-matrix requires build_matrix targets in .goarchlint")
+		}
+		validators, err = matrixValidators(projectPath, cfg, opts.StrictParse, opts.Detailed, targets)
+		if err != nil {
+			return "", "", false, err
+		}
+	}
+
+	// In fail-fast mode, validation stops at the first violation that fails the build
+	var fails func(validator.Violation) bool
+	if opts.FailFast {
+		fails = failsBuild(cfg, suppressions, opts.CI, time.Now())
+		for _, v := range validators {
+			v.SetFailFast(fails)
+		}
+	}
+
+	// The fast mode skips the expensive checks the config reserves for the full mode
+	if skipped := skippedChecks(cfg, opts); len(skipped) > 0 && !opts.Quiet {
+		fmt.Fprintf(os.Stderr, "Fast mode: skipping %s (run with -mode=full to include them)\n", strings.Join(skipped, ", "))
+	}
+	runsCoverage := cfg.IsCoverageEnabled() && cfg.RunsCheck(config.CheckTestCoverage)
+	runsShuffle := len(cfg.GetShuffleCleanDirectories()) > 0 && cfg.RunsCheck(config.CheckShuffleClean)
+	runsRace := len(cfg.GetRaceCleanDirectories()) > 0 && cfg.RunsCheck(config.CheckRaceClean)
+	runsTypeChecks := cfg.RunsCheck(config.CheckTypeChecks)
+
+	// Run coverage analysis and the shuffled and race-detected tests if enabled. In
+	// fail-fast mode the tests only run once the other rules pass, see below.
+	var coverageResults []coverage.PackageCoverage
+	if runsCoverage && !opts.FailFast {
+		coverageResults = measureCoverage(projectPath, cfg, validators, opts)
+	}
+	if runsShuffle && !opts.FailFast {
+		shuffleTests(projectPath, cfg, validators, opts)
+	}
+	if runsRace && !opts.FailFast {
+		raceTests(projectPath, cfg, validators, opts)
+	}
+
+	// One checker type-checks each package once for all rules and the documentation
+	checker := typecheck.New(projectPath, cfg.Module)
+	if runsTypeChecks && usesTypeLeaks(cfg) {
+		signatures, err := collectExportedSignatures(checker, cfg, g)
+		if err != nil {
+			// Log error but don't fail - type information is best-effort
+			fmt.Fprintf(os.Stderr, "Warning: Failed to type-check packages: %v\n", err)
+		} else {
+			for _, v := range validators {
+				v.SetExportedSignatures(signatures)
+			}
+		}
+	}
+
+	if runsTypeChecks && usesInterfaces(cfg) {
+		interfaces, err := collectInterfaces(checker, cfg, g)
+		if err != nil {
+			// Log error but don't fail - type information is best-effort
+			fmt.Fprintf(os.Stderr, "Warning: Failed to type-check packages: %v\n", err)
+		} else {
+			for _, v := range validators {
+				v.SetInterfaces(interfaces)
+			}
+		}
+	}
+
+	if runsTypeChecks && usesPortImplementations(cfg) {
+		implementations, err := collectPortImplementations(checker, cfg, g)
+		if err != nil {
+			// Log error but don't fail - type information is best-effort
+			fmt.Fprintf(os.Stderr, "Warning: Failed to type-check packages: %v\n", err)
+		} else {
+			for _, v := range validators {
+				v.SetPortImplementations(implementations)
+			}
+		}
+	}
+
+	if cfg.ShouldDetectDeprecatedUsages() {
+		changes, err := gitdiff.New(projectPath).Changes(cfg.GetDeprecationsBase())
+		if err != nil {
+			// Log error but don't fail - new usages can only be detected in a git checkout
+			fmt.Fprintf(os.Stderr, "Warning: Failed to compute changes for deprecation check: %v\n", err)
+		} else {
+			for _, v := range validators {
+				v.SetChangeSet(changes)
+			}
+		}
+	}
+
+	validate := func() []validator.Violation {
+		if opts.BuildMatrix {
+			return validateBuildMatrix(validators, targets)
+		}
+		return v.Validate()
+	}
+	violations := validate()
+	if opts.FailFast && (runsCoverage || runsShuffle || runsRace) && !containsFailure(violations, fails) {
+		if runsCoverage {
+			coverageResults = measureCoverage(projectPath, cfg, validators, opts)
+		}
+		if runsShuffle {
+			shuffleTests(projectPath, cfg, validators, opts)
+		}
+		if runsRace {
+			raceTests(projectPath, cfg, validators, opts)
+		}
+		violations = validate()
+	}
+	stoppedEarly := opts.FailFast && containsFailure(violations, fails)
+	violations = applyInlineSuppressions(violations, suppressions, time.Now())
+
+	addSourceLinks(projectPath, cfg, violations)
+	addRuleSources(cfg, violations)
+	applyRuleActivation(cfg, violations, time.Now())
+	applyReplaceDirectiveMode(violations, opts.CI)
+
+	// The conformance score sums up the run; with -min-score a low score fails the build.
+	// A fail-fast run that failed has not seen every violation, so it is not scored.
+	score := conformanceScore(g, violations, coverageResults)
+	belowMinimum := opts.MinScore > 0 && score.Score < opts.MinScore && !stoppedEarly
+
+	// Output dependency graph using adapter. A fail-fast run that failed skips it, since
+	// only pass/fail matters then.
+	var graphOutput string
+	if opts.Format == "markdown" && !stoppedEarly {
+		rendered := g
+		if opts.Focus != "" {
+			rendered, err = g.Focus(opts.Focus, opts.Depth)
+			if err != nil {
+				return "", "", false, err
+			}
+		}
+		outputGraph := &outputGraphAdapter{g: rendered}
+		graphOutput = output.GenerateMarkdown(outputGraph)
+		if opts.Detailed {
+			graphOutput += output.GenerateCouplings(strongestCouplings(cfg, rendered))
+		}
+	} else if opts.Format == "inventory" && !stoppedEarly {
+		graphOutput = output.GenerateInventory(dependencyInventory(projectPath, cfg, g))
+	} else if (opts.Format == "full" || opts.Format == "docs") && !stoppedEarly {
+		// Generate comprehensive documentation
+		graphOutput, err = generateFullDocumentation(projectPath, cfg, checker, files, g, violations, score, opts.Lang, opts.Version)
+		if err != nil {
+			return "", "", false, err
+		}
+	}
+
+	// Format violations with architectural context from config
+	var violationsOutput string
+	if opts.Quiet {
+		violationsOutput = output.FormatViolationsCompact(adaptViolations(violations))
+	} else {
+		violationsOutput = formatViolations(cfg, violations, messages)
+	}
+	if stoppedEarly && !opts.Quiet {
+		violationsOutput += "\nStopped at the first error (-fail-fast); run without it to see all violations\n"
+	}
+	if !stoppedEarly && !opts.Quiet && (violationsOutput != "" || belowMinimum) {
+		violationsOutput += output.FormatScore(score, messages)
+	}
+	if belowMinimum && opts.Quiet {
+		violationsOutput += ". min-score " + messages.Text("score.below_minimum", score.Score, opts.MinScore) + "\n"
+	} else if belowMinimum {
+		violationsOutput += messages.Text("score.below_minimum", score.Score, opts.MinScore) + "\n"
+	}
+
+	// Determine if violations should cause build failure (respect warn mode)
+	shouldFail := shouldFailBuild(violations, cfg) || belowMinimum
+
+	// The JSON reports and the package page carry the violations, replacing their output,
+	// so stdout is machine-readable or a single document
+	if opts.Format == "json" {
+		report := buildReport(cfg, opts.Profile, violations, coverageResults, shouldFail)
+		if !stoppedEarly {
+			report.Score = &score
+		}
+		graphOutput, err = output.FormatReport(report)
+		if err != nil {
+			return "", "", false, err
+		}
+		violationsOutput = ""
+	}
+	if opts.Format == "package-json" {
+		graphOutput, err = packageReport(cfg, opts.PackagePath, files, g, violations, coverageResults)
+		if err != nil {
+			return "", "", false, err
+		}
+		violationsOutput = ""
+	}
+	if opts.Format == "package" {
+		graphOutput, err = packagePage(cfg, opts.PackagePath, files, g, violations, coverageResults)
+		if err != nil {
+			return "", "", false, err
+		}
+		violationsOutput = ""
+	}
+
+	// Run staticcheck if enabled (either via config or CLI flag); the flag also runs it in
+	// fast mode
+	var staticcheckFailed bool
+	if (opts.RunStaticcheck || (cfg.ShouldRunStaticcheck() && cfg.RunsCheck(config.CheckStaticcheck))) && !stoppedEarly {
+		staticcheckOutput, hasIssues, err := runStaticcheckTool(projectPath, opts.Quiet)
+		if err != nil && opts.Quiet {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		} else if err != nil {
+			// If staticcheck is not available or fails to run, show error but don't fail build
+			staticcheckOutput = fmt.Sprintf("\n⚠ Staticcheck error: %v\n", err)
+		}
+		if staticcheckOutput != "" {
+			// Append staticcheck output to violations
+			if violationsOutput != "" && !opts.Quiet {
+				violationsOutput += "\n"
+			}
+			violationsOutput += staticcheckOutput
+		}
+		if hasIssues {
+			staticcheckFailed = true
+		}
+	}
+
+	// Update shouldFail to include staticcheck failures
+	if staticcheckFailed {
+		shouldFail = true
+	}
+
+	// Check for a newer release last
+	checkForUpdate(cfg, opts)
+
+	return graphOutput, violationsOutput, shouldFail, nil
+}
+
+// checkForUpdate prints a notice about a newer release to stderr if the update check
+// is enabled. A failed check only prints a warning and never fails the run.
+func checkForUpdate(cfg *config.Config, opts RunOptions) {
+	if !opts.CheckUpdate && !cfg.ShouldCheckUpdate() {
+		return
+	}
+	notice, err := CheckUpdate(opts.Version, opts.UpdateAPIURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	} else if notice != "" {
+		fmt.Fprint(os.Stderr, notice)
+	}
+}
+
+// skippedChecks lists the enabled expensive checks the selected mode skips.
+// Staticcheck requested with the flag always runs, so it is not listed then.
+func skippedChecks(cfg *config.Config, opts RunOptions) []string {
+	enabled := []struct {
+		check string
+		on    bool
+	}{
+		{config.CheckTestCoverage, cfg.IsCoverageEnabled()},
+		{config.CheckStaticcheck, cfg.ShouldRunStaticcheck() && !opts.RunStaticcheck},
+		{config.CheckTypeChecks, usesTypeLeaks(cfg) || usesInterfaces(cfg) || usesPortImplementations(cfg)},
+		{config.CheckShuffleClean, len(cfg.GetShuffleCleanDirectories()) > 0},
+		{config.CheckRaceClean, len(cfg.GetRaceCleanDirectories()) > 0},
+	}
+	var skipped []string
+	for _, e := range enabled {
+		if e.on && !cfg.RunsCheck(e.check) {
+			skipped = append(skipped, e.check)
+		}
+	}
+	return skipped
+}
+
+// usesTypeLeaks reports whether the type_leaks rule needs the exported signatures
+func usesTypeLeaks(cfg *config.Config) bool {
+	return len(cfg.GetTypeLeakLayers()) > 0 && len(cfg.GetTypeLeakForbidden()) > 0
+}
+
+// usesInterfaces reports whether the orphan_interfaces or adapter_ports rules need the
+// type-checked interfaces
+func usesInterfaces(cfg *config.Config) bool {
+	return len(cfg.GetOrphanInterfaceLayers()) > 0 || (len(cfg.GetAdapterLayers()) > 0 && len(cfg.GetAdapterPortLayers()) > 0)
+}
+
+// usesPortImplementations reports whether adapter_ports needs the port implementations
+// to check for compile-time assertions
+func usesPortImplementations(cfg *config.Config) bool {
+	return cfg.ShouldRequireAdapterAssertions() && len(cfg.GetAdapterLayers()) > 0 && len(cfg.GetAdapterPortLayers()) > 0
+}
+
+// documentsAPI reports whether an output format documents the exported API of the
+// scanned packages
+func documentsAPI(format string) bool {
+	return format == "full" || format == "docs" || format == "package" || format == "package-json"
+}
+
+// isJSONFormat reports whether an output format writes JSON (or JSON Lines) to stdout,
+// which must not be mixed with progress output
+func isJSONFormat(format string) bool {
+	return format == "json" || format == "package-json" || format == "symbols-index"
+}
+
+// measureCoverage runs the tests of the scanned packages with coverage and hands the
+// results to the validators. A failed run only prints a warning. The progress and a
+// summary table are printed to stdout, except in quiet mode and for the JSON formats and
+// the package page, whose progress goes to stderr so stdout only holds the document.
+func measureCoverage(projectPath string, cfg *config.Config, validators []*validator.Validator, opts RunOptions) []coverage.PackageCoverage {
+	progress := progressOutput(opts)
+	coverageRunner := coverage.New(projectPath, cfg.Module)
+	coverageRunner.SetOutput(progress)
+	coverageResults, err := coverageRunner.Run(cfg.GetScanPaths())
+	if err != nil {
+		// Log error but don't fail - coverage might not be critical
+		fmt.Fprintf(os.Stderr, "Warning: Failed to run coverage analysis: %v\n", err)
+		return nil
+	}
+
+	// Display coverage summary; the JSON report and the package page carry it instead
+	if !isJSONFormat(opts.Format) && opts.Format != "package" && !opts.Quiet {
+		summaries := coverage.SummarizeByDirectory(coverageResults, cfg.Module, cfg.GetScanPaths())
+		overallCoverage := coverage.CalculateOverallCoverage(coverageResults)
+		coverage.WriteSummary(progress, summaries, overallCoverage)
+	}
+
+	// Convert to validator.PackageCoverage interface
+	validatorCoverage := make([]validator.PackageCoverage, len(coverageResults))
+	for i := range coverageResults {
+		validatorCoverage[i] = coverageResults[i]
+	}
+	for _, v := range validators {
+		v.SetCoverageResults(validatorCoverage)
+	}
+	return coverageResults
+}
+
+// shuffleTests runs the tests of the require_shuffle_clean directories in random order
+// (go test -shuffle=on) and hands the failures to the validators
+func shuffleTests(projectPath string, cfg *config.Config, validators []*validator.Validator, opts RunOptions) {
+	failures := runTests(projectPath, cfg.GetShuffleCleanDirectories(), "-shuffle=on", "in random order", opts)
+	for _, v := range validators {
+		v.SetShuffleFailures(failures)
+	}
+}
+
+// raceTests runs the tests of the require_race_clean directories with the race detector
+// (go test -race) and hands the failures to the validators
+func raceTests(projectPath string, cfg *config.Config, validators []*validator.Validator, opts RunOptions) {
+	failures := runTests(projectPath, cfg.GetRaceCleanDirectories(), "-race", "with the race detector", opts)
+	for _, v := range validators {
+		v.SetRaceFailures(failures)
+	}
+}
+
+// runTests runs the tests of dirs with flag and returns the failures. A run that cannot
+// start only prints a warning.
+func runTests(projectPath string, dirs []string, flag, description string, opts RunOptions) []validator.TestFailure {
+	progress := progressOutput(opts)
+	fmt.Fprintf(progress, "\n🔍 Running the tests of %s %s...\n", strings.Join(dirs, ", "), description)
+
+	failures, err := testrun.New(projectPath).Run(dirs, flag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to run the tests %s: %v\n", description, err)
+		return nil
+	}
+	fmt.Fprintf(progress, "  %d failing\n\n", len(failures))
+
+	// Convert to validator.TestFailure interface
+	validatorFailures := make([]validator.TestFailure, len(failures))
+	for i := range failures {
+		validatorFailures[i] = failures[i]
+	}
+	return validatorFailures
+}
+
+// progressOutput returns where the progress of test runs is printed: stdout, except in
+// quiet mode and for the JSON formats and the package page, whose progress goes to stderr
+// so stdout only holds the document
+func progressOutput(opts RunOptions) io.Writer {
+	var progress io.Writer = os.Stdout
+	if opts.Quiet {
+		progress = io.Discard
+	} else if isJSONFormat(opts.Format) || opts.Format == "package" {
+		progress = os.Stderr
+	}
+	if opts.ASCII {
+		progress = output.NewASCIIWriter(progress)
+	}
+	return progress
+}
+
+// scanProject scans the configured paths of a project and builds its dependency graph.
+// With detailed, the graph records the symbols used from each import.
+func scanProject(projectPath string, cfg *config.Config, strictParse, detailed bool) (*scanner.Scanner, []scanner.FileInfo, *graph.Graph, error) {
+	s := newScanner(projectPath, cfg, strictParse)
+	files, g, err := scanWith(s, cfg, detailed)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return s, files, g, nil
+}
+
+// scanWith scans the configured paths with a prepared scanner and builds the dependency graph
+func scanWith(s *scanner.Scanner, cfg *config.Config, detailed bool) ([]scanner.FileInfo, *graph.Graph, error) {
+	return scanWithOptions(s, cfg, scanOptions(cfg, detailed))
+}
+
+// scanOptions returns what a scan must collect for the rules enabled in cfg. With
+// detailed, it includes the symbols used from each import.
+func scanOptions(cfg *config.Config, detailed bool) scanner.ScanOptions {
+	return scanner.ScanOptions{
+		IncludeImportUsages:  detailed,
+		IncludeDefinitions:   cfg.ShouldDetectDuplicates(),
+		IncludeAPIReferences: len(cfg.GetWrapIn()) > 0,
+		IncludeSignatureRefs: cfg.ShouldDetectFrameworkLockIn(),
+		IncludeStability:     cfg.ShouldEnforceStability(),
+		IncludeDeprecations:  cfg.ShouldDetectDeprecatedUsages(),
+		IncludeSymbolRefs:    cfg.ShouldDetectDeprecatedUsages() || cfg.ShouldConfineConfigLoading(),
+		IncludePackageDoc:    len(cfg.GetPackageDocLayers()) > 0,
+		IncludeFileReads:     cfg.ShouldRequireTestdataFixtures(),
+		IncludeSymbolUses:    cfg.ShouldDetectTestSetupImports() || enforceTestScope(cfg),
+		IncludeFuncDecls:     enforceTestScope(cfg),
+		IncludeDynamicRefs:   cfg.ShouldDetectHiddenDependencies(),
+		RegisterFuncs:        cfg.GetHiddenDependencyRegisterFuncs(),
+		LookupFuncs:          cfg.GetHiddenDependencyLookupFuncs(),
+		// Blank imports of local packages are runtime wiring if the packages register from init
+		IncludeInitRegistrations: true,
+		IncludeSuppressions:      true,
+	}
+}
+
+// documentationScanOptions are what documenting the exported API needs from a scan
+var documentationScanOptions = scanner.ScanOptions{
+	IncludeExportedAPI:   true,
+	IncludeStability:     true,
+	IncludePackageDoc:    true,
+	IncludeConstructions: true,
+	IncludeExamples:      true,
+}
+
+// scanWithOptions scans the configured paths with a prepared scanner and builds the
+// dependency graph, detailed if opts include import usages
+func scanWithOptions(s *scanner.Scanner, cfg *config.Config, opts scanner.ScanOptions) ([]scanner.FileInfo, *graph.Graph, error) {
+	files, err := s.Scan(cfg.GetScanPaths(), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	detailed := opts.IncludeImportUsages
+
+	// Convert scanner.FileInfo to graph.FileInfo interface
+	graphFiles := make([]graph.FileInfo, len(files))
+	for i := range files {
+		graphFiles[i] = files[i]
+	}
+
+	var g *graph.Graph
+	if detailed {
+		// Build detailed dependency graph, weighting dependencies by their references
+		usageMap, callSites := importUsages(files)
+		g = graph.BuildDetailed(graphFiles, cfg.Module, usageMap)
+		g.ApplyCallSites(callSites)
+	} else {
+		// Build dependency graph
+		g = graph.Build(graphFiles, cfg.Module)
+	}
+
+	// Resolve imports of nested modules declared in scan_paths
+	g.ApplyModuleRoots(cfg.GetModuleRoots())
+
+	return files, g, nil
+}
+
+// importUsages returns the symbols used from each import of the files and how often
+// they are referenced, by file RelPath and import path
+func importUsages(files []scanner.FileInfo) (map[string]map[string][]string, map[string]map[string]int) {
+	usageMap := make(map[string]map[string][]string)
+	callSites := make(map[string]map[string]int)
+	for _, file := range files {
+		fileUsageMap := make(map[string][]string)
+		fileCallSites := make(map[string]int)
+		for _, usage := range file.ImportUsages {
+			fileUsageMap[usage.ImportPath] = usage.UsedSymbols
+			fileCallSites[usage.ImportPath] = usage.CallSites
+		}
+		usageMap[file.RelPath] = fileUsageMap
+		callSites[file.RelPath] = fileCallSites
+	}
+	return usageMap, callSites
+}
+
+// enforceTestScope reports whether test_scope applies, which it does only together with strict_test_naming
+func enforceTestScope(cfg *config.Config) bool {
+	return cfg.ShouldEnforceStrictTestNaming() && cfg.ShouldEnforceTestScope()
+}
+
+// newValidator creates a validator for a scanned project with parse errors and,
+// if a rule needs them, source files attached
+func newValidator(projectPath string, cfg *config.Config, s *scanner.Scanner, files []scanner.FileInfo, g *graph.Graph) *validator.Validator {
+	v := validator.NewWithPath(cfg, &graphAdapter{g: g}, projectPath)
+
+	// Project files are read from where the scanner read them, e.g. a past revision
+	project := s.FS()
+	if project != nil {
+		v.SetFS(project)
+	} else {
+		project = os.DirFS(projectPath)
+	}
+
+	// Report files skipped by the scanner because of syntax errors
+	scanParseErrors := s.ParseErrors()
+	parseErrors := make([]validator.ParseError, len(scanParseErrors))
+	for i := range scanParseErrors {
+		parseErrors[i] = scanParseErrors[i]
+	}
+	v.SetParseErrors(parseErrors)
+
+	if cfg.ShouldDetectDuplicates() || len(cfg.GetWrapIn()) > 0 || cfg.ShouldConfineConfigLoading() || cfg.ShouldDetectFrameworkLockIn() || cfg.ShouldEnforceStability() ||
+		cfg.ShouldDetectDeprecatedUsages() || len(cfg.GetPackageDocLayers()) > 0 || cfg.ShouldRequireTestdataFixtures() ||
+		cfg.ShouldDetectTestSetupImports() || enforceTestScope(cfg) || cfg.ShouldDetectHiddenDependencies() {
+		// Convert to validator.SourceFile interface
+		sourceFiles := make([]validator.SourceFile, len(files))
+		for i := range files {
+			sourceFiles[i] = &sourceFileAdapter{file: &files[i]}
+		}
+		v.SetSourceFiles(sourceFiles)
+	}
+
+	if cfg.ShouldCheckReplaceDirectives() {
+		// Convert to validator.ReplaceDirective interface
+		parsed := goModReplaces(project)
+		replaces := make([]validator.ReplaceDirective, len(parsed))
+		for i := range parsed {
+			replaces[i] = parsed[i]
+		}
+		v.SetReplaceDirectives(replaces)
+	}
+
+	if len(cfg.GetLicensePolicyLayers()) > 0 {
+		// Convert to validator.ModuleLicense interface
+		detected := license.Detect(project, goModRequirements(project))
+		licenses := make([]validator.ModuleLicense, len(detected))
+		for i := range detected {
+			licenses[i] = detected[i]
+		}
+		v.SetModuleLicenses(licenses)
+	}
+
+	return v
+}
+
+// newScanner creates a scanner for the configured project
+func newScanner(projectPath string, cfg *config.Config, strictParse bool) *scanner.Scanner {
+	s := scanner.New(projectPath, cfg.Module, cfg.IgnorePaths, cfg.ShouldLintTestFiles())
+	s.SetStrictParse(strictParse)
+	return s
+}
+
+// addRuleSources records which configuration layer defined the rule of each violation
+func addRuleSources(cfg *config.Config, violations []validator.Violation) {
+	for i := range violations {
+		violations[i].Source = cfg.GetRuleSource(violations[i].RuleKey)
+	}
+}
+
+// addSourceLinks sets the link of each violation from the source_links template. Paths
+// are made relative to the repository root and {ref} defaults to the current commit
+// (or "HEAD" outside a git repository).
+func addSourceLinks(projectPath string, cfg *config.Config, violations []validator.Violation) {
+	template := cfg.GetSourceLinkTemplate()
+	if template == "" || len(violations) == 0 {
+		return
+	}
+
+	repo := githistory.New(projectPath)
+	ref := cfg.GetSourceLinkRef()
+	if ref == "" {
+		ref = "HEAD"
+		if head, err := repo.Head(); err == nil {
+			ref = head
+		}
+	}
+	prefix, _ := repo.Prefix()
+
+	for i := range violations {
+		if violations[i].File == "" {
+			continue
+		}
+		file := filepath.ToSlash(violations[i].File)
+		if prefix != "" {
+			file = prefix + "/" + file
+		}
+		violations[i].Link = output.SourceLink(template, ref, file, violations[i].Line)
+	}
+}
+
+// violationAdapter adapts validator.Violation to output.Violation
+type violationAdapter struct {
+	validator.Violation
+}
+
+// GetSpans implements output.Violation interface
+func (va violationAdapter) GetSpans() []output.Span {
+	spans := make([]output.Span, len(va.Spans))
+	for i, span := range va.Spans {
+		spans[i] = span
+	}
+	return spans
+}
+
+// adaptViolations converts violations to the output.Violation interface
+func adaptViolations(violations []validator.Violation) []output.Violation {
+	outViolations := make([]output.Violation, len(violations))
+	for i, viol := range violations {
+		outViolations[i] = violationAdapter{viol}
+	}
+	return outViolations
+}
+
+// formatViolations formats violations in the language of messages, with architectural
+// context if error_prompt is enabled
+func formatViolations(cfg *config.Config, violations []validator.Violation, messages *output.Messages) string {
+	outViolations := adaptViolations(violations)
+
+	errorPrompt := cfg.GetErrorPrompt()
+	if !errorPrompt.Enabled {
+		// Error prompt disabled, use standard formatting
+		return output.FormatViolationsLocalized(outViolations, nil, messages)
+	}
+
+	// Create error context from config
+	errorContext := &output.ErrorContext{
+		Enabled:                 true,
+		PresetName:              cfg.PresetUsed,
+		ArchitecturalGoals:      errorPrompt.ArchitecturalGoals,
+		Principles:              errorPrompt.Principles,
+		RefactoringGuidance:     errorPrompt.RefactoringGuidance,
+		CoverageGuidance:        errorPrompt.CoverageGuidance,
+		TestNamingGuidance:      errorPrompt.TestNamingGuidance,
+		BlackboxTestingGuidance: errorPrompt.BlackboxTestingGuidance,
+	}
+	return output.FormatViolationsLocalized(outViolations, errorContext, messages)
+}
+
+// ExportGraph builds the dependency graph of a project and serializes it in the given format
+// (json, graphml or gexf) for analysis in external tools
+func ExportGraph(projectPath, format string) (string, error) {
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	// Include used symbols so the JSON export is as detailed as the -detailed graph
+	_, _, g, err := scanProject(projectPath, cfg, false, true)
+	if err != nil {
+		return "", err
+	}
+
+	// Commit dates keep the export identical across checkouts of a commit; outside a
+	// git repository files have no modification time
+	if modTimes, err := githistory.New(projectPath).LastChanged(); err == nil {
+		g.ApplyModTimes(modTimes)
+	}
+
+	return g.Export(format)
+}
+
+// ValidateGraph validates a dependency graph previously exported as JSON against the
+// project's .goarchlint rules. Only rules based on the dependency graph are checked;
+// rules that need source files, coverage or the directory structure are skipped.
+// Returns formatted violations and whether they should fail the build.
+func ValidateGraph(projectPath string, graphData []byte) (string, bool, error) {
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", false, err
+	}
+
+	g, err := graph.Import(graphData)
+	if err != nil {
+		return "", false, err
+	}
+	if g.GetModule() != "" && cfg.Module != "" && g.GetModule() != cfg.Module {
+		return "", false, fmt.Errorf("graph module %s does not match configured module %s", g.GetModule(), cfg.Module)
+	}
+
+	v := validator.New(cfg, &graphAdapter{g: g})
+	violations := v.Validate()
+	addSourceLinks(projectPath, cfg, violations)
+	addRuleSources(cfg, violations)
+	applyRuleActivation(cfg, violations, time.Now())
+
+	return formatViolations(cfg, violations, nil), shouldFailBuild(violations, cfg), nil
+}
+
+// generateFullDocumentation creates comprehensive documentation combining structure, rules,
+// dependencies, and API. filesWithAPI are the files of the run's scan, which must include
+// documentationScanOptions.
+func generateFullDocumentation(projectPath string, cfg *config.Config, checker *typecheck.Checker, filesWithAPI []scanner.FileInfo, g *graph.Graph, violations []validator.Violation, score output.Score, lang, version string) (string, error) {
+	// Convert to output.FileWithAPI interface
+	outFiles := make([]output.FileWithAPI, len(filesWithAPI))
+	for i := range filesWithAPI {
+		outFiles[i] = &fileWithAPIAdapter{file: &filesWithAPI[i]}
+	}
+
+	// Check which required directories exist
+	existingDirs := make(map[string]bool)
+	for dirPath := range cfg.Structure.RequiredDirectories {
+		fullPath := filepath.Join(projectPath, dirPath)
+		if info, err := os.Stat(fullPath); err == nil && info.IsDir() {
+			existingDirs[dirPath] = true
+		} else {
+			existingDirs[dirPath] = false
+		}
+	}
+
+	// Count unique packages
+	packageSet := make(map[string]bool)
+	for _, node := range g.Nodes {
+		packageSet[node.Package] = true
+	}
+
+	// Create full documentation structure
+	fullDoc := output.FullDocumentation{
+		Structure: output.StructureInfo{
+			RequiredDirectories:   cfg.Structure.RequiredDirectories,
+			AllowOtherDirectories: cfg.Structure.AllowOtherDirectories,
+			ExistingDirs:          existingDirs,
+		},
+		Rules: output.RulesInfo{
+			DirectoriesImport: cfg.Rules.DirectoriesImport,
+			DetectUnused:      cfg.Rules.DetectUnused,
+		},
+		Graph:          &outputGraphAdapter{g: g},
+		Files:          outFiles,
+		Violations:     nil, // Not included in output, shown separately in stderr
+		ViolationCount: len(violations),
+		Score:          &score,
+		FileCount:      len(g.Nodes),
+		PackageCount:   len(packageSet),
+		DomainLayers:   cfg.GetDomainLayers(),
+		Ports:          collectPorts(checker, cfg, g),
+		Roots:          collectCompositionRoots(filesWithAPI, g),
+		Layers:         layerSizes(cfg, g),
+		Lang:           lang,
+		Version:        version,
+	}
 
-func (fwa *fileWithAPIAdapter) GetLineCount() int {
-	return fwa.file.LineCount
+	return renderDocumentation(projectPath, cfg, "full", fullDoc)
 }
 
-// Run executes the linter on the specified project path
-// packagePath is only used when format is "package" to specify which package to document
-func Run(projectPath string, format string, detailed bool, runStaticcheck bool, packagePath string) (string, string, bool, error) {
-	// Load configuration
-	cfg, err := config.Load(projectPath)
+// indexDocumentation scans a project for the architecture index. It also returns the
+// scanned files with their exported API and the dependency graph.
+func indexDocumentation(projectPath string, cfg *config.Config, strictParse bool) (output.FullDocumentation, []scanner.FileInfo, *graph.Graph, error) {
+	// One scan provides both the exported API and the graph for statistics
+	s := newScanner(projectPath, cfg, strictParse)
+	filesWithAPI, g, err := scanWithOptions(s, cfg, unionScanOptions(documentationScanOptions, scanner.ScanOptions{IncludeInitRegistrations: true}))
 	if err != nil {
-		return "", "", false, err
+		return output.FullDocumentation{}, nil, nil, err
 	}
 
-	// Handle package format separately
-	if format == "package" {
-		if packagePath == "" {
-			return "", "", false, fmt.Errorf("package path required for -format=package")
-		}
+	// Convert to output.FileWithAPI interface
+	outFiles := make([]output.FileWithAPI, len(filesWithAPI))
+	for i := range filesWithAPI {
+		outFiles[i] = &fileWithAPIAdapter{file: &filesWithAPI[i]}
+	}
 
-		s := scanner.New(projectPath, cfg.Module, cfg.IgnorePaths, cfg.ShouldLintTestFiles())
-		filesWithAPI, err := s.Scan(cfg.ScanPaths, scanner.ScanOptions{IncludeExportedAPI: true})
-		if err != nil {
-			return "", "", false, err
+	// Check which required directories exist
+	existingDirs := make(map[string]bool)
+	for dirPath := range cfg.Structure.RequiredDirectories {
+		fullPath := filepath.Join(projectPath, dirPath)
+		if info, err := os.Stat(fullPath); err == nil && info.IsDir() {
+			existingDirs[dirPath] = true
+		} else {
+			existingDirs[dirPath] = false
 		}
+	}
 
-		// Filter files to only those in the specified package directory
-		packageFiles := []scanner.FileInfo{}
-		for _, file := range filesWithAPI {
-			// Extract directory from file path
-			fileDir := file.RelPath
-			if idx := strings.LastIndex(file.RelPath, "/"); idx >= 0 {
-				fileDir = file.RelPath[:idx]
-			}
+	// Count unique packages
+	packageSet := make(map[string]bool)
+	for _, node := range g.Nodes {
+		packageSet[node.Package] = true
+	}
+
+	// Create index documentation structure
+	indexDoc := output.FullDocumentation{
+		Structure: output.StructureInfo{
+			RequiredDirectories:   cfg.Structure.RequiredDirectories,
+			AllowOtherDirectories: cfg.Structure.AllowOtherDirectories,
+			ExistingDirs:          existingDirs,
+		},
+		Rules: output.RulesInfo{
+			DirectoriesImport: cfg.Rules.DirectoriesImport,
+			DetectUnused:      cfg.Rules.DetectUnused,
+		},
+		Graph:          &outputGraphAdapter{g: g},
+		Files:          outFiles,
+		Violations:     nil,
+		ViolationCount: 0, // Don't include violations in index
+		FileCount:      len(g.Nodes),
+		PackageCount:   len(packageSet),
+		DomainLayers:   cfg.GetDomainLayers(),
+		Ports:          collectPorts(typecheck.New(projectPath, cfg.Module), cfg, g),
+		Roots:          collectCompositionRoots(filesWithAPI, g),
+		Layers:         layerSizes(cfg, g),
+	}
 
-			if fileDir == packagePath {
-				packageFiles = append(packageFiles, file)
+	// Hosted indexes can carry the documentation of every package instead of a command
+	if cfg.ShouldInlinePackageDetails() {
+		indexDoc.PackageDetails = make(map[string]output.PackageDocumentation)
+		for _, file := range filesWithAPI {
+			pkgPath := path.Dir(file.RelPath)
+			if _, ok := indexDoc.PackageDetails[pkgPath]; !ok {
+				indexDoc.PackageDetails[pkgPath] = packageDocumentation(pkgPath, filesWithAPI, g)
 			}
 		}
+	}
 
-		if len(packageFiles) == 0 {
-			return "", "", false, fmt.Errorf("no files found in package: %s", packagePath)
-		}
+	return indexDoc, filesWithAPI, g, nil
+}
 
-		// Convert to output.FileWithAPI interface
-		outFiles := make([]output.FileWithAPI, len(packageFiles))
-		for i := range packageFiles {
-			outFiles[i] = &fileWithAPIAdapter{file: &packageFiles[i]}
+// packageDocumentation collects the documentation of the package in directory pkgPath.
+// FileCount is zero if no scanned file belongs to the package.
+func packageDocumentation(pkgPath string, filesWithAPI []scanner.FileInfo, g *graph.Graph) output.PackageDocumentation {
+	// Filter files to only those in the package directory
+	packageFiles := []scanner.FileInfo{}
+	for _, file := range filesWithAPI {
+		// Extract directory from file path
+		fileDir := file.RelPath
+		if idx := strings.LastIndex(file.RelPath, "/"); idx >= 0 {
+			fileDir = file.RelPath[:idx]
 		}
 
-		// Build graph to get dependencies for this package
-		files, err := s.Scan(cfg.ScanPaths, scanner.ScanOptions{})
-		if err != nil {
-			return "", "", false, err
+		if fileDir == pkgPath {
+			packageFiles = append(packageFiles, file)
 		}
-		graphFiles := make([]graph.FileInfo, len(files))
-		for i, f := range files {
-			graphFiles[i] = f
-		}
-		g := graph.Build(graphFiles, cfg.Module)
-
-		// Collect dependencies from files in this package
-		packageDeps := make(map[string]output.Dependency)
-		for _, node := range g.Nodes {
-			// Check if this node is in our package
-			nodeDir := node.RelPath
-			if idx := strings.LastIndex(node.RelPath, "/"); idx >= 0 {
-				nodeDir = node.RelPath[:idx]
-			}
+	}
 
-			if nodeDir == packagePath {
-				// Add all dependencies from this file
-				for _, dep := range node.Dependencies {
-					key := dep.ImportPath
-					if dep.IsLocal {
-						key = dep.LocalPath
-					}
-					if _, exists := packageDeps[key]; !exists {
-						packageDeps[key] = &dep
-					}
-				}
-			}
-		}
+	if len(packageFiles) == 0 {
+		return output.PackageDocumentation{PackagePath: pkgPath}
+	}
 
-		// Convert to slice
-		deps := make([]output.Dependency, 0, len(packageDeps))
-		for _, dep := range packageDeps {
-			deps = append(deps, dep)
-		}
+	// Convert to output.FileWithAPI interface
+	outFiles := make([]output.FileWithAPI, len(packageFiles))
+	for i := range packageFiles {
+		outFiles[i] = &fileWithAPIAdapter{file: &packageFiles[i]}
+	}
 
-		// Create package documentation
-		packageName := packageFiles[0].Package
-		pkgDoc := output.PackageDocumentation{
-			PackageName:  packageName,
-			PackagePath:  packagePath,
-			Files:        outFiles,
-			Dependencies: deps,
-			FileCount:    len(packageFiles),
-			ExportCount:  0,
+	// Collect dependencies from files in this package
+	packageDeps := make(map[string]output.Dependency)
+	for _, node := range g.Nodes {
+		// Check if this node is in our package
+		nodeDir := node.RelPath
+		if idx := strings.LastIndex(node.RelPath, "/"); idx >= 0 {
+			nodeDir = node.RelPath[:idx]
 		}
 
-		// Count exports (excluding test functions)
-		for _, file := range outFiles {
-			// Skip test files
-			isTestFile := strings.HasSuffix(file.GetRelPath(), "_test.go")
-
-			for _, decl := range file.GetExportedDecls() {
-				isTestExport := strings.HasPrefix(decl.GetName(), "Test") || strings.HasPrefix(decl.GetName(), "Benchmark")
-
-				// Only count non-test exports
-				if !isTestFile && !isTestExport {
-					pkgDoc.ExportCount++
+		if nodeDir == pkgPath {
+			// Add all dependencies from this file
+			for _, dep := range node.Dependencies {
+				key := dep.ImportPath
+				if dep.IsLocal {
+					key = dep.LocalPath
+				}
+				if _, exists := packageDeps[key]; !exists {
+					packageDeps[key] = &dep
 				}
 			}
 		}
+	}
 
-		packageOutput := output.GeneratePackageDocumentation(pkgDoc)
-		return packageOutput, "", false, nil
+	// Convert to slice, sorted for stable output
+	keys := make([]string, 0, len(packageDeps))
+	for key := range packageDeps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	deps := make([]output.Dependency, 0, len(packageDeps))
+	for _, key := range keys {
+		deps = append(deps, packageDeps[key])
 	}
 
-	// Handle API format separately
-	if format == "api" {
-		s := scanner.New(projectPath, cfg.Module, cfg.IgnorePaths, cfg.ShouldLintTestFiles())
-		filesWithAPI, err := s.Scan(cfg.ScanPaths, scanner.ScanOptions{IncludeExportedAPI: true})
-		if err != nil {
-			return "", "", false, err
-		}
+	// Create package documentation
+	pkgDoc := output.PackageDocumentation{
+		PackageName:  packageFiles[0].Package,
+		PackagePath:  pkgPath,
+		Files:        outFiles,
+		Dependencies: deps,
+		Dependents:   packageDependents(pkgPath, g),
+		FileCount:    len(packageFiles),
+		ExportCount:  0,
+	}
 
-		// Convert to output.FileWithAPI interface
-		outFiles := make([]output.FileWithAPI, len(filesWithAPI))
-		for i := range filesWithAPI {
-			outFiles[i] = &fileWithAPIAdapter{file: &filesWithAPI[i]}
-		}
+	// Count exports (excluding test functions)
+	for _, file := range outFiles {
+		// Skip test files
+		isTestFile := strings.HasSuffix(file.GetRelPath(), "_test.go")
 
-		apiOutput := output.GenerateAPIMarkdown(outFiles)
-		return apiOutput, "", false, nil
-	}
+		for _, decl := range file.GetExportedDecls() {
+			isTestExport := strings.HasPrefix(decl.GetName(), "Test") || strings.HasPrefix(decl.GetName(), "Benchmark")
 
-	// Handle index format separately
-	if format == "index" {
-		s := scanner.New(projectPath, cfg.Module, cfg.IgnorePaths, cfg.ShouldLintTestFiles())
-		filesWithAPI, err := s.Scan(cfg.ScanPaths, scanner.ScanOptions{IncludeExportedAPI: true})
-		if err != nil {
-			return "", "", false, err
+			// Only count non-test exports
+			if !isTestFile && !isTestExport {
+				pkgDoc.ExportCount++
+			}
 		}
+	}
 
-		// Convert to output.FileWithAPI interface
-		outFiles := make([]output.FileWithAPI, len(filesWithAPI))
-		for i := range filesWithAPI {
-			outFiles[i] = &fileWithAPIAdapter{file: &filesWithAPI[i]}
-		}
+	return pkgDoc
+}
 
-		// Build a minimal graph just for statistics
-		files, err := s.Scan(cfg.ScanPaths, scanner.ScanOptions{})
-		if err != nil {
-			return "", "", false, err
-		}
-		graphFiles := make([]graph.FileInfo, len(files))
-		for i, f := range files {
-			graphFiles[i] = f
+// packageDependents returns the local packages importing pkgPath with the number of
+// their files that import it, sorted by path
+func packageDependents(pkgPath string, g *graph.Graph) []output.Dependent {
+	fileCounts := make(map[string]int)
+	for _, node := range g.Nodes {
+		dir := path.Dir(node.RelPath)
+		if dir == pkgPath {
+			continue
 		}
-		g := graph.Build(graphFiles, cfg.Module)
-
-		// Check which required directories exist
-		existingDirs := make(map[string]bool)
-		for dirPath := range cfg.Structure.RequiredDirectories {
-			fullPath := filepath.Join(projectPath, dirPath)
-			if info, err := os.Stat(fullPath); err == nil && info.IsDir() {
-				existingDirs[dirPath] = true
-			} else {
-				existingDirs[dirPath] = false
+		for _, dep := range node.Dependencies {
+			if dep.IsLocal && dep.LocalPath == pkgPath {
+				fileCounts[dir]++
+				break
 			}
 		}
+	}
 
-		// Count unique packages
-		packageSet := make(map[string]bool)
-		for _, node := range g.Nodes {
-			packageSet[node.Package] = true
-		}
-
-		// Create index documentation structure
-		indexDoc := output.FullDocumentation{
-			Structure: output.StructureInfo{
-				RequiredDirectories:   cfg.Structure.RequiredDirectories,
-				AllowOtherDirectories: cfg.Structure.AllowOtherDirectories,
-				ExistingDirs:          existingDirs,
-			},
-			Rules: output.RulesInfo{
-				DirectoriesImport: cfg.Rules.DirectoriesImport,
-				DetectUnused:      cfg.Rules.DetectUnused,
-			},
-			Graph:          &outputGraphAdapter{g: g},
-			Files:          outFiles,
-			Violations:     nil,
-			ViolationCount: 0, // Don't include violations in index
-			FileCount:      len(g.Nodes),
-			PackageCount:   len(packageSet),
-		}
-
-		indexOutput := output.GenerateIndexDocumentation(indexDoc)
-		return indexOutput, "", false, nil
+	dependents := make([]output.Dependent, 0, len(fileCounts))
+	for dir, count := range fileCounts {
+		dependents = append(dependents, output.Dependent{Path: dir, FileCount: count})
 	}
+	sort.Slice(dependents, func(i, j int) bool {
+		return dependents[i].Path < dependents[j].Path
+	})
+	return dependents
+}
 
-	// Scan files
-	s := scanner.New(projectPath, cfg.Module, cfg.IgnorePaths, cfg.ShouldLintTestFiles())
+// SplitDocumentation generates the architecture documentation as cross-linked pages:
+// index.md, one page per layer and one page per package. It returns the content of
+// each page keyed by its path relative to the output directory.
+func SplitDocumentation(projectPath string) (map[string]string, error) {
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return nil, err
+	}
 
-	var g *graph.Graph
+	indexDoc, filesWithAPI, g, err := indexDocumentation(projectPath, cfg, false)
+	if err != nil {
+		return nil, err
+	}
 
-	if detailed {
-		// Scan with detailed symbol tracking
-		detailedFiles, err := s.Scan(cfg.ScanPaths, scanner.ScanOptions{IncludeImportUsages: true})
-		if err != nil {
-			return "", "", false, err
-		}
+	pkgPaths := make(map[string]bool)
+	for _, file := range filesWithAPI {
+		pkgPaths[path.Dir(file.RelPath)] = true
+	}
 
-		// Convert to graph.FileInfo interface
-		graphFiles := make([]graph.FileInfo, len(detailedFiles))
-		for i := range detailedFiles {
-			graphFiles[i] = detailedFiles[i]
-		}
+	packages := make([]output.PackageDocumentation, 0, len(pkgPaths))
+	for pkgPath := range pkgPaths {
+		packages = append(packages, packageDocumentation(pkgPath, filesWithAPI, g))
+	}
 
-		// Build usage map: file RelPath -> (import path -> used symbols)
-		usageMap := make(map[string]map[string][]string)
-		for _, file := range detailedFiles {
-			fileUsageMap := make(map[string][]string)
-			for _, usage := range file.ImportUsages {
-				fileUsageMap[usage.ImportPath] = usage.UsedSymbols
-			}
-			usageMap[file.RelPath] = fileUsageMap
-		}
+	return output.GenerateSplitDocumentation(indexDoc, packages), nil
+}
 
-		// Build detailed dependency graph
-		g = graph.BuildDetailed(graphFiles, cfg.Module, usageMap)
-	} else {
-		// Standard scan
-		files, err := s.Scan(cfg.ScanPaths, scanner.ScanOptions{})
-		if err != nil {
-			return "", "", false, err
+// renderDocumentation renders an "index" or "full" document with the user's template
+// <document>.md.tmpl from docs.templates_dir, falling back to the built-in layout
+func renderDocumentation(projectPath string, cfg *config.Config, document string, doc output.FullDocumentation) (string, error) {
+	if templatesDir := cfg.GetDocsTemplatesDir(); templatesDir != "" {
+		if !filepath.IsAbs(templatesDir) {
+			templatesDir = filepath.Join(projectPath, templatesDir)
 		}
-
-		// Convert scanner.FileInfo to graph.FileInfo interface
-		graphFiles := make([]graph.FileInfo, len(files))
-		for i, f := range files {
-			graphFiles[i] = f
+		text, err := os.ReadFile(filepath.Join(templatesDir, document+".md.tmpl"))
+		if err == nil {
+			return output.RenderDocumentTemplate(document, string(text), doc)
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to read %s template: %w", document, err)
 		}
-
-		// Build dependency graph
-		g = graph.Build(graphFiles, cfg.Module)
 	}
 
-	// Run coverage analysis if enabled
-	validatorGraph := &graphAdapter{g: g}
-	v := validator.NewWithPath(cfg, validatorGraph, projectPath)
+	if document == "index" {
+		return output.GenerateIndexDocumentation(doc), nil
+	}
+	return output.GenerateFullDocumentation(doc), nil
+}
 
-	if cfg.IsCoverageEnabled() {
-		coverageRunner := coverage.New(projectPath, cfg.Module)
-		coverageResults, err := coverageRunner.Run(cfg.ScanPaths)
-		if err != nil {
-			// Log error but don't fail - coverage might not be critical
-			fmt.Printf("Warning: Failed to run coverage analysis: %v\n", err)
-		} else {
-			// Display coverage summary
-			summaries := coverage.SummarizeByDirectory(coverageResults, cfg.Module, cfg.ScanPaths)
-			overallCoverage := coverage.CalculateOverallCoverage(coverageResults)
-			coverage.PrintSummary(summaries, overallCoverage)
-
-			// Convert to validator.PackageCoverage interface
-			validatorCoverage := make([]validator.PackageCoverage, len(coverageResults))
-			for i := range coverageResults {
-				validatorCoverage[i] = coverageResults[i]
-			}
-			v.SetCoverageResults(validatorCoverage)
+// collectExportedSignatures type-checks the packages in the type_leaks layers
+func collectExportedSignatures(checker *typecheck.Checker, cfg *config.Config, g *graph.Graph) ([]validator.ExportedSignature, error) {
+	dirSet := make(map[string]bool)
+	var dirs []string
+	for _, node := range g.Nodes {
+		dir := path.Dir(node.RelPath)
+		if node.IsTest || dirSet[dir] {
+			continue
+		}
+		if isWithinLayer(dir, cfg.GetTypeLeakLayers()) {
+			dirSet[dir] = true
+			dirs = append(dirs, dir)
 		}
 	}
 
-	violations := v.Validate()
-
-	// Convert violations to output.Violation interface
-	outViolations := make([]output.Violation, len(violations))
-	for i, viol := range violations {
-		outViolations[i] = viol
+	signatures, err := checker.ExportedSignatures(dirs)
+	if err != nil {
+		return nil, err
 	}
 
-	// Output dependency graph using adapter
-	var graphOutput string
-	if format == "markdown" {
-		outputGraph := &outputGraphAdapter{g: g}
-		graphOutput = output.GenerateMarkdown(outputGraph)
-	} else if format == "full" || format == "docs" {
-		// Generate comprehensive documentation
-		graphOutput = generateFullDocumentation(projectPath, cfg, g, violations)
+	// Convert to validator.ExportedSignature interface
+	result := make([]validator.ExportedSignature, len(signatures))
+	for i := range signatures {
+		result[i] = &signatureAdapter{sig: &signatures[i]}
 	}
+	return result, nil
+}
 
-	// Format violations with architectural context from config
-	var violationsOutput string
-	errorPrompt := cfg.GetErrorPrompt()
-	if errorPrompt.Enabled {
-		// Create error context from config
-		errorContext := &output.ErrorContext{
-			Enabled:                  true,
-			PresetName:               cfg.PresetUsed,
-			ArchitecturalGoals:       errorPrompt.ArchitecturalGoals,
-			Principles:               errorPrompt.Principles,
-			RefactoringGuidance:      errorPrompt.RefactoringGuidance,
-			CoverageGuidance:         errorPrompt.CoverageGuidance,
-			TestNamingGuidance:       errorPrompt.TestNamingGuidance,
-			BlackboxTestingGuidance:  errorPrompt.BlackboxTestingGuidance,
-		}
-		violationsOutput = output.FormatViolationsWithContext(outViolations, errorContext)
-	} else {
-		// Error prompt disabled, use standard formatting
-		violationsOutput = output.FormatViolations(outViolations)
+// collectInterfaces type-checks the project and returns the interfaces declared in the
+// orphan_interfaces layers and the adapter_ports port layers, with the non-test types
+// implementing them
+func collectInterfaces(checker *typecheck.Checker, cfg *config.Config, g *graph.Graph) ([]validator.InterfaceDecl, error) {
+	interfaces, err := typecheckInterfaces(checker, g, func(dir string) bool {
+		return isWithinLayer(dir, cfg.GetOrphanInterfaceLayers()) || isWithinLayer(dir, cfg.GetAdapterPortLayers())
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Determine if violations should cause build failure (respect warn mode)
-	shouldFail := shouldFailBuild(violations, cfg)
+	// Convert to validator.InterfaceDecl interface
+	result := make([]validator.InterfaceDecl, len(interfaces))
+	for i := range interfaces {
+		result[i] = interfaces[i]
+	}
+	return result, nil
+}
 
-	// Run staticcheck if enabled (either via config or CLI flag)
-	var staticcheckFailed bool
-	if runStaticcheck || cfg.ShouldRunStaticcheck() {
-		staticcheckOutput, hasIssues, err := runStaticcheckTool(projectPath)
-		if err != nil {
-			// If staticcheck is not available or fails to run, show error but don't fail build
-			staticcheckOutput = fmt.Sprintf("\n⚠ Staticcheck error: %v\n", err)
+// collectPortImplementations type-checks the port and adapter layers and returns the
+// ports each non-test type of the adapter layers implements
+func collectPortImplementations(checker *typecheck.Checker, cfg *config.Config, g *graph.Graph) ([]validator.PortImplementation, error) {
+	dirSet := make(map[string]bool)
+	var portDirs, adapterDirs []string
+	for _, node := range g.Nodes {
+		dir := path.Dir(node.RelPath)
+		if node.IsTest || dirSet[dir] {
+			continue
 		}
-		if staticcheckOutput != "" {
-			// Append staticcheck output to violations
-			if violationsOutput != "" {
-				violationsOutput += "\n"
-			}
-			violationsOutput += staticcheckOutput
+		dirSet[dir] = true
+		if isWithinLayer(dir, cfg.GetAdapterPortLayers()) {
+			portDirs = append(portDirs, dir)
 		}
-		if hasIssues {
-			staticcheckFailed = true
+		if isWithinLayer(dir, cfg.GetAdapterLayers()) {
+			adapterDirs = append(adapterDirs, dir)
 		}
 	}
+	if len(portDirs) == 0 || len(adapterDirs) == 0 {
+		return nil, nil
+	}
 
-	// Update shouldFail to include staticcheck failures
-	if staticcheckFailed {
-		shouldFail = true
+	implementations, err := checker.Implementations(portDirs, adapterDirs)
+	if err != nil {
+		return nil, err
 	}
 
-	return graphOutput, violationsOutput, shouldFail, nil
+	// Convert to validator.PortImplementation interface
+	result := make([]validator.PortImplementation, len(implementations))
+	for i := range implementations {
+		result[i] = implementations[i]
+	}
+	return result, nil
 }
 
-// generateFullDocumentation creates comprehensive documentation combining structure, rules, dependencies, and API
-func generateFullDocumentation(projectPath string, cfg *config.Config, g *graph.Graph, violations []validator.Violation) string {
-	// Scan for public API
-	s := scanner.New(projectPath, cfg.Module, cfg.IgnorePaths, cfg.ShouldLintTestFiles())
-	filesWithAPI, err := s.Scan(cfg.ScanPaths, scanner.ScanOptions{IncludeExportedAPI: true})
-	if err != nil {
-		// Fallback to empty API if scan fails
-		filesWithAPI = []scanner.FileInfo{}
+// layerSizes sums the packages, files, lines and bytes of the scanned code per
+// directories_import layer for the docs, configured layers by name and the packages
+// outside them last
+func layerSizes(cfg *config.Config, g *graph.Graph) []output.LayerSize {
+	rules := cfg.GetDirectoriesImport()
+	sizes := make(map[string]*output.LayerSize)
+	packages := make(map[string]bool)
+	for _, node := range g.Nodes {
+		dir := path.Dir(node.RelPath)
+		layer := layerOf(dir, rules)
+		size := sizes[layer]
+		if size == nil {
+			size = &output.LayerSize{Layer: layer}
+			sizes[layer] = size
+		}
+		if !packages[dir] {
+			packages[dir] = true
+			size.Packages++
+		}
+		size.Files++
+		size.Lines += node.LineCount
+		size.Size += node.Size
 	}
 
-	// Convert to output.FileWithAPI interface
-	outFiles := make([]output.FileWithAPI, len(filesWithAPI))
-	for i := range filesWithAPI {
-		outFiles[i] = &fileWithAPIAdapter{file: &filesWithAPI[i]}
+	result := make([]output.LayerSize, 0, len(sizes))
+	for _, size := range sizes {
+		result = append(result, *size)
 	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].Layer == "") != (result[j].Layer == "") {
+			return result[j].Layer == ""
+		}
+		return result[i].Layer < result[j].Layer
+	})
+	return result
+}
 
-	// Check which required directories exist
-	existingDirs := make(map[string]bool)
-	for dirPath := range cfg.Structure.RequiredDirectories {
-		fullPath := filepath.Join(projectPath, dirPath)
-		if info, err := os.Stat(fullPath); err == nil && info.IsDir() {
-			existingDirs[dirPath] = true
-		} else {
-			existingDirs[dirPath] = false
+// collectPorts returns the interfaces of the port layers with the types implementing them
+// for the docs. Port layers default to directories named ports or domain. Type checking
+// is best-effort: on failure the section is left out.
+func collectPorts(checker *typecheck.Checker, cfg *config.Config, g *graph.Graph) []output.Port {
+	interfaces, err := typecheckInterfaces(checker, g, func(dir string) bool {
+		if layers := cfg.GetPortLayers(); len(layers) > 0 {
+			return isWithinLayer(dir, layers)
+		}
+		for _, segment := range strings.Split(dir, "/") {
+			if segment == "ports" || segment == "domain" {
+				return true
+			}
 		}
+		return false
+	})
+	if err != nil {
+		return nil
 	}
 
-	// Count unique packages
-	packageSet := make(map[string]bool)
-	for _, node := range g.Nodes {
-		packageSet[node.Package] = true
+	ports := make([]output.Port, len(interfaces))
+	for i, iface := range interfaces {
+		ports[i] = output.Port{
+			Interface:    iface.Name,
+			Package:      path.Dir(iface.File),
+			Implementers: iface.Implementers,
+		}
 	}
+	return ports
+}
 
-	// Create full documentation structure
-	fullDoc := output.FullDocumentation{
-		Structure: output.StructureInfo{
-			RequiredDirectories:   cfg.Structure.RequiredDirectories,
-			AllowOtherDirectories: cfg.Structure.AllowOtherDirectories,
-			ExistingDirs:          existingDirs,
-		},
-		Rules: output.RulesInfo{
-			DirectoriesImport: cfg.Rules.DirectoriesImport,
-			DetectUnused:      cfg.Rules.DetectUnused,
-		},
-		Graph:          &outputGraphAdapter{g: g},
-		Files:          outFiles,
-		Violations:     nil, // Not included in output, shown separately in stderr
-		ViolationCount: len(violations),
-		FileCount:      len(g.Nodes),
-		PackageCount:   len(packageSet),
+// typecheckInterfaces type-checks the non-test packages of the graph and returns the
+// interfaces declared in the directories accepted by inLayer
+func typecheckInterfaces(checker *typecheck.Checker, g *graph.Graph, inLayer func(dir string) bool) ([]typecheck.Interface, error) {
+	dirSet := make(map[string]bool)
+	var dirs, implementationDirs []string
+	for _, node := range g.Nodes {
+		dir := path.Dir(node.RelPath)
+		if node.IsTest || dirSet[dir] {
+			continue
+		}
+		dirSet[dir] = true
+		implementationDirs = append(implementationDirs, dir)
+		if inLayer(dir) {
+			dirs = append(dirs, dir)
+		}
 	}
+	if len(dirs) == 0 {
+		return nil, nil
+	}
+
+	return checker.Interfaces(dirs, implementationDirs)
+}
 
-	return output.GenerateFullDocumentation(fullDoc)
+// isWithinLayer reports whether dir is one of the layer directories or below one
+func isWithinLayer(dir string, layers []string) bool {
+	for _, layer := range layers {
+		layer = strings.TrimSuffix(layer, "/")
+		if dir == layer || strings.HasPrefix(dir, layer+"/") {
+			return true
+		}
+	}
+	return false
 }
 
 // shouldFailBuild determines if violations should cause build failure
@@ -569,6 +1669,10 @@ func shouldFailBuild(violations []validator.Violation, cfg *config.Config) bool
 	sharedImportsMode := cfg.GetSharedExternalImportsMode()
 
 	for _, viol := range violations {
+		// Warnings and informational findings never fail the build
+		if !viol.IsError() {
+			continue
+		}
 		// If any violation is NOT a shared external import, fail
 		if viol.Type != validator.ViolationSharedExternalImport {
 			return true
@@ -583,6 +1687,31 @@ func shouldFailBuild(violations []validator.Violation, cfg *config.Config) bool
 	return false
 }
 
+// failsBuild returns a check whether a single violation fails the build, with inline
+// suppressions, scheduled rules, CI mode and the shared external imports mode taken into
+// account as in shouldFailBuild
+func failsBuild(cfg *config.Config, suppressions []inlineSuppression, ci bool, now time.Time) func(validator.Violation) bool {
+	return func(viol validator.Violation) bool {
+		single := applyInlineSuppressions([]validator.Violation{viol}, suppressions, now)
+		applyRuleActivation(cfg, single, now)
+		applyReplaceDirectiveMode(single, ci)
+		return shouldFailBuild(single, cfg)
+	}
+}
+
+// containsFailure reports whether fails is set and reports true for any of the violations
+func containsFailure(violations []validator.Violation, fails func(validator.Violation) bool) bool {
+	if fails == nil {
+		return false
+	}
+	for _, viol := range violations {
+		if fails(viol) {
+			return true
+		}
+	}
+	return false
+}
+
 const defaultConfig = `# go-arch-lint configuration
 #
 # This configuration enforces a strict 3-layer architecture:
@@ -590,6 +1719,9 @@ const defaultConfig = `# go-arch-lint configuration
 # - pkg: Public APIs and orchestration, can import from internal
 # - internal: Domain primitives with complete isolation (cannot import each other)
 
+# Schema version of this file; newer go-arch-lint versions check it before reading the rules
+version: 1
+
 # Validation rules
 rules:
   # Define what each directory type can import
@@ -681,8 +1813,21 @@ Example: ` + "`internal/A`" + ` imports ` + "`internal/B`" + ` → Should B's lo
 Run ` + "`go-arch-lint .`" + ` frequently during development. Zero violations required.
 `
 
+// InitOptions configures InitWithOptions
+type InitOptions struct {
+	Preset       string // Architecture preset (empty or "custom" for the default configuration)
+	CreateDirs   bool   // Create the preset's required directories
+	WithExamples bool   // Write the preset's example code into the required directories
+}
+
 // Init initializes a new go-arch-lint project with default configuration and documentation
 func Init(projectPath, preset string, createDirs bool) error {
+	return InitWithOptions(projectPath, InitOptions{Preset: preset, CreateDirs: createDirs})
+}
+
+// InitWithOptions initializes a new go-arch-lint project with the given options
+func InitWithOptions(projectPath string, opts InitOptions) error {
+	preset, createDirs := opts.Preset, opts.CreateDirs
 	// Check if .goarchlint already exists
 	configPath := filepath.Join(projectPath, ".goarchlint")
 	if _, err := os.Stat(configPath); err == nil {
@@ -727,6 +1872,13 @@ func Init(projectPath, preset string, createDirs bool) error {
 	}
 	fmt.Println("✓ Created docs/goarch_agent_instructions.md")
 
+	// Write example code before documentation generation so the docs describe it
+	if opts.WithExamples {
+		if err := initExamples(projectPath, preset); err != nil {
+			return err
+		}
+	}
+
 	// Check if go.mod exists - needed for documentation generation
 	goModPath := filepath.Join(projectPath, "go.mod")
 	if _, err := os.Stat(goModPath); os.IsNotExist(err) {
@@ -2,13 +2,32 @@ package linter_test
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/kgatilin/go-arch-lint/pkg/linter"
 )
 
+// writeProject writes files, keyed by their path relative to the project root, into a
+// temporary directory and returns it
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	for relPath, content := range files {
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmpDir
+}
+
 func TestRun_MarkdownFormat(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -22,7 +41,6 @@ scan_paths:
   - cmd
   - pkg
 ignore_paths: []
-detect_unused: false
 `
 	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
 		t.Fatal(err)
@@ -94,6 +112,79 @@ func Run() {}
 	}
 }
 
+func TestRunWithOptions_DetailedMarkdownCouplings(t *testing.T) {
+	files := map[string]string{
+		".goarchlint":            "module: github.com/test/project\nrules:\n  directories_import:\n    cmd: [pkg]\n    pkg: []\nscan_paths:\n  - cmd\n  - pkg\n",
+		"cmd/app/main.go":        "package main\n\nimport \"github.com/test/project/pkg/service\"\n\nfunc main() {\n\tservice.Run()\n\tservice.Run()\n\tservice.Stop()\n}\n",
+		"cmd/app/flags.go":       "package main\n\nimport \"github.com/test/project/pkg/store\"\n\nvar _ = store.Open\n",
+		"pkg/service/service.go": "package service\n\nimport \"github.com/test/project/pkg/store\"\n\nfunc Run() { store.Open() }\n\nfunc Stop() {}\n",
+		"pkg/store/store.go":     "package store\n\nfunc Open() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	graphOutput, _, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "markdown", Detailed: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+
+	// Edges carry their weight; the report only lists cross-layer couplings, heaviest first
+	expected := []string{
+		"  - local:pkg/service (3 call sites)\n",
+		"| `cmd/app` (cmd) | `pkg/service` (pkg) | 3 | 2 | 1 |\n| `cmd/app` (cmd) | `pkg/store` (pkg) | 1 | 1 | 1 |\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(graphOutput, want) {
+			t.Errorf("expected %q in detailed graph, got:\n%s", want, graphOutput)
+		}
+	}
+	if strings.Contains(graphOutput, "\n| `pkg/service` (pkg) |") {
+		t.Errorf("expected the coupling within the pkg layer to be left out, got:\n%s", graphOutput)
+	}
+
+	// Without -detailed there are no weights
+	graphOutput, _, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "markdown"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if strings.Contains(graphOutput, "call site") || strings.Contains(graphOutput, "Strongest Couplings") {
+		t.Errorf("expected no weights without -detailed, got:\n%s", graphOutput)
+	}
+}
+
+func TestRunWithOptions_FocusedMarkdown(t *testing.T) {
+	files := map[string]string{
+		".goarchlint":               "module: github.com/test/project\nscan_paths:\n  - cmd\n  - internal\n",
+		"cmd/app/main.go":           "package main\n\nimport _ \"github.com/test/project/internal/app/api\"\n\nfunc main() {}\n",
+		"internal/app/api/api.go":   "package api\n\nimport _ \"github.com/test/project/internal/app/core\"\n",
+		"internal/app/core/core.go": "package core\n\nimport _ \"github.com/test/project/internal/store\"\n",
+		"internal/store/store.go":   "package store\n\nimport _ \"github.com/test/project/internal/db\"\n",
+		"internal/db/db.go":         "package db\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	graphOutput, _, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "markdown", Focus: "internal/app/**", Depth: 1})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	for _, want := range []string{"## cmd/app/main.go", "## internal/app/api/api.go", "## internal/app/core/core.go", "## internal/store/store.go\ndepends on: (none)"} {
+		if !strings.Contains(graphOutput, want) {
+			t.Errorf("expected %q in focused graph, got:\n%s", want, graphOutput)
+		}
+	}
+	if strings.Contains(graphOutput, "internal/db") {
+		t.Errorf("expected internal/db beyond the depth to be left out, got:\n%s", graphOutput)
+	}
+
+	_, _, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "api", Focus: "internal/app/**"})
+	if err == nil || !strings.Contains(err.Error(), "-focus is only supported with -format=markdown") {
+		t.Errorf("expected error for -focus with another format, got %v", err)
+	}
+	_, _, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "markdown", Focus: "internal/missing"})
+	if err == nil || !strings.Contains(err.Error(), "no packages match") {
+		t.Errorf("expected error for unmatched focus, got %v", err)
+	}
+}
+
 func TestRun_APIFormat(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -106,7 +197,6 @@ func TestRun_APIFormat(t *testing.T) {
 scan_paths:
   - pkg
 ignore_paths: []
-detect_unused: false
 `
 	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
 		t.Fatal(err)
@@ -176,6 +266,56 @@ const Version = "1.0"
 	}
 }
 
+func TestRun_SymbolsIndexFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(relPath, content string) {
+		t.Helper()
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module github.com/test/project\n\ngo 1.21\n")
+	write(".goarchlint", "rules:\n  directories_import:\n    pkg: []\n  test_files:\n    lint: true\n")
+	write("pkg/api/api.go", `package api
+
+// Client calls the service. It is safe for concurrent use.
+type Client struct {
+	Name string
+}
+
+// Do sends a request
+func (c *Client) Do() error {
+	return nil
+}
+
+type hidden struct{}
+
+// Skipped is a method of an unexported type
+func (hidden) Skipped() {}
+`)
+	write("pkg/api/api_test.go", "package api_test\n\nimport \"testing\"\n\nfunc TestDo(t *testing.T) {}\n")
+
+	indexOutput, violationsOutput, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "symbols-index", ASCII: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if violationsOutput != "" {
+		t.Errorf("expected no violations for the symbols index, got: %s", violationsOutput)
+	}
+
+	want := []string{
+		`{"id":"github.com/test/project/pkg/api.Client","package":"github.com/test/project/pkg/api","name":"Client","kind":"type","type_kind":"struct","signature":"Client","doc":"Client calls the service.","file":"pkg/api/api.go","line":4}`,
+		`{"id":"github.com/test/project/pkg/api.Client.Do","package":"github.com/test/project/pkg/api","name":"Client.Do","kind":"func","signature":"(*Client) Do() error","doc":"Do sends a request","file":"pkg/api/api.go","line":9}`,
+	}
+	if indexOutput != strings.Join(want, "\n")+"\n" {
+		t.Errorf("unexpected symbols index:\n%s", indexOutput)
+	}
+}
+
 func TestRun_WithViolations(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -188,7 +328,6 @@ func TestRun_WithViolations(t *testing.T) {
 scan_paths:
   - pkg
 ignore_paths: []
-detect_unused: false
 `
 	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
 		t.Fatal(err)
@@ -254,6 +393,87 @@ func Helper() {}
 	}
 }
 
+func TestRun_GermanReport(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+scan_paths:
+  - internal
+`,
+		"internal/order/order.go": "package order\n\nimport \"github.com/test/project/internal/store\"\n\nfunc Place() { store.Save() }\n",
+		"internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violationsOutput, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "markdown", Lang: "de"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+
+	// The type, issue, rule and fix reported by the validator are translated too
+	expected := []string{
+		"[ERROR] Verbotener Import\n",
+		"  Datei: internal/order/order.go:3:8\n",
+		"  Problem: internal/order importiert internal/store\n",
+		"  Regel: internal darf nur importieren aus: []\n",
+		"  Behebung: Verwende Interfaces und Dependency Inversion statt direkter Importe\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(violationsOutput, want) {
+			t.Errorf("expected the report to contain %q, got:\n%s", want, violationsOutput)
+		}
+	}
+	if strings.Contains(violationsOutput, "imports") {
+		t.Errorf("expected no English violation texts, got:\n%s", violationsOutput)
+	}
+}
+
+func TestRun_FixCodesPerFixText(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: []
+    internal: []
+scan_paths:
+  - cmd
+  - internal
+`,
+		"cmd/a/main.go":           "package main\n\nimport _ \"github.com/test/project/internal/store\"\n\nfunc main() {}\n",
+		"cmd/b/main.go":           "package main\n\nimport _ \"github.com/test/project/internal/store\"\n\nfunc main() {}\n",
+		"internal/order/order.go": "package order\n\nimport _ \"github.com/test/project/internal/store\"\n",
+		"internal/bill/bill.go":   "package bill\n\nimport _ \"github.com/test/project/internal/store\"\n",
+		"internal/store/store.go": "package store\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, report, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "markdown"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	_, quiet, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "markdown", Quiet: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+
+	// The forbidden imports of cmd and internal have different fixes, so each gets a code
+	footnotes := regexp.MustCompile(`(?m)^  (ARCH005-[0-9a-f]{6}): `).FindAllStringSubmatch(report, -1)
+	if len(footnotes) != 2 || footnotes[0][1] == footnotes[1][1] {
+		t.Fatalf("expected footnotes for two ARCH005 fixes, got:\n%s", report)
+	}
+	for _, footnote := range footnotes {
+		code := footnote[1]
+		if strings.Count(report, "Fix: see "+code+"\n") != 2 {
+			t.Errorf("expected two violations to reference %s, got:\n%s", code, report)
+		}
+		if strings.Count(quiet, " "+code+" ") != 2 {
+			t.Errorf("expected the quiet output to use %s twice, got:\n%s", code, quiet)
+		}
+	}
+}
+
 func TestRun_InvalidConfig(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -515,165 +735,2110 @@ func Query() error {
 		t.Fatal(err)
 	}
 
-	// Run linter
-	_, violationsOutput, _, err := linter.Run(tmpDir, "markdown", false, false, "")
+	// Run linter
+	_, violationsOutput, _, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Should NOT have violation (matches glob pattern)
+	if strings.Contains(violationsOutput, "github.com/pkg/errors") {
+		t.Error("github.com/pkg/errors should match glob pattern github.com/pkg/*, but was flagged")
+	}
+}
+
+func TestRun_SharedExternalImports_ErrorMode(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create config with mode: error
+	configYAML := `rules:
+  directories_import:
+    cmd: [internal]
+    internal: []
+  detect_unused: false
+  shared_external_imports:
+    detect: true
+    mode: error
+scan_paths:
+  - cmd
+  - internal
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	goMod := `module github.com/test/project
+
+go 1.21
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create files with shared external import
+	cmdDir := filepath.Join(tmpDir, "cmd")
+	if err := os.MkdirAll(cmdDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	mainGo := `package main
+
+import "github.com/pkg/errors"
+
+func main() {
+	err := errors.New("test")
+	_ = err
+}
+`
+	if err := os.WriteFile(filepath.Join(cmdDir, "main.go"), []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	internalDir := filepath.Join(tmpDir, "internal")
+	if err := os.MkdirAll(internalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	repoGo := `package internal
+
+import "github.com/pkg/errors"
+
+func Query() error {
+	return errors.New("error")
+}
+`
+	if err := os.WriteFile(filepath.Join(internalDir, "repo.go"), []byte(repoGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run linter
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Should have violation
+	if !strings.Contains(violationsOutput, "Shared External Import") {
+		t.Error("expected shared external import violation")
+	}
+
+	// SHOULD fail in error mode
+	if !shouldFail {
+		t.Error("expected shouldFail=true in error mode, got false")
+	}
+}
+
+func TestRun_SharedExternalImports_SingleLayer(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create config
+	configYAML := `rules:
+  directories_import:
+    cmd: [internal]
+    internal: []
+  detect_unused: false
+  shared_external_imports:
+    detect: true
+    mode: error
+scan_paths:
+  - internal
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	goMod := `module github.com/test/project
+
+go 1.21
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create multiple files in SAME layer importing same external package
+	internalDir := filepath.Join(tmpDir, "internal")
+	if err := os.MkdirAll(internalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	repo1Go := `package internal
+
+import "github.com/pkg/errors"
+
+func Query() error {
+	return errors.New("error")
+}
+`
+	if err := os.WriteFile(filepath.Join(internalDir, "repo1.go"), []byte(repo1Go), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo2Go := `package internal
+
+import "github.com/pkg/errors"
+
+func Save() error {
+	return errors.New("save error")
+}
+`
+	if err := os.WriteFile(filepath.Join(internalDir, "repo2.go"), []byte(repo2Go), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run linter
+	_, violationsOutput, _, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Should NOT have violation (same layer is OK)
+	if strings.Contains(violationsOutput, "Shared External Import") {
+		t.Errorf("expected no shared external import violation for same layer, got: %s", violationsOutput)
+	}
+}
+
+func TestRun_DetectDuplicates(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create config
+	configYAML := `rules:
+  directories_import:
+    pkg: [internal]
+    internal: []
+  detect_unused: false
+  detect_duplicates: true
+scan_paths:
+  - pkg
+  - internal
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	goMod := `module github.com/test/project
+
+go 1.21
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Same struct copied into two layers
+	internalDir := filepath.Join(tmpDir, "internal", "user")
+	pkgDir := filepath.Join(tmpDir, "pkg", "api")
+	for _, dir := range []string{internalDir, pkgDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	userGo := `package user
+
+type User struct {
+	ID    string
+	Email string
+}
+`
+	if err := os.WriteFile(filepath.Join(internalDir, "user.go"), []byte(userGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dtoGo := `package api
+
+type UserResponse struct {
+	ID    string
+	Email string
+}
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "dto.go"), []byte(dtoGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run linter
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(violationsOutput, "[INFO] Duplicate Definition") {
+		t.Errorf("expected informational duplicate definition, got: %s", violationsOutput)
+	}
+	if !strings.Contains(violationsOutput, "Keep the definition in internal/user") {
+		t.Errorf("expected canonical home suggestion, got: %s", violationsOutput)
+	}
+
+	// Informational findings must not fail the build
+	if shouldFail {
+		t.Error("expected duplicate definitions not to fail the build")
+	}
+}
+
+func TestRun_WrapIn(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create config
+	configYAML := `rules:
+  directories_import:
+    pkg: [internal]
+    internal: []
+  detect_unused: false
+  wrap_in:
+    github.com/aws/aws-sdk-go-v2: internal/storage
+scan_paths:
+  - pkg
+  - internal
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	goMod := `module github.com/test/project
+
+go 1.21
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	storageDir := filepath.Join(tmpDir, "internal", "storage")
+	uploadDir := filepath.Join(tmpDir, "pkg", "upload")
+	for _, dir := range []string{storageDir, uploadDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Wrapper leaks the SDK client through its public API
+	storageGo := `package storage
+
+import "github.com/aws/aws-sdk-go-v2/service/s3"
+
+func NewClient() *s3.Client {
+	return nil
+}
+`
+	if err := os.WriteFile(filepath.Join(storageDir, "storage.go"), []byte(storageGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Package bypassing the wrapper entirely
+	uploadGo := `package upload
+
+import "github.com/aws/aws-sdk-go-v2/service/s3"
+
+var input s3.PutObjectInput
+`
+	if err := os.WriteFile(filepath.Join(uploadDir, "upload.go"), []byte(uploadGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run linter
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(violationsOutput, "Unwrapped External Import") || !strings.Contains(violationsOutput, "pkg/upload/upload.go") {
+		t.Errorf("expected unwrapped import violation for pkg/upload, got: %s", violationsOutput)
+	}
+	if !strings.Contains(violationsOutput, "Anti-corruption Layer Bypass") || !strings.Contains(violationsOutput, "internal/storage/storage.go:5") {
+		t.Errorf("expected wrapper bypass violation for internal/storage, got: %s", violationsOutput)
+	}
+	if !shouldFail {
+		t.Error("expected wrap_in violations to fail the build")
+	}
+}
+
+func TestRun_TypeLeaks(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create config
+	configYAML := `rules:
+  directories_import:
+    internal: []
+  detect_unused: false
+  type_leaks:
+    layers: [internal/domain]
+    forbidden: [github.com/jackc/pgx]
+scan_paths:
+  - internal
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	goMod := `module github.com/test/project
+
+go 1.21
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	domainDir := filepath.Join(tmpDir, "internal", "domain")
+	if err := os.MkdirAll(domainDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// pgx is used internally (allowed) and in an exported signature (leak)
+	orderGo := `package domain
+
+import "github.com/jackc/pgx/v5"
+
+func Save(tx pgx.Tx) error {
+	return nil
+}
+
+func count() int {
+	var rows pgx.Rows
+	_ = rows
+	return 0
+}
+`
+	if err := os.WriteFile(filepath.Join(domainDir, "order.go"), []byte(orderGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run linter
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(violationsOutput, "Type Leak in Exported Signature") {
+		t.Errorf("expected type leak violation, got: %s", violationsOutput)
+	}
+	if !strings.Contains(violationsOutput, "internal/domain/order.go:5") {
+		t.Errorf("expected violation on Save, got: %s", violationsOutput)
+	}
+	if strings.Contains(violationsOutput, "Rows") {
+		t.Errorf("expected unexported usage not to be reported, got: %s", violationsOutput)
+	}
+	if !shouldFail {
+		t.Error("expected type leaks to fail the build")
+	}
+}
+
+func TestRun_ConfigLoadingConfinement(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create config (allowed directories default to cmd)
+	configYAML := `rules:
+  directories_import:
+    cmd: [internal]
+    internal: []
+  detect_unused: false
+  config_loading:
+    enabled: true
+scan_paths:
+  - cmd
+  - internal
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	goMod := `module github.com/test/project
+
+go 1.21
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmdDir := filepath.Join(tmpDir, "cmd", "app")
+	serviceDir := filepath.Join(tmpDir, "internal", "service")
+	for _, dir := range []string{cmdDir, serviceDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mainGo := `package main
+
+import "os"
+
+func main() {
+	_ = os.Getenv("PORT")
+}
+`
+	if err := os.WriteFile(filepath.Join(cmdDir, "main.go"), []byte(mainGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	serviceGo := `package service
+
+import "os"
+
+func Timeout() string {
+	return os.Getenv("TIMEOUT")
+}
+`
+	if err := os.WriteFile(filepath.Join(serviceDir, "service.go"), []byte(serviceGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run linter
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(violationsOutput, "Scattered Configuration Loading") || !strings.Contains(violationsOutput, "internal/service/service.go") {
+		t.Errorf("expected configuration loading violation for internal/service, got: %s", violationsOutput)
+	}
+	if strings.Contains(violationsOutput, "cmd/app/main.go") {
+		t.Errorf("expected cmd to be allowed to read the environment, got: %s", violationsOutput)
+	}
+	if !shouldFail {
+		t.Error("expected configuration loading violations to fail the build")
+	}
+}
+
+func TestRun_FrameworkLockIn(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create config
+	configYAML := `rules:
+  directories_import:
+    internal: []
+  detect_unused: false
+  framework_lock_in:
+    enabled: true
+scan_paths:
+  - internal
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	goMod := `module github.com/test/project
+
+go 1.21
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handlersDir := filepath.Join(tmpDir, "internal", "handlers")
+	orderDir := filepath.Join(tmpDir, "internal", "order")
+	for _, dir := range []string{handlersDir, orderDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handlerGo := `package handlers
+
+import "github.com/gin-gonic/gin"
+
+func CreateOrder(c *gin.Context) {}
+`
+	if err := os.WriteFile(filepath.Join(handlersDir, "order.go"), []byte(handlerGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	serviceGo := `package order
+
+import "github.com/gin-gonic/gin"
+
+func Create(c *gin.Context) error {
+	return nil
+}
+`
+	if err := os.WriteFile(filepath.Join(orderDir, "service.go"), []byte(serviceGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run linter
+	_, violationsOutput, _, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(violationsOutput, "Framework Lock-in") || !strings.Contains(violationsOutput, "internal/order/service.go:5") {
+		t.Errorf("expected framework lock-in violation for internal/order, got: %s", violationsOutput)
+	}
+	if strings.Contains(violationsOutput, "internal/handlers/order.go") {
+		t.Errorf("expected handlers to be allowed to use gin.Context, got: %s", violationsOutput)
+	}
+}
+
+func TestRun_HiddenDependencies(t *testing.T) {
+	// Create config
+	configYAML := `rules:
+  directories_import:
+    internal/app: [internal/registry]
+    internal/billing: [internal/registry]
+    internal/registry: []
+  detect_unused: false
+  hidden_dependencies:
+    enabled: true
+scan_paths:
+  - internal
+`
+
+	goMod := `module github.com/test/project
+
+go 1.21
+`
+
+	files := map[string]string{
+		".goarchlint": configYAML,
+		"go.mod":      goMod,
+		"internal/registry/registry.go": `package registry
+
+var services = map[string]any{}
+
+func Register(name string, svc any) { services[name] = svc }
+
+func Get(name string) any { return services[name] }
+`,
+		"internal/billing/billing.go": `package billing
+
+import "github.com/test/project/internal/registry"
+
+type Service struct{}
+
+func init() {
+	registry.Register("billing", &Service{})
+}
+`,
+		"internal/app/app.go": `package app
+
+import "github.com/test/project/internal/registry"
+
+func Checkout() any {
+	return registry.Get("billing")
+}
+`,
+	}
+	tmpDir := writeProject(t, files)
+
+	// Run linter
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(violationsOutput, "Hidden Dependency") || !strings.Contains(violationsOutput, "internal/app/app.go:6") {
+		t.Errorf("expected hidden dependency warning for internal/app, got: %s", violationsOutput)
+	}
+	if !strings.Contains(violationsOutput, "registered at internal/billing/billing.go:8 (registry.Register)") {
+		t.Errorf("expected the registration site in the issue, got: %s", violationsOutput)
+	}
+	if shouldFail {
+		t.Error("expected hidden dependency warnings not to fail the build")
+	}
+}
+
+func TestRun_MultiRootScanPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Two services with their own go.mod, linted as one architecture
+	configYAML := `rules:
+  directories_import:
+    services/billing: [libs]
+    services/orders: [libs]
+    libs: []
+  detect_unused: false
+scan_paths:
+  - path: services/billing
+    module: github.com/org/billing
+  - path: services/orders
+    module: github.com/org/orders
+  - path: libs
+    module: github.com/org/libs
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	billingDir := filepath.Join(tmpDir, "services", "billing")
+	ordersDir := filepath.Join(tmpDir, "services", "orders")
+	libsDir := filepath.Join(tmpDir, "libs", "money")
+	for _, dir := range []string{billingDir, ordersDir, libsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	billingGo := `package billing
+
+import (
+	"github.com/org/libs/money"
+	"github.com/org/orders"
+)
+
+var _ = money.Amount
+var _ = orders.Find
+`
+	if err := os.WriteFile(filepath.Join(billingDir, "billing.go"), []byte(billingGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ordersGo := `package orders
+
+func Find() {}
+`
+	if err := os.WriteFile(filepath.Join(ordersDir, "orders.go"), []byte(ordersGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	moneyGo := `package money
+
+var Amount = 0
+`
+	if err := os.WriteFile(filepath.Join(libsDir, "money.go"), []byte(moneyGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run linter
+	graphOutput, violationsOutput, _, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(graphOutput, "local:libs/money") {
+		t.Errorf("expected nested module import to resolve to libs/money, got: %s", graphOutput)
+	}
+	if !strings.Contains(violationsOutput, "services/billing imports services/orders") {
+		t.Errorf("expected cross-service violation, got: %s", violationsOutput)
+	}
+	if strings.Contains(violationsOutput, "libs/money") {
+		t.Errorf("expected libs import to be allowed, got: %s", violationsOutput)
+	}
+}
+
+func TestRun_ParseErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [internal]
+    internal: []
+  detect_unused: false
+scan_paths:
+  - cmd
+  - internal
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmdDir := filepath.Join(tmpDir, "cmd", "app")
+	internalDir := filepath.Join(tmpDir, "internal", "order")
+	for _, dir := range []string{cmdDir, internalDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Default scans only parse the import block, so the error must be there
+	brokenGo := `package main
+
+import fmt
+
+func main() {}
+`
+	if err := os.WriteFile(filepath.Join(cmdDir, "main.go"), []byte(brokenGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The remaining files are still checked
+	orderGo := `package order
+
+import "github.com/test/project/cmd/app"
+
+var _ = app.Version
+`
+	if err := os.WriteFile(filepath.Join(internalDir, "order.go"), []byte(orderGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run should report parse errors as violations, got: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected parse errors to fail the build")
+	}
+	if !strings.Contains(violationsOutput, "Parse Error") || !strings.Contains(violationsOutput, "cmd/app/main.go") {
+		t.Errorf("expected parse error violation for cmd/app/main.go, got: %s", violationsOutput)
+	}
+	if !strings.Contains(violationsOutput, "internal/order imports cmd/app") {
+		t.Errorf("expected remaining files to be validated, got: %s", violationsOutput)
+	}
+
+	// Strict parsing restores the previous behavior
+	_, _, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{StrictParse: true})
+	if err == nil || !strings.Contains(err.Error(), "main.go") {
+		t.Errorf("expected strict parse error naming main.go, got: %v", err)
+	}
+}
+
+func TestRun_PackageNaming(t *testing.T) {
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [internal]
+    internal: []
+  detect_unused: false
+  package_naming:
+    enabled: true
+scan_paths:
+  - cmd
+  - internal
+`
+
+	files := map[string]string{
+		".goarchlint":                configYAML,
+		"cmd/app/main.go":            "package main\n",
+		"internal/order/order.go":    "package order\n",
+		"internal/order/helpers.go":  "package helpers\n",
+		"internal/payment/charge.go": "package billing\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected package naming violations to fail the build")
+	}
+	if !strings.Contains(violationsOutput, "internal/order contains multiple packages: helpers, order") {
+		t.Errorf("expected multiple packages violation, got: %s", violationsOutput)
+	}
+	if !strings.Contains(violationsOutput, "package billing does not match directory payment") {
+		t.Errorf("expected package name violation, got: %s", violationsOutput)
+	}
+	if strings.Contains(violationsOutput, "cmd/app") {
+		t.Errorf("expected main packages to be exempt, got: %s", violationsOutput)
+	}
+}
+
+func TestRun_StabilityAnnotations(t *testing.T) {
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: [pkg]
+  detect_unused: false
+  enforce_stability: true
+scan_paths:
+  - cmd
+  - pkg
+`
+
+	files := map[string]string{
+		".goarchlint": configYAML,
+		"pkg/api/api.go": `// archlint:stability stable
+package api
+
+import "github.com/test/project/pkg/client"
+
+func Call() { client.Do() }
+`,
+		"pkg/client/doc.go": `// Package client is still evolving.
+//
+// archlint:stability experimental
+package client
+`,
+		"pkg/client/client.go": `package client
+
+func Do() {}
+`,
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected stability violation to fail the build")
+	}
+	if !strings.Contains(violationsOutput, "stable package pkg/api imports experimental package pkg/client") {
+		t.Errorf("expected stability violation, got: %s", violationsOutput)
+	}
+
+	// Annotations are surfaced in the API documentation
+	apiOutput, _, _, err := linter.Run(tmpDir, "api", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.Contains(apiOutput, "## client\n\nPackage client is still evolving.\n\n**Stability**: experimental") {
+		t.Errorf("expected client stability in API docs, got: %s", apiOutput)
+	}
+	if !strings.Contains(apiOutput, "## api\n\n**Stability**: stable") {
+		t.Errorf("expected api stability in API docs, got: %s", apiOutput)
+	}
+}
+
+func TestRun_DeprecatedUsages(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    internal: [internal]
+  detect_unused: false
+  deprecations:
+    enabled: true
+scan_paths:
+  - internal
+`
+	legacyGo := `package legacy
+
+// OldFind looks up an order.
+//
+// Deprecated: use Find instead.
+func OldFind() {}
+
+func Find() {}
+`
+	orderGo := `package order
+
+import "github.com/test/project/internal/legacy"
+
+func Existing() { legacy.OldFind() }
+`
+	files := map[string]string{
+		".goarchlint":               configYAML,
+		"internal/legacy/legacy.go": legacyGo,
+		"internal/order/order.go":   orderGo,
+	}
+	tmpDir := writeProject(t, files)
+
+	for _, args := range [][]string{{"init", "-q"}, {"add", "-A"}, {"commit", "-q", "-m", "initial"}} {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = tmpDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	// Existing usages are tolerated
+	_, violationsOutput, _, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if strings.Contains(violationsOutput, "Deprecated") {
+		t.Errorf("expected existing usage to be tolerated, got: %s", violationsOutput)
+	}
+
+	// New usages are reported
+	orderGo += `
+func Create() {
+	legacy.OldFind()
+	legacy.Find()
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "internal/order/order.go"), []byte(orderGo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected new deprecated usage to fail the build")
+	}
+	if !strings.Contains(violationsOutput, "internal/order/order.go:8") || !strings.Contains(violationsOutput, "New usage of deprecated legacy.OldFind") {
+		t.Errorf("expected new usage on line 8, got: %s", violationsOutput)
+	}
+	if strings.Count(violationsOutput, "New usage of deprecated") != 1 {
+		t.Errorf("expected exactly one new usage, got: %s", violationsOutput)
+	}
+}
+
+func TestExportGraph_AndValidateGraph(t *testing.T) {
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: [internal]
+    internal: []
+scan_paths:
+  - cmd
+  - pkg
+  - internal
+`
+	files := map[string]string{
+		".goarchlint":             configYAML,
+		"cmd/app/main.go":         "package main\n\nimport \"github.com/test/project/pkg/service\"\n\nfunc main() { service.Run() }\n",
+		"pkg/service/service.go":  "package service\n\nimport \"github.com/test/project/internal/store\"\n\nfunc Run() { store.Save() }\n",
+		"internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	jsonOutput, err := linter.ExportGraph(tmpDir, "json")
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if !strings.Contains(jsonOutput, `"import_path": "github.com/test/project/internal/store"`) {
+		t.Errorf("expected store dependency in JSON export, got: %s", jsonOutput)
+	}
+	if !strings.Contains(jsonOutput, `"Save"`) {
+		t.Errorf("expected used symbols in JSON export, got: %s", jsonOutput)
+	}
+
+	graphML, err := linter.ExportGraph(tmpDir, "graphml")
+	if err != nil {
+		t.Fatalf("ExportGraph graphml failed: %v", err)
+	}
+	if !strings.Contains(graphML, `<node id="pkg/service">`) {
+		t.Errorf("expected package node in GraphML export, got: %s", graphML)
+	}
+
+	if _, err := linter.ExportGraph(tmpDir, "dot"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+
+	// The exported graph satisfies the rules it was produced under
+	violationsOutput, shouldFail, err := linter.ValidateGraph(tmpDir, []byte(jsonOutput))
+	if err != nil {
+		t.Fatalf("ValidateGraph failed: %v", err)
+	}
+	if shouldFail || violationsOutput != "" {
+		t.Errorf("expected no violations, got: %s", violationsOutput)
+	}
+
+	// A pre-built graph with a forbidden edge is rejected
+	badGraph := strings.Replace(jsonOutput, "github.com/test/project/internal/store", "github.com/test/project/cmd/app", 1)
+	badGraph = strings.Replace(badGraph, `"local_path": "internal/store"`, `"local_path": "cmd/app"`, 1)
+	violationsOutput, shouldFail, err = linter.ValidateGraph(tmpDir, []byte(badGraph))
+	if err != nil {
+		t.Fatalf("ValidateGraph failed: %v", err)
+	}
+	if !shouldFail || !strings.Contains(violationsOutput, "pkg/service") {
+		t.Errorf("expected violation for pkg -> cmd import, got: %s", violationsOutput)
+	}
+
+	// Graphs of another module are refused
+	otherModule := strings.Replace(jsonOutput, `"module": "github.com/test/project"`, `"module": "github.com/other/project"`, 1)
+	if _, _, err := linter.ValidateGraph(tmpDir, []byte(otherModule)); err == nil {
+		t.Error("expected error for graph of a different module")
+	}
+
+	if _, _, err := linter.ValidateGraph(tmpDir, []byte("not json")); err == nil {
+		t.Error("expected error for invalid graph data")
+	}
+}
+
+func TestRun_OwnershipBoundaries(t *testing.T) {
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    internal: [internal]
+  ownership:
+    teams:
+      internal/payments: payments
+      internal/identity: identity
+scan_paths:
+  - internal
+`
+	files := map[string]string{
+		".goarchlint":                      configYAML,
+		"internal/identity/api/api.go":     "package api\n\nfunc Lookup() {}\n",
+		"internal/identity/users/users.go": "package users\n\nfunc Find() {}\n",
+		"internal/payments/charge/charge.go": `package charge
+
+import (
+	"github.com/test/project/internal/identity/api"
+	"github.com/test/project/internal/identity/users"
+)
+
+func Charge() {
+	api.Lookup()
+	users.Find()
+}
+`,
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected cross-team import to fail the build")
+	}
+	if !strings.Contains(violationsOutput, "Cross-team Internal Access") || !strings.Contains(violationsOutput, "imports internal/identity/users") {
+		t.Errorf("expected cross-team violation, got: %s", violationsOutput)
+	}
+	if strings.Contains(violationsOutput, "imports internal/identity/api") {
+		t.Errorf("expected contract package import to be allowed, got: %s", violationsOutput)
+	}
+}
+
+func TestRun_PackageDocs(t *testing.T) {
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    pkg: [internal]
+    internal: []
+  package_docs:
+    layers: [pkg]
+scan_paths:
+  - pkg
+  - internal
+`
+	files := map[string]string{
+		".goarchlint":             configYAML,
+		"pkg/service/doc.go":      "// Package service runs the application. It wires the store.\npackage service\n",
+		"pkg/service/service.go":  "package service\n\nfunc Run() {}\n",
+		"pkg/client/client.go":    "package client\n\nfunc Call() {}\n",
+		"internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected missing package doc to fail the build")
+	}
+	if !strings.Contains(violationsOutput, "Missing Package Documentation") || !strings.Contains(violationsOutput, "Package client has no package doc comment") {
+		t.Errorf("expected missing package doc violation for pkg/client, got: %s", violationsOutput)
+	}
+	if strings.Contains(violationsOutput, "Package service") || strings.Contains(violationsOutput, "Package store") {
+		t.Errorf("expected documented and unconfigured packages to pass, got: %s", violationsOutput)
+	}
+
+	index, _, _, err := linter.Run(tmpDir, "index", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.Contains(index, "Package service runs the application.") || strings.Contains(index, "It wires the store") {
+		t.Errorf("expected package synopsis in index output, got: %s", index)
+	}
+}
+
+func TestRun_IndexGlossary(t *testing.T) {
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+scan_paths:
+  - internal
+`
+	files := map[string]string{
+		".goarchlint": configYAML,
+		"internal/domain/order.go": `package domain
+
+// Order is a customer's request to buy products.
+type Order struct {
+	ID int
+}
+
+// OrderRepository persists orders.
+type OrderRepository interface {
+	Save(Order) error
+}
+`,
+		"internal/store/store.go": "package store\n\n// Store is not a domain term.\ntype Store struct{}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	index, _, _, err := linter.Run(tmpDir, "index", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.Contains(index, "- **Order** (struct, `internal/domain`): Order is a customer's request to buy products.") ||
+		!strings.Contains(index, "- **OrderRepository** (interface, `internal/domain`): OrderRepository persists orders.") {
+		t.Errorf("expected domain types in glossary, got: %s", index)
+	}
+	if strings.Contains(index, "**Store**") {
+		t.Errorf("expected non-domain types to be left out of the glossary, got: %s", index)
+	}
+}
+
+func TestRun_IndexInlinePackageDetails(t *testing.T) {
+	configYAML := `module: github.com/test/project
+docs:
+  inline_package_details: true
+rules:
+  directories_import:
+    internal: []
+scan_paths:
+  - internal
+`
+	files := map[string]string{
+		".goarchlint":             configYAML,
+		"internal/store/store.go": "package store\n\nimport \"github.com/test/project/internal/model\"\n\n// Save persists a record.\nfunc Save(model.Record) error { return nil }\n",
+		"internal/model/model.go": "package model\n\n// Record is a stored value.\ntype Record struct{}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	index, _, _, err := linter.Run(tmpDir, "index", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.Contains(index, "<details id=\"package-internal-store\">") || !strings.Contains(index, "#### Package: store") {
+		t.Errorf("expected the store documentation inline, got: %s", index)
+	}
+	if !strings.Contains(index, "Save(model.Record) error") || !strings.Contains(index, "`internal/model`") {
+		t.Errorf("expected the API and dependencies of store inline, got: %s", index)
+	}
+	if strings.Contains(index, "go-arch-lint -format=package") {
+		t.Errorf("expected no details commands, got: %s", index)
+	}
+}
+
+func TestRun_IndexPortsAndAdapters(t *testing.T) {
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+scan_paths:
+  - internal
+`
+	files := map[string]string{
+		".goarchlint": configYAML,
+		"internal/ports/ports.go": `package ports
+
+type OrderRepository interface {
+	Save(id string) error
+}
+
+type Notifier interface {
+	Notify(msg string) error
+}
+`,
+		"internal/adapters/db/repo.go":      "package db\n\ntype Repo struct{}\n\nfunc (r *Repo) Save(id string) error { return nil }\n",
+		"internal/adapters/memory/store.go": "package memory\n\ntype Store struct{}\n\nfunc (s Store) Save(id string) error { return nil }\n",
+		// Test doubles are not adapters
+		"internal/adapters/db/repo_test.go": "package db\n\ntype fakeNotifier struct{}\n\nfunc (fakeNotifier) Notify(msg string) error { return nil }\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	index, _, _, err := linter.Run(tmpDir, "index", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.Contains(index, "- **Notifier** (`internal/ports`) → *(no implementation)*\n") ||
+		!strings.Contains(index, "- **OrderRepository** (`internal/ports`) → *internal/adapters/db.Repo, internal/adapters/memory.Store\n") {
+		t.Errorf("expected ports mapped to their adapters, got: %s", index)
+	}
+}
+
+func TestRun_CompositionRoot(t *testing.T) {
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [internal]
+    internal: []
+scan_paths:
+  - cmd
+  - internal
+`
+	files := map[string]string{
+		".goarchlint":               configYAML,
+		"internal/app/service.go":   "package app\n\ntype Service struct{}\n\nfunc NewService(repo any) *Service { return &Service{} }\n",
+		"internal/infra/db/repo.go": "package db\n\ntype Repo struct{ DSN string }\n",
+		"cmd/api/main.go": `package main
+
+import (
+	"github.com/test/project/internal/app"
+	"github.com/test/project/internal/infra/db"
+)
+
+func main() {
+	repo := &db.Repo{DSN: "postgres://"}
+	_ = app.NewService(repo)
+}
+`,
+	}
+	tmpDir := writeProject(t, files)
+
+	full, _, _, err := linter.Run(tmpDir, "full", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	expected := "- **cmd/api**\n  - internal/infra/db.Repo\n  - internal/app.NewService ← internal/infra/db.Repo\n"
+	if !strings.Contains(full, expected) {
+		t.Errorf("expected composition root:\n%s\ngot: %s", expected, full)
+	}
+}
+
+func TestRun_FullDocumentation(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [internal]
+    internal: []
+scan_paths:
+  - cmd
+  - internal
+`,
+		"cmd/app/main.go":         "package main\n\nimport \"github.com/test/project/internal/store\"\n\nfunc main() { store.Open() }\n",
+		"internal/store/store.go": "// Package store persists orders.\npackage store\n\n// Open opens the store\nfunc Open() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	full, _, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "full", Quiet: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !strings.HasPrefix(full, "# Project Architecture\n\n**Generated by go-arch-lint on ") {
+		t.Errorf("expected the document to start with its title, got:\n%.200s", full)
+	}
+
+	// The sections follow each other in the order of the table of contents
+	rest := full
+	for _, heading := range []string{"## Table of Contents", "## Project Structure", "## Architectural Rules", "## Dependency Graph", "## Public API", "## Statistics"} {
+		i := strings.Index(rest, "\n"+heading+"\n")
+		if i < 0 {
+			t.Fatalf("expected %q after the previous sections, got:\n%s", heading, full)
+		}
+		rest = rest[i+len(heading):]
+	}
+
+	// The API comes from the run's own scan
+	if !strings.Contains(full, "**Open** `Open()`") {
+		t.Errorf("expected the exported API of internal/store, got:\n%s", full)
+	}
+}
+
+func TestRun_DIWiring(t *testing.T) {
+	configYAML := `module: github.com/test/project
+scan_paths:
+  - pkg
+`
+	files := map[string]string{
+		".goarchlint":              configYAML,
+		"pkg/app/infra/db/repo.go": "package db\n\ntype Repo struct{}\n\nfunc NewRepo() *Repo { return &Repo{} }\n",
+		"pkg/app/app.go":           "package app\n\ntype App struct{}\n\nfunc NewApp(repo any) *App { return &App{} }\n",
+		"pkg/app/wire_gen.go": `// Code generated by Wire. DO NOT EDIT.
+
+package app
+
+import "github.com/test/project/pkg/app/infra/db"
+
+func Initialize() *App {
+	repo := db.NewRepo()
+	app := NewApp(repo)
+	return app
+}
+`,
+		"pkg/app/legacy.go": "package app\n\nimport \"github.com/test/project/pkg/app/infra/db\"\n\nvar _ = db.NewRepo\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	// The generated wiring may reach nested packages; hand-written code may not
+	_, violations, _, err := linter.Run(tmpDir, "markdown", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if strings.Contains(violations, "wire_gen.go") || !strings.Contains(violations, "File: pkg/app/legacy.go:3:8") {
+		t.Errorf("expected skip-level violations for legacy.go only, got: %s", violations)
+	}
+
+	full, _, _, err := linter.Run(tmpDir, "full", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	expected := "- **pkg/app**\n  - pkg/app/infra/db.NewRepo\n  - pkg/app.NewApp ← pkg/app/infra/db.NewRepo\n"
+	if !strings.Contains(full, expected) {
+		t.Errorf("expected composition root:\n%s\ngot: %s", expected, full)
+	}
+}
+
+func TestRun_DocsTemplates(t *testing.T) {
+	configYAML := `module: github.com/test/project
+docs:
+  templates_dir: docs/templates
+rules:
+  directories_import:
+    internal: []
+scan_paths:
+  - internal
+`
+	files := map[string]string{
+		".goarchlint":                  configYAML,
+		"internal/store/store.go":      "package store\n\nfunc Save() {}\n",
+		"docs/templates/index.md.tmpl": "# Custom Index ({{.FileCount}} files)\n\n{{section \"packages\"}}",
+	}
+	tmpDir := writeProject(t, files)
+
+	index, _, _, err := linter.Run(tmpDir, "index", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.HasPrefix(index, "# Custom Index (1 files)\n\n## Package Directory") || strings.Contains(index, "## Quick Reference") {
+		t.Errorf("expected index rendered from template, got: %s", index)
+	}
+
+	// Documents without a template keep the built-in layout
+	full, _, _, err := linter.Run(tmpDir, "full", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.HasPrefix(full, "# Project Architecture\n") {
+		t.Errorf("expected built-in full documentation, got: %s", full)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "docs/templates/full.md.tmpl"), []byte("{{section \"nope\"}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := linter.Run(tmpDir, "full", false, false, ""); err == nil || !strings.Contains(err.Error(), `unknown full section "nope"`) {
+		t.Errorf("expected template error, got: %v", err)
+	}
+}
+
+func TestSplitDocumentation(t *testing.T) {
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: [internal]
+    internal: []
+scan_paths:
+  - cmd
+  - pkg
+  - internal
+`
+	files := map[string]string{
+		".goarchlint":             configYAML,
+		"cmd/app/main.go":         "package main\n\nimport \"github.com/test/project/pkg/service\"\n\nfunc main() { service.Run() }\n",
+		"pkg/service/service.go":  "// Package service runs the application.\npackage service\n\nimport \"github.com/test/project/internal/store\"\n\nfunc Run() { store.Save() }\n",
+		"internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	pages, err := linter.SplitDocumentation(tmpDir)
+	if err != nil {
+		t.Fatalf("SplitDocumentation failed: %v", err)
+	}
+
+	for _, page := range []string{"index.md", "layers/cmd.md", "layers/pkg.md", "layers/internal.md", "packages/cmd/app.md", "packages/pkg/service.md", "packages/internal/store.md"} {
+		if _, ok := pages[page]; !ok {
+			t.Errorf("expected page %s, got pages: %v", page, pages)
+		}
+	}
+
+	service := pages["packages/pkg/service.md"]
+	if !strings.Contains(service, "Package service runs the application.") ||
+		!strings.Contains(service, "- **Imports**: [internal/store](../../packages/internal/store.md)") ||
+		!strings.Contains(service, "- **Imported by**: [cmd/app](../../packages/cmd/app.md)") {
+		t.Errorf("unexpected package page: %s", service)
+	}
+}
+
+func TestRun_SourceLinks(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    pkg: []
+    internal: []
+scan_paths:
+  - pkg
+  - internal
+source_links:
+  url: https://github.com/org/repo/blob/{ref}/{file}#L{line}
+`
+	// The project lives in a subdirectory of the repository
+	files := map[string]string{
+		"service/.goarchlint":             configYAML,
+		"service/pkg/service/service.go":  "package service\n\nimport \"github.com/test/project/internal/store\"\n\nfunc Run() { store.Save() }\n",
+		"service/internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	repoDir := writeProject(t, files)
+	projectDir := filepath.Join(repoDir, "service")
+	for _, args := range [][]string{{"init", "-q"}, {"add", "-A"}, {"commit", "-q", "-m", "initial"}} {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repoDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	head, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, violationsOutput, _, err := linter.Run(projectDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	expected := "  Link: https://github.com/org/repo/blob/" + strings.TrimSpace(string(head)) + "/service/pkg/service/service.go#L3\n"
+	if !strings.Contains(violationsOutput, expected) {
+		t.Errorf("expected source link %q, got: %s", expected, violationsOutput)
+	}
+}
+
+func TestRun_RuleSource(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+preset:
+  name: custom
+  rules:
+    directories_import:
+      cmd: [pkg]
+      pkg: []
+      internal: []
+overrides:
+  rules:
+    directories_import:
+      cmd/tool: [pkg]
+scan_paths:
+  - cmd
+  - internal
+`,
+		"cmd/app/main.go":         "package main\n\nimport \"github.com/test/project/internal/store\"\n\nfunc main() { store.Save() }\n",
+		"cmd/tool/main.go":        "package main\n\nimport \"github.com/test/project/internal/store\"\n\nfunc main() { store.Save() }\n",
+		"internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violations, _, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(violations, "  Source: preset custom (rules.directories_import.cmd)\n") {
+		t.Errorf("expected preset as source of the cmd rule, got:\n%s", violations)
+	}
+	if !strings.Contains(violations, "  Source: overrides (rules.directories_import.cmd/tool)\n") {
+		t.Errorf("expected overrides as source of the cmd/tool rule, got:\n%s", violations)
+	}
+}
+
+func TestRunWithOptions_Profile(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+preset:
+  name: custom
+  rules:
+    directories_import:
+      cmd: [pkg, internal]
+      pkg: []
+      internal: []
+profiles:
+  strict:
+    rules:
+      directories_import:
+        cmd: [pkg]
+scan_paths:
+  - cmd
+  - internal
+`,
+		"cmd/app/main.go":         "package main\n\nimport \"github.com/test/project/internal/store\"\n\nfunc main() { store.Save() }\n",
+		"internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	// The lenient base rules allow cmd to import internal
+	_, violations, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if strings.Contains(violations, "Forbidden Import") {
+		t.Errorf("expected no forbidden imports without a profile, got:\n%s", violations)
+	}
+
+	_, violations, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Profile: "strict"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !strings.Contains(violations, "  Source: profile strict (rules.directories_import.cmd)\n") {
+		t.Errorf("expected forbidden import from the strict profile, got:\n%s", violations)
+	}
+
+	_, _, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Profile: "nightly"})
+	if err == nil || !strings.Contains(err.Error(), `unknown profile "nightly" (available: strict)`) {
+		t.Errorf("expected unknown profile error, got %v", err)
+	}
+}
+
+func TestRunWithOptions_Mode(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+  type_leaks:
+    layers: [internal/domain]
+    forbidden: [github.com/jackc/pgx]
+modes:
+  full: [type_checks]
+scan_paths:
+  - internal
+`,
+		"go.mod":                   "module github.com/test/project\n\ngo 1.21\n",
+		"internal/domain/order.go": "package domain\n\nimport \"github.com/jackc/pgx/v5\"\n\nfunc Save(tx pgx.Tx) error { return nil }\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	// The fast mode leaves out the type-checked rules
+	_, violations, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if strings.Contains(violations, "Type Leak in Exported Signature") || shouldFail {
+		t.Errorf("expected no type leaks in fast mode, got:\n%s", violations)
+	}
+
+	_, violations, shouldFail, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Mode: "full"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !strings.Contains(violations, "Type Leak in Exported Signature") || !shouldFail {
+		t.Errorf("expected the type leak in full mode, got:\n%s", violations)
+	}
+
+	_, _, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Mode: "nightly"})
+	if err == nil || !strings.Contains(err.Error(), `unknown mode "nightly"`) {
+		t.Errorf("expected unknown mode error, got %v", err)
+	}
+}
+
+func TestRun_RequireShuffleClean(t *testing.T) {
+	// Whichever of the two tests runs second sees the counter the other one left behind
+	sharedTest := `package store_test
+
+import (
+	"testing"
+
+	"github.com/test/project/internal/store"
+)
+
+func TestFirst(t *testing.T) {
+	if store.Count != 0 {
+		t.Fatalf("counter left at %d", store.Count)
+	}
+	store.Count++
+}
+
+func TestSecond(t *testing.T) {
+	if store.Count != 0 {
+		t.Fatalf("counter left at %d", store.Count)
+	}
+	store.Count++
+}
+`
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+  require_shuffle_clean: [internal/store]
+scan_paths:
+  - internal
+`,
+		"go.mod":                       "module github.com/test/project\n\ngo 1.21\n",
+		"internal/store/store.go":      "package store\n\nvar Count int\n",
+		"internal/store/store_test.go": sharedTest,
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violations, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Quiet: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if strings.Count(violations, "rules.require_shuffle_clean") != 1 || !strings.Contains(violations, "internal/store/store_test.go:") {
+		t.Errorf("expected one order-dependent test in store_test.go, got:\n%s", violations)
+	}
+	if !strings.Contains(violations, "run in random order (seed ") || !strings.Contains(violations, "counter left at 1") {
+		t.Errorf("expected the seed and the failure message, got:\n%s", violations)
+	}
+	if !shouldFail {
+		t.Error("expected an order-dependent test to fail the build")
+	}
+
+	// Listed under modes.full, the tests only run in full mode
+	config := strings.Replace(files[".goarchlint"], "scan_paths:", "modes:\n  full: [require_shuffle_clean]\nscan_paths:", 1)
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, violations, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Quiet: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if strings.Contains(violations, "rules.require_shuffle_clean") {
+		t.Errorf("expected no shuffled tests in fast mode, got:\n%s", violations)
+	}
+}
+
+func TestRun_RequireRaceClean(t *testing.T) {
+	if out, err := exec.Command("go", "env", "CGO_ENABLED").Output(); err != nil || strings.TrimSpace(string(out)) != "1" {
+		t.Skip("the race detector needs cgo")
+	}
+
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+  require_race_clean: [internal/worker]
+scan_paths:
+  - internal
+`,
+		"go.mod": "module github.com/test/project\n\ngo 1.21\n",
+		"internal/worker/pool.go": `package worker
+
+import "sync"
+
+type Pool struct{ done int }
+
+func (p *Pool) Run(jobs int) int {
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.done++
+		}()
+	}
+	wg.Wait()
+	return p.done
+}
+`,
+		"internal/worker/pool_test.go": `package worker_test
+
+import (
+	"testing"
+
+	"github.com/test/project/internal/worker"
+)
+
+func TestRun(t *testing.T) {
+	(&worker.Pool{}).Run(4)
+}
+`,
+	}
+	tmpDir := writeProject(t, files)
+
+	// The race detector is too slow for the fast mode, so it only runs in full mode
+	_, violations, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Quiet: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if strings.Contains(violations, "rules.require_race_clean") {
+		t.Errorf("expected no race detection in fast mode, got:\n%s", violations)
+	}
+
+	_, violations, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Quiet: true, Mode: "full"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if strings.Count(violations, "rules.require_race_clean") != 1 || !strings.Contains(violations, "internal/worker/pool.go:13") {
+		t.Errorf("expected one data race in pool.go, got:\n%s", violations)
+	}
+	if !strings.Contains(violations, "TestRun of internal/worker has a data race: ") || !strings.Contains(violations, " in worker.(*Pool).Run.func1()") {
+		t.Errorf("expected the racing access in the issue, got:\n%s", violations)
+	}
+	if !shouldFail {
+		t.Error("expected a data race to fail the build")
+	}
+}
+
+func TestRun_RequireTestdataFixtures(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+  test_files:
+    lint: true
+    require_testdata: true
+scan_paths:
+  - internal
+`,
+		"internal/parser/parser.go": "package parser\n\nimport \"os\"\n\nfunc Defaults() ([]byte, error) { return os.ReadFile(\"testdata/defaults.json\") }\n",
+		"internal/parser/parser_test.go": `package parser_test
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	if _, err := os.ReadFile("testdata/input.json"); err != nil {
+		t.Skip()
+	}
+	if _, err := os.ReadFile("golden.json"); err != nil {
+		t.Skip()
+	}
+}
+`,
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violations, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if strings.Count(violations, "Test Fixture Outside testdata") != 2 {
+		t.Errorf("expected 2 fixture violations, got:\n%s", violations)
+	}
+	if !strings.Contains(violations, `production code reads test fixture "testdata/defaults.json" via os.ReadFile`) {
+		t.Errorf("expected production read of testdata to be reported, got:\n%s", violations)
+	}
+	if !strings.Contains(violations, `test reads fixture "golden.json" outside testdata/ via os.ReadFile`) {
+		t.Errorf("expected test fixture outside testdata to be reported, got:\n%s", violations)
+	}
+	if strings.Contains(violations, "input.json") {
+		t.Errorf("expected fixture under testdata to be accepted, got:\n%s", violations)
+	}
+	if !shouldFail {
+		t.Error("expected fixture violations to fail the build")
+	}
+}
+
+func TestRun_TestSetupImports(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+  test_files:
+    lint: true
+    exempt_imports: [github.com/test/project/internal]
+  test_setup_imports:
+    enabled: true
+scan_paths:
+  - internal
+`,
+		"internal/store/store.go":     "package store\n\ntype Order struct{ ID string }\n\nfunc Save(o *Order) error { return nil }\n",
+		"internal/billing/invoice.go": "package billing\n\nfunc Total() int { return 0 }\n",
+		"internal/billing/invoice_test.go": `package billing_test
+
+import (
+	"testing"
+
+	"github.com/test/project/internal/billing"
+	"github.com/test/project/internal/store"
+)
+
+func TestTotal(t *testing.T) {
+	_ = store.Order{ID: "1"}
+	if billing.Total() != 0 {
+		t.Fail()
+	}
+}
+`,
+		"internal/billing/export_test.go": `package billing_test
+
+import (
+	"testing"
+
+	"github.com/test/project/internal/store"
+)
+
+func TestExport(t *testing.T) {
+	if err := store.Save(&store.Order{}); err != nil {
+		t.Fail()
+	}
+}
+`,
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violations, _, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if strings.Count(violations, "Test Setup via Package Internals") != 1 {
+		t.Errorf("expected 1 test setup violation, got:\n%s", violations)
+	}
+	if !strings.Contains(violations, "File: internal/billing/invoice_test.go") ||
+		!strings.Contains(violations, "test imports internal/store only to build fixtures (Order{})") {
+		t.Errorf("expected setup-only import of internal/store to be reported, got:\n%s", violations)
+	}
+}
+
+func TestRun_TestScope(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+  test_files:
+    lint: true
+  strict_test_naming: true
+  test_scope:
+    enabled: true
+    shared: [helpers.go]
+scan_paths:
+  - internal
+`,
+		"internal/billing/invoice.go": "package billing\n\nfunc Total(items []int) int { return applyTax(len(items)) }\n",
+		"internal/billing/tax.go":     "package billing\n\nfunc applyTax(amount int) int { return amount }\n",
+		"internal/billing/helpers.go": "package billing\n\nfunc sample() []int { return []int{1} }\n",
+		"internal/billing/invoice_test.go": `package billing
+
+import "testing"
+
+func TestTotal(t *testing.T) {
+	if Total(sample()) != applyTax(1) {
+		t.Fail()
+	}
+}
+`,
+		"internal/billing/tax_test.go": `package billing
+
+import "testing"
+
+func TestApplyTax(t *testing.T) {
+	if applyTax(2) != 2 {
+		t.Fail()
+	}
+}
+`,
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violations, _, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if strings.Count(violations, "Test Exceeds Unit Scope") != 1 {
+		t.Errorf("expected 1 test scope violation, got:\n%s", violations)
+	}
+	if !strings.Contains(violations, "File: internal/billing/invoice_test.go") ||
+		!strings.Contains(violations, "invoice_test.go references declarations outside invoice.go: tax.go (applyTax)") {
+		t.Errorf("expected use of tax.go from invoice_test.go to be reported, got:\n%s", violations)
+	}
+}
+
+func TestRun_TestSupportPackages(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+  test_files:
+    lint: true
+    support_packages: [internal/testsupport]
+scan_paths:
+  - internal
+`,
+		"internal/testsupport/orders.go": "package testsupport\n\nfunc Order() string { return \"order\" }\n",
+		"internal/fixtures/fixtures.go":  "package fixtures\n\nfunc Customer() string { return \"acme\" }\n",
+		"internal/billing/invoice.go": `package billing
+
+import "github.com/test/project/internal/testsupport"
+
+func Total() string { return testsupport.Order() }
+`,
+		"internal/billing/invoice_test.go": `package billing
+
+import (
+	"testing"
+
+	"github.com/test/project/internal/fixtures"
+	"github.com/test/project/internal/testsupport"
+)
+
+func TestTotal(t *testing.T) {
+	if Total() != testsupport.Order() || fixtures.Customer() == "" {
+		t.Fail()
+	}
+}
+`,
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violations, _, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !strings.Contains(violations, "Production Import of Test Support") ||
+		!strings.Contains(violations, "internal/billing imports test support package internal/testsupport") {
+		t.Errorf("expected production import of internal/testsupport to be reported, got:\n%s", violations)
+	}
+	if !strings.Contains(violations, "Unlisted Test Fixture Package") ||
+		!strings.Contains(violations, "test imports fixture package internal/fixtures") {
+		t.Errorf("expected test import of unlisted internal/fixtures to be reported, got:\n%s", violations)
+	}
+	// Only the production file breaks directories_import by importing internal/testsupport
+	if strings.Count(violations, "Issue: internal/billing imports internal/testsupport") != 1 {
+		t.Errorf("expected test import of internal/testsupport to be exempt from directories_import, got:\n%s", violations)
+	}
+}
+
+func TestRun_ExemptImportsPerDirectory(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+    tests: []
+  test_files:
+    lint: true
+    location: any
+    exempt_imports:
+      tests: ["*"]
+      internal/domain: [testing]
+scan_paths:
+  - internal
+  - tests
+`,
+		"internal/infra/db.go":          "package infra\n\nfunc Open() error { return nil }\n",
+		"internal/domain/order.go":      "package domain\n\ntype Order struct{}\n",
+		"tests/e2e/checkout_test.go":    "package e2e\n\nimport (\n\t\"testing\"\n\n\t\"github.com/test/project/internal/infra\"\n)\n\nfunc TestCheckout(t *testing.T) { _ = infra.Open() }\n",
+		"internal/domain/order_test.go": "package domain_test\n\nimport (\n\t\"testing\"\n\n\t\"github.com/test/project/internal/infra\"\n)\n\nfunc TestOrder(t *testing.T) { _ = infra.Open() }\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violations, _, err := linter.Run(tmpDir, "", false, false, "")
 	if err != nil {
 		t.Fatalf("Run failed: %v", err)
 	}
 
-	// Should NOT have violation (matches glob pattern)
-	if strings.Contains(violationsOutput, "github.com/pkg/errors") {
-		t.Error("github.com/pkg/errors should match glob pattern github.com/pkg/*, but was flagged")
+	if strings.Contains(violations, "tests/e2e/checkout_test.go") {
+		t.Errorf("expected e2e tests to be allowed any import, got:\n%s", violations)
+	}
+	if !strings.Contains(violations, "internal/domain imports internal/infra") {
+		t.Errorf("expected domain test import of internal/infra to be forbidden, got:\n%s", violations)
 	}
 }
 
-func TestRun_SharedExternalImports_ErrorMode(t *testing.T) {
-	tmpDir := t.TempDir()
-
-	// Create config with mode: error
-	configYAML := `rules:
+func TestRun_MainPackages(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
   directories_import:
     cmd: [internal]
     internal: []
-  detect_unused: false
-  shared_external_imports:
-    detect: true
-    mode: error
+    tools: []
+  main_packages: [cmd]
 scan_paths:
   - cmd
   - internal
-`
-	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
-		t.Fatal(err)
+  - tools
+`,
+		"cmd/api/main.go":     "package main\n\nimport \"github.com/test/project/internal/app\"\n\nfunc main() { app.Run() }\n",
+		"internal/app/app.go": "package app\n\nfunc Run() {}\n",
+		"tools/gen/main.go":   "package main\n\nfunc main() {}\n",
 	}
+	tmpDir := writeProject(t, files)
 
-	goMod := `module github.com/test/project
-
-go 1.21
-`
-	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
-		t.Fatal(err)
+	_, violations, _, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
 	}
 
-	// Create files with shared external import
-	cmdDir := filepath.Join(tmpDir, "cmd")
-	if err := os.MkdirAll(cmdDir, 0755); err != nil {
-		t.Fatal(err)
+	if strings.Count(violations, "Main Package Outside Approved Locations") != 1 ||
+		!strings.Contains(violations, "main package tools/gen is outside the approved locations") {
+		t.Errorf("expected main package in tools/gen to be reported, got:\n%s", violations)
 	}
-
-	mainGo := `package main
-
-import "github.com/pkg/errors"
-
-func main() {
-	err := errors.New("test")
-	_ = err
 }
-`
-	if err := os.WriteFile(filepath.Join(cmdDir, "main.go"), []byte(mainGo), 0644); err != nil {
-		t.Fatal(err)
-	}
-
-	internalDir := filepath.Join(tmpDir, "internal")
-	if err := os.MkdirAll(internalDir, 0755); err != nil {
-		t.Fatal(err)
-	}
-
-	repoGo := `package internal
-
-import "github.com/pkg/errors"
 
-func Query() error {
-	return errors.New("error")
-}
-`
-	if err := os.WriteFile(filepath.Join(internalDir, "repo.go"), []byte(repoGo), 0644); err != nil {
-		t.Fatal(err)
+func TestRun_OrphanInterfaces(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal/ports: []
+    internal/adapters: [internal/ports]
+  orphan_interfaces:
+    layers: [internal/ports]
+scan_paths:
+  - internal
+`,
+		"internal/ports/ports.go":         "package ports\n\ntype Repository interface {\n\tSave(id string) error\n}\n\ntype Notifier interface {\n\tNotify(msg string) error\n}\n",
+		"internal/adapters/store/repo.go": "package store\n\nimport \"github.com/test/project/internal/ports\"\n\nvar _ ports.Repository = (*Repo)(nil)\n\ntype Repo struct{}\n\nfunc (r *Repo) Save(id string) error { return nil }\n",
 	}
+	tmpDir := writeProject(t, files)
 
-	// Run linter
-	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "markdown", false, false, "")
+	_, violations, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
 	if err != nil {
 		t.Fatalf("Run failed: %v", err)
 	}
 
-	// Should have violation
-	if !strings.Contains(violationsOutput, "Shared External Import") {
-		t.Error("expected shared external import violation")
+	if strings.Count(violations, "Orphan Interface") != 1 ||
+		!strings.Contains(violations, "Interface ports.Notifier is not implemented by any type in the codebase") {
+		t.Errorf("expected only Notifier to be reported, got:\n%s", violations)
 	}
-
-	// SHOULD fail in error mode
-	if !shouldFail {
-		t.Error("expected shouldFail=true in error mode, got false")
+	if shouldFail {
+		t.Error("expected orphan interfaces not to fail the build")
 	}
 }
 
-func TestRun_SharedExternalImports_SingleLayer(t *testing.T) {
-	tmpDir := t.TempDir()
-
-	// Create config
-	configYAML := `rules:
+func TestRun_PortlessAdapters(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
   directories_import:
-    cmd: [internal]
-    internal: []
-  detect_unused: false
-  shared_external_imports:
-    detect: true
-    mode: error
+    internal/ports: []
+    internal/adapters: [internal/ports]
+  adapter_ports:
+    adapters: [internal/adapters]
+    ports: [internal/ports]
 scan_paths:
   - internal
-`
-	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
-		t.Fatal(err)
+`,
+		"internal/ports/ports.go":           "package ports\n\ntype Repository interface {\n\tSave(id string) error\n}\n",
+		"internal/adapters/store/repo.go":   "package store\n\ntype Repo struct{}\n\nfunc (r *Repo) Save(id string) error { return nil }\n",
+		"internal/adapters/helpers/slug.go": "package helpers\n\nfunc Slug(s string) string { return s }\n",
 	}
+	tmpDir := writeProject(t, files)
 
-	goMod := `module github.com/test/project
-
-go 1.21
-`
-	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
-		t.Fatal(err)
+	_, violations, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
 	}
 
-	// Create multiple files in SAME layer importing same external package
-	internalDir := filepath.Join(tmpDir, "internal")
-	if err := os.MkdirAll(internalDir, 0755); err != nil {
-		t.Fatal(err)
+	if strings.Count(violations, "Adapter Implements No Port") != 1 ||
+		!strings.Contains(violations, "Adapter package internal/adapters/helpers does not implement any port") {
+		t.Errorf("expected only the helpers package to be reported, got:\n%s", violations)
 	}
-
-	repo1Go := `package internal
-
-import "github.com/pkg/errors"
-
-func Query() error {
-	return errors.New("error")
-}
-`
-	if err := os.WriteFile(filepath.Join(internalDir, "repo1.go"), []byte(repo1Go), 0644); err != nil {
-		t.Fatal(err)
+	if shouldFail {
+		t.Error("expected portless adapters not to fail the build")
 	}
-
-	repo2Go := `package internal
-
-import "github.com/pkg/errors"
-
-func Save() error {
-	return errors.New("save error")
 }
-`
-	if err := os.WriteFile(filepath.Join(internalDir, "repo2.go"), []byte(repo2Go), 0644); err != nil {
-		t.Fatal(err)
+
+func TestRun_AdapterAssertions(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal/ports: []
+    internal/adapters: [internal/ports]
+  adapter_ports:
+    adapters: [internal/adapters]
+    ports: [internal/ports]
+    require_assertions: true
+scan_paths:
+  - internal
+`,
+		"internal/ports/ports.go":          "package ports\n\ntype Repository interface {\n\tSave(id string) error\n}\n\ntype Notifier interface {\n\tNotify(msg string)\n}\n",
+		"internal/adapters/store/repo.go":  "package store\n\nimport \"github.com/test/project/internal/ports\"\n\nvar _ ports.Repository = (*Repo)(nil)\n\ntype Repo struct{}\n\nfunc (r *Repo) Save(id string) error { return nil }\n",
+		"internal/adapters/smtp/mailer.go": "package smtp\n\ntype Mailer struct{}\n\nfunc (m Mailer) Notify(msg string) {}\n",
 	}
+	tmpDir := writeProject(t, files)
 
-	// Run linter
-	_, violationsOutput, _, err := linter.Run(tmpDir, "markdown", false, false, "")
+	_, violations, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
 	if err != nil {
 		t.Fatalf("Run failed: %v", err)
 	}
 
-	// Should NOT have violation (same layer is OK)
-	if strings.Contains(violationsOutput, "Shared External Import") {
-		t.Errorf("expected no shared external import violation for same layer, got: %s", violationsOutput)
+	if strings.Count(violations, "Missing Adapter Assertion") != 1 ||
+		!strings.Contains(violations, "internal/adapters/smtp.Mailer implements port internal/ports.Notifier without a compile-time assertion") ||
+		!strings.Contains(violations, "internal/adapters/smtp/mailer.go:3:6") {
+		t.Errorf("expected only the smtp mailer to be reported, got:\n%s", violations)
+	}
+	if !shouldFail {
+		t.Error("expected missing adapter assertions to fail the build")
 	}
 }
 
@@ -694,7 +2859,6 @@ scan_paths:
   - cmd
   - pkg
   - internal
-detect_unused: false
 `
 	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
 		t.Fatal(err)
@@ -810,7 +2974,6 @@ scan_paths:
   - cmd
   - pkg
   - internal
-detect_unused: false
 `
 	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
 		t.Fatal(err)
@@ -1190,6 +3353,41 @@ func main() {
 }
 
 // TestRun_RequireBlackboxTests tests that whitebox tests are detected when rule is enabled
+func TestRun_RequireBlackboxTests_AllowWhitebox(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint": `scan_paths:
+  - internal
+rules:
+  directories_import:
+    internal: []
+  test_files:
+    lint: true
+    require_blackbox: true
+    allow_whitebox:
+      - path: internal/algo
+        reason: heap invariants are not observable through the API
+`,
+		"internal/algo/heap.go":      "package algo\n\nfunc push() {}\n",
+		"internal/algo/heap_test.go": "package algo\n\nimport \"testing\"\n\nfunc TestPush(t *testing.T) { push() }\n",
+		"internal/app/app.go":        "package app\n\nfunc Process() {}\n",
+		"internal/app/app_test.go":   "package app\n\nimport \"testing\"\n\nfunc TestProcess(t *testing.T) { Process() }\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violations, _, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if strings.Count(violations, "[ERROR] Whitebox Test") != 1 || !strings.Contains(violations, "File: internal/app/app_test.go") {
+		t.Errorf("expected only internal/app to be reported, got:\n%s", violations)
+	}
+	if strings.Contains(violations, "internal/algo/heap_test.go") {
+		t.Errorf("expected exempted internal/algo not to be reported, got:\n%s", violations)
+	}
+}
+
 func TestRun_RequireBlackboxTests(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -1520,6 +3718,137 @@ go 1.21
 	}
 }
 
+func TestInit_WritesSchemaVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module github.com/test/project\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(tmpDir, ".goarchlint")
+
+	for _, step := range []struct {
+		name string
+		run  func() error
+	}{
+		{"init", func() error { return linter.Init(tmpDir, "ddd", false) }},
+		{"refresh", func() error { return linter.Refresh(tmpDir, "hexagonal") }},
+	} {
+		if err := step.run(); err != nil {
+			t.Fatalf("%s failed: %v", step.name, err)
+		}
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "\nversion: 1\nmodule: github.com/test/project\n") {
+			t.Errorf("expected %s to write the schema version, got:\n%s", step.name, data)
+		}
+	}
+
+	customDir := t.TempDir()
+	if err := linter.Init(customDir, "custom", false); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(customDir, ".goarchlint"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "\nversion: 1\n") {
+		t.Errorf("expected the custom config to declare the schema version, got:\n%s", data)
+	}
+}
+
+func TestRunWithOptions_FailFast(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal/a: []
+    internal/b: []
+    internal/d: []
+  active_from:
+    rules.directories_import.internal/a: 2999-01-01
+scan_paths:
+  - internal
+`,
+		"internal/a/a.go": "package a\n\nimport _ \"github.com/test/project/internal/c\"\n",
+		"internal/b/b.go": "package b\n\nimport _ \"github.com/test/project/internal/c\"\n",
+		"internal/c/c.go": "package c\n",
+		"internal/d/d.go": "package d\n\nimport _ \"github.com/test/project/internal/c\"\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	graphOutput, violationsOutput, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "markdown", FailFast: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected the run to fail")
+	}
+	if graphOutput != "" {
+		t.Errorf("expected no dependency graph after a failing fail-fast run, got:\n%s", graphOutput)
+	}
+
+	// The scheduled rule does not fail the build, so validation continues to internal/b
+	// and stops there, before internal/d
+	if !strings.Contains(violationsOutput, "internal/a imports internal/c") || !strings.Contains(violationsOutput, "internal/b imports internal/c") {
+		t.Errorf("expected the warning and the first error, got:\n%s", violationsOutput)
+	}
+	if strings.Contains(violationsOutput, "internal/d imports internal/c") {
+		t.Errorf("expected validation to stop at the first error, got:\n%s", violationsOutput)
+	}
+	if !strings.Contains(violationsOutput, "Stopped at the first error (-fail-fast)") {
+		t.Errorf("expected a note that more violations may exist, got:\n%s", violationsOutput)
+	}
+
+	_, violationsOutput, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !strings.Contains(violationsOutput, "internal/d imports internal/c") {
+		t.Errorf("expected all violations without fail-fast, got:\n%s", violationsOutput)
+	}
+}
+
+func TestRunWithOptions_Quiet(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module github.com/test/project\n\ngo 1.21\n",
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal/a: []
+    internal/b: []
+scan_paths:
+  - internal
+error_prompt:
+  enabled: true
+  architectural_goals: Keep the layers apart
+`,
+		"internal/a/a.go": "package a\n\nimport _ \"github.com/test/project/internal/b\"\n",
+		"internal/b/b.go": "package b\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violationsOutput, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Quiet: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected the forbidden import to fail the build")
+	}
+
+	lines := strings.Split(strings.TrimSuffix(violationsOutput, "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one line per violation, got:\n%s", violationsOutput)
+	}
+	if !strings.HasPrefix(lines[0], "internal/a/a.go:3") || !strings.Contains(lines[0], " rules.directories_import.internal/a ") {
+		t.Errorf("expected a file:line rule message line, got %q", lines[0])
+	}
+	if strings.Contains(violationsOutput, "Keep the layers apart") || strings.Contains(violationsOutput, "TIP") {
+		t.Errorf("expected no guidance in quiet mode, got:\n%s", violationsOutput)
+	}
+}
+
 func TestRefresh_WithSamePreset(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -1619,6 +3948,39 @@ go 1.21
 	}
 }
 
+func TestRefresh_PreservesProfiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module github.com/test/project\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configYAML := `module: github.com/test/project
+preset:
+  name: ddd
+profiles:
+  strict:
+    rules:
+      detect_unused: true
+`
+	configPath := filepath.Join(tmpDir, ".goarchlint")
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := linter.Refresh(tmpDir, ""); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	refreshedData, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(refreshedData), "profiles:\n    strict:\n        rules:\n") {
+		t.Errorf("refreshed config lost the profiles section:\n%s", refreshedData)
+	}
+}
+
 func TestRefresh_NoConfigExists(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -1892,3 +4254,71 @@ func Foo() string {
 		t.Errorf("expected no violations when strict_test_naming is disabled, got: %s", violationsOutput)
 	}
 }
+
+func TestRun_APIExamples(t *testing.T) {
+	configYAML := `module: github.com/test/project
+scan_paths:
+  - pkg
+`
+	files := map[string]string{
+		".goarchlint":               configYAML,
+		"pkg/mathx/mathx.go":        "package mathx\n\n// Sum adds two numbers. It never overflows silently.\nfunc Sum(a int, b int) int { return a + b }\n",
+		"pkg/mathx/example_test.go": "package mathx_test\n\nfunc ExampleSum() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	apiOutput, _, _, err := linter.Run(tmpDir, "api", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	expected := "- Sum(int, int) int - Sum adds two numbers.\n  - Examples: `ExampleSum` (pkg/mathx/example_test.go:3)\n"
+	if !strings.Contains(apiOutput, expected) {
+		t.Errorf("expected documented function with example:\n%s\ngot: %s", expected, apiOutput)
+	}
+}
+
+func TestRun_LicensePolicy(t *testing.T) {
+	t.Setenv("GOMODCACHE", t.TempDir()) // Vendored modules only
+
+	configYAML := `module: github.com/test/project
+rules:
+  directories_import:
+    internal: []
+  license_policy:
+    layers:
+      internal/domain: [permissive]
+scan_paths:
+  - internal
+`
+	files := map[string]string{
+		".goarchlint":                       configYAML,
+		"go.mod":                            "module github.com/test/project\n\ngo 1.22\n\nrequire (\n\tgithub.com/mit/lib v1.0.0\n\tgithub.com/gpl/lib v1.0.0\n)\n",
+		"vendor/github.com/mit/lib/LICENSE": "Permission is hereby granted, free of charge, to any person",
+		"vendor/github.com/gpl/lib/COPYING": "GNU GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007",
+		"internal/domain/order/order.go":    "package order\n\nimport (\n\t\"github.com/gpl/lib\"\n\t\"github.com/mit/lib/sub\"\n)\n\nvar _, _ = lib.X, sub.Y\n",
+		"internal/infra/store/store.go":     "package store\n\nimport \"github.com/gpl/lib\"\n\nvar _ = lib.X\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, violationsOutput, shouldFail, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	expected := []string{
+		"[ERROR] Disallowed Dependency License",
+		"File: internal/domain/order/order.go:4:2",
+		"Issue: internal/domain/order imports github.com/gpl/lib, licensed GPL-3.0 (copyleft)",
+		"Rule: internal/domain may only depend on modules licensed: permissive",
+	}
+	for _, want := range expected {
+		if !strings.Contains(violationsOutput, want) {
+			t.Errorf("expected %q in violations, got:\n%s", want, violationsOutput)
+		}
+	}
+	if strings.Count(violationsOutput, "Disallowed Dependency License") != 1 {
+		t.Errorf("expected only the domain layer import to be reported, got:\n%s", violationsOutput)
+	}
+	if !shouldFail {
+		t.Error("expected the license violation to fail the build")
+	}
+}
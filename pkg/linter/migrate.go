@@ -0,0 +1,65 @@
+package linter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// MigrateConfig upgrades a .goarchlint in the old flat format (preset_used and
+// top-level rules) to the preset/overrides format in place. The previous file is
+// backed up to .goarchlint.backup. It returns warnings about the migration.
+func MigrateConfig(projectPath string) ([]string, error) {
+	configPath := filepath.Join(projectPath, ".goarchlint")
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf(".goarchlint not found, run 'go-arch-lint init' first")
+		}
+		return nil, fmt.Errorf("reading .goarchlint: %w", err)
+	}
+
+	var flat struct {
+		PresetUsed string `yaml:"preset_used"`
+	}
+	if err := yaml.Unmarshal(data, &flat); err != nil {
+		return nil, fmt.Errorf("parsing .goarchlint: %w", err)
+	}
+
+	// Compare against the current preset so only customizations become overrides
+	var presetSection *config.PresetSection
+	if preset, err := GetPreset(flat.PresetUsed); err == nil {
+		presetSection = &config.PresetSection{
+			Name:      preset.Name,
+			Structure: preset.Config.Structure,
+			Rules:     preset.Config.Rules,
+			ErrorPrompt: config.ErrorPrompt{
+				Enabled:                 true,
+				ArchitecturalGoals:      preset.ArchitecturalGoals,
+				Principles:              preset.Principles,
+				RefactoringGuidance:     preset.RefactoringGuidance,
+				CoverageGuidance:        preset.CoverageGuidance,
+				BlackboxTestingGuidance: preset.BlackboxTestingGuidance,
+			},
+		}
+	}
+
+	migrated, warnings, err := config.MigrateFlat(data, presetSection)
+	if err != nil {
+		return nil, err
+	}
+
+	// Backup existing config
+	if err := os.WriteFile(configPath+".backup", data, 0644); err != nil {
+		return nil, fmt.Errorf("creating backup: %w", err)
+	}
+	if err := os.WriteFile(configPath, migrated, 0644); err != nil {
+		return nil, fmt.Errorf("writing config file: %w", err)
+	}
+
+	return warnings, nil
+}
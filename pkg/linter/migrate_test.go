@@ -0,0 +1,71 @@
+package linter_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	flatConfig := `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: []
+scan_paths:
+  - cmd
+  - pkg
+`
+	files := map[string]string{
+		".goarchlint": flatConfig,
+		"cmd/main.go": "package main\n\nimport \"github.com/test/project/pkg/a\"\n\nfunc main() { a.Run() }\n",
+		"pkg/a/a.go":  "package a\n\nimport \"github.com/test/project/pkg/b\"\n\nfunc Run() { b.Run() }\n",
+		"pkg/b/b.go":  "package b\n\nfunc Run() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	_, before, _, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	warnings, err := linter.MigrateConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("MigrateConfig failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+
+	backup, err := os.ReadFile(filepath.Join(tmpDir, ".goarchlint.backup"))
+	if err != nil || string(backup) != flatConfig {
+		t.Errorf("expected backup of the flat config, got %q (%v)", backup, err)
+	}
+	migrated, err := os.ReadFile(filepath.Join(tmpDir, ".goarchlint"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(migrated), "preset:\n  name: custom\n") {
+		t.Errorf("expected preset section, got:\n%s", migrated)
+	}
+
+	// The migrated config enforces the same rules
+	_, after, _, err := linter.Run(tmpDir, "", false, false, "")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.Contains(before, "pkg/a imports pkg/b") {
+		t.Fatalf("expected a violation before migrating, got:\n%s", before)
+	}
+	if !strings.Contains(after, "pkg/a imports pkg/b") {
+		t.Errorf("expected the same violation after migrating, got:\n%s", after)
+	}
+
+	// Migrating again is an error
+	if _, err := linter.MigrateConfig(tmpDir); err == nil {
+		t.Error("expected error for already migrated config")
+	}
+}
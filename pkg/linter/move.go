@@ -0,0 +1,610 @@
+package linter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// PlanMove describes what moving the package directory from to the directory to would
+// change: the files moved, the imports to rewrite and the violations the move introduces
+// or resolves. Nothing is written: the move is simulated in memory over the project files,
+// which are validated with the current config. Subpackages move along.
+func PlanMove(projectPath, from, to, format string) (string, error) {
+	if !containsFormat(output.MovePlanFormats, format) {
+		return "", fmt.Errorf("unsupported move plan format %q (supported: %s)", format, strings.Join(output.MovePlanFormats, ", "))
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", err
+	}
+	from, to, err = checkMove(projectPath, from, to)
+	if err != nil {
+		return "", err
+	}
+	fromImport, toImport, err := moveImportPaths(cfg, from, to)
+	if err != nil {
+		return "", err
+	}
+
+	plan := output.MovePlan{From: from, To: to, FromImport: fromImport, ToImport: toImport, Introduced: []output.MoveViolation{}, Resolved: []output.MoveViolation{}}
+	if plan.Files, err = filesUnder(filepath.Join(projectPath, filepath.FromSlash(from))); err != nil {
+		return "", err
+	}
+	if plan.Rewrites, err = importRewrites(projectPath, fromImport, toImport); err != nil {
+		return "", err
+	}
+
+	before, err := previewViolations(projectPath, nil, cfg, typecheck.New(projectPath, cfg.Module))
+	if err != nil {
+		return "", err
+	}
+
+	moved, err := newMovedFS(projectPath, from, to, fromImport, toImport)
+	if err != nil {
+		return "", err
+	}
+	checker := typecheck.New(projectPath, cfg.Module)
+	checker.SetFS(moved)
+	after, err := previewViolations(projectPath, moved, cfg, checker)
+	if err != nil {
+		return "", err
+	}
+
+	// Compare each side with the other renamed to its layout, so that violations the move
+	// only relocates cancel out. Collapsed violations are compared location by location.
+	before, after = expandSpans(before), expandSpans(after)
+	for _, viol := range moveDelta(after, renameViolations(before, from, to)) {
+		plan.Introduced = append(plan.Introduced, moveViolation(viol))
+	}
+	for _, viol := range moveDelta(before, renameViolations(after, to, from)) {
+		plan.Resolved = append(plan.Resolved, moveViolation(viol))
+	}
+
+	if path.Base(from) != path.Base(to) {
+		plan.Notes = append(plan.Notes, fmt.Sprintf("The directory name changes from %s to %s; package clauses are kept, so importers keep referring to the old package name.", path.Base(from), path.Base(to)))
+	}
+	if data, err := os.ReadFile(filepath.Join(projectPath, ".goarchlint")); err == nil && pathIndex(string(data), from) >= 0 {
+		plan.Notes = append(plan.Notes, fmt.Sprintf(".goarchlint mentions %s; update the paths in the config by hand.", from))
+	}
+
+	return output.FormatMovePlan(plan, format)
+}
+
+// Move moves the package directory from to the directory to, with its subpackages, and
+// rewrites every import of them in the project. Rewritten files are formatted like gofmt.
+func Move(projectPath, from, to string) error {
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return err
+	}
+	from, to, err = checkMove(projectPath, from, to)
+	if err != nil {
+		return err
+	}
+	fromImport, toImport, err := moveImportPaths(cfg, from, to)
+	if err != nil {
+		return err
+	}
+	return movePackage(projectPath, from, to, fromImport, toImport)
+}
+
+// checkMove cleans the directories of a move, relative to the project root, and checks
+// that the source is a directory and the destination does not exist yet
+func checkMove(projectPath, from, to string) (string, string, error) {
+	from = path.Clean(filepath.ToSlash(from))
+	to = path.Clean(filepath.ToSlash(to))
+	for _, dir := range []string{from, to} {
+		if path.IsAbs(dir) || dir == "." || dir == ".." || strings.HasPrefix(dir, "../") {
+			return "", "", fmt.Errorf("%q is not a directory inside the project", dir)
+		}
+	}
+	if from == to || strings.HasPrefix(to, from+"/") {
+		return "", "", fmt.Errorf("cannot move %s into itself", from)
+	}
+
+	if info, err := os.Stat(filepath.Join(projectPath, filepath.FromSlash(from))); err != nil || !info.IsDir() {
+		return "", "", fmt.Errorf("%s is not a directory", from)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, filepath.FromSlash(to))); err == nil {
+		return "", "", fmt.Errorf("%s already exists", to)
+	}
+	return from, to, nil
+}
+
+// moveImportPaths returns the import paths of a package directory before and after a
+// move, within the module of the project or the nested module declared in scan_paths
+// that contains it. Moves between modules are not supported.
+func moveImportPaths(cfg *config.Config, from, to string) (string, string, error) {
+	fromModule, fromImport := dirImportPath(cfg, from)
+	toModule, toImport := dirImportPath(cfg, to)
+	if fromModule != toModule {
+		return "", "", fmt.Errorf("cannot move %s from module %s to module %s", from, fromModule, toModule)
+	}
+	if fromModule == "" {
+		return "", "", fmt.Errorf("no module path configured")
+	}
+	return fromImport, toImport, nil
+}
+
+// dirImportPath returns the module containing a directory and the import path of the
+// directory. The deepest nested module root wins over the project module.
+func dirImportPath(cfg *config.Config, dir string) (string, string) {
+	module, root := cfg.Module, ""
+	for rootModule, rootDir := range cfg.GetModuleRoots() {
+		if (dir == rootDir || strings.HasPrefix(dir, rootDir+"/")) && len(rootDir) >= len(root) {
+			module, root = rootModule, rootDir
+		}
+	}
+	if root == "" {
+		return module, module + "/" + dir
+	}
+	if dir == root {
+		return module, module
+	}
+	return module, module + "/" + strings.TrimPrefix(dir, root+"/")
+}
+
+// movedImport returns the import path after a move, and whether the import refers to the
+// moved package or one of its subpackages
+func movedImport(importPath, fromImport, toImport string) (string, bool) {
+	if importPath == fromImport {
+		return toImport, true
+	}
+	if strings.HasPrefix(importPath, fromImport+"/") {
+		return toImport + strings.TrimPrefix(importPath, fromImport), true
+	}
+	return "", false
+}
+
+// importRewrites lists the imports of the moved package in the Go files of the project,
+// including test files and files outside the scan paths
+func importRewrites(projectPath, fromImport, toImport string) ([]output.ImportRewrite, error) {
+	rewrites := []output.ImportRewrite{}
+	err := walkGoFiles(projectPath, func(filePath, relPath string) error {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, filePath, nil, parser.ImportsOnly)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", relPath, err)
+		}
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if newPath, ok := movedImport(importPath, fromImport, toImport); ok {
+				rewrites = append(rewrites, output.ImportRewrite{
+					File: relPath,
+					Line: fset.Position(spec.Path.Pos()).Line,
+					Old:  importPath,
+					New:  newPath,
+				})
+			}
+		}
+		return nil
+	})
+	return rewrites, err
+}
+
+// movePackage rewrites the imports of the moved package in every Go file under root, then
+// renames its directory
+func movePackage(root, from, to, fromImport, toImport string) error {
+	err := walkGoFiles(root, func(filePath, relPath string) error {
+		return rewriteImports(filePath, fromImport, toImport)
+	})
+	if err != nil {
+		return err
+	}
+
+	target := filepath.Join(root, filepath.FromSlash(to))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	return os.Rename(filepath.Join(root, filepath.FromSlash(from)), target)
+}
+
+// rewriteImports replaces the imports of the moved package in one file. Files without
+// such imports are untouched.
+func rewriteImports(filePath, fromImport, toImport string) error {
+	content, changed, err := rewrittenImports(filePath, fromImport, toImport)
+	if err != nil || !changed {
+		return err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, content, info.Mode().Perm())
+}
+
+// rewrittenImports returns the content of a file with the imports of the moved package
+// replaced, formatted like gofmt, which keeps import blocks sorted, and whether the file
+// has such imports at all
+func rewrittenImports(filePath, fromImport, toImport string) ([]byte, bool, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing %s: %w", filePath, err)
+	}
+
+	changed := false
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if newPath, ok := movedImport(importPath, fromImport, toImport); ok {
+			spec.Path.Value = strconv.Quote(newPath)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, false, nil
+	}
+	ast.SortImports(fset, file)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, false, fmt.Errorf("formatting %s: %w", filePath, err)
+	}
+	return buf.Bytes(), true, nil
+}
+
+// walkGoFiles calls fn for every Go file under root, skipping the directories the go
+// tool ignores: vendor, testdata and names starting with "." or "_"
+func walkGoFiles(root string, fn func(filePath, relPath string) error) error {
+	return filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if filePath != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(filePath, ".go") {
+			return nil
+		}
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+		return fn(filePath, filepath.ToSlash(relPath))
+	})
+}
+
+// filesUnder lists the regular files under dir, relative to it and sorted
+func filesUnder(dir string) ([]string, error) {
+	files := []string{}
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relPath))
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// renameViolations returns copies of the violations with the directory from replaced by
+// to in their file and issue
+func renameViolations(violations []validator.Violation, from, to string) []validator.Violation {
+	renamed := make([]validator.Violation, len(violations))
+	for i, viol := range violations {
+		viol.File = replacePath(viol.File, from, to)
+		viol.Issue = replacePath(viol.Issue, from, to)
+		renamed[i] = viol
+	}
+	return renamed
+}
+
+// expandSpans replaces each violation that collapses several findings of a file by one
+// violation per finding
+func expandSpans(violations []validator.Violation) []validator.Violation {
+	var expanded []validator.Violation
+	for _, viol := range violations {
+		if len(viol.Spans) == 0 {
+			expanded = append(expanded, viol)
+			continue
+		}
+		for _, span := range viol.Spans {
+			finding := viol
+			finding.Line, finding.Column, finding.Issue, finding.Spans = span.Line, span.Column, span.Issue, nil
+			expanded = append(expanded, finding)
+		}
+	}
+	return expanded
+}
+
+// moveDelta returns the violations of a that are not in b, counting duplicates. Positions
+// are left out of the comparison, since sorting rewritten imports may shift their lines.
+func moveDelta(a, b []validator.Violation) []validator.Violation {
+	type key struct {
+		violationType validator.ViolationType
+		file          string
+		issue         string
+	}
+	remaining := make(map[key]int)
+	for _, viol := range b {
+		remaining[key{viol.Type, viol.File, viol.Issue}]++
+	}
+
+	var delta []validator.Violation
+	for _, viol := range a {
+		k := key{viol.Type, viol.File, viol.Issue}
+		if remaining[k] > 0 {
+			remaining[k]--
+			continue
+		}
+		delta = append(delta, viol)
+	}
+	return delta
+}
+
+// replacePath replaces the slash-separated path from by to wherever it appears as whole
+// path elements, e.g. internal/foo in "internal/foo/x.go" or "example.com/m/internal/foo"
+// but not in "internal/foobar" or "xinternal/foo"
+func replacePath(s, from, to string) string {
+	var sb strings.Builder
+	for {
+		i := pathIndex(s, from)
+		if i < 0 {
+			sb.WriteString(s)
+			return sb.String()
+		}
+		sb.WriteString(s[:i])
+		sb.WriteString(to)
+		s = s[i+len(from):]
+	}
+}
+
+// pathIndex returns the index of the first occurrence of the path p in s as whole path
+// elements, or -1
+func pathIndex(s, p string) int {
+	for offset := 0; offset < len(s); {
+		i := strings.Index(s[offset:], p)
+		if i < 0 {
+			return -1
+		}
+		start, end := offset+i, offset+i+len(p)
+		if (start == 0 || !isPathChar(s[start-1])) && (end == len(s) || !isPathChar(s[end])) {
+			return start
+		}
+		offset = start + 1
+	}
+	return -1
+}
+
+// isPathChar reports whether c may appear in a path element
+func isPathChar(c byte) bool {
+	return c == '_' || c == '-' || c == '.' || c == '~' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// moveViolation converts a violation for the move plan
+func moveViolation(viol validator.Violation) output.MoveViolation {
+	return output.MoveViolation{Type: string(viol.Type), File: viol.File, Line: viol.Line, Issue: viol.Issue}
+}
+
+// movedFS is the project directory as it would be after moving the directory from to the
+// directory to: what was under from is under to, and files whose imports the move
+// rewrites have their new content. Everything else is read from the project as it is.
+type movedFS struct {
+	base      fs.FS
+	from, to  string
+	rewritten map[string][]byte // Content by name after the move
+}
+
+// newMovedFS simulates moving the package directory from to the directory to, without
+// writing anything
+func newMovedFS(projectPath, from, to, fromImport, toImport string) (*movedFS, error) {
+	rewritten := make(map[string][]byte)
+	err := walkGoFiles(projectPath, func(filePath, relPath string) error {
+		content, changed, err := rewrittenImports(filePath, fromImport, toImport)
+		if err != nil || !changed {
+			return err
+		}
+		if rest, ok := cutDir(relPath, from); ok {
+			relPath = to + rest
+		}
+		rewritten[relPath] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &movedFS{base: os.DirFS(projectPath), from: from, to: to, rewritten: rewritten}, nil
+}
+
+// Open implements fs.FS
+func (m *movedFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	src, ok := m.source(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	info, err := fs.Stat(m.base, src)
+	switch {
+	case err == nil && !info.IsDir():
+		if content, ok := m.rewritten[name]; ok {
+			return &movedFile{info: movedInfo{FileInfo: info, name: info.Name(), size: int64(len(content))}, Reader: bytes.NewReader(content)}, nil
+		}
+		return m.base.Open(src)
+	case err == nil:
+		return m.openDir(name, src, info)
+	case errors.Is(err, fs.ErrNotExist) && m.aboveTo(name):
+		// A new parent directory of to
+		if info, err = fs.Stat(m.base, m.from); err != nil {
+			return nil, err
+		}
+		return m.openDir(name, "", info)
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	default:
+		return nil, err
+	}
+}
+
+// openDir lists the directory name after the move, read from the directory src of the
+// project ("" if it does not exist yet)
+func (m *movedFS) openDir(name, src string, info fs.FileInfo) (fs.File, error) {
+	var entries []fs.DirEntry
+	if src != "" {
+		baseEntries, err := fs.ReadDir(m.base, src)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range baseEntries {
+			child := path.Join(name, entry.Name())
+			if _, ok := m.source(child); !ok {
+				continue // The moved directory
+			}
+			if content, ok := m.rewritten[child]; ok {
+				childInfo, err := entry.Info()
+				if err != nil {
+					return nil, err
+				}
+				entry = fs.FileInfoToDirEntry(movedInfo{FileInfo: childInfo, name: entry.Name(), size: int64(len(content))})
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	// The next directory on the way to to, unless it exists already
+	if m.aboveTo(name) {
+		next, _, _ := strings.Cut(strings.TrimPrefix(m.to, name+"/"), "/")
+		if name == "." {
+			next, _, _ = strings.Cut(m.to, "/")
+		}
+		if !slices.ContainsFunc(entries, func(entry fs.DirEntry) bool { return entry.Name() == next }) {
+			fromInfo, err := fs.Stat(m.base, m.from)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, fs.FileInfoToDirEntry(movedInfo{FileInfo: fromInfo, name: next, size: fromInfo.Size()}))
+			sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+		}
+	}
+
+	return &movedDir{info: movedInfo{FileInfo: info, name: path.Base(name), size: info.Size()}, entries: entries}, nil
+}
+
+// source returns the name in the project of a name after the move, or false if nothing
+// is there after the move
+func (m *movedFS) source(name string) (string, bool) {
+	if rest, ok := cutDir(name, m.to); ok {
+		return m.from + rest, true
+	}
+	if _, ok := cutDir(name, m.from); ok {
+		return "", false
+	}
+	return name, true
+}
+
+// aboveTo reports whether name is a parent directory of to
+func (m *movedFS) aboveTo(name string) bool {
+	return name == "." || strings.HasPrefix(m.to, name+"/")
+}
+
+// cutDir returns what follows the directory dir in name ("" or "/..."), and whether name
+// is dir or below it
+func cutDir(name, dir string) (string, bool) {
+	if name == dir {
+		return "", true
+	}
+	if rest, ok := strings.CutPrefix(name, dir+"/"); ok {
+		return "/" + rest, true
+	}
+	return "", false
+}
+
+// movedInfo is the file info of an entry whose name or size changes with the move
+type movedInfo struct {
+	fs.FileInfo
+	name string
+	size int64
+}
+
+func (i movedInfo) Name() string { return i.name }
+func (i movedInfo) Size() int64  { return i.size }
+
+// movedFile is an open file with rewritten imports
+type movedFile struct {
+	info movedInfo
+	*bytes.Reader
+}
+
+// Stat implements fs.File
+func (f *movedFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+// Close implements fs.File
+func (f *movedFile) Close() error {
+	return nil
+}
+
+// movedDir is an open directory after the move
+type movedDir struct {
+	info    movedInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+// Stat implements fs.File
+func (d *movedDir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+// Read implements fs.File
+func (d *movedDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+// Close implements fs.File
+func (d *movedDir) Close() error {
+	return nil
+}
+
+// ReadDir implements fs.ReadDirFile
+func (d *movedDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries := d.entries[d.offset:]
+	if n > 0 {
+		if len(entries) == 0 {
+			return nil, io.EOF
+		}
+		if n < len(entries) {
+			entries = entries[:n]
+		}
+	}
+	d.offset += len(entries)
+	return entries, nil
+}
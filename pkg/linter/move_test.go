@@ -0,0 +1,243 @@
+package linter_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+// writeMoveProject creates a project where pkg/api imports internal/util, which the
+// rules forbid, and internal/util has a subpackage
+func writeMoveProject(t *testing.T) string {
+	t.Helper()
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: [pkg/shared]
+    pkg/shared: []
+    internal: []
+scan_paths:
+  - cmd
+  - pkg
+  - internal
+`,
+		"cmd/app/main.go":                 "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/test/project/pkg/api\"\n)\n\nfunc main() { fmt.Println(api.Name()) }\n",
+		"pkg/api/api.go":                  "package api\n\nimport (\n\t\"github.com/test/project/internal/util\"\n\t\"github.com/test/project/internal/util/strs\"\n\t\"github.com/test/project/pkg/shared/zz\"\n)\n\nfunc Name() string { return util.Name() + strs.Upper() + zz.Z() }\n",
+		"pkg/shared/zz/zz.go":             "package zz\n\nfunc Z() string { return \"\" }\n",
+		"internal/util/util.go":           "// Package util has helpers.\npackage util\n\nfunc Name() string { return \"util\" }\n",
+		"internal/util/util_test.go":      "package util_test\n\nimport (\n\t\"testing\"\n\n\t\"github.com/test/project/internal/util\"\n)\n\nfunc TestName(t *testing.T) { _ = util.Name() }\n",
+		"internal/util/strs/strs.go":      "package strs\n\nfunc Upper() string { return \"\" }\n",
+		"internal/utilities/utilities.go": "package utilities\n",
+	}
+	return writeProject(t, files)
+}
+
+func TestPlanMove(t *testing.T) {
+	tmpDir := writeMoveProject(t)
+
+	result, err := linter.PlanMove(tmpDir, "internal/util", "pkg/shared/util", "json")
+	if err != nil {
+		t.Fatalf("PlanMove failed: %v", err)
+	}
+
+	var plan struct {
+		FromImport string   `json:"from_import"`
+		ToImport   string   `json:"to_import"`
+		Files      []string `json:"files"`
+		Rewrites   []struct {
+			File string `json:"file"`
+			Line int    `json:"line"`
+			Old  string `json:"old"`
+			New  string `json:"new"`
+		} `json:"rewrites"`
+		Introduced []struct {
+			File  string `json:"file"`
+			Issue string `json:"issue"`
+		} `json:"introduced"`
+		Resolved []struct {
+			File  string `json:"file"`
+			Issue string `json:"issue"`
+		} `json:"resolved"`
+		Notes []string `json:"notes"`
+	}
+	if err := json.Unmarshal([]byte(result), &plan); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+
+	if plan.FromImport != "github.com/test/project/internal/util" || plan.ToImport != "github.com/test/project/pkg/shared/util" {
+		t.Errorf("unexpected import paths: %s -> %s", plan.FromImport, plan.ToImport)
+	}
+	if strings.Join(plan.Files, ",") != "strs/strs.go,util.go,util_test.go" {
+		t.Errorf("unexpected moved files: %v", plan.Files)
+	}
+
+	// Test files are rewritten too, internal/utilities is not
+	var rewrites []string
+	for _, rewrite := range plan.Rewrites {
+		rewrites = append(rewrites, rewrite.File+":"+strings.TrimPrefix(rewrite.New, "github.com/test/project/"))
+	}
+	if strings.Join(rewrites, ",") != "internal/util/util_test.go:pkg/shared/util,pkg/api/api.go:pkg/shared/util,pkg/api/api.go:pkg/shared/util/strs" {
+		t.Errorf("unexpected rewrites: %v", rewrites)
+	}
+
+	// pkg/api may import pkg/shared but not internal
+	if len(plan.Introduced) != 0 {
+		t.Errorf("expected no introduced violations, got %+v", plan.Introduced)
+	}
+	if len(plan.Resolved) != 2 {
+		t.Errorf("expected the two forbidden imports of pkg/api to be resolved, got %+v", plan.Resolved)
+	}
+	for _, viol := range plan.Resolved {
+		if viol.File != "pkg/api/api.go" || !strings.Contains(viol.Issue, "internal/util") {
+			t.Errorf("expected resolved violations at their current location, got %+v", viol)
+		}
+	}
+	if len(plan.Notes) != 0 {
+		t.Errorf("expected no notes, got %v", plan.Notes)
+	}
+
+	// Nothing was written
+	if _, err := os.Stat(filepath.Join(tmpDir, "internal", "util", "util.go")); err != nil {
+		t.Errorf("expected the package to stay in place: %v", err)
+	}
+
+	back, err := linter.PlanMove(tmpDir, "pkg/shared/zz", "internal/zz", "markdown")
+	if err != nil {
+		t.Fatalf("PlanMove failed: %v", err)
+	}
+	if !strings.Contains(back, "The move introduces 1 and resolves 0 violations.") || !strings.Contains(back, "`pkg/api/api.go:") {
+		t.Errorf("expected the import of the moved package to become a violation, got:\n%s", back)
+	}
+
+	renamed, err := linter.PlanMove(tmpDir, "pkg/shared", "pkg/common", "markdown")
+	if err != nil {
+		t.Fatalf("PlanMove failed: %v", err)
+	}
+	for _, want := range []string{"The directory name changes from shared to common", ".goarchlint mentions pkg/shared"} {
+		if !strings.Contains(renamed, want) {
+			t.Errorf("expected note %q, got:\n%s", want, renamed)
+		}
+	}
+}
+
+func TestPlanMove_TypeChecksMovedPackages(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    internal: [internal, pkg]
+    pkg: []
+  type_leaks:
+    layers: [internal/domain]
+    forbidden: [internal/infra]
+scan_paths:
+  - internal
+  - pkg
+`,
+		"internal/domain/order.go": "package domain\n\nimport \"github.com/test/project/pkg/store\"\n\nfunc Open() *store.DB { return nil }\n",
+		"pkg/store/db.go":          "package store\n\ntype DB struct{}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	// internal/infra does not exist yet; the signature of Open only leaks once the
+	// moved package and the rewritten import are type-checked
+	result, err := linter.PlanMove(tmpDir, "pkg/store", "internal/infra/store", "json")
+	if err != nil {
+		t.Fatalf("PlanMove failed: %v", err)
+	}
+
+	var plan struct {
+		Introduced []struct {
+			Type  string `json:"type"`
+			File  string `json:"file"`
+			Issue string `json:"issue"`
+		} `json:"introduced"`
+	}
+	if err := json.Unmarshal([]byte(result), &plan); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+	if len(plan.Introduced) != 1 || plan.Introduced[0].File != "internal/domain/order.go" || !strings.Contains(plan.Introduced[0].Issue, "internal/infra/store.DB") {
+		t.Errorf("expected the type leak of Open to be introduced, got %+v", plan.Introduced)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "internal", "infra")); !os.IsNotExist(err) {
+		t.Errorf("expected nothing to be written, got %v", err)
+	}
+}
+
+func TestPlanMove_Errors(t *testing.T) {
+	tmpDir := writeMoveProject(t)
+
+	tests := []struct {
+		name     string
+		from, to string
+		format   string
+		wantErr  string
+	}{
+		{"missing source", "internal/nope", "internal/x", "markdown", "is not a directory"},
+		{"existing destination", "internal/util", "internal/utilities", "markdown", "already exists"},
+		{"into itself", "internal/util", "internal/util/inner", "markdown", "into itself"},
+		{"outside project", "internal/util", "../util", "markdown", "not a directory inside the project"},
+		{"unsupported format", "internal/util", "internal/x", "csv", "unsupported move plan format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := linter.PlanMove(tmpDir, tt.from, tt.to, tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+	tmpDir := writeMoveProject(t)
+
+	if err := linter.Move(tmpDir, "internal/util", "pkg/shared/util"); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "internal", "util")); !os.IsNotExist(err) {
+		t.Errorf("expected internal/util to be gone, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "pkg", "shared", "util", "strs", "strs.go")); err != nil {
+		t.Errorf("expected subpackages to move along: %v", err)
+	}
+
+	// The rewritten imports are sorted again, as gofmt would
+	api, err := os.ReadFile(filepath.Join(tmpDir, "pkg", "api", "api.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantImports := "import (\n\t\"github.com/test/project/pkg/shared/util\"\n\t\"github.com/test/project/pkg/shared/util/strs\"\n\t\"github.com/test/project/pkg/shared/zz\"\n)\n"
+	if !strings.Contains(string(api), wantImports) {
+		t.Errorf("unexpected rewritten imports:\n%s", api)
+	}
+
+	test, err := os.ReadFile(filepath.Join(tmpDir, "pkg", "shared", "util", "util_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(test), "\"github.com/test/project/pkg/shared/util\"") {
+		t.Errorf("expected the moved test file to import the new path:\n%s", test)
+	}
+
+	untouched, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "app", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(untouched) != "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/test/project/pkg/api\"\n)\n\nfunc main() { fmt.Println(api.Name()) }\n" {
+		t.Errorf("expected files without imports of the package to be untouched:\n%s", untouched)
+	}
+
+	// The moved project validates: pkg/api now only imports pkg/shared
+	if _, _, hasViolations, err := linter.Run(tmpDir, "markdown", false, false, ""); err != nil || hasViolations {
+		t.Errorf("expected no violations after the move, got %v (err %v)", hasViolations, err)
+	}
+}
@@ -0,0 +1,78 @@
+package linter
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/coverage"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/internal/scanner"
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// packageReport renders the documentation of one package as JSON, together with its
+// test coverage and the violations in its files
+func packageReport(cfg *config.Config, pkgPath string, filesWithAPI []scanner.FileInfo, g *graph.Graph, violations []validator.Violation, coverageResults []coverage.PackageCoverage) (string, error) {
+	pkgDoc, err := documentedPackage(pkgPath, filesWithAPI, g)
+	if err != nil {
+		return "", err
+	}
+
+	report := output.NewPackageReport(pkgDoc)
+	report.Violations, report.Coverage = packageViolationsAndCoverage(cfg, pkgPath, violations, coverageResults)
+
+	return output.FormatPackageReport(report)
+}
+
+// packagePage renders the markdown documentation of one package with its health: test
+// coverage against the applying threshold and the number of open violations
+func packagePage(cfg *config.Config, pkgPath string, filesWithAPI []scanner.FileInfo, g *graph.Graph, violations []validator.Violation, coverageResults []coverage.PackageCoverage) (string, error) {
+	pkgDoc, err := documentedPackage(pkgPath, filesWithAPI, g)
+	if err != nil {
+		return "", err
+	}
+
+	pkgViolations, pkgCoverage := packageViolationsAndCoverage(cfg, pkgPath, violations, coverageResults)
+	pkgDoc.Health = &output.PackageHealth{Coverage: pkgCoverage, Violations: len(pkgViolations)}
+	if pkgCoverage != nil {
+		pkgDoc.Health.Threshold = coverage.GetThresholdForPackage(pkgCoverage.Package, cfg.Module, cfg.GetCoverageThreshold(), cfg.GetPackageThresholds())
+	}
+
+	return output.GeneratePackageDocumentation(pkgDoc), nil
+}
+
+// documentedPackage collects the documentation of one package from the files of the run's
+// scan, which must include documentationScanOptions
+func documentedPackage(pkgPath string, filesWithAPI []scanner.FileInfo, g *graph.Graph) (output.PackageDocumentation, error) {
+	pkgDoc := packageDocumentation(pkgPath, filesWithAPI, g)
+	if pkgDoc.FileCount == 0 {
+		return output.PackageDocumentation{}, fmt.Errorf("no files found in package: %s", pkgPath)
+	}
+	return pkgDoc, nil
+}
+
+// packageViolationsAndCoverage returns the violations in the files of a package and its
+// test coverage, in the form of the JSON report. Coverage is nil when it was not measured.
+func packageViolationsAndCoverage(cfg *config.Config, pkgPath string, violations []validator.Violation, coverageResults []coverage.PackageCoverage) ([]output.ReportViolation, *output.ReportPackageCoverage) {
+	importPath := cfg.Module + "/" + pkgPath
+	pkgViolations := []output.ReportViolation{}
+	var pkgCoverage *output.ReportPackageCoverage
+
+	runReport := buildReport(cfg, "", violations, coverageResults, false)
+	for _, viol := range runReport.Violations {
+		// Coverage violations name the package by import path
+		if viol.File == pkgPath || viol.File == importPath || path.Dir(viol.File) == pkgPath {
+			pkgViolations = append(pkgViolations, viol)
+		}
+	}
+	if runReport.Coverage != nil {
+		for _, result := range runReport.Coverage.Packages {
+			if result.Package == importPath {
+				pkgCoverage = &result
+			}
+		}
+	}
+	return pkgViolations, pkgCoverage
+}
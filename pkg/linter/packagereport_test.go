@@ -0,0 +1,87 @@
+package linter_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestRunWithOptions_PackageJSON(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/billing
+rules:
+  directories_import:
+    internal/order: []
+    internal/store: []
+    internal/api: [internal/order]
+scan_paths:
+  - internal
+`,
+		"internal/order/order.go": "// Package order handles orders.\npackage order\n\nimport _ \"github.com/test/billing/internal/store\"\n\n// Order is a placed order.\ntype Order struct {\n\tID string\n}\n\n// Place places an order.\nfunc Place(o Order) error { return nil }\n",
+		"internal/store/store.go": "package store\n",
+		"internal/api/api.go":     "package api\n\nimport \"github.com/test/billing/internal/order\"\n\nvar _ = order.Place\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	graphOutput, violationsOutput, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "package-json", PackagePath: "internal/order"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if violationsOutput != "" {
+		t.Errorf("expected the violations to be part of the report only, got:\n%s", violationsOutput)
+	}
+
+	var report output.PackageReport
+	if err := json.Unmarshal([]byte(graphOutput), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, graphOutput)
+	}
+	if report.Package != "order" || report.Path != "internal/order" || report.Description != "Package order handles orders." {
+		t.Errorf("unexpected package fields: %+v", report)
+	}
+	if len(report.Exports) != 2 || report.Exports[0].Name != "Order" || report.Exports[1].Signature != "Place(Order) error" {
+		t.Errorf("unexpected exports: %+v", report.Exports)
+	}
+	if len(report.Dependencies) != 1 || report.Dependencies[0].Path != "internal/store" {
+		t.Errorf("unexpected dependencies: %+v", report.Dependencies)
+	}
+	if len(report.Dependents) != 1 || report.Dependents[0] != "internal/api" {
+		t.Errorf("expected internal/api as dependent, got %v", report.Dependents)
+	}
+
+	// Only the forbidden import in the package is reported
+	if len(report.Violations) != 1 || report.Violations[0].File != "internal/order/order.go" {
+		t.Errorf("expected the forbidden import of internal/order, got %+v", report.Violations)
+	}
+
+	// The markdown page lists the importing packages with their file counts and the health
+	pkgOutput, _, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "package", PackagePath: "internal/order"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !strings.Contains(pkgOutput, "## Imported By\n\nThis package is imported by:\n\n- `internal/api` (1 file)\n") {
+		t.Errorf("expected internal/api in Imported By section, got:\n%s", pkgOutput)
+	}
+	if !strings.Contains(pkgOutput, "- **Violations**: 1 open\n") || strings.Contains(pkgOutput, "- **Coverage**") {
+		t.Errorf("expected the forbidden import in the package health, got:\n%s", pkgOutput)
+	}
+	pkgOutput, _, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "package", PackagePath: "internal/api"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !strings.Contains(pkgOutput, "No local package imports this package.") {
+		t.Errorf("expected no dependents for internal/api, got:\n%s", pkgOutput)
+	}
+
+	_, _, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "package-json"})
+	if err == nil || !strings.Contains(err.Error(), "package path required") {
+		t.Errorf("expected error without package path, got %v", err)
+	}
+
+	_, _, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "package-json", PackagePath: "internal/missing"})
+	if err == nil || !strings.Contains(err.Error(), "no files found") {
+		t.Errorf("expected error for unknown package, got %v", err)
+	}
+}
@@ -124,6 +124,10 @@ Blackbox tests (using 'package foo_test' instead of 'package foo') verify behavi
 						"cmd":             {"internal/app", "internal/infra"},
 					},
 					DetectUnused: true,
+					AdapterPorts: config.AdapterPorts{
+						Adapters: []string{"internal/infra"},
+						Ports:    []string{"internal/domain", "internal/app"},
+					},
 					SharedExternalImports: config.SharedExternalImports{
 						Detect: true,
 						Mode:   "warn",
@@ -142,12 +146,12 @@ Blackbox tests (using 'package foo_test' instead of 'package foo') verify behavi
 						Lint:            true,
 						Location:        "colocated",
 						RequireBlackbox: true,
-						ExemptImports: []string{
+						ExemptImports: config.ExemptImports{All: []string{
 							"testing",
 							"github.com/stretchr/testify/assert",
 							"github.com/stretchr/testify/require",
 							"github.com/stretchr/testify/mock",
-						},
+						}},
 					},
 					TestCoverage: config.TestCoverage{
 						Enabled:   true,
@@ -270,12 +274,12 @@ Blackbox tests (using 'package foo_test' instead of 'package foo') verify behavi
 						Lint:            true,
 						Location:        "colocated",
 						RequireBlackbox: true,
-						ExemptImports: []string{
+						ExemptImports: config.ExemptImports{All: []string{
 							"testing",
 							"github.com/stretchr/testify/assert",
 							"github.com/stretchr/testify/require",
 							"github.com/stretchr/testify/mock",
-						},
+						}},
 					},
 					TestCoverage: config.TestCoverage{
 						Enabled:   true,
@@ -390,6 +394,10 @@ Blackbox tests (using 'package foo_test' instead of 'package foo') verify behavi
 						"cmd":               {"internal/ports", "internal/adapters"},
 					},
 					DetectUnused: true,
+					AdapterPorts: config.AdapterPorts{
+						Adapters: []string{"internal/adapters"},
+						Ports:    []string{"internal/ports"},
+					},
 					SharedExternalImports: config.SharedExternalImports{
 						Detect: true,
 						Mode:   "warn",
@@ -408,12 +416,12 @@ Blackbox tests (using 'package foo_test' instead of 'package foo') verify behavi
 						Lint:            true,
 						Location:        "colocated",
 						RequireBlackbox: true,
-						ExemptImports: []string{
+						ExemptImports: config.ExemptImports{All: []string{
 							"testing",
 							"github.com/stretchr/testify/assert",
 							"github.com/stretchr/testify/require",
 							"github.com/stretchr/testify/mock",
-						},
+						}},
 					},
 					TestCoverage: config.TestCoverage{
 						Enabled:   true,
@@ -469,13 +477,15 @@ func CreateConfigFromPreset(projectPath, presetName string, createDirs bool) err
 	}
 
 	type ConfigFile struct {
+		Version   int              `yaml:"version"`
 		Module    string           `yaml:"module"`
 		Preset    PresetSection    `yaml:"preset"`
 		Overrides OverridesSection `yaml:"overrides,omitempty"`
 	}
 
 	configData := ConfigFile{
-		Module: module,
+		Version:   config.SchemaVersion,
+		Module:  module,
 		Preset: PresetSection{
 			Name:      presetName,
 			Structure: preset.Config.Structure,
@@ -573,15 +583,39 @@ func detectModuleFromGoMod(projectPath string) (string, error) {
 func RefreshConfigFromPreset(projectPath, presetName string) error {
 	configPath := filepath.Join(projectPath, ".goarchlint")
 
+	data, configContent, _, err := refreshedConfig(projectPath, presetName)
+	if err != nil {
+		return err
+	}
+
+	// Backup existing config
+	backupPath := configPath + ".backup"
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		return fmt.Errorf("creating backup: %w", err)
+	}
+
+	// Write updated .goarchlint file
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		return fmt.Errorf("writing config file: %w", err)
+	}
+
+	return nil
+}
+
+// refreshedConfig builds the refreshed .goarchlint without writing it. It returns the
+// current file contents, the refreshed contents and the name of the preset used.
+func refreshedConfig(projectPath, presetName string) ([]byte, string, string, error) {
+	configPath := filepath.Join(projectPath, ".goarchlint")
+
 	// Check if .goarchlint exists
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return fmt.Errorf(".goarchlint not found, run 'go-arch-lint init' first")
+		return nil, "", "", fmt.Errorf(".goarchlint not found, run 'go-arch-lint init' first")
 	}
 
 	// Read existing config
 	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return fmt.Errorf("reading .goarchlint: %w", err)
+		return nil, "", "", fmt.Errorf("reading .goarchlint: %w", err)
 	}
 
 	// Parse existing config to extract preset name and overrides
@@ -597,17 +631,19 @@ func RefreshConfigFromPreset(projectPath, presetName string) error {
 		ErrorPrompt *config.ErrorPrompt `yaml:"error_prompt,omitempty"`
 	}
 	type NewConfigFile struct {
-		Preset    *PresetSection    `yaml:"preset,omitempty"`
-		Overrides *OverridesSection `yaml:"overrides,omitempty"`
+		RulesFrom *config.RulesFrom           `yaml:"rules_from,omitempty"`
+		Preset    *PresetSection              `yaml:"preset,omitempty"`
+		Overrides *OverridesSection           `yaml:"overrides,omitempty"`
+		Profiles  map[string]OverridesSection `yaml:"profiles,omitempty"`
 	}
 
 	var oldCfg OldConfigFile
 	var newCfg NewConfigFile
 	if err := yaml.Unmarshal(data, &oldCfg); err != nil {
-		return fmt.Errorf("parsing .goarchlint (old format): %w", err)
+		return nil, "", "", fmt.Errorf("parsing .goarchlint (old format): %w", err)
 	}
 	if err := yaml.Unmarshal(data, &newCfg); err != nil {
-		return fmt.Errorf("parsing .goarchlint (new format): %w", err)
+		return nil, "", "", fmt.Errorf("parsing .goarchlint (new format): %w", err)
 	}
 
 	// Determine preset name to use
@@ -619,14 +655,14 @@ func RefreshConfigFromPreset(projectPath, presetName string) error {
 			// Fall back to old format
 			presetName = oldCfg.PresetUsed
 		} else {
-			return fmt.Errorf("config was not created from a preset, cannot refresh. Use --preset to specify a preset to switch to")
+			return nil, "", "", fmt.Errorf("config was not created from a preset, cannot refresh. Use --preset to specify a preset to switch to")
 		}
 	}
 
 	// Get the preset
 	preset, err := GetPreset(presetName)
 	if err != nil {
-		return err
+		return nil, "", "", err
 	}
 
 	// Preserve existing overrides
@@ -635,16 +671,10 @@ func RefreshConfigFromPreset(projectPath, presetName string) error {
 		existingOverrides = *newCfg.Overrides
 	}
 
-	// Backup existing config
-	backupPath := configPath + ".backup"
-	if err := os.WriteFile(backupPath, data, 0644); err != nil {
-		return fmt.Errorf("creating backup: %w", err)
-	}
-
 	// Detect module from go.mod
 	module, err := detectModuleFromGoMod(projectPath)
 	if err != nil {
-		return fmt.Errorf("detecting module: %w", err)
+		return nil, "", "", fmt.Errorf("detecting module: %w", err)
 	}
 
 	// Build new config with updated preset and preserved overrides
@@ -655,13 +685,18 @@ func RefreshConfigFromPreset(projectPath, presetName string) error {
 		ErrorPrompt config.ErrorPrompt `yaml:"error_prompt"`
 	}
 	type FinalConfigFile struct {
+		Version   int                 `yaml:"version"`
 		Module    string              `yaml:"module"`
+		RulesFrom *config.RulesFrom   `yaml:"rules_from,omitempty"`
 		Preset    FinalPresetSection  `yaml:"preset"`
 		Overrides OverridesSection    `yaml:"overrides,omitempty"`
+		Profiles  map[string]OverridesSection `yaml:"profiles,omitempty"`
 	}
 
 	configData := FinalConfigFile{
-		Module: module,
+		Version:   config.SchemaVersion,
+		Module:    module,
+		RulesFrom: newCfg.RulesFrom, // Preserve the shared rule bundle
 		Preset: FinalPresetSection{
 			Name:      presetName,
 			Structure: preset.Config.Structure,
@@ -676,12 +711,13 @@ func RefreshConfigFromPreset(projectPath, presetName string) error {
 			},
 		},
 		Overrides: existingOverrides, // Preserve existing overrides
+		Profiles:  newCfg.Profiles,   // Preserve existing profiles
 	}
 
 	// Marshal to YAML
 	yamlData, err := yaml.Marshal(configData)
 	if err != nil {
-		return fmt.Errorf("marshaling config: %w", err)
+		return nil, "", "", fmt.Errorf("marshaling config: %w", err)
 	}
 
 	// Create config content with header
@@ -689,7 +725,7 @@ func RefreshConfigFromPreset(projectPath, presetName string) error {
 	configContent += "# Previous config backed up to .goarchlint.backup\n"
 	configContent += "#\n"
 	configContent += "# The 'preset' section has been updated with the latest preset version.\n"
-	configContent += "# Your custom 'overrides' section has been preserved.\n"
+	configContent += "# Your custom 'overrides' and 'profiles' sections have been preserved.\n"
 	configContent += "#\n\n"
 	configContent += string(yamlData)
 
@@ -708,10 +744,5 @@ func RefreshConfigFromPreset(projectPath, presetName string) error {
 		configContent += "#      Custom goals for your project...\n"
 	}
 
-	// Write updated .goarchlint file
-	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
-		return fmt.Errorf("writing config file: %w", err)
-	}
-
-	return nil
+	return data, configContent, presetName, nil
 }
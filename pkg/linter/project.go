@@ -0,0 +1,163 @@
+package linter
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/scanner"
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
+)
+
+// ProjectOptions configures a project loaded with LoadProject
+type ProjectOptions struct {
+	Detailed bool   // Record the symbols used from each import, as -detailed does
+	Profile  string // Named profile from the config's profiles section (empty for none)
+}
+
+// Project is a scanned project held in memory, for tools that embed the linter and keep
+// its dependency graph up to date while files change, such as editors and file watchers.
+// A Project is not safe for concurrent use.
+type Project struct {
+	path     string
+	opts     ProjectOptions
+	cfg      *config.Config
+	scanner  *scanner.Scanner
+	scanOpts scanner.ScanOptions
+	files    []scanner.FileInfo
+	graph    *graph.Graph
+}
+
+// LoadProject loads the configuration of a project, scans it and builds its dependency graph
+func LoadProject(projectPath string, opts ProjectOptions) (*Project, error) {
+	p := &Project{path: projectPath, opts: opts}
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// load reads the configuration and scans the whole project
+func (p *Project) load() error {
+	cfg, err := config.Load(p.path)
+	if err != nil {
+		return err
+	}
+	if err := cfg.SelectProfile(p.opts.Profile); err != nil {
+		return err
+	}
+
+	s := newScanner(p.path, cfg, false)
+	scanOpts := scanOptions(cfg, p.opts.Detailed)
+	files, g, err := scanWithOptions(s, cfg, scanOpts)
+	if err != nil {
+		return err
+	}
+	p.cfg, p.scanner, p.scanOpts, p.files, p.graph = cfg, s, scanOpts, files, g
+	return nil
+}
+
+// Update parses the given files again and updates the dependency graph, without
+// scanning the rest of the project. Paths are absolute or relative to the project root,
+// and may name new or deleted files; files the scan does not cover are ignored. A change
+// to .goarchlint, go.mod or the go.mod of a scan path reloads the configuration, with the
+// module paths of nested modules, and scans the whole project. Modules nested elsewhere
+// are not supported and return an error.
+func (p *Project) Update(changedFiles []string) error {
+	relPaths := make([]string, 0, len(changedFiles))
+	for _, file := range changedFiles {
+		if filepath.IsAbs(file) {
+			rel, err := filepath.Rel(p.path, file)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return fmt.Errorf("%s is outside the project", file)
+			}
+			file = rel
+		}
+		relPath := filepath.ToSlash(filepath.Clean(file))
+		if relPath == ".goarchlint" || relPath == "go.mod" {
+			return p.load()
+		}
+		if path.Base(relPath) == "go.mod" {
+			dir := path.Dir(relPath)
+			for _, scanPath := range p.cfg.GetScanPaths() {
+				if path.Clean(scanPath) == dir {
+					return p.load()
+				}
+			}
+			return fmt.Errorf("%s: nested modules are only supported at the root of a scan path; add %s to scan_paths", relPath, dir)
+		}
+		relPaths = append(relPaths, relPath)
+	}
+
+	changed, removed, err := p.scanner.ScanFiles(p.cfg.GetScanPaths(), relPaths, p.scanOpts)
+	if err != nil {
+		return err
+	}
+
+	// Replace the changed files in place and drop the removed ones, as the graph does
+	dropped := make(map[string]bool, len(removed))
+	for _, relPath := range removed {
+		dropped[relPath] = true
+	}
+	rescanned := make(map[string]scanner.FileInfo, len(changed))
+	for _, file := range changed {
+		rescanned[file.RelPath] = file
+	}
+	files := make([]scanner.FileInfo, 0, len(p.files)+len(changed))
+	for _, file := range p.files {
+		if dropped[file.RelPath] {
+			continue
+		}
+		if updated, ok := rescanned[file.RelPath]; ok {
+			file = updated
+			delete(rescanned, file.RelPath)
+		}
+		files = append(files, file)
+	}
+	graphFiles := make([]graph.FileInfo, len(changed))
+	for i, file := range changed {
+		if _, ok := rescanned[file.RelPath]; ok {
+			files = append(files, file)
+		}
+		graphFiles[i] = file
+	}
+	p.files = files
+
+	if p.scanOpts.IncludeImportUsages {
+		usageMap, callSites := importUsages(changed)
+		p.graph.Update(graphFiles, removed, usageMap)
+		p.graph.ApplyCallSites(callSites)
+	} else {
+		p.graph.Update(graphFiles, removed, nil)
+	}
+	return nil
+}
+
+// Validate checks the project against its rules and returns the formatted violations
+// and whether they should fail the build. Coverage, staticcheck and the checks reading
+// git history are left out.
+func (p *Project) Validate() (string, bool) {
+	v := newValidator(p.path, p.cfg, p.scanner, p.files, p.graph)
+	// The files change between validations, so they are type-checked afresh
+	attachTypeInformation(typecheck.New(p.path, p.cfg.Module), p.cfg, p.graph, v)
+
+	suppressions, _ := inlineSuppressions(p.files)
+	now := time.Now()
+	violations := applyInlineSuppressions(v.Validate(), suppressions, now)
+	addSourceLinks(p.path, p.cfg, violations)
+	addRuleSources(p.cfg, violations)
+	applyRuleActivation(p.cfg, violations, now)
+	applyReplaceDirectiveMode(violations, false)
+
+	return formatViolations(p.cfg, violations, nil), shouldFailBuild(violations, p.cfg)
+}
+
+// ExportGraph serializes the current dependency graph in the given format (json,
+// graphml or gexf), as ExportGraph does for a fresh scan
+func (p *Project) ExportGraph(format string) (string, error) {
+	return p.graph.Export(format)
+}
@@ -0,0 +1,128 @@
+package linter_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestProject_Update(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(relPath, content string) {
+		t.Helper()
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("go.mod", "module github.com/test/project\n\ngo 1.21\n")
+	write(".goarchlint", "module: github.com/test/project\nrules:\n  directories_import:\n    cmd: [pkg]\n    pkg: []\n    internal: []\n")
+	write("cmd/app/main.go", "package main\n\nimport \"github.com/test/project/pkg/api\"\n\nfunc main() { api.Serve() }\n")
+	write("pkg/api/api.go", "package api\n\nfunc Serve() {}\n")
+	write("internal/store/store.go", "package store\n\nfunc Save() {}\n")
+
+	project, err := linter.LoadProject(tmpDir, linter.ProjectOptions{Detailed: true})
+	if err != nil {
+		t.Fatalf("LoadProject failed: %v", err)
+	}
+	if violations, shouldFail := project.Validate(); shouldFail {
+		t.Fatalf("expected the project to pass, got:\n%s", violations)
+	}
+
+	// A new file with a forbidden import, given as an absolute path
+	write("pkg/api/store.go", "package api\n\nimport \"github.com/test/project/internal/store\"\n\nfunc save() { store.Save() }\n")
+	if err := project.Update([]string{filepath.Join(tmpDir, "pkg/api/store.go")}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	violations, shouldFail := project.Validate()
+	if !shouldFail || !strings.Contains(violations, "pkg/api/store.go") {
+		t.Errorf("expected the new import to be reported, got:\n%s", violations)
+	}
+	exported, err := project.ExportGraph("json")
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if !strings.Contains(exported, "pkg/api/store.go") || !strings.Contains(exported, "Save") {
+		t.Errorf("expected the graph to include the new file and its used symbols, got:\n%s", exported)
+	}
+
+	// Deleting the file resolves the violation
+	if err := os.Remove(filepath.Join(tmpDir, "pkg/api/store.go")); err != nil {
+		t.Fatal(err)
+	}
+	if err := project.Update([]string{"pkg/api/store.go"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if violations, shouldFail := project.Validate(); shouldFail {
+		t.Errorf("expected the project to pass again, got:\n%s", violations)
+	}
+
+	// A config change reloads the rules
+	write("pkg/api/store.go", "package api\n\nimport \"github.com/test/project/internal/store\"\n\nfunc save() { store.Save() }\n")
+	write(".goarchlint", "module: github.com/test/project\nrules:\n  directories_import:\n    cmd: [pkg]\n    pkg: [internal]\n    internal: []\n")
+	if err := project.Update([]string{".goarchlint"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if violations, shouldFail := project.Validate(); shouldFail {
+		t.Errorf("expected the new rules to allow the import, got:\n%s", violations)
+	}
+
+	if err := project.Update([]string{filepath.Join(filepath.Dir(tmpDir), "elsewhere.go")}); err == nil {
+		t.Error("expected an error for a file outside the project")
+	}
+	if err := project.Update([]string{"internal/plugin/go.mod"}); err == nil || !strings.Contains(err.Error(), "add internal/plugin to scan_paths") {
+		t.Errorf("expected an error for a module nested below a scan path, got %v", err)
+	}
+}
+
+func TestProject_Update_NestedModule(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(relPath, content string) {
+		t.Helper()
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(".goarchlint", "module: github.com/test/project\nscan_paths:\n  - services/billing\n  - services/api\nrules:\n  directories_import:\n    services: [services]\n")
+	write("services/billing/go.mod", "module github.com/test/billing\n\ngo 1.21\n")
+	write("services/billing/invoice/invoice.go", "package invoice\n\nfunc Total() int { return 0 }\n")
+	write("services/api/go.mod", "module github.com/test/api\n\ngo 1.21\n")
+	write("services/api/handler/handler.go", "package handler\n\nimport \"github.com/test/payments/invoice\"\n\nvar _ = invoice.Total\n")
+
+	project, err := linter.LoadProject(tmpDir, linter.ProjectOptions{})
+	if err != nil {
+		t.Fatalf("LoadProject failed: %v", err)
+	}
+	exported, err := project.ExportGraph("json")
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if strings.Contains(exported, `"local_path": "services/billing/invoice"`) {
+		t.Fatalf("expected the import of another module to be external, got:\n%s", exported)
+	}
+
+	// Renaming the nested module makes the import local
+	write("services/billing/go.mod", "module github.com/test/payments\n\ngo 1.21\n")
+	if err := project.Update([]string{"services/billing/go.mod"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	exported, err = project.ExportGraph("json")
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if !strings.Contains(exported, `"local_path": "services/billing/invoice"`) {
+		t.Errorf("expected the import to resolve to the renamed nested module, got:\n%s", exported)
+	}
+}
@@ -0,0 +1,140 @@
+package linter
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/internal/scanner"
+)
+
+// PromotionOptions configures the package promotion advisor
+type PromotionOptions struct {
+	MinImporters int    // pkg/ packages that must import an internal package to suggest its promotion
+	Format       string // "markdown" or "json"
+}
+
+// Promotions suggests moving packages between internal/ and pkg/ based on who imports
+// them: internal packages many pkg/ packages build on are de facto public API, while
+// pkg/ packages used by a single cmd/ binary are private to it.
+func Promotions(projectPath string, opts PromotionOptions) (string, error) {
+	if !containsFormat(output.PromotionFormats, opts.Format) {
+		return "", fmt.Errorf("unsupported promotions format %q (supported: %s)", opts.Format, strings.Join(output.PromotionFormats, ", "))
+	}
+	if opts.MinImporters < 1 {
+		return "", fmt.Errorf("-min-importers must be at least 1")
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	// The stability annotations come from the scan building the graph
+	scanOpts := unionScanOptions(scanOptions(cfg, false), scanner.ScanOptions{IncludeStability: true})
+	files, g, err := scanWithOptions(newScanner(projectPath, cfg, false), cfg, scanOpts)
+	if err != nil {
+		return "", err
+	}
+
+	report := promotionCandidates(g, packageStabilities(files), opts.MinImporters)
+	return output.FormatPromotions(report, opts.Format)
+}
+
+// promotionCandidates finds the internal/ packages imported by at least minImporters
+// pkg/ packages and not marked experimental or deprecated, and the pkg/ packages only
+// imported from one cmd/ binary
+func promotionCandidates(g *graph.Graph, stabilities map[string]string, minImporters int) output.PromotionReport {
+	afferent, _ := packageCoupling(g)
+
+	report := output.PromotionReport{MinImporters: minImporters, Promote: []output.PromotionEntry{}, Demote: []output.PromotionEntry{}}
+	for pkg, importerSet := range afferent {
+		importers := make([]string, 0, len(importerSet))
+		for importer := range importerSet {
+			importers = append(importers, importer)
+		}
+		sort.Strings(importers)
+
+		switch topLevelDir(pkg) {
+		case "internal":
+			pkgImporters := 0
+			for _, importer := range importers {
+				if topLevelDir(importer) == "pkg" {
+					pkgImporters++
+				}
+			}
+			stability := stabilities[pkg]
+			if pkgImporters < minImporters || stability == "experimental" || stability == "deprecated" {
+				continue
+			}
+			report.Promote = append(report.Promote, output.PromotionEntry{
+				Package:   pkg,
+				Target:    "pkg/" + strings.TrimPrefix(pkg, "internal/"),
+				Importers: importers,
+				Stability: stability,
+			})
+		case "pkg":
+			if binary := singleBinary(importers); binary != "" {
+				report.Demote = append(report.Demote, output.PromotionEntry{
+					Package:   pkg,
+					Target:    "internal/" + strings.TrimPrefix(pkg, "pkg/"),
+					Importers: importers,
+					Stability: stabilities[pkg],
+				})
+			}
+		}
+	}
+
+	// Most imported first
+	sort.Slice(report.Promote, func(i, j int) bool {
+		if len(report.Promote[i].Importers) != len(report.Promote[j].Importers) {
+			return len(report.Promote[i].Importers) > len(report.Promote[j].Importers)
+		}
+		return report.Promote[i].Package < report.Promote[j].Package
+	})
+	sort.Slice(report.Demote, func(i, j int) bool {
+		return report.Demote[i].Package < report.Demote[j].Package
+	})
+	return report
+}
+
+// singleBinary returns the cmd/ binary directory (e.g. cmd/app) all importers belong
+// to, or "" if an importer is outside cmd/ or the importers span several binaries
+func singleBinary(importers []string) string {
+	binary := ""
+	for _, importer := range importers {
+		segments := strings.Split(importer, "/")
+		if segments[0] != "cmd" || len(segments) < 2 {
+			return ""
+		}
+		dir := path.Join(segments[0], segments[1])
+		if binary != "" && dir != binary {
+			return ""
+		}
+		binary = dir
+	}
+	return binary
+}
+
+// packageStabilities returns the archlint:stability annotation of each package directory
+func packageStabilities(files []scanner.FileInfo) map[string]string {
+	stabilities := make(map[string]string)
+	for _, file := range files {
+		if file.Stability != "" {
+			stabilities[path.Dir(file.RelPath)] = file.Stability
+		}
+	}
+	return stabilities
+}
+
+// topLevelDir returns the first element of a slash-separated directory
+func topLevelDir(dir string) string {
+	if i := strings.Index(dir, "/"); i >= 0 {
+		return dir[:i]
+	}
+	return dir
+}
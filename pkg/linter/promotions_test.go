@@ -0,0 +1,86 @@
+package linter_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestPromotions(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg, internal]
+    pkg: [internal]
+    internal: []
+scan_paths:
+  - cmd
+  - pkg
+  - internal
+`,
+		"cmd/server/main.go":   "package main\n\nimport (\n\t\"github.com/test/project/pkg/api\"\n\t\"github.com/test/project/pkg/flags\"\n)\n\nfunc main() { flags.Parse(); api.Serve() }\n",
+		"cmd/worker/main.go":   "package main\n\nimport \"github.com/test/project/pkg/jobs\"\n\nfunc main() { jobs.Run() }\n",
+		"pkg/api/api.go":       "package api\n\nimport (\n\t\"github.com/test/project/internal/money\"\n\t\"github.com/test/project/internal/draft\"\n)\n\nfunc Serve() { money.Add(); draft.Try() }\n",
+		"pkg/jobs/jobs.go":     "package jobs\n\nimport (\n\t\"github.com/test/project/internal/money\"\n\t\"github.com/test/project/internal/draft\"\n)\n\nfunc Run() { money.Add(); draft.Try() }\n",
+		"pkg/flags/flags.go":   "package flags\n\nfunc Parse() {}\n",
+		"internal/money/m.go":  "// Package money handles amounts.\n//\n// archlint:stability stable\npackage money\n\nfunc Add() {}\n",
+		"internal/draft/d.go":  "// Package draft is being designed.\n//\n// archlint:stability experimental\npackage draft\n\nfunc Try() {}\n",
+		"internal/unused/u.go": "package unused\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	result, err := linter.Promotions(tmpDir, linter.PromotionOptions{MinImporters: 2, Format: "json"})
+	if err != nil {
+		t.Fatalf("Promotions failed: %v", err)
+	}
+
+	var report struct {
+		Promote []struct {
+			Package   string   `json:"package"`
+			Target    string   `json:"target"`
+			Importers []string `json:"importers"`
+			Stability string   `json:"stability"`
+		} `json:"promote"`
+		Demote []struct {
+			Package   string   `json:"package"`
+			Target    string   `json:"target"`
+			Importers []string `json:"importers"`
+		} `json:"demote"`
+	}
+	if err := json.Unmarshal([]byte(result), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+
+	// internal/draft is experimental, so only internal/money is promoted
+	if len(report.Promote) != 1 || report.Promote[0].Package != "internal/money" || report.Promote[0].Target != "pkg/money" ||
+		report.Promote[0].Stability != "stable" || strings.Join(report.Promote[0].Importers, ",") != "pkg/api,pkg/jobs" {
+		t.Errorf("unexpected promotion candidates:\n%s", result)
+	}
+
+	// Each of pkg/api, pkg/flags and pkg/jobs is used by one binary only
+	var demoted []string
+	for _, entry := range report.Demote {
+		demoted = append(demoted, entry.Package+"->"+entry.Target)
+	}
+	if strings.Join(demoted, ",") != "pkg/api->internal/api,pkg/flags->internal/flags,pkg/jobs->internal/jobs" {
+		t.Errorf("unexpected demotion candidates: %v", demoted)
+	}
+
+	strict, err := linter.Promotions(tmpDir, linter.PromotionOptions{MinImporters: 3, Format: "markdown"})
+	if err != nil {
+		t.Fatalf("Promotions failed: %v", err)
+	}
+	if !strings.Contains(strict, "## Promote to pkg/") || strings.Contains(strict, "`internal/money`") {
+		t.Errorf("expected no promotion candidates with -min-importers=3, got:\n%s", strict)
+	}
+
+	if _, err := linter.Promotions(tmpDir, linter.PromotionOptions{MinImporters: 2, Format: "csv"}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+	if _, err := linter.Promotions(tmpDir, linter.PromotionOptions{MinImporters: 0, Format: "json"}); err == nil {
+		t.Error("expected error for -min-importers below 1")
+	}
+}
@@ -0,0 +1,75 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/publish"
+)
+
+// PublishOptions configures publishing the architecture index to a documentation system.
+// Credentials are passed in by the caller (the CLI reads them from environment variables).
+type PublishOptions struct {
+	Target   string // "confluence" or "notion"
+	Title    string // Page title (default: "<module> Architecture")
+	URL      string // Confluence site URL, or Notion API URL override
+	User     string // Confluence user
+	Token    string // Confluence API token or Notion integration token
+	Space    string // Confluence space key
+	ParentID string // Parent page ID for new pages
+	PageID   string // Notion page to replace instead of creating a new one
+}
+
+// Publish generates the architecture index and creates or updates a page with it in
+// Confluence or Notion. It returns the URL of the published page.
+func Publish(projectPath string, opts PublishOptions) (string, error) {
+	publisher, err := newPublisher(opts)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	doc, _, _, err := indexDocumentation(projectPath, cfg, false)
+	if err != nil {
+		return "", err
+	}
+	markdown, err := renderDocumentation(projectPath, cfg, "index", doc)
+	if err != nil {
+		return "", err
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = cfg.Module + " Architecture"
+	}
+
+	return publisher.Publish(publish.Page{Title: title, Markdown: markdown})
+}
+
+// newPublisher creates the publisher for the target
+func newPublisher(opts PublishOptions) (publish.Publisher, error) {
+	switch opts.Target {
+	case "confluence":
+		return &publish.Confluence{
+			BaseURL:  opts.URL,
+			User:     opts.User,
+			Token:    opts.Token,
+			Space:    opts.Space,
+			ParentID: opts.ParentID,
+		}, nil
+	case "notion":
+		return &publish.Notion{
+			Token:        opts.Token,
+			ParentPageID: opts.ParentID,
+			PageID:       opts.PageID,
+			BaseURL:      opts.URL,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported publish target %q (supported: %s)", opts.Target, strings.Join(publish.Targets, ", "))
+	}
+}
@@ -0,0 +1,66 @@
+package linter_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestPublish_Confluence(t *testing.T) {
+	files := map[string]string{
+		".goarchlint":             "module: github.com/test/project\nrules:\n  directories_import:\n    pkg: [internal]\n    internal: []\nscan_paths:\n  - pkg\n  - internal\n",
+		"pkg/service/service.go":  "package service\n\nimport \"github.com/test/project/internal/store\"\n\nfunc Run() { store.Save() }\n",
+		"internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	var created struct {
+		Title string `json:"title"`
+		Body  struct {
+			Storage struct {
+				Value string `json:"value"`
+			} `json:"storage"`
+		} `json:"body"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"results":[]}`))
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&created)
+		w.Write([]byte(`{"id":"1","_links":{"webui":"/pages/1"}}`))
+	}))
+	defer server.Close()
+
+	url, err := linter.Publish(tmpDir, linter.PublishOptions{
+		Target: "confluence",
+		URL:    server.URL,
+		User:   "me",
+		Token:  "secret",
+		Space:  "ARCH",
+	})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if url != server.URL+"/pages/1" {
+		t.Errorf("unexpected page URL %q", url)
+	}
+	if created.Title != "github.com/test/project Architecture" {
+		t.Errorf("expected default title, got %q", created.Title)
+	}
+	if !strings.Contains(created.Body.Storage.Value, "<h1>") || !strings.Contains(created.Body.Storage.Value, "pkg/service") {
+		t.Errorf("expected index documentation in storage format, got: %s", created.Body.Storage.Value)
+	}
+}
+
+func TestPublish_UnsupportedTarget(t *testing.T) {
+	_, err := linter.Publish(t.TempDir(), linter.PublishOptions{Target: "wiki"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported publish target") {
+		t.Errorf("expected unsupported target error, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package linter
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/query"
+)
+
+// Query evaluates a query expression over the package dependency graph of a project,
+// e.g. "deps(internal/app) & layer(infra)", and returns the matching packages one per
+// line. Layers are the keys of directories_import. Test files are left out of the graph.
+func Query(projectPath, expression string) (string, error) {
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	_, _, g, err := scanProject(projectPath, cfg, false, false)
+	if err != nil {
+		return "", err
+	}
+
+	layers := make([]string, 0, len(cfg.GetDirectoriesImport()))
+	for key := range cfg.GetDirectoriesImport() {
+		layers = append(layers, key)
+	}
+
+	result, err := query.Run(expression, queryPackages(g), layers)
+	if err != nil {
+		return "", err
+	}
+	if len(result) == 0 {
+		return "", nil
+	}
+	return strings.Join(result, "\n") + "\n", nil
+}
+
+// queryPackages aggregates the non-test files of the graph into packages, adding the
+// stdlib and external packages they import
+func queryPackages(g *graph.Graph) []query.Package {
+	packages := make(map[string]*query.Package)
+	imports := make(map[string]map[string]bool)
+	getPackage := func(path, kind string) *query.Package {
+		if pkg, ok := packages[path]; ok {
+			return pkg
+		}
+		pkg := &query.Package{Path: path, Kind: kind}
+		packages[path] = pkg
+		imports[path] = make(map[string]bool)
+		return pkg
+	}
+
+	for _, node := range g.Nodes {
+		if node.IsTest {
+			continue
+		}
+		dir := path.Dir(node.RelPath)
+		pkg := getPackage(dir, "local")
+		if node.Package == "main" {
+			pkg.Main = true
+		}
+
+		for _, dep := range node.Dependencies {
+			target, kind := dep.ImportPath, "external"
+			if dep.IsLocal {
+				target, kind = dep.LocalPath, "local"
+			} else if graph.IsStdLib(dep.ImportPath) {
+				kind = "stdlib"
+			}
+			getPackage(target, kind)
+			if target != dir {
+				imports[dir][target] = true
+			}
+		}
+	}
+
+	result := make([]query.Package, 0, len(packages))
+	for path, pkg := range packages {
+		for target := range imports[path] {
+			pkg.Imports = append(pkg.Imports, target)
+		}
+		sort.Strings(pkg.Imports)
+		result = append(result, *pkg)
+	}
+	return result
+}
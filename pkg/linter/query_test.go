@@ -0,0 +1,51 @@
+package linter_test
+
+import (
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestQuery(t *testing.T) {
+	files := map[string]string{
+		".goarchlint": `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [internal/app, internal/infra]
+    internal/app: [internal/domain, internal/infra]
+    internal/domain: []
+    internal/infra: [internal/domain]
+scan_paths:
+  - cmd
+  - internal
+`,
+		"cmd/api/main.go":               "package main\n\nimport \"github.com/test/project/internal/app\"\n\nfunc main() { app.Run() }\n",
+		"internal/app/app.go":           "package app\n\nimport (\n\t\"github.com/test/project/internal/domain\"\n\t\"github.com/test/project/internal/infra/db\"\n)\n\nfunc Run() { db.Save(domain.Order{}) }\n",
+		"internal/app/app_test.go":      "package app_test\n\nimport \"github.com/test/project/internal/infra/cache\"\n\nvar _ = cache.Name\n",
+		"internal/domain/order.go":      "package domain\n\ntype Order struct{}\n",
+		"internal/infra/db/db.go":       "package db\n\nimport \"github.com/test/project/internal/domain\"\n\nfunc Save(domain.Order) {}\n",
+		"internal/infra/cache/cache.go": "package cache\n\nconst Name = \"cache\"\n",
+	}
+	tmpDir := writeProject(t, files)
+
+	// Imports of test files are not part of the graph
+	result, err := linter.Query(tmpDir, "deps(internal/app) & layer(infra)")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if result != "internal/infra/db\n" {
+		t.Errorf("expected internal/infra/db, got:\n%s", result)
+	}
+
+	result, err = linter.Query(tmpDir, "rclosure(internal/domain) & main()")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if result != "cmd/api\n" {
+		t.Errorf("expected cmd/api, got:\n%s", result)
+	}
+
+	if _, err := linter.Query(tmpDir, "deps(internal/app"); err == nil {
+		t.Error("expected an error for an unterminated call")
+	}
+}
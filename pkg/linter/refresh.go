@@ -0,0 +1,170 @@
+package linter
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/typecheck"
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+// PreviewRefresh validates the project with the current .goarchlint and with the config
+// that Refresh would write, and describes how the violations change, grouped by top-level
+// directory. Nothing is written. Coverage and deprecation checks are not part of the
+// preview since they need test runs and git history.
+func PreviewRefresh(projectPath, preset string) (string, error) {
+	data, refreshed, presetName, err := refreshedConfig(projectPath, preset)
+	if err != nil {
+		return "", err
+	}
+
+	currentCfg, err := config.Parse(projectPath, data)
+	if err != nil {
+		return "", fmt.Errorf("current config: %w", err)
+	}
+	refreshedCfg, err := config.Parse(projectPath, []byte(refreshed))
+	if err != nil {
+		return "", fmt.Errorf("refreshed config: %w", err)
+	}
+
+	// Both configs validate the same code, so the packages are type-checked once
+	checker := typecheck.New(projectPath, currentCfg.Module)
+	before, err := previewViolations(projectPath, nil, currentCfg, checker)
+	if err != nil {
+		return "", err
+	}
+	after, err := previewViolations(projectPath, nil, refreshedCfg, checker)
+	if err != nil {
+		return "", err
+	}
+
+	action := fmt.Sprintf("refreshing the '%s' preset", presetName)
+	if current := currentCfg.GetPresetUsed(); current != "" && current != presetName {
+		action = fmt.Sprintf("switching from '%s' to '%s'", current, presetName)
+	} else if current == "" {
+		action = fmt.Sprintf("switching to '%s'", presetName)
+	}
+
+	return formatRefreshPreview(action, violationDelta(after, before), violationDelta(before, after)), nil
+}
+
+// previewViolations validates the project with cfg, read from fsys (nil for the disk),
+// leaving out the checks that run tests or read git history. checker must read the same
+// files.
+func previewViolations(projectPath string, fsys fs.FS, cfg *config.Config, checker *typecheck.Checker) ([]validator.Violation, error) {
+	s := newScanner(projectPath, cfg, false)
+	s.SetFS(fsys)
+	files, g, err := scanWith(s, cfg, false)
+	if err != nil {
+		return nil, err
+	}
+	v := newValidator(projectPath, cfg, s, files, g)
+	attachTypeInformation(checker, cfg, g, v)
+
+	return v.Validate(), nil
+}
+
+// attachTypeInformation type-checks what the rules enabled in cfg need with checker and
+// attaches it to v. Type information is best-effort, as in a regular run: on failure the
+// rules needing it are skipped.
+func attachTypeInformation(checker *typecheck.Checker, cfg *config.Config, g *graph.Graph, v *validator.Validator) {
+	if usesTypeLeaks(cfg) {
+		if signatures, err := collectExportedSignatures(checker, cfg, g); err == nil {
+			v.SetExportedSignatures(signatures)
+		}
+	}
+	if usesInterfaces(cfg) {
+		if interfaces, err := collectInterfaces(checker, cfg, g); err == nil {
+			v.SetInterfaces(interfaces)
+		}
+	}
+	if usesPortImplementations(cfg) {
+		if implementations, err := collectPortImplementations(checker, cfg, g); err == nil {
+			v.SetPortImplementations(implementations)
+		}
+	}
+}
+
+// violationDelta returns the violations of a that are not in b, counting duplicates
+func violationDelta(a, b []validator.Violation) []validator.Violation {
+	remaining := make(map[matrixKey]int)
+	for _, viol := range b {
+		remaining[matrixKey{viol.Type, viol.File, viol.Line, viol.Column, viol.Issue}]++
+	}
+
+	var delta []validator.Violation
+	for _, viol := range a {
+		key := matrixKey{viol.Type, viol.File, viol.Line, viol.Column, viol.Issue}
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		delta = append(delta, viol)
+	}
+	return delta
+}
+
+// formatRefreshPreview renders the added and resolved violations, one line per top-level
+// directory with the count of each violation type
+func formatRefreshPreview(action string, added, resolved []validator.Violation) string {
+	var b strings.Builder
+	if len(added) == 0 && len(resolved) == 0 {
+		fmt.Fprintf(&b, "Preview: %s does not change any violations\n", action)
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Preview: %s adds %d new and resolves %d existing violations\n", action, len(added), len(resolved))
+	for _, group := range groupByTopDirectory(added) {
+		fmt.Fprintf(&b, "  + %d new in %s (%s)\n", group.count, group.dir, group.types)
+	}
+	for _, group := range groupByTopDirectory(resolved) {
+		fmt.Fprintf(&b, "  - %d resolved in %s (%s)\n", group.count, group.dir, group.types)
+	}
+	return b.String()
+}
+
+// violationGroup summarizes the violations of one top-level directory
+type violationGroup struct {
+	dir   string
+	count int
+	types string
+}
+
+// groupByTopDirectory groups violations by the first element of their file path, largest
+// group first. Files at the project root, like .goarchlint, form their own group.
+func groupByTopDirectory(violations []validator.Violation) []violationGroup {
+	counts := make(map[string]map[validator.ViolationType]int)
+	for _, viol := range violations {
+		dir := viol.File
+		if i := strings.Index(dir, "/"); i >= 0 {
+			dir = dir[:i+1]
+		}
+		if counts[dir] == nil {
+			counts[dir] = make(map[validator.ViolationType]int)
+		}
+		counts[dir][viol.Type]++
+	}
+
+	groups := make([]violationGroup, 0, len(counts))
+	for dir, byType := range counts {
+		types := make([]string, 0, len(byType))
+		total := 0
+		for violationType, n := range byType {
+			types = append(types, fmt.Sprintf("%s: %d", violationType, n))
+			total += n
+		}
+		sort.Strings(types)
+		groups = append(groups, violationGroup{dir: dir, count: total, types: strings.Join(types, ", ")})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].count != groups[j].count {
+			return groups[i].count > groups[j].count
+		}
+		return groups[i].dir < groups[j].dir
+	})
+	return groups
+}
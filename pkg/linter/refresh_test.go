@@ -0,0 +1,52 @@
+package linter_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestPreviewRefresh(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module github.com/test/project\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := linter.Init(tmpDir, "simple", true); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(tmpDir, ".goarchlint")
+	before, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := linter.PreviewRefresh(tmpDir, "")
+	if err != nil {
+		t.Fatalf("PreviewRefresh failed: %v", err)
+	}
+	if !strings.Contains(preview, "refreshing the 'simple' preset does not change any violations") {
+		t.Errorf("expected no changes when refreshing the same preset, got:\n%s", preview)
+	}
+
+	preview, err = linter.PreviewRefresh(tmpDir, "ddd")
+	if err != nil {
+		t.Fatalf("PreviewRefresh failed: %v", err)
+	}
+	if !strings.Contains(preview, "switching from 'simple' to 'ddd' adds 6 new") {
+		t.Errorf("expected the switch to add violations, got:\n%s", preview)
+	}
+	if !strings.Contains(preview, "+ 6 new in internal/ (Missing Required Directory: 3, Unused Required Directory: 3)") {
+		t.Errorf("expected new violations grouped under internal/, got:\n%s", preview)
+	}
+
+	after, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Error("PreviewRefresh must not change .goarchlint")
+	}
+}
@@ -0,0 +1,128 @@
+package linter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/githistory"
+)
+
+// cachedRun is the result of a run stored in the run cache
+type cachedRun struct {
+	Commit     string `json:"commit"`
+	Graph      string `json:"graph"`
+	Violations string `json:"violations"`
+	ShouldFail bool   `json:"should_fail"`
+}
+
+// runCached returns the cached result of an identical run if there is one, and runs the
+// linter and caches its result otherwise. Results are keyed by the commit checked out,
+// the effective configuration, the version of the binary, the run options and the date
+// (expiring archlint:ignore directives and active_from depend on it), and only
+// cached while the project directory has no uncommitted or untracked changes: a dirty
+// working tree, or a project outside a git repository, always runs. A run that fails
+// with an error is never cached, and a cache that cannot be written only prints a warning.
+// The update check is not part of the result and runs on cache hits as well.
+func runCached(projectPath string, opts RunOptions) (string, string, bool, error) {
+	key, commit, cfg, ok := runCacheKey(projectPath, opts, time.Now())
+	if !ok {
+		return runWithOptions(projectPath, opts)
+	}
+
+	cacheDir, err := runCacheDir(opts.CacheDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return runWithOptions(projectPath, opts)
+	}
+	cachePath := filepath.Join(cacheDir, key+".json")
+
+	if data, err := os.ReadFile(cachePath); err == nil {
+		var cached cachedRun
+		if err := json.Unmarshal(data, &cached); err == nil && cached.Commit == commit {
+			if !opts.Quiet {
+				fmt.Fprintf(os.Stderr, "Using the cached result of commit %s\n", shortCommit(commit))
+			}
+			checkForUpdate(cfg, opts)
+			return cached.Graph, cached.Violations, cached.ShouldFail, nil
+		}
+	}
+
+	graphOutput, violationsOutput, shouldFail, err := runWithOptions(projectPath, opts)
+	if err != nil {
+		return graphOutput, violationsOutput, shouldFail, err
+	}
+
+	data, err := json.Marshal(cachedRun{Commit: commit, Graph: graphOutput, Violations: violationsOutput, ShouldFail: shouldFail})
+	if err == nil {
+		err = os.MkdirAll(cacheDir, 0755)
+	}
+	if err == nil {
+		err = os.WriteFile(cachePath, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: caching the result failed: %v\n", err)
+	}
+	return graphOutput, violationsOutput, shouldFail, nil
+}
+
+// runCacheKey returns the cache key of a run on the date of now, the commit it is for and
+// the loaded configuration, or false if the run cannot be cached
+func runCacheKey(projectPath string, opts RunOptions, now time.Time) (string, string, *config.Config, bool) {
+	repo := githistory.New(projectPath)
+	clean, err := repo.Clean()
+	if err != nil || !clean {
+		return "", "", nil, false
+	}
+	commit, err := repo.Head()
+	if err != nil {
+		return "", "", nil, false
+	}
+
+	// The effective configuration covers what the commit does not: presets built into
+	// the binary and rules_from bundles following a branch
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", "", nil, false
+	}
+	if err := cfg.SelectProfile(opts.Profile); err != nil {
+		return "", "", nil, false
+	}
+	effective, err := cfg.Effective()
+	if err != nil {
+		return "", "", nil, false
+	}
+
+	// Where the result is cached and the update check do not change it
+	opts.Cache, opts.CacheDir, opts.CheckUpdate = false, "", false
+
+	sum := sha256.New()
+	fmt.Fprintf(sum, "%s\x00%s\x00%s\x00%s\x00%s\x00%+v", opts.Version, projectPath, commit, now.Format("2006-01-02"), effective, opts)
+	return hex.EncodeToString(sum.Sum(nil)), commit, cfg, true
+}
+
+// runCacheDir returns the directory of the run cache: dir if given, or go-arch-lint/runs
+// in the user cache directory
+func runCacheDir(dir string) (string, error) {
+	if dir != "" {
+		return dir, nil
+	}
+	cacheRoot, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating the user cache directory: %w", err)
+	}
+	return filepath.Join(cacheRoot, "go-arch-lint", "runs"), nil
+}
+
+// shortCommit abbreviates a commit hash for messages
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
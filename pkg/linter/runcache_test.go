@@ -0,0 +1,108 @@
+package linter_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestRunWithOptions_Cache(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+	cacheDir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = tmpDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	write := func(relPath, content string) {
+		t.Helper()
+		fullPath := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("go.mod", "module github.com/test/project\n\ngo 1.21\n")
+	write(".goarchlint", "module: github.com/test/project\nrules:\n  directories_import:\n    cmd: [pkg]\n    pkg: []\n")
+	write("cmd/app/main.go", "package main\n\nimport \"github.com/test/project/pkg/service\"\n\nfunc main() { service.Run() }\n")
+	write("pkg/service/service.go", "package service\n\nfunc Run() {}\n")
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "initial")
+
+	opts := linter.RunOptions{Format: "json", Cache: true, CacheDir: cacheDir}
+	first, _, shouldFail, err := linter.RunWithOptions(tmpDir, opts)
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if shouldFail {
+		t.Fatalf("expected a passing run, got:\n%s", first)
+	}
+
+	entries, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one cached run, got %v (%v)", entries, err)
+	}
+
+	// Mark the cached result to tell a cache hit from a new run
+	data, err := os.ReadFile(entries[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	marked := strings.Replace(string(data), `"graph":"`, `"graph":"CACHED`, 1)
+	if err := os.WriteFile(entries[0], []byte(marked), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cached, _, _, err := linter.RunWithOptions(tmpDir, opts)
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !strings.HasPrefix(cached, "CACHED") {
+		t.Errorf("expected the cached result, got:\n%s", cached)
+	}
+
+	// Other options are another run
+	other, _, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "json", Quiet: true, Cache: true, CacheDir: cacheDir})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if strings.HasPrefix(other, "CACHED") {
+		t.Error("expected a run with other options not to use the cache")
+	}
+
+	// Uncommitted changes are linted, not looked up
+	write("pkg/service/extra.go", "package service\n\nimport \"github.com/test/project/cmd/app\"\n\nvar _ = app.X\n")
+	dirty, _, shouldFail, err := linter.RunWithOptions(tmpDir, opts)
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if strings.HasPrefix(dirty, "CACHED") || !shouldFail {
+		t.Errorf("expected a new failing run on the dirty working tree, got:\n%s", dirty)
+	}
+
+	// Committing the change makes a new cache entry
+	git("add", "-A")
+	git("commit", "-q", "-m", "break the rules")
+	if _, _, shouldFail, err := linter.RunWithOptions(tmpDir, opts); err != nil || !shouldFail {
+		t.Fatalf("expected a failing run, got %v (%v)", shouldFail, err)
+	}
+	entries, _ = filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	if len(entries) != 3 {
+		t.Errorf("expected three cached runs, got %d", len(entries))
+	}
+}
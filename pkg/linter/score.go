@@ -0,0 +1,64 @@
+package linter
+
+import (
+	"math"
+
+	"github.com/kgatilin/go-arch-lint/internal/coverage"
+	"github.com/kgatilin/go-arch-lint/internal/graph"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/internal/validator"
+)
+
+const (
+	// scoreWarningWeight is how much a warning counts against the score relative to an
+	// error; informational findings do not count
+	scoreWarningWeight = 0.25
+
+	// scoreCouplingHalf is the average number of local packages imported per package at
+	// which the coupling component drops to 50
+	scoreCouplingHalf = 5.0
+)
+
+// Weights of the score components; without coverage the others share its weight
+const (
+	scoreViolationsWeight = 0.6
+	scoreCoverageWeight   = 0.2
+	scoreCouplingWeight   = 0.2
+)
+
+// conformanceScore rates how well a project conforms to its architecture, from 0 to 100.
+// The violations component is 100 × packages / (packages + weighted violations), so one
+// error per package halves it. The coupling component is 100 × 5 / (5 + average local
+// imports per package). The coverage component is the overall coverage, if measured.
+func conformanceScore(g *graph.Graph, violations []validator.Violation, coverageResults []coverage.PackageCoverage) output.Score {
+	packages, localDeps, _ := graphMetrics(g)
+	packageCount := math.Max(float64(packages), 1)
+
+	weighted := 0.0
+	for _, viol := range violations {
+		switch viol.Severity {
+		case validator.SeverityWarning:
+			weighted += scoreWarningWeight
+		case validator.SeverityInfo:
+		default:
+			weighted++
+		}
+	}
+
+	violationsScore := 100 * packageCount / (packageCount + weighted)
+	couplingScore := 100 * scoreCouplingHalf / (scoreCouplingHalf + float64(localDeps)/packageCount)
+	score := output.Score{
+		Violations: int(math.Round(violationsScore)),
+		Coupling:   int(math.Round(couplingScore)),
+	}
+
+	total := (scoreViolationsWeight*violationsScore + scoreCouplingWeight*couplingScore) / (scoreViolationsWeight + scoreCouplingWeight)
+	if coverageResults != nil {
+		coverageScore := coverage.CalculateOverallCoverage(coverageResults)
+		rounded := int(math.Round(coverageScore))
+		score.Coverage = &rounded
+		total = scoreViolationsWeight*violationsScore + scoreCoverageWeight*coverageScore + scoreCouplingWeight*couplingScore
+	}
+	score.Score = int(math.Round(total))
+	return score
+}
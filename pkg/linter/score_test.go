@@ -0,0 +1,112 @@
+package linter_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func writeScoreProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	files[".goarchlint"] = `module: github.com/test/project
+rules:
+  directories_import:
+    cmd: [pkg]
+    pkg: []
+scan_paths:
+  - cmd
+  - pkg
+`
+	return writeProject(t, files)
+}
+
+func TestRunWithOptions_ConformanceScore(t *testing.T) {
+	// Three packages, two local imports, one error: violations 100 × 3/4, coupling 100 × 5/(5 + 2/3)
+	tmpDir := writeScoreProject(t, map[string]string{
+		"cmd/app/main.go":     "package main\n\nimport \"github.com/test/project/pkg/api\"\n\nfunc main() { api.Run() }\n",
+		"pkg/api/api.go":      "package api\n\nimport \"github.com/test/project/cmd/app/config\"\n\nfunc Run() { config.Load() }\n",
+		"cmd/app/config/c.go": "package config\n\nfunc Load() {}\n",
+	})
+
+	_, violations, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !strings.Contains(violations, "Conformance score: 78/100 (violations 75, coupling 88)\n") {
+		t.Errorf("expected score line in report, got:\n%s", violations)
+	}
+
+	report, _, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "json"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	var parsed struct {
+		Score *struct {
+			Score      int  `json:"score"`
+			Violations int  `json:"violations"`
+			Coverage   *int `json:"coverage"`
+			Coupling   int  `json:"coupling"`
+		} `json:"score"`
+	}
+	if err := json.Unmarshal([]byte(report), &parsed); err != nil {
+		t.Fatalf("invalid JSON report: %v\n%s", err, report)
+	}
+	if parsed.Score == nil || parsed.Score.Score != 78 || parsed.Score.Violations != 75 || parsed.Score.Coupling != 88 || parsed.Score.Coverage != nil {
+		t.Errorf("expected score in JSON report, got %+v", parsed.Score)
+	}
+
+	docs, _, _, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "full"})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !strings.Contains(docs, "- **Conformance Score**: 78/100\n") {
+		t.Errorf("expected score in documentation statistics, got:\n%s", docs)
+	}
+}
+
+func TestRunWithOptions_MinScore(t *testing.T) {
+	// No violations, but the coupling component keeps the score at 98
+	tmpDir := writeScoreProject(t, map[string]string{
+		"cmd/app/main.go": "package main\n\nimport \"github.com/test/project/pkg/api\"\n\nfunc main() { api.Run() }\n",
+		"pkg/api/api.go":  "package api\n\nfunc Run() {}\n",
+	})
+
+	_, violations, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{MinScore: 98})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if shouldFail || violations != "" {
+		t.Errorf("expected a clean run at the minimum score, got shouldFail=%v:\n%s", shouldFail, violations)
+	}
+
+	_, violations, shouldFail, err = linter.RunWithOptions(tmpDir, linter.RunOptions{MinScore: 99})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !shouldFail {
+		t.Error("expected a score below the minimum to fail the build")
+	}
+	for _, want := range []string{"Conformance score: 98/100", "Conformance score 98 is below the required minimum of 99\n"} {
+		if !strings.Contains(violations, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, violations)
+		}
+	}
+
+	_, violations, _, err = linter.RunWithOptions(tmpDir, linter.RunOptions{MinScore: 99, Quiet: true})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if violations != ". min-score Conformance score 98 is below the required minimum of 99\n" {
+		t.Errorf("expected one compact line for the score gate, got %q", violations)
+	}
+
+	report, _, shouldFail, err := linter.RunWithOptions(tmpDir, linter.RunOptions{Format: "json", MinScore: 99})
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if !shouldFail || !strings.Contains(report, `"failed": true`) {
+		t.Errorf("expected the JSON report to record the failed gate, got:\n%s", report)
+	}
+}
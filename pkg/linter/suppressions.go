@@ -0,0 +1,122 @@
+package linter
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kgatilin/go-arch-lint/internal/config"
+	"github.com/kgatilin/go-arch-lint/internal/githistory"
+	"github.com/kgatilin/go-arch-lint/internal/output"
+	"github.com/kgatilin/go-arch-lint/internal/scanner"
+)
+
+// SuppressionOptions configures a suppression report
+type SuppressionOptions struct {
+	Format  string // "markdown" or "json"
+	Profile string // Named profile from the config's profiles section (empty for none)
+}
+
+// Suppressions lists what the configuration and archlint:ignore directives exempt from the
+// rules, with the location, reason and age of each exemption, for architecture and
+// security audits. Rules whose active_from date has passed and expired directives are
+// enforced and not listed. Ages come from git blame and are unknown outside a git
+// repository.
+func Suppressions(projectPath string, opts SuppressionOptions) (string, error) {
+	if !containsFormat(output.SuppressionFormats, opts.Format) {
+		return "", fmt.Errorf("unsupported suppressions format %q (supported: %s)", opts.Format, strings.Join(output.SuppressionFormats, ", "))
+	}
+
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return "", err
+	}
+	if err := cfg.SelectProfile(opts.Profile); err != nil {
+		return "", err
+	}
+
+	files, err := newScanner(projectPath, cfg, false).Scan(cfg.GetScanPaths(), scanner.ScanOptions{IncludeSuppressions: true})
+	if err != nil {
+		return "", err
+	}
+	inline, warnings := inlineSuppressions(files)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	repo := githistory.New(projectPath)
+	dates := make(map[string]map[int]time.Time)
+	lineDates := func(path string) map[int]time.Time {
+		if _, ok := dates[path]; !ok {
+			// Not committed or not a git repository: ages are unknown
+			dates[path], _ = repo.LineDates(path)
+		}
+		return dates[path]
+	}
+	entries := suppressionEntries(cfg.GetSuppressions(), inline, time.Now(), lineDates)
+	return output.FormatSuppressions(entries, opts.Format)
+}
+
+// suppressionEntries converts the configured suppressions and inline directives that are
+// active at now into report entries. lineDates returns the commit dates of the lines of a
+// file, by line number.
+func suppressionEntries(suppressions []config.Suppression, inline []inlineSuppression, now time.Time, lineDates func(path string) map[int]time.Time) []output.SuppressionEntry {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	addAge := func(entry *output.SuppressionEntry, path string, line int) {
+		date, ok := lineDates(path)[line]
+		if !ok {
+			return
+		}
+		added := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		days := int(today.Sub(added).Hours() / 24)
+		entry.Added = added.Format("2006-01-02")
+		entry.AgeDays = &days
+	}
+
+	var entries []output.SuppressionEntry
+	for _, suppression := range suppressions {
+		if !suppression.Until.IsZero() && !today.Before(suppression.Until) {
+			continue // The deferred rule is enforced
+		}
+
+		entry := output.SuppressionEntry{
+			Kind:   suppression.Kind,
+			Key:    suppression.Key,
+			Target: suppression.Target,
+			Reason: suppression.Reason,
+			Source: suppression.Source,
+		}
+		if !suppression.Until.IsZero() {
+			entry.Until = suppression.Until.Format("2006-01-02")
+		}
+		if suppression.Line > 0 {
+			entry.Location = fmt.Sprintf(".goarchlint:%d", suppression.Line)
+			addAge(&entry, ".goarchlint", suppression.Line)
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, suppression := range inline {
+		if !suppression.until.IsZero() && !today.Before(suppression.until) {
+			continue // The directive expired and the rule is enforced
+		}
+
+		target := suppression.Rule
+		if suppression.WholeFile {
+			target += " (whole file)"
+		}
+		entry := output.SuppressionEntry{
+			Kind:     "inline ignore",
+			Key:      suppression.Rule,
+			Target:   target,
+			Reason:   suppression.Reason,
+			Until:    suppression.Until,
+			Source:   "archlint:ignore",
+			Location: suppression.location(),
+		}
+		addAge(&entry, suppression.file, suppression.Line)
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,104 @@
+package linter_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func TestSuppressions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = tmpDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	configYAML := `module: github.com/test/project
+rules:
+  test_files:
+    allow_whitebox:
+      - path: internal/parser
+        reason: Table tests exercise the unexported tokenizer
+  active_from:
+    rules.directories_import.cmd: "2099-01-01"
+    rules.directories_import.pkg: "2000-01-01"
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".goarchlint"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	appSrc := "package app\n\n//archlint:ignore rules.directories_import until=2099-06-30 reason=Moving to ports\nimport _ \"github.com/test/project/internal/store\"\n\n//archlint:ignore rules.detect_unused until=2000-01-01\nvar x = 1\n"
+	if err := os.MkdirAll(filepath.Join(tmpDir, "internal", "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "internal", "app", "app.go"), []byte(appSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Outside a git repository the ages are unknown
+	report, err := linter.Suppressions(tmpDir, linter.SuppressionOptions{Format: "markdown"})
+	if err != nil {
+		t.Fatalf("Suppressions failed: %v", err)
+	}
+	expected := []string{
+		"| whitebox tests | `internal/parser` | Table tests exercise the unexported tokenizer | .goarchlint:5 | .goarchlint | - |\n",
+		"| deferred rule | `rules.directories_import.cmd` | enforced from 2099-01-01 | .goarchlint:8 | .goarchlint | - |\n",
+		"| inline ignore | `rules.directories_import` | Moving to ports enforced from 2099-06-30 | internal/app/app.go:3 | archlint:ignore | - |\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, report)
+		}
+	}
+	// Rules whose active_from or until date has passed are enforced, not suppressed
+	if strings.Contains(report, "rules.directories_import.pkg") || strings.Contains(report, "rules.detect_unused") {
+		t.Errorf("expected enforced rules to be left out, got:\n%s", report)
+	}
+
+	// Committed lines get the date of their commit
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "config", "--date", "2020-01-02T12:00:00Z")
+
+	data, err := linter.Suppressions(tmpDir, linter.SuppressionOptions{Format: "json"})
+	if err != nil {
+		t.Fatalf("Suppressions failed: %v", err)
+	}
+	var entries []struct {
+		Target  string `json:"target"`
+		Added   string `json:"added"`
+		AgeDays *int   `json:"age_days"`
+	}
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, data)
+	}
+	found := 0
+	for _, entry := range entries {
+		if entry.Target != "internal/parser" && entry.Target != "rules.directories_import" {
+			continue
+		}
+		found++
+		if entry.Added != "2020-01-02" || entry.AgeDays == nil || *entry.AgeDays < 365 {
+			t.Errorf("expected %s added 2020-01-02, got %+v", entry.Target, entry)
+		}
+	}
+	if found != 2 {
+		t.Errorf("expected whitebox exemption and inline ignore in json, got:\n%s", data)
+	}
+
+	if _, err := linter.Suppressions(tmpDir, linter.SuppressionOptions{Format: "csv"}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
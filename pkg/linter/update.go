@@ -0,0 +1,55 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kgatilin/go-arch-lint/internal/release"
+)
+
+// releaseRepo is the GitHub repository go-arch-lint is released from
+const releaseRepo = "kgatilin/go-arch-lint"
+
+// CheckUpdate compares version, the version of the running binary, with the published
+// releases. When newer releases exist it returns a notice naming the latest one and the
+// rule changes listed in the notes of every newer release, since rule semantics evolve
+// across releases. It returns an empty string when version is the latest.
+// apiURL overrides the GitHub API URL (empty: https://api.github.com).
+func CheckUpdate(version, apiURL string) (string, error) {
+	client := &release.Client{BaseURL: apiURL, Repo: releaseRepo}
+	newer, err := client.Newer(version)
+	if err != nil {
+		return "", fmt.Errorf("checking for updates: %w", err)
+	}
+	if len(newer) == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	latest := newer[0]
+	fmt.Fprintf(&b, "⚠ go-arch-lint %s is available (installed: %s)", latest.Tag, version)
+	if latest.URL != "" {
+		fmt.Fprintf(&b, ": %s", latest.URL)
+	}
+	b.WriteString("\n")
+
+	found := false
+	for _, r := range newer {
+		changes := release.RuleChanges(r.Body)
+		if len(changes) == 0 {
+			continue
+		}
+		if !found {
+			b.WriteString("  Rule changes since your version:\n")
+			found = true
+		}
+		fmt.Fprintf(&b, "    %s:\n", r.Tag)
+		for _, change := range changes {
+			fmt.Fprintf(&b, "      %s\n", change)
+		}
+	}
+	if !found {
+		b.WriteString("  The release notes list no rule changes\n")
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,77 @@
+package linter_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kgatilin/go-arch-lint/pkg/linter"
+)
+
+func newReleasesServer(t *testing.T, releases string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/kgatilin/go-arch-lint/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(releases))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckUpdate(t *testing.T) {
+	server := newReleasesServer(t, `[
+		{"tag_name": "v0.2.0", "html_url": "https://github.com/kgatilin/go-arch-lint/releases/tag/v0.2.0",
+		 "body": "## Rule changes\n- detect_unused also checks internal packages\n\n## Fixes\n- Faster scans"},
+		{"tag_name": "v0.1.1", "body": "- Fix crash"},
+		{"tag_name": "v0.1.0", "body": "- New default_policy rule"},
+		{"tag_name": "v0.0.9", "body": "- Stricter rule for tests"}
+	]`)
+
+	notice, err := linter.CheckUpdate("0.0.9", server.URL)
+	if err != nil {
+		t.Fatalf("CheckUpdate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"go-arch-lint v0.2.0 is available (installed: 0.0.9): https://github.com/kgatilin/go-arch-lint/releases/tag/v0.2.0",
+		"    v0.2.0:\n      - detect_unused also checks internal packages\n",
+		"    v0.1.0:\n      - New default_policy rule\n",
+	} {
+		if !strings.Contains(notice, want) {
+			t.Errorf("expected notice to contain %q, got:\n%s", want, notice)
+		}
+	}
+	for _, unwanted := range []string{"Faster scans", "v0.1.1", "Stricter rule for tests"} {
+		if strings.Contains(notice, unwanted) {
+			t.Errorf("expected notice not to contain %q, got:\n%s", unwanted, notice)
+		}
+	}
+}
+
+func TestCheckUpdate_UpToDate(t *testing.T) {
+	server := newReleasesServer(t, `[{"tag_name": "v0.0.9"}, {"tag_name": "v0.0.8"}]`)
+
+	notice, err := linter.CheckUpdate("v0.0.9", server.URL)
+	if err != nil {
+		t.Fatalf("CheckUpdate failed: %v", err)
+	}
+	if notice != "" {
+		t.Errorf("expected no notice for the latest version, got:\n%s", notice)
+	}
+}
+
+func TestCheckUpdate_NoRuleChanges(t *testing.T) {
+	server := newReleasesServer(t, `[{"tag_name": "v0.1.0", "body": "- Bug fixes"}]`)
+
+	notice, err := linter.CheckUpdate("0.0.9", server.URL)
+	if err != nil {
+		t.Fatalf("CheckUpdate failed: %v", err)
+	}
+	if !strings.Contains(notice, "The release notes list no rule changes") {
+		t.Errorf("expected a note about missing rule changes, got:\n%s", notice)
+	}
+}